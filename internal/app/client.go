@@ -3,11 +3,16 @@ package app
 import (
 	"context"
 	"ecommerce/internal/handler/api"
+	recordmapper "ecommerce/internal/mapper/record"
 	response_api "ecommerce/internal/mapper/response/api"
 	"ecommerce/internal/middlewares"
-	"ecommerce/pkg/auth"
+	"ecommerce/internal/repository"
+	"ecommerce/internal/service"
+	"ecommerce/pkg/database"
+	db "ecommerce/pkg/database/schema"
 	"ecommerce/pkg/dotenv"
 	"ecommerce/pkg/logger"
+	"ecommerce/pkg/payment"
 	"flag"
 	"net/http"
 	"os"
@@ -58,8 +63,37 @@ func RunClient() {
 		logger.Fatal("Failed to load .env file", zap.Error(err))
 	}
 
+	// The gateway rejects revoked tokens itself instead of relying on the
+	// gRPC server alone, so it reads the revocation list too.
+	dbConn, err := database.NewClient(logger)
+
+	if err != nil {
+		logger.Fatal("Failed to connect to database", zap.Error(err))
+	}
+
+	queries := db.New(dbConn)
+	mapperRecord := recordmapper.NewRecordMapper()
+
+	revocation := service.NewTokenRevocationService(
+		repository.NewRevokedTokenRepository(queries, mapperRecord.RevokedTokenMapping),
+		repository.NewRefreshTokenRepository(queries, mapperRecord.RefreshTokenRecordMapper),
+		logger,
+		revocationCacheTTL(),
+	)
+
 	e := echo.New()
 
+	ipExtractor, err := middlewares.TrustedProxyIPExtractor(viper.GetString("TRUSTED_PROXIES"))
+	if err != nil {
+		logger.Fatal("Failed to parse trusted proxies", zap.Error(err))
+	}
+	e.IPExtractor = ipExtractor
+
+	gatewaySecret := viper.GetString("GATEWAY_SECRET")
+	if gatewaySecret == "" {
+		logger.Fatal("GATEWAY_SECRET is required to forward client info to the gRPC server")
+	}
+
 	e.Use(middleware.Recover())
 	e.Use(middleware.Logger())
 
@@ -71,18 +105,28 @@ func RunClient() {
 			echo.HeaderContentType,
 			echo.HeaderAccept,
 			echo.HeaderAuthorization,
+			middlewares.IdempotencyKeyHeader,
 		},
 		AllowCredentials: true,
 	}))
 
-	middlewares.WebSecurityConfig(e)
+	token, err := newTokenVerifier()
+
+	if err != nil {
+		logger.Fatal("Failed to create token verifier", zap.Error(err))
+	}
+
+	middlewares.WebSecurityConfig(e, token, revocation)
+	e.Use(middlewares.ForwardAuthorization)
+	e.Use(middlewares.ForwardIdempotencyKey)
+	e.Use(middlewares.ForwardClientInfo(gatewaySecret))
 
 	e.GET("/swagger/*", echoSwagger.WrapHandler)
 
-	token, err := auth.NewManager(viper.GetString("SECRET_KEY"))
+	paymentProvider, err := payment.NewProvider(viper.GetString("PAYMENT_PROVIDER"), viper.GetString("PAYMENT_WEBHOOK_SECRET"))
 
 	if err != nil {
-		logger.Fatal("Failed to create token manager", zap.Error(err))
+		logger.Fatal("Failed to create payment provider", zap.Error(err))
 	}
 
 	mapping := response_api.NewResponseApiMapper()
@@ -92,6 +136,7 @@ func RunClient() {
 		Token:   token,
 		E:       e,
 		Logger:  logger,
+		Payment: paymentProvider,
 		Mapping: *mapping,
 	}
 
@@ -6,6 +6,7 @@ import (
 	protomapper "ecommerce/internal/mapper/proto"
 	recordmapper "ecommerce/internal/mapper/record"
 	response_service "ecommerce/internal/mapper/response/services"
+	"ecommerce/internal/middlewares"
 	"ecommerce/internal/pb"
 	"ecommerce/internal/repository"
 	"ecommerce/internal/service"
@@ -16,13 +17,19 @@ import (
 	"ecommerce/pkg/dotenv"
 	"ecommerce/pkg/hash"
 	"ecommerce/pkg/logger"
+	"ecommerce/pkg/mailer"
+	"ecommerce/pkg/payment"
 	"flag"
 	"fmt"
 	"net"
+	"time"
 
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 )
 
 var (
@@ -30,12 +37,16 @@ var (
 )
 
 type Server struct {
-	Logger       logger.LoggerInterface
-	DB           *db.Queries
-	TokenManager *auth.Manager
-	Services     *service.Service
-	Handlers     *gapi.Handler
-	Ctx          context.Context
+	Logger                 logger.LoggerInterface
+	DB                     *db.Queries
+	TokenManager           *auth.Manager
+	Services               *service.Service
+	Handlers               *gapi.Handler
+	AuthInterceptor        *middlewares.AuthInterceptor
+	DeadlineInterceptor    *middlewares.DeadlineInterceptor
+	IdempotencyInterceptor *middlewares.IdempotencyInterceptor
+	ClientInfoInterceptor  *middlewares.ClientInfoInterceptor
+	Ctx                    context.Context
 }
 
 func NewServer() (*Server, error) {
@@ -50,7 +61,7 @@ func NewServer() (*Server, error) {
 		logger.Fatal("Failed to load .env file", zap.Error(err))
 	}
 
-	tokenManager, err := auth.NewManager(viper.GetString("SECRET_KEY"))
+	tokenManager, err := newTokenManager()
 	if err != nil {
 		logger.Fatal("Failed to create token manager", zap.Error(err))
 	}
@@ -69,18 +80,41 @@ func NewServer() (*Server, error) {
 
 	depsRepo := repository.Deps{
 		DB:           DB,
-		Ctx:          ctx,
+		Conn:         conn,
 		MapperRecord: mapperRecord,
 	}
 
 	repositories := repository.NewRepositories(depsRepo)
 
+	paymentProvider, err := payment.NewProvider(viper.GetString("PAYMENT_PROVIDER"), viper.GetString("PAYMENT_WEBHOOK_SECRET"))
+	if err != nil {
+		logger.Fatal("Failed to create payment provider", zap.Error(err))
+	}
+
+	mail, err := mailer.NewMailer(mailer.Config{
+		Driver:   viper.GetString("MAIL_DRIVER"),
+		From:     viper.GetString("MAIL_FROM"),
+		Host:     viper.GetString("SMTP_HOST"),
+		Port:     viper.GetInt("SMTP_PORT"),
+		Username: viper.GetString("SMTP_USERNAME"),
+		Password: viper.GetString("SMTP_PASSWORD"),
+		Dir:      viper.GetString("MAIL_DIR"),
+	})
+	if err != nil {
+		logger.Fatal("Failed to create mailer", zap.Error(err))
+	}
+
 	services := service.NewService(service.Deps{
-		Repositories: repositories,
-		Hash:         hash,
-		Token:        tokenManager,
-		Logger:       logger,
-		Mapper:       *mapperResponse,
+		Repositories:       repositories,
+		Hash:               hash,
+		Token:              tokenManager,
+		Logger:             logger,
+		Payment:            paymentProvider,
+		Mapper:             *mapperResponse,
+		RevocationCacheTTL: revocationCacheTTL(),
+		Mailer:             mail,
+		AppURL:             viper.GetString("APP_URL"),
+		TaxRate:            viper.GetInt("TAX_RATE_BASIS_POINTS"),
 	})
 
 	mapperProto := protomapper.NewProtoMapper()
@@ -90,6 +124,34 @@ func NewServer() (*Server, error) {
 		Mapper:  *mapperProto,
 	})
 
+	authInterceptor := middlewares.NewAuthInterceptor(tokenManager, services.Revocation, logger)
+
+	defaultTimeout := viper.GetDuration("GRPC_DEFAULT_TIMEOUT")
+	if defaultTimeout == 0 {
+		defaultTimeout = 10 * time.Second
+	}
+
+	methodTimeouts, err := middlewares.ParseMethodTimeouts(viper.GetString("GRPC_METHOD_TIMEOUTS"))
+	if err != nil {
+		logger.Fatal("Failed to parse gRPC method timeouts", zap.Error(err))
+	}
+
+	deadlineInterceptor := middlewares.NewDeadlineInterceptor(defaultTimeout, methodTimeouts)
+
+	idempotencyKeyTTL := viper.GetDuration("IDEMPOTENCY_KEY_TTL")
+	if idempotencyKeyTTL == 0 {
+		idempotencyKeyTTL = 24 * time.Hour
+	}
+
+	idempotencyInterceptor := middlewares.NewIdempotencyInterceptor(repositories.Idempotency, logger, idempotencyKeyTTL)
+
+	gatewaySecret := viper.GetString("GATEWAY_SECRET")
+	if gatewaySecret == "" {
+		logger.Fatal("GATEWAY_SECRET is required to trust client info forwarded by the gateway")
+	}
+
+	clientInfoInterceptor := middlewares.NewClientInfoInterceptor(gatewaySecret)
+
 	db_seeder := viper.GetString("DB_SEEDER")
 
 	if db_seeder == "true" {
@@ -109,12 +171,16 @@ func NewServer() (*Server, error) {
 	}
 
 	return &Server{
-		Logger:       logger,
-		DB:           DB,
-		TokenManager: tokenManager,
-		Services:     services,
-		Handlers:     handlers,
-		Ctx:          ctx,
+		Logger:                 logger,
+		DB:                     DB,
+		TokenManager:           tokenManager,
+		Services:               services,
+		Handlers:               handlers,
+		AuthInterceptor:        authInterceptor,
+		DeadlineInterceptor:    deadlineInterceptor,
+		IdempotencyInterceptor: idempotencyInterceptor,
+		ClientInfoInterceptor:  clientInfoInterceptor,
+		Ctx:                    ctx,
 	}, nil
 }
 
@@ -124,15 +190,94 @@ func (s *Server) Run() {
 		s.Logger.Fatal("Failed to listen", zap.Error(err))
 	}
 
-	grpcServer := grpc.NewServer()
+	grpcServer := s.NewGRPCServer()
+
+	s.Logger.Info(fmt.Sprintf("Server running on port %d", *port))
+
+	if err := grpcServer.Serve(lis); err != nil {
+		s.Logger.Fatal("Failed to serve gRPC server", zap.Error(err))
+	}
+}
+
+// NewGRPCServer builds a gRPC server with every service handler registered,
+// plus the standard health checking and reflection services. Every call goes
+// through the deadline, client info and auth interceptors before reaching a
+// handler, and create calls carrying an idempotency key through the
+// idempotency one.
+func (s *Server) NewGRPCServer() *grpc.Server {
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(s.DeadlineInterceptor.Unary(), s.ClientInfoInterceptor.Unary(), s.AuthInterceptor.Unary(), s.IdempotencyInterceptor.Unary()),
+		grpc.ChainStreamInterceptor(s.AuthInterceptor.Stream()),
+	)
 
 	pb.RegisterAuthServiceServer(grpcServer, s.Handlers.Auth)
 	pb.RegisterUserServiceServer(grpcServer, s.Handlers.User)
 	pb.RegisterRoleServiceServer(grpcServer, s.Handlers.Role)
+	pb.RegisterCategoryServiceServer(grpcServer, s.Handlers.Category)
+	pb.RegisterMerchantServiceServer(grpcServer, s.Handlers.Merchant)
+	pb.RegisterProductServiceServer(grpcServer, s.Handlers.Product)
+	pb.RegisterOrderServiceServer(grpcServer, s.Handlers.Order)
+	pb.RegisterOrderItemServiceServer(grpcServer, s.Handlers.OrderItem)
+	pb.RegisterTransactionServiceServer(grpcServer, s.Handlers.Transaction)
+	pb.RegisterCartServiceServer(grpcServer, s.Handlers.Cart)
+	pb.RegisterReviewServiceServer(grpcServer, s.Handlers.Review)
+	pb.RegisterSliderServiceServer(grpcServer, s.Handlers.Slider)
+	pb.RegisterShippingServiceServer(grpcServer, s.Handlers.Shipping)
 
-	s.Logger.Info(fmt.Sprintf("Server running on port %d", *port))
+	healthServer := health.NewServer()
+	for name := range grpcServer.GetServiceInfo() {
+		healthServer.SetServingStatus(name, grpc_health_v1.HealthCheckResponse_SERVING)
+	}
+	healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
+	grpc_health_v1.RegisterHealthServer(grpcServer, healthServer)
 
-	if err := grpcServer.Serve(lis); err != nil {
-		s.Logger.Fatal("Failed to serve gRPC server", zap.Error(err))
+	reflection.Register(grpcServer)
+
+	return grpcServer
+}
+
+// revocationCacheTTL bounds how long a token revoked by another process can
+// still be used here.
+func revocationCacheTTL() time.Duration {
+	ttl := viper.GetDuration("TOKEN_REVOCATION_CACHE_TTL")
+	if ttl == 0 {
+		ttl = 30 * time.Second
+	}
+
+	return ttl
+}
+
+// newTokenManager builds the token manager the gRPC server issues and
+// verifies tokens with.
+func newTokenManager() (*auth.Manager, error) {
+	return auth.NewManager(tokenConfig())
+}
+
+// newTokenVerifier builds the verify-only token manager of the gateway, which
+// needs just the public keys in JWT_KEYS_DIR.
+func newTokenVerifier() (*auth.Manager, error) {
+	return auth.NewVerifier(tokenConfig())
+}
+
+// tokenConfig is shared by the gRPC server and the gateway so both check the
+// same keys, issuer and audience. JWT_KEYS_DIR switches from the HS256
+// SECRET_KEY to asymmetric keys.
+func tokenConfig() auth.Config {
+	issuer := viper.GetString("JWT_ISSUER")
+	if issuer == "" {
+		issuer = "ecommerce"
+	}
+
+	audience := viper.GetString("JWT_AUDIENCE")
+	if audience == "" {
+		audience = "ecommerce-api"
+	}
+
+	return auth.Config{
+		SecretKey:   viper.GetString("SECRET_KEY"),
+		KeysDir:     viper.GetString("JWT_KEYS_DIR"),
+		ActiveKeyID: viper.GetString("JWT_ACTIVE_KID"),
+		Issuer:      issuer,
+		Audience:    audience,
 	}
 }
@@ -0,0 +1,178 @@
+package app
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"ecommerce/internal/handler/gapi"
+	protomapper "ecommerce/internal/mapper/proto"
+	recordmapper "ecommerce/internal/mapper/record"
+	response_service "ecommerce/internal/mapper/response/services"
+	"ecommerce/internal/middlewares"
+	"ecommerce/internal/pb"
+	"ecommerce/internal/repository"
+	"ecommerce/internal/service"
+	"ecommerce/pkg/auth"
+	db "ecommerce/pkg/database/schema"
+	"ecommerce/pkg/hash"
+	"ecommerce/pkg/logger"
+	"ecommerce/pkg/payment"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// newTestServer wires the gRPC server the way NewServer does, minus the
+// database connection, the environment and the seeder.
+func newTestServer(t *testing.T) *Server {
+	t.Helper()
+
+	log := &logger.Logger{Log: zap.NewNop()}
+
+	tokenManager, err := auth.NewManager(auth.Config{
+		SecretKey: "test-secret",
+		Issuer:    "ecommerce",
+		Audience:  "ecommerce-api",
+	})
+	if err != nil {
+		t.Fatalf("create token manager: %v", err)
+	}
+
+	repositories := repository.NewRepositories(repository.Deps{
+		DB:           db.New(nil),
+		MapperRecord: recordmapper.NewRecordMapper(),
+	})
+
+	services := service.NewService(service.Deps{
+		Repositories:       repositories,
+		Hash:               hash.NewHashingPassword(),
+		Token:              tokenManager,
+		Logger:             log,
+		Payment:            payment.NewFakeProvider(),
+		Mapper:             *response_service.NewResponseServiceMapper(),
+		RevocationCacheTTL: time.Minute,
+	})
+
+	handlers := gapi.NewHandler(gapi.Deps{
+		Service: *services,
+		Mapper:  *protomapper.NewProtoMapper(),
+	})
+
+	return &Server{
+		Logger:                 log,
+		TokenManager:           tokenManager,
+		Services:               services,
+		Handlers:               handlers,
+		AuthInterceptor:        middlewares.NewAuthInterceptor(tokenManager, services.Revocation, log),
+		DeadlineInterceptor:    middlewares.NewDeadlineInterceptor(10*time.Second, nil),
+		IdempotencyInterceptor: middlewares.NewIdempotencyInterceptor(repositories.Idempotency, log, time.Hour),
+		ClientInfoInterceptor:  middlewares.NewClientInfoInterceptor("test-gateway-secret"),
+		Ctx:                    context.Background(),
+	}
+}
+
+// TestGRPCServerServesEveryService calls one RPC of every service the REST
+// gateway has a client for. gRPC answers Unimplemented for a service that is
+// not registered before any interceptor runs, so an unauthenticated call
+// coming back Unauthenticated proves the service is served, without needing
+// a database behind the handlers.
+func TestGRPCServerServesEveryService(t *testing.T) {
+	lis := bufconn.Listen(1024 * 1024)
+
+	grpcServer := newTestServer(t).NewGRPCServer()
+	go grpcServer.Serve(lis)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("dial bufconn: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	health, err := grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("health check: %v", err)
+	}
+	if health.Status != grpc_health_v1.HealthCheckResponse_SERVING {
+		t.Errorf("health status = %v, want SERVING", health.Status)
+	}
+
+	calls := map[string]func() error{
+		"AuthService": func() error {
+			_, err := pb.NewAuthServiceClient(conn).GetMe(ctx, &pb.GetMeRequest{})
+			return err
+		},
+		"UserService": func() error {
+			_, err := pb.NewUserServiceClient(conn).FindAll(ctx, &pb.FindAllUserRequest{})
+			return err
+		},
+		"RoleService": func() error {
+			_, err := pb.NewRoleServiceClient(conn).FindAllRole(ctx, &pb.FindAllRoleRequest{})
+			return err
+		},
+		"CategoryService": func() error {
+			_, err := pb.NewCategoryServiceClient(conn).FindAll(ctx, &pb.FindAllCategoryRequest{})
+			return err
+		},
+		"MerchantService": func() error {
+			_, err := pb.NewMerchantServiceClient(conn).FindAll(ctx, &pb.FindAllMerchantRequest{})
+			return err
+		},
+		"ProductService": func() error {
+			_, err := pb.NewProductServiceClient(conn).FindAll(ctx, &pb.FindAllProductRequest{})
+			return err
+		},
+		"OrderService": func() error {
+			_, err := pb.NewOrderServiceClient(conn).FindAll(ctx, &pb.FindAllOrderRequest{})
+			return err
+		},
+		"OrderItemService": func() error {
+			_, err := pb.NewOrderItemServiceClient(conn).FindAll(ctx, &pb.FindAllOrderItemRequest{})
+			return err
+		},
+		"TransactionService": func() error {
+			_, err := pb.NewTransactionServiceClient(conn).FindAll(ctx, &pb.FindAllTransactionRequest{})
+			return err
+		},
+		"CartService": func() error {
+			_, err := pb.NewCartServiceClient(conn).FindSummary(ctx, &emptypb.Empty{})
+			return err
+		},
+		"ReviewService": func() error {
+			_, err := pb.NewReviewServiceClient(conn).FindAll(ctx, &pb.FindAllReviewRequest{})
+			return err
+		},
+		"SliderService": func() error {
+			_, err := pb.NewSliderServiceClient(conn).FindAll(ctx, &pb.FindAllSliderRequest{})
+			return err
+		},
+		"ShippingService": func() error {
+			_, err := pb.NewShippingServiceClient(conn).FindAll(ctx, &pb.FindAllShippingRequest{})
+			return err
+		},
+	}
+
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			if code := status.Code(call()); code != codes.Unauthenticated {
+				t.Errorf("code = %v, want %v", code, codes.Unauthenticated)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package money
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency is used for amounts whose currency is not stored with
+// them, such as product and cart prices, which are always in the currency
+// of the merchant selling them.
+const DefaultCurrency = "IDR"
+
+// exponents maps the ISO 4217 currencies we accept to the number of digits
+// after the decimal point in their minor unit. Rupiah is quoted without
+// fractional units, so IDR amounts are whole rupiah.
+var exponents = map[string]int{
+	"IDR": 0,
+}
+
+// Money is an amount in the minor units of an ISO 4217 currency. Amounts
+// are kept as integers so that they can be added and compared exactly.
+type Money struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// New returns amount in currency, falling back to DefaultCurrency when
+// currency is empty.
+func New(amount int64, currency string) Money {
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+
+	return Money{Amount: amount, Currency: currency}
+}
+
+// IsSupported reports whether currency is an ISO 4217 code we can price in.
+func IsSupported(currency string) bool {
+	_, ok := exponents[currency]
+	return ok
+}
+
+// String formats the amount with its currency code, grouping thousands and
+// placing the decimal point according to the currency, e.g.
+// "IDR 21,000,000".
+func (m Money) String() string {
+	exponent := exponents[m.Currency]
+
+	amount := m.Amount
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	digits := strconv.FormatInt(amount, 10)
+	if len(digits) <= exponent {
+		digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+	}
+
+	whole, fraction := digits[:len(digits)-exponent], digits[len(digits)-exponent:]
+
+	var b strings.Builder
+	b.WriteString(m.Currency)
+	b.WriteString(" ")
+	b.WriteString(sign)
+	for i, r := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(r)
+	}
+	if exponent > 0 {
+		b.WriteByte('.')
+		b.WriteString(fraction)
+	}
+
+	return b.String()
+}
+
+// MarshalJSON adds the formatted amount next to the raw fields so that
+// clients do not have to know the exponent of each currency.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Amount    int64  `json:"amount"`
+		Currency  string `json:"currency"`
+		Formatted string `json:"formatted"`
+	}{
+		Amount:    m.Amount,
+		Currency:  m.Currency,
+		Formatted: m.String(),
+	})
+}
@@ -5,7 +5,7 @@ type CartRecord struct {
 	UserID    int     `json:"user_id"`
 	ProductID int     `json:"product_id"`
 	Name      string  `json:"name"`
-	Price     int     `json:"price"`
+	Price     int64   `json:"price"`
 	Image     string  `json:"image"`
 	Quantity  int     `json:"quantity"`
 	Weight    int     `json:"weight"`
@@ -13,3 +13,17 @@ type CartRecord struct {
 	UpdatedAt string  `json:"updated_at"`
 	DeletedAt *string `json:"deleted_at"`
 }
+
+type CartCheckoutRecord struct {
+	ID         int `json:"id"`
+	UserID     int `json:"user_id"`
+	ProductID  int `json:"product_id"`
+	MerchantID int `json:"merchant_id"`
+	Quantity   int `json:"quantity"`
+}
+
+type CartSummaryRecord struct {
+	ItemCount   int   `json:"item_count"`
+	TotalWeight int   `json:"total_weight"`
+	Subtotal    int64 `json:"subtotal"`
+}
@@ -0,0 +1,17 @@
+package record
+
+// IdempotencyKeyRecord is a client supplied key claimed by one request.
+// Response holds the serialized result once the request completed, or the
+// serialized error status when Failed is set.
+type IdempotencyKeyRecord struct {
+	ID          int     `json:"id"`
+	UserID      int     `json:"user_id"`
+	Method      string  `json:"method"`
+	Key         string  `json:"key"`
+	RequestHash string  `json:"request_hash"`
+	Response    []byte  `json:"response"`
+	Failed      bool    `json:"failed"`
+	LockedAt    string  `json:"locked_at"`
+	CompletedAt *string `json:"completed_at"`
+	CreatedAt   string  `json:"created_at"`
+}
@@ -0,0 +1,12 @@
+package record
+
+// LoginThrottleRecord counts the recent failed logins of one account (Scope
+// "account", Subject the email) or IP address (Scope "ip").
+type LoginThrottleRecord struct {
+	ID             int     `json:"id"`
+	Scope          string  `json:"scope"`
+	Subject        string  `json:"subject"`
+	FailedAttempts int     `json:"failed_attempts"`
+	LastFailedAt   string  `json:"last_failed_at"`
+	LockedUntil    *string `json:"locked_until"`
+}
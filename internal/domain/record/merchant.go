@@ -9,6 +9,7 @@ type MerchantRecord struct {
 	ContactEmail string  `json:"contact_email"`
 	ContactPhone string  `json:"contact_phone"`
 	Status       string  `json:"status"`
+	Currency     string  `json:"currency"`
 	CreatedAt    string  `json:"created_at"`
 	UpdatedAt    string  `json:"updated_at"`
 	DeletedAt    *string `json:"deleted_at"`
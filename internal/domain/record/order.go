@@ -1,11 +1,36 @@
 package record
 
+const (
+	OrderStatusPending    = "pending"
+	OrderStatusPaid       = "paid"
+	OrderStatusProcessing = "processing"
+	OrderStatusShipped    = "shipped"
+	OrderStatusDelivered  = "delivered"
+	OrderStatusCancelled  = "cancelled"
+	OrderStatusRefunded   = "refunded"
+)
+
 type OrderRecord struct {
+	ID             int     `json:"id"`
+	MerchantID     int     `json:"merchant_id"`
+	UserID         int     `json:"user_id"`
+	TotalPrice     int64   `json:"total_price"`
+	Subtotal       int64   `json:"subtotal"`
+	ShippingCost   int64   `json:"shipping_cost"`
+	TaxAmount      int64   `json:"tax_amount"`
+	DiscountAmount int64   `json:"discount_amount"`
+	Currency       string  `json:"currency"`
+	Status         string  `json:"status"`
+	CreatedAt      string  `json:"created_at"`
+	UpdatedAt      string  `json:"updated_at"`
+	DeletedAt      *string `json:"deleted_at"`
+}
+
+type OrderStatusHistoryRecord struct {
 	ID         int     `json:"id"`
-	MerchantID int     `json:"merchant_id"`
-	UserID     int     `json:"user_id"`
-	TotalPrice int     `json:"total_price"`
+	OrderID    int     `json:"order_id"`
+	FromStatus *string `json:"from_status"`
+	ToStatus   string  `json:"to_status"`
+	Note       string  `json:"note"`
 	CreatedAt  string  `json:"created_at"`
-	UpdatedAt  string  `json:"updated_at"`
-	DeletedAt  *string `json:"deleted_at"`
 }
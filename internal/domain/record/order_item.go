@@ -5,7 +5,7 @@ type OrderItemRecord struct {
 	OrderID   int     `json:"order_id"`
 	ProductID int     `json:"product_id"`
 	Quantity  int     `json:"quantity"`
-	Price     int     `json:"price"`
+	Price     int64   `json:"price"`
 	CreatedAt string  `json:"created_at"`
 	UpdatedAt string  `json:"updated_at"`
 	DeletedAt *string `json:"deleted_at"`
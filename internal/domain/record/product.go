@@ -6,7 +6,7 @@ type ProductRecord struct {
 	CategoryID   int     `json:"category_id"`
 	Name         string  `json:"name"`
 	Description  string  `json:"description"`
-	Price        int     `json:"price"`
+	Price        int64   `json:"price"`
 	CountInStock int     `json:"count_in_stock"`
 	Brand        string  `json:"brand"`
 	Weight       int     `json:"weight"`
@@ -17,3 +17,12 @@ type ProductRecord struct {
 	UpdatedAt    string  `json:"updated_at"`
 	DeletedAt    *string `json:"deleted_at"`
 }
+
+// ProductFacetRecord is the number of products matching a search that share
+// one value of a facet. Price facets use the price bucket index as Value.
+type ProductFacetRecord struct {
+	Facet string `json:"facet"`
+	Value string `json:"value"`
+	Label string `json:"label"`
+	Count int    `json:"count"`
+}
@@ -1,10 +1,17 @@
 package record
 
 type RefreshTokenRecord struct {
-	ID        int    `json:"id"`
-	UserID    int    `json:"user_id"`
-	Token     string `json:"token"`
-	ExpiredAt string `json:"expired_at"`
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
+	ID         int     `json:"id"`
+	UserID     int     `json:"user_id"`
+	Token      string  `json:"token"`
+	FamilyID   string  `json:"family_id"`
+	Device     string  `json:"device"`
+	UserAgent  string  `json:"user_agent"`
+	IPAddress  string  `json:"ip_address"`
+	SignedInAt string  `json:"signed_in_at"`
+	ExpiredAt  string  `json:"expired_at"`
+	UsedAt     *string `json:"used_at"`
+	RevokedAt  *string `json:"revoked_at"`
+	CreatedAt  string  `json:"created_at"`
+	UpdatedAt  string  `json:"updated_at"`
 }
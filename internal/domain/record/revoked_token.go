@@ -0,0 +1,12 @@
+package record
+
+// RevokedTokenRecord revokes a single access token when TokenID is set, and
+// otherwise every access token of UserID issued before RevokedAt.
+type RevokedTokenRecord struct {
+	ID        int    `json:"id"`
+	TokenID   string `json:"token_id"`
+	UserID    int    `json:"user_id"`
+	Reason    string `json:"reason"`
+	RevokedAt string `json:"revoked_at"`
+	ExpiresAt string `json:"expires_at"`
+}
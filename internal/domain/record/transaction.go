@@ -1,14 +1,52 @@
 package record
 
+const (
+	PaymentStatusPending  = "pending"
+	PaymentStatusPaid     = "paid"
+	PaymentStatusFailed   = "failed"
+	PaymentStatusRefunded = "refunded"
+)
+
 type TransactionRecord struct {
-	ID            int     `json:"id"`
-	OrderID       int     `json:"order_id"`
-	MerchantID    int     `json:"merchant_id"`
-	PaymentMethod string  `json:"payment_method"`
-	Amount        int     `json:"amount"`
-	ChangeAmount  int     `json:"change_amount"`
-	PaymentStatus string  `json:"payment_status"`
-	CreatedAt     string  `json:"created_at"`
-	UpdatedAt     string  `json:"updated_at"`
-	DeletedAt     *string `json:"deleted_at"`
+	ID               int     `json:"id"`
+	OrderID          int     `json:"order_id"`
+	MerchantID       int     `json:"merchant_id"`
+	PaymentMethod    string  `json:"payment_method"`
+	Amount           int64   `json:"amount"`
+	AmountDue        int64   `json:"amount_due"`
+	ChangeAmount     int64   `json:"change_amount"`
+	PaymentStatus    string  `json:"payment_status"`
+	PaymentProvider  string  `json:"payment_provider"`
+	ProviderChargeID string  `json:"provider_charge_id"`
+	RefundedAmount   int64   `json:"refunded_amount"`
+	CreatedAt        string  `json:"created_at"`
+	UpdatedAt        string  `json:"updated_at"`
+	DeletedAt        *string `json:"deleted_at"`
+}
+
+type PaymentEventRecord struct {
+	ID            int    `json:"id"`
+	Provider      string `json:"provider"`
+	EventID       string `json:"event_id"`
+	EventType     string `json:"event_type"`
+	TransactionID *int   `json:"transaction_id"`
+	CreatedAt     string `json:"created_at"`
+}
+
+type RefundRecord struct {
+	ID               int    `json:"id"`
+	TransactionID    int    `json:"transaction_id"`
+	Amount           int64  `json:"amount"`
+	Reason           string `json:"reason"`
+	ProviderRefundID string `json:"provider_refund_id"`
+	Status           string `json:"status"`
+	CreatedAt        string `json:"created_at"`
+}
+
+type RefundItemRecord struct {
+	ID          int   `json:"id"`
+	RefundID    int   `json:"refund_id"`
+	OrderItemID int   `json:"order_item_id"`
+	Quantity    int   `json:"quantity"`
+	Amount      int64 `json:"amount"`
 }
@@ -10,4 +10,5 @@ type UserRecord struct {
 	CreatedAt       string  `json:"created_at"`
 	UpdatedAt       string  `json:"updated_at"`
 	DeletedAt       *string `json:"deleted_at"`
+	EmailVerifiedAt *string `json:"email_verified_at"`
 }
@@ -0,0 +1,13 @@
+package record
+
+// UserTokenRecord is a single-use token mailed to a user. Token holds the
+// hash of the value that was sent, never the value itself.
+type UserTokenRecord struct {
+	ID        int     `json:"id"`
+	UserID    int     `json:"user_id"`
+	Purpose   string  `json:"purpose"`
+	Token     string  `json:"token"`
+	ExpiresAt string  `json:"expires_at"`
+	UsedAt    *string `json:"used_at"`
+	CreatedAt string  `json:"created_at"`
+}
@@ -5,6 +5,11 @@ import "github.com/go-playground/validator/v10"
 type AuthRequest struct {
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required,min=6"`
+	Device   string `json:"device" validate:"max=255"`
+
+	// Filled in from the incoming request, never from the body.
+	UserAgent string `json:"-"`
+	IPAddress string `json:"-"`
 }
 
 type RegisterRequest struct {
@@ -39,6 +44,56 @@ func (r *RegisterRequest) Validate() error {
 	return nil
 }
 
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token           string `json:"token" validate:"required"`
+	Password        string `json:"password" validate:"required,min=6"`
+	ConfirmPassword string `json:"confirm_password" validate:"required,eqfield=Password"`
+}
+
+func (r *VerifyEmailRequest) Validate() error {
+	validate := validator.New()
+
+	err := validate.Struct(r)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ForgotPasswordRequest) Validate() error {
+	validate := validator.New()
+
+	err := validate.Struct(r)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ResetPasswordRequest) Validate() error {
+	validate := validator.New()
+
+	err := validate.Struct(r)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 type JWTToken struct {
 	Token string `json:"token"`
 }
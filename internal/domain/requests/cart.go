@@ -6,7 +6,7 @@ type CartCreateRecord struct {
 	ProductID    int    `json:"product_id"`
 	UserID       int    `json:"user_id"`
 	Name         string `json:"name"`
-	Price        int    `json:"price"`
+	Price        int64  `json:"price"`
 	ImageProduct string `json:"image_product"`
 	Quantity     int    `json:"quantity"`
 	Weight       int    `json:"weight"`
@@ -15,11 +15,40 @@ type CartCreateRecord struct {
 type CreateCartRequest struct {
 	Quantity  int `json:"quantity" validate:"required,gt=0"`
 	ProductID int `json:"product_id" validate:"required"`
-	UserID    int `json:"user_id,omitempty"`
+	// UserID is the authenticated caller whose cart is changed.
+	UserID int `json:"-"`
+}
+
+type UpdateCartQuantityRequest struct {
+	CartID   int `json:"cart_id" validate:"required"`
+	Quantity int `json:"quantity" validate:"required,gt=0"`
+	// UserID is the authenticated caller who must own the cart row.
+	UserID int `json:"-"`
+}
+
+type CheckoutCartRequest struct {
+	// UserID is the authenticated caller whose cart is checked out.
+	UserID          int                            `json:"-"`
+	MerchantID      int                            `json:"merchant_id"`
+	ShippingAddress CheckoutShippingAddressRequest `json:"shipping_address" validate:"required"`
+}
+
+type CheckoutShippingAddressRequest struct {
+	Alamat         string `json:"alamat" validate:"required,min=5"`
+	Provinsi       string `json:"provinsi" validate:"required"`
+	Kota           string `json:"kota" validate:"required"`
+	Courier        string `json:"courier" validate:"required"`
+	ShippingMethod string `json:"shipping_method" validate:"required"`
+	// ShippingCost is the cost of shipping the whole cart. It is split across
+	// the orders the checkout places, one per merchant.
+	ShippingCost int64  `json:"shipping_cost" validate:"gte=0"`
+	Negara       string `json:"negara" validate:"required"`
 }
 
 type DeleteCartRequest struct {
 	CartIds []int `json:"cart_ids"`
+	// UserID is the authenticated caller; rows of other users are left alone.
+	UserID int `json:"-"`
 }
 
 func (l *CartCreateRecord) Validate() error {
@@ -56,3 +85,27 @@ func (l *DeleteCartRequest) Validate() error {
 
 	return nil
 }
+
+func (l *UpdateCartQuantityRequest) Validate() error {
+	validate := validator.New()
+
+	err := validate.Struct(l)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (l *CheckoutCartRequest) Validate() error {
+	validate := validator.New()
+
+	err := validate.Struct(l)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,17 @@
+package requests
+
+import "time"
+
+// ClaimIdempotencyKeyRequest claims a key for one request at LockedAt. Claims
+// still running since before StaleBefore, and keys created before
+// ExpiredBefore, are taken over. All three times are in UTC, the zone the
+// timestamps are stored in.
+type ClaimIdempotencyKeyRequest struct {
+	UserID        int       `json:"user_id" validate:"required"`
+	Method        string    `json:"method" validate:"required"`
+	Key           string    `json:"key" validate:"required,max=255"`
+	RequestHash   string    `json:"request_hash" validate:"required"`
+	LockedAt      time.Time `json:"locked_at"`
+	StaleBefore   time.Time `json:"stale_before"`
+	ExpiredBefore time.Time `json:"expired_before"`
+}
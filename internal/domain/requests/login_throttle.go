@@ -0,0 +1,48 @@
+package requests
+
+import (
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// RecordLoginFailureRequest counts a failed login against Subject. The count
+// starts over when the previous failure happened before ResetBefore.
+type RecordLoginFailureRequest struct {
+	Scope       string    `json:"scope" validate:"required,max=10"`
+	Subject     string    `json:"subject" validate:"required,max=255"`
+	Now         time.Time `json:"now"`
+	ResetBefore time.Time `json:"reset_before"`
+}
+
+// CreateLoginAuditLogRequest records a lockout, or an unlock by the admin
+// ActorID.
+type CreateLoginAuditLogRequest struct {
+	Event          string     `json:"event" validate:"required,max=30"`
+	Scope          string     `json:"scope" validate:"required,max=10"`
+	Subject        string     `json:"subject" validate:"required,max=255"`
+	FailedAttempts int        `json:"failed_attempts"`
+	LockedUntil    *time.Time `json:"locked_until"`
+	IPAddress      string     `json:"ip_address"`
+	ActorID        int        `json:"actor_id"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// UnlockLoginRequest lifts the login lockout of an account, an IP address or
+// both.
+type UnlockLoginRequest struct {
+	Email     string `json:"email" validate:"required_without=IPAddress,omitempty,email"`
+	IPAddress string `json:"ip_address" validate:"required_without=Email,omitempty,ip"`
+}
+
+func (r *UnlockLoginRequest) Validate() error {
+	validate := validator.New()
+
+	err := validate.Struct(r)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
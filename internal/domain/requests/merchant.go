@@ -1,6 +1,12 @@
 package requests
 
-import "github.com/go-playground/validator/v10"
+import (
+	"errors"
+
+	"ecommerce/internal/domain/money"
+
+	"github.com/go-playground/validator/v10"
+)
 
 type CreateMerchantRequest struct {
 	UserID       int    `json:"user_id" validate:"required"`
@@ -10,6 +16,9 @@ type CreateMerchantRequest struct {
 	ContactEmail string `json:"contact_email" validate:"required,email"`
 	ContactPhone string `json:"contact_phone" validate:"required"`
 	Status       string `json:"status" validate:"required"`
+	// Currency is the ISO 4217 code the merchant prices its products in. It
+	// defaults to money.DefaultCurrency and cannot be changed afterwards.
+	Currency string `json:"currency"`
 }
 
 type UpdateMerchantRequest struct {
@@ -29,6 +38,9 @@ func (r *CreateMerchantRequest) Validate() error {
 	if err != nil {
 		return err
 	}
+	if r.Currency != "" && !money.IsSupported(r.Currency) {
+		return errors.New("currency is not supported")
+	}
 	return nil
 }
 
@@ -3,22 +3,23 @@ package requests
 import "github.com/go-playground/validator/v10"
 
 type CreateOrderRecordRequest struct {
-	MerchantID int `json:"merchant_id" validate:"required"`
-	UserID     int `json:"user_id" validate:"required"`
-	TotalPrice int `json:"total_price"`
+	MerchantID int   `json:"merchant_id" validate:"required"`
+	UserID     int   `json:"user_id" validate:"required"`
+	TotalPrice int64 `json:"total_price"`
 }
 
 type UpdateOrderRecordRequest struct {
-	OrderID    int `json:"order_id" validate:"required"`
-	MerchantID int `json:"merchant_id" validate:"required"`
-	UserID     int `json:"user_id" validate:"required"`
-	TotalPrice int `json:"total_price" validate:"required"`
+	OrderID        int   `json:"order_id" validate:"required"`
+	MerchantID     int   `json:"merchant_id" validate:"required"`
+	UserID         int   `json:"user_id" validate:"required"`
+	DiscountAmount int64 `json:"discount_amount" validate:"gte=0"`
 }
 
 type CreateOrderRequest struct {
-	MerchantID      int                          `json:"merchant_id" validate:"required"`
-	UserID          int                          `json:"user_id" validate:"required"`
-	TotalPrice      int                          `json:"total_price" validate:"required"`
+	MerchantID int `json:"merchant_id" validate:"required"`
+	// UserID is the authenticated caller placing the order.
+	UserID          int                          `json:"-"`
+	TotalPrice      int64                        `json:"total_price" validate:"required"`
 	Items           []CreateOrderItemRequest     `json:"items" validate:"required"`
 	ShippingAddress CreateShippingAddressRequest `json:"shipping_address"`
 }
@@ -26,22 +27,35 @@ type CreateOrderRequest struct {
 type UpdateOrderRequest struct {
 	OrderID         int                          `json:"order_id" validate:"required"`
 	UserID          int                          `json:"user_id" validate:"required"`
-	TotalPrice      int                          `json:"total_price" validate:"required"`
+	TotalPrice      int64                        `json:"total_price" validate:"required"`
+	DiscountAmount  int64                        `json:"discount_amount" validate:"gte=0"`
 	Items           []UpdateOrderItemRequest     `json:"items" validate:"required"`
 	ShippingAddress UpdateShippingAddressRequest `json:"shipping_address"`
 }
 
 type CreateOrderItemRequest struct {
-	ProductID int `json:"product_id" validate:"required"`
-	Quantity  int `json:"quantity" validate:"required"`
-	Price     int `json:"price" validate:"required"`
+	ProductID int   `json:"product_id" validate:"required"`
+	Quantity  int   `json:"quantity" validate:"required"`
+	Price     int64 `json:"price" validate:"required"`
 }
 
 type UpdateOrderItemRequest struct {
-	OrderItemID int `json:"order_item_id" validate:"required"`
-	ProductID   int `json:"product_id" validate:"required"`
-	Quantity    int `json:"quantity" validate:"required"`
-	Price       int `json:"price" validate:"required"`
+	OrderItemID int   `json:"order_item_id" validate:"required"`
+	ProductID   int   `json:"product_id" validate:"required"`
+	Quantity    int   `json:"quantity" validate:"required"`
+	Price       int64 `json:"price" validate:"required"`
+}
+
+type UpdateOrderStatusRequest struct {
+	OrderID int    `json:"order_id" validate:"required"`
+	Note    string `json:"note"`
+}
+
+type CreateOrderStatusHistoryRecordRequest struct {
+	OrderID    int    `json:"order_id" validate:"required"`
+	FromStatus string `json:"from_status"`
+	ToStatus   string `json:"to_status" validate:"required"`
+	Note       string `json:"note"`
 }
 
 func (r *CreateOrderRequest) Validate() error {
@@ -61,3 +75,12 @@ func (r *UpdateOrderRequest) Validate() error {
 	}
 	return nil
 }
+
+func (r *UpdateOrderStatusRequest) Validate() error {
+	validate := validator.New()
+	err := validate.Struct(r)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -3,18 +3,18 @@ package requests
 import "github.com/go-playground/validator/v10"
 
 type CreateOrderItemRecordRequest struct {
-	OrderID   int `json:"order_id" validate:"required"`
-	ProductID int `json:"product_id" validate:"required"`
-	Quantity  int `json:"quantity" validate:"required"`
-	Price     int `json:"price" validate:"required"`
+	OrderID   int   `json:"order_id" validate:"required"`
+	ProductID int   `json:"product_id" validate:"required"`
+	Quantity  int   `json:"quantity" validate:"required"`
+	Price     int64 `json:"price" validate:"required"`
 }
 
 type UpdateOrderItemRecordRequest struct {
-	OrderItemID int `json:"order_item_id" validate:"required"`
-	OrderID     int `json:"order_id" validate:"required"`
-	ProductID   int `json:"product_id" validate:"required"`
-	Quantity    int `json:"quantity" validate:"required"`
-	Price       int `json:"price" validate:"required"`
+	OrderItemID int   `json:"order_item_id" validate:"required"`
+	OrderID     int   `json:"order_id" validate:"required"`
+	ProductID   int   `json:"product_id" validate:"required"`
+	Quantity    int   `json:"quantity" validate:"required"`
+	Price       int64 `json:"price" validate:"required"`
 }
 
 func (r *CreateOrderItemRequest) Validate() error {
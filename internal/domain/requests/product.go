@@ -1,13 +1,26 @@
 package requests
 
-import "github.com/go-playground/validator/v10"
+import (
+	"errors"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Sort orders accepted by ProductFilter.Sort.
+const (
+	ProductSortNewest      = "newest"
+	ProductSortPriceAsc    = "price_asc"
+	ProductSortPriceDesc   = "price_desc"
+	ProductSortRating      = "rating"
+	ProductSortBestSelling = "best_selling"
+)
 
 type CreateProductRequest struct {
 	MerchantID   int    `json:"merchant_id" validate:"required"`
 	CategoryID   int    `json:"category_id" validate:"required"`
 	Name         string `json:"name" validate:"required"`
 	Description  string `json:"description" validate:"required"`
-	Price        int    `json:"price" validate:"required"`
+	Price        int64  `json:"price" validate:"required"`
 	CountInStock int    `json:"count_in_stock" validate:"required"`
 	Brand        string `json:"brand" validate:"required"`
 	Weight       int    `json:"weight" validate:"required"`
@@ -23,7 +36,7 @@ type UpdateProductRequest struct {
 	CategoryID   int    `json:"category_id" validate:"required"`
 	Name         string `json:"name" validate:"required"`
 	Description  string `json:"description" validate:"required"`
-	Price        int    `json:"price" validate:"required"`
+	Price        int64  `json:"price" validate:"required"`
 	CountInStock int    `json:"count_in_stock" validate:"required"`
 	Brand        string `json:"brand" validate:"required"`
 	Weight       int    `json:"weight" validate:"required"`
@@ -33,6 +46,30 @@ type UpdateProductRequest struct {
 	Barcode      string `json:"barcode" validate:"required"`
 }
 
+// ProductFilter narrows and orders a product listing. Zero-valued fields are
+// not applied, and an empty Sort lists the newest products first.
+type ProductFilter struct {
+	MinPrice  int64   `json:"min_price" validate:"gte=0"`
+	MaxPrice  int64   `json:"max_price" validate:"gte=0"`
+	MinRating float64 `json:"min_rating" validate:"gte=0,lte=5"`
+	Brand     string  `json:"brand"`
+	InStock   bool    `json:"in_stock"`
+	Sort      string  `json:"sort" validate:"omitempty,oneof=newest price_asc price_desc rating best_selling"`
+}
+
+// SearchProductRequest describes a full-text product search. Zero-valued
+// filters are not applied.
+type SearchProductRequest struct {
+	Query      string `json:"query" validate:"required"`
+	CategoryID int    `json:"category_id" validate:"gte=0"`
+	MerchantID int    `json:"merchant_id" validate:"gte=0"`
+	Brand      string `json:"brand"`
+	MinPrice   int64  `json:"min_price" validate:"gte=0"`
+	MaxPrice   int64  `json:"max_price" validate:"gte=0"`
+	Page       int    `json:"page"`
+	PageSize   int    `json:"page_size"`
+}
+
 func (r *CreateProductRequest) Validate() error {
 	validate := validator.New()
 	err := validate.Struct(r)
@@ -50,3 +87,29 @@ func (r *UpdateProductRequest) Validate() error {
 	}
 	return nil
 }
+
+func (r *SearchProductRequest) Validate() error {
+	validate := validator.New()
+	err := validate.Struct(r)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *ProductFilter) Validate() error {
+	validate := validator.New()
+	err := validate.Struct(r)
+	if err != nil {
+		return err
+	}
+	if r.MaxPrice > 0 && r.MaxPrice < r.MinPrice {
+		return errors.New("max_price must not be lower than min_price")
+	}
+	return nil
+}
+
+// IsEmpty reports whether the filter neither narrows nor reorders a listing.
+func (r *ProductFilter) IsEmpty() bool {
+	return *r == ProductFilter{} || *r == ProductFilter{Sort: ProductSortNewest}
+}
@@ -3,34 +3,32 @@ package requests
 import "github.com/go-playground/validator/v10"
 
 type CreateRefreshToken struct {
-	UserId    int    `json:"user_id" validate:"required,min=1"`
-	Token     string `json:"token" validate:"required,min=1"`
-	ExpiresAt string `json:"expires_at" validate:"required,min=1"`
-}
-
-type UpdateRefreshToken struct {
-	UserId    int    `json:"user_id" validate:"required,min=1"`
-	Token     string `json:"token" validate:"required,min=1"`
-	ExpiresAt string `json:"expires_at" validate:"required,min=1"`
+	UserId     int    `json:"user_id" validate:"required,min=1"`
+	Token      string `json:"token" validate:"required,min=1"`
+	FamilyID   string `json:"family_id" validate:"required,min=1"`
+	Device     string `json:"device"`
+	UserAgent  string `json:"user_agent"`
+	IPAddress  string `json:"ip_address"`
+	SignedInAt string `json:"signed_in_at" validate:"required,min=1"`
+	ExpiresAt  string `json:"expires_at" validate:"required,min=1"`
 }
 
 type RefreshTokenRequest struct {
 	RefreshToken string `json:"refresh_token" validate:"required,min=1"`
-}
 
-func (r *CreateRefreshToken) Validate() error {
-	validate := validator.New()
-
-	err := validate.Struct(r)
+	// Filled in from the incoming request, never from the body.
+	UserAgent string `json:"-"`
+	IPAddress string `json:"-"`
+}
 
-	if err != nil {
-		return err
-	}
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required,min=1"`
 
-	return nil
+	// The caller's access token, when one was sent, so it is revoked too.
+	AccessToken string `json:"-"`
 }
 
-func (r *UpdateRefreshToken) Validate() error {
+func (r *CreateRefreshToken) Validate() error {
 	validate := validator.New()
 
 	err := validate.Struct(r)
@@ -3,10 +3,11 @@ package requests
 import "github.com/go-playground/validator/v10"
 
 type CreateReviewRequest struct {
-	UserID    int    `json:"user_id"`
 	ProductID int    `json:"product_id"`
 	Rating    int    `json:"rating"`
 	Comment   string `json:"comment"`
+	// UserID is the authenticated caller writing the review.
+	UserID int `json:"-"`
 }
 
 type UpdateReviewRequest struct {
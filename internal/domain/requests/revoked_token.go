@@ -0,0 +1,22 @@
+package requests
+
+import "time"
+
+// RevokeAccessTokenRequest revokes the access token TokenID until ExpiresAt,
+// when it would have expired anyway.
+type RevokeAccessTokenRequest struct {
+	TokenID   string    `json:"token_id" validate:"required"`
+	UserID    int       `json:"user_id"`
+	Reason    string    `json:"reason" validate:"required,max=50"`
+	RevokedAt time.Time `json:"revoked_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// RevokeUserAccessTokensRequest revokes every access token of UserID issued
+// before RevokedAt. ExpiresAt is when the last of them expires.
+type RevokeUserAccessTokensRequest struct {
+	UserID    int       `json:"user_id" validate:"required"`
+	Reason    string    `json:"reason" validate:"required,max=50"`
+	RevokedAt time.Time `json:"revoked_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -9,7 +9,7 @@ type CreateShippingAddressRequest struct {
 	Kota           string `json:"kota" validate:"required"`
 	Courier        string `json:"courier" validate:"required"`
 	ShippingMethod string `json:"shipping_method" validate:"required"`
-	ShippingCost   int    `json:"shipping_cost" validate:"required"`
+	ShippingCost   int64  `json:"shipping_cost" validate:"gte=0"`
 	Negara         string `json:"negara" validate:"required"`
 }
 
@@ -21,7 +21,7 @@ type UpdateShippingAddressRequest struct {
 	Kota           string `json:"kota,omitempty" validate:"omitempty"`
 	Courier        string `json:"courier" validate:"required"`
 	ShippingMethod string `json:"shipping_method" validate:"required"`
-	ShippingCost   int    `json:"shipping_cost" validate:"required"`
+	ShippingCost   int64  `json:"shipping_cost" validate:"gte=0"`
 	Negara         string `json:"negara,omitempty" validate:"omitempty"`
 }
 
@@ -6,19 +6,91 @@ type CreateTransactionRequest struct {
 	OrderID       int    `json:"order_id" validate:"required"`
 	MerchantID    int    `json:"merchant_id" validate:"required"`
 	PaymentMethod string `json:"payment_method" validate:"required"`
-	Amount        int    `json:"amount" validate:"required"`
-	ChangeAmount  int    `json:"change_amount" validate:"required"`
-	PaymentStatus string `json:"payment_status" validate:"required"`
+	Amount        int64  `json:"amount" validate:"required"`
+	// AmountDue and ChangeAmount are ignored: the amount due is the order
+	// total and the change is what Amount exceeds it by.
+	AmountDue    int64 `json:"amount_due"`
+	ChangeAmount int64 `json:"change_amount"`
+	// PaymentStatus is ignored on create: transactions start pending and
+	// move to paid or failed on the payment provider outcome.
+	PaymentStatus string `json:"payment_status"`
 }
 
 type UpdateTransactionRequest struct {
-	TransactionID int    `json:"transaction_id" validate:"required"`
+	TransactionID int `json:"transaction_id" validate:"required"`
+	// OrderID and MerchantID must match the transaction: a transaction
+	// keeps paying the order it was created for.
 	OrderID       int    `json:"order_id" validate:"required"`
 	MerchantID    int    `json:"merchant_id" validate:"required"`
 	PaymentMethod string `json:"payment_method" validate:"required"`
-	Amount        int    `json:"amount" validate:"required"`
-	ChangeAmount  int    `json:"change_amount" validate:"required"`
-	PaymentStatus string `json:"payment_status" validate:"required"`
+	Amount        int64  `json:"amount" validate:"required"`
+	// AmountDue and ChangeAmount are ignored, as on create. The payment
+	// status is not editable: only the payment provider moves it.
+	AmountDue    int64 `json:"amount_due"`
+	ChangeAmount int64 `json:"change_amount"`
+}
+
+// UpdateTransactionPaymentRequest records a payment provider outcome on a
+// transaction.
+type UpdateTransactionPaymentRequest struct {
+	TransactionID    int    `json:"transaction_id" validate:"required"`
+	PaymentStatus    string `json:"payment_status" validate:"required"`
+	PaymentProvider  string `json:"payment_provider"`
+	ProviderChargeID string `json:"provider_charge_id"`
+}
+
+// CreatePaymentEventRequest stores a provider webhook event so it is only
+// applied once.
+type CreatePaymentEventRequest struct {
+	Provider      string `json:"provider" validate:"required"`
+	EventID       string `json:"event_id" validate:"required"`
+	EventType     string `json:"event_type" validate:"required"`
+	TransactionID int    `json:"transaction_id"`
+	Payload       []byte `json:"payload"`
+}
+
+// PaymentEventRequest is a raw provider webhook together with its signature.
+type PaymentEventRequest struct {
+	Payload   []byte `json:"payload" validate:"required"`
+	Signature string `json:"signature" validate:"required"`
+}
+
+// RefundTransactionRequest refunds a paid transaction. Without items every
+// order item is refunded in full; otherwise only the listed quantities are.
+// While an earlier refund is pending with the payment provider, that refund
+// is retried instead and the items are ignored.
+type RefundTransactionRequest struct {
+	TransactionID int                 `json:"transaction_id" validate:"required"`
+	Reason        string              `json:"reason"`
+	Items         []RefundItemRequest `json:"items" validate:"dive"`
+}
+
+type RefundItemRequest struct {
+	OrderItemID int `json:"order_item_id" validate:"required"`
+	Quantity    int `json:"quantity" validate:"required,gt=0"`
+}
+
+type CreateRefundRequest struct {
+	TransactionID    int    `json:"transaction_id" validate:"required"`
+	Amount           int64  `json:"amount" validate:"required,gt=0"`
+	Reason           string `json:"reason"`
+	ProviderRefundID string `json:"provider_refund_id"`
+	Status           string `json:"status" validate:"required"`
+}
+
+// UpdateRefundStatusRequest records the payment provider outcome of a
+// pending refund.
+type UpdateRefundStatusRequest struct {
+	RefundID         int    `json:"refund_id" validate:"required"`
+	Status           string `json:"status" validate:"required"`
+	ProviderRefundID string `json:"provider_refund_id"`
+}
+
+type CreateRefundItemRequest struct {
+	RefundID    int   `json:"refund_id" validate:"required"`
+	OrderItemID int   `json:"order_item_id" validate:"required"`
+	Quantity    int   `json:"quantity" validate:"required,gt=0"`
+	Amount      int64 `json:"amount"`
 }
 
 func (r *CreateTransactionRequest) Validate() error {
@@ -38,3 +110,21 @@ func (r *UpdateTransactionRequest) Validate() error {
 	}
 	return nil
 }
+
+func (r *PaymentEventRequest) Validate() error {
+	validate := validator.New()
+	err := validate.Struct(r)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *RefundTransactionRequest) Validate() error {
+	validate := validator.New()
+	err := validate.Struct(r)
+	if err != nil {
+		return err
+	}
+	return nil
+}
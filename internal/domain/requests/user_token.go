@@ -0,0 +1,12 @@
+package requests
+
+import "time"
+
+// CreateUserTokenRequest stores the hash of a token mailed to UserID for
+// Purpose. The token can be used once, until ExpiresAt.
+type CreateUserTokenRequest struct {
+	UserID    int       `json:"user_id" validate:"required"`
+	Purpose   string    `json:"purpose" validate:"required,max=30"`
+	Token     string    `json:"token" validate:"required"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
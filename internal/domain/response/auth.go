@@ -28,3 +28,29 @@ type ApiResponseGetMe struct {
 	Message string        `json:"messsage"`
 	Data    *UserResponse `json:"data"`
 }
+
+type ApiResponseLogout struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+type ApiResponseSessions struct {
+	Status  string             `json:"status"`
+	Message string             `json:"message"`
+	Data    []*SessionResponse `json:"data"`
+}
+
+type ApiResponseVerifyEmail struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+type ApiResponsePasswordReset struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+type ApiResponseUnlockLogin struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
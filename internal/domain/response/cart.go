@@ -1,16 +1,36 @@
 package response
 
+import "ecommerce/internal/domain/money"
+
 type CartResponse struct {
-	ID        int    `json:"id"`
-	UserID    int    `json:"user_id"`
-	ProductID int    `json:"product_id"`
-	Name      string `json:"name"`
-	Price     int    `json:"price"`
-	Image     string `json:"image"`
-	Quantity  int    `json:"quantity"`
-	Weight    int    `json:"weight"`
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
+	ID        int         `json:"id"`
+	UserID    int         `json:"user_id"`
+	ProductID int         `json:"product_id"`
+	Name      string      `json:"name"`
+	Price     money.Money `json:"price"`
+	Image     string      `json:"image"`
+	Quantity  int         `json:"quantity"`
+	Weight    int         `json:"weight"`
+	CreatedAt string      `json:"created_at"`
+	UpdatedAt string      `json:"updated_at"`
+}
+
+type CartSummaryResponse struct {
+	ItemCount   int         `json:"item_count"`
+	TotalWeight int         `json:"total_weight"`
+	Subtotal    money.Money `json:"subtotal"`
+}
+
+type ApiResponseCart struct {
+	Status  string        `json:"status"`
+	Message string        `json:"message"`
+	Data    *CartResponse `json:"data"`
+}
+
+type ApiResponseCartSummary struct {
+	Status  string               `json:"status"`
+	Message string               `json:"message"`
+	Data    *CartSummaryResponse `json:"data"`
 }
 
 type ApiResponseCartPagination struct {
@@ -9,6 +9,7 @@ type MerchantResponse struct {
 	ContactEmail string `json:"contact_email"`
 	ContactPhone string `json:"contact_phone"`
 	Status       string `json:"status"`
+	Currency     string `json:"currency"`
 	CreatedAt    string `json:"created_at"`
 	UpdatedAt    string `json:"updated_at"`
 }
@@ -22,6 +23,7 @@ type MerchantResponseDeleteAt struct {
 	ContactEmail string `json:"contact_email"`
 	ContactPhone string `json:"contact_phone"`
 	Status       string `json:"status"`
+	Currency     string `json:"currency"`
 	CreatedAt    string `json:"created_at"`
 	UpdatedAt    string `json:"updated_at"`
 	DeletedAt    string `json:"deleted_at"`
@@ -1,22 +1,43 @@
 package response
 
+import "ecommerce/internal/domain/money"
+
 type OrderResponse struct {
-	ID         int    `json:"id"`
-	MerchantID int    `json:"merchant_id"`
-	UserID     int    `json:"user_id"`
-	TotalPrice int    `json:"total_price"`
-	CreatedAt  string `json:"created_at"`
-	UpdatedAt  string `json:"updated_at"`
+	ID             int         `json:"id"`
+	MerchantID     int         `json:"merchant_id"`
+	UserID         int         `json:"user_id"`
+	TotalPrice     money.Money `json:"total_price"`
+	Subtotal       money.Money `json:"subtotal"`
+	ShippingCost   money.Money `json:"shipping_cost"`
+	TaxAmount      money.Money `json:"tax_amount"`
+	DiscountAmount money.Money `json:"discount_amount"`
+	Status         string      `json:"status"`
+	CreatedAt      string      `json:"created_at"`
+	UpdatedAt      string      `json:"updated_at"`
 }
 
 type OrderResponseDeleteAt struct {
+	ID             int         `json:"id"`
+	MerchantID     int         `json:"merchant_id"`
+	UserID         int         `json:"user_id"`
+	TotalPrice     money.Money `json:"total_price"`
+	Subtotal       money.Money `json:"subtotal"`
+	ShippingCost   money.Money `json:"shipping_cost"`
+	TaxAmount      money.Money `json:"tax_amount"`
+	DiscountAmount money.Money `json:"discount_amount"`
+	Status         string      `json:"status"`
+	CreatedAt      string      `json:"created_at"`
+	UpdatedAt      string      `json:"updated_at"`
+	DeleteAt       string      `json:"deleted_at"`
+}
+
+type OrderStatusHistoryResponse struct {
 	ID         int    `json:"id"`
-	MerchantID int    `json:"merchant_id"`
-	UserID     int    `json:"user_id"`
-	TotalPrice int    `json:"total_price"`
+	OrderID    int    `json:"order_id"`
+	FromStatus string `json:"from_status"`
+	ToStatus   string `json:"to_status"`
+	Note       string `json:"note"`
 	CreatedAt  string `json:"created_at"`
-	UpdatedAt  string `json:"updated_at"`
-	DeleteAt   string `json:"deleted_at"`
 }
 
 type ApiResponseOrder struct {
@@ -60,3 +81,9 @@ type ApiResponsePaginationOrder struct {
 	Data       []*OrderResponse `json:"data"`
 	Pagination PaginationMeta   `json:"pagination"`
 }
+
+type ApiResponseOrderStatusHistory struct {
+	Status  string                        `json:"status"`
+	Message string                        `json:"message"`
+	Data    []*OrderStatusHistoryResponse `json:"data"`
+}
@@ -1,24 +1,26 @@
 package response
 
+import "ecommerce/internal/domain/money"
+
 type OrderItemResponse struct {
-	ID        int    `json:"id"`
-	OrderID   int    `json:"order_id"`
-	ProductID int    `json:"product_id"`
-	Quantity  int    `json:"quantity"`
-	Price     int    `json:"price"`
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
+	ID        int         `json:"id"`
+	OrderID   int         `json:"order_id"`
+	ProductID int         `json:"product_id"`
+	Quantity  int         `json:"quantity"`
+	Price     money.Money `json:"price"`
+	CreatedAt string      `json:"created_at"`
+	UpdatedAt string      `json:"updated_at"`
 }
 
 type OrderItemResponseDeleteAt struct {
-	ID        int    `json:"id"`
-	OrderID   int    `json:"order_id"`
-	ProductID int    `json:"product_id"`
-	Quantity  int    `json:"quantity"`
-	Price     int    `json:"price"`
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
-	DeleteAt  string `json:"deleted_at"`
+	ID        int         `json:"id"`
+	OrderID   int         `json:"order_id"`
+	ProductID int         `json:"product_id"`
+	Quantity  int         `json:"quantity"`
+	Price     money.Money `json:"price"`
+	CreatedAt string      `json:"created_at"`
+	UpdatedAt string      `json:"updated_at"`
+	DeleteAt  string      `json:"deleted_at"`
 }
 
 type ApiResponseOrderItem struct {
@@ -1,38 +1,61 @@
 package response
 
+import "ecommerce/internal/domain/money"
+
 type ProductResponse struct {
-	ID           int     `json:"id"`
-	MerchantID   int     `json:"merchant_id"`
-	CategoryID   int     `json:"category_id"`
-	Name         string  `json:"name"`
-	Description  string  `json:"description"`
-	Price        int     `json:"price"`
-	CountInStock int     `json:"count_in_stock"`
-	Brand        string  `json:"brand"`
-	Weight       int     `json:"weight"`
-	Rating       float32 `json:"rating"`
-	SlugProduct  string  `json:"slug_product"`
-	ImageProduct string  `json:"image_product"`
-	CreatedAt    string  `json:"created_at"`
-	UpdatedAt    string  `json:"updated_at"`
+	ID           int         `json:"id"`
+	MerchantID   int         `json:"merchant_id"`
+	CategoryID   int         `json:"category_id"`
+	Name         string      `json:"name"`
+	Description  string      `json:"description"`
+	Price        money.Money `json:"price"`
+	CountInStock int         `json:"count_in_stock"`
+	Brand        string      `json:"brand"`
+	Weight       int         `json:"weight"`
+	Rating       float32     `json:"rating"`
+	SlugProduct  string      `json:"slug_product"`
+	ImageProduct string      `json:"image_product"`
+	CreatedAt    string      `json:"created_at"`
+	UpdatedAt    string      `json:"updated_at"`
 }
 
 type ProductResponseDeleteAt struct {
-	ID           int     `json:"id"`
-	MerchantID   int     `json:"merchant_id"`
-	CategoryID   int     `json:"category_id"`
-	Name         string  `json:"name"`
-	Description  string  `json:"description"`
-	Price        int     `json:"price"`
-	CountInStock int     `json:"count_in_stock"`
-	Brand        string  `json:"brand"`
-	Weight       int     `json:"weight"`
-	Rating       float32 `json:"rating"`
-	SlugProduct  string  `json:"slug_product"`
-	ImageProduct string  `json:"image_product"`
-	CreatedAt    string  `json:"created_at"`
-	UpdatedAt    string  `json:"updated_at"`
-	DeleteAt     string  `json:"deleted_at"`
+	ID           int         `json:"id"`
+	MerchantID   int         `json:"merchant_id"`
+	CategoryID   int         `json:"category_id"`
+	Name         string      `json:"name"`
+	Description  string      `json:"description"`
+	Price        money.Money `json:"price"`
+	CountInStock int         `json:"count_in_stock"`
+	Brand        string      `json:"brand"`
+	Weight       int         `json:"weight"`
+	Rating       float32     `json:"rating"`
+	SlugProduct  string      `json:"slug_product"`
+	ImageProduct string      `json:"image_product"`
+	CreatedAt    string      `json:"created_at"`
+	UpdatedAt    string      `json:"updated_at"`
+	DeleteAt     string      `json:"deleted_at"`
+}
+
+type ProductFacetValueResponse struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+	Count int    `json:"count"`
+}
+
+// ProductPriceBucketResponse counts products priced from Min up to but not
+// including Max. The last bucket has no upper bound and leaves Max nil.
+type ProductPriceBucketResponse struct {
+	Min   money.Money  `json:"min"`
+	Max   *money.Money `json:"max,omitempty"`
+	Count int          `json:"count"`
+}
+
+type ProductFacetsResponse struct {
+	Categories   []*ProductFacetValueResponse  `json:"categories"`
+	Brands       []*ProductFacetValueResponse  `json:"brands"`
+	Merchants    []*ProductFacetValueResponse  `json:"merchants"`
+	PriceBuckets []*ProductPriceBucketResponse `json:"price_buckets"`
 }
 
 type ApiResponseProduct struct {
@@ -76,3 +99,11 @@ type ApiResponsePaginationProduct struct {
 	Data       []*ProductResponse `json:"data"`
 	Pagination PaginationMeta     `json:"pagination"`
 }
+
+type ApiResponseProductSearch struct {
+	Status     string                 `json:"status"`
+	Message    string                 `json:"message"`
+	Data       []*ProductResponse     `json:"data"`
+	Pagination PaginationMeta         `json:"pagination"`
+	Facets     *ProductFacetsResponse `json:"facets"`
+}
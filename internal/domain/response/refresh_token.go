@@ -7,3 +7,13 @@ type RefreshTokenResponse struct {
 	CreatedAt string `json:"created_at"`
 	UpdatedAt string `json:"updated_at"`
 }
+
+type SessionResponse struct {
+	ID           string `json:"id"`
+	Device       string `json:"device"`
+	UserAgent    string `json:"user_agent"`
+	IPAddress    string `json:"ip_address"`
+	SignedInAt   string `json:"signed_in_at"`
+	LastActiveAt string `json:"last_active_at"`
+	ExpiresAt    string `json:"expires_at"`
+}
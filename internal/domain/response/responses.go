@@ -1,10 +1,14 @@
 package response
 
+// PaginationMeta describes a page of results. Cursor-paginated lists skip
+// counting the table, so they leave CurrentPage, TotalPages and TotalRecords
+// at zero and set NextCursor while more rows remain.
 type PaginationMeta struct {
-	CurrentPage  int `json:"current_page"`
-	PageSize     int `json:"page_size"`
-	TotalPages   int `json:"total_pages"`
-	TotalRecords int `json:"total_records"`
+	CurrentPage  int    `json:"current_page"`
+	PageSize     int    `json:"page_size"`
+	TotalPages   int    `json:"total_pages"`
+	TotalRecords int    `json:"total_records"`
+	NextCursor   string `json:"next_cursor,omitempty"`
 }
 
 type ApiResponse[T any] struct {
@@ -20,7 +24,24 @@ type APIResponsePagination[T any] struct {
 	Meta    PaginationMeta `json:"pagination"`
 }
 
+// Error codes classify an ErrorResponse. They are carried unchanged from the
+// services through gRPC to the REST gateway, so clients can rely on them.
+// An empty code means an internal error.
+const (
+	ErrCodeInternal                = "internal"
+	ErrCodeValidation              = "validation"
+	ErrCodeNotFound                = "not_found"
+	ErrCodeConflict                = "conflict"
+	ErrCodeUnauthorized            = "unauthorized"
+	ErrCodeForbidden               = "forbidden"
+	ErrCodeInsufficientStock       = "insufficient_stock"
+	ErrCodeInvalidStatusTransition = "invalid_status_transition"
+	ErrCodeEmptyCart               = "empty_cart"
+	ErrCodeTooManyAttempts         = "too_many_attempts"
+)
+
 type ErrorResponse struct {
 	Status  string `json:"status"`
 	Message string `json:"message"`
+	Code    string `json:"code,omitempty"`
 }
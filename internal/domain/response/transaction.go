@@ -1,28 +1,38 @@
 package response
 
+import "ecommerce/internal/domain/money"
+
 type TransactionResponse struct {
 	ID            int    `json:"id"`
 	OrderID       int    `json:"order_id"`
 	MerchantID    int    `json:"merchant_id"`
 	PaymentMethod string `json:"payment_method"`
-	Amount        int    `json:"amount"`
-	ChangeAmount  int    `json:"change_amount"`
-	PaymentStatus string `json:"payment_status"`
-	CreatedAt     string `json:"created_at"`
-	UpdatedAt     string `json:"updated_at"`
+	// Amount is what the customer tendered, AmountDue the order total charged
+	// and ChangeAmount the overpayment returned to the customer.
+	Amount        money.Money `json:"amount"`
+	AmountDue     money.Money `json:"amount_due"`
+	ChangeAmount  money.Money `json:"change_amount"`
+	PaymentStatus string      `json:"payment_status"`
+	// RefundedAmount is the total refunded so far and NetAmount what remains
+	// paid after those refunds.
+	RefundedAmount money.Money `json:"refunded_amount"`
+	NetAmount      money.Money `json:"net_amount"`
+	CreatedAt      string      `json:"created_at"`
+	UpdatedAt      string      `json:"updated_at"`
 }
 
 type TransactionResponseDeleteAt struct {
-	ID            int    `json:"id"`
-	OrderID       int    `json:"order_id"`
-	MerchantID    int    `json:"merchant_id"`
-	PaymentMethod string `json:"payment_method"`
-	Amount        int    `json:"amount"`
-	ChangeAmount  int    `json:"change_amount"`
-	PaymentStatus string `json:"payment_status"`
-	CreatedAt     string `json:"created_at"`
-	UpdatedAt     string `json:"updated_at"`
-	DeletedAt     string `json:"deleted_at"`
+	ID            int         `json:"id"`
+	OrderID       int         `json:"order_id"`
+	MerchantID    int         `json:"merchant_id"`
+	PaymentMethod string      `json:"payment_method"`
+	Amount        money.Money `json:"amount"`
+	AmountDue     money.Money `json:"amount_due"`
+	ChangeAmount  money.Money `json:"change_amount"`
+	PaymentStatus string      `json:"payment_status"`
+	CreatedAt     string      `json:"created_at"`
+	UpdatedAt     string      `json:"updated_at"`
+	DeletedAt     string      `json:"deleted_at"`
 }
 
 type ApiResponseTransaction struct {
@@ -11,6 +11,7 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 type authHandleApi struct {
@@ -32,6 +33,14 @@ func NewHandlerAuth(router *echo.Echo, client pb.AuthServiceClient, logger logge
 	routerAuth.POST("/login", authHandler.Login)
 	routerAuth.POST("/refresh-token", authHandler.RefreshToken)
 	routerAuth.GET("/me", authHandler.GetMe)
+	routerAuth.POST("/logout", authHandler.Logout)
+	routerAuth.POST("/logout-all", authHandler.LogoutAll)
+	routerAuth.GET("/sessions", authHandler.FindSessions)
+	routerAuth.DELETE("/sessions/:id", authHandler.RevokeSession)
+	routerAuth.POST("/verify-email", authHandler.VerifyEmail)
+	routerAuth.POST("/forgot-password", authHandler.RequestPasswordReset)
+	routerAuth.POST("/reset-password", authHandler.ResetPassword)
+	routerAuth.POST("/unlock", authHandler.UnlockLogin)
 
 	return authHandler
 }
@@ -66,6 +75,7 @@ func (h *authHandleApi) Register(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Bad Request: ",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -74,6 +84,7 @@ func (h *authHandleApi) Register(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Validation Error: ",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -91,10 +102,7 @@ func (h *authHandleApi) Register(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Internal Server Error", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Internal Server Error: " + err.Error(),
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToResponseRegister(res)
@@ -121,6 +129,7 @@ func (h *authHandleApi) Login(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Bad Request: ",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -129,12 +138,14 @@ func (h *authHandleApi) Login(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Validation Error: ",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
 	data := &pb.LoginRequest{
 		Email:    body.Email,
 		Password: body.Password,
+		Device:   body.Device,
 	}
 
 	ctx := c.Request().Context()
@@ -143,11 +154,7 @@ func (h *authHandleApi) Login(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to login user", zap.Error(err))
-
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Internal Server Error: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToResponseLogin(res)
@@ -158,8 +165,7 @@ func (h *authHandleApi) Login(c echo.Context) error {
 // RefreshToken godoc
 // @Summary Refresh access token
 // @Tags Auth
-// @Security Bearer
-// @Description Refreshes the access token using a valid refresh token.
+// @Description Exchanges a refresh token for a new access and refresh token. Each refresh token can only be used once; reusing one ends its session.
 // @Accept json
 // @Produce json
 // @Param request body requests.RefreshTokenRequest true "Refresh token data"
@@ -175,6 +181,7 @@ func (h *authHandleApi) RefreshToken(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Bad Request: Invalid request body",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -183,6 +190,7 @@ func (h *authHandleApi) RefreshToken(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Validation Error: " + err.Error(),
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -192,10 +200,7 @@ func (h *authHandleApi) RefreshToken(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to refresh token", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Internal Server Error: " + err.Error(),
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToResponseRefreshToken(res)
@@ -224,6 +229,7 @@ func (h *authHandleApi) GetMe(c echo.Context) error {
 		return c.JSON(http.StatusUnauthorized, response.ErrorResponse{
 			Status:  "error",
 			Message: "Unauthorized: Missing or invalid Authorization header",
+			Code:    response.ErrCodeUnauthorized,
 		})
 	}
 
@@ -235,13 +241,319 @@ func (h *authHandleApi) GetMe(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to get user information", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
+		return grpcErrorResponse(c, err)
+	}
+
+	so := h.mapping.ToResponseGetMe(res)
+
+	return c.JSON(http.StatusOK, so)
+}
+
+// Logout godoc
+// @Summary Log out the current session
+// @Tags Auth
+// @Description Revokes the session the given refresh token belongs to.
+// @Accept json
+// @Produce json
+// @Param request body requests.RefreshTokenRequest true "Refresh token of the session"
+// @Success 200 {object} response.ApiResponseLogout "Success"
+// @Failure 400 {object} response.ErrorResponse "Bad Request"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /api/auth/logout [post]
+func (h *authHandleApi) Logout(c echo.Context) error {
+	var body requests.RefreshTokenRequest
+
+	if err := c.Bind(&body); err != nil {
+		h.logger.Debug("Validation Error", zap.Error(err))
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Status:  "error",
+			Message: "Bad Request: Invalid request body",
+			Code:    response.ErrCodeValidation,
+		})
+	}
+
+	if err := body.Validate(); err != nil {
+		h.logger.Debug("Validation Error", zap.Error(err))
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
-			Message: "Internal Server Error: " + err.Error(),
+			Message: "Validation Error: " + err.Error(),
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
-	so := h.mapping.ToResponseGetMe(res)
+	res, err := h.client.Logout(c.Request().Context(), &pb.LogoutRequest{
+		RefreshToken: body.RefreshToken,
+	})
+
+	if err != nil {
+		h.logger.Debug("Failed to logout", zap.Error(err))
+		return grpcErrorResponse(c, err)
+	}
+
+	so := h.mapping.ToResponseLogout(res)
+
+	return c.JSON(http.StatusOK, so)
+}
+
+// LogoutAll godoc
+// @Summary Log out every session
+// @Tags Auth
+// @Security Bearer
+// @Description Revokes all sessions of the current user on every device.
+// @Produce json
+// @Success 200 {object} response.ApiResponseLogout "Success"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /api/auth/logout-all [post]
+func (h *authHandleApi) LogoutAll(c echo.Context) error {
+	res, err := h.client.LogoutAll(c.Request().Context(), &emptypb.Empty{})
+
+	if err != nil {
+		h.logger.Debug("Failed to logout all sessions", zap.Error(err))
+		return grpcErrorResponse(c, err)
+	}
+
+	so := h.mapping.ToResponseLogout(res)
+
+	return c.JSON(http.StatusOK, so)
+}
+
+// FindSessions godoc
+// @Summary List active sessions
+// @Tags Auth
+// @Security Bearer
+// @Description Lists the devices the current user is signed in on.
+// @Produce json
+// @Success 200 {object} response.ApiResponseSessions "Success"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /api/auth/sessions [get]
+func (h *authHandleApi) FindSessions(c echo.Context) error {
+	res, err := h.client.FindSessions(c.Request().Context(), &emptypb.Empty{})
+
+	if err != nil {
+		h.logger.Debug("Failed to find sessions", zap.Error(err))
+		return grpcErrorResponse(c, err)
+	}
+
+	so := h.mapping.ToResponseSessions(res)
+
+	return c.JSON(http.StatusOK, so)
+}
+
+// RevokeSession godoc
+// @Summary Revoke a session
+// @Tags Auth
+// @Security Bearer
+// @Description Signs the current user out of one of their sessions.
+// @Produce json
+// @Param id path string true "Session ID"
+// @Success 200 {object} response.ApiResponseLogout "Success"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Session not found"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /api/auth/sessions/{id} [delete]
+func (h *authHandleApi) RevokeSession(c echo.Context) error {
+	res, err := h.client.RevokeSession(c.Request().Context(), &pb.RevokeSessionRequest{
+		SessionId: c.Param("id"),
+	})
+
+	if err != nil {
+		h.logger.Debug("Failed to revoke session", zap.Error(err))
+		return grpcErrorResponse(c, err)
+	}
+
+	so := h.mapping.ToResponseLogout(res)
+
+	return c.JSON(http.StatusOK, so)
+}
+
+// VerifyEmail godoc
+// @Summary Verify an email address
+// @Tags Auth
+// @Description Confirms the email address of the account a verification link was sent to.
+// @Accept json
+// @Produce json
+// @Param request body requests.VerifyEmailRequest true "Token from the verification link"
+// @Success 200 {object} response.ApiResponseVerifyEmail "Success"
+// @Failure 400 {object} response.ErrorResponse "Bad Request"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /api/auth/verify-email [post]
+func (h *authHandleApi) VerifyEmail(c echo.Context) error {
+	var body requests.VerifyEmailRequest
+
+	if err := c.Bind(&body); err != nil {
+		h.logger.Debug("Bad Request", zap.Error(err))
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Status:  "error",
+			Message: "Bad Request: Invalid request body",
+			Code:    response.ErrCodeValidation,
+		})
+	}
+
+	if err := body.Validate(); err != nil {
+		h.logger.Debug("Validation Error", zap.Error(err))
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Status:  "error",
+			Message: "Validation Error: " + err.Error(),
+			Code:    response.ErrCodeValidation,
+		})
+	}
+
+	res, err := h.client.VerifyEmail(c.Request().Context(), &pb.VerifyEmailRequest{
+		Token: body.Token,
+	})
+
+	if err != nil {
+		h.logger.Debug("Failed to verify email", zap.Error(err))
+		return grpcErrorResponse(c, err)
+	}
+
+	so := h.mapping.ToResponseVerifyEmail(res)
+
+	return c.JSON(http.StatusOK, so)
+}
+
+// RequestPasswordReset godoc
+// @Summary Request a password reset link
+// @Tags Auth
+// @Description Emails a password reset link if an account is registered under the address. The response does not reveal whether it is.
+// @Accept json
+// @Produce json
+// @Param request body requests.ForgotPasswordRequest true "Email of the account"
+// @Success 200 {object} response.ApiResponsePasswordReset "Success"
+// @Failure 400 {object} response.ErrorResponse "Bad Request"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /api/auth/forgot-password [post]
+func (h *authHandleApi) RequestPasswordReset(c echo.Context) error {
+	var body requests.ForgotPasswordRequest
+
+	if err := c.Bind(&body); err != nil {
+		h.logger.Debug("Bad Request", zap.Error(err))
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Status:  "error",
+			Message: "Bad Request: Invalid request body",
+			Code:    response.ErrCodeValidation,
+		})
+	}
+
+	if err := body.Validate(); err != nil {
+		h.logger.Debug("Validation Error", zap.Error(err))
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Status:  "error",
+			Message: "Validation Error: " + err.Error(),
+			Code:    response.ErrCodeValidation,
+		})
+	}
+
+	res, err := h.client.RequestPasswordReset(c.Request().Context(), &pb.RequestPasswordResetRequest{
+		Email: body.Email,
+	})
+
+	if err != nil {
+		h.logger.Debug("Failed to request password reset", zap.Error(err))
+		return grpcErrorResponse(c, err)
+	}
+
+	so := h.mapping.ToResponsePasswordReset(res)
+
+	return c.JSON(http.StatusOK, so)
+}
+
+// ResetPassword godoc
+// @Summary Reset a password
+// @Tags Auth
+// @Description Sets a new password with the token from a password reset link and signs the user out everywhere.
+// @Accept json
+// @Produce json
+// @Param request body requests.ResetPasswordRequest true "Token from the reset link and the new password"
+// @Success 200 {object} response.ApiResponsePasswordReset "Success"
+// @Failure 400 {object} response.ErrorResponse "Bad Request"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /api/auth/reset-password [post]
+func (h *authHandleApi) ResetPassword(c echo.Context) error {
+	var body requests.ResetPasswordRequest
+
+	if err := c.Bind(&body); err != nil {
+		h.logger.Debug("Bad Request", zap.Error(err))
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Status:  "error",
+			Message: "Bad Request: Invalid request body",
+			Code:    response.ErrCodeValidation,
+		})
+	}
+
+	if err := body.Validate(); err != nil {
+		h.logger.Debug("Validation Error", zap.Error(err))
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Status:  "error",
+			Message: "Validation Error: " + err.Error(),
+			Code:    response.ErrCodeValidation,
+		})
+	}
+
+	res, err := h.client.ResetPassword(c.Request().Context(), &pb.ResetPasswordRequest{
+		Token:           body.Token,
+		Password:        body.Password,
+		ConfirmPassword: body.ConfirmPassword,
+	})
+
+	if err != nil {
+		h.logger.Debug("Failed to reset password", zap.Error(err))
+		return grpcErrorResponse(c, err)
+	}
+
+	so := h.mapping.ToResponsePasswordReset(res)
+
+	return c.JSON(http.StatusOK, so)
+}
+
+// UnlockLogin godoc
+// @Summary Lift a login lockout
+// @Tags Auth
+// @Security Bearer
+// @Description Clears the failed login attempts of an account, an IP address or both, ending any lockout. Admin only.
+// @Accept json
+// @Produce json
+// @Param request body requests.UnlockLoginRequest true "Email and/or IP address to unlock"
+// @Success 200 {object} response.ApiResponseUnlockLogin "Success"
+// @Failure 400 {object} response.ErrorResponse "Bad Request"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /api/auth/unlock [post]
+func (h *authHandleApi) UnlockLogin(c echo.Context) error {
+	var body requests.UnlockLoginRequest
+
+	if err := c.Bind(&body); err != nil {
+		h.logger.Debug("Bad Request", zap.Error(err))
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Status:  "error",
+			Message: "Bad Request: Invalid request body",
+			Code:    response.ErrCodeValidation,
+		})
+	}
+
+	if err := body.Validate(); err != nil {
+		h.logger.Debug("Validation Error", zap.Error(err))
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Status:  "error",
+			Message: "Validation Error: " + err.Error(),
+			Code:    response.ErrCodeValidation,
+		})
+	}
+
+	res, err := h.client.UnlockLogin(c.Request().Context(), &pb.UnlockLoginRequest{
+		Email:     body.Email,
+		IpAddress: body.IPAddress,
+	})
+
+	if err != nil {
+		h.logger.Debug("Failed to unlock login", zap.Error(err))
+		return grpcErrorResponse(c, err)
+	}
+
+	so := h.mapping.ToResponseUnlockLogin(res)
 
 	return c.JSON(http.StatusOK, so)
 }
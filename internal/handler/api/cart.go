@@ -1,6 +1,7 @@
 package api
 
 import (
+	"ecommerce/internal/domain/requests"
 	"ecommerce/internal/domain/response"
 	response_api "ecommerce/internal/mapper/response/api"
 	"ecommerce/internal/pb"
@@ -10,12 +11,14 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 type cartHandleApi struct {
-	client  pb.CartServiceClient
-	logger  logger.LoggerInterface
-	mapping response_api.CartResponseMapper
+	client       pb.CartServiceClient
+	logger       logger.LoggerInterface
+	mapping      response_api.CartResponseMapper
+	orderMapping response_api.OrderResponseMapper
 }
 
 func NewHandlerCart(
@@ -23,17 +26,22 @@ func NewHandlerCart(
 	client pb.CartServiceClient,
 	logger logger.LoggerInterface,
 	mapping response_api.CartResponseMapper,
+	orderMapping response_api.OrderResponseMapper,
 ) *cartHandleApi {
 	cartHandler := &cartHandleApi{
-		client:  client,
-		logger:  logger,
-		mapping: mapping,
+		client:       client,
+		logger:       logger,
+		mapping:      mapping,
+		orderMapping: orderMapping,
 	}
 
 	routerCart := router.Group("/api/cart")
 	routerCart.GET("", cartHandler.FindAll)
+	routerCart.GET("/summary", cartHandler.FindSummary)
+	routerCart.POST("/update/:id", cartHandler.UpdateQuantity)
 	routerCart.DELETE("/:id", cartHandler.Delete)
 	routerCart.POST("/delete-all", cartHandler.DeleteAll)
+	routerCart.POST("/checkout", cartHandler.Checkout)
 
 	return cartHandler
 }
@@ -44,7 +52,6 @@ func NewHandlerCart(
 // @Description Retrieve a list of all carts
 // @Accept json
 // @Produce json
-// @Param user_id query int true "User ID"
 // @Param page query int false "Page number" default(1)
 // @Param page_size query int false "Number of items per page" default(10)
 // @Param search query string false "Search query"
@@ -52,14 +59,6 @@ func NewHandlerCart(
 // @Failure 500 {object} response.ErrorResponse "Failed to retrieve cart data"
 // @Router /api/cart [get]
 func (h *cartHandleApi) FindAll(c echo.Context) error {
-	userID, err := strconv.Atoi(c.QueryParam("user_id"))
-	if err != nil || userID <= 0 {
-		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
-			Status:  "error",
-			Message: "Invalid user ID",
-		})
-	}
-
 	page, err := strconv.Atoi(c.QueryParam("page"))
 	if err != nil || page <= 0 {
 		page = 1
@@ -74,7 +73,6 @@ func (h *cartHandleApi) FindAll(c echo.Context) error {
 	ctx := c.Request().Context()
 
 	req := &pb.FindAllCartRequest{
-		UserId:   int32(userID),
 		Page:     int32(page),
 		PageSize: int32(pageSize),
 		Search:   search,
@@ -83,13 +81,92 @@ func (h *cartHandleApi) FindAll(c echo.Context) error {
 	res, err := h.client.FindAll(ctx, req)
 	if err != nil {
 		h.logger.Debug("Failed to retrieve cart data", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
+		return grpcErrorResponse(c, err)
+	}
+
+	so := h.mapping.ToApiResponseCartPagination(res)
+	return c.JSON(http.StatusOK, so)
+}
+
+// @Security Bearer
+// @Summary Find cart summary
+// @Tags Cart
+// @Description Retrieve the item count, total weight and subtotal of the caller's cart
+// @Accept json
+// @Produce json
+// @Success 200 {object} response.ApiResponseCartSummary "Cart summary"
+// @Failure 500 {object} response.ErrorResponse "Failed to retrieve cart summary"
+// @Router /api/cart/summary [get]
+func (h *cartHandleApi) FindSummary(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	res, err := h.client.FindSummary(ctx, &emptypb.Empty{})
+	if err != nil {
+		h.logger.Debug("Failed to retrieve cart summary", zap.Error(err))
+		return grpcErrorResponse(c, err)
+	}
+
+	so := h.mapping.ToApiResponseCartSummary(res)
+	return c.JSON(http.StatusOK, so)
+}
+
+// @Security Bearer
+// @Summary Update cart quantity
+// @Tags Cart
+// @Description Set the quantity of a cart item
+// @Accept json
+// @Produce json
+// @Param id path int true "Cart ID"
+// @Param request body requests.UpdateCartQuantityRequest true "Cart quantity"
+// @Success 200 {object} response.ApiResponseCart "Successfully updated cart quantity"
+// @Failure 400 {object} response.ErrorResponse "Invalid request body or validation error"
+// @Failure 409 {object} response.ErrorResponse "Insufficient stock"
+// @Failure 500 {object} response.ErrorResponse "Failed to update cart quantity"
+// @Router /api/cart/update/{id} [post]
+func (h *cartHandleApi) UpdateQuantity(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Debug("Invalid cart ID", zap.Error(err))
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
-			Message: "Failed to retrieve cart data",
+			Message: "Invalid cart ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
-	so := h.mapping.ToApiResponseCartPagination(res)
+	var req requests.UpdateCartQuantityRequest
+	if err := c.Bind(&req); err != nil {
+		h.logger.Debug("Invalid request body", zap.Error(err))
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Status:  "error",
+			Message: "Invalid request body",
+			Code:    response.ErrCodeValidation,
+		})
+	}
+
+	req.CartID = id
+
+	if err := req.Validate(); err != nil {
+		h.logger.Debug("Validation error", zap.Error(err))
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Status:  "error",
+			Message: "Validation error",
+			Code:    response.ErrCodeValidation,
+		})
+	}
+
+	ctx := c.Request().Context()
+
+	res, err := h.client.UpdateQuantity(ctx, &pb.UpdateCartQuantityRequest{
+		CartId:   int32(req.CartID),
+		Quantity: int32(req.Quantity),
+	})
+	if err != nil {
+		h.logger.Debug("Failed to update cart quantity", zap.Error(err))
+		return grpcErrorResponse(c, err)
+	}
+
+	so := h.mapping.ToApiResponseCart(res)
 	return c.JSON(http.StatusOK, so)
 }
 
@@ -111,6 +188,7 @@ func (h *cartHandleApi) Delete(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid cart ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -122,10 +200,7 @@ func (h *cartHandleApi) Delete(c echo.Context) error {
 	res, err := h.client.Delete(ctx, req)
 	if err != nil {
 		h.logger.Debug("Failed to delete cart", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to delete cart",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseCartDelete(res)
@@ -149,6 +224,7 @@ func (h *cartHandleApi) DeleteAll(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid request body",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -156,12 +232,66 @@ func (h *cartHandleApi) DeleteAll(c echo.Context) error {
 	res, err := h.client.DeleteAll(ctx, &req)
 	if err != nil {
 		h.logger.Debug("Failed to delete carts", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
+		return grpcErrorResponse(c, err)
+	}
+
+	so := h.mapping.ToApiResponseCartAll(res)
+	return c.JSON(http.StatusOK, so)
+}
+
+// @Security Bearer
+// @Summary Checkout cart
+// @Tags Cart
+// @Description Turn the user's cart into one order per merchant, priced from the current product prices
+// @Accept json
+// @Produce json
+// @Param request body requests.CheckoutCartRequest true "Checkout details"
+// @Success 200 {object} response.ApiResponsesOrder "Successfully checked out cart"
+// @Failure 400 {object} response.ErrorResponse "Invalid request body or validation error"
+// @Failure 409 {object} response.ErrorResponse "Cart is empty or stock is insufficient"
+// @Failure 500 {object} response.ErrorResponse "Failed to checkout cart"
+// @Router /api/cart/checkout [post]
+func (h *cartHandleApi) Checkout(c echo.Context) error {
+	var req requests.CheckoutCartRequest
+	if err := c.Bind(&req); err != nil {
+		h.logger.Debug("Invalid request body", zap.Error(err))
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
-			Message: "Failed to delete carts",
+			Message: "Invalid request body",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
-	so := h.mapping.ToApiResponseCartAll(res)
+	if err := req.Validate(); err != nil {
+		h.logger.Debug("Validation error", zap.Error(err))
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Status:  "error",
+			Message: "Validation error",
+			Code:    response.ErrCodeValidation,
+		})
+	}
+
+	ctx := c.Request().Context()
+
+	grpcReq := &pb.CheckoutCartRequest{
+		MerchantId: int32(req.MerchantID),
+		Shipping: &pb.CheckoutShippingAddressRequest{
+			Alamat:         req.ShippingAddress.Alamat,
+			Provinsi:       req.ShippingAddress.Provinsi,
+			Kota:           req.ShippingAddress.Kota,
+			Courier:        req.ShippingAddress.Courier,
+			ShippingMethod: req.ShippingAddress.ShippingMethod,
+			ShippingCost:   req.ShippingAddress.ShippingCost,
+			Negara:         req.ShippingAddress.Negara,
+		},
+	}
+
+	res, err := h.client.Checkout(ctx, grpcReq)
+	if err != nil {
+		h.logger.Debug("Failed to checkout cart", zap.Error(err))
+		return grpcErrorResponse(c, err)
+	}
+
+	so := h.orderMapping.ToApiResponsesOrder(res)
 	return c.JSON(http.StatusOK, so)
 }
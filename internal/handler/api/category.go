@@ -90,10 +90,7 @@ func (h *categoryHandleApi) FindAllCategory(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to retrieve category data", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to retrieve category data: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponsePaginationCategory(res)
@@ -120,6 +117,7 @@ func (h *categoryHandleApi) FindById(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid category ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -133,10 +131,7 @@ func (h *categoryHandleApi) FindById(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to retrieve category data", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to retrieve category data: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseCategory(res)
@@ -178,10 +173,7 @@ func (h *categoryHandleApi) FindByActive(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to retrieve category data", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to retrieve category data: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponsePaginationCategoryDeleteAt(res)
@@ -224,10 +216,7 @@ func (h *categoryHandleApi) FindByTrashed(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to retrieve category data", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to retrieve category data: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponsePaginationCategoryDeleteAt(res)
@@ -261,6 +250,7 @@ func (h *categoryHandleApi) Create(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid image file",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -294,10 +284,7 @@ func (h *categoryHandleApi) Create(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to create category", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to create category: " + err.Error(),
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	return c.JSON(http.StatusOK, res)
@@ -325,6 +312,7 @@ func (h *categoryHandleApi) Update(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid category ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -367,10 +355,7 @@ func (h *categoryHandleApi) Update(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to update category", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to update category: " + err.Error(),
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	return c.JSON(http.StatusOK, res)
@@ -396,6 +381,7 @@ func (h *categoryHandleApi) TrashedCategory(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid category ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -409,10 +395,7 @@ func (h *categoryHandleApi) TrashedCategory(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to trashed category", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to trashed category: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseCategoryDeleteAt(res)
@@ -440,6 +423,7 @@ func (h *categoryHandleApi) RestoreCategory(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid category ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -453,10 +437,7 @@ func (h *categoryHandleApi) RestoreCategory(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to restore category", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to restore category: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseCategoryDeleteAt(res)
@@ -484,6 +465,7 @@ func (h *categoryHandleApi) DeleteCategoryPermanent(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid category ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -497,10 +479,7 @@ func (h *categoryHandleApi) DeleteCategoryPermanent(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to delete category", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to delete category: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseCategoryDelete(res)
@@ -526,11 +505,7 @@ func (h *categoryHandleApi) RestoreAllCategory(c echo.Context) error {
 	res, err := h.client.RestoreAllCategory(ctx, &emptypb.Empty{})
 
 	if err != nil {
-		h.logger.Error("Failed to restore all category", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to permanently restore all category",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseCategoryAll(res)
@@ -558,12 +533,7 @@ func (h *categoryHandleApi) DeleteAllCategoryPermanent(c echo.Context) error {
 	res, err := h.client.DeleteAllCategoryPermanent(ctx, &emptypb.Empty{})
 
 	if err != nil {
-		h.logger.Error("Failed to permanently delete all category", zap.Error(err))
-
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to permanently delete all category",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseCategoryAll(res)
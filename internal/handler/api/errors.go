@@ -0,0 +1,64 @@
+package api
+
+import (
+	"ecommerce/internal/domain/response"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// httpStatuses maps the gRPC code of a failed call to the HTTP status the
+// gateway answers with. Codes that are not listed become 500.
+var httpStatuses = map[codes.Code]int{
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.FailedPrecondition: http.StatusConflict,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+	codes.Unavailable:        http.StatusServiceUnavailable,
+}
+
+// fallbackErrorCodes gives the error code for statuses that carry no
+// ErrorInfo detail, such as those raised by interceptors.
+var fallbackErrorCodes = map[codes.Code]string{
+	codes.InvalidArgument:  response.ErrCodeValidation,
+	codes.NotFound:         response.ErrCodeNotFound,
+	codes.AlreadyExists:    response.ErrCodeConflict,
+	codes.Unauthenticated:  response.ErrCodeUnauthorized,
+	codes.PermissionDenied: response.ErrCodeForbidden,
+}
+
+// grpcErrorResponse writes the error returned by a gRPC call as a JSON
+// ErrorResponse, using the HTTP status and error code that match it.
+func grpcErrorResponse(c echo.Context, err error) error {
+	st := status.Convert(err)
+
+	httpStatus, ok := httpStatuses[st.Code()]
+	if !ok {
+		httpStatus = http.StatusInternalServerError
+	}
+
+	code, ok := fallbackErrorCodes[st.Code()]
+	if !ok {
+		code = response.ErrCodeInternal
+	}
+
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.GetReason() != "" {
+			code = info.GetReason()
+			break
+		}
+	}
+
+	return c.JSON(httpStatus, response.ErrorResponse{
+		Status:  "error",
+		Message: st.Message(),
+		Code:    code,
+	})
+}
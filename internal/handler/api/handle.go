@@ -5,6 +5,7 @@ import (
 	"ecommerce/internal/pb"
 	"ecommerce/pkg/auth"
 	"ecommerce/pkg/logger"
+	"ecommerce/pkg/payment"
 
 	"github.com/labstack/echo/v4"
 	"google.golang.org/grpc"
@@ -15,6 +16,7 @@ type Deps struct {
 	Token   auth.TokenManager
 	E       *echo.Echo
 	Logger  logger.LoggerInterface
+	Payment payment.PaymentProvider
 	Mapping response_api.ResponseApiMapper
 }
 
@@ -43,8 +45,10 @@ func NewHandler(deps Deps) {
 	NewHandlerOrder(deps.E, clientOrder, deps.Logger, deps.Mapping.OrderResponseMapper)
 	NewHandlerProduct(deps.E, clientProduct, deps.Logger, deps.Mapping.ProductResponseMapper)
 	NewHandlerTransaction(deps.E, clientTransaction, deps.Logger, deps.Mapping.TransactionResponseMapper)
-	NewHandlerCart(deps.E, clientCart, deps.Logger, deps.Mapping.CartResponseMapper)
+	NewHandlerPayment(deps.E, clientTransaction, deps.Payment, deps.Logger, deps.Mapping.TransactionResponseMapper)
+	NewHandlerCart(deps.E, clientCart, deps.Logger, deps.Mapping.CartResponseMapper, deps.Mapping.OrderResponseMapper)
 	NewHandlerReview(deps.E, clientReview, deps.Logger, deps.Mapping.ReviewMapper)
 	NewHandlerSlider(deps.E, clientSlider, deps.Logger, deps.Mapping.SliderMapper)
 	NewHandlerShippingAddress(deps.E, clientShipping, deps.Logger, deps.Mapping.ShippingAddressResponseMapper)
+	NewHandlerJWKS(deps.E, deps.Token)
 }
@@ -0,0 +1,20 @@
+package api
+
+import (
+	"ecommerce/internal/middlewares"
+
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc/metadata"
+)
+
+// IdempotentReplayedHeader tells the client its request was answered with
+// the stored result of an earlier request with the same idempotency key.
+const IdempotentReplayedHeader = "Idempotent-Replayed"
+
+// markReplayed copies the replay marker from the gRPC response header onto
+// the HTTP response.
+func markReplayed(c echo.Context, header metadata.MD) {
+	if len(header.Get(middlewares.IdempotentReplayedHeader)) > 0 {
+		c.Response().Header().Set(IdempotentReplayedHeader, "true")
+	}
+}
@@ -0,0 +1,34 @@
+package api
+
+import (
+	"ecommerce/pkg/auth"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type jwksHandleApi struct {
+	token auth.TokenManager
+}
+
+func NewHandlerJWKS(router *echo.Echo, token auth.TokenManager) *jwksHandleApi {
+	jwksHandler := &jwksHandleApi{
+		token: token,
+	}
+
+	router.GET("/.well-known/jwks.json", jwksHandler.JWKS)
+
+	return jwksHandler
+}
+
+// @Summary Public keys for verifying access tokens
+// @Tags Auth
+// @Description Lists the public keys access tokens are signed with, identified by kid. Keys retired by a rotation stay listed until the tokens they signed have expired. Empty when tokens are signed with a shared HS256 secret.
+// @Produce json
+// @Success 200 {object} auth.JWKS "Key set"
+// @Router /.well-known/jwks.json [get]
+func (h *jwksHandleApi) JWKS(c echo.Context) error {
+	c.Response().Header().Set(echo.HeaderCacheControl, "public, max-age=300")
+
+	return c.JSON(http.StatusOK, h.token.JWKS())
+}
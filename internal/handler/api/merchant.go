@@ -35,6 +35,7 @@ func NewHandlerMerchant(
 	routercategory := router.Group("/api/merchant")
 
 	routercategory.GET("", merchantHandler.FindAllMerchant)
+	routercategory.GET("/me", merchantHandler.FindMe)
 	routercategory.GET("/:id", merchantHandler.FindById)
 	routercategory.GET("/active", merchantHandler.FindByActive)
 	routercategory.GET("/trashed", merchantHandler.FindByTrashed)
@@ -89,10 +90,7 @@ func (h *merchantHandleApi) FindAllMerchant(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to retrieve merchant data", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to retrieve merchant data: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponsePaginationMerchant(res)
@@ -119,6 +117,7 @@ func (h *merchantHandleApi) FindById(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid user ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -132,10 +131,7 @@ func (h *merchantHandleApi) FindById(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to retrieve merchant data", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to retrieve merchant data: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseMerchant(res)
@@ -143,6 +139,30 @@ func (h *merchantHandleApi) FindById(c echo.Context) error {
 	return c.JSON(http.StatusOK, so)
 }
 
+// @Security Bearer
+// @Summary Find merchants of the current user
+// @Tags Merchant
+// @Description Retrieve the merchants owned by the authenticated user
+// @Accept json
+// @Produce json
+// @Success 200 {object} response.ApiResponsesMerchant "merchant data"
+// @Failure 500 {object} response.ErrorResponse "Failed to retrieve merchant data"
+// @Router /api/merchant/me [get]
+func (h *merchantHandleApi) FindMe(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	res, err := h.client.FindMe(ctx, &emptypb.Empty{})
+
+	if err != nil {
+		h.logger.Debug("Failed to retrieve merchant data", zap.Error(err))
+		return grpcErrorResponse(c, err)
+	}
+
+	so := h.mapping.ToApiResponsesMerchant(res)
+
+	return c.JSON(http.StatusOK, so)
+}
+
 // @Security Bearer
 // @Summary Retrieve active merchant
 // @Tags Merchant
@@ -177,10 +197,7 @@ func (h *merchantHandleApi) FindByActive(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to retrieve merchant data", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to retrieve merchant data: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 	return c.JSON(http.StatusOK, res)
 }
@@ -220,10 +237,7 @@ func (h *merchantHandleApi) FindByTrashed(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to retrieve merchant data", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to retrieve merchant data: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponsePaginationMerchantDeleteAt(res)
@@ -251,6 +265,7 @@ func (h *merchantHandleApi) Create(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid request body",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -259,6 +274,7 @@ func (h *merchantHandleApi) Create(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Validation Error: " + err.Error(),
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -272,16 +288,14 @@ func (h *merchantHandleApi) Create(c echo.Context) error {
 		ContactEmail: body.ContactEmail,
 		ContactPhone: body.ContactPhone,
 		Status:       body.Status,
+		Currency:     body.Currency,
 	}
 
 	res, err := h.client.Create(ctx, req)
 
 	if err != nil {
 		h.logger.Debug("Failed to create merchant", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to create merchant: " + err.Error(),
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseMerchant(res)
@@ -309,6 +323,7 @@ func (h *merchantHandleApi) Update(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid request body",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -317,6 +332,7 @@ func (h *merchantHandleApi) Update(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Validation Error: " + err.Error(),
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -337,10 +353,7 @@ func (h *merchantHandleApi) Update(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to update merchant", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to update merchant: " + err.Error(),
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseMerchant(res)
@@ -368,6 +381,7 @@ func (h *merchantHandleApi) TrashedMerchant(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid merchant ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -381,10 +395,7 @@ func (h *merchantHandleApi) TrashedMerchant(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to trashed merchant", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to trashed merchant: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseMerchantDeleteAt(res)
@@ -412,6 +423,7 @@ func (h *merchantHandleApi) RestoreMerchant(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid merchant ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -425,10 +437,7 @@ func (h *merchantHandleApi) RestoreMerchant(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to restore merchant", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to restore merchant: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseMerchantDeleteAt(res)
@@ -456,6 +465,7 @@ func (h *merchantHandleApi) DeleteMerchantPermanent(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid merchant ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -469,10 +479,7 @@ func (h *merchantHandleApi) DeleteMerchantPermanent(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to delete merchant", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to delete merchant: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseMerchantDelete(res)
@@ -498,11 +505,7 @@ func (h *merchantHandleApi) RestoreAllMerchant(c echo.Context) error {
 	res, err := h.client.RestoreAllMerchant(ctx, &emptypb.Empty{})
 
 	if err != nil {
-		h.logger.Error("Failed to restore all merchant", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to permanently restore all merchant",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseMerchantAll(res)
@@ -530,12 +533,7 @@ func (h *merchantHandleApi) DeleteAllMerchantPermanent(c echo.Context) error {
 	res, err := h.client.DeleteAllMerchantPermanent(ctx, &emptypb.Empty{})
 
 	if err != nil {
-		h.logger.Error("Failed to permanently delete all merchant", zap.Error(err))
-
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to permanently delete all merchant",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseMerchantAll(res)
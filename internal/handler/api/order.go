@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"ecommerce/internal/domain/requests"
 	"ecommerce/internal/domain/response"
 	response_api "ecommerce/internal/mapper/response/api"
@@ -11,6 +12,8 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -42,6 +45,12 @@ func NewHandlerOrder(
 	routercategory.POST("/create", orderHandler.Create)
 	routercategory.POST("/update/:id", orderHandler.Update)
 
+	routercategory.GET("/history/:id", orderHandler.FindStatusHistory)
+	routercategory.POST("/cancel/:id", orderHandler.Cancel)
+	routercategory.POST("/processing/:id", orderHandler.MarkProcessing)
+	routercategory.POST("/shipped/:id", orderHandler.MarkShipped)
+	routercategory.POST("/delivered/:id", orderHandler.MarkDelivered)
+
 	routercategory.POST("/trashed/:id", orderHandler.TrashedOrder)
 	routercategory.POST("/restore/:id", orderHandler.RestoreOrder)
 	routercategory.DELETE("/permanent/:id", orderHandler.DeleteOrderPermanent)
@@ -61,6 +70,7 @@ func NewHandlerOrder(
 // @Param page query int false "Page number" default(1)
 // @Param page_size query int false "Number of items per page" default(10)
 // @Param search query string false "Search query"
+// @Param cursor query string false "Keyset cursor; empty for the first page"
 // @Success 200 {object} response.ApiResponsePaginationOrder "List of orders"
 // @Failure 500 {object} response.ErrorResponse "Failed to retrieve order data"
 // @Router /api/order [get]
@@ -85,13 +95,15 @@ func (h *orderHandleApi) FindAllOrders(c echo.Context) error {
 		Search:   search,
 	}
 
+	if c.QueryParams().Has("cursor") {
+		cursor := c.QueryParam("cursor")
+		req.Cursor = &cursor
+	}
+
 	res, err := h.client.FindAll(ctx, req)
 	if err != nil {
 		h.logger.Debug("Failed to retrieve order data", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to retrieve order data",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponsePaginationOrder(res)
@@ -117,6 +129,7 @@ func (h *orderHandleApi) FindById(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid order ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -129,10 +142,7 @@ func (h *orderHandleApi) FindById(c echo.Context) error {
 	res, err := h.client.FindById(ctx, req)
 	if err != nil {
 		h.logger.Debug("Failed to retrieve order data", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to retrieve order data",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseOrder(res)
@@ -173,10 +183,7 @@ func (h *orderHandleApi) FindByActive(c echo.Context) error {
 	res, err := h.client.FindByActive(ctx, req)
 	if err != nil {
 		h.logger.Debug("Failed to retrieve active order data", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to retrieve active order data",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponsePaginationOrderDeleteAt(res)
@@ -217,10 +224,7 @@ func (h *orderHandleApi) FindByTrashed(c echo.Context) error {
 	res, err := h.client.FindByTrashed(ctx, req)
 	if err != nil {
 		h.logger.Debug("Failed to retrieve trashed order data", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to retrieve trashed order data",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponsePaginationOrderDeleteAt(res)
@@ -234,9 +238,11 @@ func (h *orderHandleApi) FindByTrashed(c echo.Context) error {
 // @Description Create a new order with provided details
 // @Accept json
 // @Produce json
+// @Param Idempotency-Key header string false "Key that makes retries of this request return the first result"
 // @Param request body requests.CreateOrderRequest true "Order details"
 // @Success 200 {object} response.ApiResponseOrder "Successfully created order"
 // @Failure 400 {object} response.ErrorResponse "Invalid request body or validation error"
+// @Failure 409 {object} response.ErrorResponse "Insufficient stock or idempotency key conflict"
 // @Failure 500 {object} response.ErrorResponse "Failed to create order"
 // @Router /api/order/create [post]
 func (h *orderHandleApi) Create(c echo.Context) error {
@@ -246,6 +252,7 @@ func (h *orderHandleApi) Create(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid request body",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -254,6 +261,7 @@ func (h *orderHandleApi) Create(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Validation error",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -261,8 +269,7 @@ func (h *orderHandleApi) Create(c echo.Context) error {
 
 	grpcReq := &pb.CreateOrderRequest{
 		MerchantId: int32(req.MerchantID),
-		UserId:     int32(req.UserID),
-		TotalPrice: int32(req.TotalPrice),
+		TotalPrice: req.TotalPrice,
 		Items:      []*pb.CreateOrderItemRequest{},
 		Shipping: &pb.CreateShippingAddressRequest{
 			Alamat:         req.ShippingAddress.Alamat,
@@ -270,7 +277,7 @@ func (h *orderHandleApi) Create(c echo.Context) error {
 			Kota:           req.ShippingAddress.Kota,
 			Courier:        req.ShippingAddress.Courier,
 			ShippingMethod: req.ShippingAddress.ShippingMethod,
-			ShippingCost:   int32(req.ShippingAddress.ShippingCost),
+			ShippingCost:   req.ShippingAddress.ShippingCost,
 			Negara:         req.ShippingAddress.Negara,
 		},
 	}
@@ -279,19 +286,20 @@ func (h *orderHandleApi) Create(c echo.Context) error {
 		grpcReq.Items = append(grpcReq.Items, &pb.CreateOrderItemRequest{
 			ProductId: int32(item.ProductID),
 			Quantity:  int32(item.Quantity),
-			Price:     int32(item.Price),
+			Price:     item.Price,
 		})
 	}
 
-	res, err := h.client.Create(ctx, grpcReq)
+	var header metadata.MD
+
+	res, err := h.client.Create(ctx, grpcReq, grpc.Header(&header))
 	if err != nil {
 		h.logger.Debug("Failed to create order", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to create order",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
+	markReplayed(c, header)
+
 	return c.JSON(http.StatusOK, res)
 }
 
@@ -304,6 +312,7 @@ func (h *orderHandleApi) Create(c echo.Context) error {
 // @Param request body requests.UpdateOrderRequest true "Order update details"
 // @Success 200 {object} response.ApiResponseOrder "Successfully updated order"
 // @Failure 400 {object} response.ErrorResponse "Invalid request body or validation error"
+// @Failure 409 {object} response.ErrorResponse "Order not pending or insufficient stock"
 // @Failure 500 {object} response.ErrorResponse "Failed to update order"
 // @Router /api/order/update [put]
 func (h *orderHandleApi) Update(c echo.Context) error {
@@ -313,6 +322,7 @@ func (h *orderHandleApi) Update(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid request body",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -321,15 +331,17 @@ func (h *orderHandleApi) Update(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Validation error",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
 	ctx := c.Request().Context()
 
 	grpcReq := &pb.UpdateOrderRequest{
-		OrderId:    int32(req.OrderID),
-		TotalPrice: int32(req.TotalPrice),
-		Items:      []*pb.UpdateOrderItemRequest{},
+		OrderId:        int32(req.OrderID),
+		TotalPrice:     req.TotalPrice,
+		DiscountAmount: req.DiscountAmount,
+		Items:          []*pb.UpdateOrderItemRequest{},
 		Shipping: &pb.UpdateShippingAddressRequest{
 			ShippingId:     int32(req.ShippingAddress.ShippingID),
 			OrderId:        int32(req.ShippingAddress.OrderID),
@@ -338,7 +350,7 @@ func (h *orderHandleApi) Update(c echo.Context) error {
 			Kota:           req.ShippingAddress.Kota,
 			Courier:        req.ShippingAddress.Courier,
 			ShippingMethod: req.ShippingAddress.ShippingMethod,
-			ShippingCost:   int32(req.ShippingAddress.ShippingCost),
+			ShippingCost:   req.ShippingAddress.ShippingCost,
 			Negara:         req.ShippingAddress.Negara,
 		},
 	}
@@ -348,20 +360,166 @@ func (h *orderHandleApi) Update(c echo.Context) error {
 			OrderItemId: int32(item.OrderItemID),
 			ProductId:   int32(item.ProductID),
 			Quantity:    int32(item.Quantity),
-			Price:       int32(item.Price),
+			Price:       item.Price,
 		})
 	}
 
 	res, err := h.client.Update(ctx, grpcReq)
 	if err != nil {
 		h.logger.Debug("Failed to update order", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
+		return grpcErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// @Security Bearer
+// @Summary Retrieve order status history
+// @Tags Order
+// @Description Retrieve the status timeline of an order, oldest change first
+// @Accept json
+// @Produce json
+// @Param id path int true "Order ID"
+// @Success 200 {object} response.ApiResponseOrderStatusHistory "Order status history"
+// @Failure 400 {object} response.ErrorResponse "Invalid order ID"
+// @Failure 500 {object} response.ErrorResponse "Failed to retrieve order status history"
+// @Router /api/order/history/{id} [get]
+func (h *orderHandleApi) FindStatusHistory(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Debug("Invalid order ID", zap.Error(err))
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
-			Message: "Failed to update order",
+			Message: "Invalid order ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
-	return c.JSON(http.StatusOK, res)
+	ctx := c.Request().Context()
+
+	req := &pb.FindByIdOrderRequest{
+		Id: int32(id),
+	}
+
+	res, err := h.client.FindStatusHistory(ctx, req)
+	if err != nil {
+		h.logger.Debug("Failed to retrieve order status history", zap.Error(err))
+		return grpcErrorResponse(c, err)
+	}
+
+	so := h.mapping.ToApiResponseOrderStatusHistory(res)
+
+	return c.JSON(http.StatusOK, so)
+}
+
+// @Security Bearer
+// @Summary Cancel an order
+// @Tags Order
+// @Description Cancel an order and return its items to stock
+// @Accept json
+// @Produce json
+// @Param id path int true "Order ID"
+// @Param request body requests.UpdateOrderStatusRequest false "Optional note for the status timeline"
+// @Success 200 {object} response.ApiResponseOrder "Updated order"
+// @Failure 400 {object} response.ErrorResponse "Invalid order ID"
+// @Failure 409 {object} response.ErrorResponse "Transition not allowed from the current status"
+// @Failure 500 {object} response.ErrorResponse "Failed to update order status"
+// @Router /api/order/cancel/{id} [post]
+func (h *orderHandleApi) Cancel(c echo.Context) error {
+	return h.changeStatus(c, h.client.Cancel)
+}
+
+// @Security Bearer
+// @Summary Mark order as processing
+// @Tags Order
+// @Description Mark a paid order as being processed
+// @Accept json
+// @Produce json
+// @Param id path int true "Order ID"
+// @Param request body requests.UpdateOrderStatusRequest false "Optional note for the status timeline"
+// @Success 200 {object} response.ApiResponseOrder "Updated order"
+// @Failure 400 {object} response.ErrorResponse "Invalid order ID"
+// @Failure 409 {object} response.ErrorResponse "Transition not allowed from the current status"
+// @Failure 500 {object} response.ErrorResponse "Failed to update order status"
+// @Router /api/order/processing/{id} [post]
+func (h *orderHandleApi) MarkProcessing(c echo.Context) error {
+	return h.changeStatus(c, h.client.MarkProcessing)
+}
+
+// @Security Bearer
+// @Summary Mark order as shipped
+// @Tags Order
+// @Description Mark a processing order as shipped
+// @Accept json
+// @Produce json
+// @Param id path int true "Order ID"
+// @Param request body requests.UpdateOrderStatusRequest false "Optional note for the status timeline"
+// @Success 200 {object} response.ApiResponseOrder "Updated order"
+// @Failure 400 {object} response.ErrorResponse "Invalid order ID"
+// @Failure 409 {object} response.ErrorResponse "Transition not allowed from the current status"
+// @Failure 500 {object} response.ErrorResponse "Failed to update order status"
+// @Router /api/order/shipped/{id} [post]
+func (h *orderHandleApi) MarkShipped(c echo.Context) error {
+	return h.changeStatus(c, h.client.MarkShipped)
+}
+
+// @Security Bearer
+// @Summary Mark order as delivered
+// @Tags Order
+// @Description Mark a shipped order as delivered
+// @Accept json
+// @Produce json
+// @Param id path int true "Order ID"
+// @Param request body requests.UpdateOrderStatusRequest false "Optional note for the status timeline"
+// @Success 200 {object} response.ApiResponseOrder "Updated order"
+// @Failure 400 {object} response.ErrorResponse "Invalid order ID"
+// @Failure 409 {object} response.ErrorResponse "Transition not allowed from the current status"
+// @Failure 500 {object} response.ErrorResponse "Failed to update order status"
+// @Router /api/order/delivered/{id} [post]
+func (h *orderHandleApi) MarkDelivered(c echo.Context) error {
+	return h.changeStatus(c, h.client.MarkDelivered)
+}
+
+func (h *orderHandleApi) changeStatus(
+	c echo.Context,
+	call func(ctx context.Context, in *pb.UpdateOrderStatusRequest, opts ...grpc.CallOption) (*pb.ApiResponseOrder, error),
+) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Debug("Invalid order ID", zap.Error(err))
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Status:  "error",
+			Message: "Invalid order ID",
+			Code:    response.ErrCodeValidation,
+		})
+	}
+
+	var body requests.UpdateOrderStatusRequest
+	if err := c.Bind(&body); err != nil {
+		h.logger.Debug("Invalid request body", zap.Error(err))
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Status:  "error",
+			Message: "Invalid request body",
+			Code:    response.ErrCodeValidation,
+		})
+	}
+
+	ctx := c.Request().Context()
+
+	req := &pb.UpdateOrderStatusRequest{
+		OrderId: int32(id),
+		Note:    body.Note,
+	}
+
+	res, err := call(ctx, req)
+	if err != nil {
+		h.logger.Debug("Failed to update order status", zap.Error(err))
+		return grpcErrorResponse(c, err)
+	}
+
+	so := h.mapping.ToApiResponseOrder(res)
+
+	return c.JSON(http.StatusOK, so)
 }
 
 // @Security Bearer
@@ -384,6 +542,7 @@ func (h *orderHandleApi) TrashedOrder(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid order ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -397,10 +556,7 @@ func (h *orderHandleApi) TrashedOrder(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to trashed order", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to trashed order: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseOrderDeleteAt(res)
@@ -428,6 +584,7 @@ func (h *orderHandleApi) RestoreOrder(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid order ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -441,10 +598,7 @@ func (h *orderHandleApi) RestoreOrder(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to restore order", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to restore order: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseOrderDeleteAt(res)
@@ -472,6 +626,7 @@ func (h *orderHandleApi) DeleteOrderPermanent(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid order ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -485,10 +640,7 @@ func (h *orderHandleApi) DeleteOrderPermanent(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to delete order", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to delete order: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseOrderDelete(res)
@@ -512,11 +664,7 @@ func (h *orderHandleApi) RestoreAllOrder(c echo.Context) error {
 	res, err := h.client.RestoreAllOrder(ctx, &emptypb.Empty{})
 
 	if err != nil {
-		h.logger.Error("Failed to restore all orders", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to permanently restore all orders",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseOrderAll(res)
@@ -542,12 +690,7 @@ func (h *orderHandleApi) DeleteAllOrderPermanent(c echo.Context) error {
 	res, err := h.client.DeleteAllOrderPermanent(ctx, &emptypb.Empty{})
 
 	if err != nil {
-		h.logger.Error("Failed to permanently delete all orders", zap.Error(err))
-
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to permanently delete all orders",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseOrderAll(res)
@@ -77,10 +77,7 @@ func (h *orderItemHandleApi) FindAllOrderItems(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to retrieve order item data", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to retrieve order item data: " + err.Error(),
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponsePaginationOrderItem(res)
@@ -122,10 +119,7 @@ func (h *orderItemHandleApi) FindByActive(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to retrieve order item data", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to retrieve order item data: " + err.Error(),
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponsePaginationOrderItemDeleteAt(res)
@@ -167,10 +161,7 @@ func (h *orderItemHandleApi) FindByTrashed(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to retrieve order item data", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to retrieve order item data: " + err.Error(),
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponsePaginationOrderItemDeleteAt(res)
@@ -197,6 +188,7 @@ func (h *orderItemHandleApi) FindOrderItemByOrder(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid order ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -210,10 +202,7 @@ func (h *orderItemHandleApi) FindOrderItemByOrder(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to retrieve order item data", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to retrieve order item data: " + err.Error(),
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponsesOrderItem(res)
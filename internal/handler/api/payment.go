@@ -0,0 +1,106 @@
+package api
+
+import (
+	"ecommerce/internal/domain/response"
+	response_api "ecommerce/internal/mapper/response/api"
+	"ecommerce/internal/pb"
+	"ecommerce/pkg/logger"
+	"ecommerce/pkg/payment"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// maxWebhookBodySize bounds the webhook body read into memory.
+const maxWebhookBodySize = 1 << 20
+
+type paymentHandleApi struct {
+	client   pb.TransactionServiceClient
+	provider payment.PaymentProvider
+	logger   logger.LoggerInterface
+	mapping  response_api.TransactionResponseMapper
+}
+
+func NewHandlerPayment(
+	router *echo.Echo,
+	client pb.TransactionServiceClient,
+	provider payment.PaymentProvider,
+	logger logger.LoggerInterface,
+	mapping response_api.TransactionResponseMapper,
+) *paymentHandleApi {
+	paymentHandler := &paymentHandleApi{
+		client:   client,
+		provider: provider,
+		logger:   logger,
+		mapping:  mapping,
+	}
+
+	routerPayment := router.Group("/api/payments")
+
+	routerPayment.POST("/webhook", paymentHandler.Webhook)
+
+	return paymentHandler
+}
+
+// @Summary Receive a payment provider webhook
+// @Tags Payment
+// @Description Verify the HMAC signature of a payment provider event and apply it to the matching transaction and order. Redelivered events are acknowledged without being applied again.
+// @Accept json
+// @Produce json
+// @Param X-Payment-Signature header string true "Hex encoded HMAC-SHA256 of the raw body"
+// @Success 200 {object} response.ApiResponseTransaction "Event processed"
+// @Failure 400 {object} response.ErrorResponse "Invalid payment event"
+// @Failure 401 {object} response.ErrorResponse "Invalid webhook signature"
+// @Failure 404 {object} response.ErrorResponse "Transaction not found"
+// @Router /api/payments/webhook [post]
+func (h *paymentHandleApi) Webhook(c echo.Context) error {
+	payload, err := io.ReadAll(io.LimitReader(c.Request().Body, maxWebhookBodySize))
+	if err != nil {
+		h.logger.Debug("Failed to read webhook body", zap.Error(err))
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Status:  "error",
+			Message: "Invalid payment event",
+			Code:    response.ErrCodeValidation,
+		})
+	}
+
+	signature := c.Request().Header.Get(payment.SignatureHeader)
+
+	if _, err := h.provider.VerifyWebhook(payload, signature); err != nil {
+		h.logger.Debug("Rejected payment webhook", zap.Error(err))
+
+		if errors.Is(err, payment.ErrInvalidSignature) {
+			return c.JSON(http.StatusUnauthorized, response.ErrorResponse{
+				Status:  "error",
+				Message: "Invalid webhook signature",
+				Code:    response.ErrCodeUnauthorized,
+			})
+		}
+
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Status:  "error",
+			Message: "Invalid payment event",
+			Code:    response.ErrCodeValidation,
+		})
+	}
+
+	ctx := c.Request().Context()
+
+	req := &pb.PaymentEventRequest{
+		Payload:   payload,
+		Signature: signature,
+	}
+
+	res, err := h.client.HandlePaymentEvent(ctx, req)
+	if err != nil {
+		h.logger.Debug("Failed to process payment event", zap.Error(err))
+		return grpcErrorResponse(c, err)
+	}
+
+	so := h.mapping.ToApiResponseTransaction(res)
+
+	return c.JSON(http.StatusOK, so)
+}
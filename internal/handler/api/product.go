@@ -1,6 +1,7 @@
 package api
 
 import (
+	"ecommerce/internal/domain/requests"
 	"ecommerce/internal/domain/response"
 	response_api "ecommerce/internal/mapper/response/api"
 	"ecommerce/internal/pb"
@@ -36,6 +37,8 @@ func NewHandlerProduct(
 	routercategory := router.Group("/api/product")
 
 	routercategory.GET("", productHandler.FindAllProduct)
+	routercategory.GET("/me", productHandler.FindMe)
+	routercategory.GET("/search", productHandler.Search)
 	routercategory.GET("/:id", productHandler.FindById)
 	routercategory.GET("/merchant/:merchant_id", productHandler.FindByMerchant)
 	routercategory.GET("/category/:category_name", productHandler.FindByCategory)
@@ -65,7 +68,15 @@ func NewHandlerProduct(
 // @Param page query int false "Page number" default(1)
 // @Param page_size query int false "Number of items per page" default(10)
 // @Param search query string false "Search query"
+// @Param cursor query string false "Keyset cursor; empty for the first page"
+// @Param min_price query int false "Minimum price"
+// @Param max_price query int false "Maximum price"
+// @Param min_rating query number false "Minimum rating, 0 to 5"
+// @Param brand query string false "Brand"
+// @Param in_stock query bool false "Only products in stock"
+// @Param sort query string false "Sort order" Enums(newest, price_asc, price_desc, rating, best_selling)
 // @Success 200 {object} response.ApiResponsePaginationProduct "List of products"
+// @Failure 400 {object} response.ErrorResponse "Invalid product filter"
 // @Failure 500 {object} response.ErrorResponse "Failed to retrieve product data"
 // @Router /api/product [get]
 func (h *productHandleApi) FindAllProduct(c echo.Context) error {
@@ -81,22 +92,35 @@ func (h *productHandleApi) FindAllProduct(c echo.Context) error {
 
 	search := c.QueryParam("search")
 
+	filter, err := parseProductFilter(c)
+	if err != nil {
+		h.logger.Debug("Invalid product filter", zap.Error(err))
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Status:  "error",
+			Message: "Invalid product filter",
+			Code:    response.ErrCodeValidation,
+		})
+	}
+
 	ctx := c.Request().Context()
 
 	req := &pb.FindAllProductRequest{
 		Page:     int32(page),
 		PageSize: int32(pageSize),
 		Search:   search,
+		Filter:   filter,
+	}
+
+	if c.QueryParams().Has("cursor") {
+		cursor := c.QueryParam("cursor")
+		req.Cursor = &cursor
 	}
 
 	res, err := h.client.FindAll(ctx, req)
 
 	if err != nil {
 		h.logger.Debug("Failed to retrieve product data", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to retrieve product data: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponsePaginationProduct(res)
@@ -114,7 +138,14 @@ func (h *productHandleApi) FindAllProduct(c echo.Context) error {
 // @Param page query int false "Page number" default(1)
 // @Param page_size query int false "Number of items per page" default(10)
 // @Param search query string false "Search query"
+// @Param min_price query int false "Minimum price"
+// @Param max_price query int false "Maximum price"
+// @Param min_rating query number false "Minimum rating, 0 to 5"
+// @Param brand query string false "Brand"
+// @Param in_stock query bool false "Only products in stock"
+// @Param sort query string false "Sort order" Enums(newest, price_asc, price_desc, rating, best_selling)
 // @Success 200 {object} response.ApiResponsePaginationProduct "List of products"
+// @Failure 400 {object} response.ErrorResponse "Invalid product filter"
 // @Failure 500 {object} response.ErrorResponse "Failed to retrieve product data"
 // @Router /api/product/merchant [get]
 func (h *productHandleApi) FindByMerchant(c echo.Context) error {
@@ -124,6 +155,7 @@ func (h *productHandleApi) FindByMerchant(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid merchant ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -139,6 +171,16 @@ func (h *productHandleApi) FindByMerchant(c echo.Context) error {
 
 	search := c.QueryParam("search")
 
+	filter, err := parseProductFilter(c)
+	if err != nil {
+		h.logger.Debug("Invalid product filter", zap.Error(err))
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Status:  "error",
+			Message: "Invalid product filter",
+			Code:    response.ErrCodeValidation,
+		})
+	}
+
 	ctx := c.Request().Context()
 
 	req := &pb.FindAllProductMerchantRequest{
@@ -146,15 +188,56 @@ func (h *productHandleApi) FindByMerchant(c echo.Context) error {
 		Page:       int32(page),
 		PageSize:   int32(pageSize),
 		Search:     search,
+		Filter:     filter,
 	}
 
 	res, err := h.client.FindByMerchant(ctx, req)
 	if err != nil {
 		h.logger.Debug("Failed to retrieve product data by merchant", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to retrieve product data: ",
-		})
+		return grpcErrorResponse(c, err)
+	}
+
+	so := h.mapping.ToApiResponsePaginationProduct(res)
+	return c.JSON(http.StatusOK, so)
+}
+
+// @Security Bearer
+// @Summary Find products of the current user
+// @Tags Product
+// @Description Retrieve the products of every merchant owned by the authenticated user
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Number of items per page" default(10)
+// @Param search query string false "Search query"
+// @Success 200 {object} response.ApiResponsePaginationProduct "List of products"
+// @Failure 500 {object} response.ErrorResponse "Failed to retrieve product data"
+// @Router /api/product/me [get]
+func (h *productHandleApi) FindMe(c echo.Context) error {
+	page, err := strconv.Atoi(c.QueryParam("page"))
+	if err != nil || page <= 0 {
+		page = 1
+	}
+
+	pageSize, err := strconv.Atoi(c.QueryParam("page_size"))
+	if err != nil || pageSize <= 0 {
+		pageSize = 10
+	}
+
+	search := c.QueryParam("search")
+
+	ctx := c.Request().Context()
+
+	req := &pb.FindAllProductRequest{
+		Page:     int32(page),
+		PageSize: int32(pageSize),
+		Search:   search,
+	}
+
+	res, err := h.client.FindMe(ctx, req)
+	if err != nil {
+		h.logger.Debug("Failed to retrieve product data by user", zap.Error(err))
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponsePaginationProduct(res)
@@ -171,7 +254,14 @@ func (h *productHandleApi) FindByMerchant(c echo.Context) error {
 // @Param page query int false "Page number" default(1)
 // @Param page_size query int false "Number of items per page" default(10)
 // @Param search query string false "Search query"
+// @Param min_price query int false "Minimum price"
+// @Param max_price query int false "Maximum price"
+// @Param min_rating query number false "Minimum rating, 0 to 5"
+// @Param brand query string false "Brand"
+// @Param in_stock query bool false "Only products in stock"
+// @Param sort query string false "Sort order" Enums(newest, price_asc, price_desc, rating, best_selling)
 // @Success 200 {object} response.ApiResponsePaginationProduct "List of products"
+// @Failure 400 {object} response.ErrorResponse "Invalid product filter"
 // @Failure 500 {object} response.ErrorResponse "Failed to retrieve product data"
 // @Router /api/product/category [get]
 func (h *productHandleApi) FindByCategory(c echo.Context) error {
@@ -180,6 +270,7 @@ func (h *productHandleApi) FindByCategory(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Category name is required",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -195,6 +286,16 @@ func (h *productHandleApi) FindByCategory(c echo.Context) error {
 
 	search := c.QueryParam("search")
 
+	filter, err := parseProductFilter(c)
+	if err != nil {
+		h.logger.Debug("Invalid product filter", zap.Error(err))
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Status:  "error",
+			Message: "Invalid product filter",
+			Code:    response.ErrCodeValidation,
+		})
+	}
+
 	ctx := c.Request().Context()
 
 	req := &pb.FindAllProductCategoryRequest{
@@ -202,18 +303,93 @@ func (h *productHandleApi) FindByCategory(c echo.Context) error {
 		Page:         int32(page),
 		PageSize:     int32(pageSize),
 		Search:       search,
+		Filter:       filter,
 	}
 
 	res, err := h.client.FindByCategory(ctx, req)
 	if err != nil {
 		h.logger.Debug("Failed to retrieve product data by category", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
+		return grpcErrorResponse(c, err)
+	}
+
+	so := h.mapping.ToApiResponsePaginationProduct(res)
+	return c.JSON(http.StatusOK, so)
+}
+
+// @Security Bearer
+// @Summary Search products
+// @Tags Product
+// @Description Full-text search over product name, brand, description and slug, ranked by relevance, with facet counts
+// @Accept json
+// @Produce json
+// @Param q query string true "Search query"
+// @Param category_id query int false "Category ID"
+// @Param merchant_id query int false "Merchant ID"
+// @Param brand query string false "Brand"
+// @Param min_price query int false "Minimum price"
+// @Param max_price query int false "Maximum price"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Number of items per page" default(10)
+// @Success 200 {object} response.ApiResponseProductSearch "Matching products and facet counts"
+// @Failure 400 {object} response.ErrorResponse "Invalid search parameters"
+// @Failure 500 {object} response.ErrorResponse "Failed to search products"
+// @Router /api/product/search [get]
+func (h *productHandleApi) Search(c echo.Context) error {
+	page, err := strconv.Atoi(c.QueryParam("page"))
+	if err != nil || page <= 0 {
+		page = 1
+	}
+
+	pageSize, err := strconv.Atoi(c.QueryParam("page_size"))
+	if err != nil || pageSize <= 0 {
+		pageSize = 10
+	}
+
+	categoryID, _ := strconv.Atoi(c.QueryParam("category_id"))
+	merchantID, _ := strconv.Atoi(c.QueryParam("merchant_id"))
+	minPrice, _ := strconv.ParseInt(c.QueryParam("min_price"), 10, 64)
+	maxPrice, _ := strconv.ParseInt(c.QueryParam("max_price"), 10, 64)
+
+	body := requests.SearchProductRequest{
+		Query:      c.QueryParam("q"),
+		CategoryID: categoryID,
+		MerchantID: merchantID,
+		Brand:      c.QueryParam("brand"),
+		MinPrice:   minPrice,
+		MaxPrice:   maxPrice,
+		Page:       page,
+		PageSize:   pageSize,
+	}
+
+	if err := body.Validate(); err != nil {
+		h.logger.Debug("Validation error", zap.Error(err))
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
-			Message: "Failed to retrieve product data: ",
+			Message: "Validation error",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
-	so := h.mapping.ToApiResponsePaginationProduct(res)
+	ctx := c.Request().Context()
+
+	req := &pb.SearchProductRequest{
+		Query:      body.Query,
+		CategoryId: int32(body.CategoryID),
+		MerchantId: int32(body.MerchantID),
+		Brand:      body.Brand,
+		MinPrice:   body.MinPrice,
+		MaxPrice:   body.MaxPrice,
+		Page:       int32(body.Page),
+		PageSize:   int32(body.PageSize),
+	}
+
+	res, err := h.client.Search(ctx, req)
+	if err != nil {
+		h.logger.Debug("Failed to search products", zap.Error(err))
+		return grpcErrorResponse(c, err)
+	}
+
+	so := h.mapping.ToApiResponseProductSearch(res)
 	return c.JSON(http.StatusOK, so)
 }
 
@@ -236,6 +412,7 @@ func (h *productHandleApi) FindById(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid product ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -249,10 +426,7 @@ func (h *productHandleApi) FindById(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to retrieve product data", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to retrieve product data: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseProduct(res)
@@ -294,10 +468,7 @@ func (h *productHandleApi) FindByActive(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to retrieve product data", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to retrieve product data: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponsePaginationProductDeleteAt(res)
@@ -339,10 +510,7 @@ func (h *productHandleApi) FindByTrashed(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to retrieve product data", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to retrieve product data: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponsePaginationProductDeleteAt(res)
@@ -379,6 +547,7 @@ func (h *productHandleApi) Create(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid merchant ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -387,16 +556,18 @@ func (h *productHandleApi) Create(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid category ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
 	name := c.FormValue("name")
 	description := c.FormValue("description")
-	price, err := strconv.Atoi(c.FormValue("price"))
+	price, err := strconv.ParseInt(c.FormValue("price"), 10, 64)
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid price",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -405,6 +576,7 @@ func (h *productHandleApi) Create(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid count in stock",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -414,6 +586,7 @@ func (h *productHandleApi) Create(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid weight",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -422,6 +595,7 @@ func (h *productHandleApi) Create(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid rating",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -434,6 +608,7 @@ func (h *productHandleApi) Create(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid image file",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -461,7 +636,7 @@ func (h *productHandleApi) Create(c echo.Context) error {
 		CategoryId:   int32(categoryID),
 		Name:         name,
 		Description:  description,
-		Price:        int32(price),
+		Price:        price,
 		CountInStock: int32(countInStock),
 		Brand:        brand,
 		Weight:       int32(weight),
@@ -475,10 +650,7 @@ func (h *productHandleApi) Create(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to create product", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to create product: " + err.Error(),
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	return c.JSON(http.StatusOK, res)
@@ -514,6 +686,7 @@ func (h *productHandleApi) Update(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid product ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -522,6 +695,7 @@ func (h *productHandleApi) Update(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid merchant ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -530,16 +704,18 @@ func (h *productHandleApi) Update(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid category ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
 	name := c.FormValue("name")
 	description := c.FormValue("description")
-	price, err := strconv.Atoi(c.FormValue("price"))
+	price, err := strconv.ParseInt(c.FormValue("price"), 10, 64)
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid price",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -548,6 +724,7 @@ func (h *productHandleApi) Update(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid count in stock",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -557,6 +734,7 @@ func (h *productHandleApi) Update(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid weight",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -565,6 +743,7 @@ func (h *productHandleApi) Update(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid rating",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -600,7 +779,7 @@ func (h *productHandleApi) Update(c echo.Context) error {
 		CategoryId:   int32(categoryID),
 		Name:         name,
 		Description:  description,
-		Price:        int32(price),
+		Price:        price,
 		CountInStock: int32(countInStock),
 		Brand:        brand,
 		Weight:       int32(weight),
@@ -614,10 +793,7 @@ func (h *productHandleApi) Update(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to update product", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to update product: " + err.Error(),
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	return c.JSON(http.StatusOK, res)
@@ -643,6 +819,7 @@ func (h *productHandleApi) TrashedProduct(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid product ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -656,10 +833,7 @@ func (h *productHandleApi) TrashedProduct(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to retrieve trashed product", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to retrieve trashed product: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponsesProductDeleteAt(res)
@@ -687,6 +861,7 @@ func (h *productHandleApi) RestoreProduct(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid product ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -700,10 +875,7 @@ func (h *productHandleApi) RestoreProduct(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to restore product", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to restore product: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponsesProductDeleteAt(res)
@@ -731,6 +903,7 @@ func (h *productHandleApi) DeleteProductPermanent(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid product ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -744,10 +917,7 @@ func (h *productHandleApi) DeleteProductPermanent(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to delete product", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to delete product: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseProductDelete(res)
@@ -771,11 +941,7 @@ func (h *productHandleApi) RestoreAllProduct(c echo.Context) error {
 	res, err := h.client.RestoreAllProduct(ctx, &emptypb.Empty{})
 
 	if err != nil {
-		h.logger.Error("Failed to restore all products", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to restore all products",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseProductAll(res)
@@ -801,11 +967,7 @@ func (h *productHandleApi) DeleteAllProductPermanent(c echo.Context) error {
 	res, err := h.client.DeleteAllProductPermanent(ctx, &emptypb.Empty{})
 
 	if err != nil {
-		h.logger.Error("Failed to permanently delete all products", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to permanently delete all products",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseProductAll(res)
@@ -814,3 +976,52 @@ func (h *productHandleApi) DeleteAllProductPermanent(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, so)
 }
+
+// parseProductFilter reads the listing filter and sort query parameters and
+// validates them before they are forwarded to the product service.
+func parseProductFilter(c echo.Context) (*pb.ProductFilter, error) {
+	var (
+		filter requests.ProductFilter
+		err    error
+	)
+
+	if v := c.QueryParam("min_price"); v != "" {
+		if filter.MinPrice, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return nil, err
+		}
+	}
+
+	if v := c.QueryParam("max_price"); v != "" {
+		if filter.MaxPrice, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return nil, err
+		}
+	}
+
+	if v := c.QueryParam("min_rating"); v != "" {
+		if filter.MinRating, err = strconv.ParseFloat(v, 64); err != nil {
+			return nil, err
+		}
+	}
+
+	if v := c.QueryParam("in_stock"); v != "" {
+		if filter.InStock, err = strconv.ParseBool(v); err != nil {
+			return nil, err
+		}
+	}
+
+	filter.Brand = c.QueryParam("brand")
+	filter.Sort = c.QueryParam("sort")
+
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &pb.ProductFilter{
+		MinPrice:  filter.MinPrice,
+		MaxPrice:  filter.MaxPrice,
+		MinRating: filter.MinRating,
+		Brand:     filter.Brand,
+		InStock:   filter.InStock,
+		Sort:      filter.Sort,
+	}, nil
+}
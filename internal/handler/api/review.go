@@ -32,7 +32,7 @@ func NewHandlerReview(
 		mapping: mapping,
 	}
 
-	routercategory := router.Group("/api/category")
+	routercategory := router.Group("/api/review")
 
 	routercategory.GET("", reviewHandler.FindAll)
 	routercategory.GET("/product/:id", reviewHandler.FindByProduct)
@@ -61,6 +61,7 @@ func NewHandlerReview(
 // @Param page query int false "Page number" default(1)
 // @Param page_size query int false "Number of items per page" default(10)
 // @Param search query string false "Search query"
+// @Param cursor query string false "Keyset cursor; empty for the first page"
 // @Success 200 {object} response.ApiResponsePaginationReview "List of review"
 // @Failure 500 {object} response.ErrorResponse "Failed to retrieve review data"
 // @Router /api/review [get]
@@ -85,14 +86,16 @@ func (h *reviewHandleApi) FindAll(c echo.Context) error {
 		Search:   search,
 	}
 
+	if c.QueryParams().Has("cursor") {
+		cursor := c.QueryParam("cursor")
+		req.Cursor = &cursor
+	}
+
 	res, err := h.client.FindAll(ctx, req)
 
 	if err != nil {
 		h.logger.Debug("Failed to retrieve review data", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to retrieve review data: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponsePaginationReview(res)
@@ -120,6 +123,7 @@ func (h *reviewHandleApi) FindByProduct(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid product ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -146,10 +150,7 @@ func (h *reviewHandleApi) FindByProduct(c echo.Context) error {
 	res, err := h.client.FindByProduct(ctx, req)
 	if err != nil {
 		h.logger.Debug("Failed to retrieve review data", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to retrieve review data",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponsePaginationReview(res)
@@ -190,10 +191,7 @@ func (h *reviewHandleApi) FindByActive(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to retrieve category data", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to retrieve category data: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponsePaginationReviewDeleteAt(res)
@@ -236,10 +234,7 @@ func (h *reviewHandleApi) FindByTrashed(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to retrieve review data", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to retrieve review data: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponsePaginationReviewDeleteAt(res)
@@ -253,13 +248,13 @@ func (h *reviewHandleApi) Create(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid request body",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
 	ctx := c.Request().Context()
 
 	grpcReq := &pb.CreateReviewRequest{
-		UserId:    int32(req.UserID),
 		ProductId: int32(req.ProductID),
 		Comment:   req.Comment,
 		Rating:    int32(req.Rating),
@@ -268,10 +263,7 @@ func (h *reviewHandleApi) Create(c echo.Context) error {
 	res, err := h.client.Create(ctx, grpcReq)
 	if err != nil {
 		h.logger.Debug("Failed to create review", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to create review: " + err.Error(),
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	return c.JSON(http.StatusOK, res)
@@ -283,6 +275,7 @@ func (h *reviewHandleApi) Update(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid request body",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -298,10 +291,7 @@ func (h *reviewHandleApi) Update(c echo.Context) error {
 	res, err := h.client.Update(ctx, grpcReq)
 	if err != nil {
 		h.logger.Debug("Failed to update review", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to update review: " + err.Error(),
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	return c.JSON(http.StatusOK, res)
@@ -327,6 +317,7 @@ func (h *reviewHandleApi) TrashedReview(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid review ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -340,10 +331,7 @@ func (h *reviewHandleApi) TrashedReview(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to trashed review", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to trashed review: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseReviewDeleteAt(res)
@@ -371,6 +359,7 @@ func (h *reviewHandleApi) RestoreReview(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid review ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -384,10 +373,7 @@ func (h *reviewHandleApi) RestoreReview(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to restore review", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to restore review: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseReviewDeleteAt(res)
@@ -415,6 +401,7 @@ func (h *reviewHandleApi) DeleteReviewPermanent(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid review ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -428,10 +415,7 @@ func (h *reviewHandleApi) DeleteReviewPermanent(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to delete review", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to delete review: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseReviewDelete(res)
@@ -457,11 +441,7 @@ func (h *reviewHandleApi) RestoreAllReview(c echo.Context) error {
 	res, err := h.client.RestoreAllReview(ctx, &emptypb.Empty{})
 
 	if err != nil {
-		h.logger.Error("Failed to restore all review", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to permanently restore all review",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseReviewAll(res)
@@ -489,12 +469,7 @@ func (h *reviewHandleApi) DeleteAllReviewPermanent(c echo.Context) error {
 	res, err := h.client.DeleteAllReviewPermanent(ctx, &emptypb.Empty{})
 
 	if err != nil {
-		h.logger.Error("Failed to permanently delete all category", zap.Error(err))
-
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to permanently delete all category",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseReviewAll(res)
@@ -5,7 +5,6 @@ import (
 	response_api "ecommerce/internal/mapper/response/api"
 	"ecommerce/internal/pb"
 	"ecommerce/pkg/logger"
-	"errors"
 	"net/http"
 	"strconv"
 
@@ -82,18 +81,8 @@ func (h *roleHandleApi) FindAll(c echo.Context) error {
 
 	res, err := h.role.FindAllRole(ctx, req)
 	if err != nil {
-		if errors.Is(err, echo.ErrUnauthorized) {
-			return c.JSON(http.StatusUnauthorized, response.ErrorResponse{
-				Status:  "error",
-				Message: "Unauthorized",
-			})
-		}
-
 		h.logger.Debug("Failed to fetch role records", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch role records",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponsePaginationRole(res)
@@ -119,6 +108,7 @@ func (h *roleHandleApi) FindById(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid role ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -131,10 +121,7 @@ func (h *roleHandleApi) FindById(c echo.Context) error {
 	res, err := h.role.FindByIdRole(ctx, req)
 	if err != nil {
 		h.logger.Debug("Failed to fetch role", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch role",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseRole(res)
@@ -180,10 +167,7 @@ func (h *roleHandleApi) FindByActive(c echo.Context) error {
 	res, err := h.role.FindByActive(ctx, req)
 	if err != nil {
 		h.logger.Debug("Failed to fetch active roles", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch active roles",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponsePaginationRoleDeleteAt(res)
@@ -229,10 +213,7 @@ func (h *roleHandleApi) FindByTrashed(c echo.Context) error {
 	res, err := h.role.FindByTrashed(ctx, req)
 	if err != nil {
 		h.logger.Debug("Failed to fetch trashed roles", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch trashed roles",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponsePaginationRoleDeleteAt(res)
@@ -258,6 +239,7 @@ func (h *roleHandleApi) FindByUserId(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid user ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -270,10 +252,7 @@ func (h *roleHandleApi) FindByUserId(c echo.Context) error {
 	res, err := h.role.FindByUserId(ctx, req)
 	if err != nil {
 		h.logger.Debug("Failed to fetch role by user ID", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch role by user ID",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponsesRole(res)
@@ -299,6 +278,7 @@ func (h *roleHandleApi) Create(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid request body",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -307,10 +287,7 @@ func (h *roleHandleApi) Create(c echo.Context) error {
 	res, err := h.role.CreateRole(ctx, &req)
 	if err != nil {
 		h.logger.Debug("Failed to create role", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to create role",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseRole(res)
@@ -337,6 +314,7 @@ func (h *roleHandleApi) Update(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid role ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -345,6 +323,7 @@ func (h *roleHandleApi) Update(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid request body",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -355,10 +334,7 @@ func (h *roleHandleApi) Update(c echo.Context) error {
 	res, err := h.role.UpdateRole(ctx, &req)
 	if err != nil {
 		h.logger.Debug("Failed to update role", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to update role",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseRole(res)
@@ -384,6 +360,7 @@ func (h *roleHandleApi) Trashed(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid role ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -396,10 +373,7 @@ func (h *roleHandleApi) Trashed(c echo.Context) error {
 	res, err := h.role.TrashedRole(ctx, req)
 	if err != nil {
 		h.logger.Debug("Failed to trash role", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to trash role",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseRole(res)
@@ -425,6 +399,7 @@ func (h *roleHandleApi) Restore(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid role ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -437,10 +412,7 @@ func (h *roleHandleApi) Restore(c echo.Context) error {
 	res, err := h.role.RestoreRole(ctx, req)
 	if err != nil {
 		h.logger.Debug("Failed to restore role", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to restore role",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseRole(res)
@@ -466,6 +438,7 @@ func (h *roleHandleApi) DeletePermanent(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid role ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -478,10 +451,7 @@ func (h *roleHandleApi) DeletePermanent(c echo.Context) error {
 	res, err := h.role.DeleteRolePermanent(ctx, req)
 	if err != nil {
 		h.logger.Debug("Failed to delete role permanently", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to delete role permanently",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseRoleDelete(res)
@@ -505,10 +475,7 @@ func (h *roleHandleApi) RestoreAll(c echo.Context) error {
 	res, err := h.role.RestoreAllRole(ctx, &emptypb.Empty{})
 	if err != nil {
 		h.logger.Debug("Failed to restore all roles", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to restore all roles",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseRoleAll(res)
@@ -532,10 +499,7 @@ func (h *roleHandleApi) DeleteAllPermanent(c echo.Context) error {
 	res, err := h.role.DeleteAllRolePermanent(ctx, &emptypb.Empty{})
 	if err != nil {
 		h.logger.Debug("Failed to delete all roles permanently", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to delete all roles permanently",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseRoleAll(res)
@@ -87,10 +87,7 @@ func (h *shippingAddressHandleApi) FindAllShipping(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to retrieve shipping-address data", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to retrieve shipping-address data: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponsePaginationShippingAddress(res)
@@ -117,6 +114,7 @@ func (h *shippingAddressHandleApi) FindById(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid shipping address ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -130,10 +128,7 @@ func (h *shippingAddressHandleApi) FindById(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to retrieve shipping address data", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to retrieve shipping address data: " + err.Error(),
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseShippingAddress(res)
@@ -160,6 +155,7 @@ func (h *shippingAddressHandleApi) FindByOrder(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid order ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -173,10 +169,7 @@ func (h *shippingAddressHandleApi) FindByOrder(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to retrieve shipping address data", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to retrieve shipping address data: " + err.Error(),
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseShippingAddress(res)
@@ -218,10 +211,7 @@ func (h *shippingAddressHandleApi) FindByActive(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to retrieve shipping-address data", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to retrieve shipping-address data: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponsePaginationShippingAddressDeleteAt(res)
@@ -264,10 +254,7 @@ func (h *shippingAddressHandleApi) FindByTrashed(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to retrieve category data", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to retrieve category data: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponsePaginationShippingAddressDeleteAt(res)
@@ -295,6 +282,7 @@ func (h *shippingAddressHandleApi) TrashedShippingAddress(c echo.Context) error
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid shipping address ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -308,10 +296,7 @@ func (h *shippingAddressHandleApi) TrashedShippingAddress(c echo.Context) error
 
 	if err != nil {
 		h.logger.Debug("Failed to retrieve trashed shipping address", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to retrieve trashed shipping address: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseShippingAddressDeleteAt(res)
@@ -339,6 +324,7 @@ func (h *shippingAddressHandleApi) RestoreShippingAddress(c echo.Context) error
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid shipping address ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -352,10 +338,7 @@ func (h *shippingAddressHandleApi) RestoreShippingAddress(c echo.Context) error
 
 	if err != nil {
 		h.logger.Debug("Failed to restore shipping address", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to restore shipping address: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseShippingAddressDeleteAt(res)
@@ -383,6 +366,7 @@ func (h *shippingAddressHandleApi) DeleteShippingAddressPermanent(c echo.Context
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid shipping address ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -396,10 +380,7 @@ func (h *shippingAddressHandleApi) DeleteShippingAddressPermanent(c echo.Context
 
 	if err != nil {
 		h.logger.Debug("Failed to delete shipping address", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to delete shipping address: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseShippingAddressDelete(res)
@@ -423,11 +404,7 @@ func (h *shippingAddressHandleApi) RestoreAllShippingAddress(c echo.Context) err
 	res, err := h.client.RestoreAllShipping(ctx, &emptypb.Empty{})
 
 	if err != nil {
-		h.logger.Error("Failed to restore all shipping addresses", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to restore all shipping addresses",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseShippingAddressAll(res)
@@ -453,11 +430,7 @@ func (h *shippingAddressHandleApi) DeleteAllShippingAddressPermanent(c echo.Cont
 	res, err := h.client.DeleteAllShippingPermanent(ctx, &emptypb.Empty{})
 
 	if err != nil {
-		h.logger.Error("Failed to permanently delete all shipping addresses", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to permanently delete all shipping addresses",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseShippingAddressAll(res)
@@ -90,10 +90,7 @@ func (h *sliderHandleApi) FindAllSlider(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to retrieve slider data", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to retrieve slider data: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponsePaginationSlider(res)
@@ -138,10 +135,7 @@ func (h *sliderHandleApi) FindByActive(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to retrieve slider data", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to retrieve slider data: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponsePaginationSliderDeleteAt(res)
@@ -187,10 +181,7 @@ func (h *sliderHandleApi) FindByTrashed(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to retrieve slider data", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to retrieve slider data: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponsePaginationSliderDeleteAt(res)
@@ -220,6 +211,7 @@ func (h *sliderHandleApi) Create(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid image file",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -251,10 +243,7 @@ func (h *sliderHandleApi) Create(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to create slider", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to create slider: " + err.Error(),
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	return c.JSON(http.StatusOK, res)
@@ -280,6 +269,7 @@ func (h *sliderHandleApi) Update(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid slider ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -318,10 +308,7 @@ func (h *sliderHandleApi) Update(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to update slider", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to update slider: " + err.Error(),
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	return c.JSON(http.StatusOK, res)
@@ -347,6 +334,7 @@ func (h *sliderHandleApi) TrashedSlider(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid slider ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -360,10 +348,7 @@ func (h *sliderHandleApi) TrashedSlider(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to trashed slider", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to trashed slider: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseSliderDeleteAt(res)
@@ -391,6 +376,7 @@ func (h *sliderHandleApi) RestoreSlider(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid slider ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -404,10 +390,7 @@ func (h *sliderHandleApi) RestoreSlider(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to restore slider", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to restore slider: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseSliderDeleteAt(res)
@@ -435,6 +418,7 @@ func (h *sliderHandleApi) DeleteSliderPermanent(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid slider ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -448,10 +432,7 @@ func (h *sliderHandleApi) DeleteSliderPermanent(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to delete slider", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to delete slider: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseSliderDelete(res)
@@ -477,11 +458,7 @@ func (h *sliderHandleApi) RestoreAllSlider(c echo.Context) error {
 	res, err := h.client.RestoreAllSlider(ctx, &emptypb.Empty{})
 
 	if err != nil {
-		h.logger.Error("Failed to restore all slider", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to permanently restore all slider",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseSliderAll(res)
@@ -509,12 +486,7 @@ func (h *sliderHandleApi) DeleteAllSliderPermanent(c echo.Context) error {
 	res, err := h.client.DeleteAllSliderPermanent(ctx, &emptypb.Empty{})
 
 	if err != nil {
-		h.logger.Error("Failed to permanently delete all slider", zap.Error(err))
-
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to permanently delete all slider",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseSliderAll(res)
@@ -11,6 +11,8 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -42,6 +44,7 @@ func NewHandlerTransaction(
 
 	routercategory.POST("/create", transactionHandle.Create)
 	routercategory.POST("/update/:id", transactionHandle.Update)
+	routercategory.POST("/refund/:id", transactionHandle.Refund)
 
 	routercategory.POST("/trashed/:id", transactionHandle.TrashedTransaction)
 	routercategory.POST("/restore/:id", transactionHandle.RestoreTransaction)
@@ -62,6 +65,7 @@ func NewHandlerTransaction(
 // @Param page query int false "Page number" default(1)
 // @Param page_size query int false "Number of items per page" default(10)
 // @Param search query string false "Search query"
+// @Param cursor query string false "Keyset cursor; empty for the first page"
 // @Success 200 {object} response.ApiResponsePaginationTransaction "List of transactions"
 // @Failure 500 {object} response.ErrorResponse "Failed to retrieve transaction data"
 // @Router /api/transaction [get]
@@ -86,14 +90,16 @@ func (h *transactionHandleApi) FindAllTransaction(c echo.Context) error {
 		Search:   search,
 	}
 
+	if c.QueryParams().Has("cursor") {
+		cursor := c.QueryParam("cursor")
+		req.Cursor = &cursor
+	}
+
 	res, err := h.client.FindAll(ctx, req)
 
 	if err != nil {
 		h.logger.Debug("Failed to retrieve transaction data", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to retrieve transaction data: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponsePaginationTransaction(res)
@@ -122,6 +128,7 @@ func (h *transactionHandleApi) FindByMerchant(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid merchant ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -150,10 +157,7 @@ func (h *transactionHandleApi) FindByMerchant(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to retrieve transaction data", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to retrieve transaction data",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponsePaginationTransaction(res)
@@ -180,6 +184,7 @@ func (h *transactionHandleApi) FindById(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid transaction ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -193,10 +198,7 @@ func (h *transactionHandleApi) FindById(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to retrieve transaction data", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to retrieve transaction data: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseTransaction(res)
@@ -238,10 +240,7 @@ func (h *transactionHandleApi) FindByActive(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to retrieve transaction data", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to retrieve transaction data: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponsePaginationTransactionDeleteAt(res)
@@ -284,10 +283,7 @@ func (h *transactionHandleApi) FindByTrashed(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to retrieve transaction data", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to retrieve transaction data: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponsePaginationTransactionDeleteAt(res)
@@ -301,6 +297,7 @@ func (h *transactionHandleApi) FindByTrashed(c echo.Context) error {
 // @Description Create a new transaction record
 // @Accept json
 // @Produce json
+// @Param Idempotency-Key header string false "Key that makes retries of this request return the first result"
 // @Param request body requests.CreateTransactionRequest true "Transaction details"
 // @Success 200 {object} response.ApiResponseTransaction "Successfully created transaction"
 // @Failure 400 {object} response.ErrorResponse "Invalid request body or validation error"
@@ -312,6 +309,7 @@ func (h *transactionHandleApi) Create(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid request body",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -319,6 +317,7 @@ func (h *transactionHandleApi) Create(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Validation error",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -327,20 +326,21 @@ func (h *transactionHandleApi) Create(c echo.Context) error {
 		OrderId:       int32(req.OrderID),
 		MerchantId:    int32(req.MerchantID),
 		PaymentMethod: req.PaymentMethod,
-		Amount:        int32(req.Amount),
-		ChangeAmount:  int32(req.ChangeAmount),
+		Amount:        req.Amount,
+		ChangeAmount:  req.ChangeAmount,
 		PaymentStatus: req.PaymentStatus,
 	}
 
-	res, err := h.client.Create(ctx, grpcReq)
+	var header metadata.MD
+
+	res, err := h.client.Create(ctx, grpcReq, grpc.Header(&header))
 	if err != nil {
 		h.logger.Debug("Failed to create transaction", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to create transaction: " + err.Error(),
-		})
+		return grpcErrorResponse(c, err)
 	}
 
+	markReplayed(c, header)
+
 	so := h.mapping.ToApiResponseTransaction(res)
 
 	return c.JSON(http.StatusOK, so)
@@ -363,6 +363,7 @@ func (h *transactionHandleApi) Update(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid request body",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -370,6 +371,7 @@ func (h *transactionHandleApi) Update(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Validation error",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -379,20 +381,84 @@ func (h *transactionHandleApi) Update(c echo.Context) error {
 		OrderId:       int32(req.OrderID),
 		MerchantId:    int32(req.MerchantID),
 		PaymentMethod: req.PaymentMethod,
-		Amount:        int32(req.Amount),
-		ChangeAmount:  int32(req.ChangeAmount),
-		PaymentStatus: req.PaymentStatus,
+		Amount:        req.Amount,
+		ChangeAmount:  req.ChangeAmount,
 	}
 
 	res, err := h.client.Update(ctx, grpcReq)
 	if err != nil {
 		h.logger.Debug("Failed to update transaction", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
+		return grpcErrorResponse(c, err)
+	}
+
+	so := h.mapping.ToApiResponseTransaction(res)
+
+	return c.JSON(http.StatusOK, so)
+}
+
+// @Security Bearer
+// @Summary Refund a transaction
+// @Tags Transaction
+// @Description Refund a paid transaction in full, or only the listed order item quantities. Refunded items are returned to stock.
+// @Accept json
+// @Produce json
+// @Param id path int true "Transaction ID"
+// @Param request body requests.RefundTransactionRequest true "Refund reason and items; omit items for a full refund"
+// @Success 200 {object} response.ApiResponseTransaction "Successfully refunded transaction"
+// @Failure 400 {object} response.ErrorResponse "Invalid request body or validation error"
+// @Failure 404 {object} response.ErrorResponse "Transaction not found"
+// @Failure 409 {object} response.ErrorResponse "Transaction cannot be refunded"
+// @Failure 500 {object} response.ErrorResponse "Failed to refund transaction"
+// @Router /api/transaction/refund/{id} [post]
+func (h *transactionHandleApi) Refund(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Debug("Invalid transaction ID", zap.Error(err))
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
-			Message: "Failed to update transaction: " + err.Error(),
+			Message: "Invalid transaction ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
+	var req requests.RefundTransactionRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Status:  "error",
+			Message: "Invalid request body",
+			Code:    response.ErrCodeValidation,
+		})
+	}
+
+	req.TransactionID = id
+
+	if err := req.Validate(); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Status:  "error",
+			Message: "Validation error",
+			Code:    response.ErrCodeValidation,
+		})
+	}
+
+	ctx := c.Request().Context()
+	grpcReq := &pb.RefundTransactionRequest{
+		TransactionId: int32(req.TransactionID),
+		Reason:        req.Reason,
+	}
+
+	for _, item := range req.Items {
+		grpcReq.Items = append(grpcReq.Items, &pb.RefundItemRequest{
+			OrderItemId: int32(item.OrderItemID),
+			Quantity:    int32(item.Quantity),
+		})
+	}
+
+	res, err := h.client.RefundTransaction(ctx, grpcReq)
+	if err != nil {
+		h.logger.Debug("Failed to refund transaction", zap.Error(err))
+		return grpcErrorResponse(c, err)
+	}
+
 	so := h.mapping.ToApiResponseTransaction(res)
 
 	return c.JSON(http.StatusOK, so)
@@ -418,6 +484,7 @@ func (h *transactionHandleApi) TrashedTransaction(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid transaction ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -431,10 +498,7 @@ func (h *transactionHandleApi) TrashedTransaction(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to retrieve trashed transaction", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to retrieve trashed transaction",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseTransactionDeleteAt(res)
@@ -462,6 +526,7 @@ func (h *transactionHandleApi) RestoreTransaction(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid transaction ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -475,10 +540,7 @@ func (h *transactionHandleApi) RestoreTransaction(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to restore transaction", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to restore transaction",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseTransactionDeleteAt(res)
@@ -506,6 +568,7 @@ func (h *transactionHandleApi) DeleteTransactionPermanent(c echo.Context) error
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid transaction ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -519,10 +582,7 @@ func (h *transactionHandleApi) DeleteTransactionPermanent(c echo.Context) error
 
 	if err != nil {
 		h.logger.Debug("Failed to delete transaction", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to delete transaction",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseTransactionDelete(res)
@@ -546,11 +606,7 @@ func (h *transactionHandleApi) RestoreAllTransaction(c echo.Context) error {
 	res, err := h.client.RestoreAllTransaction(ctx, &emptypb.Empty{})
 
 	if err != nil {
-		h.logger.Error("Failed to restore all transactions", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to restore all transactions",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseTransactionAll(res)
@@ -576,11 +632,7 @@ func (h *transactionHandleApi) DeleteAllTransactionPermanent(c echo.Context) err
 	res, err := h.client.DeleteAllTransactionPermanent(ctx, &emptypb.Empty{})
 
 	if err != nil {
-		h.logger.Error("Failed to permanently delete all transactions", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to permanently delete all transactions",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseTransactionAll(res)
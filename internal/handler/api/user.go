@@ -83,10 +83,7 @@ func (h *userHandleApi) FindAllUser(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to retrieve user data", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to retrieve user data: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponsePaginationUser(res)
@@ -113,6 +110,7 @@ func (h *userHandleApi) FindById(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid user ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -126,10 +124,7 @@ func (h *userHandleApi) FindById(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to retrieve user data", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to retrieve user data: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseUser(user)
@@ -171,10 +166,7 @@ func (h *userHandleApi) FindByActive(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to retrieve user data", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to retrieve user data: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponsePaginationUserDeleteAt(res)
@@ -217,10 +209,7 @@ func (h *userHandleApi) FindByTrashed(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to retrieve user data", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to retrieve user data: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponsePaginationUserDeleteAt(res)
@@ -248,6 +237,7 @@ func (h *userHandleApi) Create(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid request body",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -256,6 +246,7 @@ func (h *userHandleApi) Create(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Validation Error: " + err.Error(),
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -273,10 +264,7 @@ func (h *userHandleApi) Create(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to create user", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to create user: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseUser(res)
@@ -304,6 +292,7 @@ func (h *userHandleApi) Update(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid request body",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -312,6 +301,7 @@ func (h *userHandleApi) Update(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Validation Error: " + err.Error(),
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -330,10 +320,7 @@ func (h *userHandleApi) Update(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to update user", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to update user: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseUser(res)
@@ -361,6 +348,7 @@ func (h *userHandleApi) TrashedUser(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid user ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -374,10 +362,7 @@ func (h *userHandleApi) TrashedUser(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to trashed user", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to trashed user: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseUserDeleteAt(user)
@@ -405,6 +390,7 @@ func (h *userHandleApi) RestoreUser(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid user ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -418,10 +404,7 @@ func (h *userHandleApi) RestoreUser(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to restore user", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to restore user: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseUserDeleteAt(user)
@@ -449,6 +432,7 @@ func (h *userHandleApi) DeleteUserPermanent(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid user ID",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
@@ -462,10 +446,7 @@ func (h *userHandleApi) DeleteUserPermanent(c echo.Context) error {
 
 	if err != nil {
 		h.logger.Debug("Failed to delete user", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to delete user: ",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseUserDelete(user)
@@ -491,11 +472,7 @@ func (h *userHandleApi) RestoreAllUser(c echo.Context) error {
 	res, err := h.client.RestoreAllUser(ctx, &emptypb.Empty{})
 
 	if err != nil {
-		h.logger.Error("Failed to restore all user", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to permanently restore all user",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseUserAll(res)
@@ -523,12 +500,7 @@ func (h *userHandleApi) DeleteAllUserPermanent(c echo.Context) error {
 	res, err := h.client.DeleteAllUserPermanent(ctx, &emptypb.Empty{})
 
 	if err != nil {
-		h.logger.Error("Failed to permanently delete all user", zap.Error(err))
-
-		return c.JSON(http.StatusInternalServerError, response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to permanently delete all user",
-		})
+		return grpcErrorResponse(c, err)
 	}
 
 	so := h.mapping.ToApiResponseUserAll(res)
@@ -3,12 +3,13 @@ package gapi
 import (
 	"context"
 	"ecommerce/internal/domain/requests"
+	"ecommerce/internal/domain/response"
 	protomapper "ecommerce/internal/mapper/proto"
+	"ecommerce/internal/middlewares"
 	"ecommerce/internal/pb"
 	"ecommerce/internal/service"
 
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 type authHandleGrpc struct {
@@ -22,46 +23,214 @@ func NewAuthHandleGrpc(auth service.AuthService, mapping protomapper.AuthProtoMa
 }
 
 func (s *authHandleGrpc) LoginUser(ctx context.Context, req *pb.LoginRequest) (*pb.ApiResponseLogin, error) {
+	userAgent, ip := middlewares.ClientFromContext(ctx)
+
 	request := &requests.AuthRequest{
-		Email:    req.Email,
-		Password: req.Password,
+		Email:     req.Email,
+		Password:  req.Password,
+		Device:    req.Device,
+		UserAgent: userAgent,
+		IPAddress: ip,
 	}
 
-	res, err := s.authService.Login(request)
+	res, err := s.authService.Login(ctx, request)
 	if err != nil {
-		return nil, status.Errorf(codes.Unauthenticated, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Login failed: ",
-		})
+		return nil, toGrpcError(err)
 	}
 
 	return s.mapping.ToProtoResponseLogin("success", "Login successful", res), nil
 }
 
 func (s *authHandleGrpc) RefreshToken(ctx context.Context, req *pb.RefreshTokenRequest) (*pb.ApiResponseRefreshToken, error) {
-	res, err := s.authService.RefreshToken(req.RefreshToken)
+	userAgent, ip := middlewares.ClientFromContext(ctx)
+
+	res, err := s.authService.RefreshToken(ctx, &requests.RefreshTokenRequest{
+		RefreshToken: req.RefreshToken,
+		UserAgent:    userAgent,
+		IPAddress:    ip,
+	})
 
 	if err != nil {
-		return nil, status.Errorf(codes.Unauthenticated, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Refresh token failed: ",
-		})
+		return nil, toGrpcError(err)
 	}
 
 	return s.mapping.ToProtoResponseRefreshToken("success", "Registration successful", res), nil
 }
 
 func (s *authHandleGrpc) GetMe(ctx context.Context, req *pb.GetMeRequest) (*pb.ApiResponseGetMe, error) {
-	res, err := s.authService.GetMe(req.AccessToken)
+	res, err := s.authService.GetMe(ctx, req.AccessToken)
+
+	if err != nil {
+		return nil, toGrpcError(err)
+	}
+
+	return s.mapping.ToProtoResponseGetMe("success", "Refresh token successful", res), nil
+}
+
+func (s *authHandleGrpc) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.ApiResponseLogout, error) {
+	// Logout is public, so the access token is optional and only revoked
+	// when it belongs to the same user as the session.
+	accessToken, _ := middlewares.BearerToken(ctx)
+
+	_, err := s.authService.Logout(ctx, &requests.LogoutRequest{
+		RefreshToken: req.RefreshToken,
+		AccessToken:  accessToken,
+	})
+
+	if err != nil {
+		return nil, toGrpcError(err)
+	}
+
+	return s.mapping.ToProtoResponseLogout("success", "Logout successful"), nil
+}
+
+func (s *authHandleGrpc) LogoutAll(ctx context.Context, _ *emptypb.Empty) (*pb.ApiResponseLogout, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	_, errResp := s.authService.LogoutAll(ctx, userID)
+
+	if errResp != nil {
+		return nil, toGrpcError(errResp)
+	}
+
+	return s.mapping.ToProtoResponseLogout("success", "Logged out of all sessions"), nil
+}
+
+func (s *authHandleGrpc) FindSessions(ctx context.Context, _ *emptypb.Empty) (*pb.ApiResponseSessions, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res, errResp := s.authService.FindSessions(ctx, userID)
+
+	if errResp != nil {
+		return nil, toGrpcError(errResp)
+	}
+
+	return s.mapping.ToProtoResponseSessions("success", "Successfully fetched sessions", res), nil
+}
 
+func (s *authHandleGrpc) RevokeSession(ctx context.Context, req *pb.RevokeSessionRequest) (*pb.ApiResponseLogout, error) {
+	userID, err := callerID(ctx)
 	if err != nil {
-		return nil, status.Errorf(codes.Unauthenticated, "%v", &pb.ErrorResponse{
+		return nil, err
+	}
+
+	if req.SessionId == "" {
+		return nil, toGrpcError(&response.ErrorResponse{
 			Status:  "error",
-			Message: "Get me failed: ",
+			Message: "Session id is required",
+			Code:    response.ErrCodeValidation,
 		})
 	}
 
-	return s.mapping.ToProtoResponseGetMe("success", "Refresh token successful", res), nil
+	_, errResp := s.authService.RevokeSession(ctx, userID, req.SessionId)
+
+	if errResp != nil {
+		return nil, toGrpcError(errResp)
+	}
+
+	return s.mapping.ToProtoResponseLogout("success", "Session revoked successfully"), nil
+}
+
+func (s *authHandleGrpc) VerifyEmail(ctx context.Context, req *pb.VerifyEmailRequest) (*pb.ApiResponseVerifyEmail, error) {
+	request := &requests.VerifyEmailRequest{
+		Token: req.GetToken(),
+	}
+
+	if err := request.Validate(); err != nil {
+		return nil, toGrpcError(&response.ErrorResponse{
+			Status:  "error",
+			Message: "Verification token is required",
+			Code:    response.ErrCodeValidation,
+		})
+	}
+
+	_, errResp := s.authService.VerifyEmail(ctx, request)
+
+	if errResp != nil {
+		return nil, toGrpcError(errResp)
+	}
+
+	return s.mapping.ToProtoResponseVerifyEmail("success", "Email verified successfully"), nil
+}
+
+func (s *authHandleGrpc) RequestPasswordReset(ctx context.Context, req *pb.RequestPasswordResetRequest) (*pb.ApiResponsePasswordReset, error) {
+	request := &requests.ForgotPasswordRequest{
+		Email: req.GetEmail(),
+	}
+
+	if err := request.Validate(); err != nil {
+		return nil, toGrpcError(&response.ErrorResponse{
+			Status:  "error",
+			Message: "A valid email is required",
+			Code:    response.ErrCodeValidation,
+		})
+	}
+
+	_, errResp := s.authService.RequestPasswordReset(ctx, request)
+
+	if errResp != nil {
+		return nil, toGrpcError(errResp)
+	}
+
+	return s.mapping.ToProtoResponsePasswordReset("success", "If the email is registered, a password reset link has been sent"), nil
+}
+
+func (s *authHandleGrpc) ResetPassword(ctx context.Context, req *pb.ResetPasswordRequest) (*pb.ApiResponsePasswordReset, error) {
+	request := &requests.ResetPasswordRequest{
+		Token:           req.GetToken(),
+		Password:        req.GetPassword(),
+		ConfirmPassword: req.GetConfirmPassword(),
+	}
+
+	if err := request.Validate(); err != nil {
+		return nil, toGrpcError(&response.ErrorResponse{
+			Status:  "error",
+			Message: "Invalid password reset request: " + err.Error(),
+			Code:    response.ErrCodeValidation,
+		})
+	}
+
+	_, errResp := s.authService.ResetPassword(ctx, request)
+
+	if errResp != nil {
+		return nil, toGrpcError(errResp)
+	}
+
+	return s.mapping.ToProtoResponsePasswordReset("success", "Password reset successfully"), nil
+}
+
+func (s *authHandleGrpc) UnlockLogin(ctx context.Context, req *pb.UnlockLoginRequest) (*pb.ApiResponseUnlockLogin, error) {
+	actorID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &requests.UnlockLoginRequest{
+		Email:     req.GetEmail(),
+		IPAddress: req.GetIpAddress(),
+	}
+
+	if err := request.Validate(); err != nil {
+		return nil, toGrpcError(&response.ErrorResponse{
+			Status:  "error",
+			Message: "A valid email or IP address is required",
+			Code:    response.ErrCodeValidation,
+		})
+	}
+
+	_, errResp := s.authService.UnlockLogin(ctx, actorID, request)
+
+	if errResp != nil {
+		return nil, toGrpcError(errResp)
+	}
+
+	return s.mapping.ToProtoResponseUnlockLogin("success", "Login unlocked successfully"), nil
 }
 
 func (s *authHandleGrpc) RegisterUser(ctx context.Context, req *pb.RegisterRequest) (*pb.ApiResponseRegister, error) {
@@ -73,9 +242,9 @@ func (s *authHandleGrpc) RegisterUser(ctx context.Context, req *pb.RegisterReque
 		ConfirmPassword: req.ConfirmPassword,
 	}
 
-	res, errResp := s.authService.Register(request)
+	res, errResp := s.authService.Register(ctx, request)
 	if errResp != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "status: %s, message: %s", errResp.Status, errResp.Message)
+		return nil, toGrpcError(errResp)
 	}
 
 	return s.mapping.ToProtoResponseRegister("success", "Get me successful", res), nil
@@ -10,26 +10,34 @@ import (
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 type cartHandleGrpc struct {
 	pb.UnimplementedCartServiceServer
-	cartService service.CartService
-	mapping     protomapper.CartProtoMapper
+	cartService  service.CartService
+	mapping      protomapper.CartProtoMapper
+	orderMapping protomapper.OrderProtoMapper
 }
 
 func NewCartHandleGrpc(
 	cartService service.CartService,
 	mapping protomapper.CartProtoMapper,
+	orderMapping protomapper.OrderProtoMapper,
 ) *cartHandleGrpc {
 	return &cartHandleGrpc{
-		cartService: cartService,
-		mapping:     mapping,
+		cartService:  cartService,
+		mapping:      mapping,
+		orderMapping: orderMapping,
 	}
 }
 
 func (s *cartHandleGrpc) FindAll(ctx context.Context, request *pb.FindAllCartRequest) (*pb.ApiResponsePaginationCart, error) {
-	cart_id := request.GetUserId()
+	user_id, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	page := int(request.GetPage())
 	pageSize := int(request.GetPageSize())
 	search := request.GetSearch()
@@ -41,13 +49,10 @@ func (s *cartHandleGrpc) FindAll(ctx context.Context, request *pb.FindAllCartReq
 		pageSize = 10
 	}
 
-	cartItems, totalRecords, err := s.cartService.FindAll(int(cart_id), page, pageSize, search)
+	cartItems, totalRecords, errResp := s.cartService.FindAll(ctx, user_id, page, pageSize, search)
 
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch cart items",
-		})
+	if errResp != nil {
+		return nil, toGrpcError(errResp)
 	}
 
 	totalPages := int(math.Ceil(float64(totalRecords) / float64(pageSize)))
@@ -64,10 +69,15 @@ func (s *cartHandleGrpc) FindAll(ctx context.Context, request *pb.FindAllCartReq
 }
 
 func (s *cartHandleGrpc) Create(ctx context.Context, request *pb.CreateCartRequest) (*pb.ApiResponseCart, error) {
+	user_id, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	req := &requests.CreateCartRequest{
 		Quantity:  int(request.GetQuantity()),
 		ProductID: int(request.GetProductId()),
-		UserID:    int(request.GetUserId()),
+		UserID:    user_id,
 	}
 
 	if err := req.Validate(); err != nil {
@@ -77,15 +87,55 @@ func (s *cartHandleGrpc) Create(ctx context.Context, request *pb.CreateCartReque
 		})
 	}
 
-	cartItem, err := s.cartService.CreateCart(req)
+	cartItem, errResp := s.cartService.CreateCart(ctx, req)
+	if errResp != nil {
+		return nil, toGrpcError(errResp)
+	}
+
+	so := s.mapping.ToProtoResponseCart("success", "Successfully added item to cart", cartItem)
+	return so, nil
+}
+
+func (s *cartHandleGrpc) UpdateQuantity(ctx context.Context, request *pb.UpdateCartQuantityRequest) (*pb.ApiResponseCart, error) {
+	user_id, err := callerID(ctx)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
+		return nil, err
+	}
+
+	req := &requests.UpdateCartQuantityRequest{
+		CartID:   int(request.GetCartId()),
+		Quantity: int(request.GetQuantity()),
+		UserID:   user_id,
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", &pb.ErrorResponse{
 			Status:  "error",
-			Message: "Failed to add item to cart",
+			Message: "Failed to update cart quantity: " + err.Error(),
 		})
 	}
 
-	so := s.mapping.ToProtoResponseCart("success", "Successfully added item to cart", cartItem)
+	cartItem, errResp := s.cartService.UpdateQuantity(ctx, req)
+	if errResp != nil {
+		return nil, toGrpcError(errResp)
+	}
+
+	so := s.mapping.ToProtoResponseCart("success", "Successfully updated cart quantity", cartItem)
+	return so, nil
+}
+
+func (s *cartHandleGrpc) FindSummary(ctx context.Context, _ *emptypb.Empty) (*pb.ApiResponseCartSummary, error) {
+	user_id, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	summary, errResp := s.cartService.FindSummary(ctx, user_id)
+	if errResp != nil {
+		return nil, toGrpcError(errResp)
+	}
+
+	so := s.mapping.ToProtoResponseCartSummary("success", "Successfully fetched cart summary", summary)
 	return so, nil
 }
 
@@ -97,13 +147,15 @@ func (s *cartHandleGrpc) Delete(ctx context.Context, request *pb.FindByIdCartReq
 		})
 	}
 
-	_, err := s.cartService.DeletePermanent(int(request.GetId()))
-
+	user_id, err := callerID(ctx)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to remove item from cart: ",
-		})
+		return nil, err
+	}
+
+	_, errResp := s.cartService.DeletePermanent(ctx, user_id, int(request.GetId()))
+
+	if errResp != nil {
+		return nil, toGrpcError(errResp)
 	}
 
 	so := s.mapping.ToProtoResponseCartDelete("success", "Successfully removed item from cart")
@@ -112,6 +164,11 @@ func (s *cartHandleGrpc) Delete(ctx context.Context, request *pb.FindByIdCartReq
 }
 
 func (s *cartHandleGrpc) DeleteAll(ctx context.Context, req *pb.DeleteCartRequest) (*pb.ApiResponseCartAll, error) {
+	user_id, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	cartIDs := make([]int, len(req.GetCartIds()))
 	for i, id := range req.GetCartIds() {
 		cartIDs[i] = int(id)
@@ -119,16 +176,53 @@ func (s *cartHandleGrpc) DeleteAll(ctx context.Context, req *pb.DeleteCartReques
 
 	deleteRequest := &requests.DeleteCartRequest{
 		CartIds: cartIDs,
+		UserID:  user_id,
 	}
 
-	_, err := s.cartService.DeleteAllPermanently(deleteRequest)
+	_, errResp := s.cartService.DeleteAllPermanently(ctx, deleteRequest)
+	if errResp != nil {
+		return nil, toGrpcError(errResp)
+	}
+
+	so := s.mapping.ToProtoResponseCartAll("success", "Successfully cleared cart")
+	return so, nil
+}
+
+func (s *cartHandleGrpc) Checkout(ctx context.Context, request *pb.CheckoutCartRequest) (*pb.ApiResponsesOrder, error) {
+	user_id, err := callerID(ctx)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
+		return nil, err
+	}
+
+	req := &requests.CheckoutCartRequest{
+		UserID:     user_id,
+		MerchantID: int(request.GetMerchantId()),
+	}
+
+	if request.Shipping != nil {
+		req.ShippingAddress = requests.CheckoutShippingAddressRequest{
+			Alamat:         request.Shipping.GetAlamat(),
+			Provinsi:       request.Shipping.GetProvinsi(),
+			Kota:           request.Shipping.GetKota(),
+			Courier:        request.Shipping.GetCourier(),
+			ShippingMethod: request.Shipping.GetShippingMethod(),
+			ShippingCost:   request.Shipping.GetShippingCost(),
+			Negara:         request.Shipping.GetNegara(),
+		}
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", &pb.ErrorResponse{
 			Status:  "error",
-			Message: "Failed to clear cart",
+			Message: "Failed to checkout cart: " + err.Error(),
 		})
 	}
 
-	so := s.mapping.ToProtoResponseCartAll("success", "Successfully cleared cart")
+	orders, errResp := s.cartService.Checkout(ctx, req)
+	if errResp != nil {
+		return nil, toGrpcError(errResp)
+	}
+
+	so := s.orderMapping.ToProtoResponsesOrder("success", "Successfully checked out cart", orders)
 	return so, nil
 }
@@ -41,13 +41,10 @@ func (s *categoryHandleGrpc) FindAll(ctx context.Context, request *pb.FindAllCat
 		pageSize = 10
 	}
 
-	category, totalRecords, err := s.categoryService.FindAll(page, pageSize, search)
+	category, totalRecords, err := s.categoryService.FindAll(ctx, page, pageSize, search)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch categories: ",
-		})
+		return nil, toGrpcError(err)
 	}
 
 	totalPages := int(math.Ceil(float64(totalRecords) / float64(pageSize)))
@@ -71,13 +68,10 @@ func (s *categoryHandleGrpc) FindById(ctx context.Context, request *pb.FindByIdC
 		})
 	}
 
-	category, err := s.categoryService.FindById(int(request.GetId()))
+	category, err := s.categoryService.FindById(ctx, int(request.GetId()))
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch category: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseCategory("success", "Successfully fetched categories", category)
@@ -98,13 +92,10 @@ func (s *categoryHandleGrpc) FindByActive(ctx context.Context, request *pb.FindA
 		pageSize = 10
 	}
 
-	users, totalRecords, err := s.categoryService.FindByActive(search, page, pageSize)
+	users, totalRecords, err := s.categoryService.FindByActive(ctx, search, page, pageSize)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch active categories: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	totalPages := int(math.Ceil(float64(totalRecords) / float64(pageSize)))
@@ -132,13 +123,10 @@ func (s *categoryHandleGrpc) FindByTrashed(ctx context.Context, request *pb.Find
 		pageSize = 10
 	}
 
-	users, totalRecords, err := s.categoryService.FindByTrashed(search, page, pageSize)
+	users, totalRecords, err := s.categoryService.FindByTrashed(ctx, search, page, pageSize)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch trashed categories: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	totalPages := int(math.Ceil(float64(totalRecords) / float64(pageSize)))
@@ -170,12 +158,9 @@ func (s *categoryHandleGrpc) Create(ctx context.Context, request *pb.CreateCateg
 		})
 	}
 
-	category, err := s.categoryService.CreateCategory(req)
+	category, err := s.categoryService.CreateCategory(ctx, req)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to create category: ",
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseCategory("success", "Successfully created category", category)
@@ -205,12 +190,9 @@ func (s *categoryHandleGrpc) Update(ctx context.Context, request *pb.UpdateCateg
 		})
 	}
 
-	category, err := s.categoryService.UpdateCategory(req)
+	category, err := s.categoryService.UpdateCategory(ctx, req)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to update category: ",
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseCategory("success", "Successfully updated category", category)
@@ -225,13 +207,10 @@ func (s *categoryHandleGrpc) TrashedCategory(ctx context.Context, request *pb.Fi
 		})
 	}
 
-	category, err := s.categoryService.TrashedCategory(int(request.GetId()))
+	category, err := s.categoryService.TrashedCategory(ctx, int(request.GetId()))
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to trashed category: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseCategoryDeleteAt("success", "Successfully trashed category", category)
@@ -247,13 +226,10 @@ func (s *categoryHandleGrpc) RestoreCategory(ctx context.Context, request *pb.Fi
 		})
 	}
 
-	category, err := s.categoryService.RestoreCategory(int(request.GetId()))
+	category, err := s.categoryService.RestoreCategory(ctx, int(request.GetId()))
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to restore category: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseCategoryDeleteAt("success", "Successfully restored category", category)
@@ -269,13 +245,10 @@ func (s *categoryHandleGrpc) DeleteCategoryPermanent(ctx context.Context, reques
 		})
 	}
 
-	_, err := s.categoryService.DeleteCategoryPermanent(int(request.GetId()))
+	_, err := s.categoryService.DeleteCategoryPermanent(ctx, int(request.GetId()))
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to delete category permanently: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseCategoryDelete("success", "Successfully deleted category permanently")
@@ -284,13 +257,10 @@ func (s *categoryHandleGrpc) DeleteCategoryPermanent(ctx context.Context, reques
 }
 
 func (s *categoryHandleGrpc) RestoreAllCategory(ctx context.Context, _ *emptypb.Empty) (*pb.ApiResponseCategoryAll, error) {
-	_, err := s.categoryService.RestoreAllCategories()
+	_, err := s.categoryService.RestoreAllCategories(ctx)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to restore all category: ",
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseCategoryAll("success", "Successfully restore all category")
@@ -299,13 +269,10 @@ func (s *categoryHandleGrpc) RestoreAllCategory(ctx context.Context, _ *emptypb.
 }
 
 func (s *categoryHandleGrpc) DeleteAllCategoryPermanent(ctx context.Context, _ *emptypb.Empty) (*pb.ApiResponseCategoryAll, error) {
-	_, err := s.categoryService.DeleteAllCategoriesPermanent()
+	_, err := s.categoryService.DeleteAllCategoriesPermanent(ctx)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to delete category permanent: ",
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseCategoryAll("success", "Successfully delete category permanen")
@@ -0,0 +1,54 @@
+package gapi
+
+import (
+	"ecommerce/internal/domain/response"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorDomain identifies this service in the ErrorInfo detail attached to
+// every error status.
+const ErrorDomain = "ecommerce"
+
+// errorCodes maps service error codes to the gRPC code they are reported as.
+// Codes that are not listed are reported as codes.Internal.
+var errorCodes = map[string]codes.Code{
+	response.ErrCodeValidation:              codes.InvalidArgument,
+	response.ErrCodeNotFound:                codes.NotFound,
+	response.ErrCodeConflict:                codes.AlreadyExists,
+	response.ErrCodeUnauthorized:            codes.Unauthenticated,
+	response.ErrCodeForbidden:               codes.PermissionDenied,
+	response.ErrCodeInsufficientStock:       codes.FailedPrecondition,
+	response.ErrCodeInvalidStatusTransition: codes.FailedPrecondition,
+	response.ErrCodeEmptyCart:               codes.FailedPrecondition,
+	response.ErrCodeTooManyAttempts:         codes.ResourceExhausted,
+}
+
+// toGrpcError translates a service error into a gRPC status. The service
+// error code travels as the Reason of an errdetails.ErrorInfo so the REST
+// gateway can recover it.
+func toGrpcError(errResp *response.ErrorResponse) error {
+	code, ok := errorCodes[errResp.Code]
+	if !ok {
+		code = codes.Internal
+	}
+
+	reason := errResp.Code
+	if reason == "" {
+		reason = response.ErrCodeInternal
+	}
+
+	st := status.New(code, errResp.Message)
+
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: reason,
+		Domain: ErrorDomain,
+	})
+	if err != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
@@ -32,14 +32,14 @@ func NewHandler(deps Deps) *Handler {
 		Role:        NewRoleHandleGrpc(deps.Service.Role, deps.Mapper.RoleProtoMapper),
 		User:        NewUserHandleGrpc(deps.Service.User, deps.Mapper.UserProtoMapper),
 		Category:    NewCategoryHandleGrpc(deps.Service.Category, deps.Mapper.CategoryProtoMapper),
-		Merchant:    NewMerchantHandleGrpc(deps.Service.Merchant, deps.Mapper.MerchantProtoMapper),
-		OrderItem:   NewOrderItemHandleGrpc(deps.Service.OrderItem, deps.Mapper.OrderItemProtoMapper),
-		Order:       NewOrderHandleGrpc(deps.Service.Order, deps.Mapper.OrderProtoMapper),
-		Product:     NewProductHandleGrpc(deps.Service.Product, deps.Mapper.ProductProtoMapper),
-		Transaction: NewTransactionHandleGrpc(deps.Service.Transaction, deps.Mapper.TransactionProtoMapper),
-		Review:      NewReviewHandleGrpc(deps.Service.Review, deps.Mapper.ReviewProtoMapper),
-		Shipping:    NewShippingAddressHandleGrpc(deps.Service.Shipping, deps.Mapper.ShippingProtoMapper),
+		Merchant:    NewMerchantHandleGrpc(deps.Service.Merchant, deps.Service.Ownership, deps.Mapper.MerchantProtoMapper),
+		OrderItem:   NewOrderItemHandleGrpc(deps.Service.OrderItem, deps.Service.Ownership, deps.Mapper.OrderItemProtoMapper),
+		Order:       NewOrderHandleGrpc(deps.Service.Order, deps.Service.Ownership, deps.Mapper.OrderProtoMapper),
+		Product:     NewProductHandleGrpc(deps.Service.Product, deps.Service.Ownership, deps.Mapper.ProductProtoMapper),
+		Transaction: NewTransactionHandleGrpc(deps.Service.Transaction, deps.Service.Ownership, deps.Mapper.TransactionProtoMapper),
+		Review:      NewReviewHandleGrpc(deps.Service.Review, deps.Service.Ownership, deps.Mapper.ReviewProtoMapper),
+		Shipping:    NewShippingAddressHandleGrpc(deps.Service.Shipping, deps.Service.Ownership, deps.Mapper.ShippingProtoMapper),
 		Slider:      NewSliderHandleGrpc(deps.Service.Slider, deps.Mapper.SliderProtoMapper),
-		Cart:        NewCartHandleGrpc(deps.Service.Cart, deps.Mapper.CartProtoMapper),
+		Cart:        NewCartHandleGrpc(deps.Service.Cart, deps.Mapper.CartProtoMapper, deps.Mapper.OrderProtoMapper),
 	}
 }
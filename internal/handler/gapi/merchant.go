@@ -3,6 +3,7 @@ package gapi
 import (
 	"context"
 	"ecommerce/internal/domain/requests"
+	"ecommerce/internal/domain/response"
 	protomapper "ecommerce/internal/mapper/proto"
 	"ecommerce/internal/pb"
 	"ecommerce/internal/service"
@@ -15,17 +16,20 @@ import (
 
 type merchantHandleGrpc struct {
 	pb.UnimplementedMerchantServiceServer
-	merchantService service.MerchantService
-	mapping         protomapper.MerchantProtoMapper
+	merchantService  service.MerchantService
+	ownershipService service.OwnershipService
+	mapping          protomapper.MerchantProtoMapper
 }
 
 func NewMerchantHandleGrpc(
 	merchantService service.MerchantService,
+	ownershipService service.OwnershipService,
 	mapping protomapper.MerchantProtoMapper,
 ) *merchantHandleGrpc {
 	return &merchantHandleGrpc{
-		merchantService: merchantService,
-		mapping:         mapping,
+		merchantService:  merchantService,
+		ownershipService: ownershipService,
+		mapping:          mapping,
 	}
 }
 
@@ -41,13 +45,10 @@ func (s *merchantHandleGrpc) FindAll(ctx context.Context, request *pb.FindAllMer
 		pageSize = 10
 	}
 
-	merchant, totalRecords, err := s.merchantService.FindAll(page, pageSize, search)
+	merchant, totalRecords, err := s.merchantService.FindAll(ctx, page, pageSize, search)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch merchant: ",
-		})
+		return nil, toGrpcError(err)
 	}
 
 	totalPages := int(math.Ceil(float64(totalRecords) / float64(pageSize)))
@@ -71,13 +72,10 @@ func (s *merchantHandleGrpc) FindById(ctx context.Context, request *pb.FindByIdM
 		})
 	}
 
-	merchant, err := s.merchantService.FindById(int(request.GetId()))
+	merchant, err := s.merchantService.FindById(ctx, int(request.GetId()))
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch merchant: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseMerchant("success", "Successfully fetched categories", merchant)
@@ -98,13 +96,10 @@ func (s *merchantHandleGrpc) FindByActive(ctx context.Context, request *pb.FindA
 		pageSize = 10
 	}
 
-	merchant, totalRecords, err := s.merchantService.FindByActive(search, page, pageSize)
+	merchant, totalRecords, err := s.merchantService.FindByActive(ctx, search, page, pageSize)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch active merchant: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	totalPages := int(math.Ceil(float64(totalRecords) / float64(pageSize)))
@@ -132,13 +127,10 @@ func (s *merchantHandleGrpc) FindByTrashed(ctx context.Context, request *pb.Find
 		pageSize = 10
 	}
 
-	users, totalRecords, err := s.merchantService.FindByTrashed(search, page, pageSize)
+	users, totalRecords, err := s.merchantService.FindByTrashed(ctx, search, page, pageSize)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch trashed merchant: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	totalPages := int(math.Ceil(float64(totalRecords) / float64(pageSize)))
@@ -155,6 +147,21 @@ func (s *merchantHandleGrpc) FindByTrashed(ctx context.Context, request *pb.Find
 	return so, nil
 }
 
+func (s *merchantHandleGrpc) FindMe(ctx context.Context, _ *emptypb.Empty) (*pb.ApiResponsesMerchant, error) {
+	user_id, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	merchants, errResp := s.merchantService.FindByUser(ctx, user_id)
+	if errResp != nil {
+		return nil, toGrpcError(errResp)
+	}
+
+	so := s.mapping.ToProtoResponsesMerchant("success", "Successfully fetched merchants", merchants)
+	return so, nil
+}
+
 func (s *merchantHandleGrpc) Create(ctx context.Context, request *pb.CreateMerchantRequest) (*pb.ApiResponseMerchant, error) {
 	req := &requests.CreateMerchantRequest{
 		UserID:       int(request.GetUserId()),
@@ -164,6 +171,7 @@ func (s *merchantHandleGrpc) Create(ctx context.Context, request *pb.CreateMerch
 		ContactEmail: request.GetContactEmail(),
 		ContactPhone: request.GetContactPhone(),
 		Status:       request.GetStatus(),
+		Currency:     request.GetCurrency(),
 	}
 
 	if err := req.Validate(); err != nil {
@@ -173,12 +181,15 @@ func (s *merchantHandleGrpc) Create(ctx context.Context, request *pb.CreateMerch
 		})
 	}
 
-	merchant, err := s.merchantService.CreateMerchant(req)
+	if err := authorizeOwner(ctx, func(user_id int) *response.ErrorResponse {
+		return authorizeSelf(user_id, req.UserID)
+	}); err != nil {
+		return nil, err
+	}
+
+	merchant, err := s.merchantService.CreateMerchant(ctx, req)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to create merchant: ",
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseMerchant("success", "Successfully created merchant", merchant)
@@ -211,12 +222,19 @@ func (s *merchantHandleGrpc) Update(ctx context.Context, request *pb.UpdateMerch
 		})
 	}
 
-	merchant, err := s.merchantService.UpdateMerchant(req)
+	if err := authorizeOwner(ctx, func(user_id int) *response.ErrorResponse {
+		if errResp := s.ownershipService.AuthorizeMerchant(ctx, user_id, req.MerchantID); errResp != nil {
+			return errResp
+		}
+
+		return authorizeSelf(user_id, req.UserID)
+	}); err != nil {
+		return nil, err
+	}
+
+	merchant, err := s.merchantService.UpdateMerchant(ctx, req)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to update merchant: ",
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseMerchant("success", "Successfully updated merchant", merchant)
@@ -231,13 +249,10 @@ func (s *merchantHandleGrpc) TrashedMerchant(ctx context.Context, request *pb.Fi
 		})
 	}
 
-	merchant, err := s.merchantService.TrashedMerchant(int(request.GetId()))
+	merchant, err := s.merchantService.TrashedMerchant(ctx, int(request.GetId()))
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to trashed merchant: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseMerchantDeleteAt("success", "Successfully trashed merchant", merchant)
@@ -253,13 +268,10 @@ func (s *merchantHandleGrpc) RestoreMerchant(ctx context.Context, request *pb.Fi
 		})
 	}
 
-	merchant, err := s.merchantService.RestoreMerchant(int(request.GetId()))
+	merchant, err := s.merchantService.RestoreMerchant(ctx, int(request.GetId()))
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to restore merchant: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseMerchantDeleteAt("success", "Successfully restored merchant", merchant)
@@ -275,13 +287,10 @@ func (s *merchantHandleGrpc) DeleteMerchantPermanent(ctx context.Context, reques
 		})
 	}
 
-	_, err := s.merchantService.DeleteMerchantPermanent(int(request.GetId()))
+	_, err := s.merchantService.DeleteMerchantPermanent(ctx, int(request.GetId()))
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to delete merchant permanently: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseMerchantDelete("success", "Successfully deleted merchant permanently")
@@ -290,13 +299,10 @@ func (s *merchantHandleGrpc) DeleteMerchantPermanent(ctx context.Context, reques
 }
 
 func (s *merchantHandleGrpc) RestoreAllMerchant(ctx context.Context, _ *emptypb.Empty) (*pb.ApiResponseMerchantAll, error) {
-	_, err := s.merchantService.RestoreAllMerchant()
+	_, err := s.merchantService.RestoreAllMerchant(ctx)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to restore all merchant: ",
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseMerchantAll("success", "Successfully restore all merchant")
@@ -305,13 +311,10 @@ func (s *merchantHandleGrpc) RestoreAllMerchant(ctx context.Context, _ *emptypb.
 }
 
 func (s *merchantHandleGrpc) DeleteAllMerchantPermanent(ctx context.Context, _ *emptypb.Empty) (*pb.ApiResponseMerchantAll, error) {
-	_, err := s.merchantService.DeleteAllMerchantPermanent()
+	_, err := s.merchantService.DeleteAllMerchantPermanent(ctx)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to delete merchant permanent: ",
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseMerchantAll("success", "Successfully delete merchant permanen")
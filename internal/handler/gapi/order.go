@@ -3,6 +3,7 @@ package gapi
 import (
 	"context"
 	"ecommerce/internal/domain/requests"
+	"ecommerce/internal/domain/response"
 	protomapper "ecommerce/internal/mapper/proto"
 	"ecommerce/internal/pb"
 	"ecommerce/internal/service"
@@ -15,17 +16,20 @@ import (
 
 type orderHandleGrpc struct {
 	pb.UnimplementedOrderServiceServer
-	orderService service.OrderService
-	mapping      protomapper.OrderProtoMapper
+	orderService     service.OrderService
+	ownershipService service.OwnershipService
+	mapping          protomapper.OrderProtoMapper
 }
 
 func NewOrderHandleGrpc(
 	orderService service.OrderService,
+	ownershipService service.OwnershipService,
 	mapping protomapper.OrderProtoMapper,
 ) *orderHandleGrpc {
 	return &orderHandleGrpc{
-		orderService: orderService,
-		mapping:      mapping,
+		orderService:     orderService,
+		ownershipService: ownershipService,
+		mapping:          mapping,
 	}
 }
 
@@ -41,13 +45,26 @@ func (s *orderHandleGrpc) FindAll(ctx context.Context, request *pb.FindAllOrderR
 		pageSize = 10
 	}
 
-	merchant, totalRecords, err := s.orderService.FindAll(page, pageSize, search)
+	if request.Cursor != nil {
+		orders, nextCursor, err := s.orderService.FindAllCursor(ctx, request.GetCursor(), pageSize, search)
+
+		if err != nil {
+			return nil, toGrpcError(err)
+		}
+
+		paginationMeta := &pb.PaginationMeta{
+			PageSize:   int32(pageSize),
+			NextCursor: nextCursor,
+		}
+
+		so := s.mapping.ToProtoResponsePaginationOrder(paginationMeta, "success", "Successfully fetched order", orders)
+		return so, nil
+	}
+
+	merchant, totalRecords, err := s.orderService.FindAll(ctx, page, pageSize, search)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch order: ",
-		})
+		return nil, toGrpcError(err)
 	}
 
 	totalPages := int(math.Ceil(float64(totalRecords) / float64(pageSize)))
@@ -71,13 +88,16 @@ func (s *orderHandleGrpc) FindById(ctx context.Context, request *pb.FindByIdOrde
 		})
 	}
 
-	merchant, err := s.orderService.FindById(int(request.GetId()))
+	if err := authorizeOwner(ctx, func(user_id int) *response.ErrorResponse {
+		return s.ownershipService.AuthorizeOrderAccess(ctx, user_id, int(request.GetId()))
+	}); err != nil {
+		return nil, err
+	}
+
+	merchant, err := s.orderService.FindById(ctx, int(request.GetId()))
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch order: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseOrder("success", "Successfully fetched order", merchant)
@@ -98,13 +118,10 @@ func (s *orderHandleGrpc) FindByActive(ctx context.Context, request *pb.FindAllO
 		pageSize = 10
 	}
 
-	merchant, totalRecords, err := s.orderService.FindByActive(page, pageSize, search)
+	merchant, totalRecords, err := s.orderService.FindByActive(ctx, page, pageSize, search)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch active order: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	totalPages := int(math.Ceil(float64(totalRecords) / float64(pageSize)))
@@ -132,13 +149,10 @@ func (s *orderHandleGrpc) FindByTrashed(ctx context.Context, request *pb.FindAll
 		pageSize = 10
 	}
 
-	users, totalRecords, err := s.orderService.FindByTrashed(page, pageSize, search)
+	users, totalRecords, err := s.orderService.FindByTrashed(ctx, page, pageSize, search)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch trashed order: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	totalPages := int(math.Ceil(float64(totalRecords) / float64(pageSize)))
@@ -156,17 +170,22 @@ func (s *orderHandleGrpc) FindByTrashed(ctx context.Context, request *pb.FindAll
 }
 
 func (s *orderHandleGrpc) Create(ctx context.Context, request *pb.CreateOrderRequest) (*pb.ApiResponseOrder, error) {
+	user_id, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	req := &requests.CreateOrderRequest{
 		MerchantID: int(request.GetMerchantId()),
-		UserID:     int(request.UserId),
-		TotalPrice: int(request.GetTotalPrice()),
+		UserID:     user_id,
+		TotalPrice: request.GetTotalPrice(),
 	}
 
 	for _, item := range request.GetItems() {
 		req.Items = append(req.Items, requests.CreateOrderItemRequest{
 			ProductID: int(item.GetProductId()),
 			Quantity:  int(item.GetQuantity()),
-			Price:     int(item.GetPrice()),
+			Price:     item.GetPrice(),
 		})
 	}
 
@@ -178,7 +197,7 @@ func (s *orderHandleGrpc) Create(ctx context.Context, request *pb.CreateOrderReq
 			Kota:           request.Shipping.GetKota(),
 			Courier:        request.Shipping.GetCourier(),
 			ShippingMethod: request.Shipping.GetShippingMethod(),
-			ShippingCost:   int(request.Shipping.GetShippingCost()),
+			ShippingCost:   request.Shipping.GetShippingCost(),
 			Negara:         request.Shipping.GetNegara(),
 		}
 	}
@@ -190,12 +209,9 @@ func (s *orderHandleGrpc) Create(ctx context.Context, request *pb.CreateOrderReq
 		})
 	}
 
-	order, err := s.orderService.CreateOrder(req)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to create order: ",
-		})
+	order, errResp := s.orderService.CreateOrder(ctx, req)
+	if errResp != nil {
+		return nil, toGrpcError(errResp)
 	}
 
 	so := s.mapping.ToProtoResponseOrder("success", "Successfully created order", order)
@@ -211,8 +227,9 @@ func (s *orderHandleGrpc) Update(ctx context.Context, request *pb.UpdateOrderReq
 	}
 
 	req := &requests.UpdateOrderRequest{
-		OrderID:    int(request.OrderId),
-		TotalPrice: int(request.GetTotalPrice()),
+		OrderID:        int(request.OrderId),
+		TotalPrice:     request.GetTotalPrice(),
+		DiscountAmount: request.GetDiscountAmount(),
 	}
 
 	for _, item := range request.GetItems() {
@@ -220,7 +237,7 @@ func (s *orderHandleGrpc) Update(ctx context.Context, request *pb.UpdateOrderReq
 			OrderItemID: int(item.GetOrderItemId()),
 			ProductID:   int(item.GetProductId()),
 			Quantity:    int(item.GetQuantity()),
-			Price:       int(item.GetPrice()),
+			Price:       item.GetPrice(),
 		})
 	}
 
@@ -233,7 +250,7 @@ func (s *orderHandleGrpc) Update(ctx context.Context, request *pb.UpdateOrderReq
 			Kota:           request.Shipping.GetKota(),
 			Courier:        request.Shipping.GetCourier(),
 			ShippingMethod: request.Shipping.GetShippingMethod(),
-			ShippingCost:   int(request.Shipping.GetShippingCost()),
+			ShippingCost:   request.Shipping.GetShippingCost(),
 			Negara:         request.Shipping.GetNegara(),
 		}
 	}
@@ -245,19 +262,22 @@ func (s *orderHandleGrpc) Update(ctx context.Context, request *pb.UpdateOrderReq
 		})
 	}
 
-	order, err := s.orderService.UpdateOrder(req)
+	if err := authorizeOwner(ctx, func(user_id int) *response.ErrorResponse {
+		return s.ownershipService.AuthorizeOrder(ctx, user_id, req.OrderID)
+	}); err != nil {
+		return nil, err
+	}
+
+	order, err := s.orderService.UpdateOrder(ctx, req)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to update order: ",
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseOrder("success", "Successfully updated order", order)
 	return so, nil
 }
 
-func (s *orderHandleGrpc) TrashedOrder(ctx context.Context, request *pb.FindByIdOrderRequest) (*pb.ApiResponseOrderDeleteAt, error) {
+func (s *orderHandleGrpc) FindStatusHistory(ctx context.Context, request *pb.FindByIdOrderRequest) (*pb.ApiResponseOrderStatusHistory, error) {
 	if request.GetId() == 0 {
 		return nil, status.Errorf(codes.InvalidArgument, "%v", &pb.ErrorResponse{
 			Status:  "error",
@@ -265,21 +285,144 @@ func (s *orderHandleGrpc) TrashedOrder(ctx context.Context, request *pb.FindById
 		})
 	}
 
-	merchant, err := s.orderService.TrashedOrder(int(request.GetId()))
+	if err := authorizeOwner(ctx, func(user_id int) *response.ErrorResponse {
+		return s.ownershipService.AuthorizeOrderAccess(ctx, user_id, int(request.GetId()))
+	}); err != nil {
+		return nil, err
+	}
+
+	histories, err := s.orderService.FindStatusHistory(ctx, int(request.GetId()))
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
+		return nil, toGrpcError(err)
+	}
+
+	so := s.mapping.ToProtoResponseOrderStatusHistory("success", "Successfully fetched order status history", histories)
+
+	return so, nil
+}
+
+func (s *orderHandleGrpc) Cancel(ctx context.Context, request *pb.UpdateOrderStatusRequest) (*pb.ApiResponseOrder, error) {
+	req := &requests.UpdateOrderStatusRequest{
+		OrderID: int(request.GetOrderId()),
+		Note:    request.GetNote(),
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", &pb.ErrorResponse{
 			Status:  "error",
-			Message: "Failed to trashed order: " + err.Message,
+			Message: "Invalid order id",
 		})
 	}
 
-	so := s.mapping.ToProtoResponseOrderDeleteAt("success", "Successfully trashed order", merchant)
+	if err := authorizeOwner(ctx, func(user_id int) *response.ErrorResponse {
+		return s.ownershipService.AuthorizeOrderAccess(ctx, user_id, req.OrderID)
+	}); err != nil {
+		return nil, err
+	}
+
+	order, err := s.orderService.CancelOrder(ctx, req)
+
+	if err != nil {
+		return nil, toGrpcError(err)
+	}
+
+	so := s.mapping.ToProtoResponseOrder("success", "Successfully cancelled order", order)
 
 	return so, nil
 }
 
-func (s *orderHandleGrpc) RestoreOrder(ctx context.Context, request *pb.FindByIdOrderRequest) (*pb.ApiResponseOrderDeleteAt, error) {
+func (s *orderHandleGrpc) MarkProcessing(ctx context.Context, request *pb.UpdateOrderStatusRequest) (*pb.ApiResponseOrder, error) {
+	req := &requests.UpdateOrderStatusRequest{
+		OrderID: int(request.GetOrderId()),
+		Note:    request.GetNote(),
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", &pb.ErrorResponse{
+			Status:  "error",
+			Message: "Invalid order id",
+		})
+	}
+
+	if err := authorizeOwner(ctx, func(user_id int) *response.ErrorResponse {
+		return s.ownershipService.AuthorizeOrder(ctx, user_id, req.OrderID)
+	}); err != nil {
+		return nil, err
+	}
+
+	order, err := s.orderService.MarkProcessing(ctx, req)
+
+	if err != nil {
+		return nil, toGrpcError(err)
+	}
+
+	so := s.mapping.ToProtoResponseOrder("success", "Successfully marked order as processing", order)
+
+	return so, nil
+}
+
+func (s *orderHandleGrpc) MarkShipped(ctx context.Context, request *pb.UpdateOrderStatusRequest) (*pb.ApiResponseOrder, error) {
+	req := &requests.UpdateOrderStatusRequest{
+		OrderID: int(request.GetOrderId()),
+		Note:    request.GetNote(),
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", &pb.ErrorResponse{
+			Status:  "error",
+			Message: "Invalid order id",
+		})
+	}
+
+	if err := authorizeOwner(ctx, func(user_id int) *response.ErrorResponse {
+		return s.ownershipService.AuthorizeOrder(ctx, user_id, req.OrderID)
+	}); err != nil {
+		return nil, err
+	}
+
+	order, err := s.orderService.MarkShipped(ctx, req)
+
+	if err != nil {
+		return nil, toGrpcError(err)
+	}
+
+	so := s.mapping.ToProtoResponseOrder("success", "Successfully marked order as shipped", order)
+
+	return so, nil
+}
+
+func (s *orderHandleGrpc) MarkDelivered(ctx context.Context, request *pb.UpdateOrderStatusRequest) (*pb.ApiResponseOrder, error) {
+	req := &requests.UpdateOrderStatusRequest{
+		OrderID: int(request.GetOrderId()),
+		Note:    request.GetNote(),
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", &pb.ErrorResponse{
+			Status:  "error",
+			Message: "Invalid order id",
+		})
+	}
+
+	if err := authorizeOwner(ctx, func(user_id int) *response.ErrorResponse {
+		return s.ownershipService.AuthorizeOrder(ctx, user_id, req.OrderID)
+	}); err != nil {
+		return nil, err
+	}
+
+	order, err := s.orderService.MarkDelivered(ctx, req)
+
+	if err != nil {
+		return nil, toGrpcError(err)
+	}
+
+	so := s.mapping.ToProtoResponseOrder("success", "Successfully marked order as delivered", order)
+
+	return so, nil
+}
+
+func (s *orderHandleGrpc) TrashedOrder(ctx context.Context, request *pb.FindByIdOrderRequest) (*pb.ApiResponseOrderDeleteAt, error) {
 	if request.GetId() == 0 {
 		return nil, status.Errorf(codes.InvalidArgument, "%v", &pb.ErrorResponse{
 			Status:  "error",
@@ -287,15 +430,31 @@ func (s *orderHandleGrpc) RestoreOrder(ctx context.Context, request *pb.FindById
 		})
 	}
 
-	merchant, err := s.orderService.RestoreOrder(int(request.GetId()))
+	merchant, err := s.orderService.TrashedOrder(ctx, int(request.GetId()))
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
+		return nil, toGrpcError(err)
+	}
+
+	so := s.mapping.ToProtoResponseOrderDeleteAt("success", "Successfully trashed order", merchant)
+
+	return so, nil
+}
+
+func (s *orderHandleGrpc) RestoreOrder(ctx context.Context, request *pb.FindByIdOrderRequest) (*pb.ApiResponseOrderDeleteAt, error) {
+	if request.GetId() == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", &pb.ErrorResponse{
 			Status:  "error",
-			Message: "Failed to restore order: " + err.Message,
+			Message: "Invalid order id",
 		})
 	}
 
+	merchant, err := s.orderService.RestoreOrder(ctx, int(request.GetId()))
+
+	if err != nil {
+		return nil, toGrpcError(err)
+	}
+
 	so := s.mapping.ToProtoResponseOrderDeleteAt("success", "Successfully restored order", merchant)
 
 	return so, nil
@@ -309,13 +468,10 @@ func (s *orderHandleGrpc) DeleteOrderPermanent(ctx context.Context, request *pb.
 		})
 	}
 
-	_, err := s.orderService.DeleteOrderPermanent(int(request.GetId()))
+	_, err := s.orderService.DeleteOrderPermanent(ctx, int(request.GetId()))
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to delete order permanently: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseOrderDelete("success", "Successfully deleted order permanently")
@@ -324,13 +480,10 @@ func (s *orderHandleGrpc) DeleteOrderPermanent(ctx context.Context, request *pb.
 }
 
 func (s *orderHandleGrpc) RestoreAllOrder(ctx context.Context, _ *emptypb.Empty) (*pb.ApiResponseOrderAll, error) {
-	_, err := s.orderService.RestoreAllOrder()
+	_, err := s.orderService.RestoreAllOrder(ctx)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to restore all order: ",
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseOrderAll("success", "Successfully restore all order")
@@ -339,13 +492,10 @@ func (s *orderHandleGrpc) RestoreAllOrder(ctx context.Context, _ *emptypb.Empty)
 }
 
 func (s *orderHandleGrpc) DeleteAllOrderPermanent(ctx context.Context, _ *emptypb.Empty) (*pb.ApiResponseOrderAll, error) {
-	_, err := s.orderService.DeleteAllOrderPermanent()
+	_, err := s.orderService.DeleteAllOrderPermanent(ctx)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to delete order permanent: ",
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseOrderAll("success", "Successfully delete order permanen")
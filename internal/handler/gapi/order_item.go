@@ -2,6 +2,7 @@ package gapi
 
 import (
 	"context"
+	"ecommerce/internal/domain/response"
 	protomapper "ecommerce/internal/mapper/proto"
 	"ecommerce/internal/pb"
 	"ecommerce/internal/service"
@@ -14,15 +15,18 @@ import (
 type orderItemHandleGrpc struct {
 	pb.UnimplementedOrderItemServiceServer
 	orderItemService service.OrderItemService
+	ownershipService service.OwnershipService
 	mapping          protomapper.OrderItemProtoMapper
 }
 
 func NewOrderItemHandleGrpc(
 	orderItemService service.OrderItemService,
+	ownershipService service.OwnershipService,
 	mapping protomapper.OrderItemProtoMapper,
 ) *orderItemHandleGrpc {
 	return &orderItemHandleGrpc{
 		orderItemService: orderItemService,
+		ownershipService: ownershipService,
 		mapping:          mapping,
 	}
 }
@@ -39,12 +43,9 @@ func (s *orderItemHandleGrpc) FindAll(ctx context.Context, request *pb.FindAllOr
 		pageSize = 10
 	}
 
-	orderItems, totalRecords, err := s.orderItemService.FindAllOrderItems(search, page, pageSize)
+	orderItems, totalRecords, err := s.orderItemService.FindAllOrderItems(ctx, search, page, pageSize)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch order items: ",
-		})
+		return nil, toGrpcError(err)
 	}
 
 	totalPages := int(math.Ceil(float64(totalRecords) / float64(pageSize)))
@@ -72,12 +73,9 @@ func (s *orderItemHandleGrpc) FindByActive(ctx context.Context, request *pb.Find
 		pageSize = 10
 	}
 
-	orderItems, totalRecords, err := s.orderItemService.FindByActive(search, page, pageSize)
+	orderItems, totalRecords, err := s.orderItemService.FindByActive(ctx, search, page, pageSize)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch active order items: ",
-		})
+		return nil, toGrpcError(err)
 	}
 
 	totalPages := int(math.Ceil(float64(totalRecords) / float64(pageSize)))
@@ -105,12 +103,9 @@ func (s *orderItemHandleGrpc) FindByTrashed(ctx context.Context, request *pb.Fin
 		pageSize = 10
 	}
 
-	orderItems, totalRecords, err := s.orderItemService.FindByTrashed(search, page, pageSize)
+	orderItems, totalRecords, err := s.orderItemService.FindByTrashed(ctx, search, page, pageSize)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch trashed order items: ",
-		})
+		return nil, toGrpcError(err)
 	}
 
 	totalPages := int(math.Ceil(float64(totalRecords) / float64(pageSize)))
@@ -134,12 +129,15 @@ func (s *orderItemHandleGrpc) FindOrderItemByOrder(ctx context.Context, request
 		})
 	}
 
-	orderItems, err := s.orderItemService.FindOrderItemByOrder(int(request.GetId()))
+	if err := authorizeOwner(ctx, func(user_id int) *response.ErrorResponse {
+		return s.ownershipService.AuthorizeOrderAccess(ctx, user_id, int(request.GetId()))
+	}); err != nil {
+		return nil, err
+	}
+
+	orderItems, err := s.orderItemService.FindOrderItemByOrder(ctx, int(request.GetId()))
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch order items by order: ",
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponsesOrderItem("success", "Successfully fetched order items by order", orderItems)
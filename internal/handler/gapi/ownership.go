@@ -0,0 +1,75 @@
+package gapi
+
+import (
+	"context"
+	"ecommerce/internal/domain/response"
+	"ecommerce/internal/middlewares"
+	"ecommerce/pkg/auth"
+)
+
+// callerID returns the ID of the user the auth interceptor authenticated.
+func callerID(ctx context.Context) (int, error) {
+	userID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		return 0, toGrpcError(&response.ErrorResponse{
+			Status:  "error",
+			Message: "Unauthorized: missing caller identity",
+			Code:    response.ErrCodeUnauthorized,
+		})
+	}
+
+	return userID, nil
+}
+
+func isAdmin(ctx context.Context) bool {
+	return middlewares.HasPermission(auth.RolesFromContext(ctx), middlewares.PermissionAdmin)
+}
+
+// authorizeOwner runs check for the authenticated caller and turns a failed
+// ownership check into a gRPC status. Admins skip the check.
+func authorizeOwner(ctx context.Context, check func(user_id int) *response.ErrorResponse) error {
+	if isAdmin(ctx) {
+		return nil
+	}
+
+	userID, err := callerID(ctx)
+	if err != nil {
+		return err
+	}
+
+	if errResp := check(userID); errResp != nil {
+		return toGrpcError(errResp)
+	}
+
+	return nil
+}
+
+// authorizeUser lets a caller act on their own account only. Admins may act
+// on any account.
+func authorizeUser(ctx context.Context, user_id int) error {
+	return authorizeOwner(ctx, func(caller_id int) *response.ErrorResponse {
+		if caller_id != user_id {
+			return &response.ErrorResponse{
+				Status:  "error",
+				Message: "You cannot access another user's account",
+				Code:    response.ErrCodeForbidden,
+			}
+		}
+
+		return nil
+	})
+}
+
+// authorizeSelf rejects writes that assign a resource to a user other than
+// the caller.
+func authorizeSelf(user_id int, owner_id int) *response.ErrorResponse {
+	if user_id != owner_id {
+		return &response.ErrorResponse{
+			Status:  "error",
+			Message: "You cannot assign a merchant to another user",
+			Code:    response.ErrCodeForbidden,
+		}
+	}
+
+	return nil
+}
@@ -3,6 +3,7 @@ package gapi
 import (
 	"context"
 	"ecommerce/internal/domain/requests"
+	"ecommerce/internal/domain/response"
 	protomapper "ecommerce/internal/mapper/proto"
 	"ecommerce/internal/pb"
 	"ecommerce/internal/service"
@@ -15,17 +16,20 @@ import (
 
 type productHandleGrpc struct {
 	pb.UnimplementedProductServiceServer
-	productService service.ProductService
-	mapping        protomapper.ProductProtoMapper
+	productService   service.ProductService
+	ownershipService service.OwnershipService
+	mapping          protomapper.ProductProtoMapper
 }
 
 func NewProductHandleGrpc(
 	productService service.ProductService,
+	ownershipService service.OwnershipService,
 	mapping protomapper.ProductProtoMapper,
 ) *productHandleGrpc {
 	return &productHandleGrpc{
-		productService: productService,
-		mapping:        mapping,
+		productService:   productService,
+		ownershipService: ownershipService,
+		mapping:          mapping,
 	}
 }
 
@@ -41,13 +45,32 @@ func (s *productHandleGrpc) FindAll(ctx context.Context, request *pb.FindAllProd
 		pageSize = 10
 	}
 
-	product, totalRecords, err := s.productService.FindAll(page, pageSize, search)
+	filter := toProductFilter(request.GetFilter())
+
+	if request.Cursor != nil {
+		if !filter.IsEmpty() {
+			return nil, toGrpcError(&response.ErrorResponse{Status: "error", Message: "Cursor pagination does not support filters or sorting", Code: response.ErrCodeValidation})
+		}
+
+		product, nextCursor, err := s.productService.FindAllCursor(ctx, request.GetCursor(), pageSize, search)
+
+		if err != nil {
+			return nil, toGrpcError(err)
+		}
+
+		paginationMeta := &pb.PaginationMeta{
+			PageSize:   int32(pageSize),
+			NextCursor: nextCursor,
+		}
+
+		so := s.mapping.ToProtoResponsePaginationProduct(paginationMeta, "success", "Successfully fetched product", product)
+		return so, nil
+	}
+
+	product, totalRecords, err := s.productService.FindAll(ctx, page, pageSize, search, filter)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch product: ",
-		})
+		return nil, toGrpcError(err)
 	}
 
 	totalPages := int(math.Ceil(float64(totalRecords) / float64(pageSize)))
@@ -76,13 +99,46 @@ func (s *productHandleGrpc) FindByMerchant(ctx context.Context, request *pb.Find
 		pageSize = 10
 	}
 
-	product, totalRecords, err := s.productService.FindByMerchant(merchant_id, page, pageSize, search)
+	product, totalRecords, err := s.productService.FindByMerchant(ctx, merchant_id, page, pageSize, search, toProductFilter(request.GetFilter()))
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch product: ",
-		})
+		return nil, toGrpcError(err)
+	}
+
+	totalPages := int(math.Ceil(float64(totalRecords) / float64(pageSize)))
+
+	paginationMeta := &pb.PaginationMeta{
+		CurrentPage:  int32(page),
+		PageSize:     int32(pageSize),
+		TotalPages:   int32(totalPages),
+		TotalRecords: int32(totalRecords),
+	}
+
+	so := s.mapping.ToProtoResponsePaginationProduct(paginationMeta, "success", "Successfully fetched product", product)
+	return so, nil
+}
+
+func (s *productHandleGrpc) FindMe(ctx context.Context, request *pb.FindAllProductRequest) (*pb.ApiResponsePaginationProduct, error) {
+	user_id, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	page := int(request.GetPage())
+	pageSize := int(request.GetPageSize())
+	search := request.GetSearch()
+
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+
+	product, totalRecords, errResp := s.productService.FindByUser(ctx, user_id, page, pageSize, search)
+
+	if errResp != nil {
+		return nil, toGrpcError(errResp)
 	}
 
 	totalPages := int(math.Ceil(float64(totalRecords) / float64(pageSize)))
@@ -111,13 +167,10 @@ func (s *productHandleGrpc) FindByCategory(ctx context.Context, request *pb.Find
 		pageSize = 10
 	}
 
-	product, totalRecords, err := s.productService.FindByCategory(category_name, page, pageSize, search)
+	product, totalRecords, err := s.productService.FindByCategory(ctx, category_name, page, pageSize, search, toProductFilter(request.GetFilter()))
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch product: ",
-		})
+		return nil, toGrpcError(err)
 	}
 
 	totalPages := int(math.Ceil(float64(totalRecords) / float64(pageSize)))
@@ -133,6 +186,47 @@ func (s *productHandleGrpc) FindByCategory(ctx context.Context, request *pb.Find
 	return so, nil
 }
 
+func (s *productHandleGrpc) Search(ctx context.Context, request *pb.SearchProductRequest) (*pb.ApiResponseProductSearch, error) {
+	page := int(request.GetPage())
+	pageSize := int(request.GetPageSize())
+
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+
+	req := &requests.SearchProductRequest{
+		Query:      request.GetQuery(),
+		CategoryID: int(request.GetCategoryId()),
+		MerchantID: int(request.GetMerchantId()),
+		Brand:      request.GetBrand(),
+		MinPrice:   request.GetMinPrice(),
+		MaxPrice:   request.GetMaxPrice(),
+		Page:       page,
+		PageSize:   pageSize,
+	}
+
+	products, facets, totalRecords, err := s.productService.Search(ctx, req)
+
+	if err != nil {
+		return nil, toGrpcError(err)
+	}
+
+	totalPages := int(math.Ceil(float64(totalRecords) / float64(pageSize)))
+
+	paginationMeta := &pb.PaginationMeta{
+		CurrentPage:  int32(page),
+		PageSize:     int32(pageSize),
+		TotalPages:   int32(totalPages),
+		TotalRecords: int32(totalRecords),
+	}
+
+	so := s.mapping.ToProtoResponseProductSearch(paginationMeta, "success", "Successfully searched products", products, facets)
+	return so, nil
+}
+
 func (s *productHandleGrpc) FindById(ctx context.Context, request *pb.FindByIdProductRequest) (*pb.ApiResponseProduct, error) {
 	if request.GetId() == 0 {
 		return nil, status.Errorf(codes.InvalidArgument, "%v", &pb.ErrorResponse{
@@ -141,13 +235,10 @@ func (s *productHandleGrpc) FindById(ctx context.Context, request *pb.FindByIdPr
 		})
 	}
 
-	product, err := s.productService.FindById(int(request.GetId()))
+	product, err := s.productService.FindById(ctx, int(request.GetId()))
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch product: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseProduct("success", "Successfully fetched product", product)
@@ -168,13 +259,10 @@ func (s *productHandleGrpc) FindByActive(ctx context.Context, request *pb.FindAl
 		pageSize = 10
 	}
 
-	product, totalRecords, err := s.productService.FindByActive(search, page, pageSize)
+	product, totalRecords, err := s.productService.FindByActive(ctx, search, page, pageSize)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch active product: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	totalPages := int(math.Ceil(float64(totalRecords) / float64(pageSize)))
@@ -202,13 +290,10 @@ func (s *productHandleGrpc) FindByTrashed(ctx context.Context, request *pb.FindA
 		pageSize = 10
 	}
 
-	users, totalRecords, err := s.productService.FindByTrashed(search, page, pageSize)
+	users, totalRecords, err := s.productService.FindByTrashed(ctx, search, page, pageSize)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch trashed product: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	totalPages := int(math.Ceil(float64(totalRecords) / float64(pageSize)))
@@ -231,7 +316,7 @@ func (s *productHandleGrpc) Create(ctx context.Context, request *pb.CreateProduc
 		CategoryID:   int(request.GetCategoryId()),
 		Name:         request.GetName(),
 		Description:  request.GetDescription(),
-		Price:        int(request.GetPrice()),
+		Price:        request.GetPrice(),
 		CountInStock: int(request.GetCountInStock()),
 		Brand:        request.GetBrand(),
 		Weight:       int(request.GetWeight()),
@@ -248,12 +333,15 @@ func (s *productHandleGrpc) Create(ctx context.Context, request *pb.CreateProduc
 		})
 	}
 
-	product, err := s.productService.CreateProduct(req)
+	if err := authorizeOwner(ctx, func(user_id int) *response.ErrorResponse {
+		return s.ownershipService.AuthorizeMerchant(ctx, user_id, req.MerchantID)
+	}); err != nil {
+		return nil, err
+	}
+
+	product, err := s.productService.CreateProduct(ctx, req)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to create product: ",
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseProduct("success", "Successfully created product", product)
@@ -274,7 +362,7 @@ func (s *productHandleGrpc) Update(ctx context.Context, request *pb.UpdateProduc
 		CategoryID:   int(request.GetCategoryId()),
 		Name:         request.GetName(),
 		Description:  request.GetDescription(),
-		Price:        int(request.GetPrice()),
+		Price:        request.GetPrice(),
 		CountInStock: int(request.GetCountInStock()),
 		Brand:        request.GetBrand(),
 		Weight:       int(request.GetWeight()),
@@ -291,12 +379,19 @@ func (s *productHandleGrpc) Update(ctx context.Context, request *pb.UpdateProduc
 		})
 	}
 
-	product, err := s.productService.UpdateProduct(req)
+	if err := authorizeOwner(ctx, func(user_id int) *response.ErrorResponse {
+		if errResp := s.ownershipService.AuthorizeProduct(ctx, user_id, req.ProductID); errResp != nil {
+			return errResp
+		}
+
+		return s.ownershipService.AuthorizeMerchant(ctx, user_id, req.MerchantID)
+	}); err != nil {
+		return nil, err
+	}
+
+	product, err := s.productService.UpdateProduct(ctx, req)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to update product: ",
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseProduct("success", "Successfully updated product", product)
@@ -311,13 +406,10 @@ func (s *productHandleGrpc) TrashedProduct(ctx context.Context, request *pb.Find
 		})
 	}
 
-	product, err := s.productService.TrashProduct(int(request.GetId()))
+	product, err := s.productService.TrashProduct(ctx, int(request.GetId()))
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to trashed product: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseProductDeleteAt("success", "Successfully trashed product", product)
@@ -333,13 +425,10 @@ func (s *productHandleGrpc) RestoreProduct(ctx context.Context, request *pb.Find
 		})
 	}
 
-	product, err := s.productService.RestoreProduct(int(request.GetId()))
+	product, err := s.productService.RestoreProduct(ctx, int(request.GetId()))
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to restore product: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseProductDeleteAt("success", "Successfully restored product", product)
@@ -355,13 +444,10 @@ func (s *productHandleGrpc) DeleteProductPermanent(ctx context.Context, request
 		})
 	}
 
-	_, err := s.productService.DeleteProductPermanent(int(request.GetId()))
+	_, err := s.productService.DeleteProductPermanent(ctx, int(request.GetId()))
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to delete Product permanently: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseProductDelete("success", "Successfully deleted Product permanently")
@@ -370,13 +456,10 @@ func (s *productHandleGrpc) DeleteProductPermanent(ctx context.Context, request
 }
 
 func (s *productHandleGrpc) RestoreAllProduct(ctx context.Context, _ *emptypb.Empty) (*pb.ApiResponseProductAll, error) {
-	_, err := s.productService.RestoreAllProducts()
+	_, err := s.productService.RestoreAllProducts(ctx)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to restore all Product: ",
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseProductAll("success", "Successfully restore all Product")
@@ -385,16 +468,24 @@ func (s *productHandleGrpc) RestoreAllProduct(ctx context.Context, _ *emptypb.Em
 }
 
 func (s *productHandleGrpc) DeleteAllProductPermanent(ctx context.Context, _ *emptypb.Empty) (*pb.ApiResponseProductAll, error) {
-	_, err := s.productService.DeleteAllProductsPermanent()
+	_, err := s.productService.DeleteAllProductsPermanent(ctx)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to delete Product permanent: ",
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseProductAll("success", "Successfully delete Product permanen")
 
 	return so, nil
 }
+
+func toProductFilter(filter *pb.ProductFilter) *requests.ProductFilter {
+	return &requests.ProductFilter{
+		MinPrice:  filter.GetMinPrice(),
+		MaxPrice:  filter.GetMaxPrice(),
+		MinRating: filter.GetMinRating(),
+		Brand:     filter.GetBrand(),
+		InStock:   filter.GetInStock(),
+		Sort:      filter.GetSort(),
+	}
+}
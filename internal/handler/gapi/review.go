@@ -3,6 +3,7 @@ package gapi
 import (
 	"context"
 	"ecommerce/internal/domain/requests"
+	"ecommerce/internal/domain/response"
 	protomapper "ecommerce/internal/mapper/proto"
 	"ecommerce/internal/pb"
 	"ecommerce/internal/service"
@@ -15,17 +16,20 @@ import (
 
 type reviewHandleGrpc struct {
 	pb.UnimplementedReviewServiceServer
-	reviewService service.ReviewService
-	mapping       protomapper.ReviewProtoMapper
+	reviewService    service.ReviewService
+	ownershipService service.OwnershipService
+	mapping          protomapper.ReviewProtoMapper
 }
 
 func NewReviewHandleGrpc(
 	reviewService service.ReviewService,
+	ownershipService service.OwnershipService,
 	mapping protomapper.ReviewProtoMapper,
 ) *reviewHandleGrpc {
 	return &reviewHandleGrpc{
-		reviewService: reviewService,
-		mapping:       mapping,
+		reviewService:    reviewService,
+		ownershipService: ownershipService,
+		mapping:          mapping,
 	}
 }
 
@@ -41,13 +45,26 @@ func (s *reviewHandleGrpc) FindAll(ctx context.Context, request *pb.FindAllRevie
 		pageSize = 10
 	}
 
-	Review, totalRecords, err := s.reviewService.FindAllReviews(search, page, pageSize)
+	if request.Cursor != nil {
+		reviews, nextCursor, err := s.reviewService.FindAllReviewsCursor(ctx, request.GetCursor(), pageSize, search)
+
+		if err != nil {
+			return nil, toGrpcError(err)
+		}
+
+		paginationMeta := &pb.PaginationMeta{
+			PageSize:   int32(pageSize),
+			NextCursor: nextCursor,
+		}
+
+		so := s.mapping.ToProtoResponsePaginationReview(paginationMeta, "success", "Successfully fetched reviews", reviews)
+		return so, nil
+	}
+
+	Review, totalRecords, err := s.reviewService.FindAllReviews(ctx, search, page, pageSize)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch reviews: ",
-		})
+		return nil, toGrpcError(err)
 	}
 
 	totalPages := int(math.Ceil(float64(totalRecords) / float64(pageSize)))
@@ -76,13 +93,10 @@ func (s *reviewHandleGrpc) FindByProduct(ctx context.Context, request *pb.FindAl
 		pageSize = 10
 	}
 
-	Review, totalRecords, err := s.reviewService.FindByProduct(product_id, search, page, pageSize)
+	Review, totalRecords, err := s.reviewService.FindByProduct(ctx, product_id, search, page, pageSize)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch reviews: ",
-		})
+		return nil, toGrpcError(err)
 	}
 
 	totalPages := int(math.Ceil(float64(totalRecords) / float64(pageSize)))
@@ -110,13 +124,10 @@ func (s *reviewHandleGrpc) FindByActive(ctx context.Context, request *pb.FindAll
 		pageSize = 10
 	}
 
-	users, totalRecords, err := s.reviewService.FindByActive(search, page, pageSize)
+	users, totalRecords, err := s.reviewService.FindByActive(ctx, search, page, pageSize)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch active reviews: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	totalPages := int(math.Ceil(float64(totalRecords) / float64(pageSize)))
@@ -144,13 +155,10 @@ func (s *reviewHandleGrpc) FindByTrashed(ctx context.Context, request *pb.FindAl
 		pageSize = 10
 	}
 
-	users, totalRecords, err := s.reviewService.FindByTrashed(search, page, pageSize)
+	users, totalRecords, err := s.reviewService.FindByTrashed(ctx, search, page, pageSize)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch trashed reviews: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	totalPages := int(math.Ceil(float64(totalRecords) / float64(pageSize)))
@@ -168,8 +176,13 @@ func (s *reviewHandleGrpc) FindByTrashed(ctx context.Context, request *pb.FindAl
 }
 
 func (s *reviewHandleGrpc) Create(ctx context.Context, request *pb.CreateReviewRequest) (*pb.ApiResponseReview, error) {
+	user_id, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	req := &requests.CreateReviewRequest{
-		UserID:    int(request.GetUserId()),
+		UserID:    user_id,
 		ProductID: int(request.GetProductId()),
 		Rating:    int(request.GetRating()),
 		Comment:   request.GetComment(),
@@ -179,9 +192,9 @@ func (s *reviewHandleGrpc) Create(ctx context.Context, request *pb.CreateReviewR
 		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err.Error())
 	}
 
-	review, err := s.reviewService.CreateReview(req)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Failed to create review: ")
+	review, errResp := s.reviewService.CreateReview(ctx, req)
+	if errResp != nil {
+		return nil, toGrpcError(errResp)
 	}
 
 	return s.mapping.ToProtoResponseReview("success", "Successfully created review", review), nil
@@ -203,10 +216,15 @@ func (s *reviewHandleGrpc) Update(ctx context.Context, request *pb.UpdateReviewR
 		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err.Error())
 	}
 
-	review, err := s.reviewService.UpdateReview(req)
-	if err != nil {
+	if err := authorizeOwner(ctx, func(user_id int) *response.ErrorResponse {
+		return s.ownershipService.AuthorizeReview(ctx, user_id, req.ReviewID)
+	}); err != nil {
+		return nil, err
+	}
 
-		return nil, status.Errorf(codes.Internal, "Failed to update review: ")
+	review, err := s.reviewService.UpdateReview(ctx, req)
+	if err != nil {
+		return nil, toGrpcError(err)
 	}
 
 	return s.mapping.ToProtoResponseReview("success", "Successfully updated review", review), nil
@@ -220,13 +238,10 @@ func (s *reviewHandleGrpc) TrashedReview(ctx context.Context, request *pb.FindBy
 		})
 	}
 
-	Review, err := s.reviewService.TrashedReview(int(request.GetId()))
+	Review, err := s.reviewService.TrashedReview(ctx, int(request.GetId()))
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to trashed Review: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseReviewDeleteAt("success", "Successfully trashed Review", Review)
@@ -242,13 +257,10 @@ func (s *reviewHandleGrpc) RestoreReview(ctx context.Context, request *pb.FindBy
 		})
 	}
 
-	Review, err := s.reviewService.RestoreReview(int(request.GetId()))
+	Review, err := s.reviewService.RestoreReview(ctx, int(request.GetId()))
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to restore Review: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseReviewDeleteAt("success", "Successfully restored Review", Review)
@@ -264,13 +276,10 @@ func (s *reviewHandleGrpc) DeleteReviewPermanent(ctx context.Context, request *p
 		})
 	}
 
-	_, err := s.reviewService.DeleteReviewPermanent(int(request.GetId()))
+	_, err := s.reviewService.DeleteReviewPermanent(ctx, int(request.GetId()))
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to delete Review permanently: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseReviewDelete("success", "Successfully deleted Review permanently")
@@ -279,13 +288,10 @@ func (s *reviewHandleGrpc) DeleteReviewPermanent(ctx context.Context, request *p
 }
 
 func (s *reviewHandleGrpc) RestoreAllReview(ctx context.Context, _ *emptypb.Empty) (*pb.ApiResponseReviewAll, error) {
-	_, err := s.reviewService.RestoreAllReviews()
+	_, err := s.reviewService.RestoreAllReviews(ctx)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to restore all Review: ",
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseReviewAll("success", "Successfully restore all Review")
@@ -294,13 +300,10 @@ func (s *reviewHandleGrpc) RestoreAllReview(ctx context.Context, _ *emptypb.Empt
 }
 
 func (s *reviewHandleGrpc) DeleteAllReviewPermanent(ctx context.Context, _ *emptypb.Empty) (*pb.ApiResponseReviewAll, error) {
-	_, err := s.reviewService.DeleteAllReviewsPermanent()
+	_, err := s.reviewService.DeleteAllReviewsPermanent(ctx)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to delete Review permanent: ",
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseReviewAll("success", "Successfully delete Review permanen")
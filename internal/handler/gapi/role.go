@@ -8,8 +8,6 @@ import (
 	"ecommerce/internal/service"
 	"math"
 
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -38,13 +36,10 @@ func (s *roleHandleGrpc) FindAllRole(ctx context.Context, req *pb.FindAllRoleReq
 		pageSize = 10
 	}
 
-	role, totalRecords, err := s.roleService.FindAll(page, pageSize, search)
+	role, totalRecords, err := s.roleService.FindAll(ctx, page, pageSize, search)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch card records: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	totalPages := int(math.Ceil(float64(totalRecords) / float64(pageSize)))
@@ -64,13 +59,10 @@ func (s *roleHandleGrpc) FindAllRole(ctx context.Context, req *pb.FindAllRoleReq
 func (s *roleHandleGrpc) FindByIdRole(ctx context.Context, req *pb.FindByIdRoleRequest) (*pb.ApiResponseRole, error) {
 	roleID := int(req.GetRoleId())
 
-	role, err := s.roleService.FindById(roleID)
+	role, err := s.roleService.FindById(ctx, roleID)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch role: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	roleResponse := s.mapping.ToProtoResponseRole("success", "Successfully fetched role", role)
@@ -81,13 +73,10 @@ func (s *roleHandleGrpc) FindByIdRole(ctx context.Context, req *pb.FindByIdRoleR
 func (s *roleHandleGrpc) FindByUserId(ctx context.Context, req *pb.FindByIdUserRoleRequest) (*pb.ApiResponsesRole, error) {
 	userID := int(req.GetUserId())
 
-	role, err := s.roleService.FindByUserId(userID)
+	role, err := s.roleService.FindByUserId(ctx, userID)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch role by user ID: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	roleResponse := s.mapping.ToProtoResponsesRole("success", "Successfully fetched role by user ID", role)
@@ -107,13 +96,10 @@ func (s *roleHandleGrpc) FindByActive(ctx context.Context, req *pb.FindAllRoleRe
 		pageSize = 10
 	}
 
-	roles, totalRecords, err := s.roleService.FindByActiveRole(page, pageSize, search)
+	roles, totalRecords, err := s.roleService.FindByActiveRole(ctx, page, pageSize, search)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch active roles: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	totalPages := int(math.Ceil(float64(totalRecords) / float64(pageSize)))
@@ -141,13 +127,10 @@ func (s *roleHandleGrpc) FindByTrashed(ctx context.Context, req *pb.FindAllRoleR
 		pageSize = 10
 	}
 
-	roles, totalRecords, err := s.roleService.FindByTrashedRole(page, pageSize, search)
+	roles, totalRecords, err := s.roleService.FindByTrashedRole(ctx, page, pageSize, search)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch trashed roles: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	totalPages := int(math.Ceil(float64(totalRecords) / float64(pageSize)))
@@ -166,15 +149,12 @@ func (s *roleHandleGrpc) FindByTrashed(ctx context.Context, req *pb.FindAllRoleR
 func (s *roleHandleGrpc) CreateRole(ctx context.Context, req *pb.CreateRoleRequest) (*pb.ApiResponseRole, error) {
 	name := req.GetName()
 
-	role, err := s.roleService.CreateRole(&requests.CreateRoleRequest{
+	role, err := s.roleService.CreateRole(ctx, &requests.CreateRoleRequest{
 		Name: name,
 	})
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to create role: t" + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseRole("success", "Successfully created role", role)
@@ -186,16 +166,13 @@ func (s *roleHandleGrpc) UpdateRole(ctx context.Context, req *pb.UpdateRoleReque
 	roleID := int(req.GetId())
 	name := req.GetName()
 
-	role, err := s.roleService.UpdateRole(&requests.UpdateRoleRequest{
+	role, err := s.roleService.UpdateRole(ctx, &requests.UpdateRoleRequest{
 		ID:   roleID,
 		Name: name,
 	})
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to update role: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseRole("success", "Successfully updated role", role)
@@ -206,13 +183,10 @@ func (s *roleHandleGrpc) UpdateRole(ctx context.Context, req *pb.UpdateRoleReque
 func (s *roleHandleGrpc) TrashedRole(ctx context.Context, req *pb.FindByIdRoleRequest) (*pb.ApiResponseRole, error) {
 	roleID := int(req.GetRoleId())
 
-	role, err := s.roleService.TrashedRole(roleID)
+	role, err := s.roleService.TrashedRole(ctx, roleID)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to trash role: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseRole("success", "Successfully trashed role", role)
@@ -223,13 +197,10 @@ func (s *roleHandleGrpc) TrashedRole(ctx context.Context, req *pb.FindByIdRoleRe
 func (s *roleHandleGrpc) RestoreRole(ctx context.Context, req *pb.FindByIdRoleRequest) (*pb.ApiResponseRole, error) {
 	roleID := int(req.GetRoleId())
 
-	role, err := s.roleService.RestoreRole(roleID)
+	role, err := s.roleService.RestoreRole(ctx, roleID)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to restore role: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseRole("success", "Successfully restored role", role)
@@ -240,13 +211,10 @@ func (s *roleHandleGrpc) RestoreRole(ctx context.Context, req *pb.FindByIdRoleRe
 func (s *roleHandleGrpc) DeleteRolePermanent(ctx context.Context, req *pb.FindByIdRoleRequest) (*pb.ApiResponseRoleDelete, error) {
 	roleID := int(req.GetRoleId())
 
-	_, err := s.roleService.DeleteRolePermanent(roleID)
+	_, err := s.roleService.DeleteRolePermanent(ctx, roleID)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to delete role permanently: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseRoleDelete("success", "Successfully deleted role permanently")
@@ -255,13 +223,10 @@ func (s *roleHandleGrpc) DeleteRolePermanent(ctx context.Context, req *pb.FindBy
 }
 
 func (s *roleHandleGrpc) RestoreAllRole(ctx context.Context, req *emptypb.Empty) (*pb.ApiResponseRoleAll, error) {
-	_, err := s.roleService.RestoreAllRole()
+	_, err := s.roleService.RestoreAllRole(ctx)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to restore all roles: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseRoleAll("success", "Successfully restored all roles")
@@ -270,13 +235,10 @@ func (s *roleHandleGrpc) RestoreAllRole(ctx context.Context, req *emptypb.Empty)
 }
 
 func (s *roleHandleGrpc) DeleteAllRolePermanent(ctx context.Context, req *emptypb.Empty) (*pb.ApiResponseRoleAll, error) {
-	_, err := s.roleService.DeleteAllRolePermanent()
+	_, err := s.roleService.DeleteAllRolePermanent(ctx)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to delete all roles permanently: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseRoleAll("success", "Successfully deleted all roles")
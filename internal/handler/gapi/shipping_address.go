@@ -2,6 +2,7 @@ package gapi
 
 import (
 	"context"
+	"ecommerce/internal/domain/response"
 	protomapper "ecommerce/internal/mapper/proto"
 	"ecommerce/internal/pb"
 	"ecommerce/internal/service"
@@ -14,17 +15,20 @@ import (
 
 type shippingAddressHandleGrpc struct {
 	pb.UnimplementedShippingServiceServer
-	shippingService service.ShippingAddressService
-	mapping         protomapper.ShippingAddresProtoMapper
+	shippingService  service.ShippingAddressService
+	ownershipService service.OwnershipService
+	mapping          protomapper.ShippingAddresProtoMapper
 }
 
 func NewShippingAddressHandleGrpc(
 	shipping service.ShippingAddressService,
+	ownershipService service.OwnershipService,
 	mapping protomapper.ShippingAddresProtoMapper,
 ) *shippingAddressHandleGrpc {
 	return &shippingAddressHandleGrpc{
-		shippingService: shipping,
-		mapping:         mapping,
+		shippingService:  shipping,
+		ownershipService: ownershipService,
+		mapping:          mapping,
 	}
 }
 
@@ -40,13 +44,10 @@ func (s *shippingAddressHandleGrpc) FindAll(ctx context.Context, request *pb.Fin
 		pageSize = 10
 	}
 
-	Shipping, totalRecords, err := s.shippingService.FindAll(page, pageSize, search)
+	Shipping, totalRecords, err := s.shippingService.FindAll(ctx, page, pageSize, search)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch Shippings: ",
-		})
+		return nil, toGrpcError(err)
 	}
 
 	totalPages := int(math.Ceil(float64(totalRecords) / float64(pageSize)))
@@ -70,13 +71,16 @@ func (s *shippingAddressHandleGrpc) FindById(ctx context.Context, request *pb.Fi
 		})
 	}
 
-	shipping, err := s.shippingService.FindById(int(request.GetId()))
+	if err := authorizeOwner(ctx, func(user_id int) *response.ErrorResponse {
+		return s.ownershipService.AuthorizeShippingAccess(ctx, user_id, int(request.GetId()))
+	}); err != nil {
+		return nil, err
+	}
+
+	shipping, err := s.shippingService.FindById(ctx, int(request.GetId()))
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch shipping address: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseShippingAddress("success", "Successfully fetched shipping address", shipping)
@@ -97,13 +101,10 @@ func (s *shippingAddressHandleGrpc) FindByActive(ctx context.Context, request *p
 		pageSize = 10
 	}
 
-	users, totalRecords, err := s.shippingService.FindByActive(search, page, pageSize)
+	users, totalRecords, err := s.shippingService.FindByActive(ctx, search, page, pageSize)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch active Shippings: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	totalPages := int(math.Ceil(float64(totalRecords) / float64(pageSize)))
@@ -131,13 +132,10 @@ func (s *shippingAddressHandleGrpc) FindByTrashed(ctx context.Context, request *
 		pageSize = 10
 	}
 
-	users, totalRecords, err := s.shippingService.FindByTrashed(search, page, pageSize)
+	users, totalRecords, err := s.shippingService.FindByTrashed(ctx, search, page, pageSize)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch trashed Shippings: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	totalPages := int(math.Ceil(float64(totalRecords) / float64(pageSize)))
@@ -162,13 +160,10 @@ func (s *shippingAddressHandleGrpc) TrashedShipping(ctx context.Context, request
 		})
 	}
 
-	Shipping, err := s.shippingService.TrashShippingAddress(int(request.GetId()))
+	Shipping, err := s.shippingService.TrashShippingAddress(ctx, int(request.GetId()))
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to trashed Shipping: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseShippingAddressDeleteAt("success", "Successfully trashed Shipping", Shipping)
@@ -184,13 +179,10 @@ func (s *shippingAddressHandleGrpc) RestoreShipping(ctx context.Context, request
 		})
 	}
 
-	Shipping, err := s.shippingService.RestoreShippingAddress(int(request.GetId()))
+	Shipping, err := s.shippingService.RestoreShippingAddress(ctx, int(request.GetId()))
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to restore Shipping: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseShippingAddressDeleteAt("success", "Successfully restored Shipping", Shipping)
@@ -206,13 +198,10 @@ func (s *shippingAddressHandleGrpc) DeleteShippingPermanent(ctx context.Context,
 		})
 	}
 
-	_, err := s.shippingService.DeleteShippingAddressPermanently(int(request.GetId()))
+	_, err := s.shippingService.DeleteShippingAddressPermanently(ctx, int(request.GetId()))
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to delete Shipping permanently: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseShippingAddressDelete("success", "Successfully deleted Shipping permanently")
@@ -221,13 +210,10 @@ func (s *shippingAddressHandleGrpc) DeleteShippingPermanent(ctx context.Context,
 }
 
 func (s *shippingAddressHandleGrpc) RestoreAllShipping(ctx context.Context, _ *emptypb.Empty) (*pb.ApiResponseShippingAll, error) {
-	_, err := s.shippingService.RestoreAllShippingAddress()
+	_, err := s.shippingService.RestoreAllShippingAddress(ctx)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to restore all Shipping: ",
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseShippingAddressAll("success", "Successfully restore all Shipping")
@@ -236,13 +222,10 @@ func (s *shippingAddressHandleGrpc) RestoreAllShipping(ctx context.Context, _ *e
 }
 
 func (s *shippingAddressHandleGrpc) DeleteShippingAddressPermanently(ctx context.Context, _ *emptypb.Empty) (*pb.ApiResponseShippingAll, error) {
-	_, err := s.shippingService.DeleteAllPermanentShippingAddress()
+	_, err := s.shippingService.DeleteAllPermanentShippingAddress(ctx)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to delete Shipping permanent: ",
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseShippingAddressAll("success", "Successfully delete Shipping permanen")
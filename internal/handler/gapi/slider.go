@@ -41,13 +41,10 @@ func (s *sliderHandleGrpc) FindAll(ctx context.Context, request *pb.FindAllSlide
 		pageSize = 10
 	}
 
-	category, totalRecords, err := s.sliderService.FindAll(page, pageSize, search)
+	category, totalRecords, err := s.sliderService.FindAll(ctx, page, pageSize, search)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch sliders: ",
-		})
+		return nil, toGrpcError(err)
 	}
 
 	totalPages := int(math.Ceil(float64(totalRecords) / float64(pageSize)))
@@ -75,13 +72,10 @@ func (s *sliderHandleGrpc) FindByActive(ctx context.Context, request *pb.FindAll
 		pageSize = 10
 	}
 
-	users, totalRecords, err := s.sliderService.FindByActive(search, page, pageSize)
+	users, totalRecords, err := s.sliderService.FindByActive(ctx, search, page, pageSize)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch active categories: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	totalPages := int(math.Ceil(float64(totalRecords) / float64(pageSize)))
@@ -109,13 +103,10 @@ func (s *sliderHandleGrpc) FindByTrashed(ctx context.Context, request *pb.FindAl
 		pageSize = 10
 	}
 
-	users, totalRecords, err := s.sliderService.FindByTrashed(search, page, pageSize)
+	users, totalRecords, err := s.sliderService.FindByTrashed(ctx, search, page, pageSize)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch trashed slider: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	totalPages := int(math.Ceil(float64(totalRecords) / float64(pageSize)))
@@ -142,9 +133,9 @@ func (s *sliderHandleGrpc) Create(ctx context.Context, request *pb.CreateSliderR
 		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err.Error())
 	}
 
-	slider, err := s.sliderService.CreateSlider(req)
+	slider, err := s.sliderService.CreateSlider(ctx, req)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Failed to create slider:")
+		return nil, toGrpcError(err)
 	}
 
 	return s.mapping.ToProtoResponseSlider("success", "Successfully created slider", slider), nil
@@ -165,10 +156,9 @@ func (s *sliderHandleGrpc) Update(ctx context.Context, request *pb.UpdateSliderR
 		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err.Error())
 	}
 
-	slider, err := s.sliderService.UpdateSlider(req)
+	slider, err := s.sliderService.UpdateSlider(ctx, req)
 	if err != nil {
-
-		return nil, status.Errorf(codes.Internal, "Failed to update slider: ")
+		return nil, toGrpcError(err)
 	}
 
 	return s.mapping.ToProtoResponseSlider("success", "Successfully updated slider", slider), nil
@@ -182,13 +172,10 @@ func (s *sliderHandleGrpc) TrashedSlider(ctx context.Context, request *pb.FindBy
 		})
 	}
 
-	slider, err := s.sliderService.TrashedSlider(int(request.GetId()))
+	slider, err := s.sliderService.TrashedSlider(ctx, int(request.GetId()))
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to trashed slider: ",
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseSliderDeleteAt("success", "Successfully trashed slider", slider)
@@ -204,13 +191,10 @@ func (s *sliderHandleGrpc) RestoreSlider(ctx context.Context, request *pb.FindBy
 		})
 	}
 
-	slider, err := s.sliderService.RestoreSlider(int(request.GetId()))
+	slider, err := s.sliderService.RestoreSlider(ctx, int(request.GetId()))
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to restore slider: ",
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseSliderDeleteAt("success", "Successfully restored slider", slider)
@@ -226,13 +210,10 @@ func (s *sliderHandleGrpc) DeleteSliderPermanent(ctx context.Context, request *p
 		})
 	}
 
-	_, err := s.sliderService.DeleteSliderPermanent(int(request.GetId()))
+	_, err := s.sliderService.DeleteSliderPermanent(ctx, int(request.GetId()))
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to delete slider permanently: ",
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseSliderDelete("success", "Successfully deleted slider permanently")
@@ -241,13 +222,10 @@ func (s *sliderHandleGrpc) DeleteSliderPermanent(ctx context.Context, request *p
 }
 
 func (s *sliderHandleGrpc) RestoreAllSlider(ctx context.Context, _ *emptypb.Empty) (*pb.ApiResponseSliderAll, error) {
-	_, err := s.sliderService.RestoreAllSliders()
+	_, err := s.sliderService.RestoreAllSliders(ctx)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to restore all sliders",
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseSliderAll("success", "Successfully restored all sliders")
@@ -256,13 +234,10 @@ func (s *sliderHandleGrpc) RestoreAllSlider(ctx context.Context, _ *emptypb.Empt
 }
 
 func (s *sliderHandleGrpc) DeleteAllSliderPermanent(ctx context.Context, _ *emptypb.Empty) (*pb.ApiResponseSliderAll, error) {
-	_, err := s.sliderService.DeleteAllSlidersPermanent()
+	_, err := s.sliderService.DeleteAllSlidersPermanent(ctx)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to delete all sliders permanently",
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseSliderAll("success", "Successfully deleted all sliders permanently")
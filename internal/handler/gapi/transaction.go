@@ -3,6 +3,7 @@ package gapi
 import (
 	"context"
 	"ecommerce/internal/domain/requests"
+	"ecommerce/internal/domain/response"
 	protomapper "ecommerce/internal/mapper/proto"
 	"ecommerce/internal/pb"
 	"ecommerce/internal/service"
@@ -16,15 +17,18 @@ import (
 type transactionHandleGrpc struct {
 	pb.UnimplementedTransactionServiceServer
 	transactionService service.TransactionService
+	ownershipService   service.OwnershipService
 	mapping            protomapper.TransactionProtoMapper
 }
 
 func NewTransactionHandleGrpc(
 	transactionService service.TransactionService,
+	ownershipService service.OwnershipService,
 	mapping protomapper.TransactionProtoMapper,
 ) *transactionHandleGrpc {
 	return &transactionHandleGrpc{
 		transactionService: transactionService,
+		ownershipService:   ownershipService,
 		mapping:            mapping,
 	}
 }
@@ -41,13 +45,26 @@ func (s *transactionHandleGrpc) FindAll(ctx context.Context, request *pb.FindAll
 		pageSize = 10
 	}
 
-	transaction, totalRecords, err := s.transactionService.FindAllTransactions(search, page, pageSize)
+	if request.Cursor != nil {
+		transactions, nextCursor, err := s.transactionService.FindAllTransactionsCursor(ctx, request.GetCursor(), pageSize, search)
+
+		if err != nil {
+			return nil, toGrpcError(err)
+		}
+
+		paginationMeta := &pb.PaginationMeta{
+			PageSize:   int32(pageSize),
+			NextCursor: nextCursor,
+		}
+
+		so := s.mapping.ToProtoResponsePaginationTransaction(paginationMeta, "success", "Successfully fetched transaction", transactions)
+		return so, nil
+	}
+
+	transaction, totalRecords, err := s.transactionService.FindAllTransactions(ctx, search, page, pageSize)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch transaction: ",
-		})
+		return nil, toGrpcError(err)
 	}
 
 	totalPages := int(math.Ceil(float64(totalRecords) / float64(pageSize)))
@@ -76,13 +93,16 @@ func (s *transactionHandleGrpc) FindByMerchant(ctx context.Context, request *pb.
 		pageSize = 10
 	}
 
-	transaction, totalRecords, err := s.transactionService.FindByMerchant(merchant_id, search, page, pageSize)
+	if err := authorizeOwner(ctx, func(user_id int) *response.ErrorResponse {
+		return s.ownershipService.AuthorizeMerchant(ctx, user_id, merchant_id)
+	}); err != nil {
+		return nil, err
+	}
+
+	transaction, totalRecords, err := s.transactionService.FindByMerchant(ctx, merchant_id, search, page, pageSize)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch transaction: ",
-		})
+		return nil, toGrpcError(err)
 	}
 
 	totalPages := int(math.Ceil(float64(totalRecords) / float64(pageSize)))
@@ -106,13 +126,16 @@ func (s *transactionHandleGrpc) FindById(ctx context.Context, request *pb.FindBy
 		})
 	}
 
-	transaction, err := s.transactionService.FindById(int(request.GetId()))
+	if err := authorizeOwner(ctx, func(user_id int) *response.ErrorResponse {
+		return s.ownershipService.AuthorizeTransactionAccess(ctx, user_id, int(request.GetId()))
+	}); err != nil {
+		return nil, err
+	}
+
+	transaction, err := s.transactionService.FindById(ctx, int(request.GetId()))
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch transaction: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseTransaction("success", "Successfully fetched transaction", transaction)
@@ -133,13 +156,10 @@ func (s *transactionHandleGrpc) FindByActive(ctx context.Context, request *pb.Fi
 		pageSize = 10
 	}
 
-	transaction, totalRecords, err := s.transactionService.FindByActive(search, page, pageSize)
+	transaction, totalRecords, err := s.transactionService.FindByActive(ctx, search, page, pageSize)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch active transaction: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	totalPages := int(math.Ceil(float64(totalRecords) / float64(pageSize)))
@@ -167,13 +187,10 @@ func (s *transactionHandleGrpc) FindByTrashed(ctx context.Context, request *pb.F
 		pageSize = 10
 	}
 
-	transaction, totalRecords, err := s.transactionService.FindByTrashed(search, page, pageSize)
+	transaction, totalRecords, err := s.transactionService.FindByTrashed(ctx, search, page, pageSize)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch trashed transaction: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	totalPages := int(math.Ceil(float64(totalRecords) / float64(pageSize)))
@@ -195,8 +212,8 @@ func (s *transactionHandleGrpc) Create(ctx context.Context, request *pb.CreateTr
 		OrderID:       int(request.GetOrderId()),
 		MerchantID:    int(request.GetMerchantId()),
 		PaymentMethod: request.GetPaymentMethod(),
-		Amount:        int(request.GetAmount()),
-		ChangeAmount:  int(request.GetChangeAmount()),
+		Amount:        request.GetAmount(),
+		ChangeAmount:  request.GetChangeAmount(),
 		PaymentStatus: request.GetPaymentStatus(),
 	}
 
@@ -207,15 +224,51 @@ func (s *transactionHandleGrpc) Create(ctx context.Context, request *pb.CreateTr
 		})
 	}
 
-	transaction, err := s.transactionService.CreateTransaction(req)
+	if err := authorizeOwner(ctx, func(user_id int) *response.ErrorResponse {
+		if errResp := s.ownershipService.AuthorizeOrder(ctx, user_id, req.OrderID); errResp != nil {
+			return errResp
+		}
+
+		return s.ownershipService.AuthorizeMerchant(ctx, user_id, req.MerchantID)
+	}); err != nil {
+		return nil, err
+	}
+
+	transaction, err := s.transactionService.CreateTransaction(ctx, req)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
+		return nil, toGrpcError(err)
+	}
+
+	so := s.mapping.ToProtoResponseTransaction("success", "Successfully created transaction", transaction)
+	return so, nil
+}
+
+// HandlePaymentEvent is reachable without a bearer token; the event is
+// authenticated by the provider signature over its payload instead.
+func (s *transactionHandleGrpc) HandlePaymentEvent(ctx context.Context, request *pb.PaymentEventRequest) (*pb.ApiResponseTransaction, error) {
+	req := &requests.PaymentEventRequest{
+		Payload:   request.GetPayload(),
+		Signature: request.GetSignature(),
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", &pb.ErrorResponse{
 			Status:  "error",
-			Message: "Failed to create transaction: ",
+			Message: "Invalid payment event: " + err.Error(),
 		})
 	}
 
-	so := s.mapping.ToProtoResponseTransaction("success", "Successfully created transaction", transaction)
+	transaction, duplicate, err := s.transactionService.HandlePaymentEvent(ctx, req)
+	if err != nil {
+		return nil, toGrpcError(err)
+	}
+
+	message := "Successfully processed payment event"
+	if duplicate {
+		message = "Payment event already processed"
+	}
+
+	so := s.mapping.ToProtoResponseTransaction("success", message, transaction)
 	return so, nil
 }
 
@@ -232,9 +285,8 @@ func (s *transactionHandleGrpc) Update(ctx context.Context, request *pb.UpdateTr
 		OrderID:       int(request.GetOrderId()),
 		MerchantID:    int(request.GetMerchantId()),
 		PaymentMethod: request.GetPaymentMethod(),
-		Amount:        int(request.GetAmount()),
-		ChangeAmount:  int(request.GetChangeAmount()),
-		PaymentStatus: request.GetPaymentStatus(),
+		Amount:        request.GetAmount(),
+		ChangeAmount:  request.GetChangeAmount(),
 	}
 
 	if err := req.Validate(); err != nil {
@@ -244,15 +296,68 @@ func (s *transactionHandleGrpc) Update(ctx context.Context, request *pb.UpdateTr
 		})
 	}
 
-	transaction, err := s.transactionService.UpdateTransaction(req)
+	if err := authorizeOwner(ctx, func(user_id int) *response.ErrorResponse {
+		if errResp := s.ownershipService.AuthorizeTransaction(ctx, user_id, req.TransactionID); errResp != nil {
+			return errResp
+		}
+
+		if errResp := s.ownershipService.AuthorizeOrder(ctx, user_id, req.OrderID); errResp != nil {
+			return errResp
+		}
+
+		return s.ownershipService.AuthorizeMerchant(ctx, user_id, req.MerchantID)
+	}); err != nil {
+		return nil, err
+	}
+
+	transaction, err := s.transactionService.UpdateTransaction(ctx, req)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
+		return nil, toGrpcError(err)
+	}
+
+	so := s.mapping.ToProtoResponseTransaction("success", "Successfully updated transaction", transaction)
+	return so, nil
+}
+
+func (s *transactionHandleGrpc) RefundTransaction(ctx context.Context, request *pb.RefundTransactionRequest) (*pb.ApiResponseTransaction, error) {
+	if request.GetTransactionId() == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", &pb.ErrorResponse{
 			Status:  "error",
-			Message: "Failed to update transaction: ",
+			Message: "Invalid transaction id",
 		})
 	}
 
-	so := s.mapping.ToProtoResponseTransaction("success", "Successfully updated transaction", transaction)
+	req := &requests.RefundTransactionRequest{
+		TransactionID: int(request.GetTransactionId()),
+		Reason:        request.GetReason(),
+	}
+
+	for _, item := range request.GetItems() {
+		req.Items = append(req.Items, requests.RefundItemRequest{
+			OrderItemID: int(item.GetOrderItemId()),
+			Quantity:    int(item.GetQuantity()),
+		})
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", &pb.ErrorResponse{
+			Status:  "error",
+			Message: "Failed to refund transaction: " + err.Error(),
+		})
+	}
+
+	if err := authorizeOwner(ctx, func(user_id int) *response.ErrorResponse {
+		return s.ownershipService.AuthorizeTransaction(ctx, user_id, req.TransactionID)
+	}); err != nil {
+		return nil, err
+	}
+
+	transaction, err := s.transactionService.RefundTransaction(ctx, req)
+	if err != nil {
+		return nil, toGrpcError(err)
+	}
+
+	so := s.mapping.ToProtoResponseTransaction("success", "Successfully refunded transaction", transaction)
 	return so, nil
 }
 
@@ -264,13 +369,10 @@ func (s *transactionHandleGrpc) TrashedTransaction(ctx context.Context, request
 		})
 	}
 
-	transaction, err := s.transactionService.TrashedTransaction(int(request.GetId()))
+	transaction, err := s.transactionService.TrashedTransaction(ctx, int(request.GetId()))
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to trashed transaction: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseTransactionDeleteAt("success", "Successfully trashed transaction", transaction)
@@ -286,13 +388,10 @@ func (s *transactionHandleGrpc) RestoreTransaction(ctx context.Context, request
 		})
 	}
 
-	transaction, err := s.transactionService.RestoreTransaction(int(request.GetId()))
+	transaction, err := s.transactionService.RestoreTransaction(ctx, int(request.GetId()))
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to restore transaction: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseTransactionDeleteAt("success", "Successfully restored transaction", transaction)
@@ -308,13 +407,10 @@ func (s *transactionHandleGrpc) DeleteTransactionPermanent(ctx context.Context,
 		})
 	}
 
-	_, err := s.transactionService.DeleteTransactionPermanently(int(request.GetId()))
+	_, err := s.transactionService.DeleteTransactionPermanently(ctx, int(request.GetId()))
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to delete Transaction permanently: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseTransactionDelete("success", "Successfully deleted Transaction permanently")
@@ -323,13 +419,10 @@ func (s *transactionHandleGrpc) DeleteTransactionPermanent(ctx context.Context,
 }
 
 func (s *transactionHandleGrpc) RestoreAllTransaction(ctx context.Context, _ *emptypb.Empty) (*pb.ApiResponseTransactionAll, error) {
-	_, err := s.transactionService.RestoreAllTransactions()
+	_, err := s.transactionService.RestoreAllTransactions(ctx)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to restore all Transaction: ",
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseTransactionAll("success", "Successfully restore all Transaction")
@@ -338,13 +431,10 @@ func (s *transactionHandleGrpc) RestoreAllTransaction(ctx context.Context, _ *em
 }
 
 func (s *transactionHandleGrpc) DeleteAllTransactionPermanent(ctx context.Context, _ *emptypb.Empty) (*pb.ApiResponseTransactionAll, error) {
-	_, err := s.transactionService.DeleteAllTransactionPermanent()
+	_, err := s.transactionService.DeleteAllTransactionPermanent(ctx)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to delete Transaction permanent: ",
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseTransactionAll("success", "Successfully delete Transaction permanen")
@@ -35,13 +35,10 @@ func (s *userHandleGrpc) FindAll(ctx context.Context, request *pb.FindAllUserReq
 		pageSize = 10
 	}
 
-	users, totalRecords, err := s.userService.FindAll(page, pageSize, search)
+	users, totalRecords, err := s.userService.FindAll(ctx, page, pageSize, search)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch users: ",
-		})
+		return nil, toGrpcError(err)
 	}
 
 	totalPages := int(math.Ceil(float64(totalRecords) / float64(pageSize)))
@@ -65,13 +62,14 @@ func (s *userHandleGrpc) FindById(ctx context.Context, request *pb.FindByIdUserR
 		})
 	}
 
-	user, err := s.userService.FindByID(int(request.GetId()))
+	if err := authorizeUser(ctx, int(request.GetId())); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userService.FindByID(ctx, int(request.GetId()))
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch user: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseUser("success", "Successfully fetched user", user)
@@ -92,13 +90,10 @@ func (s *userHandleGrpc) FindByActive(ctx context.Context, request *pb.FindAllUs
 		pageSize = 10
 	}
 
-	users, totalRecords, err := s.userService.FindByActive(page, pageSize, search)
+	users, totalRecords, err := s.userService.FindByActive(ctx, page, pageSize, search)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch active users: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	totalPages := int(math.Ceil(float64(totalRecords) / float64(pageSize)))
@@ -126,13 +121,10 @@ func (s *userHandleGrpc) FindByTrashed(ctx context.Context, request *pb.FindAllU
 		pageSize = 10
 	}
 
-	users, totalRecords, err := s.userService.FindByTrashed(page, pageSize, search)
+	users, totalRecords, err := s.userService.FindByTrashed(ctx, page, pageSize, search)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to fetch trashed users: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	totalPages := int(math.Ceil(float64(totalRecords) / float64(pageSize)))
@@ -165,13 +157,10 @@ func (s *userHandleGrpc) Create(ctx context.Context, request *pb.CreateUserReque
 		})
 	}
 
-	user, err := s.userService.CreateUser(req)
+	user, err := s.userService.CreateUser(ctx, req)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to create user: ",
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseUser("success", "Successfully created user", user)
@@ -187,6 +176,10 @@ func (s *userHandleGrpc) Update(ctx context.Context, request *pb.UpdateUserReque
 		})
 	}
 
+	if err := authorizeUser(ctx, int(request.GetId())); err != nil {
+		return nil, err
+	}
+
 	req := &requests.UpdateUserRequest{
 		UserID:          int(request.GetId()),
 		FirstName:       request.GetFirstname(),
@@ -203,13 +196,10 @@ func (s *userHandleGrpc) Update(ctx context.Context, request *pb.UpdateUserReque
 		})
 	}
 
-	user, err := s.userService.UpdateUser(req)
+	user, err := s.userService.UpdateUser(ctx, req)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to update user: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseUser("success", "Successfully updated user", user)
@@ -225,13 +215,10 @@ func (s *userHandleGrpc) TrashedUser(ctx context.Context, request *pb.FindByIdUs
 		})
 	}
 
-	user, err := s.userService.TrashedUser(int(request.GetId()))
+	user, err := s.userService.TrashedUser(ctx, int(request.GetId()))
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to trashed user: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseUserDeleteAt("success", "Successfully trashed user", user)
@@ -247,13 +234,10 @@ func (s *userHandleGrpc) RestoreUser(ctx context.Context, request *pb.FindByIdUs
 		})
 	}
 
-	user, err := s.userService.RestoreUser(int(request.GetId()))
+	user, err := s.userService.RestoreUser(ctx, int(request.GetId()))
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to restore user: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseUserDeleteAt("success", "Successfully restored user", user)
@@ -269,13 +253,10 @@ func (s *userHandleGrpc) DeleteUserPermanent(ctx context.Context, request *pb.Fi
 		})
 	}
 
-	_, err := s.userService.DeleteUserPermanent(int(request.GetId()))
+	_, err := s.userService.DeleteUserPermanent(ctx, int(request.GetId()))
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to delete user permanently: " + err.Message,
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseUserDelete("success", "Successfully deleted user permanently")
@@ -284,13 +265,10 @@ func (s *userHandleGrpc) DeleteUserPermanent(ctx context.Context, request *pb.Fi
 }
 
 func (s *userHandleGrpc) RestoreAllUser(ctx context.Context, _ *emptypb.Empty) (*pb.ApiResponseUserAll, error) {
-	_, err := s.userService.RestoreAllUser()
+	_, err := s.userService.RestoreAllUser(ctx)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to restore all user: ",
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseUserAll("success", "Successfully restore all user")
@@ -299,13 +277,10 @@ func (s *userHandleGrpc) RestoreAllUser(ctx context.Context, _ *emptypb.Empty) (
 }
 
 func (s *userHandleGrpc) DeleteAllUserPermanent(ctx context.Context, _ *emptypb.Empty) (*pb.ApiResponseUserAll, error) {
-	_, err := s.userService.DeleteAllUserPermanent()
+	_, err := s.userService.DeleteAllUserPermanent(ctx)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to delete user permanent: ",
-		})
+		return nil, toGrpcError(err)
 	}
 
 	so := s.mapping.ToProtoResponseUserAll("success", "Successfully delete user permanen")
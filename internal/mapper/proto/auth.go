@@ -63,3 +63,53 @@ func (s *authProtoMapper) ToProtoResponseGetMe(status string, message string, re
 		},
 	}
 }
+
+func (s *authProtoMapper) ToProtoResponseLogout(status string, message string) *pb.ApiResponseLogout {
+	return &pb.ApiResponseLogout{
+		Status:  status,
+		Message: message,
+	}
+}
+
+func (s *authProtoMapper) ToProtoResponseSessions(status string, message string, sessions []*response.SessionResponse) *pb.ApiResponseSessions {
+	data := make([]*pb.SessionResponse, 0, len(sessions))
+
+	for _, session := range sessions {
+		data = append(data, &pb.SessionResponse{
+			Id:           session.ID,
+			Device:       session.Device,
+			UserAgent:    session.UserAgent,
+			IpAddress:    session.IPAddress,
+			SignedInAt:   session.SignedInAt,
+			LastActiveAt: session.LastActiveAt,
+			ExpiresAt:    session.ExpiresAt,
+		})
+	}
+
+	return &pb.ApiResponseSessions{
+		Status:  status,
+		Message: message,
+		Data:    data,
+	}
+}
+
+func (s *authProtoMapper) ToProtoResponseVerifyEmail(status string, message string) *pb.ApiResponseVerifyEmail {
+	return &pb.ApiResponseVerifyEmail{
+		Status:  status,
+		Message: message,
+	}
+}
+
+func (s *authProtoMapper) ToProtoResponsePasswordReset(status string, message string) *pb.ApiResponsePasswordReset {
+	return &pb.ApiResponsePasswordReset{
+		Status:  status,
+		Message: message,
+	}
+}
+
+func (s *authProtoMapper) ToProtoResponseUnlockLogin(status string, message string) *pb.ApiResponseUnlockLogin {
+	return &pb.ApiResponseUnlockLogin{
+		Status:  status,
+		Message: message,
+	}
+}
@@ -32,7 +32,7 @@ func (c *cartProtoMapper) mapResponseCart(cart *response.CartResponse) *pb.CartR
 		UserId:    int32(cart.UserID),
 		ProductId: int32(cart.ProductID),
 		Name:      cart.Name,
-		Price:     int32(cart.Price),
+		Price:     mapMoney(cart.Price),
 		Image:     cart.Image,
 		Quantity:  int32(cart.Quantity),
 		Weight:    int32(cart.Weight),
@@ -67,3 +67,15 @@ func (c *cartProtoMapper) ToProtoResponsePaginationCart(pagination *pb.Paginatio
 		Pagination: mapPaginationMeta(pagination),
 	}
 }
+
+func (c *cartProtoMapper) ToProtoResponseCartSummary(status string, message string, summary *response.CartSummaryResponse) *pb.ApiResponseCartSummary {
+	return &pb.ApiResponseCartSummary{
+		Status:  status,
+		Message: message,
+		Data: &pb.CartSummaryResponse{
+			ItemCount:   int32(summary.ItemCount),
+			TotalWeight: int32(summary.TotalWeight),
+			Subtotal:    mapMoney(summary.Subtotal),
+		},
+	}
+}
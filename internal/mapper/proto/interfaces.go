@@ -10,6 +10,11 @@ type AuthProtoMapper interface {
 	ToProtoResponseRegister(status string, message string, response *response.UserResponse) *pb.ApiResponseRegister
 	ToProtoResponseRefreshToken(status string, message string, response *response.TokenResponse) *pb.ApiResponseRefreshToken
 	ToProtoResponseGetMe(status string, message string, response *response.UserResponse) *pb.ApiResponseGetMe
+	ToProtoResponseLogout(status string, message string) *pb.ApiResponseLogout
+	ToProtoResponseSessions(status string, message string, sessions []*response.SessionResponse) *pb.ApiResponseSessions
+	ToProtoResponseVerifyEmail(status string, message string) *pb.ApiResponseVerifyEmail
+	ToProtoResponsePasswordReset(status string, message string) *pb.ApiResponsePasswordReset
+	ToProtoResponseUnlockLogin(status string, message string) *pb.ApiResponseUnlockLogin
 }
 
 type UserProtoMapper interface {
@@ -70,6 +75,7 @@ type OrderProtoMapper interface {
 	ToProtoResponseOrderAll(status string, message string) *pb.ApiResponseOrderAll
 	ToProtoResponsePaginationOrderDeleteAt(pagination *pb.PaginationMeta, status string, message string, orders []*response.OrderResponseDeleteAt) *pb.ApiResponsePaginationOrderDeleteAt
 	ToProtoResponsePaginationOrder(pagination *pb.PaginationMeta, status string, message string, orders []*response.OrderResponse) *pb.ApiResponsePaginationOrder
+	ToProtoResponseOrderStatusHistory(status string, message string, histories []*response.OrderStatusHistoryResponse) *pb.ApiResponseOrderStatusHistory
 }
 
 type ProductProtoMapper interface {
@@ -81,6 +87,7 @@ type ProductProtoMapper interface {
 	ToProtoResponseProductAll(status string, message string) *pb.ApiResponseProductAll
 	ToProtoResponsePaginationProductDeleteAt(pagination *pb.PaginationMeta, status string, message string, products []*response.ProductResponseDeleteAt) *pb.ApiResponsePaginationProductDeleteAt
 	ToProtoResponsePaginationProduct(pagination *pb.PaginationMeta, status string, message string, products []*response.ProductResponse) *pb.ApiResponsePaginationProduct
+	ToProtoResponseProductSearch(pagination *pb.PaginationMeta, status string, message string, products []*response.ProductResponse, facets *response.ProductFacetsResponse) *pb.ApiResponseProductSearch
 }
 
 type TransactionProtoMapper interface {
@@ -97,6 +104,7 @@ type CartProtoMapper interface {
 	ToProtoResponseCartDelete(status string, message string) *pb.ApiResponseCartDelete
 	ToProtoResponseCartAll(status string, message string) *pb.ApiResponseCartAll
 	ToProtoResponseCart(status string, message string, categories *response.CartResponse) *pb.ApiResponseCart
+	ToProtoResponseCartSummary(status string, message string, summary *response.CartSummaryResponse) *pb.ApiResponseCartSummary
 	ToProtoResponsePaginationCart(pagination *pb.PaginationMeta, status string, message string, categories []*response.CartResponse) *pb.ApiResponsePaginationCart
 }
 
@@ -77,6 +77,7 @@ func (m *merchantProtoMapper) mapResponseMerchant(merchant *response.MerchantRes
 		ContactEmail: merchant.ContactEmail,
 		ContactPhone: merchant.ContactPhone,
 		Status:       merchant.Status,
+		Currency:     merchant.Currency,
 		CreatedAt:    merchant.CreatedAt,
 		UpdatedAt:    merchant.UpdatedAt,
 	}
@@ -102,6 +103,7 @@ func (m *merchantProtoMapper) mapResponseMerchantDeleteAt(merchant *response.Mer
 		ContactEmail: merchant.ContactEmail,
 		ContactPhone: merchant.ContactPhone,
 		Status:       merchant.Status,
+		Currency:     merchant.Currency,
 		CreatedAt:    merchant.CreatedAt,
 		UpdatedAt:    merchant.UpdatedAt,
 		DeletedAt:    merchant.DeletedAt,
@@ -0,0 +1,13 @@
+package protomapper
+
+import (
+	"ecommerce/internal/domain/money"
+	"ecommerce/internal/pb"
+)
+
+func mapMoney(m money.Money) *pb.Money {
+	return &pb.Money{
+		Amount:   m.Amount,
+		Currency: m.Currency,
+	}
+}
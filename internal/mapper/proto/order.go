@@ -67,14 +67,27 @@ func (o *orderProtoMapper) ToProtoResponsePaginationOrder(pagination *pb.Paginat
 	}
 }
 
+func (o *orderProtoMapper) ToProtoResponseOrderStatusHistory(status string, message string, histories []*response.OrderStatusHistoryResponse) *pb.ApiResponseOrderStatusHistory {
+	return &pb.ApiResponseOrderStatusHistory{
+		Status:  status,
+		Message: message,
+		Data:    o.mapResponsesOrderStatusHistory(histories),
+	}
+}
+
 func (o *orderProtoMapper) mapResponseOrder(order *response.OrderResponse) *pb.OrderResponse {
 	return &pb.OrderResponse{
-		Id:         int32(order.ID),
-		MerchantId: int32(order.MerchantID),
-		UserId:     int32(order.UserID),
-		TotalPrice: int32(order.TotalPrice),
-		CreatedAt:  order.CreatedAt,
-		UpdatedAt:  order.UpdatedAt,
+		Id:             int32(order.ID),
+		MerchantId:     int32(order.MerchantID),
+		UserId:         int32(order.UserID),
+		TotalPrice:     mapMoney(order.TotalPrice),
+		Subtotal:       mapMoney(order.Subtotal),
+		ShippingCost:   mapMoney(order.ShippingCost),
+		TaxAmount:      mapMoney(order.TaxAmount),
+		DiscountAmount: mapMoney(order.DiscountAmount),
+		Status:         order.Status,
+		CreatedAt:      order.CreatedAt,
+		UpdatedAt:      order.UpdatedAt,
 	}
 }
 
@@ -90,13 +103,18 @@ func (o *orderProtoMapper) mapResponsesOrder(orders []*response.OrderResponse) [
 
 func (o *orderProtoMapper) mapResponseOrderDeleteAt(order *response.OrderResponseDeleteAt) *pb.OrderResponseDeleteAt {
 	return &pb.OrderResponseDeleteAt{
-		Id:         int32(order.ID),
-		MerchantId: int32(order.MerchantID),
-		UserId:     int32(order.UserID),
-		TotalPrice: int32(order.TotalPrice),
-		CreatedAt:  order.CreatedAt,
-		UpdatedAt:  order.UpdatedAt,
-		DeletedAt:  order.DeleteAt,
+		Id:             int32(order.ID),
+		MerchantId:     int32(order.MerchantID),
+		UserId:         int32(order.UserID),
+		TotalPrice:     mapMoney(order.TotalPrice),
+		Subtotal:       mapMoney(order.Subtotal),
+		ShippingCost:   mapMoney(order.ShippingCost),
+		TaxAmount:      mapMoney(order.TaxAmount),
+		DiscountAmount: mapMoney(order.DiscountAmount),
+		Status:         order.Status,
+		CreatedAt:      order.CreatedAt,
+		UpdatedAt:      order.UpdatedAt,
+		DeletedAt:      order.DeleteAt,
 	}
 }
 
@@ -109,3 +127,24 @@ func (o *orderProtoMapper) mapResponsesOrderDeleteAt(orders []*response.OrderRes
 
 	return mappedOrders
 }
+
+func (o *orderProtoMapper) mapResponseOrderStatusHistory(history *response.OrderStatusHistoryResponse) *pb.OrderStatusHistoryResponse {
+	return &pb.OrderStatusHistoryResponse{
+		Id:         int32(history.ID),
+		OrderId:    int32(history.OrderID),
+		FromStatus: history.FromStatus,
+		ToStatus:   history.ToStatus,
+		Note:       history.Note,
+		CreatedAt:  history.CreatedAt,
+	}
+}
+
+func (o *orderProtoMapper) mapResponsesOrderStatusHistory(histories []*response.OrderStatusHistoryResponse) []*pb.OrderStatusHistoryResponse {
+	var mappedHistories []*pb.OrderStatusHistoryResponse
+
+	for _, history := range histories {
+		mappedHistories = append(mappedHistories, o.mapResponseOrderStatusHistory(history))
+	}
+
+	return mappedHistories
+}
@@ -65,7 +65,7 @@ func (o *orderItemProtoMapper) mapResponseOrderItem(orderItem *response.OrderIte
 		OrderId:   int32(orderItem.OrderID),
 		ProductId: int32(orderItem.ProductID),
 		Quantity:  int32(orderItem.Quantity),
-		Price:     int32(orderItem.Price),
+		Price:     mapMoney(orderItem.Price),
 		CreatedAt: orderItem.CreatedAt,
 		UpdatedAt: orderItem.UpdatedAt,
 	}
@@ -87,7 +87,7 @@ func (o *orderItemProtoMapper) mapResponseOrderItemDelete(orderItem *response.Or
 		OrderId:   int32(orderItem.OrderID),
 		ProductId: int32(orderItem.ProductID),
 		Quantity:  int32(orderItem.Quantity),
-		Price:     int32(orderItem.Price),
+		Price:     mapMoney(orderItem.Price),
 		CreatedAt: orderItem.CreatedAt,
 		UpdatedAt: orderItem.UpdatedAt,
 		DeletedAt: orderItem.DeleteAt,
@@ -67,6 +67,16 @@ func (p *productProtoMapper) ToProtoResponsePaginationProduct(pagination *pb.Pag
 	}
 }
 
+func (p *productProtoMapper) ToProtoResponseProductSearch(pagination *pb.PaginationMeta, status string, message string, products []*response.ProductResponse, facets *response.ProductFacetsResponse) *pb.ApiResponseProductSearch {
+	return &pb.ApiResponseProductSearch{
+		Status:     status,
+		Message:    message,
+		Data:       p.mapResponsesProduct(products),
+		Pagination: mapPaginationMeta(pagination),
+		Facets:     p.mapResponseProductFacets(facets),
+	}
+}
+
 func (p *productProtoMapper) mapResponseProduct(product *response.ProductResponse) *pb.ProductResponse {
 	return &pb.ProductResponse{
 		Id:           int32(product.ID),
@@ -74,7 +84,7 @@ func (p *productProtoMapper) mapResponseProduct(product *response.ProductRespons
 		CategoryId:   int32(product.CategoryID),
 		Name:         product.Name,
 		Description:  product.Description,
-		Price:        int32(product.Price),
+		Price:        mapMoney(product.Price),
 		CountInStock: int32(product.CountInStock),
 		Brand:        product.Brand,
 		Weight:       int32(product.Weight),
@@ -103,7 +113,7 @@ func (p *productProtoMapper) mapResponseProductDeleteAt(product *response.Produc
 		CategoryId:   int32(product.CategoryID),
 		Name:         product.Name,
 		Description:  product.Description,
-		Price:        int32(product.Price),
+		Price:        mapMoney(product.Price),
 		CountInStock: int32(product.CountInStock),
 		Brand:        product.Brand,
 		Weight:       int32(product.Weight),
@@ -125,3 +135,39 @@ func (p *productProtoMapper) mapResponsesProductDeleteAt(products []*response.Pr
 
 	return mappedProducts
 }
+
+func (p *productProtoMapper) mapResponseProductFacets(facets *response.ProductFacetsResponse) *pb.ProductFacets {
+	priceBuckets := make([]*pb.ProductPriceBucket, 0, len(facets.PriceBuckets))
+	for _, bucket := range facets.PriceBuckets {
+		priceBucket := &pb.ProductPriceBucket{
+			Min:   mapMoney(bucket.Min),
+			Count: int32(bucket.Count),
+		}
+		if bucket.Max != nil {
+			priceBucket.Max = mapMoney(*bucket.Max)
+		}
+
+		priceBuckets = append(priceBuckets, priceBucket)
+	}
+
+	return &pb.ProductFacets{
+		Categories:   p.mapResponsesProductFacetValue(facets.Categories),
+		Brands:       p.mapResponsesProductFacetValue(facets.Brands),
+		Merchants:    p.mapResponsesProductFacetValue(facets.Merchants),
+		PriceBuckets: priceBuckets,
+	}
+}
+
+func (p *productProtoMapper) mapResponsesProductFacetValue(values []*response.ProductFacetValueResponse) []*pb.ProductFacetValue {
+	mappedValues := make([]*pb.ProductFacetValue, 0, len(values))
+
+	for _, value := range values {
+		mappedValues = append(mappedValues, &pb.ProductFacetValue{
+			Value: value.Value,
+			Label: value.Label,
+			Count: int32(value.Count),
+		})
+	}
+
+	return mappedValues
+}
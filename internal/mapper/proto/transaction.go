@@ -69,15 +69,18 @@ func (t *transactionProtoMapper) ToProtoResponsePaginationTransaction(pagination
 
 func (t *transactionProtoMapper) mapResponseTransaction(transaction *response.TransactionResponse) *pb.TransactionResponse {
 	return &pb.TransactionResponse{
-		Id:            int32(transaction.ID),
-		OrderId:       int32(transaction.OrderID),
-		MerchantId:    int32(transaction.MerchantID),
-		PaymentMethod: transaction.PaymentMethod,
-		Amount:        int32(transaction.Amount),
-		ChangeAmount:  int32(transaction.ChangeAmount),
-		PaymentStatus: transaction.PaymentStatus,
-		CreatedAt:     transaction.CreatedAt,
-		UpdatedAt:     transaction.UpdatedAt,
+		Id:             int32(transaction.ID),
+		OrderId:        int32(transaction.OrderID),
+		MerchantId:     int32(transaction.MerchantID),
+		PaymentMethod:  transaction.PaymentMethod,
+		Amount:         mapMoney(transaction.Amount),
+		AmountDue:      mapMoney(transaction.AmountDue),
+		ChangeAmount:   mapMoney(transaction.ChangeAmount),
+		PaymentStatus:  transaction.PaymentStatus,
+		RefundedAmount: mapMoney(transaction.RefundedAmount),
+		NetAmount:      mapMoney(transaction.NetAmount),
+		CreatedAt:      transaction.CreatedAt,
+		UpdatedAt:      transaction.UpdatedAt,
 	}
 }
 
@@ -97,8 +100,9 @@ func (t *transactionProtoMapper) mapResponseTransactionDeleteAt(transaction *res
 		OrderId:       int32(transaction.OrderID),
 		MerchantId:    int32(transaction.MerchantID),
 		PaymentMethod: transaction.PaymentMethod,
-		Amount:        int32(transaction.Amount),
-		ChangeAmount:  int32(transaction.ChangeAmount),
+		Amount:        mapMoney(transaction.Amount),
+		AmountDue:     mapMoney(transaction.AmountDue),
+		ChangeAmount:  mapMoney(transaction.ChangeAmount),
 		PaymentStatus: transaction.PaymentStatus,
 		CreatedAt:     transaction.CreatedAt,
 		UpdatedAt:     transaction.UpdatedAt,
@@ -129,5 +129,6 @@ func mapPaginationMeta(s *pb.PaginationMeta) *pb.PaginationMeta {
 		PageSize:     int32(s.PageSize),
 		TotalPages:   int32(s.TotalPages),
 		TotalRecords: int32(s.TotalRecords),
+		NextCursor:   s.NextCursor,
 	}
 }
@@ -25,7 +25,7 @@ func (s *cartRecordMapper) ToCartRecord(cart *db.Cart) *record.CartRecord {
 		UserID:    int(cart.UserID),
 		ProductID: int(cart.ProductID),
 		Name:      cart.Name,
-		Price:     int(cart.Price),
+		Price:     cart.Price,
 		Image:     cart.Image,
 		Quantity:  int(cart.Quantity),
 		Weight:    int(cart.Weight),
@@ -48,7 +48,7 @@ func (s *cartRecordMapper) ToCartRecordPagination(cart *db.GetCartsRow) *record.
 		UserID:    int(cart.UserID),
 		ProductID: int(cart.ProductID),
 		Name:      cart.Name,
-		Price:     int(cart.Price),
+		Price:     cart.Price,
 		Image:     cart.Image,
 		Quantity:  int(cart.Quantity),
 		Weight:    int(cart.Weight),
@@ -67,3 +67,31 @@ func (s *cartRecordMapper) ToCartsRecordPagination(carts []*db.GetCartsRow) []*r
 
 	return cartRecords
 }
+
+func (s *cartRecordMapper) ToCartCheckoutRecord(cart *db.GetCartsForCheckoutRow) *record.CartCheckoutRecord {
+	return &record.CartCheckoutRecord{
+		ID:         int(cart.CartID),
+		UserID:     int(cart.UserID),
+		ProductID:  int(cart.ProductID),
+		MerchantID: int(cart.MerchantID),
+		Quantity:   int(cart.Quantity),
+	}
+}
+
+func (s *cartRecordMapper) ToCartsCheckoutRecord(carts []*db.GetCartsForCheckoutRow) []*record.CartCheckoutRecord {
+	var result []*record.CartCheckoutRecord
+
+	for _, cart := range carts {
+		result = append(result, s.ToCartCheckoutRecord(cart))
+	}
+
+	return result
+}
+
+func (s *cartRecordMapper) ToCartSummaryRecord(summary *db.GetCartSummaryRow) *record.CartSummaryRecord {
+	return &record.CartSummaryRecord{
+		ItemCount:   int(summary.ItemCount),
+		TotalWeight: int(summary.TotalWeight),
+		Subtotal:    summary.Subtotal,
+	}
+}
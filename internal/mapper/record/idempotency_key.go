@@ -0,0 +1,34 @@
+package recordmapper
+
+import (
+	"ecommerce/internal/domain/record"
+	db "ecommerce/pkg/database/schema"
+)
+
+type idempotencyKeyRecordMapper struct {
+}
+
+func NewIdempotencyKeyRecordMapper() *idempotencyKeyRecordMapper {
+	return &idempotencyKeyRecordMapper{}
+}
+
+func (m *idempotencyKeyRecordMapper) ToIdempotencyKeyRecord(key *db.IdempotencyKey) *record.IdempotencyKeyRecord {
+	var completedAt *string
+	if key.CompletedAt.Valid {
+		completedAtStr := key.CompletedAt.Time.Format("2006-01-02 15:04:05.000")
+		completedAt = &completedAtStr
+	}
+
+	return &record.IdempotencyKeyRecord{
+		ID:          int(key.IdempotencyKeyID),
+		UserID:      int(key.UserID),
+		Method:      key.Method,
+		Key:         key.IdempotencyKey,
+		RequestHash: key.RequestHash,
+		Response:    key.Response,
+		Failed:      key.Failed,
+		LockedAt:    key.LockedAt.Format("2006-01-02 15:04:05.000"),
+		CompletedAt: completedAt,
+		CreatedAt:   key.CreatedAt.Time.Format("2006-01-02 15:04:05.000"),
+	}
+}
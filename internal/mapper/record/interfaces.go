@@ -50,6 +50,7 @@ type CategoryRecordMapper interface {
 
 type MerchantRecordMapping interface {
 	ToMerchantRecord(Merchant *db.Merchant) *record.MerchantRecord
+	ToMerchantsRecord(Merchants []*db.Merchant) []*record.MerchantRecord
 	ToMerchantRecordPagination(Merchant *db.GetMerchantsRow) *record.MerchantRecord
 	ToMerchantsRecordPagination(Merchants []*db.GetMerchantsRow) []*record.MerchantRecord
 
@@ -84,6 +85,9 @@ type OrderRecordMapping interface {
 
 	ToOrderRecordByMerchantPagination(order *db.GetOrdersByMerchantRow) *record.OrderRecord
 	ToOrdersRecordByMerchantPagination(orders []*db.GetOrdersByMerchantRow) []*record.OrderRecord
+
+	ToOrderStatusHistoryRecord(history *db.OrderStatusHistory) *record.OrderStatusHistoryRecord
+	ToOrderStatusHistoriesRecord(histories []*db.OrderStatusHistory) []*record.OrderStatusHistoryRecord
 }
 
 type ProductRecordMapping interface {
@@ -98,9 +102,15 @@ type ProductRecordMapping interface {
 
 	ToProductRecordMerchantPagination(product *db.GetProductsByMerchantRow) *record.ProductRecord
 	ToProductsRecordMerchantPagination(products []*db.GetProductsByMerchantRow) []*record.ProductRecord
+	ToProductRecordUserPagination(product *db.GetProductsByUserIDRow) *record.ProductRecord
+	ToProductsRecordUserPagination(products []*db.GetProductsByUserIDRow) []*record.ProductRecord
 
 	ToProductRecordCategoryPagination(product *db.GetProductsByCategoryNameRow) *record.ProductRecord
 	ToProductsRecordCategoryPagination(products []*db.GetProductsByCategoryNameRow) []*record.ProductRecord
+
+	ToProductRecordSearchPagination(product *db.SearchProductsRow) *record.ProductRecord
+	ToProductsRecordSearchPagination(products []*db.SearchProductsRow) []*record.ProductRecord
+	ToProductFacetsRecord(facets []*db.SearchProductFacetsRow) []*record.ProductFacetRecord
 }
 
 type TransactionRecordMapping interface {
@@ -115,16 +125,26 @@ type TransactionRecordMapping interface {
 
 	ToTransactionMerchantRecordPagination(transaction *db.GetTransactionByMerchantRow) *record.TransactionRecord
 	ToTransactionMerchantsRecordPagination(products []*db.GetTransactionByMerchantRow) []*record.TransactionRecord
+
+	ToPaymentEventRecord(event *db.PaymentEvent) *record.PaymentEventRecord
+	ToRefundRecord(refund *db.Refund) *record.RefundRecord
+	ToRefundsRecord(refunds []*db.Refund) []*record.RefundRecord
+	ToRefundItemRecord(item *db.RefundItem) *record.RefundItemRecord
+	ToRefundItemsRecord(items []*db.RefundItem) []*record.RefundItemRecord
 }
 
 type CartRecordMapping interface {
 	ToCartRecord(cart *db.Cart) *record.CartRecord
 	ToCartRecordPagination(cart *db.GetCartsRow) *record.CartRecord
 	ToCartsRecordPagination(carts []*db.GetCartsRow) []*record.CartRecord
+	ToCartCheckoutRecord(cart *db.GetCartsForCheckoutRow) *record.CartCheckoutRecord
+	ToCartsCheckoutRecord(carts []*db.GetCartsForCheckoutRow) []*record.CartCheckoutRecord
+	ToCartSummaryRecord(summary *db.GetCartSummaryRow) *record.CartSummaryRecord
 }
 
 type ReviewRecordMapping interface {
 	ToReviewRecord(review *db.Review) *record.ReviewRecord
+	ToReviewsRecord(reviews []*db.Review) []*record.ReviewRecord
 	ToReviewRecordPagination(review *db.GetReviewsRow) *record.ReviewRecord
 	ToReviewsRecordPagination(reviews []*db.GetReviewsRow) []*record.ReviewRecord
 
@@ -156,3 +176,21 @@ type SliderMapping interface {
 	ToSliderRecordTrashedPagination(slider *db.GetSlidersTrashedRow) *record.SliderRecord
 	ToSlidersRecordTrashedPagination(sliders []*db.GetSlidersTrashedRow) []*record.SliderRecord
 }
+
+type IdempotencyKeyRecordMapping interface {
+	ToIdempotencyKeyRecord(key *db.IdempotencyKey) *record.IdempotencyKeyRecord
+}
+
+type RevokedTokenRecordMapping interface {
+	ToRevokedTokenRecord(token *db.RevokedToken) *record.RevokedTokenRecord
+	ToRevokedTokensRecord(tokens []*db.RevokedToken) []*record.RevokedTokenRecord
+}
+
+type UserTokenRecordMapping interface {
+	ToUserTokenRecord(token *db.UserToken) *record.UserTokenRecord
+}
+
+type LoginThrottleRecordMapping interface {
+	ToLoginThrottleRecord(throttle *db.LoginThrottle) *record.LoginThrottleRecord
+	ToLoginThrottlesRecord(throttles []*db.LoginThrottle) []*record.LoginThrottleRecord
+}
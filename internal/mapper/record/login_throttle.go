@@ -0,0 +1,40 @@
+package recordmapper
+
+import (
+	"ecommerce/internal/domain/record"
+	db "ecommerce/pkg/database/schema"
+)
+
+type loginThrottleRecordMapper struct {
+}
+
+func NewLoginThrottleRecordMapper() *loginThrottleRecordMapper {
+	return &loginThrottleRecordMapper{}
+}
+
+func (m *loginThrottleRecordMapper) ToLoginThrottleRecord(throttle *db.LoginThrottle) *record.LoginThrottleRecord {
+	var lockedUntil *string
+
+	if throttle.LockedUntil.Valid {
+		formatedLockedUntil := throttle.LockedUntil.Time.Format("2006-01-02 15:04:05.000")
+
+		lockedUntil = &formatedLockedUntil
+	}
+
+	return &record.LoginThrottleRecord{
+		ID:             int(throttle.LoginThrottleID),
+		Scope:          throttle.Scope,
+		Subject:        throttle.Subject,
+		FailedAttempts: int(throttle.FailedAttempts),
+		LastFailedAt:   throttle.LastFailedAt.Format("2006-01-02 15:04:05.000"),
+		LockedUntil:    lockedUntil,
+	}
+}
+
+func (m *loginThrottleRecordMapper) ToLoginThrottlesRecord(throttles []*db.LoginThrottle) []*record.LoginThrottleRecord {
+	var records []*record.LoginThrottleRecord
+	for _, throttle := range throttles {
+		records = append(records, m.ToLoginThrottleRecord(throttle))
+	}
+	return records
+}
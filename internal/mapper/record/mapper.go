@@ -15,6 +15,10 @@ type RecordMapper struct {
 	ReviewRecordMapping      ReviewRecordMapping
 	ShippingAddressMapping   ShippingAddressMapping
 	SliderMapping            SliderMapping
+	IdempotencyKeyMapping    IdempotencyKeyRecordMapping
+	RevokedTokenMapping      RevokedTokenRecordMapping
+	UserTokenMapping         UserTokenRecordMapping
+	LoginThrottleMapping     LoginThrottleRecordMapping
 }
 
 func NewRecordMapper() *RecordMapper {
@@ -33,5 +37,9 @@ func NewRecordMapper() *RecordMapper {
 		ReviewRecordMapping:      NewReviewRecordMapper(),
 		ShippingAddressMapping:   NewShippingAddressRecordMapper(),
 		SliderMapping:            NewSliderRecordMapper(),
+		IdempotencyKeyMapping:    NewIdempotencyKeyRecordMapper(),
+		RevokedTokenMapping:      NewRevokedTokenRecordMapper(),
+		UserTokenMapping:         NewUserTokenRecordMapper(),
+		LoginThrottleMapping:     NewLoginThrottleRecordMapper(),
 	}
 }
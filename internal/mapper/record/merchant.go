@@ -28,6 +28,7 @@ func (s *merchantRecordMapper) ToMerchantRecord(Merchant *db.Merchant) *record.M
 		ContactEmail: Merchant.ContactEmail.String,
 		ContactPhone: Merchant.ContactPhone.String,
 		Status:       Merchant.Status,
+		Currency:     Merchant.Currency,
 		CreatedAt:    Merchant.CreatedAt.Time.Format("2006-01-02 15:04:05.000"),
 		UpdatedAt:    Merchant.UpdatedAt.Time.Format("2006-01-02 15:04:05.000"),
 		DeletedAt:    deletedAt,
@@ -50,6 +51,7 @@ func (s *merchantRecordMapper) ToMerchantRecordPagination(Merchant *db.GetMercha
 		ContactEmail: Merchant.ContactEmail.String,
 		ContactPhone: Merchant.ContactPhone.String,
 		Status:       Merchant.Status,
+		Currency:     Merchant.Currency,
 		CreatedAt:    Merchant.CreatedAt.Time.Format("2006-01-02 15:04:05.000"),
 		UpdatedAt:    Merchant.UpdatedAt.Time.Format("2006-01-02 15:04:05.000"),
 		DeletedAt:    deletedAt,
@@ -82,6 +84,7 @@ func (s *merchantRecordMapper) ToMerchantRecordActivePagination(Merchant *db.Get
 		ContactEmail: Merchant.ContactEmail.String,
 		ContactPhone: Merchant.ContactPhone.String,
 		Status:       Merchant.Status,
+		Currency:     Merchant.Currency,
 		CreatedAt:    Merchant.CreatedAt.Time.Format("2006-01-02 15:04:05.000"),
 		UpdatedAt:    Merchant.UpdatedAt.Time.Format("2006-01-02 15:04:05.000"),
 		DeletedAt:    deletedAt,
@@ -114,6 +117,7 @@ func (s *merchantRecordMapper) ToMerchantRecordTrashedPagination(Merchant *db.Ge
 		ContactEmail: Merchant.ContactEmail.String,
 		ContactPhone: Merchant.ContactPhone.String,
 		Status:       Merchant.Status,
+		Currency:     Merchant.Currency,
 		CreatedAt:    Merchant.CreatedAt.Time.Format("2006-01-02 15:04:05.000"),
 		UpdatedAt:    Merchant.UpdatedAt.Time.Format("2006-01-02 15:04:05.000"),
 		DeletedAt:    deletedAt,
@@ -129,3 +133,13 @@ func (s *merchantRecordMapper) ToMerchantsRecordTrashedPagination(Merchants []*d
 
 	return result
 }
+
+func (s *merchantRecordMapper) ToMerchantsRecord(Merchants []*db.Merchant) []*record.MerchantRecord {
+	var result []*record.MerchantRecord
+
+	for _, Merchant := range Merchants {
+		result = append(result, s.ToMerchantRecord(Merchant))
+	}
+
+	return result
+}
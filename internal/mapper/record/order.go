@@ -20,12 +20,18 @@ func (s *orderRecordMapper) ToOrderRecord(order *db.Order) *record.OrderRecord {
 	}
 
 	return &record.OrderRecord{
-		ID:         int(order.OrderID),
-		MerchantID: int(order.MerchantID),
-		TotalPrice: int(order.TotalPrice),
-		CreatedAt:  order.CreatedAt.Time.Format("2006-01-02 15:04:05.000"),
-		UpdatedAt:  order.UpdatedAt.Time.Format("2006-01-02 15:04:05.000"),
-		DeletedAt:  deletedAt,
+		ID:             int(order.OrderID),
+		MerchantID:     int(order.MerchantID),
+		TotalPrice:     order.TotalPrice,
+		Subtotal:       order.Subtotal,
+		ShippingCost:   order.ShippingCost,
+		TaxAmount:      order.TaxAmount,
+		DiscountAmount: order.DiscountAmount,
+		Status:         order.Status,
+		Currency:       order.Currency,
+		CreatedAt:      order.CreatedAt.Time.Format("2006-01-02 15:04:05.000"),
+		UpdatedAt:      order.UpdatedAt.Time.Format("2006-01-02 15:04:05.000"),
+		DeletedAt:      deletedAt,
 	}
 }
 
@@ -47,12 +53,18 @@ func (s *orderRecordMapper) ToOrderRecordPagination(order *db.GetOrdersRow) *rec
 	}
 
 	return &record.OrderRecord{
-		ID:         int(order.OrderID),
-		MerchantID: int(order.MerchantID),
-		TotalPrice: int(order.TotalPrice),
-		CreatedAt:  order.CreatedAt.Time.Format("2006-01-02 15:04:05.000"),
-		UpdatedAt:  order.UpdatedAt.Time.Format("2006-01-02 15:04:05.000"),
-		DeletedAt:  deletedAt,
+		ID:             int(order.OrderID),
+		MerchantID:     int(order.MerchantID),
+		TotalPrice:     order.TotalPrice,
+		Subtotal:       order.Subtotal,
+		ShippingCost:   order.ShippingCost,
+		TaxAmount:      order.TaxAmount,
+		DiscountAmount: order.DiscountAmount,
+		Status:         order.Status,
+		Currency:       order.Currency,
+		CreatedAt:      order.CreatedAt.Time.Format("2006-01-02 15:04:05.000"),
+		UpdatedAt:      order.UpdatedAt.Time.Format("2006-01-02 15:04:05.000"),
+		DeletedAt:      deletedAt,
 	}
 }
 
@@ -74,12 +86,18 @@ func (s *orderRecordMapper) ToOrderRecordActivePagination(order *db.GetOrdersAct
 	}
 
 	return &record.OrderRecord{
-		ID:         int(order.OrderID),
-		MerchantID: int(order.MerchantID),
-		TotalPrice: int(order.TotalPrice),
-		CreatedAt:  order.CreatedAt.Time.Format("2006-01-02 15:04:05.000"),
-		UpdatedAt:  order.UpdatedAt.Time.Format("2006-01-02 15:04:05.000"),
-		DeletedAt:  deletedAt,
+		ID:             int(order.OrderID),
+		MerchantID:     int(order.MerchantID),
+		TotalPrice:     order.TotalPrice,
+		Subtotal:       order.Subtotal,
+		ShippingCost:   order.ShippingCost,
+		TaxAmount:      order.TaxAmount,
+		DiscountAmount: order.DiscountAmount,
+		Status:         order.Status,
+		Currency:       order.Currency,
+		CreatedAt:      order.CreatedAt.Time.Format("2006-01-02 15:04:05.000"),
+		UpdatedAt:      order.UpdatedAt.Time.Format("2006-01-02 15:04:05.000"),
+		DeletedAt:      deletedAt,
 	}
 }
 
@@ -101,12 +119,18 @@ func (s *orderRecordMapper) ToOrderRecordTrashedPagination(order *db.GetOrdersTr
 	}
 
 	return &record.OrderRecord{
-		ID:         int(order.OrderID),
-		MerchantID: int(order.MerchantID),
-		TotalPrice: int(order.TotalPrice),
-		CreatedAt:  order.CreatedAt.Time.Format("2006-01-02 15:04:05.000"),
-		UpdatedAt:  order.UpdatedAt.Time.Format("2006-01-02 15:04:05.000"),
-		DeletedAt:  deletedAt,
+		ID:             int(order.OrderID),
+		MerchantID:     int(order.MerchantID),
+		TotalPrice:     order.TotalPrice,
+		Subtotal:       order.Subtotal,
+		ShippingCost:   order.ShippingCost,
+		TaxAmount:      order.TaxAmount,
+		DiscountAmount: order.DiscountAmount,
+		Status:         order.Status,
+		Currency:       order.Currency,
+		CreatedAt:      order.CreatedAt.Time.Format("2006-01-02 15:04:05.000"),
+		UpdatedAt:      order.UpdatedAt.Time.Format("2006-01-02 15:04:05.000"),
+		DeletedAt:      deletedAt,
 	}
 }
 
@@ -128,12 +152,18 @@ func (s *orderRecordMapper) ToOrderRecordByMerchantPagination(order *db.GetOrder
 	}
 
 	return &record.OrderRecord{
-		ID:         int(order.OrderID),
-		MerchantID: int(order.MerchantID),
-		TotalPrice: int(order.TotalPrice),
-		CreatedAt:  order.CreatedAt.Time.Format("2006-01-02 15:04:05.000"),
-		UpdatedAt:  order.UpdatedAt.Time.Format("2006-01-02 15:04:05.000"),
-		DeletedAt:  deletedAt,
+		ID:             int(order.OrderID),
+		MerchantID:     int(order.MerchantID),
+		TotalPrice:     order.TotalPrice,
+		Subtotal:       order.Subtotal,
+		ShippingCost:   order.ShippingCost,
+		TaxAmount:      order.TaxAmount,
+		DiscountAmount: order.DiscountAmount,
+		Status:         order.Status,
+		Currency:       order.Currency,
+		CreatedAt:      order.CreatedAt.Time.Format("2006-01-02 15:04:05.000"),
+		UpdatedAt:      order.UpdatedAt.Time.Format("2006-01-02 15:04:05.000"),
+		DeletedAt:      deletedAt,
 	}
 }
 
@@ -146,3 +176,29 @@ func (s *orderRecordMapper) ToOrdersRecordByMerchantPagination(orders []*db.GetO
 
 	return result
 }
+
+func (s *orderRecordMapper) ToOrderStatusHistoryRecord(history *db.OrderStatusHistory) *record.OrderStatusHistoryRecord {
+	var fromStatus *string
+	if history.FromStatus.Valid {
+		fromStatus = &history.FromStatus.String
+	}
+
+	return &record.OrderStatusHistoryRecord{
+		ID:         int(history.OrderStatusHistoryID),
+		OrderID:    int(history.OrderID),
+		FromStatus: fromStatus,
+		ToStatus:   history.ToStatus,
+		Note:       history.Note.String,
+		CreatedAt:  history.CreatedAt.Time.Format("2006-01-02 15:04:05.000"),
+	}
+}
+
+func (s *orderRecordMapper) ToOrderStatusHistoriesRecord(histories []*db.OrderStatusHistory) []*record.OrderStatusHistoryRecord {
+	var result []*record.OrderStatusHistoryRecord
+
+	for _, history := range histories {
+		result = append(result, s.ToOrderStatusHistoryRecord(history))
+	}
+
+	return result
+}
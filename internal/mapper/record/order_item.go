@@ -24,7 +24,7 @@ func (s *orderItemRecordMapper) ToOrderItemRecord(orderItems *db.OrderItem) *rec
 		OrderID:   int(orderItems.OrderID),
 		ProductID: int(orderItems.ProductID),
 		Quantity:  int(orderItems.Quantity),
-		Price:     int(orderItems.Price),
+		Price:     orderItems.Price,
 		CreatedAt: orderItems.CreatedAt.Time.Format("2006-01-02 15:04:05.000"),
 		UpdatedAt: orderItems.UpdatedAt.Time.Format("2006-01-02 15:04:05.000"),
 		DeletedAt: deletedAt,
@@ -53,7 +53,7 @@ func (s *orderItemRecordMapper) ToOrderItemRecordPagination(orderItems *db.GetOr
 		OrderID:   int(orderItems.OrderID),
 		ProductID: int(orderItems.ProductID),
 		Quantity:  int(orderItems.Quantity),
-		Price:     int(orderItems.Price),
+		Price:     orderItems.Price,
 		CreatedAt: orderItems.CreatedAt.Time.Format("2006-01-02 15:04:05.000"),
 		UpdatedAt: orderItems.UpdatedAt.Time.Format("2006-01-02 15:04:05.000"),
 		DeletedAt: deletedAt,
@@ -82,7 +82,7 @@ func (s *orderItemRecordMapper) ToOrderItemRecordActivePagination(orderItems *db
 		OrderID:   int(orderItems.OrderID),
 		ProductID: int(orderItems.ProductID),
 		Quantity:  int(orderItems.Quantity),
-		Price:     int(orderItems.Price),
+		Price:     orderItems.Price,
 		CreatedAt: orderItems.CreatedAt.Time.Format("2006-01-02 15:04:05.000"),
 		UpdatedAt: orderItems.UpdatedAt.Time.Format("2006-01-02 15:04:05.000"),
 		DeletedAt: deletedAt,
@@ -111,7 +111,7 @@ func (s *orderItemRecordMapper) ToOrderItemRecordTrashedPagination(orderItems *d
 		OrderID:   int(orderItems.OrderID),
 		ProductID: int(orderItems.ProductID),
 		Quantity:  int(orderItems.Quantity),
-		Price:     int(orderItems.Price),
+		Price:     orderItems.Price,
 		CreatedAt: orderItems.CreatedAt.Time.Format("2006-01-02 15:04:05.000"),
 		UpdatedAt: orderItems.UpdatedAt.Time.Format("2006-01-02 15:04:05.000"),
 		DeletedAt: deletedAt,
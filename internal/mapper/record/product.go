@@ -25,7 +25,7 @@ func (s *productRecordMapper) ToProductRecord(product *db.Product) *record.Produ
 		CategoryID:   int(product.CategoryID),
 		Name:         product.Name,
 		Description:  product.Description.String,
-		Price:        int(product.Price),
+		Price:        product.Price,
 		CountInStock: int(product.CountInStock),
 		Brand:        product.Brand.String,
 		Weight:       int(product.Weight.Int32),
@@ -61,7 +61,7 @@ func (s *productRecordMapper) ToProductRecordPagination(product *db.GetProductsR
 		CategoryID:   int(product.CategoryID),
 		Name:         product.Name,
 		Description:  product.Description.String,
-		Price:        int(product.Price),
+		Price:        product.Price,
 		CountInStock: int(product.CountInStock),
 		Brand:        product.Brand.String,
 		Weight:       int(product.Weight.Int32),
@@ -97,7 +97,7 @@ func (s *productRecordMapper) ToProductRecordActivePagination(product *db.GetPro
 		CategoryID:   int(product.CategoryID),
 		Name:         product.Name,
 		Description:  product.Description.String,
-		Price:        int(product.Price),
+		Price:        product.Price,
 		CountInStock: int(product.CountInStock),
 		Brand:        product.Brand.String,
 		Weight:       int(product.Weight.Int32),
@@ -133,7 +133,7 @@ func (s *productRecordMapper) ToProductRecordTrashedPagination(product *db.GetPr
 		CategoryID:   int(product.CategoryID),
 		Name:         product.Name,
 		Description:  product.Description.String,
-		Price:        int(product.Price),
+		Price:        product.Price,
 		CountInStock: int(product.CountInStock),
 		Brand:        product.Brand.String,
 		Weight:       int(product.Weight.Int32),
@@ -169,7 +169,7 @@ func (s *productRecordMapper) ToProductRecordMerchantPagination(product *db.GetP
 		CategoryID:   int(product.CategoryID),
 		Name:         product.Name,
 		Description:  product.Description.String,
-		Price:        int(product.Price),
+		Price:        product.Price,
 		CountInStock: int(product.CountInStock),
 		Brand:        product.Brand.String,
 		Weight:       int(product.Weight.Int32),
@@ -192,6 +192,42 @@ func (s *productRecordMapper) ToProductsRecordMerchantPagination(products []*db.
 	return result
 }
 
+func (s *productRecordMapper) ToProductRecordUserPagination(product *db.GetProductsByUserIDRow) *record.ProductRecord {
+	var deletedAt *string
+	if product.DeletedAt.Valid {
+		deletedAtStr := product.DeletedAt.Time.Format("2006-01-02 15:04:05.000")
+		deletedAt = &deletedAtStr
+	}
+
+	return &record.ProductRecord{
+		ID:           int(product.ProductID),
+		MerchantID:   int(product.MerchantID),
+		CategoryID:   int(product.CategoryID),
+		Name:         product.Name,
+		Description:  product.Description.String,
+		Price:        product.Price,
+		CountInStock: int(product.CountInStock),
+		Brand:        product.Brand.String,
+		Weight:       int(product.Weight.Int32),
+		Rating:       float32(product.Rating.Float64),
+		SlugProduct:  product.SlugProduct.String,
+		ImageProduct: product.ImageProduct.String,
+		CreatedAt:    product.CreatedAt.Time.Format("2006-01-02 15:04:05.000"),
+		UpdatedAt:    product.UpdatedAt.Time.Format("2006-01-02 15:04:05.000"),
+		DeletedAt:    deletedAt,
+	}
+}
+
+func (s *productRecordMapper) ToProductsRecordUserPagination(products []*db.GetProductsByUserIDRow) []*record.ProductRecord {
+	var result []*record.ProductRecord
+
+	for _, product := range products {
+		result = append(result, s.ToProductRecordUserPagination(product))
+	}
+
+	return result
+}
+
 func (s *productRecordMapper) ToProductRecordCategoryPagination(product *db.GetProductsByCategoryNameRow) *record.ProductRecord {
 	var deletedAt *string
 	if product.DeletedAt.Valid {
@@ -205,7 +241,7 @@ func (s *productRecordMapper) ToProductRecordCategoryPagination(product *db.GetP
 		CategoryID:   int(product.CategoryID),
 		Name:         product.Name,
 		Description:  product.Description.String,
-		Price:        int(product.Price),
+		Price:        product.Price,
 		CountInStock: int(product.CountInStock),
 		Brand:        product.Brand.String,
 		Weight:       int(product.Weight.Int32),
@@ -227,3 +263,54 @@ func (s *productRecordMapper) ToProductsRecordCategoryPagination(products []*db.
 
 	return result
 }
+
+func (s *productRecordMapper) ToProductRecordSearchPagination(product *db.SearchProductsRow) *record.ProductRecord {
+	var deletedAt *string
+	if product.DeletedAt.Valid {
+		deletedAtStr := product.DeletedAt.Time.Format("2006-01-02 15:04:05.000")
+		deletedAt = &deletedAtStr
+	}
+
+	return &record.ProductRecord{
+		ID:           int(product.ProductID),
+		MerchantID:   int(product.MerchantID),
+		CategoryID:   int(product.CategoryID),
+		Name:         product.Name,
+		Description:  product.Description.String,
+		Price:        product.Price,
+		CountInStock: int(product.CountInStock),
+		Brand:        product.Brand.String,
+		Weight:       int(product.Weight.Int32),
+		Rating:       float32(product.Rating.Float64),
+		SlugProduct:  product.SlugProduct.String,
+		ImageProduct: product.ImageProduct.String,
+		CreatedAt:    product.CreatedAt.Time.Format("2006-01-02 15:04:05.000"),
+		UpdatedAt:    product.UpdatedAt.Time.Format("2006-01-02 15:04:05.000"),
+		DeletedAt:    deletedAt,
+	}
+}
+
+func (s *productRecordMapper) ToProductsRecordSearchPagination(products []*db.SearchProductsRow) []*record.ProductRecord {
+	var result []*record.ProductRecord
+
+	for _, product := range products {
+		result = append(result, s.ToProductRecordSearchPagination(product))
+	}
+
+	return result
+}
+
+func (s *productRecordMapper) ToProductFacetsRecord(facets []*db.SearchProductFacetsRow) []*record.ProductFacetRecord {
+	var result []*record.ProductFacetRecord
+
+	for _, facet := range facets {
+		result = append(result, &record.ProductFacetRecord{
+			Facet: facet.Facet,
+			Value: facet.Value,
+			Label: facet.Label,
+			Count: int(facet.Count),
+		})
+	}
+
+	return result
+}
@@ -13,13 +13,32 @@ func NewRefreshTokenRecordMapper() *refreshTokenRecordMapper {
 }
 
 func (r *refreshTokenRecordMapper) ToRefreshTokenRecord(refreshToken *db.RefreshToken) *record.RefreshTokenRecord {
+	var usedAt *string
+	if refreshToken.UsedAt.Valid {
+		usedAtStr := refreshToken.UsedAt.Time.Format("2006-01-02 15:04:05.000")
+		usedAt = &usedAtStr
+	}
+
+	var revokedAt *string
+	if refreshToken.RevokedAt.Valid {
+		revokedAtStr := refreshToken.RevokedAt.Time.Format("2006-01-02 15:04:05.000")
+		revokedAt = &revokedAtStr
+	}
+
 	return &record.RefreshTokenRecord{
-		ID:        int(refreshToken.RefreshTokenID),
-		UserID:    int(refreshToken.UserID),
-		Token:     refreshToken.Token,
-		ExpiredAt: refreshToken.Expiration.String(),
-		CreatedAt: refreshToken.CreatedAt.Time.String(),
-		UpdatedAt: refreshToken.UpdatedAt.Time.String(),
+		ID:         int(refreshToken.RefreshTokenID),
+		UserID:     int(refreshToken.UserID),
+		Token:      refreshToken.Token,
+		FamilyID:   refreshToken.FamilyID,
+		Device:     refreshToken.Device.String,
+		UserAgent:  refreshToken.UserAgent.String,
+		IPAddress:  refreshToken.IpAddress.String,
+		SignedInAt: refreshToken.SignedInAt.Format("2006-01-02 15:04:05"),
+		ExpiredAt:  refreshToken.Expiration.Format("2006-01-02 15:04:05"),
+		UsedAt:     usedAt,
+		RevokedAt:  revokedAt,
+		CreatedAt:  refreshToken.CreatedAt.Time.Format("2006-01-02 15:04:05"),
+		UpdatedAt:  refreshToken.UpdatedAt.Time.Format("2006-01-02 15:04:05"),
 	}
 }
 
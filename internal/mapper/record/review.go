@@ -32,6 +32,16 @@ func (s *reviewRecordMapper) ToReviewRecord(review *db.Review) *record.ReviewRec
 	}
 }
 
+func (s *reviewRecordMapper) ToReviewsRecord(reviews []*db.Review) []*record.ReviewRecord {
+	var result []*record.ReviewRecord
+
+	for _, review := range reviews {
+		result = append(result, s.ToReviewRecord(review))
+	}
+
+	return result
+}
+
 func (s *reviewRecordMapper) ToReviewRecordPagination(review *db.GetReviewsRow) *record.ReviewRecord {
 	var deletedAt *string
 	if review.DeletedAt.Valid {
@@ -0,0 +1,32 @@
+package recordmapper
+
+import (
+	"ecommerce/internal/domain/record"
+	db "ecommerce/pkg/database/schema"
+)
+
+type revokedTokenRecordMapper struct {
+}
+
+func NewRevokedTokenRecordMapper() *revokedTokenRecordMapper {
+	return &revokedTokenRecordMapper{}
+}
+
+func (m *revokedTokenRecordMapper) ToRevokedTokenRecord(token *db.RevokedToken) *record.RevokedTokenRecord {
+	return &record.RevokedTokenRecord{
+		ID:        int(token.RevokedTokenID),
+		TokenID:   token.Jti.String,
+		UserID:    int(token.UserID.Int32),
+		Reason:    token.Reason,
+		RevokedAt: token.RevokedAt.Format("2006-01-02 15:04:05.000"),
+		ExpiresAt: token.ExpiresAt.Format("2006-01-02 15:04:05.000"),
+	}
+}
+
+func (m *revokedTokenRecordMapper) ToRevokedTokensRecord(tokens []*db.RevokedToken) []*record.RevokedTokenRecord {
+	var records []*record.RevokedTokenRecord
+	for _, token := range tokens {
+		records = append(records, m.ToRevokedTokenRecord(token))
+	}
+	return records
+}
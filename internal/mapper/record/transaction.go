@@ -20,14 +20,20 @@ func (s *transactionRecordMapper) ToTransactionRecord(transaction *db.Transactio
 	}
 
 	return &record.TransactionRecord{
-		ID:            int(transaction.TransactionID),
-		OrderID:       int(transaction.OrderID),
-		PaymentMethod: transaction.PaymentMethod,
-		Amount:        int(transaction.Amount),
-		PaymentStatus: transaction.PaymentStatus,
-		CreatedAt:     transaction.CreatedAt.Time.Format("2006-01-02 15:04:05.000"),
-		UpdatedAt:     transaction.UpdatedAt.Time.Format("2006-01-02 15:04:05.000"),
-		DeletedAt:     deletedAt,
+		ID:               int(transaction.TransactionID),
+		OrderID:          int(transaction.OrderID),
+		MerchantID:       int(transaction.MerchantID),
+		PaymentMethod:    transaction.PaymentMethod,
+		Amount:           transaction.Amount,
+		PaymentStatus:    transaction.PaymentStatus,
+		PaymentProvider:  transaction.PaymentProvider.String,
+		ProviderChargeID: transaction.ProviderChargeID.String,
+		RefundedAmount:   transaction.RefundedAmount,
+		AmountDue:        transaction.AmountDue,
+		ChangeAmount:     transaction.ChangeAmount,
+		CreatedAt:        transaction.CreatedAt.Time.Format("2006-01-02 15:04:05.000"),
+		UpdatedAt:        transaction.UpdatedAt.Time.Format("2006-01-02 15:04:05.000"),
+		DeletedAt:        deletedAt,
 	}
 }
 
@@ -49,14 +55,20 @@ func (s *transactionRecordMapper) ToTransactionMerchantRecordPagination(transact
 	}
 
 	return &record.TransactionRecord{
-		ID:            int(transaction.TransactionID),
-		OrderID:       int(transaction.OrderID),
-		PaymentMethod: transaction.PaymentMethod,
-		Amount:        int(transaction.Amount),
-		PaymentStatus: transaction.PaymentStatus,
-		CreatedAt:     transaction.CreatedAt.Time.Format("2006-01-02 15:04:05.000"),
-		UpdatedAt:     transaction.UpdatedAt.Time.Format("2006-01-02 15:04:05.000"),
-		DeletedAt:     deletedAt,
+		ID:               int(transaction.TransactionID),
+		OrderID:          int(transaction.OrderID),
+		MerchantID:       int(transaction.MerchantID),
+		PaymentMethod:    transaction.PaymentMethod,
+		Amount:           transaction.Amount,
+		PaymentStatus:    transaction.PaymentStatus,
+		PaymentProvider:  transaction.PaymentProvider.String,
+		ProviderChargeID: transaction.ProviderChargeID.String,
+		RefundedAmount:   transaction.RefundedAmount,
+		AmountDue:        transaction.AmountDue,
+		ChangeAmount:     transaction.ChangeAmount,
+		CreatedAt:        transaction.CreatedAt.Time.Format("2006-01-02 15:04:05.000"),
+		UpdatedAt:        transaction.UpdatedAt.Time.Format("2006-01-02 15:04:05.000"),
+		DeletedAt:        deletedAt,
 	}
 }
 
@@ -78,14 +90,20 @@ func (s *transactionRecordMapper) ToTransactionRecordPagination(transaction *db.
 	}
 
 	return &record.TransactionRecord{
-		ID:            int(transaction.TransactionID),
-		OrderID:       int(transaction.OrderID),
-		PaymentMethod: transaction.PaymentMethod,
-		Amount:        int(transaction.Amount),
-		PaymentStatus: transaction.PaymentStatus,
-		CreatedAt:     transaction.CreatedAt.Time.Format("2006-01-02 15:04:05.000"),
-		UpdatedAt:     transaction.UpdatedAt.Time.Format("2006-01-02 15:04:05.000"),
-		DeletedAt:     deletedAt,
+		ID:               int(transaction.TransactionID),
+		OrderID:          int(transaction.OrderID),
+		MerchantID:       int(transaction.MerchantID),
+		PaymentMethod:    transaction.PaymentMethod,
+		Amount:           transaction.Amount,
+		PaymentStatus:    transaction.PaymentStatus,
+		PaymentProvider:  transaction.PaymentProvider.String,
+		ProviderChargeID: transaction.ProviderChargeID.String,
+		RefundedAmount:   transaction.RefundedAmount,
+		AmountDue:        transaction.AmountDue,
+		ChangeAmount:     transaction.ChangeAmount,
+		CreatedAt:        transaction.CreatedAt.Time.Format("2006-01-02 15:04:05.000"),
+		UpdatedAt:        transaction.UpdatedAt.Time.Format("2006-01-02 15:04:05.000"),
+		DeletedAt:        deletedAt,
 	}
 }
 
@@ -107,15 +125,20 @@ func (s *transactionRecordMapper) ToTransactionRecordActivePagination(transactio
 	}
 
 	return &record.TransactionRecord{
-		ID:            int(transaction.TransactionID),
-		OrderID:       int(transaction.OrderID),
-		MerchantID:    int(transaction.MerchantID),
-		PaymentMethod: transaction.PaymentMethod,
-		Amount:        int(transaction.Amount),
-		PaymentStatus: transaction.PaymentStatus,
-		CreatedAt:     transaction.CreatedAt.Time.Format("2006-01-02 15:04:05.000"),
-		UpdatedAt:     transaction.UpdatedAt.Time.Format("2006-01-02 15:04:05.000"),
-		DeletedAt:     deletedAt,
+		ID:               int(transaction.TransactionID),
+		OrderID:          int(transaction.OrderID),
+		MerchantID:       int(transaction.MerchantID),
+		PaymentMethod:    transaction.PaymentMethod,
+		Amount:           transaction.Amount,
+		PaymentStatus:    transaction.PaymentStatus,
+		PaymentProvider:  transaction.PaymentProvider.String,
+		ProviderChargeID: transaction.ProviderChargeID.String,
+		RefundedAmount:   transaction.RefundedAmount,
+		AmountDue:        transaction.AmountDue,
+		ChangeAmount:     transaction.ChangeAmount,
+		CreatedAt:        transaction.CreatedAt.Time.Format("2006-01-02 15:04:05.000"),
+		UpdatedAt:        transaction.UpdatedAt.Time.Format("2006-01-02 15:04:05.000"),
+		DeletedAt:        deletedAt,
 	}
 }
 
@@ -137,15 +160,20 @@ func (s *transactionRecordMapper) ToTransactionRecordTrashedPagination(transacti
 	}
 
 	return &record.TransactionRecord{
-		ID:            int(transaction.TransactionID),
-		OrderID:       int(transaction.OrderID),
-		MerchantID:    int(transaction.MerchantID),
-		PaymentMethod: transaction.PaymentMethod,
-		Amount:        int(transaction.Amount),
-		PaymentStatus: transaction.PaymentStatus,
-		CreatedAt:     transaction.CreatedAt.Time.Format("2006-01-02 15:04:05.000"),
-		UpdatedAt:     transaction.UpdatedAt.Time.Format("2006-01-02 15:04:05.000"),
-		DeletedAt:     deletedAt,
+		ID:               int(transaction.TransactionID),
+		OrderID:          int(transaction.OrderID),
+		MerchantID:       int(transaction.MerchantID),
+		PaymentMethod:    transaction.PaymentMethod,
+		Amount:           transaction.Amount,
+		PaymentStatus:    transaction.PaymentStatus,
+		PaymentProvider:  transaction.PaymentProvider.String,
+		ProviderChargeID: transaction.ProviderChargeID.String,
+		RefundedAmount:   transaction.RefundedAmount,
+		AmountDue:        transaction.AmountDue,
+		ChangeAmount:     transaction.ChangeAmount,
+		CreatedAt:        transaction.CreatedAt.Time.Format("2006-01-02 15:04:05.000"),
+		UpdatedAt:        transaction.UpdatedAt.Time.Format("2006-01-02 15:04:05.000"),
+		DeletedAt:        deletedAt,
 	}
 }
 
@@ -158,3 +186,62 @@ func (s *transactionRecordMapper) ToTransactionsRecordTrashedPagination(products
 
 	return result
 }
+
+func (s *transactionRecordMapper) ToPaymentEventRecord(event *db.PaymentEvent) *record.PaymentEventRecord {
+	var transactionID *int
+	if event.TransactionID.Valid {
+		id := int(event.TransactionID.Int32)
+		transactionID = &id
+	}
+
+	return &record.PaymentEventRecord{
+		ID:            int(event.PaymentEventID),
+		Provider:      event.Provider,
+		EventID:       event.EventID,
+		EventType:     event.EventType,
+		TransactionID: transactionID,
+		CreatedAt:     event.CreatedAt.Time.Format("2006-01-02 15:04:05.000"),
+	}
+}
+
+func (s *transactionRecordMapper) ToRefundRecord(refund *db.Refund) *record.RefundRecord {
+	return &record.RefundRecord{
+		ID:               int(refund.RefundID),
+		TransactionID:    int(refund.TransactionID),
+		Amount:           refund.Amount,
+		Reason:           refund.Reason.String,
+		ProviderRefundID: refund.ProviderRefundID.String,
+		Status:           refund.Status,
+		CreatedAt:        refund.CreatedAt.Time.Format("2006-01-02 15:04:05.000"),
+	}
+}
+
+func (s *transactionRecordMapper) ToRefundsRecord(refunds []*db.Refund) []*record.RefundRecord {
+	var result []*record.RefundRecord
+
+	for _, refund := range refunds {
+		result = append(result, s.ToRefundRecord(refund))
+	}
+
+	return result
+}
+
+func (s *transactionRecordMapper) ToRefundItemRecord(item *db.RefundItem) *record.RefundItemRecord {
+	return &record.RefundItemRecord{
+		ID:          int(item.RefundItemID),
+		RefundID:    int(item.RefundID),
+		OrderItemID: int(item.OrderItemID),
+		Quantity:    int(item.Quantity),
+		Amount:      item.Amount,
+	}
+}
+
+func (s *transactionRecordMapper) ToRefundItemsRecord(items []*db.RefundItem) []*record.RefundItemRecord {
+	var result []*record.RefundItemRecord
+
+	for _, item := range items {
+		result = append(result, s.ToRefundItemRecord(item))
+	}
+
+	return result
+}
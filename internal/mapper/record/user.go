@@ -21,15 +21,24 @@ func (s *userRecordMapper) ToUserRecord(user *db.User) *record.UserRecord {
 		deletedAt = &formatedDeletedAt
 	}
 
+	var emailVerifiedAt *string
+
+	if user.EmailVerifiedAt.Valid {
+		formatedEmailVerifiedAt := user.EmailVerifiedAt.Time.Format("2006-01-02 15:04:05")
+
+		emailVerifiedAt = &formatedEmailVerifiedAt
+	}
+
 	return &record.UserRecord{
-		ID:        int(user.UserID),
-		FirstName: user.Firstname,
-		LastName:  user.Lastname,
-		Email:     user.Email,
-		Password:  user.Password,
-		CreatedAt: user.CreatedAt.Time.Format("2006-01-02 15:04:05"),
-		UpdatedAt: user.UpdatedAt.Time.Format("2006-01-02 15:04:05"),
-		DeletedAt: deletedAt,
+		ID:              int(user.UserID),
+		FirstName:       user.Firstname,
+		LastName:        user.Lastname,
+		Email:           user.Email,
+		Password:        user.Password,
+		CreatedAt:       user.CreatedAt.Time.Format("2006-01-02 15:04:05"),
+		UpdatedAt:       user.UpdatedAt.Time.Format("2006-01-02 15:04:05"),
+		DeletedAt:       deletedAt,
+		EmailVerifiedAt: emailVerifiedAt,
 	}
 }
 
@@ -42,15 +51,24 @@ func (s *userRecordMapper) ToUserRecordPagination(user *db.GetUsersRow) *record.
 		deletedAt = &formatedDeletedAt
 	}
 
+	var emailVerifiedAt *string
+
+	if user.EmailVerifiedAt.Valid {
+		formatedEmailVerifiedAt := user.EmailVerifiedAt.Time.Format("2006-01-02 15:04:05")
+
+		emailVerifiedAt = &formatedEmailVerifiedAt
+	}
+
 	return &record.UserRecord{
-		ID:        int(user.UserID),
-		FirstName: user.Firstname,
-		LastName:  user.Lastname,
-		Email:     user.Email,
-		Password:  user.Password,
-		CreatedAt: user.CreatedAt.Time.Format("2006-01-02 15:04:05"),
-		UpdatedAt: user.UpdatedAt.Time.Format("2006-01-02 15:04:05"),
-		DeletedAt: deletedAt,
+		ID:              int(user.UserID),
+		FirstName:       user.Firstname,
+		LastName:        user.Lastname,
+		Email:           user.Email,
+		Password:        user.Password,
+		CreatedAt:       user.CreatedAt.Time.Format("2006-01-02 15:04:05"),
+		UpdatedAt:       user.UpdatedAt.Time.Format("2006-01-02 15:04:05"),
+		DeletedAt:       deletedAt,
+		EmailVerifiedAt: emailVerifiedAt,
 	}
 }
 
@@ -73,15 +91,24 @@ func (s *userRecordMapper) ToUserRecordActivePagination(user *db.GetUsersActiveR
 		deletedAt = &formatedDeletedAt
 	}
 
+	var emailVerifiedAt *string
+
+	if user.EmailVerifiedAt.Valid {
+		formatedEmailVerifiedAt := user.EmailVerifiedAt.Time.Format("2006-01-02 15:04:05")
+
+		emailVerifiedAt = &formatedEmailVerifiedAt
+	}
+
 	return &record.UserRecord{
-		ID:        int(user.UserID),
-		FirstName: user.Firstname,
-		LastName:  user.Lastname,
-		Email:     user.Email,
-		Password:  user.Password,
-		CreatedAt: user.CreatedAt.Time.Format("2006-01-02 15:04:05"),
-		UpdatedAt: user.UpdatedAt.Time.Format("2006-01-02 15:04:05"),
-		DeletedAt: deletedAt,
+		ID:              int(user.UserID),
+		FirstName:       user.Firstname,
+		LastName:        user.Lastname,
+		Email:           user.Email,
+		Password:        user.Password,
+		CreatedAt:       user.CreatedAt.Time.Format("2006-01-02 15:04:05"),
+		UpdatedAt:       user.UpdatedAt.Time.Format("2006-01-02 15:04:05"),
+		DeletedAt:       deletedAt,
+		EmailVerifiedAt: emailVerifiedAt,
 	}
 }
 
@@ -104,15 +131,24 @@ func (s *userRecordMapper) ToUserRecordTrashedPagination(user *db.GetUserTrashed
 		deletedAt = &formatedDeletedAt
 	}
 
+	var emailVerifiedAt *string
+
+	if user.EmailVerifiedAt.Valid {
+		formatedEmailVerifiedAt := user.EmailVerifiedAt.Time.Format("2006-01-02 15:04:05")
+
+		emailVerifiedAt = &formatedEmailVerifiedAt
+	}
+
 	return &record.UserRecord{
-		ID:        int(user.UserID),
-		FirstName: user.Firstname,
-		LastName:  user.Lastname,
-		Email:     user.Email,
-		Password:  user.Password,
-		CreatedAt: user.CreatedAt.Time.Format("2006-01-02 15:04:05"),
-		UpdatedAt: user.UpdatedAt.Time.Format("2006-01-02 15:04:05"),
-		DeletedAt: deletedAt,
+		ID:              int(user.UserID),
+		FirstName:       user.Firstname,
+		LastName:        user.Lastname,
+		Email:           user.Email,
+		Password:        user.Password,
+		CreatedAt:       user.CreatedAt.Time.Format("2006-01-02 15:04:05"),
+		UpdatedAt:       user.UpdatedAt.Time.Format("2006-01-02 15:04:05"),
+		DeletedAt:       deletedAt,
+		EmailVerifiedAt: emailVerifiedAt,
 	}
 }
 
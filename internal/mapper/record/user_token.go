@@ -0,0 +1,33 @@
+package recordmapper
+
+import (
+	"ecommerce/internal/domain/record"
+	db "ecommerce/pkg/database/schema"
+)
+
+type userTokenRecordMapper struct {
+}
+
+func NewUserTokenRecordMapper() *userTokenRecordMapper {
+	return &userTokenRecordMapper{}
+}
+
+func (m *userTokenRecordMapper) ToUserTokenRecord(token *db.UserToken) *record.UserTokenRecord {
+	var usedAt *string
+
+	if token.UsedAt.Valid {
+		formatedUsedAt := token.UsedAt.Time.Format("2006-01-02 15:04:05")
+
+		usedAt = &formatedUsedAt
+	}
+
+	return &record.UserTokenRecord{
+		ID:        int(token.UserTokenID),
+		UserID:    int(token.UserID),
+		Purpose:   token.Purpose,
+		Token:     token.Token,
+		ExpiresAt: token.ExpiresAt.Format("2006-01-02 15:04:05"),
+		UsedAt:    usedAt,
+		CreatedAt: token.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
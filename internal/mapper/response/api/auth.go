@@ -63,3 +63,53 @@ func (s *authResponseMapper) ToResponseGetMe(res *pb.ApiResponseGetMe) *response
 		},
 	}
 }
+
+func (s *authResponseMapper) ToResponseLogout(res *pb.ApiResponseLogout) *response.ApiResponseLogout {
+	return &response.ApiResponseLogout{
+		Status:  res.Status,
+		Message: res.Message,
+	}
+}
+
+func (s *authResponseMapper) ToResponseSessions(res *pb.ApiResponseSessions) *response.ApiResponseSessions {
+	data := make([]*response.SessionResponse, 0, len(res.Data))
+
+	for _, session := range res.Data {
+		data = append(data, &response.SessionResponse{
+			ID:           session.Id,
+			Device:       session.Device,
+			UserAgent:    session.UserAgent,
+			IPAddress:    session.IpAddress,
+			SignedInAt:   session.SignedInAt,
+			LastActiveAt: session.LastActiveAt,
+			ExpiresAt:    session.ExpiresAt,
+		})
+	}
+
+	return &response.ApiResponseSessions{
+		Status:  res.Status,
+		Message: res.Message,
+		Data:    data,
+	}
+}
+
+func (s *authResponseMapper) ToResponseVerifyEmail(res *pb.ApiResponseVerifyEmail) *response.ApiResponseVerifyEmail {
+	return &response.ApiResponseVerifyEmail{
+		Status:  res.Status,
+		Message: res.Message,
+	}
+}
+
+func (s *authResponseMapper) ToResponsePasswordReset(res *pb.ApiResponsePasswordReset) *response.ApiResponsePasswordReset {
+	return &response.ApiResponsePasswordReset{
+		Status:  res.Status,
+		Message: res.Message,
+	}
+}
+
+func (s *authResponseMapper) ToResponseUnlockLogin(res *pb.ApiResponseUnlockLogin) *response.ApiResponseUnlockLogin {
+	return &response.ApiResponseUnlockLogin{
+		Status:  res.Status,
+		Message: res.Message,
+	}
+}
@@ -18,7 +18,7 @@ func (t *cartResponseMapper) ToResponseCart(pbResponse *pb.CartResponse) *respon
 		UserID:    int(pbResponse.UserId),
 		ProductID: int(pbResponse.ProductId),
 		Name:      pbResponse.Name,
-		Price:     int(pbResponse.Price),
+		Price:     mapMoney(pbResponse.Price),
 		Image:     pbResponse.Image,
 		Quantity:  int(pbResponse.Quantity),
 		Weight:    int(pbResponse.Weight),
@@ -57,3 +57,23 @@ func (t *cartResponseMapper) ToApiResponseCartAll(pbResponse *pb.ApiResponseCart
 		Message: pbResponse.Message,
 	}
 }
+
+func (t *cartResponseMapper) ToApiResponseCart(pbResponse *pb.ApiResponseCart) *response.ApiResponseCart {
+	return &response.ApiResponseCart{
+		Status:  pbResponse.Status,
+		Message: pbResponse.Message,
+		Data:    t.ToResponseCart(pbResponse.Data),
+	}
+}
+
+func (t *cartResponseMapper) ToApiResponseCartSummary(pbResponse *pb.ApiResponseCartSummary) *response.ApiResponseCartSummary {
+	return &response.ApiResponseCartSummary{
+		Status:  pbResponse.Status,
+		Message: pbResponse.Message,
+		Data: &response.CartSummaryResponse{
+			ItemCount:   int(pbResponse.Data.ItemCount),
+			TotalWeight: int(pbResponse.Data.TotalWeight),
+			Subtotal:    mapMoney(pbResponse.Data.Subtotal),
+		},
+	}
+}
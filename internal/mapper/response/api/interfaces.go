@@ -10,6 +10,11 @@ type AuthResponseMapper interface {
 	ToResponseRegister(res *pb.ApiResponseRegister) *response.ApiResponseRegister
 	ToResponseRefreshToken(res *pb.ApiResponseRefreshToken) *response.ApiResponseRefreshToken
 	ToResponseGetMe(res *pb.ApiResponseGetMe) *response.ApiResponseGetMe
+	ToResponseLogout(res *pb.ApiResponseLogout) *response.ApiResponseLogout
+	ToResponseSessions(res *pb.ApiResponseSessions) *response.ApiResponseSessions
+	ToResponseVerifyEmail(res *pb.ApiResponseVerifyEmail) *response.ApiResponseVerifyEmail
+	ToResponsePasswordReset(res *pb.ApiResponsePasswordReset) *response.ApiResponsePasswordReset
+	ToResponseUnlockLogin(res *pb.ApiResponseUnlockLogin) *response.ApiResponseUnlockLogin
 }
 
 type RoleResponseMapper interface {
@@ -70,6 +75,7 @@ type OrderResponseMapper interface {
 	ToApiResponseOrderAll(pbResponse *pb.ApiResponseOrderAll) *response.ApiResponseOrderAll
 	ToApiResponsePaginationOrderDeleteAt(pbResponse *pb.ApiResponsePaginationOrderDeleteAt) *response.ApiResponsePaginationOrderDeleteAt
 	ToApiResponsePaginationOrder(pbResponse *pb.ApiResponsePaginationOrder) *response.ApiResponsePaginationOrder
+	ToApiResponseOrderStatusHistory(pbResponse *pb.ApiResponseOrderStatusHistory) *response.ApiResponseOrderStatusHistory
 }
 
 type ProductResponseMapper interface {
@@ -80,6 +86,7 @@ type ProductResponseMapper interface {
 	ToApiResponseProductAll(pbResponse *pb.ApiResponseProductAll) *response.ApiResponseProductAll
 	ToApiResponsePaginationProductDeleteAt(pbResponse *pb.ApiResponsePaginationProductDeleteAt) *response.ApiResponsePaginationProductDeleteAt
 	ToApiResponsePaginationProduct(pbResponse *pb.ApiResponsePaginationProduct) *response.ApiResponsePaginationProduct
+	ToApiResponseProductSearch(pbResponse *pb.ApiResponseProductSearch) *response.ApiResponseProductSearch
 }
 
 type TransactionResponseMapper interface {
@@ -94,6 +101,8 @@ type TransactionResponseMapper interface {
 
 type CartResponseMapper interface {
 	ToApiResponseCartPagination(pbResponse *pb.ApiResponsePaginationCart) *response.ApiResponseCartPagination
+	ToApiResponseCart(pbResponse *pb.ApiResponseCart) *response.ApiResponseCart
+	ToApiResponseCartSummary(pbResponse *pb.ApiResponseCartSummary) *response.ApiResponseCartSummary
 	ToApiResponseCartDelete(pbResponse *pb.ApiResponseCartDelete) *response.ApiResponseCartDelete
 	ToApiResponseCartAll(pbResponse *pb.ApiResponseCartAll) *response.ApiResponseCartAll
 }
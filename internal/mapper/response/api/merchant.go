@@ -22,6 +22,7 @@ func (m *merchantResponseMapper) ToResponseMerchant(merchant *pb.MerchantRespons
 		ContactEmail: merchant.ContactEmail,
 		ContactPhone: merchant.ContactPhone,
 		Status:       merchant.Status,
+		Currency:     merchant.Currency,
 		CreatedAt:    merchant.CreatedAt,
 		UpdatedAt:    merchant.UpdatedAt,
 	}
@@ -47,6 +48,7 @@ func (m *merchantResponseMapper) ToResponseMerchantDeleteAt(merchant *pb.Merchan
 		ContactEmail: merchant.ContactEmail,
 		ContactPhone: merchant.ContactPhone,
 		Status:       merchant.Status,
+		Currency:     merchant.Currency,
 		CreatedAt:    merchant.CreatedAt,
 		UpdatedAt:    merchant.UpdatedAt,
 		DeletedAt:    merchant.DeletedAt,
@@ -0,0 +1,10 @@
+package response_api
+
+import (
+	"ecommerce/internal/domain/money"
+	"ecommerce/internal/pb"
+)
+
+func mapMoney(m *pb.Money) money.Money {
+	return money.New(m.GetAmount(), m.GetCurrency())
+}
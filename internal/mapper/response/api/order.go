@@ -14,12 +14,17 @@ func NewOrderResponseMapper() *orderResponseMapper {
 
 func (o *orderResponseMapper) ToResponseOrder(order *pb.OrderResponse) *response.OrderResponse {
 	return &response.OrderResponse{
-		ID:         int(order.Id),
-		MerchantID: int(order.MerchantId),
-		UserID:     int(order.UserId),
-		TotalPrice: int(order.TotalPrice),
-		CreatedAt:  order.CreatedAt,
-		UpdatedAt:  order.UpdatedAt,
+		ID:             int(order.Id),
+		MerchantID:     int(order.MerchantId),
+		UserID:         int(order.UserId),
+		TotalPrice:     mapMoney(order.TotalPrice),
+		Subtotal:       mapMoney(order.Subtotal),
+		ShippingCost:   mapMoney(order.ShippingCost),
+		TaxAmount:      mapMoney(order.TaxAmount),
+		DiscountAmount: mapMoney(order.DiscountAmount),
+		Status:         order.Status,
+		CreatedAt:      order.CreatedAt,
+		UpdatedAt:      order.UpdatedAt,
 	}
 }
 
@@ -35,13 +40,18 @@ func (o *orderResponseMapper) ToResponsesOrder(orders []*pb.OrderResponse) []*re
 
 func (o *orderResponseMapper) ToResponseOrderDeleteAt(order *pb.OrderResponseDeleteAt) *response.OrderResponseDeleteAt {
 	return &response.OrderResponseDeleteAt{
-		ID:         int(order.Id),
-		MerchantID: int(order.MerchantId),
-		UserID:     int(order.UserId),
-		TotalPrice: int(order.TotalPrice),
-		CreatedAt:  order.CreatedAt,
-		UpdatedAt:  order.UpdatedAt,
-		DeleteAt:   order.DeletedAt,
+		ID:             int(order.Id),
+		MerchantID:     int(order.MerchantId),
+		UserID:         int(order.UserId),
+		TotalPrice:     mapMoney(order.TotalPrice),
+		Subtotal:       mapMoney(order.Subtotal),
+		ShippingCost:   mapMoney(order.ShippingCost),
+		TaxAmount:      mapMoney(order.TaxAmount),
+		DiscountAmount: mapMoney(order.DiscountAmount),
+		Status:         order.Status,
+		CreatedAt:      order.CreatedAt,
+		UpdatedAt:      order.UpdatedAt,
+		DeleteAt:       order.DeletedAt,
 	}
 }
 
@@ -110,3 +120,32 @@ func (o *orderResponseMapper) ToApiResponsePaginationOrder(pbResponse *pb.ApiRes
 		Pagination: *mapPaginationMeta(pbResponse.Pagination),
 	}
 }
+
+func (o *orderResponseMapper) ToResponseOrderStatusHistory(history *pb.OrderStatusHistoryResponse) *response.OrderStatusHistoryResponse {
+	return &response.OrderStatusHistoryResponse{
+		ID:         int(history.Id),
+		OrderID:    int(history.OrderId),
+		FromStatus: history.FromStatus,
+		ToStatus:   history.ToStatus,
+		Note:       history.Note,
+		CreatedAt:  history.CreatedAt,
+	}
+}
+
+func (o *orderResponseMapper) ToResponsesOrderStatusHistory(histories []*pb.OrderStatusHistoryResponse) []*response.OrderStatusHistoryResponse {
+	var mappedHistories []*response.OrderStatusHistoryResponse
+
+	for _, history := range histories {
+		mappedHistories = append(mappedHistories, o.ToResponseOrderStatusHistory(history))
+	}
+
+	return mappedHistories
+}
+
+func (o *orderResponseMapper) ToApiResponseOrderStatusHistory(pbResponse *pb.ApiResponseOrderStatusHistory) *response.ApiResponseOrderStatusHistory {
+	return &response.ApiResponseOrderStatusHistory{
+		Status:  pbResponse.Status,
+		Message: pbResponse.Message,
+		Data:    o.ToResponsesOrderStatusHistory(pbResponse.Data),
+	}
+}
@@ -18,7 +18,7 @@ func (o *orderItemResponseMapper) ToResponseOrderItem(orderItem *pb.OrderItemRes
 		OrderID:   int(orderItem.OrderId),
 		ProductID: int(orderItem.ProductId),
 		Quantity:  int(orderItem.Quantity),
-		Price:     int(orderItem.Price),
+		Price:     mapMoney(orderItem.Price),
 		CreatedAt: orderItem.CreatedAt,
 		UpdatedAt: orderItem.UpdatedAt,
 	}
@@ -40,7 +40,7 @@ func (o *orderItemResponseMapper) ToResponseOrderItemDeleteAt(orderItem *pb.Orde
 		OrderID:   int(orderItem.OrderId),
 		ProductID: int(orderItem.ProductId),
 		Quantity:  int(orderItem.Quantity),
-		Price:     int(orderItem.Price),
+		Price:     mapMoney(orderItem.Price),
 		CreatedAt: orderItem.CreatedAt,
 		UpdatedAt: orderItem.UpdatedAt,
 		DeleteAt:  orderItem.DeletedAt,
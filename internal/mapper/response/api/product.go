@@ -19,7 +19,7 @@ func (p *productResponseMapper) ToResponseProduct(product *pb.ProductResponse) *
 		CategoryID:   int(product.CategoryId),
 		Name:         product.Name,
 		Description:  product.Description,
-		Price:        int(product.Price),
+		Price:        mapMoney(product.Price),
 		CountInStock: int(product.CountInStock),
 		Brand:        product.Brand,
 		Weight:       int(product.Weight),
@@ -48,7 +48,7 @@ func (p *productResponseMapper) ToResponseProductDeleteAt(product *pb.ProductRes
 		CategoryID:   int(product.CategoryId),
 		Name:         product.Name,
 		Description:  product.Description,
-		Price:        int(product.Price),
+		Price:        mapMoney(product.Price),
 		CountInStock: int(product.CountInStock),
 		Brand:        product.Brand,
 		Weight:       int(product.Weight),
@@ -126,3 +126,50 @@ func (p *productResponseMapper) ToApiResponsePaginationProduct(pbResponse *pb.Ap
 		Pagination: *mapPaginationMeta(pbResponse.Pagination),
 	}
 }
+
+func (p *productResponseMapper) ToApiResponseProductSearch(pbResponse *pb.ApiResponseProductSearch) *response.ApiResponseProductSearch {
+	return &response.ApiResponseProductSearch{
+		Status:     pbResponse.Status,
+		Message:    pbResponse.Message,
+		Data:       p.ToResponsesProduct(pbResponse.Data),
+		Pagination: *mapPaginationMeta(pbResponse.Pagination),
+		Facets:     p.toResponseProductFacets(pbResponse.Facets),
+	}
+}
+
+func (p *productResponseMapper) toResponseProductFacets(facets *pb.ProductFacets) *response.ProductFacetsResponse {
+	priceBuckets := make([]*response.ProductPriceBucketResponse, 0, len(facets.GetPriceBuckets()))
+	for _, bucket := range facets.GetPriceBuckets() {
+		priceBucket := &response.ProductPriceBucketResponse{
+			Min:   mapMoney(bucket.Min),
+			Count: int(bucket.Count),
+		}
+		if bucket.Max != nil {
+			upper := mapMoney(bucket.Max)
+			priceBucket.Max = &upper
+		}
+
+		priceBuckets = append(priceBuckets, priceBucket)
+	}
+
+	return &response.ProductFacetsResponse{
+		Categories:   p.toResponsesProductFacetValue(facets.GetCategories()),
+		Brands:       p.toResponsesProductFacetValue(facets.GetBrands()),
+		Merchants:    p.toResponsesProductFacetValue(facets.GetMerchants()),
+		PriceBuckets: priceBuckets,
+	}
+}
+
+func (p *productResponseMapper) toResponsesProductFacetValue(values []*pb.ProductFacetValue) []*response.ProductFacetValueResponse {
+	mappedValues := make([]*response.ProductFacetValueResponse, 0, len(values))
+
+	for _, value := range values {
+		mappedValues = append(mappedValues, &response.ProductFacetValueResponse{
+			Value: value.Value,
+			Label: value.Label,
+			Count: int(value.Count),
+		})
+	}
+
+	return mappedValues
+}
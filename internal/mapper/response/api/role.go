@@ -105,5 +105,6 @@ func mapPaginationMeta(s *pb.PaginationMeta) *response.PaginationMeta {
 		PageSize:     int(s.PageSize),
 		TotalRecords: int(s.TotalRecords),
 		TotalPages:   int(s.TotalPages),
+		NextCursor:   s.NextCursor,
 	}
 }
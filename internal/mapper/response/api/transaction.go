@@ -14,15 +14,18 @@ func NewTransactionResponseMapper() *transactionResponseMapper {
 
 func (t *transactionResponseMapper) ToResponseTransaction(transaction *pb.TransactionResponse) *response.TransactionResponse {
 	return &response.TransactionResponse{
-		ID:            int(transaction.Id),
-		OrderID:       int(transaction.OrderId),
-		MerchantID:    int(transaction.MerchantId),
-		PaymentMethod: transaction.PaymentMethod,
-		Amount:        int(transaction.Amount),
-		ChangeAmount:  int(transaction.ChangeAmount),
-		PaymentStatus: transaction.PaymentStatus,
-		CreatedAt:     transaction.CreatedAt,
-		UpdatedAt:     transaction.UpdatedAt,
+		ID:             int(transaction.Id),
+		OrderID:        int(transaction.OrderId),
+		MerchantID:     int(transaction.MerchantId),
+		PaymentMethod:  transaction.PaymentMethod,
+		Amount:         mapMoney(transaction.Amount),
+		AmountDue:      mapMoney(transaction.AmountDue),
+		ChangeAmount:   mapMoney(transaction.ChangeAmount),
+		PaymentStatus:  transaction.PaymentStatus,
+		RefundedAmount: mapMoney(transaction.RefundedAmount),
+		NetAmount:      mapMoney(transaction.NetAmount),
+		CreatedAt:      transaction.CreatedAt,
+		UpdatedAt:      transaction.UpdatedAt,
 	}
 }
 
@@ -42,8 +45,9 @@ func (t *transactionResponseMapper) ToResponseTransactionDeleteAt(transaction *p
 		OrderID:       int(transaction.OrderId),
 		MerchantID:    int(transaction.MerchantId),
 		PaymentMethod: transaction.PaymentMethod,
-		Amount:        int(transaction.Amount),
-		ChangeAmount:  int(transaction.ChangeAmount),
+		Amount:        mapMoney(transaction.Amount),
+		AmountDue:     mapMoney(transaction.AmountDue),
+		ChangeAmount:  mapMoney(transaction.ChangeAmount),
 		PaymentStatus: transaction.PaymentStatus,
 		CreatedAt:     transaction.CreatedAt,
 		UpdatedAt:     transaction.UpdatedAt,
@@ -1,6 +1,7 @@
 package response_service
 
 import (
+	"ecommerce/internal/domain/money"
 	"ecommerce/internal/domain/record"
 	"ecommerce/internal/domain/response"
 )
@@ -18,7 +19,7 @@ func (s *cartResponseMapper) ToCartResponse(cart *record.CartRecord) *response.C
 		UserID:    int(cart.UserID),
 		ProductID: int(cart.ProductID),
 		Name:      cart.Name,
-		Price:     cart.Price,
+		Price:     money.New(cart.Price, money.DefaultCurrency),
 		Image:     cart.Image,
 		Quantity:  int(cart.Quantity),
 		Weight:    int(cart.Weight),
@@ -36,3 +37,11 @@ func (s *cartResponseMapper) ToCartsResponse(users []*record.CartRecord) []*resp
 
 	return responses
 }
+
+func (s *cartResponseMapper) ToCartSummaryResponse(summary *record.CartSummaryRecord) *response.CartSummaryResponse {
+	return &response.CartSummaryResponse{
+		ItemCount:   summary.ItemCount,
+		TotalWeight: summary.TotalWeight,
+		Subtotal:    money.New(summary.Subtotal, money.DefaultCurrency),
+	}
+}
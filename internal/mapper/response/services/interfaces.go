@@ -24,6 +24,8 @@ type RoleResponseMapper interface {
 type RefreshTokenResponseMapper interface {
 	ToRefreshTokenResponse(refresh *record.RefreshTokenRecord) *response.RefreshTokenResponse
 	ToRefreshTokenResponses(refreshs []*record.RefreshTokenRecord) []*response.RefreshTokenResponse
+	ToSessionResponse(refresh *record.RefreshTokenRecord) *response.SessionResponse
+	ToSessionResponses(refreshs []*record.RefreshTokenRecord) []*response.SessionResponse
 }
 
 type CategoryResponseMapper interface {
@@ -45,6 +47,8 @@ type OrderResponseMapper interface {
 	ToOrdersResponse(orders []*record.OrderRecord) []*response.OrderResponse
 	ToOrderResponseDeleteAt(order *record.OrderRecord) *response.OrderResponseDeleteAt
 	ToOrdersResponseDeleteAt(orders []*record.OrderRecord) []*response.OrderResponseDeleteAt
+	ToOrderStatusHistoryResponse(history *record.OrderStatusHistoryRecord) *response.OrderStatusHistoryResponse
+	ToOrderStatusHistoriesResponse(histories []*record.OrderStatusHistoryRecord) []*response.OrderStatusHistoryResponse
 }
 
 type OrderItemResponseMapper interface {
@@ -59,6 +63,7 @@ type ProductResponseMapper interface {
 	ToProductsResponse(products []*record.ProductRecord) []*response.ProductResponse
 	ToProductResponseDeleteAt(product *record.ProductRecord) *response.ProductResponseDeleteAt
 	ToProductsResponseDeleteAt(products []*record.ProductRecord) []*response.ProductResponseDeleteAt
+	ToProductFacetsResponse(facets []*record.ProductFacetRecord, priceBounds []int64) *response.ProductFacetsResponse
 }
 
 type TransactionResponseMapper interface {
@@ -71,6 +76,7 @@ type TransactionResponseMapper interface {
 type CartResponseMapper interface {
 	ToCartResponse(cart *record.CartRecord) *response.CartResponse
 	ToCartsResponse(users []*record.CartRecord) []*response.CartResponse
+	ToCartSummaryResponse(summary *record.CartSummaryRecord) *response.CartSummaryResponse
 }
 
 type ReviewResponseMapper interface {
@@ -22,6 +22,7 @@ func (s *merchantResponseMapper) ToMerchantResponse(merchant *record.MerchantRec
 		ContactEmail: merchant.ContactEmail,
 		ContactPhone: merchant.ContactPhone,
 		Status:       merchant.Status,
+		Currency:     merchant.Currency,
 		CreatedAt:    merchant.CreatedAt,
 		UpdatedAt:    merchant.UpdatedAt,
 	}
@@ -47,6 +48,7 @@ func (s *merchantResponseMapper) ToMerchantResponseDeleteAt(merchant *record.Mer
 		ContactEmail: merchant.ContactEmail,
 		ContactPhone: merchant.ContactPhone,
 		Status:       merchant.Status,
+		Currency:     merchant.Currency,
 		CreatedAt:    merchant.CreatedAt,
 		UpdatedAt:    merchant.UpdatedAt,
 	}
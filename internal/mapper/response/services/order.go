@@ -1,6 +1,7 @@
 package response_service
 
 import (
+	"ecommerce/internal/domain/money"
 	"ecommerce/internal/domain/record"
 	"ecommerce/internal/domain/response"
 )
@@ -14,12 +15,17 @@ func NewOrderResponseMapper() *orderResponseMapper {
 
 func (s *orderResponseMapper) ToOrderResponse(order *record.OrderRecord) *response.OrderResponse {
 	return &response.OrderResponse{
-		ID:         order.ID,
-		MerchantID: order.MerchantID,
-		UserID:     order.UserID,
-		TotalPrice: order.TotalPrice,
-		CreatedAt:  order.CreatedAt,
-		UpdatedAt:  order.UpdatedAt,
+		ID:             order.ID,
+		MerchantID:     order.MerchantID,
+		UserID:         order.UserID,
+		TotalPrice:     money.New(order.TotalPrice, order.Currency),
+		Subtotal:       money.New(order.Subtotal, order.Currency),
+		ShippingCost:   money.New(order.ShippingCost, order.Currency),
+		TaxAmount:      money.New(order.TaxAmount, order.Currency),
+		DiscountAmount: money.New(order.DiscountAmount, order.Currency),
+		Status:         order.Status,
+		CreatedAt:      order.CreatedAt,
+		UpdatedAt:      order.UpdatedAt,
 	}
 }
 
@@ -35,13 +41,18 @@ func (s *orderResponseMapper) ToOrdersResponse(orders []*record.OrderRecord) []*
 
 func (s *orderResponseMapper) ToOrderResponseDeleteAt(order *record.OrderRecord) *response.OrderResponseDeleteAt {
 	return &response.OrderResponseDeleteAt{
-		ID:         order.ID,
-		MerchantID: order.MerchantID,
-		UserID:     order.UserID,
-		TotalPrice: order.TotalPrice,
-		CreatedAt:  order.CreatedAt,
-		UpdatedAt:  order.UpdatedAt,
-		DeleteAt:   *order.DeletedAt,
+		ID:             order.ID,
+		MerchantID:     order.MerchantID,
+		UserID:         order.UserID,
+		TotalPrice:     money.New(order.TotalPrice, order.Currency),
+		Subtotal:       money.New(order.Subtotal, order.Currency),
+		ShippingCost:   money.New(order.ShippingCost, order.Currency),
+		TaxAmount:      money.New(order.TaxAmount, order.Currency),
+		DiscountAmount: money.New(order.DiscountAmount, order.Currency),
+		Status:         order.Status,
+		CreatedAt:      order.CreatedAt,
+		UpdatedAt:      order.UpdatedAt,
+		DeleteAt:       *order.DeletedAt,
 	}
 }
 
@@ -54,3 +65,29 @@ func (s *orderResponseMapper) ToOrdersResponseDeleteAt(orders []*record.OrderRec
 
 	return responses
 }
+
+func (s *orderResponseMapper) ToOrderStatusHistoryResponse(history *record.OrderStatusHistoryRecord) *response.OrderStatusHistoryResponse {
+	var fromStatus string
+	if history.FromStatus != nil {
+		fromStatus = *history.FromStatus
+	}
+
+	return &response.OrderStatusHistoryResponse{
+		ID:         history.ID,
+		OrderID:    history.OrderID,
+		FromStatus: fromStatus,
+		ToStatus:   history.ToStatus,
+		Note:       history.Note,
+		CreatedAt:  history.CreatedAt,
+	}
+}
+
+func (s *orderResponseMapper) ToOrderStatusHistoriesResponse(histories []*record.OrderStatusHistoryRecord) []*response.OrderStatusHistoryResponse {
+	var responses []*response.OrderStatusHistoryResponse
+
+	for _, history := range histories {
+		responses = append(responses, s.ToOrderStatusHistoryResponse(history))
+	}
+
+	return responses
+}
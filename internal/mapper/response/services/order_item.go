@@ -1,6 +1,7 @@
 package response_service
 
 import (
+	"ecommerce/internal/domain/money"
 	"ecommerce/internal/domain/record"
 	"ecommerce/internal/domain/response"
 )
@@ -18,7 +19,7 @@ func (s *orderItemResponseMapper) ToOrderItemResponse(order *record.OrderItemRec
 		OrderID:   order.OrderID,
 		ProductID: order.ProductID,
 		Quantity:  order.Quantity,
-		Price:     order.Price,
+		Price:     money.New(order.Price, money.DefaultCurrency),
 		CreatedAt: order.CreatedAt,
 		UpdatedAt: order.UpdatedAt,
 	}
@@ -40,7 +41,7 @@ func (s *orderItemResponseMapper) ToOrderItemResponseDeleteAt(order *record.Orde
 		OrderID:   order.OrderID,
 		ProductID: order.ProductID,
 		Quantity:  order.Quantity,
-		Price:     order.Price,
+		Price:     money.New(order.Price, money.DefaultCurrency),
 		CreatedAt: order.CreatedAt,
 		UpdatedAt: order.UpdatedAt,
 		DeleteAt:  *order.DeletedAt,
@@ -1,8 +1,10 @@
 package response_service
 
 import (
+	"ecommerce/internal/domain/money"
 	"ecommerce/internal/domain/record"
 	"ecommerce/internal/domain/response"
+	"strconv"
 )
 
 type productResponseMapper struct {
@@ -19,7 +21,7 @@ func (s *productResponseMapper) ToProductResponse(product *record.ProductRecord)
 		CategoryID:   product.CategoryID,
 		Name:         product.Name,
 		Description:  product.Description,
-		Price:        product.Price,
+		Price:        money.New(product.Price, money.DefaultCurrency),
 		CountInStock: product.CountInStock,
 		Brand:        product.Brand,
 		Weight:       product.Weight,
@@ -48,7 +50,7 @@ func (s *productResponseMapper) ToProductResponseDeleteAt(product *record.Produc
 		CategoryID:   product.CategoryID,
 		Name:         product.Name,
 		Description:  product.Description,
-		Price:        product.Price,
+		Price:        money.New(product.Price, money.DefaultCurrency),
 		CountInStock: product.CountInStock,
 		Brand:        product.Brand,
 		Weight:       product.Weight,
@@ -70,3 +72,48 @@ func (s *productResponseMapper) ToProductsResponseDeleteAt(products []*record.Pr
 
 	return responses
 }
+
+// ToProductFacetsResponse groups facet records by facet. Price facets carry
+// the index of the bucket delimited by priceBounds, as returned by
+// width_bucket, and are expanded into their price range.
+func (s *productResponseMapper) ToProductFacetsResponse(facets []*record.ProductFacetRecord, priceBounds []int64) *response.ProductFacetsResponse {
+	result := &response.ProductFacetsResponse{}
+
+	for _, facet := range facets {
+		value := &response.ProductFacetValueResponse{
+			Value: facet.Value,
+			Label: facet.Label,
+			Count: facet.Count,
+		}
+
+		switch facet.Facet {
+		case "category":
+			result.Categories = append(result.Categories, value)
+		case "brand":
+			result.Brands = append(result.Brands, value)
+		case "merchant":
+			result.Merchants = append(result.Merchants, value)
+		case "price":
+			bucket, err := strconv.Atoi(facet.Value)
+			if err != nil || bucket < 0 || bucket > len(priceBounds) {
+				continue
+			}
+
+			priceBucket := &response.ProductPriceBucketResponse{
+				Min:   money.New(0, money.DefaultCurrency),
+				Count: facet.Count,
+			}
+			if bucket > 0 {
+				priceBucket.Min = money.New(priceBounds[bucket-1], money.DefaultCurrency)
+			}
+			if bucket < len(priceBounds) {
+				upper := money.New(priceBounds[bucket], money.DefaultCurrency)
+				priceBucket.Max = &upper
+			}
+
+			result.PriceBuckets = append(result.PriceBuckets, priceBucket)
+		}
+	}
+
+	return result
+}
@@ -31,3 +31,25 @@ func (r *refreshTokenResponseMapper) ToRefreshTokenResponses(refreshs []*record.
 
 	return responses
 }
+
+func (r *refreshTokenResponseMapper) ToSessionResponse(refresh *record.RefreshTokenRecord) *response.SessionResponse {
+	return &response.SessionResponse{
+		ID:           refresh.FamilyID,
+		Device:       refresh.Device,
+		UserAgent:    refresh.UserAgent,
+		IPAddress:    refresh.IPAddress,
+		SignedInAt:   refresh.SignedInAt,
+		LastActiveAt: refresh.CreatedAt,
+		ExpiresAt:    refresh.ExpiredAt,
+	}
+}
+
+func (r *refreshTokenResponseMapper) ToSessionResponses(refreshs []*record.RefreshTokenRecord) []*response.SessionResponse {
+	var responses []*response.SessionResponse
+
+	for _, response := range refreshs {
+		responses = append(responses, r.ToSessionResponse(response))
+	}
+
+	return responses
+}
@@ -1,6 +1,7 @@
 package response_service
 
 import (
+	"ecommerce/internal/domain/money"
 	"ecommerce/internal/domain/record"
 	"ecommerce/internal/domain/response"
 )
@@ -14,15 +15,18 @@ func NewTransactionResponseMapper() *transactionResponseMapper {
 
 func (s *transactionResponseMapper) ToTransactionResponse(transaction *record.TransactionRecord) *response.TransactionResponse {
 	return &response.TransactionResponse{
-		ID:            transaction.ID,
-		OrderID:       transaction.OrderID,
-		MerchantID:    transaction.MerchantID,
-		PaymentMethod: transaction.PaymentMethod,
-		Amount:        transaction.Amount,
-		ChangeAmount:  transaction.ChangeAmount,
-		PaymentStatus: transaction.PaymentStatus,
-		CreatedAt:     transaction.CreatedAt,
-		UpdatedAt:     transaction.UpdatedAt,
+		ID:             transaction.ID,
+		OrderID:        transaction.OrderID,
+		MerchantID:     transaction.MerchantID,
+		PaymentMethod:  transaction.PaymentMethod,
+		Amount:         money.New(transaction.Amount, money.DefaultCurrency),
+		AmountDue:      money.New(transaction.AmountDue, money.DefaultCurrency),
+		ChangeAmount:   money.New(transaction.ChangeAmount, money.DefaultCurrency),
+		PaymentStatus:  transaction.PaymentStatus,
+		RefundedAmount: money.New(transaction.RefundedAmount, money.DefaultCurrency),
+		NetAmount:      money.New(transaction.AmountDue-transaction.RefundedAmount, money.DefaultCurrency),
+		CreatedAt:      transaction.CreatedAt,
+		UpdatedAt:      transaction.UpdatedAt,
 	}
 }
 
@@ -42,8 +46,9 @@ func (s *transactionResponseMapper) ToTransactionResponseDeleteAt(transaction *r
 		OrderID:       transaction.OrderID,
 		MerchantID:    transaction.MerchantID,
 		PaymentMethod: transaction.PaymentMethod,
-		Amount:        transaction.Amount,
-		ChangeAmount:  transaction.ChangeAmount,
+		Amount:        money.New(transaction.Amount, money.DefaultCurrency),
+		AmountDue:     money.New(transaction.AmountDue, money.DefaultCurrency),
+		ChangeAmount:  money.New(transaction.ChangeAmount, money.DefaultCurrency),
 		PaymentStatus: transaction.PaymentStatus,
 		CreatedAt:     transaction.CreatedAt,
 		UpdatedAt:     transaction.UpdatedAt,
@@ -1,41 +1,107 @@
 package middlewares
 
 import (
+	"context"
+	"ecommerce/internal/domain/response"
+	"ecommerce/pkg/auth"
+	"net/http"
 	"strings"
 
-	"github.com/golang-jwt/jwt/v5"
 	echojwt "github.com/labstack/echo-jwt/v4"
 	"github.com/labstack/echo/v4"
-	"github.com/spf13/viper"
+	"google.golang.org/grpc/metadata"
 )
 
 var whiteListPaths = []string{
 	"/api/auth/login",
 	"/api/auth/register",
+	"/api/auth/refresh-token",
+	"/api/auth/logout",
+	"/api/auth/verify-email",
+	"/api/auth/forgot-password",
+	"/api/auth/reset-password",
 	"/api/auth/hello",
+	"/api/payments/webhook",
+	"/.well-known/jwks.json",
 	"/docs/",
 	"/docs",
 	"/swagger",
 }
 
-func WebSecurityConfig(e *echo.Echo) {
+// RevocationChecker reports whether a validly signed access token has since
+// been revoked.
+type RevocationChecker interface {
+	IsRevoked(ctx context.Context, claims *auth.Claims) bool
+}
+
+// WebSecurityConfig authenticates every non-whitelisted route with token,
+// the same verifier the gRPC server uses, so keys, issuer and audience are
+// checked identically on both sides.
+func WebSecurityConfig(e *echo.Echo, token auth.TokenManager, revocation RevocationChecker) {
 	config := echojwt.Config{
-		SigningKey: []byte(viper.GetString("SECRET_KEY")),
-		Skipper:    skipAuth,
+		Skipper: skipAuth,
+		ParseTokenFunc: func(c echo.Context, accessToken string) (interface{}, error) {
+			return token.ParseToken(accessToken)
+		},
 		SuccessHandler: func(c echo.Context) {
-			user := c.Get("user").(*jwt.Token)
-
-			if claims, ok := user.Claims.(jwt.MapClaims); ok {
-				subject := claims["sub"]
-				c.Set("userID", subject)
+			if claims, ok := c.Get("user").(*auth.Claims); ok {
+				c.Set("userID", claims.Subject)
+				c.Set("roles", claims.Roles)
 			}
 		},
 		ErrorHandler: func(c echo.Context, err error) error {
-			return echo.ErrUnauthorized
+			return c.JSON(http.StatusUnauthorized, response.ErrorResponse{
+				Status:  "error",
+				Message: "Unauthorized: missing or invalid token",
+				Code:    response.ErrCodeUnauthorized,
+			})
 		},
 	}
 	e.Use(echojwt.WithConfig(config))
+	e.Use(rejectRevoked(revocation))
+	e.Use(requirePermission)
+}
+
+// rejectRevoked answers 401 for access tokens that were revoked before they
+// expired. Whitelisted routes carry no token and pass through.
+func rejectRevoked(revocation RevocationChecker) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			claims, ok := c.Get("user").(*auth.Claims)
+			if ok && revocation.IsRevoked(c.Request().Context(), claims) {
+				return c.JSON(http.StatusUnauthorized, response.ErrorResponse{
+					Status:  "error",
+					Message: "Unauthorized: token has been revoked",
+					Code:    response.ErrCodeUnauthorized,
+				})
+			}
 
+			return next(c)
+		}
+	}
+}
+
+// requirePermission rejects the request with 403 when the route needs a
+// permission that none of the caller's roles grant, or is not listed in
+// routePermissions at all. Whitelisted routes pass through.
+func requirePermission(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if skipAuth(c) {
+			return next(c)
+		}
+
+		roles, _ := c.Get("roles").([]string)
+
+		if permission, ok := routePermission(c.Request().Method, c.Path()); !ok || !HasPermission(roles, permission) {
+			return c.JSON(http.StatusForbidden, response.ErrorResponse{
+				Status:  "error",
+				Message: "Forbidden: insufficient permissions",
+				Code:    response.ErrCodeForbidden,
+			})
+		}
+
+		return next(c)
+	}
 }
 
 func skipAuth(e echo.Context) bool {
@@ -49,3 +115,18 @@ func skipAuth(e echo.Context) bool {
 
 	return false
 }
+
+// ForwardAuthorization copies the request's Authorization header into the
+// outgoing gRPC metadata so the gRPC server can authenticate the caller.
+func ForwardAuthorization(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		authHeader := c.Request().Header.Get(echo.HeaderAuthorization)
+
+		if authHeader != "" {
+			ctx := metadata.AppendToOutgoingContext(c.Request().Context(), "authorization", authHeader)
+			c.SetRequest(c.Request().WithContext(ctx))
+		}
+
+		return next(c)
+	}
+}
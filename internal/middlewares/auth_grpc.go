@@ -0,0 +1,168 @@
+package middlewares
+
+import (
+	"context"
+	"ecommerce/internal/pb"
+	"ecommerce/pkg/auth"
+	"ecommerce/pkg/logger"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// publicMethods can be called without a bearer token.
+var publicMethods = map[string]bool{
+	"/pb.AuthService/RegisterUser":         true,
+	"/pb.AuthService/LoginUser":            true,
+	"/pb.AuthService/RefreshToken":         true,
+	"/pb.AuthService/Logout":               true,
+	"/pb.AuthService/VerifyEmail":          true,
+	"/pb.AuthService/RequestPasswordReset": true,
+	"/pb.AuthService/ResetPassword":        true,
+
+	"/pb.TransactionService/HandlePaymentEvent": true,
+}
+
+// publicServices are infrastructure services that are always reachable.
+var publicServices = []string{
+	"/grpc.health.v1.Health/",
+	"/grpc.reflection.v1.ServerReflection/",
+	"/grpc.reflection.v1alpha.ServerReflection/",
+}
+
+type AuthInterceptor struct {
+	token      auth.TokenManager
+	revocation RevocationChecker
+	logger     logger.LoggerInterface
+}
+
+func NewAuthInterceptor(token auth.TokenManager, revocation RevocationChecker, logger logger.LoggerInterface) *AuthInterceptor {
+	return &AuthInterceptor{
+		token:      token,
+		revocation: revocation,
+		logger:     logger,
+	}
+}
+
+func (i *AuthInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := i.authorize(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func (i *AuthInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := i.authorize(stream.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &authServerStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+func (i *AuthInterceptor) authorize(ctx context.Context, method string) (context.Context, error) {
+	if isPublicMethod(method) {
+		return ctx, nil
+	}
+
+	accessToken, err := BearerToken(ctx)
+	if err != nil {
+		i.logger.Debug("Missing bearer token", zap.String("method", method))
+		return nil, status.Errorf(codes.Unauthenticated, "%v", &pb.ErrorResponse{
+			Status:  "error",
+			Message: "Unauthorized: missing or invalid authorization metadata",
+		})
+	}
+
+	claims, err := i.token.ParseToken(accessToken)
+	if err != nil {
+		i.logger.Debug("Invalid bearer token", zap.String("method", method), zap.Error(err))
+		return nil, status.Errorf(codes.Unauthenticated, "%v", &pb.ErrorResponse{
+			Status:  "error",
+			Message: "Unauthorized: invalid or expired token",
+		})
+	}
+
+	userID, err := strconv.Atoi(claims.Subject)
+	if err != nil {
+		i.logger.Debug("Invalid token subject", zap.String("subject", claims.Subject), zap.Error(err))
+		return nil, status.Errorf(codes.Unauthenticated, "%v", &pb.ErrorResponse{
+			Status:  "error",
+			Message: "Unauthorized: invalid or expired token",
+		})
+	}
+
+	if i.revocation.IsRevoked(ctx, claims) {
+		i.logger.Debug("Revoked bearer token", zap.Int("userID", userID), zap.String("method", method))
+		return nil, status.Errorf(codes.Unauthenticated, "%v", &pb.ErrorResponse{
+			Status:  "error",
+			Message: "Unauthorized: token has been revoked",
+		})
+	}
+
+	if permission, ok := rpcPermission(method); !ok || !HasPermission(claims.Roles, permission) {
+		i.logger.Debug("Permission denied", zap.Int("userID", userID), zap.String("method", method))
+		return nil, status.Errorf(codes.PermissionDenied, "%v", &pb.ErrorResponse{
+			Status:  "error",
+			Message: "Forbidden: insufficient permissions",
+		})
+	}
+
+	ctx = auth.WithUserID(ctx, userID)
+
+	return auth.WithRoles(ctx, claims.Roles), nil
+}
+
+func isPublicMethod(method string) bool {
+	if publicMethods[method] {
+		return true
+	}
+
+	for _, prefix := range publicServices {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// BearerToken returns the access token sent in the authorization metadata.
+func BearerToken(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", status.Error(codes.Unauthenticated, "missing metadata")
+	}
+
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return "", status.Error(codes.Unauthenticated, "missing authorization metadata")
+	}
+
+	token, found := strings.CutPrefix(values[0], "Bearer ")
+	if !found || token == "" {
+		return "", status.Error(codes.Unauthenticated, "invalid authorization metadata")
+	}
+
+	return token, nil
+}
+
+type authServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authServerStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,176 @@
+package middlewares
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const (
+	clientUserAgentMetadata = "client-user-agent"
+	clientIPMetadata        = "client-ip"
+	clientSignatureMetadata = "client-signature"
+)
+
+type clientInfoKey struct{}
+
+type clientInfo struct {
+	userAgent string
+	ip        string
+}
+
+// ForwardClientInfo copies the REST caller's user agent and IP address into
+// the outgoing gRPC metadata, since the gRPC server otherwise only sees the
+// gateway as its peer. The values are signed with the secret shared with the
+// gRPC server so callers cannot supply their own.
+func ForwardClientInfo(secret string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			userAgent, ip := c.Request().UserAgent(), c.RealIP()
+
+			ctx := metadata.AppendToOutgoingContext(c.Request().Context(),
+				clientUserAgentMetadata, userAgent,
+				clientIPMetadata, ip,
+				clientSignatureMetadata, signClientInfo(secret, userAgent, ip),
+			)
+			c.SetRequest(c.Request().WithContext(ctx))
+
+			return next(c)
+		}
+	}
+}
+
+// ClientInfoInterceptor accepts the client info forwarded by the REST
+// gateway only when it carries a valid signature. Calls from anyone else are
+// attributed to their own connection.
+type ClientInfoInterceptor struct {
+	secret string
+}
+
+func NewClientInfoInterceptor(secret string) *ClientInfoInterceptor {
+	return &ClientInfoInterceptor{secret: secret}
+}
+
+func (i *ClientInfoInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if forwarded, ok := i.verify(ctx); ok {
+			ctx = context.WithValue(ctx, clientInfoKey{}, forwarded)
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func (i *ClientInfoInterceptor) verify(ctx context.Context) (clientInfo, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || i.secret == "" {
+		return clientInfo{}, false
+	}
+
+	forwarded := clientInfo{
+		userAgent: firstMetadata(md, clientUserAgentMetadata),
+		ip:        firstMetadata(md, clientIPMetadata),
+	}
+
+	signature, err := hex.DecodeString(firstMetadata(md, clientSignatureMetadata))
+	if err != nil || forwarded.ip == "" {
+		return clientInfo{}, false
+	}
+
+	expected, _ := hex.DecodeString(signClientInfo(i.secret, forwarded.userAgent, forwarded.ip))
+	if !hmac.Equal(signature, expected) {
+		return clientInfo{}, false
+	}
+
+	return forwarded, true
+}
+
+// ClientFromContext returns the user agent and IP address of the client
+// behind an incoming gRPC call. Client info verified by
+// ClientInfoInterceptor wins over the direct caller.
+func ClientFromContext(ctx context.Context) (userAgent string, ip string) {
+	if forwarded, ok := ctx.Value(clientInfoKey{}).(clientInfo); ok {
+		return forwarded.userAgent, forwarded.ip
+	}
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		userAgent = firstMetadata(md, "user-agent")
+	}
+
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		ip = p.Addr.String()
+		if host, _, err := net.SplitHostPort(ip); err == nil {
+			ip = host
+		}
+	}
+
+	return userAgent, ip
+}
+
+// TrustedProxyIPExtractor resolves the REST caller's IP address from
+// X-Forwarded-For only when the request came through one of the proxies in
+// cidrs, a comma separated list of CIDR ranges or addresses. Without proxies
+// the address of the TCP peer is used.
+func TrustedProxyIPExtractor(cidrs string) (echo.IPExtractor, error) {
+	options := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+	trusted := 0
+
+	for _, entry := range strings.Split(cidrs, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		if !strings.Contains(entry, "/") {
+			if ip := net.ParseIP(entry); ip != nil && ip.To4() != nil {
+				entry += "/32"
+			} else {
+				entry += "/128"
+			}
+		}
+
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", entry, err)
+		}
+
+		options = append(options, echo.TrustIPRange(network))
+		trusted++
+	}
+
+	if trusted == 0 {
+		return echo.ExtractIPDirect(), nil
+	}
+
+	return echo.ExtractIPFromXFFHeader(options...), nil
+}
+
+func signClientInfo(secret string, userAgent string, ip string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(userAgent + "\n" + ip))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func firstMetadata(md metadata.MD, keys ...string) string {
+	for _, key := range keys {
+		if values := md.Get(key); len(values) > 0 && values[0] != "" {
+			return values[0]
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,75 @@
+package middlewares
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// DeadlineInterceptor bounds every unary call with a deadline so slow
+// queries are aborted once the caller has given up. A deadline already set
+// by the client is kept when it is shorter than the configured one.
+type DeadlineInterceptor struct {
+	defaultTimeout time.Duration
+	methodTimeouts map[string]time.Duration
+}
+
+func NewDeadlineInterceptor(defaultTimeout time.Duration, methodTimeouts map[string]time.Duration) *DeadlineInterceptor {
+	return &DeadlineInterceptor{
+		defaultTimeout: defaultTimeout,
+		methodTimeouts: methodTimeouts,
+	}
+}
+
+func (i *DeadlineInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		timeout := i.timeout(info.FullMethod)
+		if timeout <= 0 {
+			return handler(ctx, req)
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		return handler(ctx, req)
+	}
+}
+
+func (i *DeadlineInterceptor) timeout(method string) time.Duration {
+	if timeout, ok := i.methodTimeouts[method]; ok {
+		return timeout
+	}
+
+	return i.defaultTimeout
+}
+
+// ParseMethodTimeouts reads per-RPC timeouts written as a comma separated
+// list of full method names and durations, for example
+// "/pb.CartService/Checkout=30s,/pb.ProductService/FindAll=5s".
+func ParseMethodTimeouts(value string) (map[string]time.Duration, error) {
+	timeouts := make(map[string]time.Duration)
+
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		method, duration, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid method timeout %q: expected method=duration", entry)
+		}
+
+		timeout, err := time.ParseDuration(strings.TrimSpace(duration))
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout for %s: %w", method, err)
+		}
+
+		timeouts[strings.TrimSpace(method)] = timeout
+	}
+
+	return timeouts, nil
+}
@@ -0,0 +1,29 @@
+package middlewares
+
+import (
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc/metadata"
+)
+
+// IdempotencyKeyHeader lets clients retry a create request without it
+// being executed twice.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// idempotencyKeyMetadata carries the Idempotency-Key header to the gRPC
+// server.
+const idempotencyKeyMetadata = "idempotency-key"
+
+// ForwardIdempotencyKey copies the request's Idempotency-Key header into the
+// outgoing gRPC metadata.
+func ForwardIdempotencyKey(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		key := c.Request().Header.Get(IdempotencyKeyHeader)
+
+		if key != "" {
+			ctx := metadata.AppendToOutgoingContext(c.Request().Context(), idempotencyKeyMetadata, key)
+			c.SetRequest(c.Request().WithContext(ctx))
+		}
+
+		return next(c)
+	}
+}
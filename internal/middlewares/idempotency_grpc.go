@@ -0,0 +1,265 @@
+package middlewares
+
+import (
+	"context"
+	"crypto/sha256"
+	"ecommerce/internal/domain/record"
+	"ecommerce/internal/domain/requests"
+	"ecommerce/internal/pb"
+	"ecommerce/internal/repository"
+	"ecommerce/pkg/auth"
+	"ecommerce/pkg/logger"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// releasableCodes are the errors handlers return while checking the request
+// and the caller, before they change anything. A call failing with any other
+// code may already have had side effects, such as a provider charge, so its
+// error is stored and replayed instead of letting a retry run again.
+var releasableCodes = map[codes.Code]bool{
+	codes.InvalidArgument:  true,
+	codes.Unauthenticated:  true,
+	codes.PermissionDenied: true,
+}
+
+// idempotentMethods are the RPCs that honour an idempotency key, with the
+// response type a stored result is replayed as.
+var idempotentMethods = map[string]func() proto.Message{
+	"/pb.OrderService/Create":       func() proto.Message { return &pb.ApiResponseOrder{} },
+	"/pb.TransactionService/Create": func() proto.Message { return &pb.ApiResponseTransaction{} },
+}
+
+const (
+	maxIdempotencyKeyLength = 255
+	// idempotencyLockTimeout is how long a request may hold a key before a
+	// retry is allowed to take it over. It must outlast the call deadline.
+	idempotencyLockTimeout = time.Minute
+	// IdempotentReplayedHeader is set on responses replayed from a stored
+	// result.
+	IdempotentReplayedHeader = "idempotent-replayed"
+)
+
+// IdempotencyInterceptor makes retried create calls that carry the same
+// idempotency key return the first result instead of running again. Keys are
+// scoped to the caller and the method, and are forgotten after ttl.
+type IdempotencyInterceptor struct {
+	repository repository.IdempotencyKeyRepository
+	logger     logger.LoggerInterface
+	ttl        time.Duration
+}
+
+func NewIdempotencyInterceptor(repository repository.IdempotencyKeyRepository, logger logger.LoggerInterface, ttl time.Duration) *IdempotencyInterceptor {
+	return &IdempotencyInterceptor{
+		repository: repository,
+		logger:     logger,
+		ttl:        ttl,
+	}
+}
+
+// Unary must run after the auth interceptor so the caller is known.
+func (i *IdempotencyInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		newResponse, ok := idempotentMethods[info.FullMethod]
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		key := idempotencyKey(ctx)
+		if key == "" {
+			return handler(ctx, req)
+		}
+
+		if len(key) > maxIdempotencyKeyLength {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", &pb.ErrorResponse{
+				Status:  "error",
+				Message: "Idempotency key must be at most 255 characters",
+			})
+		}
+
+		userID, ok := auth.UserIDFromContext(ctx)
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		message, ok := req.(proto.Message)
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		hash, err := requestHash(info.FullMethod, message)
+		if err != nil {
+			i.logger.Error("Failed to hash request", zap.String("method", info.FullMethod), zap.Error(err))
+			return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
+				Status:  "error",
+				Message: "Failed to process idempotency key",
+			})
+		}
+
+		// locked_at and created_at are TIMESTAMP WITHOUT TIME ZONE columns
+		// compared against these cutoffs, so every one of them is written
+		// from the same UTC clock rather than the database session's zone.
+		now := time.Now().UTC()
+
+		claimed, err := i.repository.Claim(ctx, &requests.ClaimIdempotencyKeyRequest{
+			UserID:        userID,
+			Method:        info.FullMethod,
+			Key:           key,
+			RequestHash:   hash,
+			LockedAt:      now,
+			StaleBefore:   now.Add(-idempotencyLockTimeout),
+			ExpiredBefore: now.Add(-i.ttl),
+		})
+		if errors.Is(err, repository.ErrIdempotencyKeyClaimed) {
+			return i.replay(ctx, userID, info.FullMethod, key, hash, newResponse)
+		}
+		if err != nil {
+			i.logger.Error("Failed to claim idempotency key", zap.String("method", info.FullMethod), zap.Error(err))
+			return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
+				Status:  "error",
+				Message: "Failed to process idempotency key",
+			})
+		}
+
+		// The claim is settled even when the call itself was cancelled, so
+		// the key is not left locked until the takeover timeout.
+		settleCtx := context.WithoutCancel(ctx)
+
+		resp, err := handler(ctx, req)
+		if err != nil {
+			i.settleFailure(settleCtx, claimed.ID, err)
+
+			return nil, err
+		}
+
+		payload, err := proto.Marshal(resp.(proto.Message))
+		if err == nil {
+			err = i.repository.Complete(settleCtx, claimed.ID, payload)
+		}
+		if err != nil {
+			i.logger.Error("Failed to store idempotent response", zap.Int("id", claimed.ID), zap.Error(err))
+		}
+
+		return resp, nil
+	}
+}
+
+// settleFailure releases the key of a call rejected before it changed
+// anything, and stores the error of any other failed call for replay.
+func (i *IdempotencyInterceptor) settleFailure(ctx context.Context, idempotency_key_id int, err error) {
+	st := status.Convert(err)
+
+	if releasableCodes[st.Code()] {
+		if releaseErr := i.repository.Release(ctx, idempotency_key_id); releaseErr != nil {
+			i.logger.Error("Failed to release idempotency key", zap.Int("id", idempotency_key_id), zap.Error(releaseErr))
+		}
+
+		return
+	}
+
+	payload, marshalErr := proto.Marshal(st.Proto())
+	if marshalErr == nil {
+		marshalErr = i.repository.Fail(ctx, idempotency_key_id, payload)
+	}
+	if marshalErr != nil {
+		i.logger.Error("Failed to store idempotent error", zap.Int("id", idempotency_key_id), zap.Error(marshalErr))
+	}
+}
+
+// replay answers a retried call with the stored result of the call that
+// claimed the key first.
+func (i *IdempotencyInterceptor) replay(ctx context.Context, user_id int, method string, key string, hash string, newResponse func() proto.Message) (interface{}, error) {
+	stored, err := i.repository.FindByKey(ctx, user_id, method, key)
+	if err != nil || stored.CompletedAt == nil {
+		i.logger.Debug("Idempotency key still in progress", zap.String("method", method), zap.Int("userID", user_id))
+		return nil, status.Errorf(codes.AlreadyExists, "%v", &pb.ErrorResponse{
+			Status:  "error",
+			Message: "A request with this idempotency key is still being processed",
+		})
+	}
+
+	if stored.RequestHash != hash {
+		i.logger.Debug("Idempotency key reused with a different request", zap.String("method", method), zap.Int("userID", user_id))
+		return nil, status.Errorf(codes.AlreadyExists, "%v", &pb.ErrorResponse{
+			Status:  "error",
+			Message: "Idempotency key was already used for a different request",
+		})
+	}
+
+	if stored.Failed {
+		return nil, i.replayFailure(ctx, stored)
+	}
+
+	resp := newResponse()
+	if err := proto.Unmarshal(stored.Response, resp); err != nil {
+		i.logger.Error("Failed to decode stored idempotent response", zap.Int("id", stored.ID), zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
+			Status:  "error",
+			Message: "Failed to process idempotency key",
+		})
+	}
+
+	if err := grpc.SetHeader(ctx, metadata.Pairs(IdempotentReplayedHeader, "true")); err != nil {
+		i.logger.Debug("Failed to set replay header", zap.Error(err))
+	}
+
+	return resp, nil
+}
+
+// replayFailure answers a retried call with the error the first call failed
+// with.
+func (i *IdempotencyInterceptor) replayFailure(ctx context.Context, stored *record.IdempotencyKeyRecord) error {
+	var st spb.Status
+	if err := proto.Unmarshal(stored.Response, &st); err != nil {
+		i.logger.Error("Failed to decode stored idempotent error", zap.Int("id", stored.ID), zap.Error(err))
+		return status.Errorf(codes.Internal, "%v", &pb.ErrorResponse{
+			Status:  "error",
+			Message: "Failed to process idempotency key",
+		})
+	}
+
+	if err := grpc.SetHeader(ctx, metadata.Pairs(IdempotentReplayedHeader, "true")); err != nil {
+		i.logger.Debug("Failed to set replay header", zap.Error(err))
+	}
+
+	return status.ErrorProto(&st)
+}
+
+func idempotencyKey(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	values := md.Get(idempotencyKeyMetadata)
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+// requestHash fingerprints a request so a key cannot be replayed for a
+// different payload.
+func requestHash(method string, req proto.Message) (string, error) {
+	payload, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.New()
+	sum.Write([]byte(method))
+	sum.Write([]byte{0})
+	sum.Write(payload)
+
+	return hex.EncodeToString(sum.Sum(nil)), nil
+}
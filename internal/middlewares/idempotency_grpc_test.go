@@ -0,0 +1,175 @@
+package middlewares
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ecommerce/internal/domain/record"
+	"ecommerce/internal/domain/requests"
+	"ecommerce/internal/pb"
+	"ecommerce/internal/repository"
+	"ecommerce/pkg/auth"
+	"ecommerce/pkg/logger"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// fakeIdempotencyKeys keeps claimed keys in memory. Keys are never stale or
+// expired, so a second claim only succeeds after a release.
+type fakeIdempotencyKeys struct {
+	keys   map[string]*record.IdempotencyKeyRecord
+	nextID int
+}
+
+func newFakeIdempotencyKeys() *fakeIdempotencyKeys {
+	return &fakeIdempotencyKeys{keys: map[string]*record.IdempotencyKeyRecord{}}
+}
+
+func (f *fakeIdempotencyKeys) Claim(_ context.Context, request *requests.ClaimIdempotencyKeyRequest) (*record.IdempotencyKeyRecord, error) {
+	if _, ok := f.keys[request.Key]; ok {
+		return nil, repository.ErrIdempotencyKeyClaimed
+	}
+
+	f.nextID++
+	key := &record.IdempotencyKeyRecord{
+		ID:          f.nextID,
+		UserID:      request.UserID,
+		Method:      request.Method,
+		Key:         request.Key,
+		RequestHash: request.RequestHash,
+	}
+	f.keys[request.Key] = key
+
+	return key, nil
+}
+
+func (f *fakeIdempotencyKeys) FindByKey(_ context.Context, _ int, _ string, key string) (*record.IdempotencyKeyRecord, error) {
+	stored, ok := f.keys[key]
+	if !ok {
+		return nil, repository.ErrIdempotencyKeyClaimed
+	}
+
+	return stored, nil
+}
+
+func (f *fakeIdempotencyKeys) settle(id int, response []byte, failed bool) {
+	completedAt := time.Now().String()
+
+	for _, key := range f.keys {
+		if key.ID == id {
+			key.Response = response
+			key.Failed = failed
+			key.CompletedAt = &completedAt
+		}
+	}
+}
+
+func (f *fakeIdempotencyKeys) Complete(_ context.Context, id int, response []byte) error {
+	f.settle(id, response, false)
+	return nil
+}
+
+func (f *fakeIdempotencyKeys) Fail(_ context.Context, id int, response []byte) error {
+	f.settle(id, response, true)
+	return nil
+}
+
+func (f *fakeIdempotencyKeys) Release(_ context.Context, id int) error {
+	for name, key := range f.keys {
+		if key.ID == id && key.CompletedAt == nil {
+			delete(f.keys, name)
+		}
+	}
+
+	return nil
+}
+
+func TestIdempotencyInterceptorRetries(t *testing.T) {
+	tests := []struct {
+		name      string
+		firstErr  error
+		wantCalls int
+		wantCode  codes.Code
+	}{
+		{name: "success is replayed", wantCalls: 1, wantCode: codes.OK},
+		{name: "invalid argument runs again", firstErr: status.Error(codes.InvalidArgument, "bad request"), wantCalls: 2, wantCode: codes.OK},
+		{name: "permission denied runs again", firstErr: status.Error(codes.PermissionDenied, "forbidden"), wantCalls: 2, wantCode: codes.OK},
+		{name: "internal error is replayed", firstErr: status.Error(codes.Internal, "failed to record payment outcome"), wantCalls: 1, wantCode: codes.Internal},
+		{name: "not found is replayed", firstErr: status.Error(codes.NotFound, "order not found"), wantCalls: 1, wantCode: codes.NotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interceptor := NewIdempotencyInterceptor(newFakeIdempotencyKeys(), &logger.Logger{Log: zap.NewNop()}, time.Hour)
+
+			calls := 0
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				calls++
+				if calls == 1 && tt.firstErr != nil {
+					return nil, tt.firstErr
+				}
+
+				return &pb.ApiResponseTransaction{Status: "success"}, nil
+			}
+
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(idempotencyKeyMetadata, "key-1"))
+			ctx = auth.WithUserID(ctx, 1)
+			info := &grpc.UnaryServerInfo{FullMethod: "/pb.TransactionService/Create"}
+			req := &pb.CreateTransactionRequest{OrderId: 1, MerchantId: 1, Amount: 1000}
+
+			first, firstErr := interceptor.Unary()(ctx, req, info, handler)
+			if status.Code(firstErr) != status.Code(tt.firstErr) {
+				t.Fatalf("first call error = %v, want %v", firstErr, tt.firstErr)
+			}
+
+			retry, retryErr := interceptor.Unary()(ctx, req, info, handler)
+
+			if calls != tt.wantCalls {
+				t.Errorf("handler ran %d times, want %d", calls, tt.wantCalls)
+			}
+
+			if code := status.Code(retryErr); code != tt.wantCode {
+				t.Fatalf("retry code = %v, want %v", code, tt.wantCode)
+			}
+
+			if tt.wantCode == codes.Internal || tt.wantCode == codes.NotFound {
+				if got, want := status.Convert(retryErr).Message(), status.Convert(tt.firstErr).Message(); got != want {
+					t.Errorf("retry message = %q, want %q", got, want)
+				}
+			}
+
+			if tt.wantCode == codes.OK && tt.firstErr == nil {
+				got, want := retry.(*pb.ApiResponseTransaction).GetStatus(), first.(*pb.ApiResponseTransaction).GetStatus()
+				if got != want {
+					t.Errorf("replayed status = %q, want %q", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestIdempotencyInterceptorRejectsReusedKey(t *testing.T) {
+	interceptor := NewIdempotencyInterceptor(newFakeIdempotencyKeys(), &logger.Logger{Log: zap.NewNop()}, time.Hour)
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &pb.ApiResponseTransaction{Status: "success"}, nil
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(idempotencyKeyMetadata, "key-1"))
+	ctx = auth.WithUserID(ctx, 1)
+	info := &grpc.UnaryServerInfo{FullMethod: "/pb.TransactionService/Create"}
+
+	if _, err := interceptor.Unary()(ctx, &pb.CreateTransactionRequest{OrderId: 1, Amount: 1000}, info, handler); err != nil {
+		t.Fatalf("first call: %v", err)
+	}
+
+	_, err := interceptor.Unary()(ctx, &pb.CreateTransactionRequest{OrderId: 1, Amount: 2000}, info, handler)
+	if code := status.Code(err); code != codes.AlreadyExists {
+		t.Errorf("code = %v, want %v", code, codes.AlreadyExists)
+	}
+}
@@ -0,0 +1,371 @@
+package middlewares
+
+// Permission is a coarse access level granted through the user's roles.
+type Permission string
+
+const (
+	PermissionCustomer Permission = "customer"
+	PermissionMerchant Permission = "merchant"
+	PermissionAdmin    Permission = "admin"
+)
+
+const (
+	RoleAdmin    = "Admin"
+	RoleManager  = "Manager"
+	RoleCashier  = "Cashier"
+	RoleSupplier = "Supplier"
+	RoleCustomer = "Customer"
+)
+
+// rolePermissions maps role names from the roles table to the permissions
+// they grant. Every authenticated user is a customer.
+var rolePermissions = map[string][]Permission{
+	RoleAdmin:    {PermissionAdmin, PermissionMerchant},
+	RoleManager:  {PermissionMerchant},
+	RoleCashier:  {PermissionMerchant},
+	RoleSupplier: {PermissionMerchant},
+	RoleCustomer: {PermissionCustomer},
+}
+
+// routePermissions lists the permission required by a REST route, keyed by
+// HTTP method and the route pattern as registered on Echo. Every route outside
+// whiteListPaths must be listed; unlisted routes are refused.
+var routePermissions = map[string]Permission{
+	"GET /api/auth/me":              PermissionCustomer,
+	"POST /api/auth/logout-all":     PermissionCustomer,
+	"GET /api/auth/sessions":        PermissionCustomer,
+	"DELETE /api/auth/sessions/:id": PermissionCustomer,
+	"POST /api/auth/unlock":         PermissionAdmin,
+
+	"GET /api/cart":             PermissionCustomer,
+	"GET /api/cart/summary":     PermissionCustomer,
+	"POST /api/cart/update/:id": PermissionCustomer,
+	"DELETE /api/cart/:id":      PermissionCustomer,
+	"POST /api/cart/delete-all": PermissionCustomer,
+	"POST /api/cart/checkout":   PermissionCustomer,
+
+	"GET /api/category":        PermissionCustomer,
+	"GET /api/category/:id":    PermissionCustomer,
+	"GET /api/category/active": PermissionCustomer,
+
+	"GET /api/category/trashed":          PermissionAdmin,
+	"POST /api/category/create":          PermissionAdmin,
+	"POST /api/category/update/:id":      PermissionAdmin,
+	"POST /api/category/trashed/:id":     PermissionAdmin,
+	"POST /api/category/restore/:id":     PermissionAdmin,
+	"DELETE /api/category/permanent/:id": PermissionAdmin,
+	"POST /api/category/restore/all":     PermissionAdmin,
+	"POST /api/category/permanent/all":   PermissionAdmin,
+
+	"GET /api/merchant":                  PermissionCustomer,
+	"GET /api/merchant/me":               PermissionCustomer,
+	"GET /api/merchant/:id":              PermissionCustomer,
+	"GET /api/merchant/active":           PermissionCustomer,
+	"GET /api/merchant/trashed":          PermissionAdmin,
+	"POST /api/merchant/create":          PermissionMerchant,
+	"POST /api/merchant/update/:id":      PermissionMerchant,
+	"POST /api/merchant/trashed/:id":     PermissionAdmin,
+	"POST /api/merchant/restore/:id":     PermissionAdmin,
+	"DELETE /api/merchant/permanent/:id": PermissionAdmin,
+	"POST /api/merchant/restore/all":     PermissionAdmin,
+	"POST /api/merchant/permanent/all":   PermissionAdmin,
+
+	"GET /api/order":                  PermissionAdmin,
+	"GET /api/order/active":           PermissionAdmin,
+	"GET /api/order/trashed":          PermissionAdmin,
+	"GET /api/order/:id":              PermissionCustomer,
+	"POST /api/order/create":          PermissionCustomer,
+	"POST /api/order/update/:id":      PermissionMerchant,
+	"GET /api/order/history/:id":      PermissionCustomer,
+	"POST /api/order/cancel/:id":      PermissionCustomer,
+	"POST /api/order/processing/:id":  PermissionMerchant,
+	"POST /api/order/shipped/:id":     PermissionMerchant,
+	"POST /api/order/delivered/:id":   PermissionMerchant,
+	"POST /api/order/trashed/:id":     PermissionAdmin,
+	"POST /api/order/restore/:id":     PermissionAdmin,
+	"DELETE /api/order/permanent/:id": PermissionAdmin,
+	"POST /api/order/restore/all":     PermissionAdmin,
+	"POST /api/order/permanent/all":   PermissionAdmin,
+
+	"GET /api/order-item":           PermissionAdmin,
+	"GET /api/order-item/:order_id": PermissionCustomer,
+	"GET /api/order-item/active":    PermissionAdmin,
+	"GET /api/order-item/trashed":   PermissionAdmin,
+
+	"GET /api/product":                         PermissionCustomer,
+	"GET /api/product/me":                      PermissionCustomer,
+	"GET /api/product/search":                  PermissionCustomer,
+	"GET /api/product/:id":                     PermissionCustomer,
+	"GET /api/product/merchant/:merchant_id":   PermissionCustomer,
+	"GET /api/product/category/:category_name": PermissionCustomer,
+	"GET /api/product/active":                  PermissionCustomer,
+	"GET /api/product/trashed":                 PermissionAdmin,
+	"POST /api/product/create":                 PermissionMerchant,
+	"POST /api/product/update/:id":             PermissionMerchant,
+	"POST /api/product/trashed/:id":            PermissionAdmin,
+	"POST /api/product/restore/:id":            PermissionAdmin,
+	"DELETE /api/product/permanent/:id":        PermissionAdmin,
+	"POST /api/product/restore/all":            PermissionAdmin,
+	"POST /api/product/permanent/all":          PermissionAdmin,
+
+	"GET /api/review":                  PermissionCustomer,
+	"GET /api/review/product/:id":      PermissionCustomer,
+	"GET /api/review/active":           PermissionCustomer,
+	"GET /api/review/trashed":          PermissionAdmin,
+	"POST /api/review/create":          PermissionCustomer,
+	"POST /api/review/update/:id":      PermissionCustomer,
+	"POST /api/review/trashed/:id":     PermissionAdmin,
+	"POST /api/review/restore/:id":     PermissionAdmin,
+	"DELETE /api/review/permanent/:id": PermissionAdmin,
+	"POST /api/review/restore/all":     PermissionAdmin,
+	"POST /api/review/permanent/all":   PermissionAdmin,
+
+	"GET /api/role":                  PermissionAdmin,
+	"GET /api/role/:id":              PermissionAdmin,
+	"GET /api/role/active":           PermissionAdmin,
+	"GET /api/role/trashed":          PermissionAdmin,
+	"GET /api/role/user/:user_id":    PermissionAdmin,
+	"POST /api/role":                 PermissionAdmin,
+	"POST /api/role/:id":             PermissionAdmin,
+	"DELETE /api/role/:id":           PermissionAdmin,
+	"PUT /api/role/restore/:id":      PermissionAdmin,
+	"DELETE /api/role/permanent/:id": PermissionAdmin,
+	"PUT /api/role/restore-all":      PermissionAdmin,
+	"DELETE /api/role/permanent-all": PermissionAdmin,
+
+	"GET /api/shipping-address":                  PermissionAdmin,
+	"GET /api/shipping-address/:id":              PermissionCustomer,
+	"GET /api/shipping-address/order/:id":        PermissionCustomer,
+	"GET /api/shipping-address/active":           PermissionAdmin,
+	"GET /api/shipping-address/trashed":          PermissionAdmin,
+	"POST /api/shipping-address/trashed/:id":     PermissionAdmin,
+	"POST /api/shipping-address/restore/:id":     PermissionAdmin,
+	"DELETE /api/shipping-address/permanent/:id": PermissionAdmin,
+	"POST /api/shipping-address/restore/all":     PermissionAdmin,
+	"POST /api/shipping-address/permanent/all":   PermissionAdmin,
+
+	"GET /api/slider":                  PermissionCustomer,
+	"GET /api/slider/active":           PermissionCustomer,
+	"GET /api/slider/trashed":          PermissionAdmin,
+	"POST /api/slider/create":          PermissionAdmin,
+	"POST /api/slider/update/:id":      PermissionAdmin,
+	"POST /api/slider/trashed/:id":     PermissionAdmin,
+	"POST /api/slider/restore/:id":     PermissionAdmin,
+	"DELETE /api/slider/permanent/:id": PermissionAdmin,
+	"POST /api/slider/restore/all":     PermissionAdmin,
+	"POST /api/slider/permanent/all":   PermissionAdmin,
+
+	"GET /api/transaction":                       PermissionAdmin,
+	"GET /api/transaction/:id":                   PermissionCustomer,
+	"GET /api/transaction/merchant/:merchant_id": PermissionMerchant,
+	"GET /api/transaction/active":                PermissionAdmin,
+	"GET /api/transaction/trashed":               PermissionAdmin,
+	"POST /api/transaction/create":               PermissionMerchant,
+	"POST /api/transaction/update/:id":           PermissionMerchant,
+	"POST /api/transaction/refund/:id":           PermissionMerchant,
+	"POST /api/transaction/trashed/:id":          PermissionAdmin,
+	"POST /api/transaction/restore/:id":          PermissionAdmin,
+	"DELETE /api/transaction/permanent/:id":      PermissionAdmin,
+	"POST /api/transaction/restore/all":          PermissionAdmin,
+	"POST /api/transaction/permanent/all":        PermissionAdmin,
+
+	"GET /api/user":                  PermissionAdmin,
+	"GET /api/user/:id":              PermissionCustomer,
+	"GET /api/user/active":           PermissionAdmin,
+	"GET /api/user/trashed":          PermissionAdmin,
+	"POST /api/user/create":          PermissionAdmin,
+	"POST /api/user/update/:id":      PermissionCustomer,
+	"POST /api/user/trashed/:id":     PermissionAdmin,
+	"POST /api/user/restore/:id":     PermissionAdmin,
+	"DELETE /api/user/permanent/:id": PermissionAdmin,
+	"POST /api/user/restore/all":     PermissionAdmin,
+	"POST /api/user/permanent/all":   PermissionAdmin,
+}
+
+// rpcPermissions is the gRPC counterpart of routePermissions, keyed by full
+// method name. Every method outside publicMethods must be listed.
+var rpcPermissions = map[string]Permission{
+	"/pb.AuthService/GetMe":         PermissionCustomer,
+	"/pb.AuthService/LogoutAll":     PermissionCustomer,
+	"/pb.AuthService/FindSessions":  PermissionCustomer,
+	"/pb.AuthService/RevokeSession": PermissionCustomer,
+	"/pb.AuthService/UnlockLogin":   PermissionAdmin,
+
+	"/pb.CartService/FindAll":        PermissionCustomer,
+	"/pb.CartService/FindSummary":    PermissionCustomer,
+	"/pb.CartService/Create":         PermissionCustomer,
+	"/pb.CartService/UpdateQuantity": PermissionCustomer,
+	"/pb.CartService/Delete":         PermissionCustomer,
+	"/pb.CartService/DeleteAll":      PermissionCustomer,
+	"/pb.CartService/Checkout":       PermissionCustomer,
+
+	"/pb.CategoryService/FindAll":      PermissionCustomer,
+	"/pb.CategoryService/FindById":     PermissionCustomer,
+	"/pb.CategoryService/FindByActive": PermissionCustomer,
+
+	"/pb.CategoryService/FindByTrashed":              PermissionAdmin,
+	"/pb.CategoryService/Create":                     PermissionAdmin,
+	"/pb.CategoryService/Update":                     PermissionAdmin,
+	"/pb.CategoryService/TrashedCategory":            PermissionAdmin,
+	"/pb.CategoryService/RestoreCategory":            PermissionAdmin,
+	"/pb.CategoryService/DeleteCategoryPermanent":    PermissionAdmin,
+	"/pb.CategoryService/RestoreAllCategory":         PermissionAdmin,
+	"/pb.CategoryService/DeleteAllCategoryPermanent": PermissionAdmin,
+
+	"/pb.MerchantService/FindAll":                    PermissionCustomer,
+	"/pb.MerchantService/FindMe":                     PermissionCustomer,
+	"/pb.MerchantService/FindById":                   PermissionCustomer,
+	"/pb.MerchantService/FindByActive":               PermissionCustomer,
+	"/pb.MerchantService/FindByTrashed":              PermissionAdmin,
+	"/pb.MerchantService/Create":                     PermissionMerchant,
+	"/pb.MerchantService/Update":                     PermissionMerchant,
+	"/pb.MerchantService/TrashedMerchant":            PermissionAdmin,
+	"/pb.MerchantService/RestoreMerchant":            PermissionAdmin,
+	"/pb.MerchantService/DeleteMerchantPermanent":    PermissionAdmin,
+	"/pb.MerchantService/RestoreAllMerchant":         PermissionAdmin,
+	"/pb.MerchantService/DeleteAllMerchantPermanent": PermissionAdmin,
+
+	"/pb.OrderService/FindAll":                 PermissionAdmin,
+	"/pb.OrderService/FindByActive":            PermissionAdmin,
+	"/pb.OrderService/FindByTrashed":           PermissionAdmin,
+	"/pb.OrderService/FindById":                PermissionCustomer,
+	"/pb.OrderService/Create":                  PermissionCustomer,
+	"/pb.OrderService/Update":                  PermissionMerchant,
+	"/pb.OrderService/FindStatusHistory":       PermissionCustomer,
+	"/pb.OrderService/Cancel":                  PermissionCustomer,
+	"/pb.OrderService/MarkProcessing":          PermissionMerchant,
+	"/pb.OrderService/MarkShipped":             PermissionMerchant,
+	"/pb.OrderService/MarkDelivered":           PermissionMerchant,
+	"/pb.OrderService/TrashedOrder":            PermissionAdmin,
+	"/pb.OrderService/RestoreOrder":            PermissionAdmin,
+	"/pb.OrderService/DeleteOrderPermanent":    PermissionAdmin,
+	"/pb.OrderService/RestoreAllOrder":         PermissionAdmin,
+	"/pb.OrderService/DeleteAllOrderPermanent": PermissionAdmin,
+
+	"/pb.OrderItemService/FindAll":              PermissionAdmin,
+	"/pb.OrderItemService/FindOrderItemByOrder": PermissionCustomer,
+	"/pb.OrderItemService/FindByActive":         PermissionAdmin,
+	"/pb.OrderItemService/FindByTrashed":        PermissionAdmin,
+
+	"/pb.ProductService/FindAll":                   PermissionCustomer,
+	"/pb.ProductService/FindMe":                    PermissionCustomer,
+	"/pb.ProductService/Search":                    PermissionCustomer,
+	"/pb.ProductService/FindById":                  PermissionCustomer,
+	"/pb.ProductService/FindByMerchant":            PermissionCustomer,
+	"/pb.ProductService/FindByCategory":            PermissionCustomer,
+	"/pb.ProductService/FindByActive":              PermissionCustomer,
+	"/pb.ProductService/FindByTrashed":             PermissionAdmin,
+	"/pb.ProductService/Create":                    PermissionMerchant,
+	"/pb.ProductService/Update":                    PermissionMerchant,
+	"/pb.ProductService/TrashedProduct":            PermissionAdmin,
+	"/pb.ProductService/RestoreProduct":            PermissionAdmin,
+	"/pb.ProductService/DeleteProductPermanent":    PermissionAdmin,
+	"/pb.ProductService/RestoreAllProduct":         PermissionAdmin,
+	"/pb.ProductService/DeleteAllProductPermanent": PermissionAdmin,
+
+	"/pb.ReviewService/FindAll":                  PermissionCustomer,
+	"/pb.ReviewService/FindByProduct":            PermissionCustomer,
+	"/pb.ReviewService/FindByActive":             PermissionCustomer,
+	"/pb.ReviewService/FindByTrashed":            PermissionAdmin,
+	"/pb.ReviewService/Create":                   PermissionCustomer,
+	"/pb.ReviewService/Update":                   PermissionCustomer,
+	"/pb.ReviewService/TrashedReview":            PermissionAdmin,
+	"/pb.ReviewService/RestoreReview":            PermissionAdmin,
+	"/pb.ReviewService/DeleteReviewPermanent":    PermissionAdmin,
+	"/pb.ReviewService/RestoreAllReview":         PermissionAdmin,
+	"/pb.ReviewService/DeleteAllReviewPermanent": PermissionAdmin,
+
+	"/pb.RoleService/FindAllRole":            PermissionAdmin,
+	"/pb.RoleService/FindByIdRole":           PermissionAdmin,
+	"/pb.RoleService/FindByActive":           PermissionAdmin,
+	"/pb.RoleService/FindByTrashed":          PermissionAdmin,
+	"/pb.RoleService/FindByUserId":           PermissionAdmin,
+	"/pb.RoleService/CreateRole":             PermissionAdmin,
+	"/pb.RoleService/UpdateRole":             PermissionAdmin,
+	"/pb.RoleService/TrashedRole":            PermissionAdmin,
+	"/pb.RoleService/RestoreRole":            PermissionAdmin,
+	"/pb.RoleService/DeleteRolePermanent":    PermissionAdmin,
+	"/pb.RoleService/RestoreAllRole":         PermissionAdmin,
+	"/pb.RoleService/DeleteAllRolePermanent": PermissionAdmin,
+
+	"/pb.ShippingService/FindAll":                    PermissionAdmin,
+	"/pb.ShippingService/FindById":                   PermissionCustomer,
+	"/pb.ShippingService/FindByOrder":                PermissionCustomer,
+	"/pb.ShippingService/FindByActive":               PermissionAdmin,
+	"/pb.ShippingService/FindByTrashed":              PermissionAdmin,
+	"/pb.ShippingService/TrashedShipping":            PermissionAdmin,
+	"/pb.ShippingService/RestoreShipping":            PermissionAdmin,
+	"/pb.ShippingService/DeleteShippingPermanent":    PermissionAdmin,
+	"/pb.ShippingService/RestoreAllShipping":         PermissionAdmin,
+	"/pb.ShippingService/DeleteAllShippingPermanent": PermissionAdmin,
+
+	"/pb.SliderService/FindAll":                  PermissionCustomer,
+	"/pb.SliderService/FindByActive":             PermissionCustomer,
+	"/pb.SliderService/FindByTrashed":            PermissionAdmin,
+	"/pb.SliderService/Create":                   PermissionAdmin,
+	"/pb.SliderService/Update":                   PermissionAdmin,
+	"/pb.SliderService/TrashedSlider":            PermissionAdmin,
+	"/pb.SliderService/RestoreSlider":            PermissionAdmin,
+	"/pb.SliderService/DeleteSliderPermanent":    PermissionAdmin,
+	"/pb.SliderService/RestoreAllSlider":         PermissionAdmin,
+	"/pb.SliderService/DeleteAllSliderPermanent": PermissionAdmin,
+
+	"/pb.TransactionService/FindAll":                       PermissionAdmin,
+	"/pb.TransactionService/FindById":                      PermissionCustomer,
+	"/pb.TransactionService/FindByMerchant":                PermissionMerchant,
+	"/pb.TransactionService/FindByActive":                  PermissionAdmin,
+	"/pb.TransactionService/FindByTrashed":                 PermissionAdmin,
+	"/pb.TransactionService/Create":                        PermissionMerchant,
+	"/pb.TransactionService/Update":                        PermissionMerchant,
+	"/pb.TransactionService/RefundTransaction":             PermissionMerchant,
+	"/pb.TransactionService/TrashedTransaction":            PermissionAdmin,
+	"/pb.TransactionService/RestoreTransaction":            PermissionAdmin,
+	"/pb.TransactionService/DeleteTransactionPermanent":    PermissionAdmin,
+	"/pb.TransactionService/RestoreAllTransaction":         PermissionAdmin,
+	"/pb.TransactionService/DeleteAllTransactionPermanent": PermissionAdmin,
+
+	"/pb.UserService/FindAll":                PermissionAdmin,
+	"/pb.UserService/FindById":               PermissionCustomer,
+	"/pb.UserService/FindByActive":           PermissionAdmin,
+	"/pb.UserService/FindByTrashed":          PermissionAdmin,
+	"/pb.UserService/Create":                 PermissionAdmin,
+	"/pb.UserService/Update":                 PermissionCustomer,
+	"/pb.UserService/TrashedUser":            PermissionAdmin,
+	"/pb.UserService/RestoreUser":            PermissionAdmin,
+	"/pb.UserService/DeleteUserPermanent":    PermissionAdmin,
+	"/pb.UserService/RestoreAllUser":         PermissionAdmin,
+	"/pb.UserService/DeleteAllUserPermanent": PermissionAdmin,
+}
+
+// routePermission returns the permission a REST route requires, and false
+// when the route is not listed and must be refused.
+func routePermission(method string, path string) (Permission, bool) {
+	permission, ok := routePermissions[method+" "+path]
+
+	return permission, ok
+}
+
+// rpcPermission returns the permission a gRPC method requires, and false
+// when the method is not listed and must be refused.
+func rpcPermission(fullMethod string) (Permission, bool) {
+	permission, ok := rpcPermissions[fullMethod]
+
+	return permission, ok
+}
+
+// HasPermission reports whether any of roles grants permission.
+func HasPermission(roles []string, permission Permission) bool {
+	if permission == PermissionCustomer {
+		return true
+	}
+
+	for _, role := range roles {
+		for _, granted := range rolePermissions[role] {
+			if granted == permission {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,109 @@
+package middlewares
+
+import (
+	"testing"
+
+	"ecommerce/internal/pb"
+
+	"google.golang.org/grpc"
+)
+
+func TestEveryRPCHasAPermission(t *testing.T) {
+	services := []grpc.ServiceDesc{
+		pb.AuthService_ServiceDesc,
+		pb.CartService_ServiceDesc,
+		pb.CategoryService_ServiceDesc,
+		pb.MerchantService_ServiceDesc,
+		pb.OrderService_ServiceDesc,
+		pb.OrderItemService_ServiceDesc,
+		pb.ProductService_ServiceDesc,
+		pb.ReviewService_ServiceDesc,
+		pb.RoleService_ServiceDesc,
+		pb.ShippingService_ServiceDesc,
+		pb.SliderService_ServiceDesc,
+		pb.TransactionService_ServiceDesc,
+		pb.UserService_ServiceDesc,
+	}
+
+	for _, service := range services {
+		for _, method := range service.Methods {
+			fullMethod := "/" + service.ServiceName + "/" + method.MethodName
+
+			if isPublicMethod(fullMethod) {
+				continue
+			}
+
+			if _, ok := rpcPermission(fullMethod); !ok {
+				t.Errorf("%s is neither public nor listed in rpcPermissions", fullMethod)
+			}
+		}
+	}
+}
+
+func TestRPCPermissions(t *testing.T) {
+	customer := []string{RoleCustomer}
+	merchant := []string{RoleCustomer, RoleCashier}
+	admin := []string{RoleAdmin}
+
+	tests := []struct {
+		method string
+		roles  []string
+		want   bool
+	}{
+		{method: "/pb.ProductService/FindAll", roles: customer, want: true},
+		{method: "/pb.CartService/Checkout", roles: customer, want: true},
+		{method: "/pb.ReviewService/Update", roles: customer, want: true},
+		{method: "/pb.ShippingService/FindById", roles: customer, want: true},
+		{method: "/pb.ShippingService/FindAll", roles: customer, want: false},
+		{method: "/pb.ShippingService/FindByActive", roles: merchant, want: false},
+		{method: "/pb.OrderItemService/FindAll", roles: customer, want: false},
+		{method: "/pb.OrderItemService/FindByActive", roles: admin, want: true},
+		{method: "/pb.OrderService/Update", roles: customer, want: false},
+		{method: "/pb.OrderService/Update", roles: merchant, want: true},
+		{method: "/pb.ProductService/Create", roles: customer, want: false},
+		{method: "/pb.ProductService/Create", roles: admin, want: true},
+		{method: "/pb.RoleService/CreateRole", roles: merchant, want: false},
+		{method: "/pb.RoleService/CreateRole", roles: admin, want: true},
+		{method: "/pb.ProductService/Unknown", roles: admin, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			permission, ok := rpcPermission(tt.method)
+			if got := ok && HasPermission(tt.roles, permission); got != tt.want {
+				t.Errorf("allowed(%v) = %v, want %v", tt.roles, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoutePermissions(t *testing.T) {
+	customer := []string{RoleCustomer}
+	admin := []string{RoleAdmin}
+
+	tests := []struct {
+		method string
+		path   string
+		roles  []string
+		want   bool
+	}{
+		{method: "GET", path: "/api/product", roles: customer, want: true},
+		{method: "GET", path: "/api/shipping-address/:id", roles: customer, want: true},
+		{method: "GET", path: "/api/shipping-address", roles: customer, want: false},
+		{method: "GET", path: "/api/order-item", roles: customer, want: false},
+		{method: "GET", path: "/api/order-item/:order_id", roles: customer, want: true},
+		{method: "POST", path: "/api/review/update/:id", roles: customer, want: true},
+		{method: "POST", path: "/api/order/update/:id", roles: customer, want: false},
+		{method: "GET", path: "/api/user", roles: admin, want: true},
+		{method: "GET", path: "/api/unknown", roles: admin, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			permission, ok := routePermission(tt.method, tt.path)
+			if got := ok && HasPermission(tt.roles, permission); got != tt.want {
+				t.Errorf("allowed(%v) = %v, want %v", tt.roles, got, tt.want)
+			}
+		})
+	}
+}
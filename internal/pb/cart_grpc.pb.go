@@ -11,6 +11,7 @@ import (
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 // This is a compile-time assertion to ensure that this generated file
@@ -19,10 +20,13 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	CartService_FindAll_FullMethodName   = "/pb.CartService/FindAll"
-	CartService_Create_FullMethodName    = "/pb.CartService/Create"
-	CartService_Delete_FullMethodName    = "/pb.CartService/Delete"
-	CartService_DeleteAll_FullMethodName = "/pb.CartService/DeleteAll"
+	CartService_FindAll_FullMethodName        = "/pb.CartService/FindAll"
+	CartService_FindSummary_FullMethodName    = "/pb.CartService/FindSummary"
+	CartService_Create_FullMethodName         = "/pb.CartService/Create"
+	CartService_UpdateQuantity_FullMethodName = "/pb.CartService/UpdateQuantity"
+	CartService_Delete_FullMethodName         = "/pb.CartService/Delete"
+	CartService_DeleteAll_FullMethodName      = "/pb.CartService/DeleteAll"
+	CartService_Checkout_FullMethodName       = "/pb.CartService/Checkout"
 )
 
 // CartServiceClient is the client API for CartService service.
@@ -30,9 +34,12 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type CartServiceClient interface {
 	FindAll(ctx context.Context, in *FindAllCartRequest, opts ...grpc.CallOption) (*ApiResponsePaginationCart, error)
+	FindSummary(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ApiResponseCartSummary, error)
 	Create(ctx context.Context, in *CreateCartRequest, opts ...grpc.CallOption) (*ApiResponseCart, error)
+	UpdateQuantity(ctx context.Context, in *UpdateCartQuantityRequest, opts ...grpc.CallOption) (*ApiResponseCart, error)
 	Delete(ctx context.Context, in *FindByIdCartRequest, opts ...grpc.CallOption) (*ApiResponseCartDelete, error)
 	DeleteAll(ctx context.Context, in *DeleteCartRequest, opts ...grpc.CallOption) (*ApiResponseCartAll, error)
+	Checkout(ctx context.Context, in *CheckoutCartRequest, opts ...grpc.CallOption) (*ApiResponsesOrder, error)
 }
 
 type cartServiceClient struct {
@@ -53,6 +60,16 @@ func (c *cartServiceClient) FindAll(ctx context.Context, in *FindAllCartRequest,
 	return out, nil
 }
 
+func (c *cartServiceClient) FindSummary(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ApiResponseCartSummary, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApiResponseCartSummary)
+	err := c.cc.Invoke(ctx, CartService_FindSummary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *cartServiceClient) Create(ctx context.Context, in *CreateCartRequest, opts ...grpc.CallOption) (*ApiResponseCart, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ApiResponseCart)
@@ -63,6 +80,16 @@ func (c *cartServiceClient) Create(ctx context.Context, in *CreateCartRequest, o
 	return out, nil
 }
 
+func (c *cartServiceClient) UpdateQuantity(ctx context.Context, in *UpdateCartQuantityRequest, opts ...grpc.CallOption) (*ApiResponseCart, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApiResponseCart)
+	err := c.cc.Invoke(ctx, CartService_UpdateQuantity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *cartServiceClient) Delete(ctx context.Context, in *FindByIdCartRequest, opts ...grpc.CallOption) (*ApiResponseCartDelete, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ApiResponseCartDelete)
@@ -83,14 +110,27 @@ func (c *cartServiceClient) DeleteAll(ctx context.Context, in *DeleteCartRequest
 	return out, nil
 }
 
+func (c *cartServiceClient) Checkout(ctx context.Context, in *CheckoutCartRequest, opts ...grpc.CallOption) (*ApiResponsesOrder, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApiResponsesOrder)
+	err := c.cc.Invoke(ctx, CartService_Checkout_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CartServiceServer is the server API for CartService service.
 // All implementations must embed UnimplementedCartServiceServer
 // for forward compatibility.
 type CartServiceServer interface {
 	FindAll(context.Context, *FindAllCartRequest) (*ApiResponsePaginationCart, error)
+	FindSummary(context.Context, *emptypb.Empty) (*ApiResponseCartSummary, error)
 	Create(context.Context, *CreateCartRequest) (*ApiResponseCart, error)
+	UpdateQuantity(context.Context, *UpdateCartQuantityRequest) (*ApiResponseCart, error)
 	Delete(context.Context, *FindByIdCartRequest) (*ApiResponseCartDelete, error)
 	DeleteAll(context.Context, *DeleteCartRequest) (*ApiResponseCartAll, error)
+	Checkout(context.Context, *CheckoutCartRequest) (*ApiResponsesOrder, error)
 	mustEmbedUnimplementedCartServiceServer()
 }
 
@@ -104,15 +144,24 @@ type UnimplementedCartServiceServer struct{}
 func (UnimplementedCartServiceServer) FindAll(context.Context, *FindAllCartRequest) (*ApiResponsePaginationCart, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FindAll not implemented")
 }
+func (UnimplementedCartServiceServer) FindSummary(context.Context, *emptypb.Empty) (*ApiResponseCartSummary, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindSummary not implemented")
+}
 func (UnimplementedCartServiceServer) Create(context.Context, *CreateCartRequest) (*ApiResponseCart, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
 }
+func (UnimplementedCartServiceServer) UpdateQuantity(context.Context, *UpdateCartQuantityRequest) (*ApiResponseCart, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateQuantity not implemented")
+}
 func (UnimplementedCartServiceServer) Delete(context.Context, *FindByIdCartRequest) (*ApiResponseCartDelete, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
 }
 func (UnimplementedCartServiceServer) DeleteAll(context.Context, *DeleteCartRequest) (*ApiResponseCartAll, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteAll not implemented")
 }
+func (UnimplementedCartServiceServer) Checkout(context.Context, *CheckoutCartRequest) (*ApiResponsesOrder, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Checkout not implemented")
+}
 func (UnimplementedCartServiceServer) mustEmbedUnimplementedCartServiceServer() {}
 func (UnimplementedCartServiceServer) testEmbeddedByValue()                     {}
 
@@ -152,6 +201,24 @@ func _CartService_FindAll_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CartService_FindSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartServiceServer).FindSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CartService_FindSummary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartServiceServer).FindSummary(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CartService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateCartRequest)
 	if err := dec(in); err != nil {
@@ -170,6 +237,24 @@ func _CartService_Create_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CartService_UpdateQuantity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCartQuantityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartServiceServer).UpdateQuantity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CartService_UpdateQuantity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartServiceServer).UpdateQuantity(ctx, req.(*UpdateCartQuantityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CartService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(FindByIdCartRequest)
 	if err := dec(in); err != nil {
@@ -206,6 +291,24 @@ func _CartService_DeleteAll_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CartService_Checkout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckoutCartRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartServiceServer).Checkout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CartService_Checkout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartServiceServer).Checkout(ctx, req.(*CheckoutCartRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CartService_ServiceDesc is the grpc.ServiceDesc for CartService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -217,10 +320,18 @@ var CartService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "FindAll",
 			Handler:    _CartService_FindAll_Handler,
 		},
+		{
+			MethodName: "FindSummary",
+			Handler:    _CartService_FindSummary_Handler,
+		},
 		{
 			MethodName: "Create",
 			Handler:    _CartService_Create_Handler,
 		},
+		{
+			MethodName: "UpdateQuantity",
+			Handler:    _CartService_UpdateQuantity_Handler,
+		},
 		{
 			MethodName: "Delete",
 			Handler:    _CartService_Delete_Handler,
@@ -229,6 +340,10 @@ var CartService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteAll",
 			Handler:    _CartService_DeleteAll_Handler,
 		},
+		{
+			MethodName: "Checkout",
+			Handler:    _CartService_Checkout_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "cart.proto",
@@ -22,6 +22,7 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	MerchantService_FindAll_FullMethodName                    = "/pb.MerchantService/FindAll"
 	MerchantService_FindById_FullMethodName                   = "/pb.MerchantService/FindById"
+	MerchantService_FindMe_FullMethodName                     = "/pb.MerchantService/FindMe"
 	MerchantService_FindByActive_FullMethodName               = "/pb.MerchantService/FindByActive"
 	MerchantService_FindByTrashed_FullMethodName              = "/pb.MerchantService/FindByTrashed"
 	MerchantService_Create_FullMethodName                     = "/pb.MerchantService/Create"
@@ -39,6 +40,7 @@ const (
 type MerchantServiceClient interface {
 	FindAll(ctx context.Context, in *FindAllMerchantRequest, opts ...grpc.CallOption) (*ApiResponsePaginationMerchant, error)
 	FindById(ctx context.Context, in *FindByIdMerchantRequest, opts ...grpc.CallOption) (*ApiResponseMerchant, error)
+	FindMe(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ApiResponsesMerchant, error)
 	FindByActive(ctx context.Context, in *FindAllMerchantRequest, opts ...grpc.CallOption) (*ApiResponsePaginationMerchantDeleteAt, error)
 	FindByTrashed(ctx context.Context, in *FindAllMerchantRequest, opts ...grpc.CallOption) (*ApiResponsePaginationMerchantDeleteAt, error)
 	Create(ctx context.Context, in *CreateMerchantRequest, opts ...grpc.CallOption) (*ApiResponseMerchant, error)
@@ -78,6 +80,16 @@ func (c *merchantServiceClient) FindById(ctx context.Context, in *FindByIdMercha
 	return out, nil
 }
 
+func (c *merchantServiceClient) FindMe(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ApiResponsesMerchant, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApiResponsesMerchant)
+	err := c.cc.Invoke(ctx, MerchantService_FindMe_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *merchantServiceClient) FindByActive(ctx context.Context, in *FindAllMerchantRequest, opts ...grpc.CallOption) (*ApiResponsePaginationMerchantDeleteAt, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ApiResponsePaginationMerchantDeleteAt)
@@ -174,6 +186,7 @@ func (c *merchantServiceClient) DeleteAllMerchantPermanent(ctx context.Context,
 type MerchantServiceServer interface {
 	FindAll(context.Context, *FindAllMerchantRequest) (*ApiResponsePaginationMerchant, error)
 	FindById(context.Context, *FindByIdMerchantRequest) (*ApiResponseMerchant, error)
+	FindMe(context.Context, *emptypb.Empty) (*ApiResponsesMerchant, error)
 	FindByActive(context.Context, *FindAllMerchantRequest) (*ApiResponsePaginationMerchantDeleteAt, error)
 	FindByTrashed(context.Context, *FindAllMerchantRequest) (*ApiResponsePaginationMerchantDeleteAt, error)
 	Create(context.Context, *CreateMerchantRequest) (*ApiResponseMerchant, error)
@@ -199,6 +212,9 @@ func (UnimplementedMerchantServiceServer) FindAll(context.Context, *FindAllMerch
 func (UnimplementedMerchantServiceServer) FindById(context.Context, *FindByIdMerchantRequest) (*ApiResponseMerchant, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FindById not implemented")
 }
+func (UnimplementedMerchantServiceServer) FindMe(context.Context, *emptypb.Empty) (*ApiResponsesMerchant, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindMe not implemented")
+}
 func (UnimplementedMerchantServiceServer) FindByActive(context.Context, *FindAllMerchantRequest) (*ApiResponsePaginationMerchantDeleteAt, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FindByActive not implemented")
 }
@@ -283,6 +299,24 @@ func _MerchantService_FindById_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MerchantService_FindMe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerchantServiceServer).FindMe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MerchantService_FindMe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerchantServiceServer).FindMe(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MerchantService_FindByActive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(FindAllMerchantRequest)
 	if err := dec(in); err != nil {
@@ -460,6 +494,10 @@ var MerchantService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "FindById",
 			Handler:    _MerchantService_FindById_Handler,
 		},
+		{
+			MethodName: "FindMe",
+			Handler:    _MerchantService_FindMe_Handler,
+		},
 		{
 			MethodName: "FindByActive",
 			Handler:    _MerchantService_FindByActive_Handler,
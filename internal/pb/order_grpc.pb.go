@@ -26,6 +26,11 @@ const (
 	OrderService_FindByTrashed_FullMethodName           = "/pb.OrderService/FindByTrashed"
 	OrderService_Create_FullMethodName                  = "/pb.OrderService/Create"
 	OrderService_Update_FullMethodName                  = "/pb.OrderService/Update"
+	OrderService_FindStatusHistory_FullMethodName       = "/pb.OrderService/FindStatusHistory"
+	OrderService_Cancel_FullMethodName                  = "/pb.OrderService/Cancel"
+	OrderService_MarkProcessing_FullMethodName          = "/pb.OrderService/MarkProcessing"
+	OrderService_MarkShipped_FullMethodName             = "/pb.OrderService/MarkShipped"
+	OrderService_MarkDelivered_FullMethodName           = "/pb.OrderService/MarkDelivered"
 	OrderService_TrashedOrder_FullMethodName            = "/pb.OrderService/TrashedOrder"
 	OrderService_RestoreOrder_FullMethodName            = "/pb.OrderService/RestoreOrder"
 	OrderService_DeleteOrderPermanent_FullMethodName    = "/pb.OrderService/DeleteOrderPermanent"
@@ -43,6 +48,11 @@ type OrderServiceClient interface {
 	FindByTrashed(ctx context.Context, in *FindAllOrderRequest, opts ...grpc.CallOption) (*ApiResponsePaginationOrderDeleteAt, error)
 	Create(ctx context.Context, in *CreateOrderRequest, opts ...grpc.CallOption) (*ApiResponseOrder, error)
 	Update(ctx context.Context, in *UpdateOrderRequest, opts ...grpc.CallOption) (*ApiResponseOrder, error)
+	FindStatusHistory(ctx context.Context, in *FindByIdOrderRequest, opts ...grpc.CallOption) (*ApiResponseOrderStatusHistory, error)
+	Cancel(ctx context.Context, in *UpdateOrderStatusRequest, opts ...grpc.CallOption) (*ApiResponseOrder, error)
+	MarkProcessing(ctx context.Context, in *UpdateOrderStatusRequest, opts ...grpc.CallOption) (*ApiResponseOrder, error)
+	MarkShipped(ctx context.Context, in *UpdateOrderStatusRequest, opts ...grpc.CallOption) (*ApiResponseOrder, error)
+	MarkDelivered(ctx context.Context, in *UpdateOrderStatusRequest, opts ...grpc.CallOption) (*ApiResponseOrder, error)
 	TrashedOrder(ctx context.Context, in *FindByIdOrderRequest, opts ...grpc.CallOption) (*ApiResponseOrderDeleteAt, error)
 	RestoreOrder(ctx context.Context, in *FindByIdOrderRequest, opts ...grpc.CallOption) (*ApiResponseOrderDeleteAt, error)
 	DeleteOrderPermanent(ctx context.Context, in *FindByIdOrderRequest, opts ...grpc.CallOption) (*ApiResponseOrderDelete, error)
@@ -118,6 +128,56 @@ func (c *orderServiceClient) Update(ctx context.Context, in *UpdateOrderRequest,
 	return out, nil
 }
 
+func (c *orderServiceClient) FindStatusHistory(ctx context.Context, in *FindByIdOrderRequest, opts ...grpc.CallOption) (*ApiResponseOrderStatusHistory, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApiResponseOrderStatusHistory)
+	err := c.cc.Invoke(ctx, OrderService_FindStatusHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) Cancel(ctx context.Context, in *UpdateOrderStatusRequest, opts ...grpc.CallOption) (*ApiResponseOrder, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApiResponseOrder)
+	err := c.cc.Invoke(ctx, OrderService_Cancel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) MarkProcessing(ctx context.Context, in *UpdateOrderStatusRequest, opts ...grpc.CallOption) (*ApiResponseOrder, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApiResponseOrder)
+	err := c.cc.Invoke(ctx, OrderService_MarkProcessing_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) MarkShipped(ctx context.Context, in *UpdateOrderStatusRequest, opts ...grpc.CallOption) (*ApiResponseOrder, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApiResponseOrder)
+	err := c.cc.Invoke(ctx, OrderService_MarkShipped_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) MarkDelivered(ctx context.Context, in *UpdateOrderStatusRequest, opts ...grpc.CallOption) (*ApiResponseOrder, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApiResponseOrder)
+	err := c.cc.Invoke(ctx, OrderService_MarkDelivered_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *orderServiceClient) TrashedOrder(ctx context.Context, in *FindByIdOrderRequest, opts ...grpc.CallOption) (*ApiResponseOrderDeleteAt, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ApiResponseOrderDeleteAt)
@@ -178,6 +238,11 @@ type OrderServiceServer interface {
 	FindByTrashed(context.Context, *FindAllOrderRequest) (*ApiResponsePaginationOrderDeleteAt, error)
 	Create(context.Context, *CreateOrderRequest) (*ApiResponseOrder, error)
 	Update(context.Context, *UpdateOrderRequest) (*ApiResponseOrder, error)
+	FindStatusHistory(context.Context, *FindByIdOrderRequest) (*ApiResponseOrderStatusHistory, error)
+	Cancel(context.Context, *UpdateOrderStatusRequest) (*ApiResponseOrder, error)
+	MarkProcessing(context.Context, *UpdateOrderStatusRequest) (*ApiResponseOrder, error)
+	MarkShipped(context.Context, *UpdateOrderStatusRequest) (*ApiResponseOrder, error)
+	MarkDelivered(context.Context, *UpdateOrderStatusRequest) (*ApiResponseOrder, error)
 	TrashedOrder(context.Context, *FindByIdOrderRequest) (*ApiResponseOrderDeleteAt, error)
 	RestoreOrder(context.Context, *FindByIdOrderRequest) (*ApiResponseOrderDeleteAt, error)
 	DeleteOrderPermanent(context.Context, *FindByIdOrderRequest) (*ApiResponseOrderDelete, error)
@@ -211,6 +276,21 @@ func (UnimplementedOrderServiceServer) Create(context.Context, *CreateOrderReque
 func (UnimplementedOrderServiceServer) Update(context.Context, *UpdateOrderRequest) (*ApiResponseOrder, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
 }
+func (UnimplementedOrderServiceServer) FindStatusHistory(context.Context, *FindByIdOrderRequest) (*ApiResponseOrderStatusHistory, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindStatusHistory not implemented")
+}
+func (UnimplementedOrderServiceServer) Cancel(context.Context, *UpdateOrderStatusRequest) (*ApiResponseOrder, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Cancel not implemented")
+}
+func (UnimplementedOrderServiceServer) MarkProcessing(context.Context, *UpdateOrderStatusRequest) (*ApiResponseOrder, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MarkProcessing not implemented")
+}
+func (UnimplementedOrderServiceServer) MarkShipped(context.Context, *UpdateOrderStatusRequest) (*ApiResponseOrder, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MarkShipped not implemented")
+}
+func (UnimplementedOrderServiceServer) MarkDelivered(context.Context, *UpdateOrderStatusRequest) (*ApiResponseOrder, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MarkDelivered not implemented")
+}
 func (UnimplementedOrderServiceServer) TrashedOrder(context.Context, *FindByIdOrderRequest) (*ApiResponseOrderDeleteAt, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method TrashedOrder not implemented")
 }
@@ -355,6 +435,96 @@ func _OrderService_Update_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OrderService_FindStatusHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindByIdOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).FindStatusHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_FindStatusHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).FindStatusHistory(ctx, req.(*FindByIdOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_Cancel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateOrderStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).Cancel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_Cancel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).Cancel(ctx, req.(*UpdateOrderStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_MarkProcessing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateOrderStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).MarkProcessing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_MarkProcessing_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).MarkProcessing(ctx, req.(*UpdateOrderStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_MarkShipped_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateOrderStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).MarkShipped(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_MarkShipped_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).MarkShipped(ctx, req.(*UpdateOrderStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_MarkDelivered_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateOrderStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).MarkDelivered(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_MarkDelivered_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).MarkDelivered(ctx, req.(*UpdateOrderStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _OrderService_TrashedOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(FindByIdOrderRequest)
 	if err := dec(in); err != nil {
@@ -476,6 +646,26 @@ var OrderService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Update",
 			Handler:    _OrderService_Update_Handler,
 		},
+		{
+			MethodName: "FindStatusHistory",
+			Handler:    _OrderService_FindStatusHistory_Handler,
+		},
+		{
+			MethodName: "Cancel",
+			Handler:    _OrderService_Cancel_Handler,
+		},
+		{
+			MethodName: "MarkProcessing",
+			Handler:    _OrderService_MarkProcessing_Handler,
+		},
+		{
+			MethodName: "MarkShipped",
+			Handler:    _OrderService_MarkShipped_Handler,
+		},
+		{
+			MethodName: "MarkDelivered",
+			Handler:    _OrderService_MarkDelivered_Handler,
+		},
 		{
 			MethodName: "TrashedOrder",
 			Handler:    _OrderService_TrashedOrder_Handler,
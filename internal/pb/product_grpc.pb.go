@@ -22,7 +22,9 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	ProductService_FindAll_FullMethodName                   = "/pb.ProductService/FindAll"
 	ProductService_FindByMerchant_FullMethodName            = "/pb.ProductService/FindByMerchant"
+	ProductService_FindMe_FullMethodName                    = "/pb.ProductService/FindMe"
 	ProductService_FindByCategory_FullMethodName            = "/pb.ProductService/FindByCategory"
+	ProductService_Search_FullMethodName                    = "/pb.ProductService/Search"
 	ProductService_FindById_FullMethodName                  = "/pb.ProductService/FindById"
 	ProductService_FindByActive_FullMethodName              = "/pb.ProductService/FindByActive"
 	ProductService_FindByTrashed_FullMethodName             = "/pb.ProductService/FindByTrashed"
@@ -41,7 +43,9 @@ const (
 type ProductServiceClient interface {
 	FindAll(ctx context.Context, in *FindAllProductRequest, opts ...grpc.CallOption) (*ApiResponsePaginationProduct, error)
 	FindByMerchant(ctx context.Context, in *FindAllProductMerchantRequest, opts ...grpc.CallOption) (*ApiResponsePaginationProduct, error)
+	FindMe(ctx context.Context, in *FindAllProductRequest, opts ...grpc.CallOption) (*ApiResponsePaginationProduct, error)
 	FindByCategory(ctx context.Context, in *FindAllProductCategoryRequest, opts ...grpc.CallOption) (*ApiResponsePaginationProduct, error)
+	Search(ctx context.Context, in *SearchProductRequest, opts ...grpc.CallOption) (*ApiResponseProductSearch, error)
 	FindById(ctx context.Context, in *FindByIdProductRequest, opts ...grpc.CallOption) (*ApiResponseProduct, error)
 	FindByActive(ctx context.Context, in *FindAllProductRequest, opts ...grpc.CallOption) (*ApiResponsePaginationProductDeleteAt, error)
 	FindByTrashed(ctx context.Context, in *FindAllProductRequest, opts ...grpc.CallOption) (*ApiResponsePaginationProductDeleteAt, error)
@@ -82,6 +86,16 @@ func (c *productServiceClient) FindByMerchant(ctx context.Context, in *FindAllPr
 	return out, nil
 }
 
+func (c *productServiceClient) FindMe(ctx context.Context, in *FindAllProductRequest, opts ...grpc.CallOption) (*ApiResponsePaginationProduct, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApiResponsePaginationProduct)
+	err := c.cc.Invoke(ctx, ProductService_FindMe_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *productServiceClient) FindByCategory(ctx context.Context, in *FindAllProductCategoryRequest, opts ...grpc.CallOption) (*ApiResponsePaginationProduct, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ApiResponsePaginationProduct)
@@ -92,6 +106,16 @@ func (c *productServiceClient) FindByCategory(ctx context.Context, in *FindAllPr
 	return out, nil
 }
 
+func (c *productServiceClient) Search(ctx context.Context, in *SearchProductRequest, opts ...grpc.CallOption) (*ApiResponseProductSearch, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApiResponseProductSearch)
+	err := c.cc.Invoke(ctx, ProductService_Search_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *productServiceClient) FindById(ctx context.Context, in *FindByIdProductRequest, opts ...grpc.CallOption) (*ApiResponseProduct, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ApiResponseProduct)
@@ -198,7 +222,9 @@ func (c *productServiceClient) DeleteAllProductPermanent(ctx context.Context, in
 type ProductServiceServer interface {
 	FindAll(context.Context, *FindAllProductRequest) (*ApiResponsePaginationProduct, error)
 	FindByMerchant(context.Context, *FindAllProductMerchantRequest) (*ApiResponsePaginationProduct, error)
+	FindMe(context.Context, *FindAllProductRequest) (*ApiResponsePaginationProduct, error)
 	FindByCategory(context.Context, *FindAllProductCategoryRequest) (*ApiResponsePaginationProduct, error)
+	Search(context.Context, *SearchProductRequest) (*ApiResponseProductSearch, error)
 	FindById(context.Context, *FindByIdProductRequest) (*ApiResponseProduct, error)
 	FindByActive(context.Context, *FindAllProductRequest) (*ApiResponsePaginationProductDeleteAt, error)
 	FindByTrashed(context.Context, *FindAllProductRequest) (*ApiResponsePaginationProductDeleteAt, error)
@@ -225,9 +251,15 @@ func (UnimplementedProductServiceServer) FindAll(context.Context, *FindAllProduc
 func (UnimplementedProductServiceServer) FindByMerchant(context.Context, *FindAllProductMerchantRequest) (*ApiResponsePaginationProduct, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FindByMerchant not implemented")
 }
+func (UnimplementedProductServiceServer) FindMe(context.Context, *FindAllProductRequest) (*ApiResponsePaginationProduct, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindMe not implemented")
+}
 func (UnimplementedProductServiceServer) FindByCategory(context.Context, *FindAllProductCategoryRequest) (*ApiResponsePaginationProduct, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FindByCategory not implemented")
 }
+func (UnimplementedProductServiceServer) Search(context.Context, *SearchProductRequest) (*ApiResponseProductSearch, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
 func (UnimplementedProductServiceServer) FindById(context.Context, *FindByIdProductRequest) (*ApiResponseProduct, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FindById not implemented")
 }
@@ -315,6 +347,24 @@ func _ProductService_FindByMerchant_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProductService_FindMe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindAllProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).FindMe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_FindMe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).FindMe(ctx, req.(*FindAllProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ProductService_FindByCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(FindAllProductCategoryRequest)
 	if err := dec(in); err != nil {
@@ -333,6 +383,24 @@ func _ProductService_FindByCategory_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProductService_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_Search_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).Search(ctx, req.(*SearchProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ProductService_FindById_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(FindByIdProductRequest)
 	if err := dec(in); err != nil {
@@ -528,10 +596,18 @@ var ProductService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "FindByMerchant",
 			Handler:    _ProductService_FindByMerchant_Handler,
 		},
+		{
+			MethodName: "FindMe",
+			Handler:    _ProductService_FindMe_Handler,
+		},
 		{
 			MethodName: "FindByCategory",
 			Handler:    _ProductService_FindByCategory_Handler,
 		},
+		{
+			MethodName: "Search",
+			Handler:    _ProductService_Search_Handler,
+		},
 		{
 			MethodName: "FindById",
 			Handler:    _ProductService_FindById_Handler,
@@ -202,7 +202,7 @@ type CreateShippingAddressRequest struct {
 	Kota           string                 `protobuf:"bytes,4,opt,name=kota,proto3" json:"kota,omitempty"`
 	Courier        string                 `protobuf:"bytes,5,opt,name=courier,proto3" json:"courier,omitempty"`
 	ShippingMethod string                 `protobuf:"bytes,6,opt,name=shipping_method,json=shippingMethod,proto3" json:"shipping_method,omitempty"`
-	ShippingCost   int32                  `protobuf:"varint,7,opt,name=shipping_cost,json=shippingCost,proto3" json:"shipping_cost,omitempty"`
+	ShippingCost   int64                  `protobuf:"varint,7,opt,name=shipping_cost,json=shippingCost,proto3" json:"shipping_cost,omitempty"`
 	Negara         string                 `protobuf:"bytes,8,opt,name=negara,proto3" json:"negara,omitempty"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
@@ -280,7 +280,7 @@ func (x *CreateShippingAddressRequest) GetShippingMethod() string {
 	return ""
 }
 
-func (x *CreateShippingAddressRequest) GetShippingCost() int32 {
+func (x *CreateShippingAddressRequest) GetShippingCost() int64 {
 	if x != nil {
 		return x.ShippingCost
 	}
@@ -303,7 +303,7 @@ type UpdateShippingAddressRequest struct {
 	Kota           string                 `protobuf:"bytes,5,opt,name=kota,proto3" json:"kota,omitempty"`
 	Courier        string                 `protobuf:"bytes,6,opt,name=courier,proto3" json:"courier,omitempty"`
 	ShippingMethod string                 `protobuf:"bytes,7,opt,name=shipping_method,json=shippingMethod,proto3" json:"shipping_method,omitempty"`
-	ShippingCost   int32                  `protobuf:"varint,8,opt,name=shipping_cost,json=shippingCost,proto3" json:"shipping_cost,omitempty"`
+	ShippingCost   int64                  `protobuf:"varint,8,opt,name=shipping_cost,json=shippingCost,proto3" json:"shipping_cost,omitempty"`
 	Negara         string                 `protobuf:"bytes,9,opt,name=negara,proto3" json:"negara,omitempty"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
@@ -388,7 +388,7 @@ func (x *UpdateShippingAddressRequest) GetShippingMethod() string {
 	return ""
 }
 
-func (x *UpdateShippingAddressRequest) GetShippingCost() int32 {
+func (x *UpdateShippingAddressRequest) GetShippingCost() int64 {
 	if x != nil {
 		return x.ShippingCost
 	}
@@ -1068,7 +1068,7 @@ var file_shipping_address_proto_rawDesc = string([]byte{
 	0x5f, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x73,
 	0x68, 0x69, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x23, 0x0a,
 	0x0d, 0x73, 0x68, 0x69, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x5f, 0x63, 0x6f, 0x73, 0x74, 0x18, 0x07,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x73, 0x68, 0x69, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x6f,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x73, 0x68, 0x69, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x6f,
 	0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x65, 0x67, 0x61, 0x72, 0x61, 0x18, 0x08, 0x20, 0x01,
 	0x28, 0x09, 0x52, 0x06, 0x6e, 0x65, 0x67, 0x61, 0x72, 0x61, 0x22, 0xa2, 0x02, 0x0a, 0x1c, 0x55,
 	0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x68, 0x69, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x41, 0x64, 0x64,
@@ -1086,7 +1086,7 @@ var file_shipping_address_proto_rawDesc = string([]byte{
 	0x70, 0x70, 0x69, 0x6e, 0x67, 0x5f, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x07, 0x20, 0x01,
 	0x28, 0x09, 0x52, 0x0e, 0x73, 0x68, 0x69, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x4d, 0x65, 0x74, 0x68,
 	0x6f, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x68, 0x69, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x5f, 0x63,
-	0x6f, 0x73, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x73, 0x68, 0x69, 0x70, 0x70,
+	0x6f, 0x73, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x73, 0x68, 0x69, 0x70, 0x70,
 	0x69, 0x6e, 0x67, 0x43, 0x6f, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x65, 0x67, 0x61, 0x72,
 	0x61, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x65, 0x67, 0x61, 0x72, 0x61, 0x22,
 	0xdb, 0x01, 0x0a, 0x10, 0x53, 0x68, 0x69, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70,
@@ -32,6 +32,8 @@ const (
 	TransactionService_DeleteTransactionPermanent_FullMethodName    = "/pb.TransactionService/DeleteTransactionPermanent"
 	TransactionService_RestoreAllTransaction_FullMethodName         = "/pb.TransactionService/RestoreAllTransaction"
 	TransactionService_DeleteAllTransactionPermanent_FullMethodName = "/pb.TransactionService/DeleteAllTransactionPermanent"
+	TransactionService_HandlePaymentEvent_FullMethodName            = "/pb.TransactionService/HandlePaymentEvent"
+	TransactionService_RefundTransaction_FullMethodName             = "/pb.TransactionService/RefundTransaction"
 )
 
 // TransactionServiceClient is the client API for TransactionService service.
@@ -50,6 +52,8 @@ type TransactionServiceClient interface {
 	DeleteTransactionPermanent(ctx context.Context, in *FindByIdTransactionRequest, opts ...grpc.CallOption) (*ApiResponseTransactionDelete, error)
 	RestoreAllTransaction(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ApiResponseTransactionAll, error)
 	DeleteAllTransactionPermanent(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ApiResponseTransactionAll, error)
+	HandlePaymentEvent(ctx context.Context, in *PaymentEventRequest, opts ...grpc.CallOption) (*ApiResponseTransaction, error)
+	RefundTransaction(ctx context.Context, in *RefundTransactionRequest, opts ...grpc.CallOption) (*ApiResponseTransaction, error)
 }
 
 type transactionServiceClient struct {
@@ -180,6 +184,26 @@ func (c *transactionServiceClient) DeleteAllTransactionPermanent(ctx context.Con
 	return out, nil
 }
 
+func (c *transactionServiceClient) HandlePaymentEvent(ctx context.Context, in *PaymentEventRequest, opts ...grpc.CallOption) (*ApiResponseTransaction, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApiResponseTransaction)
+	err := c.cc.Invoke(ctx, TransactionService_HandlePaymentEvent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionServiceClient) RefundTransaction(ctx context.Context, in *RefundTransactionRequest, opts ...grpc.CallOption) (*ApiResponseTransaction, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApiResponseTransaction)
+	err := c.cc.Invoke(ctx, TransactionService_RefundTransaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TransactionServiceServer is the server API for TransactionService service.
 // All implementations must embed UnimplementedTransactionServiceServer
 // for forward compatibility.
@@ -196,6 +220,8 @@ type TransactionServiceServer interface {
 	DeleteTransactionPermanent(context.Context, *FindByIdTransactionRequest) (*ApiResponseTransactionDelete, error)
 	RestoreAllTransaction(context.Context, *emptypb.Empty) (*ApiResponseTransactionAll, error)
 	DeleteAllTransactionPermanent(context.Context, *emptypb.Empty) (*ApiResponseTransactionAll, error)
+	HandlePaymentEvent(context.Context, *PaymentEventRequest) (*ApiResponseTransaction, error)
+	RefundTransaction(context.Context, *RefundTransactionRequest) (*ApiResponseTransaction, error)
 	mustEmbedUnimplementedTransactionServiceServer()
 }
 
@@ -242,6 +268,12 @@ func (UnimplementedTransactionServiceServer) RestoreAllTransaction(context.Conte
 func (UnimplementedTransactionServiceServer) DeleteAllTransactionPermanent(context.Context, *emptypb.Empty) (*ApiResponseTransactionAll, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteAllTransactionPermanent not implemented")
 }
+func (UnimplementedTransactionServiceServer) HandlePaymentEvent(context.Context, *PaymentEventRequest) (*ApiResponseTransaction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HandlePaymentEvent not implemented")
+}
+func (UnimplementedTransactionServiceServer) RefundTransaction(context.Context, *RefundTransactionRequest) (*ApiResponseTransaction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefundTransaction not implemented")
+}
 func (UnimplementedTransactionServiceServer) mustEmbedUnimplementedTransactionServiceServer() {}
 func (UnimplementedTransactionServiceServer) testEmbeddedByValue()                            {}
 
@@ -479,6 +511,42 @@ func _TransactionService_DeleteAllTransactionPermanent_Handler(srv interface{},
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TransactionService_HandlePaymentEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PaymentEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).HandlePaymentEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TransactionService_HandlePaymentEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).HandlePaymentEvent(ctx, req.(*PaymentEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionService_RefundTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefundTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).RefundTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TransactionService_RefundTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).RefundTransaction(ctx, req.(*RefundTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TransactionService_ServiceDesc is the grpc.ServiceDesc for TransactionService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -534,6 +602,14 @@ var TransactionService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteAllTransactionPermanent",
 			Handler:    _TransactionService_DeleteAllTransactionPermanent_Handler,
 		},
+		{
+			MethodName: "HandlePaymentEvent",
+			Handler:    _TransactionService_HandlePaymentEvent_Handler,
+		},
+		{
+			MethodName: "RefundTransaction",
+			Handler:    _TransactionService_RefundTransaction_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "transaction.proto",
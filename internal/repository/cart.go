@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"ecommerce/internal/domain/record"
 	"ecommerce/internal/domain/requests"
 	recordmapper "ecommerce/internal/mapper/record"
@@ -12,23 +13,20 @@ import (
 
 type cartRepository struct {
 	db      *db.Queries
-	ctx     context.Context
 	mapping recordmapper.CartRecordMapping
 }
 
 func NewCartRepository(
 	db *db.Queries,
-	ctx context.Context,
 	mapping recordmapper.CartRecordMapping,
 ) *cartRepository {
 	return &cartRepository{
 		db:      db,
-		ctx:     ctx,
 		mapping: mapping,
 	}
 }
 
-func (r *cartRepository) FindCarts(user_id int, search string, page, pageSize int) ([]*record.CartRecord, int, error) {
+func (r *cartRepository) FindCarts(ctx context.Context, user_id int, search string, page, pageSize int) ([]*record.CartRecord, int, error) {
 
 	offset := (page - 1) * pageSize
 
@@ -39,7 +37,7 @@ func (r *cartRepository) FindCarts(user_id int, search string, page, pageSize in
 		Offset:  int32(offset),
 	}
 
-	res, err := r.db.GetCarts(r.ctx, req)
+	res, err := r.db.GetCarts(ctx, req)
 
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to find categories: %w", err)
@@ -55,26 +53,84 @@ func (r *cartRepository) FindCarts(user_id int, search string, page, pageSize in
 	return r.mapping.ToCartsRecordPagination(res), totalCount, nil
 }
 
-func (r *cartRepository) CreateCart(req *requests.CartCreateRecord) (*record.CartRecord, error) {
-	res, err := r.db.CreateCart(r.ctx, db.CreateCartParams{
+func (r *cartRepository) FindCartsForCheckout(ctx context.Context, user_id int, merchant_id int) ([]*record.CartCheckoutRecord, error) {
+	res, err := r.db.GetCartsForCheckout(ctx, db.GetCartsForCheckoutParams{
+		UserID:  int32(user_id),
+		Column2: int32(merchant_id),
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to find carts for checkout: %w", err)
+	}
+
+	return r.mapping.ToCartsCheckoutRecord(res), nil
+}
+
+func (r *cartRepository) FindById(ctx context.Context, cart_id int) (*record.CartRecord, error) {
+	res, err := r.db.GetCart(ctx, int32(cart_id))
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to find cart: %w", err)
+	}
+
+	return r.mapping.ToCartRecord(res), nil
+}
+
+func (r *cartRepository) FindSummary(ctx context.Context, user_id int) (*record.CartSummaryRecord, error) {
+	res, err := r.db.GetCartSummary(ctx, int32(user_id))
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to find cart summary: %w", err)
+	}
+
+	return r.mapping.ToCartSummaryRecord(res), nil
+}
+
+func (r *cartRepository) UpsertCart(ctx context.Context, req *requests.CartCreateRecord) (*record.CartRecord, error) {
+	res, err := r.db.UpsertCart(ctx, db.UpsertCartParams{
 		UserID:    int32(req.UserID),
 		ProductID: int32(req.ProductID),
 		Name:      req.Name,
-		Price:     int32(req.Price),
+		Price:     req.Price,
 		Image:     req.ImageProduct,
 		Quantity:  int32(req.Quantity),
 		Weight:    int32(req.Weight),
 	})
 
 	if err != nil {
-		return nil, errors.New("failed to create cart")
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("product %d: %w", req.ProductID, ErrInsufficientStock)
+		}
+
+		return nil, fmt.Errorf("failed to upsert cart: %w", err)
+	}
+
+	return r.mapping.ToCartRecord(res), nil
+}
+
+func (r *cartRepository) UpdateQuantity(ctx context.Context, req *requests.UpdateCartQuantityRequest) (*record.CartRecord, error) {
+	res, err := r.db.UpdateCartQuantity(ctx, db.UpdateCartQuantityParams{
+		CartID:   int32(req.CartID),
+		Quantity: int32(req.Quantity),
+		UserID:   int32(req.UserID),
+	})
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("cart %d: %w", req.CartID, ErrInsufficientStock)
+		}
+
+		return nil, fmt.Errorf("failed to update cart quantity: %w", err)
 	}
 
 	return r.mapping.ToCartRecord(res), nil
 }
 
-func (r *cartRepository) DeletePermanent(cart_id int) (bool, error) {
-	err := r.db.DeleteCart(r.ctx, int32(cart_id))
+func (r *cartRepository) DeletePermanent(ctx context.Context, user_id int, cart_id int) (bool, error) {
+	err := r.db.DeleteCart(ctx, db.DeleteCartParams{
+		CartID: int32(cart_id),
+		UserID: int32(user_id),
+	})
 
 	if err != nil {
 		return false, fmt.Errorf("failed to delete cart: %w", err)
@@ -83,13 +139,16 @@ func (r *cartRepository) DeletePermanent(cart_id int) (bool, error) {
 	return true, nil
 }
 
-func (r *cartRepository) DeleteAllPermanently(req *requests.DeleteCartRequest) (bool, error) {
+func (r *cartRepository) DeleteAllPermanently(ctx context.Context, req *requests.DeleteCartRequest) (bool, error) {
 	cartIDs := make([]int32, len(req.CartIds))
 	for i, id := range req.CartIds {
 		cartIDs[i] = int32(id)
 	}
 
-	err := r.db.DeleteAllCart(r.ctx, cartIDs)
+	err := r.db.DeleteAllCart(ctx, db.DeleteAllCartParams{
+		CartIds: cartIDs,
+		UserID:  int32(req.UserID),
+	})
 
 	if err != nil {
 		return false, fmt.Errorf("failed to delete carts: %w", err)
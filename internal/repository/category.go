@@ -12,19 +12,17 @@ import (
 
 type categoryRepository struct {
 	db      *db.Queries
-	ctx     context.Context
 	mapping recordmapper.CategoryRecordMapper
 }
 
-func NewCategoryRepository(db *db.Queries, ctx context.Context, mapping recordmapper.CategoryRecordMapper) *categoryRepository {
+func NewCategoryRepository(db *db.Queries, mapping recordmapper.CategoryRecordMapper) *categoryRepository {
 	return &categoryRepository{
 		db:      db,
-		ctx:     ctx,
 		mapping: mapping,
 	}
 }
 
-func (r *categoryRepository) FindAllCategory(search string, page, pageSize int) ([]*record.CategoriesRecord, int, error) {
+func (r *categoryRepository) FindAllCategory(ctx context.Context, search string, page, pageSize int) ([]*record.CategoriesRecord, int, error) {
 	offset := (page - 1) * pageSize
 
 	req := db.GetCategoriesParams{
@@ -33,7 +31,7 @@ func (r *categoryRepository) FindAllCategory(search string, page, pageSize int)
 		Offset:  int32(offset),
 	}
 
-	res, err := r.db.GetCategories(r.ctx, req)
+	res, err := r.db.GetCategories(ctx, req)
 
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to find categories: %w", err)
@@ -49,9 +47,9 @@ func (r *categoryRepository) FindAllCategory(search string, page, pageSize int)
 	return r.mapping.ToCategoriesRecordPagination(res), totalCount, nil
 }
 
-func (r *categoryRepository) FindById(category_id int) (*record.CategoriesRecord, error) {
+func (r *categoryRepository) FindById(ctx context.Context, category_id int) (*record.CategoriesRecord, error) {
 	fmt.Printf("Searching for cashier with ID: %d\n", category_id)
-	res, err := r.db.GetCategoryByID(r.ctx, int32(category_id))
+	res, err := r.db.GetCategoryByID(ctx, int32(category_id))
 
 	if err != nil {
 		fmt.Printf("Error fetching cashier: %v\n", err)
@@ -62,7 +60,7 @@ func (r *categoryRepository) FindById(category_id int) (*record.CategoriesRecord
 	return r.mapping.ToCategoryRecord(res), nil
 }
 
-func (r *categoryRepository) FindByActive(search string, page, pageSize int) ([]*record.CategoriesRecord, int, error) {
+func (r *categoryRepository) FindByActive(ctx context.Context, search string, page, pageSize int) ([]*record.CategoriesRecord, int, error) {
 	offset := (page - 1) * pageSize
 
 	req := db.GetCategoriesActiveParams{
@@ -71,7 +69,7 @@ func (r *categoryRepository) FindByActive(search string, page, pageSize int) ([]
 		Offset:  int32(offset),
 	}
 
-	res, err := r.db.GetCategoriesActive(r.ctx, req)
+	res, err := r.db.GetCategoriesActive(ctx, req)
 
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to find cashier: %w", err)
@@ -87,7 +85,7 @@ func (r *categoryRepository) FindByActive(search string, page, pageSize int) ([]
 	return r.mapping.ToCategoriesRecordActivePagination(res), totalCount, nil
 }
 
-func (r *categoryRepository) FindByTrashed(search string, page, pageSize int) ([]*record.CategoriesRecord, int, error) {
+func (r *categoryRepository) FindByTrashed(ctx context.Context, search string, page, pageSize int) ([]*record.CategoriesRecord, int, error) {
 	offset := (page - 1) * pageSize
 
 	req := db.GetCategoriesTrashedParams{
@@ -96,7 +94,7 @@ func (r *categoryRepository) FindByTrashed(search string, page, pageSize int) ([
 		Offset:  int32(offset),
 	}
 
-	res, err := r.db.GetCategoriesTrashed(r.ctx, req)
+	res, err := r.db.GetCategoriesTrashed(ctx, req)
 
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to find cashiers: %w", err)
@@ -112,7 +110,7 @@ func (r *categoryRepository) FindByTrashed(search string, page, pageSize int) ([
 	return r.mapping.ToCategoriesRecordTrashedPagination(res), totalCount, nil
 }
 
-func (r *categoryRepository) CreateCategory(request *requests.CreateCategoryRequest) (*record.CategoriesRecord, error) {
+func (r *categoryRepository) CreateCategory(ctx context.Context, request *requests.CreateCategoryRequest) (*record.CategoriesRecord, error) {
 	req := db.CreateCategoryParams{
 		Name: request.Name,
 		Description: sql.NullString{
@@ -126,7 +124,7 @@ func (r *categoryRepository) CreateCategory(request *requests.CreateCategoryRequ
 		},
 	}
 
-	category, err := r.db.CreateCategory(r.ctx, req)
+	category, err := r.db.CreateCategory(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create category: %w", err)
 	}
@@ -134,7 +132,7 @@ func (r *categoryRepository) CreateCategory(request *requests.CreateCategoryRequ
 	return r.mapping.ToCategoryRecord(category), nil
 }
 
-func (r *categoryRepository) UpdateCategory(request *requests.UpdateCategoryRequest) (*record.CategoriesRecord, error) {
+func (r *categoryRepository) UpdateCategory(ctx context.Context, request *requests.UpdateCategoryRequest) (*record.CategoriesRecord, error) {
 	req := db.UpdateCategoryParams{
 		CategoryID: int32(request.CategoryID),
 		Name:       request.Name,
@@ -149,7 +147,7 @@ func (r *categoryRepository) UpdateCategory(request *requests.UpdateCategoryRequ
 		},
 	}
 
-	res, err := r.db.UpdateCategory(r.ctx, req)
+	res, err := r.db.UpdateCategory(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update category: %w", err)
 	}
@@ -157,8 +155,8 @@ func (r *categoryRepository) UpdateCategory(request *requests.UpdateCategoryRequ
 	return r.mapping.ToCategoryRecord(res), nil
 }
 
-func (r *categoryRepository) TrashedCategory(category_id int) (*record.CategoriesRecord, error) {
-	res, err := r.db.TrashCategory(r.ctx, int32(category_id))
+func (r *categoryRepository) TrashedCategory(ctx context.Context, category_id int) (*record.CategoriesRecord, error) {
+	res, err := r.db.TrashCategory(ctx, int32(category_id))
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to trash category: %w", err)
@@ -167,8 +165,8 @@ func (r *categoryRepository) TrashedCategory(category_id int) (*record.Categorie
 	return r.mapping.ToCategoryRecord(res), nil
 }
 
-func (r *categoryRepository) RestoreCategory(category_id int) (*record.CategoriesRecord, error) {
-	res, err := r.db.RestoreCategory(r.ctx, int32(category_id))
+func (r *categoryRepository) RestoreCategory(ctx context.Context, category_id int) (*record.CategoriesRecord, error) {
+	res, err := r.db.RestoreCategory(ctx, int32(category_id))
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to restore category: %w", err)
@@ -177,8 +175,8 @@ func (r *categoryRepository) RestoreCategory(category_id int) (*record.Categorie
 	return r.mapping.ToCategoryRecord(res), nil
 }
 
-func (r *categoryRepository) DeleteCategoryPermanently(category_id int) (bool, error) {
-	err := r.db.DeleteCategoryPermanently(r.ctx, int32(category_id))
+func (r *categoryRepository) DeleteCategoryPermanently(ctx context.Context, category_id int) (bool, error) {
+	err := r.db.DeleteCategoryPermanently(ctx, int32(category_id))
 
 	if err != nil {
 		return false, fmt.Errorf("failed to delete category: %w", err)
@@ -187,8 +185,8 @@ func (r *categoryRepository) DeleteCategoryPermanently(category_id int) (bool, e
 	return true, nil
 }
 
-func (r *categoryRepository) RestoreAllCategories() (bool, error) {
-	err := r.db.RestoreAllCategories(r.ctx)
+func (r *categoryRepository) RestoreAllCategories(ctx context.Context) (bool, error) {
+	err := r.db.RestoreAllCategories(ctx)
 
 	if err != nil {
 		return false, fmt.Errorf("failed to restore all category: %w", err)
@@ -196,8 +194,8 @@ func (r *categoryRepository) RestoreAllCategories() (bool, error) {
 	return true, nil
 }
 
-func (r *categoryRepository) DeleteAllPermanentCategories() (bool, error) {
-	err := r.db.DeleteAllPermanentCategories(r.ctx)
+func (r *categoryRepository) DeleteAllPermanentCategories(ctx context.Context) (bool, error) {
+	err := r.db.DeleteAllPermanentCategories(ctx)
 
 	if err != nil {
 		return false, fmt.Errorf("failed to delete all category permanently: %w", err)
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"ecommerce/internal/domain/record"
+	"ecommerce/internal/domain/requests"
+	recordmapper "ecommerce/internal/mapper/record"
+	db "ecommerce/pkg/database/schema"
+	"errors"
+	"fmt"
+)
+
+var ErrIdempotencyKeyClaimed = errors.New("idempotency key already claimed")
+
+type idempotencyKeyRepository struct {
+	db      *db.Queries
+	mapping recordmapper.IdempotencyKeyRecordMapping
+}
+
+func NewIdempotencyKeyRepository(db *db.Queries, mapping recordmapper.IdempotencyKeyRecordMapping) *idempotencyKeyRepository {
+	return &idempotencyKeyRepository{
+		db:      db,
+		mapping: mapping,
+	}
+}
+
+func (r *idempotencyKeyRepository) Claim(ctx context.Context, request *requests.ClaimIdempotencyKeyRequest) (*record.IdempotencyKeyRecord, error) {
+	res, err := r.db.ClaimIdempotencyKey(ctx, db.ClaimIdempotencyKeyParams{
+		UserID:         int32(request.UserID),
+		Method:         request.Method,
+		IdempotencyKey: request.Key,
+		RequestHash:    request.RequestHash,
+		LockedAt:       request.LockedAt,
+		StaleBefore:    request.StaleBefore,
+		ExpiredBefore:  request.ExpiredBefore,
+	})
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrIdempotencyKeyClaimed
+		}
+
+		return nil, fmt.Errorf("failed to claim idempotency key: %w", err)
+	}
+
+	return r.mapping.ToIdempotencyKeyRecord(res), nil
+}
+
+func (r *idempotencyKeyRepository) FindByKey(ctx context.Context, user_id int, method string, key string) (*record.IdempotencyKeyRecord, error) {
+	res, err := r.db.GetIdempotencyKey(ctx, db.GetIdempotencyKeyParams{
+		UserID:         int32(user_id),
+		Method:         method,
+		IdempotencyKey: key,
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to find idempotency key: %w", err)
+	}
+
+	return r.mapping.ToIdempotencyKeyRecord(res), nil
+}
+
+func (r *idempotencyKeyRepository) Complete(ctx context.Context, idempotency_key_id int, response []byte) error {
+	err := r.db.CompleteIdempotencyKey(ctx, db.CompleteIdempotencyKeyParams{
+		IdempotencyKeyID: int32(idempotency_key_id),
+		Response:         response,
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to complete idempotency key: %w", err)
+	}
+
+	return nil
+}
+
+func (r *idempotencyKeyRepository) Fail(ctx context.Context, idempotency_key_id int, response []byte) error {
+	err := r.db.FailIdempotencyKey(ctx, db.FailIdempotencyKeyParams{
+		IdempotencyKeyID: int32(idempotency_key_id),
+		Response:         response,
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to store idempotency key failure: %w", err)
+	}
+
+	return nil
+}
+
+func (r *idempotencyKeyRepository) Release(ctx context.Context, idempotency_key_id int) error {
+	if err := r.db.ReleaseIdempotencyKey(ctx, int32(idempotency_key_id)); err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+
+	return nil
+}
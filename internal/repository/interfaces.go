@@ -1,195 +1,263 @@
 package repository
 
 import (
+	"context"
 	"ecommerce/internal/domain/record"
 	"ecommerce/internal/domain/requests"
+	"ecommerce/pkg/pagination"
+	"time"
 )
 
 type UserRepository interface {
-	FindAllUsers(search string, page, pageSize int) ([]*record.UserRecord, int, error)
-	FindById(user_id int) (*record.UserRecord, error)
-	FindByEmail(email string) (*record.UserRecord, error)
-	FindByActive(search string, page, pageSize int) ([]*record.UserRecord, int, error)
-	FindByTrashed(search string, page, pageSize int) ([]*record.UserRecord, int, error)
-	CreateUser(request *requests.CreateUserRequest) (*record.UserRecord, error)
-	UpdateUser(request *requests.UpdateUserRequest) (*record.UserRecord, error)
-	TrashedUser(user_id int) (*record.UserRecord, error)
-	RestoreUser(user_id int) (*record.UserRecord, error)
-	DeleteUserPermanent(user_id int) (bool, error)
-	RestoreAllUser() (bool, error)
-	DeleteAllUserPermanent() (bool, error)
+	FindAllUsers(ctx context.Context, search string, page, pageSize int) ([]*record.UserRecord, int, error)
+	FindById(ctx context.Context, user_id int) (*record.UserRecord, error)
+	FindByEmail(ctx context.Context, email string) (*record.UserRecord, error)
+	FindByActive(ctx context.Context, search string, page, pageSize int) ([]*record.UserRecord, int, error)
+	FindByTrashed(ctx context.Context, search string, page, pageSize int) ([]*record.UserRecord, int, error)
+	CreateUser(ctx context.Context, request *requests.CreateUserRequest) (*record.UserRecord, error)
+	UpdateUser(ctx context.Context, request *requests.UpdateUserRequest) (*record.UserRecord, error)
+	TrashedUser(ctx context.Context, user_id int) (*record.UserRecord, error)
+	RestoreUser(ctx context.Context, user_id int) (*record.UserRecord, error)
+	DeleteUserPermanent(ctx context.Context, user_id int) (bool, error)
+	RestoreAllUser(ctx context.Context) (bool, error)
+	DeleteAllUserPermanent(ctx context.Context) (bool, error)
+	UpdatePassword(ctx context.Context, user_id int, password string) (*record.UserRecord, error)
+	VerifyEmail(ctx context.Context, user_id int) (*record.UserRecord, error)
 }
 
 type RoleRepository interface {
-	FindAllRoles(page int, pageSize int, search string) ([]*record.RoleRecord, int, error)
-	FindById(role_id int) (*record.RoleRecord, error)
-	FindByName(name string) (*record.RoleRecord, error)
-	FindByUserId(user_id int) ([]*record.RoleRecord, error)
-	FindByActiveRole(page int, pageSize int, search string) ([]*record.RoleRecord, int, error)
-	FindByTrashedRole(page int, pageSize int, search string) ([]*record.RoleRecord, int, error)
-	CreateRole(request *requests.CreateRoleRequest) (*record.RoleRecord, error)
-	UpdateRole(request *requests.UpdateRoleRequest) (*record.RoleRecord, error)
-	TrashedRole(role_id int) (*record.RoleRecord, error)
-
-	RestoreRole(role_id int) (*record.RoleRecord, error)
-	DeleteRolePermanent(role_id int) (bool, error)
-	RestoreAllRole() (bool, error)
-	DeleteAllRolePermanent() (bool, error)
+	FindAllRoles(ctx context.Context, page int, pageSize int, search string) ([]*record.RoleRecord, int, error)
+	FindById(ctx context.Context, role_id int) (*record.RoleRecord, error)
+	FindByName(ctx context.Context, name string) (*record.RoleRecord, error)
+	FindByUserId(ctx context.Context, user_id int) ([]*record.RoleRecord, error)
+	FindByActiveRole(ctx context.Context, page int, pageSize int, search string) ([]*record.RoleRecord, int, error)
+	FindByTrashedRole(ctx context.Context, page int, pageSize int, search string) ([]*record.RoleRecord, int, error)
+	CreateRole(ctx context.Context, request *requests.CreateRoleRequest) (*record.RoleRecord, error)
+	UpdateRole(ctx context.Context, request *requests.UpdateRoleRequest) (*record.RoleRecord, error)
+	TrashedRole(ctx context.Context, role_id int) (*record.RoleRecord, error)
+
+	RestoreRole(ctx context.Context, role_id int) (*record.RoleRecord, error)
+	DeleteRolePermanent(ctx context.Context, role_id int) (bool, error)
+	RestoreAllRole(ctx context.Context) (bool, error)
+	DeleteAllRolePermanent(ctx context.Context) (bool, error)
 }
 
 type RefreshTokenRepository interface {
-	FindByToken(token string) (*record.RefreshTokenRecord, error)
-	FindByUserId(user_id int) (*record.RefreshTokenRecord, error)
-	CreateRefreshToken(req *requests.CreateRefreshToken) (*record.RefreshTokenRecord, error)
-	UpdateRefreshToken(req *requests.UpdateRefreshToken) (*record.RefreshTokenRecord, error)
-	DeleteRefreshToken(token string) error
-	DeleteRefreshTokenByUserId(user_id int) error
+	FindByToken(ctx context.Context, token string) (*record.RefreshTokenRecord, error)
+	FindActiveByUserId(ctx context.Context, user_id int) ([]*record.RefreshTokenRecord, error)
+	CreateRefreshToken(ctx context.Context, req *requests.CreateRefreshToken) (*record.RefreshTokenRecord, error)
+	MarkUsed(ctx context.Context, refresh_token_id int) (*record.RefreshTokenRecord, error)
+	RevokeFamily(ctx context.Context, family_id string) error
+	RevokeUserFamily(ctx context.Context, user_id int, family_id string) error
+	RevokeByUserId(ctx context.Context, user_id int) error
 }
 
 type UserRoleRepository interface {
-	AssignRoleToUser(req *requests.CreateUserRoleRequest) (*record.UserRoleRecord, error)
-	RemoveRoleFromUser(req *requests.RemoveUserRoleRequest) error
+	AssignRoleToUser(ctx context.Context, req *requests.CreateUserRoleRequest) (*record.UserRoleRecord, error)
+	RemoveRoleFromUser(ctx context.Context, req *requests.RemoveUserRoleRequest) error
 }
 
 type CategoryRepository interface {
-	FindAllCategory(search string, page, pageSize int) ([]*record.CategoriesRecord, int, error)
-	FindById(category_id int) (*record.CategoriesRecord, error)
-	FindByActive(search string, page, pageSize int) ([]*record.CategoriesRecord, int, error)
-	FindByTrashed(search string, page, pageSize int) ([]*record.CategoriesRecord, int, error)
-	CreateCategory(request *requests.CreateCategoryRequest) (*record.CategoriesRecord, error)
-	UpdateCategory(request *requests.UpdateCategoryRequest) (*record.CategoriesRecord, error)
-	TrashedCategory(category_id int) (*record.CategoriesRecord, error)
-	RestoreCategory(category_id int) (*record.CategoriesRecord, error)
-	DeleteCategoryPermanently(Category_id int) (bool, error)
-	RestoreAllCategories() (bool, error)
-	DeleteAllPermanentCategories() (bool, error)
+	FindAllCategory(ctx context.Context, search string, page, pageSize int) ([]*record.CategoriesRecord, int, error)
+	FindById(ctx context.Context, category_id int) (*record.CategoriesRecord, error)
+	FindByActive(ctx context.Context, search string, page, pageSize int) ([]*record.CategoriesRecord, int, error)
+	FindByTrashed(ctx context.Context, search string, page, pageSize int) ([]*record.CategoriesRecord, int, error)
+	CreateCategory(ctx context.Context, request *requests.CreateCategoryRequest) (*record.CategoriesRecord, error)
+	UpdateCategory(ctx context.Context, request *requests.UpdateCategoryRequest) (*record.CategoriesRecord, error)
+	TrashedCategory(ctx context.Context, category_id int) (*record.CategoriesRecord, error)
+	RestoreCategory(ctx context.Context, category_id int) (*record.CategoriesRecord, error)
+	DeleteCategoryPermanently(ctx context.Context, Category_id int) (bool, error)
+	RestoreAllCategories(ctx context.Context) (bool, error)
+	DeleteAllPermanentCategories(ctx context.Context) (bool, error)
 }
 
 type MerchantRepository interface {
-	FindAllMerchants(search string, page, pageSize int) ([]*record.MerchantRecord, int, error)
-	FindByActive(search string, page, pageSize int) ([]*record.MerchantRecord, int, error)
-	FindByTrashed(search string, page, pageSize int) ([]*record.MerchantRecord, int, error)
-	FindById(user_id int) (*record.MerchantRecord, error)
-	CreateMerchant(request *requests.CreateMerchantRequest) (*record.MerchantRecord, error)
-	UpdateMerchant(request *requests.UpdateMerchantRequest) (*record.MerchantRecord, error)
-	TrashedMerchant(merchant_id int) (*record.MerchantRecord, error)
-	RestoreMerchant(merchant_id int) (*record.MerchantRecord, error)
-	DeleteMerchantPermanent(Merchant_id int) (bool, error)
-	RestoreAllMerchant() (bool, error)
-	DeleteAllMerchantPermanent() (bool, error)
+	FindAllMerchants(ctx context.Context, search string, page, pageSize int) ([]*record.MerchantRecord, int, error)
+	FindByActive(ctx context.Context, search string, page, pageSize int) ([]*record.MerchantRecord, int, error)
+	FindByTrashed(ctx context.Context, search string, page, pageSize int) ([]*record.MerchantRecord, int, error)
+	FindById(ctx context.Context, user_id int) (*record.MerchantRecord, error)
+	FindByUser(ctx context.Context, user_id int) ([]*record.MerchantRecord, error)
+	CreateMerchant(ctx context.Context, request *requests.CreateMerchantRequest) (*record.MerchantRecord, error)
+	UpdateMerchant(ctx context.Context, request *requests.UpdateMerchantRequest) (*record.MerchantRecord, error)
+	TrashedMerchant(ctx context.Context, merchant_id int) (*record.MerchantRecord, error)
+	RestoreMerchant(ctx context.Context, merchant_id int) (*record.MerchantRecord, error)
+	DeleteMerchantPermanent(ctx context.Context, Merchant_id int) (bool, error)
+	RestoreAllMerchant(ctx context.Context) (bool, error)
+	DeleteAllMerchantPermanent(ctx context.Context) (bool, error)
 }
 
 type OrderRepository interface {
-	FindAllOrders(search string, page, pageSize int) ([]*record.OrderRecord, int, error)
-	FindByActive(search string, page, pageSize int) ([]*record.OrderRecord, int, error)
-	FindByTrashed(search string, page, pageSize int) ([]*record.OrderRecord, int, error)
-	FindByMerchant(merchant_id int, search string, page, pageSize int) ([]*record.OrderRecord, int, error)
-	FindById(order_id int) (*record.OrderRecord, error)
-	CreateOrder(request *requests.CreateOrderRecordRequest) (*record.OrderRecord, error)
-	UpdateOrder(request *requests.UpdateOrderRecordRequest) (*record.OrderRecord, error)
-	TrashedOrder(order_id int) (*record.OrderRecord, error)
-	RestoreOrder(order_id int) (*record.OrderRecord, error)
-	DeleteOrderPermanent(order_id int) (bool, error)
-	RestoreAllOrder() (bool, error)
-	DeleteAllOrderPermanent() (bool, error)
+	FindAllOrders(ctx context.Context, search string, page, pageSize int) ([]*record.OrderRecord, int, error)
+	FindAllOrdersCursor(ctx context.Context, search string, cursor *pagination.Cursor, limit int) ([]*record.OrderRecord, *pagination.Cursor, error)
+	FindByActive(ctx context.Context, search string, page, pageSize int) ([]*record.OrderRecord, int, error)
+	FindByTrashed(ctx context.Context, search string, page, pageSize int) ([]*record.OrderRecord, int, error)
+	FindByMerchant(ctx context.Context, merchant_id int, search string, page, pageSize int) ([]*record.OrderRecord, int, error)
+	FindById(ctx context.Context, order_id int) (*record.OrderRecord, error)
+	FindByIdForUpdate(ctx context.Context, order_id int) (*record.OrderRecord, error)
+	CreateOrder(ctx context.Context, request *requests.CreateOrderRecordRequest) (*record.OrderRecord, error)
+	UpdateOrder(ctx context.Context, request *requests.UpdateOrderRecordRequest) (*record.OrderRecord, error)
+	RecalculateTotal(ctx context.Context, order_id int, tax_rate int) (*record.OrderRecord, error)
+	UpdateOrderStatus(ctx context.Context, order_id int, current_status string, status string) (*record.OrderRecord, error)
+	CreateOrderStatusHistory(ctx context.Context, request *requests.CreateOrderStatusHistoryRecordRequest) (*record.OrderStatusHistoryRecord, error)
+	FindStatusHistory(ctx context.Context, order_id int) ([]*record.OrderStatusHistoryRecord, error)
+	TrashedOrder(ctx context.Context, order_id int) (*record.OrderRecord, error)
+	RestoreOrder(ctx context.Context, order_id int) (*record.OrderRecord, error)
+	DeleteOrderPermanent(ctx context.Context, order_id int) (bool, error)
+	RestoreAllOrder(ctx context.Context) (bool, error)
+	DeleteAllOrderPermanent(ctx context.Context) (bool, error)
 }
 
 type OrderItemRepository interface {
-	FindAllOrderItems(search string, page, pageSize int) ([]*record.OrderItemRecord, int, error)
-	FindByActive(search string, page, pageSize int) ([]*record.OrderItemRecord, int, error)
-	FindByTrashed(search string, page, pageSize int) ([]*record.OrderItemRecord, int, error)
-	FindOrderItemByOrder(order_id int) ([]*record.OrderItemRecord, error)
-	CalculateTotalPrice(order_id int) (*int32, error)
-	CreateOrderItem(req *requests.CreateOrderItemRecordRequest) (*record.OrderItemRecord, error)
-	UpdateOrderItem(req *requests.UpdateOrderItemRecordRequest) (*record.OrderItemRecord, error)
-	TrashedOrderItem(order_id int) (*record.OrderItemRecord, error)
-	RestoreOrderItem(order_id int) (*record.OrderItemRecord, error)
-	DeleteOrderItemPermanent(order_id int) (bool, error)
-	RestoreAllOrderItem() (bool, error)
-	DeleteAllOrderPermanent() (bool, error)
+	FindAllOrderItems(ctx context.Context, search string, page, pageSize int) ([]*record.OrderItemRecord, int, error)
+	FindByActive(ctx context.Context, search string, page, pageSize int) ([]*record.OrderItemRecord, int, error)
+	FindByTrashed(ctx context.Context, search string, page, pageSize int) ([]*record.OrderItemRecord, int, error)
+	FindOrderItemByOrder(ctx context.Context, order_id int) ([]*record.OrderItemRecord, error)
+	CreateOrderItem(ctx context.Context, req *requests.CreateOrderItemRecordRequest) (*record.OrderItemRecord, error)
+	UpdateOrderItem(ctx context.Context, req *requests.UpdateOrderItemRecordRequest) (*record.OrderItemRecord, error)
+	TrashedOrderItem(ctx context.Context, order_id int) (*record.OrderItemRecord, error)
+	RestoreOrderItem(ctx context.Context, order_id int) (*record.OrderItemRecord, error)
+	DeleteOrderItemPermanent(ctx context.Context, order_id int) (bool, error)
+	RestoreAllOrderItem(ctx context.Context) (bool, error)
+	DeleteAllOrderPermanent(ctx context.Context) (bool, error)
 }
 
 type ProductRepository interface {
-	FindAllProducts(search string, page, pageSize int) ([]*record.ProductRecord, int, error)
-	FindByActive(search string, page, pageSize int) ([]*record.ProductRecord, int, error)
-	FindByTrashed(search string, page, pageSize int) ([]*record.ProductRecord, int, error)
-	FindByMerchant(merchant_id int, search string, page, pageSize int) ([]*record.ProductRecord, int, error)
+	FindAllProducts(ctx context.Context, search string, filter *requests.ProductFilter, page, pageSize int) ([]*record.ProductRecord, int, error)
+	FindAllProductsCursor(ctx context.Context, search string, cursor *pagination.Cursor, limit int) ([]*record.ProductRecord, *pagination.Cursor, error)
+	FindByActive(ctx context.Context, search string, page, pageSize int) ([]*record.ProductRecord, int, error)
+	FindByTrashed(ctx context.Context, search string, page, pageSize int) ([]*record.ProductRecord, int, error)
+	FindByMerchant(ctx context.Context, merchant_id int, search string, filter *requests.ProductFilter, page, pageSize int) ([]*record.ProductRecord, int, error)
+	FindByUser(ctx context.Context, user_id int, search string, page, pageSize int) ([]*record.ProductRecord, int, error)
 
-	FindByCategory(category_name string, search string, page, pageSize int) ([]*record.ProductRecord, int, error)
+	FindByCategory(ctx context.Context, category_name string, search string, filter *requests.ProductFilter, page, pageSize int) ([]*record.ProductRecord, int, error)
+	SearchProducts(ctx context.Context, request *requests.SearchProductRequest) ([]*record.ProductRecord, int, error)
+	SearchProductFacets(ctx context.Context, request *requests.SearchProductRequest, priceBounds []int64) ([]*record.ProductFacetRecord, error)
 
-	FindById(user_id int) (*record.ProductRecord, error)
-	CreateProduct(request *requests.CreateProductRequest) (*record.ProductRecord, error)
-	UpdateProduct(request *requests.UpdateProductRequest) (*record.ProductRecord, error)
-	UpdateProductCountStock(product_id int, stock int) (*record.ProductRecord, error)
-	TrashedProduct(user_id int) (*record.ProductRecord, error)
-	RestoreProduct(user_id int) (*record.ProductRecord, error)
-	DeleteProductPermanent(user_id int) (bool, error)
-	RestoreAllProducts() (bool, error)
-	DeleteAllProductPermanent() (bool, error)
+	FindById(ctx context.Context, user_id int) (*record.ProductRecord, error)
+	CreateProduct(ctx context.Context, request *requests.CreateProductRequest) (*record.ProductRecord, error)
+	UpdateProduct(ctx context.Context, request *requests.UpdateProductRequest) (*record.ProductRecord, error)
+	UpdateProductCountStock(ctx context.Context, product_id int, stock int) (*record.ProductRecord, error)
+	ReserveProductStock(ctx context.Context, product_id int, quantity int) (*record.ProductRecord, error)
+	ReleaseProductStock(ctx context.Context, product_id int, quantity int) (*record.ProductRecord, error)
+	TrashedProduct(ctx context.Context, user_id int) (*record.ProductRecord, error)
+	RestoreProduct(ctx context.Context, user_id int) (*record.ProductRecord, error)
+	DeleteProductPermanent(ctx context.Context, user_id int) (bool, error)
+	RestoreAllProducts(ctx context.Context) (bool, error)
+	DeleteAllProductPermanent(ctx context.Context) (bool, error)
 }
 
 type TransactionRepository interface {
-	FindAllTransactions(search string, page, pageSize int) ([]*record.TransactionRecord, int, error)
-	FindByActive(search string, page, pageSize int) ([]*record.TransactionRecord, int, error)
-	FindByTrashed(search string, page, pageSize int) ([]*record.TransactionRecord, int, error)
-	FindByMerchant(merchant_id int, search string, page, pageSize int) ([]*record.TransactionRecord, int, error)
-	FindById(transaction_id int) (*record.TransactionRecord, error)
-	FindByOrderId(order_id int) (*record.TransactionRecord, error)
-	CreateTransaction(request *requests.CreateTransactionRequest) (*record.TransactionRecord, error)
-	UpdateTransaction(request *requests.UpdateTransactionRequest) (*record.TransactionRecord, error)
-	TrashTransaction(transaction_id int) (*record.TransactionRecord, error)
-	RestoreTransaction(transaction_id int) (*record.TransactionRecord, error)
-	DeleteTransactionPermanently(transaction_id int) (bool, error)
-	RestoreAllTransactions() (bool, error)
-	DeleteAllTransactionPermanent() (bool, error)
+	FindAllTransactions(ctx context.Context, search string, page, pageSize int) ([]*record.TransactionRecord, int, error)
+	FindAllTransactionsCursor(ctx context.Context, search string, cursor *pagination.Cursor, limit int) ([]*record.TransactionRecord, *pagination.Cursor, error)
+	FindByActive(ctx context.Context, search string, page, pageSize int) ([]*record.TransactionRecord, int, error)
+	FindByTrashed(ctx context.Context, search string, page, pageSize int) ([]*record.TransactionRecord, int, error)
+	FindByMerchant(ctx context.Context, merchant_id int, search string, page, pageSize int) ([]*record.TransactionRecord, int, error)
+	FindById(ctx context.Context, transaction_id int) (*record.TransactionRecord, error)
+	FindByOrderId(ctx context.Context, order_id int) (*record.TransactionRecord, error)
+	HasLiveTransaction(ctx context.Context, order_id int) (bool, error)
+	FindByIdForUpdate(ctx context.Context, transaction_id int) (*record.TransactionRecord, error)
+	FindByProviderChargeID(ctx context.Context, provider string, charge_id string) (*record.TransactionRecord, error)
+	FindRefundedQuantities(ctx context.Context, transaction_id int) (map[int]int, error)
+	FindPendingRefunds(ctx context.Context, transaction_id int) ([]*record.RefundRecord, error)
+	FindRefundItems(ctx context.Context, refund_id int) ([]*record.RefundItemRecord, error)
+	CreateTransaction(ctx context.Context, request *requests.CreateTransactionRequest) (*record.TransactionRecord, error)
+	UpdateTransaction(ctx context.Context, request *requests.UpdateTransactionRequest) (*record.TransactionRecord, error)
+	UpdateTransactionPayment(ctx context.Context, request *requests.UpdateTransactionPaymentRequest) (*record.TransactionRecord, error)
+	CreatePaymentEvent(ctx context.Context, request *requests.CreatePaymentEventRequest) (*record.PaymentEventRecord, error)
+	CreateRefund(ctx context.Context, request *requests.CreateRefundRequest) (*record.RefundRecord, error)
+	UpdateRefundStatus(ctx context.Context, request *requests.UpdateRefundStatusRequest) (*record.RefundRecord, error)
+	CreateRefundItem(ctx context.Context, request *requests.CreateRefundItemRequest) (*record.RefundItemRecord, error)
+	UpdateRefundedAmount(ctx context.Context, transaction_id int) (*record.TransactionRecord, error)
+	TrashTransaction(ctx context.Context, transaction_id int) (*record.TransactionRecord, error)
+	RestoreTransaction(ctx context.Context, transaction_id int) (*record.TransactionRecord, error)
+	DeleteTransactionPermanently(ctx context.Context, transaction_id int) (bool, error)
+	RestoreAllTransactions(ctx context.Context) (bool, error)
+	DeleteAllTransactionPermanent(ctx context.Context) (bool, error)
 }
 
 type CartRepository interface {
-	FindCarts(user_id int, search string, page, pageSize int) ([]*record.CartRecord, int, error)
-	CreateCart(req *requests.CartCreateRecord) (*record.CartRecord, error)
-	DeletePermanent(cart_id int) (bool, error)
-	DeleteAllPermanently(req *requests.DeleteCartRequest) (bool, error)
+	FindCarts(ctx context.Context, user_id int, search string, page, pageSize int) ([]*record.CartRecord, int, error)
+	FindCartsForCheckout(ctx context.Context, user_id int, merchant_id int) ([]*record.CartCheckoutRecord, error)
+	FindById(ctx context.Context, cart_id int) (*record.CartRecord, error)
+	FindSummary(ctx context.Context, user_id int) (*record.CartSummaryRecord, error)
+	UpsertCart(ctx context.Context, req *requests.CartCreateRecord) (*record.CartRecord, error)
+	UpdateQuantity(ctx context.Context, req *requests.UpdateCartQuantityRequest) (*record.CartRecord, error)
+	DeletePermanent(ctx context.Context, user_id int, cart_id int) (bool, error)
+	DeleteAllPermanently(ctx context.Context, req *requests.DeleteCartRequest) (bool, error)
 }
 
 type ShippingAddressRepository interface {
-	FindAllShippingAddress(search string, page, pageSize int) ([]*record.ShippingAddressRecord, int, error)
-	FindByOrder(shipping_id int) (*record.ShippingAddressRecord, error)
-	FindById(shipping_id int) (*record.ShippingAddressRecord, error)
-	FindByActive(search string, page, pageSize int) ([]*record.ShippingAddressRecord, int, error)
-	FindByTrashed(search string, page, pageSize int) ([]*record.ShippingAddressRecord, int, error)
-	CreateShippingAddress(request *requests.CreateShippingAddressRequest) (*record.ShippingAddressRecord, error)
-	UpdateShippingAddress(request *requests.UpdateShippingAddressRequest) (*record.ShippingAddressRecord, error)
-	TrashShippingAddress(category_id int) (*record.ShippingAddressRecord, error)
-	RestoreShippingAddress(category_id int) (*record.ShippingAddressRecord, error)
-	DeleteShippingAddressPermanently(category_id int) (bool, error)
-	RestoreAllShippingAddress() (bool, error)
-	DeleteAllPermanentShippingAddress() (bool, error)
+	FindAllShippingAddress(ctx context.Context, search string, page, pageSize int) ([]*record.ShippingAddressRecord, int, error)
+	FindByOrder(ctx context.Context, shipping_id int) (*record.ShippingAddressRecord, error)
+	FindById(ctx context.Context, shipping_id int) (*record.ShippingAddressRecord, error)
+	FindByActive(ctx context.Context, search string, page, pageSize int) ([]*record.ShippingAddressRecord, int, error)
+	FindByTrashed(ctx context.Context, search string, page, pageSize int) ([]*record.ShippingAddressRecord, int, error)
+	CreateShippingAddress(ctx context.Context, request *requests.CreateShippingAddressRequest) (*record.ShippingAddressRecord, error)
+	UpdateShippingAddress(ctx context.Context, request *requests.UpdateShippingAddressRequest) (*record.ShippingAddressRecord, error)
+	TrashShippingAddress(ctx context.Context, category_id int) (*record.ShippingAddressRecord, error)
+	RestoreShippingAddress(ctx context.Context, category_id int) (*record.ShippingAddressRecord, error)
+	DeleteShippingAddressPermanently(ctx context.Context, category_id int) (bool, error)
+	RestoreAllShippingAddress(ctx context.Context) (bool, error)
+	DeleteAllPermanentShippingAddress(ctx context.Context) (bool, error)
 }
 
 type SliderRepository interface {
-	FindAllSlider(search string, page, pageSize int) ([]*record.SliderRecord, int, error)
-	FindByActive(search string, page, pageSize int) ([]*record.SliderRecord, int, error)
-	FindByTrashed(search string, page, pageSize int) ([]*record.SliderRecord, int, error)
-	CreateSlider(request *requests.CreateSliderRequest) (*record.SliderRecord, error)
-	UpdateSlider(request *requests.UpdateSliderRequest) (*record.SliderRecord, error)
-	TrashSlider(slider_id int) (*record.SliderRecord, error)
-	RestoreSlider(slider_id int) (*record.SliderRecord, error)
-	DeleteSliderPermanently(slider_id int) (bool, error)
-	RestoreAllSlider() (bool, error)
-	DeleteAllPermanentSlider() (bool, error)
+	FindAllSlider(ctx context.Context, search string, page, pageSize int) ([]*record.SliderRecord, int, error)
+	FindByActive(ctx context.Context, search string, page, pageSize int) ([]*record.SliderRecord, int, error)
+	FindByTrashed(ctx context.Context, search string, page, pageSize int) ([]*record.SliderRecord, int, error)
+	CreateSlider(ctx context.Context, request *requests.CreateSliderRequest) (*record.SliderRecord, error)
+	UpdateSlider(ctx context.Context, request *requests.UpdateSliderRequest) (*record.SliderRecord, error)
+	TrashSlider(ctx context.Context, slider_id int) (*record.SliderRecord, error)
+	RestoreSlider(ctx context.Context, slider_id int) (*record.SliderRecord, error)
+	DeleteSliderPermanently(ctx context.Context, slider_id int) (bool, error)
+	RestoreAllSlider(ctx context.Context) (bool, error)
+	DeleteAllPermanentSlider(ctx context.Context) (bool, error)
 }
 
 type ReviewRepository interface {
-	FindAllReview(search string, page, pageSize int) ([]*record.ReviewRecord, int, error)
-	FindByProduct(product_id int, search string, page, pageSize int) ([]*record.ReviewRecord, int, error)
-	FindByActive(search string, page, pageSize int) ([]*record.ReviewRecord, int, error)
-	FindByTrashed(search string, page, pageSize int) ([]*record.ReviewRecord, int, error)
-	FindById(id int) (*record.ReviewRecord, error)
-	CreateReview(request *requests.CreateReviewRequest) (*record.ReviewRecord, error)
-	UpdateReview(request *requests.UpdateReviewRequest) (*record.ReviewRecord, error)
-	TrashReview(shipping_id int) (*record.ReviewRecord, error)
-	RestoreReview(category_id int) (*record.ReviewRecord, error)
-	DeleteReviewPermanently(category_id int) (bool, error)
-	RestoreAllReview() (bool, error)
-	DeleteAllPermanentReview() (bool, error)
+	FindAllReview(ctx context.Context, search string, page, pageSize int) ([]*record.ReviewRecord, int, error)
+	FindAllReviewsCursor(ctx context.Context, search string, cursor *pagination.Cursor, limit int) ([]*record.ReviewRecord, *pagination.Cursor, error)
+	FindByProduct(ctx context.Context, product_id int, search string, page, pageSize int) ([]*record.ReviewRecord, int, error)
+	FindByActive(ctx context.Context, search string, page, pageSize int) ([]*record.ReviewRecord, int, error)
+	FindByTrashed(ctx context.Context, search string, page, pageSize int) ([]*record.ReviewRecord, int, error)
+	FindById(ctx context.Context, id int) (*record.ReviewRecord, error)
+	CreateReview(ctx context.Context, request *requests.CreateReviewRequest) (*record.ReviewRecord, error)
+	UpdateReview(ctx context.Context, request *requests.UpdateReviewRequest) (*record.ReviewRecord, error)
+	TrashReview(ctx context.Context, shipping_id int) (*record.ReviewRecord, error)
+	RestoreReview(ctx context.Context, category_id int) (*record.ReviewRecord, error)
+	DeleteReviewPermanently(ctx context.Context, category_id int) (bool, error)
+	RestoreAllReview(ctx context.Context) (bool, error)
+	DeleteAllPermanentReview(ctx context.Context) (bool, error)
+}
+
+type IdempotencyKeyRepository interface {
+	Claim(ctx context.Context, request *requests.ClaimIdempotencyKeyRequest) (*record.IdempotencyKeyRecord, error)
+	FindByKey(ctx context.Context, user_id int, method string, key string) (*record.IdempotencyKeyRecord, error)
+	Complete(ctx context.Context, idempotency_key_id int, response []byte) error
+	Fail(ctx context.Context, idempotency_key_id int, response []byte) error
+	Release(ctx context.Context, idempotency_key_id int) error
+}
+
+type RevokedTokenRepository interface {
+	FindActive(ctx context.Context, now time.Time) ([]*record.RevokedTokenRecord, error)
+	RevokeAccessToken(ctx context.Context, request *requests.RevokeAccessTokenRequest) error
+	RevokeUserAccessTokens(ctx context.Context, request *requests.RevokeUserAccessTokensRequest) error
+	DeleteExpired(ctx context.Context, now time.Time) error
+}
+
+type UserTokenRepository interface {
+	CreateToken(ctx context.Context, request *requests.CreateUserTokenRequest) (*record.UserTokenRecord, error)
+	ConsumeToken(ctx context.Context, token string, purpose string, now time.Time) (*record.UserTokenRecord, error)
+	InvalidateTokens(ctx context.Context, user_id int, purpose string, now time.Time) error
+}
+
+type LoginThrottleRepository interface {
+	FindLocked(ctx context.Context, account string, ip_address string, now time.Time) ([]*record.LoginThrottleRecord, error)
+	RecordFailure(ctx context.Context, request *requests.RecordLoginFailureRequest) (*record.LoginThrottleRecord, error)
+	Lock(ctx context.Context, login_throttle_id int, lockedUntil time.Time) error
+	Reset(ctx context.Context, scope string, subject string) (bool, error)
+}
+
+type LoginAuditLogRepository interface {
+	CreateAuditLog(ctx context.Context, request *requests.CreateLoginAuditLogRequest) error
 }
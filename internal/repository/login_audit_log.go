@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"ecommerce/internal/domain/requests"
+	db "ecommerce/pkg/database/schema"
+	"fmt"
+)
+
+type loginAuditLogRepository struct {
+	db *db.Queries
+}
+
+func NewLoginAuditLogRepository(db *db.Queries) *loginAuditLogRepository {
+	return &loginAuditLogRepository{
+		db: db,
+	}
+}
+
+func (r *loginAuditLogRepository) CreateAuditLog(ctx context.Context, request *requests.CreateLoginAuditLogRequest) error {
+	var lockedUntil sql.NullTime
+
+	if request.LockedUntil != nil {
+		lockedUntil = sql.NullTime{Time: *request.LockedUntil, Valid: true}
+	}
+
+	err := r.db.CreateLoginAuditLog(ctx, db.CreateLoginAuditLogParams{
+		Event:          request.Event,
+		Scope:          request.Scope,
+		Subject:        request.Subject,
+		FailedAttempts: int32(request.FailedAttempts),
+		LockedUntil:    lockedUntil,
+		IpAddress:      sql.NullString{String: request.IPAddress, Valid: request.IPAddress != ""},
+		ActorID:        sql.NullInt32{Int32: int32(request.ActorID), Valid: request.ActorID != 0},
+		CreatedAt:      request.CreatedAt,
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to create login audit log: %w", err)
+	}
+
+	return nil
+}
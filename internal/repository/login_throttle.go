@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"ecommerce/internal/domain/record"
+	"ecommerce/internal/domain/requests"
+	recordmapper "ecommerce/internal/mapper/record"
+	db "ecommerce/pkg/database/schema"
+	"fmt"
+	"time"
+)
+
+type loginThrottleRepository struct {
+	db      *db.Queries
+	mapping recordmapper.LoginThrottleRecordMapping
+}
+
+func NewLoginThrottleRepository(db *db.Queries, mapping recordmapper.LoginThrottleRecordMapping) *loginThrottleRepository {
+	return &loginThrottleRepository{
+		db:      db,
+		mapping: mapping,
+	}
+}
+
+// FindLocked returns the locks still in force at now for the account and the
+// IP address.
+func (r *loginThrottleRepository) FindLocked(ctx context.Context, account string, ip_address string, now time.Time) ([]*record.LoginThrottleRecord, error) {
+	res, err := r.db.FindLockedLoginThrottles(ctx, db.FindLockedLoginThrottlesParams{
+		Account:   account,
+		IpAddress: ip_address,
+		Now:       now,
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to find login locks: %w", err)
+	}
+
+	return r.mapping.ToLoginThrottlesRecord(res), nil
+}
+
+func (r *loginThrottleRepository) RecordFailure(ctx context.Context, request *requests.RecordLoginFailureRequest) (*record.LoginThrottleRecord, error) {
+	res, err := r.db.RecordLoginFailure(ctx, db.RecordLoginFailureParams{
+		Scope:       request.Scope,
+		Subject:     request.Subject,
+		Now:         request.Now,
+		ResetBefore: request.ResetBefore,
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to record login failure: %w", err)
+	}
+
+	return r.mapping.ToLoginThrottleRecord(res), nil
+}
+
+func (r *loginThrottleRepository) Lock(ctx context.Context, login_throttle_id int, lockedUntil time.Time) error {
+	err := r.db.LockLoginThrottle(ctx, db.LockLoginThrottleParams{
+		LoginThrottleID: int32(login_throttle_id),
+		LockedUntil:     sql.NullTime{Time: lockedUntil, Valid: true},
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to lock login: %w", err)
+	}
+
+	return nil
+}
+
+// Reset forgets the failed logins of subject and reports whether there were
+// any.
+func (r *loginThrottleRepository) Reset(ctx context.Context, scope string, subject string) (bool, error) {
+	rows, err := r.db.ResetLoginThrottle(ctx, db.ResetLoginThrottleParams{
+		Scope:   scope,
+		Subject: subject,
+	})
+
+	if err != nil {
+		return false, fmt.Errorf("failed to reset login throttle: %w", err)
+	}
+
+	return rows > 0, nil
+}
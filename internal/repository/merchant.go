@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"ecommerce/internal/domain/money"
 	"ecommerce/internal/domain/record"
 	"ecommerce/internal/domain/requests"
 	recordmapper "ecommerce/internal/mapper/record"
@@ -13,19 +14,17 @@ import (
 
 type merchantRepository struct {
 	db      *db.Queries
-	ctx     context.Context
 	mapping recordmapper.MerchantRecordMapping
 }
 
-func NewMerchantRepository(db *db.Queries, ctx context.Context, mapping recordmapper.MerchantRecordMapping) *merchantRepository {
+func NewMerchantRepository(db *db.Queries, mapping recordmapper.MerchantRecordMapping) *merchantRepository {
 	return &merchantRepository{
 		db:      db,
-		ctx:     ctx,
 		mapping: mapping,
 	}
 }
 
-func (r *merchantRepository) FindAllMerchants(search string, page, pageSize int) ([]*record.MerchantRecord, int, error) {
+func (r *merchantRepository) FindAllMerchants(ctx context.Context, search string, page, pageSize int) ([]*record.MerchantRecord, int, error) {
 	offset := (page - 1) * pageSize
 
 	req := db.GetMerchantsParams{
@@ -34,7 +33,7 @@ func (r *merchantRepository) FindAllMerchants(search string, page, pageSize int)
 		Offset:  int32(offset),
 	}
 
-	res, err := r.db.GetMerchants(r.ctx, req)
+	res, err := r.db.GetMerchants(ctx, req)
 
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to find Merchants: %w", err)
@@ -50,7 +49,7 @@ func (r *merchantRepository) FindAllMerchants(search string, page, pageSize int)
 	return r.mapping.ToMerchantsRecordPagination(res), totalCount, nil
 }
 
-func (r *merchantRepository) FindByActive(search string, page, pageSize int) ([]*record.MerchantRecord, int, error) {
+func (r *merchantRepository) FindByActive(ctx context.Context, search string, page, pageSize int) ([]*record.MerchantRecord, int, error) {
 	offset := (page - 1) * pageSize
 
 	req := db.GetMerchantsActiveParams{
@@ -59,7 +58,7 @@ func (r *merchantRepository) FindByActive(search string, page, pageSize int) ([]
 		Offset:  int32(offset),
 	}
 
-	res, err := r.db.GetMerchantsActive(r.ctx, req)
+	res, err := r.db.GetMerchantsActive(ctx, req)
 
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to find Merchants: %w", err)
@@ -75,7 +74,7 @@ func (r *merchantRepository) FindByActive(search string, page, pageSize int) ([]
 	return r.mapping.ToMerchantsRecordActivePagination(res), totalCount, nil
 }
 
-func (r *merchantRepository) FindByTrashed(search string, page, pageSize int) ([]*record.MerchantRecord, int, error) {
+func (r *merchantRepository) FindByTrashed(ctx context.Context, search string, page, pageSize int) ([]*record.MerchantRecord, int, error) {
 	offset := (page - 1) * pageSize
 
 	req := db.GetMerchantsTrashedParams{
@@ -84,7 +83,7 @@ func (r *merchantRepository) FindByTrashed(search string, page, pageSize int) ([
 		Offset:  int32(offset),
 	}
 
-	res, err := r.db.GetMerchantsTrashed(r.ctx, req)
+	res, err := r.db.GetMerchantsTrashed(ctx, req)
 
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to find Merchants: %w", err)
@@ -100,8 +99,8 @@ func (r *merchantRepository) FindByTrashed(search string, page, pageSize int) ([
 	return r.mapping.ToMerchantsRecordTrashedPagination(res), totalCount, nil
 }
 
-func (r *merchantRepository) FindById(user_id int) (*record.MerchantRecord, error) {
-	res, err := r.db.GetMerchantByID(r.ctx, int32(user_id))
+func (r *merchantRepository) FindById(ctx context.Context, user_id int) (*record.MerchantRecord, error) {
+	res, err := r.db.GetMerchantByID(ctx, int32(user_id))
 
 	if err != nil {
 		fmt.Printf("Error fetching user: %v\n", err)
@@ -112,7 +111,22 @@ func (r *merchantRepository) FindById(user_id int) (*record.MerchantRecord, erro
 	return r.mapping.ToMerchantRecord(res), nil
 }
 
-func (r *merchantRepository) CreateMerchant(request *requests.CreateMerchantRequest) (*record.MerchantRecord, error) {
+func (r *merchantRepository) FindByUser(ctx context.Context, user_id int) ([]*record.MerchantRecord, error) {
+	res, err := r.db.GetMerchantsByUserID(ctx, int32(user_id))
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to find merchants by user: %w", err)
+	}
+
+	return r.mapping.ToMerchantsRecord(res), nil
+}
+
+func (r *merchantRepository) CreateMerchant(ctx context.Context, request *requests.CreateMerchantRequest) (*record.MerchantRecord, error) {
+	currency := request.Currency
+	if currency == "" {
+		currency = money.DefaultCurrency
+	}
+
 	req := db.CreateMerchantParams{
 		UserID:       int32(request.UserID),
 		Name:         request.Name,
@@ -121,9 +135,10 @@ func (r *merchantRepository) CreateMerchant(request *requests.CreateMerchantRequ
 		ContactEmail: sql.NullString{String: request.ContactEmail, Valid: request.ContactEmail != ""},
 		ContactPhone: sql.NullString{String: request.ContactPhone, Valid: request.ContactPhone != ""},
 		Status:       "active",
+		Currency:     currency,
 	}
 
-	merchant, err := r.db.CreateMerchant(r.ctx, req)
+	merchant, err := r.db.CreateMerchant(ctx, req)
 	if err != nil {
 		return nil, errors.New("failed to create Merchant")
 	}
@@ -131,7 +146,7 @@ func (r *merchantRepository) CreateMerchant(request *requests.CreateMerchantRequ
 	return r.mapping.ToMerchantRecord(merchant), nil
 }
 
-func (r *merchantRepository) UpdateMerchant(request *requests.UpdateMerchantRequest) (*record.MerchantRecord, error) {
+func (r *merchantRepository) UpdateMerchant(ctx context.Context, request *requests.UpdateMerchantRequest) (*record.MerchantRecord, error) {
 	req := db.UpdateMerchantParams{
 		MerchantID:   int32(request.MerchantID),
 		Name:         request.Name,
@@ -142,7 +157,7 @@ func (r *merchantRepository) UpdateMerchant(request *requests.UpdateMerchantRequ
 		Status:       request.Status,
 	}
 
-	res, err := r.db.UpdateMerchant(r.ctx, req)
+	res, err := r.db.UpdateMerchant(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update Merchant: %w", err)
 	}
@@ -150,8 +165,8 @@ func (r *merchantRepository) UpdateMerchant(request *requests.UpdateMerchantRequ
 	return r.mapping.ToMerchantRecord(res), nil
 }
 
-func (r *merchantRepository) TrashedMerchant(merchant_id int) (*record.MerchantRecord, error) {
-	res, err := r.db.TrashMerchant(r.ctx, int32(merchant_id))
+func (r *merchantRepository) TrashedMerchant(ctx context.Context, merchant_id int) (*record.MerchantRecord, error) {
+	res, err := r.db.TrashMerchant(ctx, int32(merchant_id))
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to trash Merchant: %w", err)
@@ -160,8 +175,8 @@ func (r *merchantRepository) TrashedMerchant(merchant_id int) (*record.MerchantR
 	return r.mapping.ToMerchantRecord(res), nil
 }
 
-func (r *merchantRepository) RestoreMerchant(merchant_id int) (*record.MerchantRecord, error) {
-	res, err := r.db.RestoreMerchant(r.ctx, int32(merchant_id))
+func (r *merchantRepository) RestoreMerchant(ctx context.Context, merchant_id int) (*record.MerchantRecord, error) {
+	res, err := r.db.RestoreMerchant(ctx, int32(merchant_id))
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to restore Merchants: %w", err)
@@ -170,8 +185,8 @@ func (r *merchantRepository) RestoreMerchant(merchant_id int) (*record.MerchantR
 	return r.mapping.ToMerchantRecord(res), nil
 }
 
-func (r *merchantRepository) DeleteMerchantPermanent(Merchant_id int) (bool, error) {
-	err := r.db.DeleteMerchantPermanently(r.ctx, int32(Merchant_id))
+func (r *merchantRepository) DeleteMerchantPermanent(ctx context.Context, Merchant_id int) (bool, error) {
+	err := r.db.DeleteMerchantPermanently(ctx, int32(Merchant_id))
 
 	if err != nil {
 		return false, fmt.Errorf("failed to delete Merchant: %w", err)
@@ -180,8 +195,8 @@ func (r *merchantRepository) DeleteMerchantPermanent(Merchant_id int) (bool, err
 	return true, nil
 }
 
-func (r *merchantRepository) RestoreAllMerchant() (bool, error) {
-	err := r.db.RestoreAllMerchants(r.ctx)
+func (r *merchantRepository) RestoreAllMerchant(ctx context.Context) (bool, error) {
+	err := r.db.RestoreAllMerchants(ctx)
 
 	if err != nil {
 		return false, fmt.Errorf("failed to restore all Merchants: %w", err)
@@ -189,8 +204,8 @@ func (r *merchantRepository) RestoreAllMerchant() (bool, error) {
 	return true, nil
 }
 
-func (r *merchantRepository) DeleteAllMerchantPermanent() (bool, error) {
-	err := r.db.DeleteAllPermanentMerchants(r.ctx)
+func (r *merchantRepository) DeleteAllMerchantPermanent(ctx context.Context) (bool, error) {
+	err := r.db.DeleteAllPermanentMerchants(ctx)
 
 	if err != nil {
 		return false, fmt.Errorf("failed to delete all Merchants permanently: %w", err)
@@ -2,29 +2,31 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"ecommerce/internal/domain/record"
 	"ecommerce/internal/domain/requests"
 	recordmapper "ecommerce/internal/mapper/record"
 	db "ecommerce/pkg/database/schema"
+	"ecommerce/pkg/pagination"
 	"errors"
 	"fmt"
 )
 
+var ErrOrderStatusConflict = errors.New("order status changed concurrently")
+
 type orderRepository struct {
 	db      *db.Queries
-	ctx     context.Context
 	mapping recordmapper.OrderRecordMapping
 }
 
-func NewOrderRepository(db *db.Queries, ctx context.Context, mapping recordmapper.OrderRecordMapping) *orderRepository {
+func NewOrderRepository(db *db.Queries, mapping recordmapper.OrderRecordMapping) *orderRepository {
 	return &orderRepository{
 		db:      db,
-		ctx:     ctx,
 		mapping: mapping,
 	}
 }
 
-func (r *orderRepository) FindAllOrders(search string, page, pageSize int) ([]*record.OrderRecord, int, error) {
+func (r *orderRepository) FindAllOrders(ctx context.Context, search string, page, pageSize int) ([]*record.OrderRecord, int, error) {
 	offset := (page - 1) * pageSize
 
 	req := db.GetOrdersParams{
@@ -33,7 +35,7 @@ func (r *orderRepository) FindAllOrders(search string, page, pageSize int) ([]*r
 		Offset:  int32(offset),
 	}
 
-	res, err := r.db.GetOrders(r.ctx, req)
+	res, err := r.db.GetOrders(ctx, req)
 
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to find users: %w", err)
@@ -49,7 +51,34 @@ func (r *orderRepository) FindAllOrders(search string, page, pageSize int) ([]*r
 	return r.mapping.ToOrdersRecordPagination(res), totalCount, nil
 }
 
-func (r *orderRepository) FindByActive(search string, page, pageSize int) ([]*record.OrderRecord, int, error) {
+func (r *orderRepository) FindAllOrdersCursor(ctx context.Context, search string, cursor *pagination.Cursor, limit int) ([]*record.OrderRecord, *pagination.Cursor, error) {
+	req := db.GetOrdersCursorParams{
+		Search:    search,
+		PageLimit: int32(limit + 1),
+	}
+
+	if cursor != nil {
+		req.CursorCreatedAt = sql.NullTime{Time: cursor.CreatedAt, Valid: true}
+		req.CursorID = sql.NullInt32{Int32: int32(cursor.ID), Valid: true}
+	}
+
+	res, err := r.db.GetOrdersCursor(ctx, req)
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find orders: %w", err)
+	}
+
+	var next *pagination.Cursor
+	if len(res) > limit {
+		res = res[:limit]
+		last := res[limit-1]
+		next = &pagination.Cursor{CreatedAt: last.CreatedAt.Time, ID: int(last.OrderID)}
+	}
+
+	return r.mapping.ToOrdersRecord(res), next, nil
+}
+
+func (r *orderRepository) FindByActive(ctx context.Context, search string, page, pageSize int) ([]*record.OrderRecord, int, error) {
 	offset := (page - 1) * pageSize
 
 	req := db.GetOrdersActiveParams{
@@ -58,7 +87,7 @@ func (r *orderRepository) FindByActive(search string, page, pageSize int) ([]*re
 		Offset:  int32(offset),
 	}
 
-	res, err := r.db.GetOrdersActive(r.ctx, req)
+	res, err := r.db.GetOrdersActive(ctx, req)
 
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to find users: %w", err)
@@ -74,7 +103,7 @@ func (r *orderRepository) FindByActive(search string, page, pageSize int) ([]*re
 	return r.mapping.ToOrdersRecordActivePagination(res), totalCount, nil
 }
 
-func (r *orderRepository) FindByTrashed(search string, page, pageSize int) ([]*record.OrderRecord, int, error) {
+func (r *orderRepository) FindByTrashed(ctx context.Context, search string, page, pageSize int) ([]*record.OrderRecord, int, error) {
 	offset := (page - 1) * pageSize
 
 	req := db.GetOrdersTrashedParams{
@@ -83,7 +112,7 @@ func (r *orderRepository) FindByTrashed(search string, page, pageSize int) ([]*r
 		Offset:  int32(offset),
 	}
 
-	res, err := r.db.GetOrdersTrashed(r.ctx, req)
+	res, err := r.db.GetOrdersTrashed(ctx, req)
 
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to find users: %w", err)
@@ -99,7 +128,7 @@ func (r *orderRepository) FindByTrashed(search string, page, pageSize int) ([]*r
 	return r.mapping.ToOrdersRecordTrashedPagination(res), totalCount, nil
 }
 
-func (r *orderRepository) FindByMerchant(merchant_id int, search string, page, pageSize int) ([]*record.OrderRecord, int, error) {
+func (r *orderRepository) FindByMerchant(ctx context.Context, merchant_id int, search string, page, pageSize int) ([]*record.OrderRecord, int, error) {
 	offset := (page - 1) * pageSize
 
 	req := db.GetOrdersByMerchantParams{
@@ -108,7 +137,7 @@ func (r *orderRepository) FindByMerchant(merchant_id int, search string, page, p
 		Offset:  int32(offset),
 	}
 
-	res, err := r.db.GetOrdersByMerchant(r.ctx, req)
+	res, err := r.db.GetOrdersByMerchant(ctx, req)
 
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to find users: %w", err)
@@ -124,8 +153,8 @@ func (r *orderRepository) FindByMerchant(merchant_id int, search string, page, p
 	return r.mapping.ToOrdersRecordByMerchantPagination(res), totalCount, nil
 }
 
-func (r *orderRepository) FindById(user_id int) (*record.OrderRecord, error) {
-	res, err := r.db.GetOrderByID(r.ctx, int32(user_id))
+func (r *orderRepository) FindById(ctx context.Context, user_id int) (*record.OrderRecord, error) {
+	res, err := r.db.GetOrderByID(ctx, int32(user_id))
 
 	if err != nil {
 		fmt.Printf("Error fetching user: %v\n", err)
@@ -136,14 +165,24 @@ func (r *orderRepository) FindById(user_id int) (*record.OrderRecord, error) {
 	return r.mapping.ToOrderRecord(res), nil
 }
 
-func (r *orderRepository) CreateOrder(request *requests.CreateOrderRecordRequest) (*record.OrderRecord, error) {
+func (r *orderRepository) FindByIdForUpdate(ctx context.Context, order_id int) (*record.OrderRecord, error) {
+	res, err := r.db.GetOrderByIDForUpdate(ctx, int32(order_id))
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock order: %w", err)
+	}
+
+	return r.mapping.ToOrderRecord(res), nil
+}
+
+func (r *orderRepository) CreateOrder(ctx context.Context, request *requests.CreateOrderRecordRequest) (*record.OrderRecord, error) {
 	req := db.CreateOrderParams{
 		MerchantID: int32(request.MerchantID),
 		UserID:     int32(request.UserID),
-		TotalPrice: int32(request.TotalPrice),
+		TotalPrice: request.TotalPrice,
 	}
 
-	user, err := r.db.CreateOrder(r.ctx, req)
+	user, err := r.db.CreateOrder(ctx, req)
 
 	if err != nil {
 		return nil, errors.New("failed create order")
@@ -152,14 +191,14 @@ func (r *orderRepository) CreateOrder(request *requests.CreateOrderRecordRequest
 	return r.mapping.ToOrderRecord(user), nil
 }
 
-func (r *orderRepository) UpdateOrder(request *requests.UpdateOrderRecordRequest) (*record.OrderRecord, error) {
+func (r *orderRepository) UpdateOrder(ctx context.Context, request *requests.UpdateOrderRecordRequest) (*record.OrderRecord, error) {
 	req := db.UpdateOrderParams{
-		OrderID:    int32(request.OrderID),
-		UserID:     int32(request.UserID),
-		TotalPrice: int32(request.TotalPrice),
+		OrderID:        int32(request.OrderID),
+		UserID:         int32(request.UserID),
+		DiscountAmount: request.DiscountAmount,
 	}
 
-	res, err := r.db.UpdateOrder(r.ctx, req)
+	res, err := r.db.UpdateOrder(ctx, req)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to update order: %w", err)
@@ -168,8 +207,69 @@ func (r *orderRepository) UpdateOrder(request *requests.UpdateOrderRecordRequest
 	return r.mapping.ToOrderRecord(res), nil
 }
 
-func (r *orderRepository) TrashedOrder(user_id int) (*record.OrderRecord, error) {
-	res, err := r.db.TrashOrder(r.ctx, int32(user_id))
+// RecalculateTotal stores the payable amount of an order: its items plus
+// shipping and tax, less the order's discount. tax_rate is in basis points of
+// the items.
+func (r *orderRepository) RecalculateTotal(ctx context.Context, order_id int, tax_rate int) (*record.OrderRecord, error) {
+	res, err := r.db.RecalculateOrderTotal(ctx, db.RecalculateOrderTotalParams{
+		TaxRate: int64(tax_rate),
+		OrderID: int32(order_id),
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to recalculate order total: %w", err)
+	}
+
+	return r.mapping.ToOrderRecord(res), nil
+}
+
+func (r *orderRepository) UpdateOrderStatus(ctx context.Context, order_id int, current_status string, status string) (*record.OrderRecord, error) {
+	res, err := r.db.UpdateOrderStatus(ctx, db.UpdateOrderStatusParams{
+		OrderID:       int32(order_id),
+		CurrentStatus: current_status,
+		Status:        status,
+	})
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("order %d: %w", order_id, ErrOrderStatusConflict)
+		}
+
+		return nil, fmt.Errorf("failed to update order status: %w", err)
+	}
+
+	return r.mapping.ToOrderRecord(res), nil
+}
+
+func (r *orderRepository) CreateOrderStatusHistory(ctx context.Context, request *requests.CreateOrderStatusHistoryRecordRequest) (*record.OrderStatusHistoryRecord, error) {
+	req := db.CreateOrderStatusHistoryParams{
+		OrderID:    int32(request.OrderID),
+		FromStatus: sql.NullString{String: request.FromStatus, Valid: request.FromStatus != ""},
+		ToStatus:   request.ToStatus,
+		Note:       sql.NullString{String: request.Note, Valid: request.Note != ""},
+	}
+
+	res, err := r.db.CreateOrderStatusHistory(ctx, req)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create order status history: %w", err)
+	}
+
+	return r.mapping.ToOrderStatusHistoryRecord(res), nil
+}
+
+func (r *orderRepository) FindStatusHistory(ctx context.Context, order_id int) ([]*record.OrderStatusHistoryRecord, error) {
+	res, err := r.db.GetOrderStatusHistories(ctx, int32(order_id))
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to find order status history: %w", err)
+	}
+
+	return r.mapping.ToOrderStatusHistoriesRecord(res), nil
+}
+
+func (r *orderRepository) TrashedOrder(ctx context.Context, user_id int) (*record.OrderRecord, error) {
+	res, err := r.db.TrashOrder(ctx, int32(user_id))
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to trash order: %w", err)
@@ -178,8 +278,8 @@ func (r *orderRepository) TrashedOrder(user_id int) (*record.OrderRecord, error)
 	return r.mapping.ToOrderRecord(res), nil
 }
 
-func (r *orderRepository) RestoreOrder(user_id int) (*record.OrderRecord, error) {
-	res, err := r.db.RestoreOrder(r.ctx, int32(user_id))
+func (r *orderRepository) RestoreOrder(ctx context.Context, user_id int) (*record.OrderRecord, error) {
+	res, err := r.db.RestoreOrder(ctx, int32(user_id))
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to restore order: %w", err)
@@ -188,8 +288,8 @@ func (r *orderRepository) RestoreOrder(user_id int) (*record.OrderRecord, error)
 	return r.mapping.ToOrderRecord(res), nil
 }
 
-func (r *orderRepository) DeleteOrderPermanent(user_id int) (bool, error) {
-	err := r.db.DeleteOrderPermanently(r.ctx, int32(user_id))
+func (r *orderRepository) DeleteOrderPermanent(ctx context.Context, user_id int) (bool, error) {
+	err := r.db.DeleteOrderPermanently(ctx, int32(user_id))
 
 	if err != nil {
 		return false, fmt.Errorf("failed to delete order: %w", err)
@@ -198,8 +298,8 @@ func (r *orderRepository) DeleteOrderPermanent(user_id int) (bool, error) {
 	return true, nil
 }
 
-func (r *orderRepository) RestoreAllOrder() (bool, error) {
-	err := r.db.RestoreAllOrders(r.ctx)
+func (r *orderRepository) RestoreAllOrder(ctx context.Context) (bool, error) {
+	err := r.db.RestoreAllOrders(ctx)
 
 	if err != nil {
 		return false, fmt.Errorf("failed to restore all orders: %w", err)
@@ -207,8 +307,8 @@ func (r *orderRepository) RestoreAllOrder() (bool, error) {
 	return true, nil
 }
 
-func (r *orderRepository) DeleteAllOrderPermanent() (bool, error) {
-	err := r.db.DeleteAllPermanentOrders(r.ctx)
+func (r *orderRepository) DeleteAllOrderPermanent(ctx context.Context) (bool, error) {
+	err := r.db.DeleteAllPermanentOrders(ctx)
 
 	if err != nil {
 		return false, fmt.Errorf("failed to delete all orders permanently: %w", err)
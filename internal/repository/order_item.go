@@ -12,19 +12,17 @@ import (
 
 type orderItemRepository struct {
 	db      *db.Queries
-	ctx     context.Context
 	mapping recordmapper.OrderItemRecordMapping
 }
 
-func NewOrderItemRepository(db *db.Queries, ctx context.Context, mapping recordmapper.OrderItemRecordMapping) *orderItemRepository {
+func NewOrderItemRepository(db *db.Queries, mapping recordmapper.OrderItemRecordMapping) *orderItemRepository {
 	return &orderItemRepository{
 		db:      db,
-		ctx:     ctx,
 		mapping: mapping,
 	}
 }
 
-func (r *orderItemRepository) FindAllOrderItems(search string, page, pageSize int) ([]*record.OrderItemRecord, int, error) {
+func (r *orderItemRepository) FindAllOrderItems(ctx context.Context, search string, page, pageSize int) ([]*record.OrderItemRecord, int, error) {
 	offset := (page - 1) * pageSize
 
 	req := db.GetOrderItemsParams{
@@ -33,7 +31,7 @@ func (r *orderItemRepository) FindAllOrderItems(search string, page, pageSize in
 		Offset:  int32(offset),
 	}
 
-	res, err := r.db.GetOrderItems(r.ctx, req)
+	res, err := r.db.GetOrderItems(ctx, req)
 
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to find order items : %w", err)
@@ -49,7 +47,7 @@ func (r *orderItemRepository) FindAllOrderItems(search string, page, pageSize in
 	return r.mapping.ToOrderItemsRecordPagination(res), totalCount, nil
 }
 
-func (r *orderItemRepository) FindByActive(search string, page, pageSize int) ([]*record.OrderItemRecord, int, error) {
+func (r *orderItemRepository) FindByActive(ctx context.Context, search string, page, pageSize int) ([]*record.OrderItemRecord, int, error) {
 	offset := (page - 1) * pageSize
 
 	req := db.GetOrderItemsActiveParams{
@@ -58,7 +56,7 @@ func (r *orderItemRepository) FindByActive(search string, page, pageSize int) ([
 		Offset:  int32(offset),
 	}
 
-	res, err := r.db.GetOrderItemsActive(r.ctx, req)
+	res, err := r.db.GetOrderItemsActive(ctx, req)
 
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to find users: %w", err)
@@ -74,7 +72,7 @@ func (r *orderItemRepository) FindByActive(search string, page, pageSize int) ([
 	return r.mapping.ToOrderItemsRecordActivePagination(res), totalCount, nil
 }
 
-func (r *orderItemRepository) FindByTrashed(search string, page, pageSize int) ([]*record.OrderItemRecord, int, error) {
+func (r *orderItemRepository) FindByTrashed(ctx context.Context, search string, page, pageSize int) ([]*record.OrderItemRecord, int, error) {
 	offset := (page - 1) * pageSize
 
 	req := db.GetOrderItemsTrashedParams{
@@ -83,7 +81,7 @@ func (r *orderItemRepository) FindByTrashed(search string, page, pageSize int) (
 		Offset:  int32(offset),
 	}
 
-	res, err := r.db.GetOrderItemsTrashed(r.ctx, req)
+	res, err := r.db.GetOrderItemsTrashed(ctx, req)
 
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to find users: %w", err)
@@ -99,8 +97,8 @@ func (r *orderItemRepository) FindByTrashed(search string, page, pageSize int) (
 	return r.mapping.ToOrderItemsRecordTrashedPagination(res), totalCount, nil
 }
 
-func (r *orderItemRepository) FindOrderItemByOrder(order_id int) ([]*record.OrderItemRecord, error) {
-	res, err := r.db.GetOrderItemsByOrder(r.ctx, int32(order_id))
+func (r *orderItemRepository) FindOrderItemByOrder(ctx context.Context, order_id int) ([]*record.OrderItemRecord, error) {
+	res, err := r.db.GetOrderItemsByOrder(ctx, int32(order_id))
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to find order by order_id: %w", err)
@@ -109,23 +107,12 @@ func (r *orderItemRepository) FindOrderItemByOrder(order_id int) ([]*record.Orde
 	return r.mapping.ToOrderItemsRecord(res), nil
 }
 
-func (r *orderItemRepository) CalculateTotalPrice(order_id int) (*int32, error) {
-	res, err := r.db.CalculateTotalPrice(r.ctx, int32(order_id))
-
-	if err != nil {
-		return nil, fmt.Errorf("failed to find order by order_id: %w", err)
-	}
-
-	return &res, nil
-
-}
-
-func (r *orderItemRepository) CreateOrderItem(req *requests.CreateOrderItemRecordRequest) (*record.OrderItemRecord, error) {
-	res, err := r.db.CreateOrderItem(r.ctx, db.CreateOrderItemParams{
+func (r *orderItemRepository) CreateOrderItem(ctx context.Context, req *requests.CreateOrderItemRecordRequest) (*record.OrderItemRecord, error) {
+	res, err := r.db.CreateOrderItem(ctx, db.CreateOrderItemParams{
 		OrderID:   int32(req.OrderID),
 		ProductID: int32(req.ProductID),
 		Quantity:  int32(req.Quantity),
-		Price:     int32(req.Price),
+		Price:     req.Price,
 	})
 
 	if err != nil {
@@ -135,11 +122,12 @@ func (r *orderItemRepository) CreateOrderItem(req *requests.CreateOrderItemRecor
 	return r.mapping.ToOrderItemRecord(res), nil
 }
 
-func (r *orderItemRepository) UpdateOrderItem(req *requests.UpdateOrderItemRecordRequest) (*record.OrderItemRecord, error) {
-	res, err := r.db.UpdateOrderItem(r.ctx, db.UpdateOrderItemParams{
+func (r *orderItemRepository) UpdateOrderItem(ctx context.Context, req *requests.UpdateOrderItemRecordRequest) (*record.OrderItemRecord, error) {
+	res, err := r.db.UpdateOrderItem(ctx, db.UpdateOrderItemParams{
 		OrderItemID: int32(req.OrderItemID),
+		OrderID:     int32(req.OrderID),
 		Quantity:    int32(req.Quantity),
-		Price:       int32(req.Price),
+		Price:       req.Price,
 	})
 
 	if err != nil {
@@ -149,8 +137,8 @@ func (r *orderItemRepository) UpdateOrderItem(req *requests.UpdateOrderItemRecor
 	return r.mapping.ToOrderItemRecord(res), nil
 }
 
-func (r *orderItemRepository) TrashedOrderItem(order_id int) (*record.OrderItemRecord, error) {
-	res, err := r.db.TrashOrderItem(r.ctx, int32(order_id))
+func (r *orderItemRepository) TrashedOrderItem(ctx context.Context, order_id int) (*record.OrderItemRecord, error) {
+	res, err := r.db.TrashOrderItem(ctx, int32(order_id))
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to trash order item: %w", err)
@@ -159,8 +147,8 @@ func (r *orderItemRepository) TrashedOrderItem(order_id int) (*record.OrderItemR
 	return r.mapping.ToOrderItemRecord(res), nil
 }
 
-func (r *orderItemRepository) RestoreOrderItem(order_id int) (*record.OrderItemRecord, error) {
-	res, err := r.db.RestoreOrderItem(r.ctx, int32(order_id))
+func (r *orderItemRepository) RestoreOrderItem(ctx context.Context, order_id int) (*record.OrderItemRecord, error) {
+	res, err := r.db.RestoreOrderItem(ctx, int32(order_id))
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to restore order item: %w", err)
@@ -169,8 +157,8 @@ func (r *orderItemRepository) RestoreOrderItem(order_id int) (*record.OrderItemR
 	return r.mapping.ToOrderItemRecord(res), nil
 }
 
-func (r *orderItemRepository) DeleteOrderItemPermanent(order_id int) (bool, error) {
-	err := r.db.DeleteOrderItemPermanently(r.ctx, int32(order_id))
+func (r *orderItemRepository) DeleteOrderItemPermanent(ctx context.Context, order_id int) (bool, error) {
+	err := r.db.DeleteOrderItemPermanently(ctx, int32(order_id))
 
 	if err != nil {
 		return false, fmt.Errorf("failed to delete order_item: %w", err)
@@ -179,8 +167,8 @@ func (r *orderItemRepository) DeleteOrderItemPermanent(order_id int) (bool, erro
 	return true, nil
 }
 
-func (r *orderItemRepository) RestoreAllOrderItem() (bool, error) {
-	err := r.db.RestoreAllUsers(r.ctx)
+func (r *orderItemRepository) RestoreAllOrderItem(ctx context.Context) (bool, error) {
+	err := r.db.RestoreAllUsers(ctx)
 
 	if err != nil {
 		return false, fmt.Errorf("failed to restore all order_item: %w", err)
@@ -188,8 +176,8 @@ func (r *orderItemRepository) RestoreAllOrderItem() (bool, error) {
 	return true, nil
 }
 
-func (r *orderItemRepository) DeleteAllOrderPermanent() (bool, error) {
-	err := r.db.DeleteAllPermanentOrders(r.ctx)
+func (r *orderItemRepository) DeleteAllOrderPermanent(ctx context.Context) (bool, error) {
+	err := r.db.DeleteAllPermanentOrders(ctx)
 
 	if err != nil {
 		return false, fmt.Errorf("failed to delete all order_item permanently: %w", err)
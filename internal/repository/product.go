@@ -7,34 +7,41 @@ import (
 	"ecommerce/internal/domain/requests"
 	recordmapper "ecommerce/internal/mapper/record"
 	db "ecommerce/pkg/database/schema"
+	"ecommerce/pkg/pagination"
 	"errors"
 	"fmt"
 )
 
+var ErrInsufficientStock = errors.New("insufficient stock")
+
 type productRepository struct {
 	db      *db.Queries
-	ctx     context.Context
 	mapping recordmapper.ProductRecordMapping
 }
 
-func NewProductRepository(db *db.Queries, ctx context.Context, mapping recordmapper.ProductRecordMapping) *productRepository {
+func NewProductRepository(db *db.Queries, mapping recordmapper.ProductRecordMapping) *productRepository {
 	return &productRepository{
 		db:      db,
-		ctx:     ctx,
 		mapping: mapping,
 	}
 }
 
-func (r *productRepository) FindAllProducts(search string, page, pageSize int) ([]*record.ProductRecord, int, error) {
+func (r *productRepository) FindAllProducts(ctx context.Context, search string, filter *requests.ProductFilter, page, pageSize int) ([]*record.ProductRecord, int, error) {
 	offset := (page - 1) * pageSize
 
 	req := db.GetProductsParams{
-		Column1: search,
-		Limit:   int32(pageSize),
-		Offset:  int32(offset),
+		Search:     search,
+		MinPrice:   sql.NullInt64{Int64: filter.MinPrice, Valid: filter.MinPrice > 0},
+		MaxPrice:   sql.NullInt64{Int64: filter.MaxPrice, Valid: filter.MaxPrice > 0},
+		MinRating:  sql.NullFloat64{Float64: filter.MinRating, Valid: filter.MinRating > 0},
+		Brand:      sql.NullString{String: filter.Brand, Valid: filter.Brand != ""},
+		InStock:    filter.InStock,
+		Sort:       productSort(filter),
+		PageLimit:  int32(pageSize),
+		PageOffset: int32(offset),
 	}
 
-	res, err := r.db.GetProducts(r.ctx, req)
+	res, err := r.db.GetProducts(ctx, req)
 
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to find products: %w", err)
@@ -50,7 +57,34 @@ func (r *productRepository) FindAllProducts(search string, page, pageSize int) (
 	return r.mapping.ToProductsRecordPagination(res), totalCount, nil
 }
 
-func (r *productRepository) FindByActive(search string, page, pageSize int) ([]*record.ProductRecord, int, error) {
+func (r *productRepository) FindAllProductsCursor(ctx context.Context, search string, cursor *pagination.Cursor, limit int) ([]*record.ProductRecord, *pagination.Cursor, error) {
+	req := db.GetProductsCursorParams{
+		Search:    search,
+		PageLimit: int32(limit + 1),
+	}
+
+	if cursor != nil {
+		req.CursorCreatedAt = sql.NullTime{Time: cursor.CreatedAt, Valid: true}
+		req.CursorID = sql.NullInt32{Int32: int32(cursor.ID), Valid: true}
+	}
+
+	res, err := r.db.GetProductsCursor(ctx, req)
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find products: %w", err)
+	}
+
+	var next *pagination.Cursor
+	if len(res) > limit {
+		res = res[:limit]
+		last := res[limit-1]
+		next = &pagination.Cursor{CreatedAt: last.CreatedAt.Time, ID: int(last.ProductID)}
+	}
+
+	return r.mapping.ToProductsRecord(res), next, nil
+}
+
+func (r *productRepository) FindByActive(ctx context.Context, search string, page, pageSize int) ([]*record.ProductRecord, int, error) {
 	offset := (page - 1) * pageSize
 
 	req := db.GetProductsActiveParams{
@@ -59,7 +93,7 @@ func (r *productRepository) FindByActive(search string, page, pageSize int) ([]*
 		Offset:  int32(offset),
 	}
 
-	res, err := r.db.GetProductsActive(r.ctx, req)
+	res, err := r.db.GetProductsActive(ctx, req)
 
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to find products: %w", err)
@@ -75,7 +109,7 @@ func (r *productRepository) FindByActive(search string, page, pageSize int) ([]*
 	return r.mapping.ToProductsRecordActivePagination(res), totalCount, nil
 }
 
-func (r *productRepository) FindByTrashed(search string, page, pageSize int) ([]*record.ProductRecord, int, error) {
+func (r *productRepository) FindByTrashed(ctx context.Context, search string, page, pageSize int) ([]*record.ProductRecord, int, error) {
 	offset := (page - 1) * pageSize
 
 	req := db.GetProductsTrashedParams{
@@ -84,7 +118,7 @@ func (r *productRepository) FindByTrashed(search string, page, pageSize int) ([]
 		Offset:  int32(offset),
 	}
 
-	res, err := r.db.GetProductsTrashed(r.ctx, req)
+	res, err := r.db.GetProductsTrashed(ctx, req)
 
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to find users: %w", err)
@@ -100,17 +134,23 @@ func (r *productRepository) FindByTrashed(search string, page, pageSize int) ([]
 	return r.mapping.ToProductsRecordTrashedPagination(res), totalCount, nil
 }
 
-func (r *productRepository) FindByMerchant(merchant_id int, search string, page, pageSize int) ([]*record.ProductRecord, int, error) {
+func (r *productRepository) FindByMerchant(ctx context.Context, merchant_id int, search string, filter *requests.ProductFilter, page, pageSize int) ([]*record.ProductRecord, int, error) {
 	offset := (page - 1) * pageSize
 
 	req := db.GetProductsByMerchantParams{
 		MerchantID: int32(merchant_id),
-		Column2:    search,
-		Limit:      int32(pageSize),
-		Offset:     int32(offset),
+		Search:     search,
+		MinPrice:   sql.NullInt64{Int64: filter.MinPrice, Valid: filter.MinPrice > 0},
+		MaxPrice:   sql.NullInt64{Int64: filter.MaxPrice, Valid: filter.MaxPrice > 0},
+		MinRating:  sql.NullFloat64{Float64: filter.MinRating, Valid: filter.MinRating > 0},
+		Brand:      sql.NullString{String: filter.Brand, Valid: filter.Brand != ""},
+		InStock:    filter.InStock,
+		Sort:       productSort(filter),
+		PageLimit:  int32(pageSize),
+		PageOffset: int32(offset),
 	}
 
-	res, err := r.db.GetProductsByMerchant(r.ctx, req)
+	res, err := r.db.GetProductsByMerchant(ctx, req)
 
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to find products: %w", err)
@@ -126,17 +166,49 @@ func (r *productRepository) FindByMerchant(merchant_id int, search string, page,
 	return r.mapping.ToProductsRecordMerchantPagination(res), totalCount, nil
 }
 
-func (r *productRepository) FindByCategory(category_name string, search string, page, pageSize int) ([]*record.ProductRecord, int, error) {
+func (r *productRepository) FindByUser(ctx context.Context, user_id int, search string, page, pageSize int) ([]*record.ProductRecord, int, error) {
 	offset := (page - 1) * pageSize
 
-	req := db.GetProductsByCategoryNameParams{
-		Name:    category_name,
+	req := db.GetProductsByUserIDParams{
+		UserID:  int32(user_id),
 		Column2: search,
 		Limit:   int32(pageSize),
 		Offset:  int32(offset),
 	}
 
-	res, err := r.db.GetProductsByCategoryName(r.ctx, req)
+	res, err := r.db.GetProductsByUserID(ctx, req)
+
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find products: %w", err)
+	}
+
+	var totalCount int
+	if len(res) > 0 {
+		totalCount = int(res[0].TotalCount)
+	} else {
+		totalCount = 0
+	}
+
+	return r.mapping.ToProductsRecordUserPagination(res), totalCount, nil
+}
+
+func (r *productRepository) FindByCategory(ctx context.Context, category_name string, search string, filter *requests.ProductFilter, page, pageSize int) ([]*record.ProductRecord, int, error) {
+	offset := (page - 1) * pageSize
+
+	req := db.GetProductsByCategoryNameParams{
+		CategoryName: category_name,
+		Search:       search,
+		MinPrice:     sql.NullInt64{Int64: filter.MinPrice, Valid: filter.MinPrice > 0},
+		MaxPrice:     sql.NullInt64{Int64: filter.MaxPrice, Valid: filter.MaxPrice > 0},
+		MinRating:    sql.NullFloat64{Float64: filter.MinRating, Valid: filter.MinRating > 0},
+		Brand:        sql.NullString{String: filter.Brand, Valid: filter.Brand != ""},
+		InStock:      filter.InStock,
+		Sort:         productSort(filter),
+		PageLimit:    int32(pageSize),
+		PageOffset:   int32(offset),
+	}
+
+	res, err := r.db.GetProductsByCategoryName(ctx, req)
 
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to find products: %w", err)
@@ -152,8 +224,56 @@ func (r *productRepository) FindByCategory(category_name string, search string,
 	return r.mapping.ToProductsRecordCategoryPagination(res), totalCount, nil
 }
 
-func (r *productRepository) FindById(user_id int) (*record.ProductRecord, error) {
-	res, err := r.db.GetProductByID(r.ctx, int32(user_id))
+func (r *productRepository) SearchProducts(ctx context.Context, request *requests.SearchProductRequest) ([]*record.ProductRecord, int, error) {
+	offset := (request.Page - 1) * request.PageSize
+
+	req := db.SearchProductsParams{
+		Query:      request.Query,
+		CategoryID: sql.NullInt32{Int32: int32(request.CategoryID), Valid: request.CategoryID > 0},
+		MerchantID: sql.NullInt32{Int32: int32(request.MerchantID), Valid: request.MerchantID > 0},
+		Brand:      sql.NullString{String: request.Brand, Valid: request.Brand != ""},
+		MinPrice:   sql.NullInt64{Int64: request.MinPrice, Valid: request.MinPrice > 0},
+		MaxPrice:   sql.NullInt64{Int64: request.MaxPrice, Valid: request.MaxPrice > 0},
+		PageLimit:  int32(request.PageSize),
+		PageOffset: int32(offset),
+	}
+
+	res, err := r.db.SearchProducts(ctx, req)
+
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to search products: %w", err)
+	}
+
+	var totalCount int
+	if len(res) > 0 {
+		totalCount = int(res[0].TotalCount)
+	}
+
+	return r.mapping.ToProductsRecordSearchPagination(res), totalCount, nil
+}
+
+func (r *productRepository) SearchProductFacets(ctx context.Context, request *requests.SearchProductRequest, priceBounds []int64) ([]*record.ProductFacetRecord, error) {
+	req := db.SearchProductFacetsParams{
+		Query:       request.Query,
+		CategoryID:  sql.NullInt32{Int32: int32(request.CategoryID), Valid: request.CategoryID > 0},
+		MerchantID:  sql.NullInt32{Int32: int32(request.MerchantID), Valid: request.MerchantID > 0},
+		Brand:       sql.NullString{String: request.Brand, Valid: request.Brand != ""},
+		MinPrice:    sql.NullInt64{Int64: request.MinPrice, Valid: request.MinPrice > 0},
+		MaxPrice:    sql.NullInt64{Int64: request.MaxPrice, Valid: request.MaxPrice > 0},
+		PriceBounds: priceBounds,
+	}
+
+	res, err := r.db.SearchProductFacets(ctx, req)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to count product facets: %w", err)
+	}
+
+	return r.mapping.ToProductFacetsRecord(res), nil
+}
+
+func (r *productRepository) FindById(ctx context.Context, user_id int) (*record.ProductRecord, error) {
+	res, err := r.db.GetProductByID(ctx, int32(user_id))
 
 	if err != nil {
 		fmt.Printf("Error fetching products: %v\n", err)
@@ -164,13 +284,13 @@ func (r *productRepository) FindById(user_id int) (*record.ProductRecord, error)
 	return r.mapping.ToProductRecord(res), nil
 }
 
-func (r *productRepository) CreateProduct(request *requests.CreateProductRequest) (*record.ProductRecord, error) {
+func (r *productRepository) CreateProduct(ctx context.Context, request *requests.CreateProductRequest) (*record.ProductRecord, error) {
 	req := db.CreateProductParams{
 		MerchantID:   int32(request.MerchantID),
 		CategoryID:   int32(request.CategoryID),
 		Name:         request.Name,
 		Description:  sql.NullString{String: request.Description, Valid: request.Description != ""},
-		Price:        int32(request.Price),
+		Price:        request.Price,
 		CountInStock: int32(request.CountInStock),
 		Brand:        sql.NullString{String: request.Brand, Valid: request.Brand != ""},
 		Weight:       sql.NullInt32{Int32: int32(request.Weight)},
@@ -181,7 +301,7 @@ func (r *productRepository) CreateProduct(request *requests.CreateProductRequest
 		ImageProduct: sql.NullString{String: request.ImageProduct, Valid: request.ImageProduct != ""},
 	}
 
-	product, err := r.db.CreateProduct(r.ctx, req)
+	product, err := r.db.CreateProduct(ctx, req)
 	if err != nil {
 		return nil, errors.New("failed to create product")
 	}
@@ -189,13 +309,13 @@ func (r *productRepository) CreateProduct(request *requests.CreateProductRequest
 	return r.mapping.ToProductRecord(product), nil
 }
 
-func (r *productRepository) UpdateProduct(request *requests.UpdateProductRequest) (*record.ProductRecord, error) {
+func (r *productRepository) UpdateProduct(ctx context.Context, request *requests.UpdateProductRequest) (*record.ProductRecord, error) {
 	req := db.UpdateProductParams{
 		ProductID:    int32(request.ProductID),
 		CategoryID:   int32(request.CategoryID),
 		Name:         request.Name,
 		Description:  sql.NullString{String: request.Description, Valid: request.Description != ""},
-		Price:        int32(request.Price),
+		Price:        request.Price,
 		CountInStock: int32(request.CountInStock),
 		Brand:        sql.NullString{String: request.Brand, Valid: request.Brand != ""},
 		Weight:       sql.NullInt32{Int32: int32(request.Weight)},
@@ -206,7 +326,7 @@ func (r *productRepository) UpdateProduct(request *requests.UpdateProductRequest
 		ImageProduct: sql.NullString{String: request.ImageProduct, Valid: request.ImageProduct != ""},
 	}
 
-	res, err := r.db.UpdateProduct(r.ctx, req)
+	res, err := r.db.UpdateProduct(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update product: %w", err)
 	}
@@ -214,8 +334,8 @@ func (r *productRepository) UpdateProduct(request *requests.UpdateProductRequest
 	return r.mapping.ToProductRecord(res), nil
 }
 
-func (r *productRepository) UpdateProductCountStock(product_id int, stock int) (*record.ProductRecord, error) {
-	res, err := r.db.UpdateProductCountStock(r.ctx, db.UpdateProductCountStockParams{
+func (r *productRepository) UpdateProductCountStock(ctx context.Context, product_id int, stock int) (*record.ProductRecord, error) {
+	res, err := r.db.UpdateProductCountStock(ctx, db.UpdateProductCountStockParams{
 		ProductID:    int32(product_id),
 		CountInStock: int32(stock),
 	})
@@ -227,8 +347,38 @@ func (r *productRepository) UpdateProductCountStock(product_id int, stock int) (
 	return r.mapping.ToProductRecord(res), nil
 }
 
-func (r *productRepository) TrashedProduct(user_id int) (*record.ProductRecord, error) {
-	res, err := r.db.TrashProduct(r.ctx, int32(user_id))
+func (r *productRepository) ReserveProductStock(ctx context.Context, product_id int, quantity int) (*record.ProductRecord, error) {
+	res, err := r.db.ReserveProductStock(ctx, db.ReserveProductStockParams{
+		ProductID:    int32(product_id),
+		CountInStock: int32(quantity),
+	})
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("product %d: %w", product_id, ErrInsufficientStock)
+		}
+
+		return nil, fmt.Errorf("failed to reserve product stock: %w", err)
+	}
+
+	return r.mapping.ToProductRecord(res), nil
+}
+
+func (r *productRepository) ReleaseProductStock(ctx context.Context, product_id int, quantity int) (*record.ProductRecord, error) {
+	res, err := r.db.ReleaseProductStock(ctx, db.ReleaseProductStockParams{
+		ProductID:    int32(product_id),
+		CountInStock: int32(quantity),
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to release product stock: %w", err)
+	}
+
+	return r.mapping.ToProductRecord(res), nil
+}
+
+func (r *productRepository) TrashedProduct(ctx context.Context, user_id int) (*record.ProductRecord, error) {
+	res, err := r.db.TrashProduct(ctx, int32(user_id))
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to trash user: %w", err)
@@ -237,8 +387,8 @@ func (r *productRepository) TrashedProduct(user_id int) (*record.ProductRecord,
 	return r.mapping.ToProductRecord(res), nil
 }
 
-func (r *productRepository) RestoreProduct(user_id int) (*record.ProductRecord, error) {
-	res, err := r.db.RestoreProduct(r.ctx, int32(user_id))
+func (r *productRepository) RestoreProduct(ctx context.Context, user_id int) (*record.ProductRecord, error) {
+	res, err := r.db.RestoreProduct(ctx, int32(user_id))
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to restore topup: %w", err)
@@ -247,8 +397,8 @@ func (r *productRepository) RestoreProduct(user_id int) (*record.ProductRecord,
 	return r.mapping.ToProductRecord(res), nil
 }
 
-func (r *productRepository) DeleteProductPermanent(user_id int) (bool, error) {
-	err := r.db.DeleteProductPermanently(r.ctx, int32(user_id))
+func (r *productRepository) DeleteProductPermanent(ctx context.Context, user_id int) (bool, error) {
+	err := r.db.DeleteProductPermanently(ctx, int32(user_id))
 
 	if err != nil {
 		return false, fmt.Errorf("failed to delete product: %w", err)
@@ -257,8 +407,8 @@ func (r *productRepository) DeleteProductPermanent(user_id int) (bool, error) {
 	return true, nil
 }
 
-func (r *productRepository) RestoreAllProducts() (bool, error) {
-	err := r.db.RestoreAllProducts(r.ctx)
+func (r *productRepository) RestoreAllProducts(ctx context.Context) (bool, error) {
+	err := r.db.RestoreAllProducts(ctx)
 
 	if err != nil {
 		return false, fmt.Errorf("failed to restore all products: %w", err)
@@ -266,11 +416,21 @@ func (r *productRepository) RestoreAllProducts() (bool, error) {
 	return true, nil
 }
 
-func (r *productRepository) DeleteAllProductPermanent() (bool, error) {
-	err := r.db.DeleteAllPermanentProducts(r.ctx)
+func (r *productRepository) DeleteAllProductPermanent(ctx context.Context) (bool, error) {
+	err := r.db.DeleteAllPermanentProducts(ctx)
 
 	if err != nil {
 		return false, fmt.Errorf("failed to delete all products permanently: %w", err)
 	}
 	return true, nil
 }
+
+// productSort returns the ORDER BY key understood by the product list
+// queries, falling back to the newest products first.
+func productSort(filter *requests.ProductFilter) string {
+	if filter.Sort == "" {
+		return requests.ProductSortNewest
+	}
+
+	return filter.Sort
+}
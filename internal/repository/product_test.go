@@ -0,0 +1,76 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	recordmapper "ecommerce/internal/mapper/record"
+	"ecommerce/internal/repository"
+	db "ecommerce/pkg/database/schema"
+	"ecommerce/pkg/database/testdb"
+)
+
+func TestReserveProductStockConcurrently(t *testing.T) {
+	conn := testdb.Open(t)
+
+	const (
+		stock   = 10
+		buyers  = 50
+		perCall = 1
+	)
+
+	_, merchantID := testdb.SeedMerchant(t, conn)
+	productID := testdb.SeedProduct(t, conn, merchantID, 1000, stock)
+	products := repository.NewProductRepository(db.New(conn), recordmapper.NewProductRecordMapper())
+
+	var (
+		wg           sync.WaitGroup
+		mu           sync.Mutex
+		reserved     int
+		insufficient int
+		start        = make(chan struct{})
+	)
+
+	for i := 0; i < buyers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			<-start
+
+			product, err := products.ReserveProductStock(context.Background(), productID, perCall)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			switch {
+			case err == nil:
+				reserved++
+				if product.CountInStock < 0 {
+					t.Errorf("stock went negative: %d", product.CountInStock)
+				}
+			case errors.Is(err, repository.ErrInsufficientStock):
+				insufficient++
+			default:
+				t.Errorf("reserve stock: %v", err)
+			}
+		}()
+	}
+
+	close(start)
+	wg.Wait()
+
+	if reserved != stock/perCall {
+		t.Errorf("reserved %d times, want %d", reserved, stock/perCall)
+	}
+
+	if insufficient != buyers-stock/perCall {
+		t.Errorf("rejected %d reservations, want %d", insufficient, buyers-stock/perCall)
+	}
+
+	if remaining := testdb.ProductStock(t, conn, productID); remaining != 0 {
+		t.Errorf("count_in_stock = %d, want 0", remaining)
+	}
+}
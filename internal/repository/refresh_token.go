@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"ecommerce/internal/domain/record"
 	"ecommerce/internal/domain/requests"
 	recordmapper "ecommerce/internal/mapper/record"
@@ -12,20 +13,18 @@ import (
 
 type refreshTokenRepository struct {
 	db      *db.Queries
-	ctx     context.Context
 	mapping recordmapper.RefreshTokenRecordMapping
 }
 
-func NewRefreshTokenRepository(db *db.Queries, ctx context.Context, mapping recordmapper.RefreshTokenRecordMapping) *refreshTokenRepository {
+func NewRefreshTokenRepository(db *db.Queries, mapping recordmapper.RefreshTokenRecordMapping) *refreshTokenRepository {
 	return &refreshTokenRepository{
 		db:      db,
-		ctx:     ctx,
 		mapping: mapping,
 	}
 }
 
-func (r *refreshTokenRepository) FindByToken(token string) (*record.RefreshTokenRecord, error) {
-	res, err := r.db.FindRefreshTokenByToken(r.ctx, token)
+func (r *refreshTokenRepository) FindByToken(ctx context.Context, token string) (*record.RefreshTokenRecord, error) {
+	res, err := r.db.FindRefreshTokenByToken(ctx, token)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to find refresh token by token: %w", err)
@@ -34,26 +33,36 @@ func (r *refreshTokenRepository) FindByToken(token string) (*record.RefreshToken
 	return r.mapping.ToRefreshTokenRecord(res), nil
 }
 
-func (r *refreshTokenRepository) FindByUserId(user_id int) (*record.RefreshTokenRecord, error) {
-	res, err := r.db.FindRefreshTokenByUserId(r.ctx, int32(user_id))
+func (r *refreshTokenRepository) FindActiveByUserId(ctx context.Context, user_id int) ([]*record.RefreshTokenRecord, error) {
+	res, err := r.db.FindActiveRefreshTokensByUserId(ctx, int32(user_id))
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to find refresh token by user id: %w", err)
+		return nil, fmt.Errorf("failed to find active refresh tokens by user id: %w", err)
 	}
 
-	return r.mapping.ToRefreshTokenRecord(res), nil
+	return r.mapping.ToRefreshTokensRecord(res), nil
 }
 
-func (r *refreshTokenRepository) CreateRefreshToken(req *requests.CreateRefreshToken) (*record.RefreshTokenRecord, error) {
+func (r *refreshTokenRepository) CreateRefreshToken(ctx context.Context, req *requests.CreateRefreshToken) (*record.RefreshTokenRecord, error) {
 	layout := "2006-01-02 15:04:05"
 	expirationTime, err := time.Parse(layout, req.ExpiresAt)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse expiration date: %w", err)
 	}
 
-	res, err := r.db.CreateRefreshToken(r.ctx, db.CreateRefreshTokenParams{
+	signedInAt, err := time.Parse(layout, req.SignedInAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse sign-in date: %w", err)
+	}
+
+	res, err := r.db.CreateRefreshToken(ctx, db.CreateRefreshTokenParams{
 		UserID:     int32(req.UserId),
 		Token:      req.Token,
+		FamilyID:   req.FamilyID,
+		Device:     sql.NullString{String: req.Device, Valid: req.Device != ""},
+		UserAgent:  sql.NullString{String: req.UserAgent, Valid: req.UserAgent != ""},
+		IpAddress:  sql.NullString{String: req.IPAddress, Valid: req.IPAddress != ""},
+		SignedInAt: signedInAt,
 		Expiration: expirationTime,
 	})
 
@@ -64,45 +73,48 @@ func (r *refreshTokenRepository) CreateRefreshToken(req *requests.CreateRefreshT
 	return r.mapping.ToRefreshTokenRecord(res), nil
 }
 
-func (r *refreshTokenRepository) UpdateRefreshToken(req *requests.UpdateRefreshToken) (*record.RefreshTokenRecord, error) {
-	layout := "2006-01-02 15:04:05"
-	expirationTime, err := time.Parse(layout, req.ExpiresAt)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse expiration date: %w", err)
-	}
+func (r *refreshTokenRepository) MarkUsed(ctx context.Context, refresh_token_id int) (*record.RefreshTokenRecord, error) {
+	res, err := r.db.MarkRefreshTokenUsed(ctx, int32(refresh_token_id))
 
-	err = r.db.UpdateRefreshTokenByUserId(r.ctx, db.UpdateRefreshTokenByUserIdParams{
-		UserID:     int32(req.UserId),
-		Token:      req.Token,
-		Expiration: expirationTime,
-	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to update refresh token expiration: %w", err)
+		return nil, fmt.Errorf("failed to mark refresh token as used: %w", err)
 	}
 
-	refreshToken, err := r.FindByUserId(req.UserId)
+	return r.mapping.ToRefreshTokenRecord(res), nil
+}
+
+func (r *refreshTokenRepository) RevokeFamily(ctx context.Context, family_id string) error {
+	err := r.db.RevokeRefreshTokenFamily(ctx, family_id)
+
 	if err != nil {
-		return nil, fmt.Errorf("failed to retrieve updated refresh token: %w", err)
+		return fmt.Errorf("failed to revoke refresh token family: %w", err)
 	}
 
-	return refreshToken, nil
+	return nil
 }
 
-func (r *refreshTokenRepository) DeleteRefreshToken(token string) error {
-	err := r.db.DeleteRefreshToken(r.ctx, token)
+func (r *refreshTokenRepository) RevokeUserFamily(ctx context.Context, user_id int, family_id string) error {
+	rows, err := r.db.RevokeRefreshTokenFamilyByUserId(ctx, db.RevokeRefreshTokenFamilyByUserIdParams{
+		UserID:   int32(user_id),
+		FamilyID: family_id,
+	})
 
 	if err != nil {
-		return fmt.Errorf("failed to delete refresh token: %w", err)
+		return fmt.Errorf("failed to revoke refresh token family: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("failed to revoke refresh token family: %w", sql.ErrNoRows)
 	}
 
 	return nil
 }
 
-func (r *refreshTokenRepository) DeleteRefreshTokenByUserId(user_id int) error {
-	err := r.db.DeleteRefreshTokenByUserId(r.ctx, int32(user_id))
+func (r *refreshTokenRepository) RevokeByUserId(ctx context.Context, user_id int) error {
+	err := r.db.RevokeRefreshTokensByUserId(ctx, int32(user_id))
 
 	if err != nil {
-		return fmt.Errorf("failed to delete refresh token: %w", err)
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
 	}
 
 	return nil
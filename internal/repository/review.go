@@ -2,33 +2,32 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"ecommerce/internal/domain/record"
 	"ecommerce/internal/domain/requests"
 	recordmapper "ecommerce/internal/mapper/record"
 	db "ecommerce/pkg/database/schema"
+	"ecommerce/pkg/pagination"
 	"errors"
 	"fmt"
 )
 
 type reviewRepository struct {
 	db      *db.Queries
-	ctx     context.Context
 	mapping recordmapper.ReviewRecordMapping
 }
 
 func NewReviewRepository(
 	db *db.Queries,
-	ctx context.Context,
 	mapping recordmapper.ReviewRecordMapping,
 ) *reviewRepository {
 	return &reviewRepository{
 		db:      db,
-		ctx:     ctx,
 		mapping: mapping,
 	}
 }
 
-func (r *reviewRepository) FindAllReview(search string, page, pageSize int) ([]*record.ReviewRecord, int, error) {
+func (r *reviewRepository) FindAllReview(ctx context.Context, search string, page, pageSize int) ([]*record.ReviewRecord, int, error) {
 	offset := (page - 1) * pageSize
 
 	req := db.GetReviewsParams{
@@ -37,7 +36,7 @@ func (r *reviewRepository) FindAllReview(search string, page, pageSize int) ([]*
 		Offset:  int32(offset),
 	}
 
-	res, err := r.db.GetReviews(r.ctx, req)
+	res, err := r.db.GetReviews(ctx, req)
 
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to find shipping address: %w", err)
@@ -53,7 +52,34 @@ func (r *reviewRepository) FindAllReview(search string, page, pageSize int) ([]*
 	return r.mapping.ToReviewsRecordPagination(res), totalCount, nil
 }
 
-func (r *reviewRepository) FindByProduct(product_id int, search string, page, pageSize int) ([]*record.ReviewRecord, int, error) {
+func (r *reviewRepository) FindAllReviewsCursor(ctx context.Context, search string, cursor *pagination.Cursor, limit int) ([]*record.ReviewRecord, *pagination.Cursor, error) {
+	req := db.GetReviewsCursorParams{
+		Search:    search,
+		PageLimit: int32(limit + 1),
+	}
+
+	if cursor != nil {
+		req.CursorCreatedAt = sql.NullTime{Time: cursor.CreatedAt, Valid: true}
+		req.CursorID = sql.NullInt32{Int32: int32(cursor.ID), Valid: true}
+	}
+
+	res, err := r.db.GetReviewsCursor(ctx, req)
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find reviews: %w", err)
+	}
+
+	var next *pagination.Cursor
+	if len(res) > limit {
+		res = res[:limit]
+		last := res[limit-1]
+		next = &pagination.Cursor{CreatedAt: last.CreatedAt.Time, ID: int(last.ReviewID)}
+	}
+
+	return r.mapping.ToReviewsRecord(res), next, nil
+}
+
+func (r *reviewRepository) FindByProduct(ctx context.Context, product_id int, search string, page, pageSize int) ([]*record.ReviewRecord, int, error) {
 	offset := (page - 1) * pageSize
 
 	req := db.GetReviewsByProductIDParams{
@@ -63,7 +89,7 @@ func (r *reviewRepository) FindByProduct(product_id int, search string, page, pa
 		Offset:    int32(offset),
 	}
 
-	res, err := r.db.GetReviewsByProductID(r.ctx, req)
+	res, err := r.db.GetReviewsByProductID(ctx, req)
 
 	if err != nil {
 
@@ -80,7 +106,7 @@ func (r *reviewRepository) FindByProduct(product_id int, search string, page, pa
 	return r.mapping.ToReviewsProductRecordPagination(res), totalCount, nil
 }
 
-func (r *reviewRepository) FindByActive(search string, page, pageSize int) ([]*record.ReviewRecord, int, error) {
+func (r *reviewRepository) FindByActive(ctx context.Context, search string, page, pageSize int) ([]*record.ReviewRecord, int, error) {
 	offset := (page - 1) * pageSize
 
 	req := db.GetReviewsActiveParams{
@@ -89,7 +115,7 @@ func (r *reviewRepository) FindByActive(search string, page, pageSize int) ([]*r
 		Offset:  int32(offset),
 	}
 
-	res, err := r.db.GetReviewsActive(r.ctx, req)
+	res, err := r.db.GetReviewsActive(ctx, req)
 
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to find shipping address: %w", err)
@@ -105,7 +131,7 @@ func (r *reviewRepository) FindByActive(search string, page, pageSize int) ([]*r
 	return r.mapping.ToReviewsRecordActivePagination(res), totalCount, nil
 }
 
-func (r *reviewRepository) FindByTrashed(search string, page, pageSize int) ([]*record.ReviewRecord, int, error) {
+func (r *reviewRepository) FindByTrashed(ctx context.Context, search string, page, pageSize int) ([]*record.ReviewRecord, int, error) {
 	offset := (page - 1) * pageSize
 
 	req := db.GetReviewsTrashedParams{
@@ -114,7 +140,7 @@ func (r *reviewRepository) FindByTrashed(search string, page, pageSize int) ([]*
 		Offset:  int32(offset),
 	}
 
-	res, err := r.db.GetReviewsTrashed(r.ctx, req)
+	res, err := r.db.GetReviewsTrashed(ctx, req)
 
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to find shipping address: %w", err)
@@ -130,8 +156,8 @@ func (r *reviewRepository) FindByTrashed(search string, page, pageSize int) ([]*
 	return r.mapping.ToReviewsRecordTrashedPagination(res), totalCount, nil
 }
 
-func (r *reviewRepository) FindById(id int) (*record.ReviewRecord, error) {
-	res, err := r.db.GetReviewByID(r.ctx, int32(id))
+func (r *reviewRepository) FindById(ctx context.Context, id int) (*record.ReviewRecord, error) {
+	res, err := r.db.GetReviewByID(ctx, int32(id))
 
 	if err != nil {
 		fmt.Printf("Error fetching review: %v\n", err)
@@ -142,7 +168,7 @@ func (r *reviewRepository) FindById(id int) (*record.ReviewRecord, error) {
 	return r.mapping.ToReviewRecord(res), nil
 }
 
-func (r *reviewRepository) CreateReview(request *requests.CreateReviewRequest) (*record.ReviewRecord, error) {
+func (r *reviewRepository) CreateReview(ctx context.Context, request *requests.CreateReviewRequest) (*record.ReviewRecord, error) {
 	req := db.CreateReviewParams{
 		UserID:    int32(request.UserID),
 		ProductID: int32(request.ProductID),
@@ -150,7 +176,7 @@ func (r *reviewRepository) CreateReview(request *requests.CreateReviewRequest) (
 		Comment:   request.Comment,
 	}
 
-	review, err := r.db.CreateReview(r.ctx, req)
+	review, err := r.db.CreateReview(ctx, req)
 	if err != nil {
 		return nil, errors.New("failed to create review")
 	}
@@ -158,7 +184,7 @@ func (r *reviewRepository) CreateReview(request *requests.CreateReviewRequest) (
 	return r.mapping.ToReviewRecord(review), nil
 }
 
-func (r *reviewRepository) UpdateReview(request *requests.UpdateReviewRequest) (*record.ReviewRecord, error) {
+func (r *reviewRepository) UpdateReview(ctx context.Context, request *requests.UpdateReviewRequest) (*record.ReviewRecord, error) {
 	req := db.UpdateReviewParams{
 		ReviewID: int32(request.ReviewID),
 		Name:     request.Name,
@@ -166,7 +192,7 @@ func (r *reviewRepository) UpdateReview(request *requests.UpdateReviewRequest) (
 		Comment:  request.Comment,
 	}
 
-	res, err := r.db.UpdateReview(r.ctx, req)
+	res, err := r.db.UpdateReview(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update review: %w", err)
 	}
@@ -174,8 +200,8 @@ func (r *reviewRepository) UpdateReview(request *requests.UpdateReviewRequest) (
 	return r.mapping.ToReviewRecord(res), nil
 }
 
-func (r *reviewRepository) TrashReview(shipping_id int) (*record.ReviewRecord, error) {
-	res, err := r.db.TrashReview(r.ctx, int32(shipping_id))
+func (r *reviewRepository) TrashReview(ctx context.Context, shipping_id int) (*record.ReviewRecord, error) {
+	res, err := r.db.TrashReview(ctx, int32(shipping_id))
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to trash shipping address: %w", err)
@@ -184,8 +210,8 @@ func (r *reviewRepository) TrashReview(shipping_id int) (*record.ReviewRecord, e
 	return r.mapping.ToReviewRecord(res), nil
 }
 
-func (r *reviewRepository) RestoreReview(category_id int) (*record.ReviewRecord, error) {
-	res, err := r.db.RestoreReview(r.ctx, int32(category_id))
+func (r *reviewRepository) RestoreReview(ctx context.Context, category_id int) (*record.ReviewRecord, error) {
+	res, err := r.db.RestoreReview(ctx, int32(category_id))
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to shipping address: %w", err)
@@ -194,8 +220,8 @@ func (r *reviewRepository) RestoreReview(category_id int) (*record.ReviewRecord,
 	return r.mapping.ToReviewRecord(res), nil
 }
 
-func (r *reviewRepository) DeleteReviewPermanently(category_id int) (bool, error) {
-	err := r.db.DeleteReviewPermanently(r.ctx, int32(category_id))
+func (r *reviewRepository) DeleteReviewPermanently(ctx context.Context, category_id int) (bool, error) {
+	err := r.db.DeleteReviewPermanently(ctx, int32(category_id))
 
 	if err != nil {
 		return false, fmt.Errorf("failed to delete shipping address: %w", err)
@@ -204,8 +230,8 @@ func (r *reviewRepository) DeleteReviewPermanently(category_id int) (bool, error
 	return true, nil
 }
 
-func (r *reviewRepository) RestoreAllReview() (bool, error) {
-	err := r.db.RestoreAllReviews(r.ctx)
+func (r *reviewRepository) RestoreAllReview(ctx context.Context) (bool, error) {
+	err := r.db.RestoreAllReviews(ctx)
 
 	if err != nil {
 		return false, fmt.Errorf("failed to restore all shipping address: %w", err)
@@ -213,8 +239,8 @@ func (r *reviewRepository) RestoreAllReview() (bool, error) {
 	return true, nil
 }
 
-func (r *reviewRepository) DeleteAllPermanentReview() (bool, error) {
-	err := r.db.DeleteAllPermanentReviews(r.ctx)
+func (r *reviewRepository) DeleteAllPermanentReview(ctx context.Context) (bool, error) {
+	err := r.db.DeleteAllPermanentReviews(ctx)
 
 	if err != nil {
 		return false, fmt.Errorf("failed to delete all shipping address permanently: %w", err)
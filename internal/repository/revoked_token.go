@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"ecommerce/internal/domain/record"
+	"ecommerce/internal/domain/requests"
+	recordmapper "ecommerce/internal/mapper/record"
+	db "ecommerce/pkg/database/schema"
+	"fmt"
+	"time"
+)
+
+type revokedTokenRepository struct {
+	db      *db.Queries
+	mapping recordmapper.RevokedTokenRecordMapping
+}
+
+func NewRevokedTokenRepository(db *db.Queries, mapping recordmapper.RevokedTokenRecordMapping) *revokedTokenRepository {
+	return &revokedTokenRepository{
+		db:      db,
+		mapping: mapping,
+	}
+}
+
+func (r *revokedTokenRepository) FindActive(ctx context.Context, now time.Time) ([]*record.RevokedTokenRecord, error) {
+	res, err := r.db.FindActiveRevokedTokens(ctx, now)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to find revoked tokens: %w", err)
+	}
+
+	return r.mapping.ToRevokedTokensRecord(res), nil
+}
+
+func (r *revokedTokenRepository) RevokeAccessToken(ctx context.Context, request *requests.RevokeAccessTokenRequest) error {
+	err := r.db.RevokeAccessToken(ctx, db.RevokeAccessTokenParams{
+		Jti:       sql.NullString{String: request.TokenID, Valid: true},
+		UserID:    sql.NullInt32{Int32: int32(request.UserID), Valid: request.UserID != 0},
+		Reason:    request.Reason,
+		RevokedAt: request.RevokedAt,
+		ExpiresAt: request.ExpiresAt,
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to revoke access token: %w", err)
+	}
+
+	return nil
+}
+
+func (r *revokedTokenRepository) RevokeUserAccessTokens(ctx context.Context, request *requests.RevokeUserAccessTokensRequest) error {
+	err := r.db.RevokeUserAccessTokens(ctx, db.RevokeUserAccessTokensParams{
+		UserID:    sql.NullInt32{Int32: int32(request.UserID), Valid: true},
+		Reason:    request.Reason,
+		RevokedAt: request.RevokedAt,
+		ExpiresAt: request.ExpiresAt,
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to revoke user access tokens: %w", err)
+	}
+
+	return nil
+}
+
+func (r *revokedTokenRepository) DeleteExpired(ctx context.Context, now time.Time) error {
+	err := r.db.DeleteExpiredRevokedTokens(ctx, now)
+
+	if err != nil {
+		return fmt.Errorf("failed to delete expired revoked tokens: %w", err)
+	}
+
+	return nil
+}
@@ -11,19 +11,17 @@ import (
 
 type roleRepository struct {
 	db      *db.Queries
-	ctx     context.Context
 	mapping recordmapper.RoleRecordMapping
 }
 
-func NewRoleRepository(db *db.Queries, ctx context.Context, mapping recordmapper.RoleRecordMapping) *roleRepository {
+func NewRoleRepository(db *db.Queries, mapping recordmapper.RoleRecordMapping) *roleRepository {
 	return &roleRepository{
 		db:      db,
-		ctx:     ctx,
 		mapping: mapping,
 	}
 }
 
-func (r *roleRepository) FindAllRoles(page int, pageSize int, search string) ([]*record.RoleRecord, int, error) {
+func (r *roleRepository) FindAllRoles(ctx context.Context, page int, pageSize int, search string) ([]*record.RoleRecord, int, error) {
 	offset := (page - 1) * pageSize
 
 	req := db.GetRolesParams{
@@ -32,7 +30,7 @@ func (r *roleRepository) FindAllRoles(page int, pageSize int, search string) ([]
 		Offset:  int32(offset),
 	}
 
-	res, err := r.db.GetRoles(r.ctx, req)
+	res, err := r.db.GetRoles(ctx, req)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to find roles: %w", err)
 	}
@@ -47,8 +45,8 @@ func (r *roleRepository) FindAllRoles(page int, pageSize int, search string) ([]
 	return r.mapping.ToRolesRecordAll(res), totalCount, nil
 }
 
-func (r *roleRepository) FindById(id int) (*record.RoleRecord, error) {
-	res, err := r.db.GetRole(r.ctx, int32(id))
+func (r *roleRepository) FindById(ctx context.Context, id int) (*record.RoleRecord, error) {
+	res, err := r.db.GetRole(ctx, int32(id))
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to find role by id: %w", err)
@@ -57,8 +55,8 @@ func (r *roleRepository) FindById(id int) (*record.RoleRecord, error) {
 	return r.mapping.ToRoleRecord(res), nil
 }
 
-func (r *roleRepository) FindByName(name string) (*record.RoleRecord, error) {
-	res, err := r.db.GetRoleByName(r.ctx, name)
+func (r *roleRepository) FindByName(ctx context.Context, name string) (*record.RoleRecord, error) {
+	res, err := r.db.GetRoleByName(ctx, name)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to find role by name: %w", err)
@@ -67,8 +65,8 @@ func (r *roleRepository) FindByName(name string) (*record.RoleRecord, error) {
 	return r.mapping.ToRoleRecord(res), nil
 }
 
-func (r *roleRepository) FindByUserId(user_id int) ([]*record.RoleRecord, error) {
-	res, err := r.db.GetUserRoles(r.ctx, int32(user_id))
+func (r *roleRepository) FindByUserId(ctx context.Context, user_id int) ([]*record.RoleRecord, error) {
+	res, err := r.db.GetUserRoles(ctx, int32(user_id))
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to find role by user id: %w", err)
@@ -77,7 +75,7 @@ func (r *roleRepository) FindByUserId(user_id int) ([]*record.RoleRecord, error)
 	return r.mapping.ToRolesRecord(res), nil
 }
 
-func (r *roleRepository) FindByActiveRole(page int, pageSize int, search string) ([]*record.RoleRecord, int, error) {
+func (r *roleRepository) FindByActiveRole(ctx context.Context, page int, pageSize int, search string) ([]*record.RoleRecord, int, error) {
 	offset := (page - 1) * pageSize
 
 	req := db.GetActiveRolesParams{
@@ -86,7 +84,7 @@ func (r *roleRepository) FindByActiveRole(page int, pageSize int, search string)
 		Offset:  int32(offset),
 	}
 
-	res, err := r.db.GetActiveRoles(r.ctx, req)
+	res, err := r.db.GetActiveRoles(ctx, req)
 
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to find active roles: %w", err)
@@ -102,7 +100,7 @@ func (r *roleRepository) FindByActiveRole(page int, pageSize int, search string)
 	return r.mapping.ToRolesRecordActive(res), totalCount, nil
 }
 
-func (r *roleRepository) FindByTrashedRole(page int, pageSize int, search string) ([]*record.RoleRecord, int, error) {
+func (r *roleRepository) FindByTrashedRole(ctx context.Context, page int, pageSize int, search string) ([]*record.RoleRecord, int, error) {
 	offset := (page - 1) * pageSize
 
 	req := db.GetTrashedRolesParams{
@@ -111,7 +109,7 @@ func (r *roleRepository) FindByTrashedRole(page int, pageSize int, search string
 		Offset:  int32(offset),
 	}
 
-	res, err := r.db.GetTrashedRoles(r.ctx, req)
+	res, err := r.db.GetTrashedRoles(ctx, req)
 
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to find trashed roles: %w", err)
@@ -127,8 +125,8 @@ func (r *roleRepository) FindByTrashedRole(page int, pageSize int, search string
 	return r.mapping.ToRolesRecordTrashed(res), totalCount, nil
 }
 
-func (r *roleRepository) CreateRole(req *requests.CreateRoleRequest) (*record.RoleRecord, error) {
-	res, err := r.db.CreateRole(r.ctx, req.Name)
+func (r *roleRepository) CreateRole(ctx context.Context, req *requests.CreateRoleRequest) (*record.RoleRecord, error) {
+	res, err := r.db.CreateRole(ctx, req.Name)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to create role: %w", err)
@@ -137,8 +135,8 @@ func (r *roleRepository) CreateRole(req *requests.CreateRoleRequest) (*record.Ro
 	return r.mapping.ToRoleRecord(res), nil
 }
 
-func (r *roleRepository) UpdateRole(req *requests.UpdateRoleRequest) (*record.RoleRecord, error) {
-	res, err := r.db.UpdateRole(r.ctx, db.UpdateRoleParams{
+func (r *roleRepository) UpdateRole(ctx context.Context, req *requests.UpdateRoleRequest) (*record.RoleRecord, error) {
+	res, err := r.db.UpdateRole(ctx, db.UpdateRoleParams{
 		RoleID:   int32(req.ID),
 		RoleName: req.Name,
 	})
@@ -150,14 +148,14 @@ func (r *roleRepository) UpdateRole(req *requests.UpdateRoleRequest) (*record.Ro
 	return r.mapping.ToRoleRecord(res), nil
 }
 
-func (r *roleRepository) TrashedRole(id int) (*record.RoleRecord, error) {
-	err := r.db.TrashRole(r.ctx, int32(id))
+func (r *roleRepository) TrashedRole(ctx context.Context, id int) (*record.RoleRecord, error) {
+	err := r.db.TrashRole(ctx, int32(id))
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to trash role: %w", err)
 	}
 
-	role, err := r.FindById(id)
+	role, err := r.FindById(ctx, id)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to find role after restore: %w", err)
@@ -166,14 +164,14 @@ func (r *roleRepository) TrashedRole(id int) (*record.RoleRecord, error) {
 	return role, nil
 }
 
-func (r *roleRepository) RestoreRole(id int) (*record.RoleRecord, error) {
-	err := r.db.RestoreRole(r.ctx, int32(id))
+func (r *roleRepository) RestoreRole(ctx context.Context, id int) (*record.RoleRecord, error) {
+	err := r.db.RestoreRole(ctx, int32(id))
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to restore role: %w", err)
 	}
 
-	role, err := r.FindById(id)
+	role, err := r.FindById(ctx, id)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to find role after restore: %w", err)
@@ -182,8 +180,8 @@ func (r *roleRepository) RestoreRole(id int) (*record.RoleRecord, error) {
 	return role, nil
 }
 
-func (r *roleRepository) DeleteRolePermanent(role_id int) (bool, error) {
-	err := r.db.DeletePermanentRole(r.ctx, int32(role_id))
+func (r *roleRepository) DeleteRolePermanent(ctx context.Context, role_id int) (bool, error) {
+	err := r.db.DeletePermanentRole(ctx, int32(role_id))
 
 	if err != nil {
 		return false, fmt.Errorf("failed to delete role: %w", err)
@@ -192,8 +190,8 @@ func (r *roleRepository) DeleteRolePermanent(role_id int) (bool, error) {
 	return true, nil
 }
 
-func (r *roleRepository) RestoreAllRole() (bool, error) {
-	err := r.db.RestoreAllRoles(r.ctx)
+func (r *roleRepository) RestoreAllRole(ctx context.Context) (bool, error) {
+	err := r.db.RestoreAllRoles(ctx)
 
 	if err != nil {
 		return false, fmt.Errorf("failed to restore all roles: %w", err)
@@ -202,8 +200,8 @@ func (r *roleRepository) RestoreAllRole() (bool, error) {
 	return true, nil
 }
 
-func (r *roleRepository) DeleteAllRolePermanent() (bool, error) {
-	err := r.db.DeleteAllPermanentRoles(r.ctx)
+func (r *roleRepository) DeleteAllRolePermanent(ctx context.Context) (bool, error) {
+	err := r.db.DeleteAllPermanentRoles(ctx)
 
 	if err != nil {
 		return false, fmt.Errorf("failed to delete all roles permanently: %w", err)
@@ -11,23 +11,20 @@ import (
 
 type shippingAdddressRepository struct {
 	db      *db.Queries
-	ctx     context.Context
 	mapping recordmapper.ShippingAddressMapping
 }
 
 func NewShippingAddressRepository(
 	db *db.Queries,
-	ctx context.Context,
 	mapping recordmapper.ShippingAddressMapping,
 ) *shippingAdddressRepository {
 	return &shippingAdddressRepository{
 		db:      db,
-		ctx:     ctx,
 		mapping: mapping,
 	}
 }
 
-func (r *shippingAdddressRepository) FindAllShippingAddress(search string, page, pageSize int) ([]*record.ShippingAddressRecord, int, error) {
+func (r *shippingAdddressRepository) FindAllShippingAddress(ctx context.Context, search string, page, pageSize int) ([]*record.ShippingAddressRecord, int, error) {
 	offset := (page - 1) * pageSize
 
 	req := db.GetShippingAddressParams{
@@ -36,7 +33,7 @@ func (r *shippingAdddressRepository) FindAllShippingAddress(search string, page,
 		Offset:  int32(offset),
 	}
 
-	res, err := r.db.GetShippingAddress(r.ctx, req)
+	res, err := r.db.GetShippingAddress(ctx, req)
 
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to find shipping address: %w", err)
@@ -52,8 +49,8 @@ func (r *shippingAdddressRepository) FindAllShippingAddress(search string, page,
 	return r.mapping.ToShippingAddresssRecordPagination(res), totalCount, nil
 }
 
-func (r *shippingAdddressRepository) FindById(shipping_id int) (*record.ShippingAddressRecord, error) {
-	res, err := r.db.GetShippingByID(r.ctx, int32(shipping_id))
+func (r *shippingAdddressRepository) FindById(ctx context.Context, shipping_id int) (*record.ShippingAddressRecord, error) {
+	res, err := r.db.GetShippingByID(ctx, int32(shipping_id))
 
 	if err != nil {
 
@@ -63,8 +60,8 @@ func (r *shippingAdddressRepository) FindById(shipping_id int) (*record.Shipping
 	return r.mapping.ToShippingAddressRecord(res), nil
 }
 
-func (r *shippingAdddressRepository) FindByOrder(order_id int) (*record.ShippingAddressRecord, error) {
-	res, err := r.db.GetShippingAddressByOrderID(r.ctx, int32(order_id))
+func (r *shippingAdddressRepository) FindByOrder(ctx context.Context, order_id int) (*record.ShippingAddressRecord, error) {
+	res, err := r.db.GetShippingAddressByOrderID(ctx, int32(order_id))
 
 	if err != nil {
 
@@ -74,7 +71,7 @@ func (r *shippingAdddressRepository) FindByOrder(order_id int) (*record.Shipping
 	return r.mapping.ToShippingAddressRecord(res), nil
 }
 
-func (r *shippingAdddressRepository) FindByActive(search string, page, pageSize int) ([]*record.ShippingAddressRecord, int, error) {
+func (r *shippingAdddressRepository) FindByActive(ctx context.Context, search string, page, pageSize int) ([]*record.ShippingAddressRecord, int, error) {
 	offset := (page - 1) * pageSize
 
 	req := db.GetShippingAddressActiveParams{
@@ -83,7 +80,7 @@ func (r *shippingAdddressRepository) FindByActive(search string, page, pageSize
 		Offset:  int32(offset),
 	}
 
-	res, err := r.db.GetShippingAddressActive(r.ctx, req)
+	res, err := r.db.GetShippingAddressActive(ctx, req)
 
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to find shipping address: %w", err)
@@ -99,7 +96,7 @@ func (r *shippingAdddressRepository) FindByActive(search string, page, pageSize
 	return r.mapping.ToShippingAddresssRecordActivePagination(res), totalCount, nil
 }
 
-func (r *shippingAdddressRepository) FindByTrashed(search string, page, pageSize int) ([]*record.ShippingAddressRecord, int, error) {
+func (r *shippingAdddressRepository) FindByTrashed(ctx context.Context, search string, page, pageSize int) ([]*record.ShippingAddressRecord, int, error) {
 	offset := (page - 1) * pageSize
 
 	req := db.GetShippingAddressTrashedParams{
@@ -108,7 +105,7 @@ func (r *shippingAdddressRepository) FindByTrashed(search string, page, pageSize
 		Offset:  int32(offset),
 	}
 
-	res, err := r.db.GetShippingAddressTrashed(r.ctx, req)
+	res, err := r.db.GetShippingAddressTrashed(ctx, req)
 
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to find shipping address: %w", err)
@@ -124,7 +121,7 @@ func (r *shippingAdddressRepository) FindByTrashed(search string, page, pageSize
 	return r.mapping.ToShippingAddresssRecordTrashedPagination(res), totalCount, nil
 }
 
-func (r *shippingAdddressRepository) CreateShippingAddress(request *requests.CreateShippingAddressRequest) (*record.ShippingAddressRecord, error) {
+func (r *shippingAdddressRepository) CreateShippingAddress(ctx context.Context, request *requests.CreateShippingAddressRequest) (*record.ShippingAddressRecord, error) {
 	req := db.CreateShippingAddressParams{
 		OrderID:        int32(request.OrderID),
 		Alamat:         request.Alamat,
@@ -133,10 +130,10 @@ func (r *shippingAdddressRepository) CreateShippingAddress(request *requests.Cre
 		Negara:         request.Negara,
 		Courier:        request.Courier,
 		ShippingMethod: request.ShippingMethod,
-		ShippingCost:   float64(request.ShippingCost),
+		ShippingCost:   request.ShippingCost,
 	}
 
-	address, err := r.db.CreateShippingAddress(r.ctx, req)
+	address, err := r.db.CreateShippingAddress(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create shipping address: %w", err)
 	}
@@ -144,19 +141,20 @@ func (r *shippingAdddressRepository) CreateShippingAddress(request *requests.Cre
 	return r.mapping.ToShippingAddressRecord(address), nil
 }
 
-func (r *shippingAdddressRepository) UpdateShippingAddress(request *requests.UpdateShippingAddressRequest) (*record.ShippingAddressRecord, error) {
+func (r *shippingAdddressRepository) UpdateShippingAddress(ctx context.Context, request *requests.UpdateShippingAddressRequest) (*record.ShippingAddressRecord, error) {
 	req := db.UpdateShippingAddressParams{
 		ShippingAddressID: int32(request.ShippingID),
+		OrderID:           int32(request.OrderID),
 		Alamat:            request.Alamat,
 		Provinsi:          request.Provinsi,
 		Kota:              request.Kota,
 		Negara:            request.Negara,
 		Courier:           request.Courier,
 		ShippingMethod:    request.ShippingMethod,
-		ShippingCost:      float64(request.ShippingCost),
+		ShippingCost:      request.ShippingCost,
 	}
 
-	res, err := r.db.UpdateShippingAddress(r.ctx, req)
+	res, err := r.db.UpdateShippingAddress(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update shipping address: %w", err)
 	}
@@ -164,8 +162,8 @@ func (r *shippingAdddressRepository) UpdateShippingAddress(request *requests.Upd
 	return r.mapping.ToShippingAddressRecord(res), nil
 }
 
-func (r *shippingAdddressRepository) TrashShippingAddress(shipping_id int) (*record.ShippingAddressRecord, error) {
-	res, err := r.db.TrashShippingAddress(r.ctx, int32(shipping_id))
+func (r *shippingAdddressRepository) TrashShippingAddress(ctx context.Context, shipping_id int) (*record.ShippingAddressRecord, error) {
+	res, err := r.db.TrashShippingAddress(ctx, int32(shipping_id))
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to trash shipping address: %w", err)
@@ -174,8 +172,8 @@ func (r *shippingAdddressRepository) TrashShippingAddress(shipping_id int) (*rec
 	return r.mapping.ToShippingAddressRecord(res), nil
 }
 
-func (r *shippingAdddressRepository) RestoreShippingAddress(category_id int) (*record.ShippingAddressRecord, error) {
-	res, err := r.db.RestoreShippingAddress(r.ctx, int32(category_id))
+func (r *shippingAdddressRepository) RestoreShippingAddress(ctx context.Context, category_id int) (*record.ShippingAddressRecord, error) {
+	res, err := r.db.RestoreShippingAddress(ctx, int32(category_id))
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to shipping address: %w", err)
@@ -184,8 +182,8 @@ func (r *shippingAdddressRepository) RestoreShippingAddress(category_id int) (*r
 	return r.mapping.ToShippingAddressRecord(res), nil
 }
 
-func (r *shippingAdddressRepository) DeleteShippingAddressPermanently(category_id int) (bool, error) {
-	err := r.db.DeleteShippingAddressPermanently(r.ctx, int32(category_id))
+func (r *shippingAdddressRepository) DeleteShippingAddressPermanently(ctx context.Context, category_id int) (bool, error) {
+	err := r.db.DeleteShippingAddressPermanently(ctx, int32(category_id))
 
 	if err != nil {
 		return false, fmt.Errorf("failed to delete shipping address: %w", err)
@@ -194,8 +192,8 @@ func (r *shippingAdddressRepository) DeleteShippingAddressPermanently(category_i
 	return true, nil
 }
 
-func (r *shippingAdddressRepository) RestoreAllShippingAddress() (bool, error) {
-	err := r.db.RestoreAllShippingAddress(r.ctx)
+func (r *shippingAdddressRepository) RestoreAllShippingAddress(ctx context.Context) (bool, error) {
+	err := r.db.RestoreAllShippingAddress(ctx)
 
 	if err != nil {
 		return false, fmt.Errorf("failed to restore all shipping address: %w", err)
@@ -203,8 +201,8 @@ func (r *shippingAdddressRepository) RestoreAllShippingAddress() (bool, error) {
 	return true, nil
 }
 
-func (r *shippingAdddressRepository) DeleteAllPermanentShippingAddress() (bool, error) {
-	err := r.db.DeleteAllPermanentShippingAddress(r.ctx)
+func (r *shippingAdddressRepository) DeleteAllPermanentShippingAddress(ctx context.Context) (bool, error) {
+	err := r.db.DeleteAllPermanentShippingAddress(ctx)
 
 	if err != nil {
 		return false, fmt.Errorf("failed to delete all shipping address permanently: %w", err)
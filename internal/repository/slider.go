@@ -11,23 +11,20 @@ import (
 
 type sliderRepository struct {
 	db      *db.Queries
-	ctx     context.Context
 	mapping recordmapper.SliderMapping
 }
 
 func NewSliderRepository(
 	db *db.Queries,
-	ctx context.Context,
 	mapping recordmapper.SliderMapping,
 ) *sliderRepository {
 	return &sliderRepository{
 		db:      db,
-		ctx:     ctx,
 		mapping: mapping,
 	}
 }
 
-func (r *sliderRepository) FindAllSlider(search string, page, pageSize int) ([]*record.SliderRecord, int, error) {
+func (r *sliderRepository) FindAllSlider(ctx context.Context, search string, page, pageSize int) ([]*record.SliderRecord, int, error) {
 	offset := (page - 1) * pageSize
 
 	req := db.GetSlidersParams{
@@ -36,7 +33,7 @@ func (r *sliderRepository) FindAllSlider(search string, page, pageSize int) ([]*
 		Offset:  int32(offset),
 	}
 
-	res, err := r.db.GetSliders(r.ctx, req)
+	res, err := r.db.GetSliders(ctx, req)
 
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to find shipping address: %w", err)
@@ -52,7 +49,7 @@ func (r *sliderRepository) FindAllSlider(search string, page, pageSize int) ([]*
 	return r.mapping.ToSlidersRecordPagination(res), totalCount, nil
 }
 
-func (r *sliderRepository) FindByActive(search string, page, pageSize int) ([]*record.SliderRecord, int, error) {
+func (r *sliderRepository) FindByActive(ctx context.Context, search string, page, pageSize int) ([]*record.SliderRecord, int, error) {
 	offset := (page - 1) * pageSize
 
 	req := db.GetSlidersActiveParams{
@@ -61,7 +58,7 @@ func (r *sliderRepository) FindByActive(search string, page, pageSize int) ([]*r
 		Offset:  int32(offset),
 	}
 
-	res, err := r.db.GetSlidersActive(r.ctx, req)
+	res, err := r.db.GetSlidersActive(ctx, req)
 
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to find shipping address: %w", err)
@@ -77,7 +74,7 @@ func (r *sliderRepository) FindByActive(search string, page, pageSize int) ([]*r
 	return r.mapping.ToSlidersRecordActivePagination(res), totalCount, nil
 }
 
-func (r *sliderRepository) FindByTrashed(search string, page, pageSize int) ([]*record.SliderRecord, int, error) {
+func (r *sliderRepository) FindByTrashed(ctx context.Context, search string, page, pageSize int) ([]*record.SliderRecord, int, error) {
 	offset := (page - 1) * pageSize
 
 	req := db.GetSlidersTrashedParams{
@@ -86,7 +83,7 @@ func (r *sliderRepository) FindByTrashed(search string, page, pageSize int) ([]*
 		Offset:  int32(offset),
 	}
 
-	res, err := r.db.GetSlidersTrashed(r.ctx, req)
+	res, err := r.db.GetSlidersTrashed(ctx, req)
 
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to find shipping address: %w", err)
@@ -102,13 +99,13 @@ func (r *sliderRepository) FindByTrashed(search string, page, pageSize int) ([]*
 	return r.mapping.ToSlidersRecordTrashedPagination(res), totalCount, nil
 }
 
-func (r *sliderRepository) CreateSlider(request *requests.CreateSliderRequest) (*record.SliderRecord, error) {
+func (r *sliderRepository) CreateSlider(ctx context.Context, request *requests.CreateSliderRequest) (*record.SliderRecord, error) {
 	req := db.CreateSliderParams{
 		Name:  request.Nama,
 		Image: request.FilePath,
 	}
 
-	slider, err := r.db.CreateSlider(r.ctx, req)
+	slider, err := r.db.CreateSlider(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create slider: %w", err)
 	}
@@ -116,14 +113,14 @@ func (r *sliderRepository) CreateSlider(request *requests.CreateSliderRequest) (
 	return r.mapping.ToSliderRecord(slider), nil
 }
 
-func (r *sliderRepository) UpdateSlider(request *requests.UpdateSliderRequest) (*record.SliderRecord, error) {
+func (r *sliderRepository) UpdateSlider(ctx context.Context, request *requests.UpdateSliderRequest) (*record.SliderRecord, error) {
 	req := db.UpdateSliderParams{
 		SliderID: int32(request.ID),
 		Name:     request.Nama,
 		Image:    request.FilePath,
 	}
 
-	res, err := r.db.UpdateSlider(r.ctx, req)
+	res, err := r.db.UpdateSlider(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update slider: %w", err)
 	}
@@ -131,8 +128,8 @@ func (r *sliderRepository) UpdateSlider(request *requests.UpdateSliderRequest) (
 	return r.mapping.ToSliderRecord(res), nil
 }
 
-func (r *sliderRepository) TrashSlider(slider_id int) (*record.SliderRecord, error) {
-	res, err := r.db.TrashSlider(r.ctx, int32(slider_id))
+func (r *sliderRepository) TrashSlider(ctx context.Context, slider_id int) (*record.SliderRecord, error) {
+	res, err := r.db.TrashSlider(ctx, int32(slider_id))
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to trash shipping address: %w", err)
@@ -141,8 +138,8 @@ func (r *sliderRepository) TrashSlider(slider_id int) (*record.SliderRecord, err
 	return r.mapping.ToSliderRecord(res), nil
 }
 
-func (r *sliderRepository) RestoreSlider(slider_id int) (*record.SliderRecord, error) {
-	res, err := r.db.RestoreSlider(r.ctx, int32(slider_id))
+func (r *sliderRepository) RestoreSlider(ctx context.Context, slider_id int) (*record.SliderRecord, error) {
+	res, err := r.db.RestoreSlider(ctx, int32(slider_id))
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to shipping address: %w", err)
@@ -151,8 +148,8 @@ func (r *sliderRepository) RestoreSlider(slider_id int) (*record.SliderRecord, e
 	return r.mapping.ToSliderRecord(res), nil
 }
 
-func (r *sliderRepository) DeleteSliderPermanently(slider_id int) (bool, error) {
-	err := r.db.DeleteSliderPermanently(r.ctx, int32(slider_id))
+func (r *sliderRepository) DeleteSliderPermanently(ctx context.Context, slider_id int) (bool, error) {
+	err := r.db.DeleteSliderPermanently(ctx, int32(slider_id))
 
 	if err != nil {
 		return false, fmt.Errorf("failed to delete shipping address: %w", err)
@@ -161,8 +158,8 @@ func (r *sliderRepository) DeleteSliderPermanently(slider_id int) (bool, error)
 	return true, nil
 }
 
-func (r *sliderRepository) RestoreAllSlider() (bool, error) {
-	err := r.db.RestoreAllSliders(r.ctx)
+func (r *sliderRepository) RestoreAllSlider(ctx context.Context) (bool, error) {
+	err := r.db.RestoreAllSliders(ctx)
 
 	if err != nil {
 		return false, fmt.Errorf("failed to restore all shipping address: %w", err)
@@ -170,8 +167,8 @@ func (r *sliderRepository) RestoreAllSlider() (bool, error) {
 	return true, nil
 }
 
-func (r *sliderRepository) DeleteAllPermanentSlider() (bool, error) {
-	err := r.db.DeleteAllPermanentSliders(r.ctx)
+func (r *sliderRepository) DeleteAllPermanentSlider(ctx context.Context) (bool, error) {
+	err := r.db.DeleteAllPermanentSliders(ctx)
 
 	if err != nil {
 		return false, fmt.Errorf("failed to delete all shipping address permanently: %w", err)
@@ -2,29 +2,31 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"ecommerce/internal/domain/record"
 	"ecommerce/internal/domain/requests"
 	recordmapper "ecommerce/internal/mapper/record"
 	db "ecommerce/pkg/database/schema"
+	"ecommerce/pkg/pagination"
 	"errors"
 	"fmt"
 )
 
+var ErrPaymentEventExists = errors.New("payment event already recorded")
+
 type transactionRepository struct {
 	db      *db.Queries
-	ctx     context.Context
 	mapping recordmapper.TransactionRecordMapping
 }
 
-func NewTransactionRepository(db *db.Queries, ctx context.Context, mapping recordmapper.TransactionRecordMapping) *transactionRepository {
+func NewTransactionRepository(db *db.Queries, mapping recordmapper.TransactionRecordMapping) *transactionRepository {
 	return &transactionRepository{
 		db:      db,
-		ctx:     ctx,
 		mapping: mapping,
 	}
 }
 
-func (r *transactionRepository) FindAllTransactions(search string, page, pageSize int) ([]*record.TransactionRecord, int, error) {
+func (r *transactionRepository) FindAllTransactions(ctx context.Context, search string, page, pageSize int) ([]*record.TransactionRecord, int, error) {
 	offset := (page - 1) * pageSize
 
 	req := db.GetTransactionsParams{
@@ -33,7 +35,7 @@ func (r *transactionRepository) FindAllTransactions(search string, page, pageSiz
 		Offset:  int32(offset),
 	}
 
-	res, err := r.db.GetTransactions(r.ctx, req)
+	res, err := r.db.GetTransactions(ctx, req)
 
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to find transactions: %w", err)
@@ -49,7 +51,34 @@ func (r *transactionRepository) FindAllTransactions(search string, page, pageSiz
 	return r.mapping.ToTransactionsRecordPagination(res), totalCount, nil
 }
 
-func (r *transactionRepository) FindByActive(search string, page, pageSize int) ([]*record.TransactionRecord, int, error) {
+func (r *transactionRepository) FindAllTransactionsCursor(ctx context.Context, search string, cursor *pagination.Cursor, limit int) ([]*record.TransactionRecord, *pagination.Cursor, error) {
+	req := db.GetTransactionsCursorParams{
+		Search:    search,
+		PageLimit: int32(limit + 1),
+	}
+
+	if cursor != nil {
+		req.CursorCreatedAt = sql.NullTime{Time: cursor.CreatedAt, Valid: true}
+		req.CursorID = sql.NullInt32{Int32: int32(cursor.ID), Valid: true}
+	}
+
+	res, err := r.db.GetTransactionsCursor(ctx, req)
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find transactions: %w", err)
+	}
+
+	var next *pagination.Cursor
+	if len(res) > limit {
+		res = res[:limit]
+		last := res[limit-1]
+		next = &pagination.Cursor{CreatedAt: last.CreatedAt.Time, ID: int(last.TransactionID)}
+	}
+
+	return r.mapping.ToTransactionsRecord(res), next, nil
+}
+
+func (r *transactionRepository) FindByActive(ctx context.Context, search string, page, pageSize int) ([]*record.TransactionRecord, int, error) {
 	offset := (page - 1) * pageSize
 
 	req := db.GetTransactionsActiveParams{
@@ -58,7 +87,7 @@ func (r *transactionRepository) FindByActive(search string, page, pageSize int)
 		Offset:  int32(offset),
 	}
 
-	res, err := r.db.GetTransactionsActive(r.ctx, req)
+	res, err := r.db.GetTransactionsActive(ctx, req)
 
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to find transactions: %w", err)
@@ -74,7 +103,7 @@ func (r *transactionRepository) FindByActive(search string, page, pageSize int)
 	return r.mapping.ToTransactionsRecordActivePagination(res), totalCount, nil
 }
 
-func (r *transactionRepository) FindByTrashed(search string, page, pageSize int) ([]*record.TransactionRecord, int, error) {
+func (r *transactionRepository) FindByTrashed(ctx context.Context, search string, page, pageSize int) ([]*record.TransactionRecord, int, error) {
 	offset := (page - 1) * pageSize
 
 	req := db.GetTransactionsTrashedParams{
@@ -83,7 +112,7 @@ func (r *transactionRepository) FindByTrashed(search string, page, pageSize int)
 		Offset:  int32(offset),
 	}
 
-	res, err := r.db.GetTransactionsTrashed(r.ctx, req)
+	res, err := r.db.GetTransactionsTrashed(ctx, req)
 
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to find transactions: %w", err)
@@ -99,7 +128,7 @@ func (r *transactionRepository) FindByTrashed(search string, page, pageSize int)
 	return r.mapping.ToTransactionsRecordTrashedPagination(res), totalCount, nil
 }
 
-func (r *transactionRepository) FindByMerchant(
+func (r *transactionRepository) FindByMerchant(ctx context.Context,
 	merchant_id int,
 	search string,
 	page,
@@ -113,7 +142,7 @@ func (r *transactionRepository) FindByMerchant(
 		Offset:  int32(offset),
 	}
 
-	res, err := r.db.GetTransactionByMerchant(r.ctx, req)
+	res, err := r.db.GetTransactionByMerchant(ctx, req)
 
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to find transactions: %w", err)
@@ -129,8 +158,8 @@ func (r *transactionRepository) FindByMerchant(
 	return r.mapping.ToTransactionMerchantsRecordPagination(res), totalCount, nil
 }
 
-func (r *transactionRepository) FindById(transaction_id int) (*record.TransactionRecord, error) {
-	res, err := r.db.GetTransactionByID(r.ctx, int32(transaction_id))
+func (r *transactionRepository) FindById(ctx context.Context, transaction_id int) (*record.TransactionRecord, error) {
+	res, err := r.db.GetTransactionByID(ctx, int32(transaction_id))
 
 	if err != nil {
 		fmt.Printf("Error fetching user: %v\n", err)
@@ -141,8 +170,8 @@ func (r *transactionRepository) FindById(transaction_id int) (*record.Transactio
 	return r.mapping.ToTransactionRecord(res), nil
 }
 
-func (r *transactionRepository) FindByOrderId(order_id int) (*record.TransactionRecord, error) {
-	res, err := r.db.GetTransactionByOrderID(r.ctx, int32(order_id))
+func (r *transactionRepository) FindByOrderId(ctx context.Context, order_id int) (*record.TransactionRecord, error) {
+	res, err := r.db.GetTransactionByOrderID(ctx, int32(order_id))
 
 	if err != nil {
 		fmt.Printf("Error fetching user: %v\n", err)
@@ -153,16 +182,51 @@ func (r *transactionRepository) FindByOrderId(order_id int) (*record.Transaction
 	return r.mapping.ToTransactionRecord(res), nil
 }
 
-func (r *transactionRepository) CreateTransaction(request *requests.CreateTransactionRequest) (*record.TransactionRecord, error) {
+func (r *transactionRepository) HasLiveTransaction(ctx context.Context, order_id int) (bool, error) {
+	exists, err := r.db.HasLiveTransactionByOrderID(ctx, int32(order_id))
+
+	if err != nil {
+		return false, fmt.Errorf("failed to check transactions of order: %w", err)
+	}
+
+	return exists, nil
+}
+
+func (r *transactionRepository) FindByIdForUpdate(ctx context.Context, transaction_id int) (*record.TransactionRecord, error) {
+	res, err := r.db.GetTransactionByIDForUpdate(ctx, int32(transaction_id))
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock transaction: %w", err)
+	}
+
+	return r.mapping.ToTransactionRecord(res), nil
+}
+
+func (r *transactionRepository) FindByProviderChargeID(ctx context.Context, provider string, charge_id string) (*record.TransactionRecord, error) {
+	res, err := r.db.GetTransactionByProviderChargeID(ctx, db.GetTransactionByProviderChargeIDParams{
+		PaymentProvider:  sql.NullString{String: provider, Valid: true},
+		ProviderChargeID: sql.NullString{String: charge_id, Valid: true},
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to find transaction by charge: %w", err)
+	}
+
+	return r.mapping.ToTransactionRecord(res), nil
+}
+
+func (r *transactionRepository) CreateTransaction(ctx context.Context, request *requests.CreateTransactionRequest) (*record.TransactionRecord, error) {
 	req := db.CreateTransactionParams{
 		OrderID:       int32(request.OrderID),
 		MerchantID:    int32(request.MerchantID),
 		PaymentMethod: request.PaymentMethod,
-		Amount:        int32(request.Amount),
+		Amount:        request.Amount,
+		AmountDue:     request.AmountDue,
+		ChangeAmount:  request.ChangeAmount,
 		PaymentStatus: request.PaymentStatus,
 	}
 
-	transaction, err := r.db.CreateTransaction(r.ctx, req)
+	transaction, err := r.db.CreateTransaction(ctx, req)
 	if err != nil {
 		return nil, errors.New("failed to create transaction")
 	}
@@ -170,17 +234,16 @@ func (r *transactionRepository) CreateTransaction(request *requests.CreateTransa
 	return r.mapping.ToTransactionRecord(transaction), nil
 }
 
-func (r *transactionRepository) UpdateTransaction(request *requests.UpdateTransactionRequest) (*record.TransactionRecord, error) {
+func (r *transactionRepository) UpdateTransaction(ctx context.Context, request *requests.UpdateTransactionRequest) (*record.TransactionRecord, error) {
 	req := db.UpdateTransactionParams{
 		TransactionID: int32(request.TransactionID),
-		MerchantID:    int32(request.MerchantID),
 		PaymentMethod: request.PaymentMethod,
-		Amount:        int32(request.Amount),
-		OrderID:       int32(request.OrderID),
-		PaymentStatus: request.PaymentStatus,
+		Amount:        request.Amount,
+		AmountDue:     request.AmountDue,
+		ChangeAmount:  request.ChangeAmount,
 	}
 
-	res, err := r.db.UpdateTransaction(r.ctx, req)
+	res, err := r.db.UpdateTransaction(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update transaction: %w", err)
 	}
@@ -188,8 +251,144 @@ func (r *transactionRepository) UpdateTransaction(request *requests.UpdateTransa
 	return r.mapping.ToTransactionRecord(res), nil
 }
 
-func (r *transactionRepository) TrashTransaction(transaction_id int) (*record.TransactionRecord, error) {
-	res, err := r.db.TrashTransaction(r.ctx, int32(transaction_id))
+func (r *transactionRepository) UpdateTransactionPayment(ctx context.Context, request *requests.UpdateTransactionPaymentRequest) (*record.TransactionRecord, error) {
+	req := db.UpdateTransactionPaymentParams{
+		TransactionID:    int32(request.TransactionID),
+		PaymentStatus:    request.PaymentStatus,
+		PaymentProvider:  sql.NullString{String: request.PaymentProvider, Valid: request.PaymentProvider != ""},
+		ProviderChargeID: sql.NullString{String: request.ProviderChargeID, Valid: request.ProviderChargeID != ""},
+	}
+
+	res, err := r.db.UpdateTransactionPayment(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update transaction payment: %w", err)
+	}
+
+	return r.mapping.ToTransactionRecord(res), nil
+}
+
+func (r *transactionRepository) CreatePaymentEvent(ctx context.Context, request *requests.CreatePaymentEventRequest) (*record.PaymentEventRecord, error) {
+	req := db.CreatePaymentEventParams{
+		Provider:      request.Provider,
+		EventID:       request.EventID,
+		EventType:     request.EventType,
+		TransactionID: sql.NullInt32{Int32: int32(request.TransactionID), Valid: request.TransactionID > 0},
+		Payload:       request.Payload,
+	}
+
+	res, err := r.db.CreatePaymentEvent(ctx, req)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("event %s: %w", request.EventID, ErrPaymentEventExists)
+		}
+
+		return nil, fmt.Errorf("failed to create payment event: %w", err)
+	}
+
+	return r.mapping.ToPaymentEventRecord(res), nil
+}
+
+func (r *transactionRepository) CreateRefund(ctx context.Context, request *requests.CreateRefundRequest) (*record.RefundRecord, error) {
+	req := db.CreateRefundParams{
+		TransactionID:    int32(request.TransactionID),
+		Amount:           request.Amount,
+		Reason:           sql.NullString{String: request.Reason, Valid: request.Reason != ""},
+		ProviderRefundID: sql.NullString{String: request.ProviderRefundID, Valid: request.ProviderRefundID != ""},
+		Status:           request.Status,
+	}
+
+	res, err := r.db.CreateRefund(ctx, req)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create refund: %w", err)
+	}
+
+	return r.mapping.ToRefundRecord(res), nil
+}
+
+func (r *transactionRepository) UpdateRefundStatus(ctx context.Context, request *requests.UpdateRefundStatusRequest) (*record.RefundRecord, error) {
+	res, err := r.db.UpdateRefundStatus(ctx, db.UpdateRefundStatusParams{
+		RefundID:         int32(request.RefundID),
+		Status:           request.Status,
+		ProviderRefundID: sql.NullString{String: request.ProviderRefundID, Valid: request.ProviderRefundID != ""},
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to update refund status: %w", err)
+	}
+
+	return r.mapping.ToRefundRecord(res), nil
+}
+
+func (r *transactionRepository) CreateRefundItem(ctx context.Context, request *requests.CreateRefundItemRequest) (*record.RefundItemRecord, error) {
+	req := db.CreateRefundItemParams{
+		RefundID:    int32(request.RefundID),
+		OrderItemID: int32(request.OrderItemID),
+		Quantity:    int32(request.Quantity),
+		Amount:      request.Amount,
+	}
+
+	res, err := r.db.CreateRefundItem(ctx, req)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create refund item: %w", err)
+	}
+
+	return r.mapping.ToRefundItemRecord(res), nil
+}
+
+// FindRefundedQuantities returns the quantity already refunded per order
+// item of a transaction.
+func (r *transactionRepository) FindRefundedQuantities(ctx context.Context, transaction_id int) (map[int]int, error) {
+	res, err := r.db.GetRefundedQuantitiesByTransaction(ctx, int32(transaction_id))
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to find refunded quantities: %w", err)
+	}
+
+	quantities := make(map[int]int, len(res))
+	for _, row := range res {
+		quantities[int(row.OrderItemID)] = int(row.RefundedQuantity)
+	}
+
+	return quantities, nil
+}
+
+// FindPendingRefunds returns the refunds of a transaction still waiting on
+// the payment provider outcome, oldest first.
+func (r *transactionRepository) FindPendingRefunds(ctx context.Context, transaction_id int) ([]*record.RefundRecord, error) {
+	res, err := r.db.GetPendingRefundsByTransaction(ctx, int32(transaction_id))
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pending refunds: %w", err)
+	}
+
+	return r.mapping.ToRefundsRecord(res), nil
+}
+
+func (r *transactionRepository) FindRefundItems(ctx context.Context, refund_id int) ([]*record.RefundItemRecord, error) {
+	res, err := r.db.GetRefundItemsByRefund(ctx, int32(refund_id))
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to find refund items: %w", err)
+	}
+
+	return r.mapping.ToRefundItemsRecord(res), nil
+}
+
+func (r *transactionRepository) UpdateRefundedAmount(ctx context.Context, transaction_id int) (*record.TransactionRecord, error) {
+	res, err := r.db.UpdateTransactionRefundedAmount(ctx, int32(transaction_id))
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to update refunded amount: %w", err)
+	}
+
+	return r.mapping.ToTransactionRecord(res), nil
+}
+
+func (r *transactionRepository) TrashTransaction(ctx context.Context, transaction_id int) (*record.TransactionRecord, error) {
+	res, err := r.db.TrashTransaction(ctx, int32(transaction_id))
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to trash user: %w", err)
@@ -198,8 +397,8 @@ func (r *transactionRepository) TrashTransaction(transaction_id int) (*record.Tr
 	return r.mapping.ToTransactionRecord(res), nil
 }
 
-func (r *transactionRepository) RestoreTransaction(transaction_id int) (*record.TransactionRecord, error) {
-	res, err := r.db.RestoreTransaction(r.ctx, int32(transaction_id))
+func (r *transactionRepository) RestoreTransaction(ctx context.Context, transaction_id int) (*record.TransactionRecord, error) {
+	res, err := r.db.RestoreTransaction(ctx, int32(transaction_id))
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to restore topup: %w", err)
@@ -208,8 +407,8 @@ func (r *transactionRepository) RestoreTransaction(transaction_id int) (*record.
 	return r.mapping.ToTransactionRecord(res), nil
 }
 
-func (r *transactionRepository) DeleteTransactionPermanently(transaction_id int) (bool, error) {
-	err := r.db.DeleteTransactionPermanently(r.ctx, int32(transaction_id))
+func (r *transactionRepository) DeleteTransactionPermanently(ctx context.Context, transaction_id int) (bool, error) {
+	err := r.db.DeleteTransactionPermanently(ctx, int32(transaction_id))
 
 	if err != nil {
 		return false, fmt.Errorf("failed to delete transactions: %w", err)
@@ -218,8 +417,8 @@ func (r *transactionRepository) DeleteTransactionPermanently(transaction_id int)
 	return true, nil
 }
 
-func (r *transactionRepository) RestoreAllTransactions() (bool, error) {
-	err := r.db.RestoreAllTransactions(r.ctx)
+func (r *transactionRepository) RestoreAllTransactions(ctx context.Context) (bool, error) {
+	err := r.db.RestoreAllTransactions(ctx)
 
 	if err != nil {
 		return false, fmt.Errorf("failed to restore all transactions: %w", err)
@@ -227,8 +426,8 @@ func (r *transactionRepository) RestoreAllTransactions() (bool, error) {
 	return true, nil
 }
 
-func (r *transactionRepository) DeleteAllTransactionPermanent() (bool, error) {
-	err := r.db.DeleteAllPermanentTransactions(r.ctx)
+func (r *transactionRepository) DeleteAllTransactionPermanent(ctx context.Context) (bool, error) {
+	err := r.db.DeleteAllPermanentTransactions(ctx)
 
 	if err != nil {
 		return false, fmt.Errorf("failed to delete all transactions permanently: %w", err)
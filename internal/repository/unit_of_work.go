@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// UnitOfWork runs a group of repository calls inside a single database
+// transaction. The repositories passed to fn are bound to that transaction,
+// so either every write made through them is committed or none is.
+type UnitOfWork interface {
+	WithinTransaction(ctx context.Context, fn func(repos *Repositories) error) error
+}
+
+type unitOfWork struct {
+	conn *sql.DB
+	deps Deps
+}
+
+func NewUnitOfWork(deps Deps) *unitOfWork {
+	return &unitOfWork{
+		conn: deps.Conn,
+		deps: deps,
+	}
+}
+
+func (u *unitOfWork) WithinTransaction(ctx context.Context, fn func(repos *Repositories) error) error {
+	tx, err := u.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	txDeps := u.deps
+	txDeps.DB = u.deps.DB.WithTx(tx)
+
+	repos := newRepositories(txDeps)
+	repos.UnitOfWork = &txUnitOfWork{repos: repos}
+
+	if err := fn(repos); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("failed to rollback transaction: %v (cause: %w)", rbErr, err)
+		}
+
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// txUnitOfWork is handed out inside an open transaction so nested calls
+// join the outer transaction instead of opening a new one.
+type txUnitOfWork struct {
+	repos *Repositories
+}
+
+func (u *txUnitOfWork) WithinTransaction(ctx context.Context, fn func(repos *Repositories) error) error {
+	return fn(u.repos)
+}
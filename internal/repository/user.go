@@ -12,19 +12,17 @@ import (
 
 type userRepository struct {
 	db      *db.Queries
-	ctx     context.Context
 	mapping recordmapper.UserRecordMapping
 }
 
-func NewUserRepository(db *db.Queries, ctx context.Context, mapping recordmapper.UserRecordMapping) *userRepository {
+func NewUserRepository(db *db.Queries, mapping recordmapper.UserRecordMapping) *userRepository {
 	return &userRepository{
 		db:      db,
-		ctx:     ctx,
 		mapping: mapping,
 	}
 }
 
-func (r *userRepository) FindAllUsers(search string, page, pageSize int) ([]*record.UserRecord, int, error) {
+func (r *userRepository) FindAllUsers(ctx context.Context, search string, page, pageSize int) ([]*record.UserRecord, int, error) {
 	offset := (page - 1) * pageSize
 
 	req := db.GetUsersParams{
@@ -33,7 +31,7 @@ func (r *userRepository) FindAllUsers(search string, page, pageSize int) ([]*rec
 		Offset:  int32(offset),
 	}
 
-	res, err := r.db.GetUsers(r.ctx, req)
+	res, err := r.db.GetUsers(ctx, req)
 
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to find users: %w", err)
@@ -49,8 +47,8 @@ func (r *userRepository) FindAllUsers(search string, page, pageSize int) ([]*rec
 	return r.mapping.ToUsersRecordPagination(res), totalCount, nil
 }
 
-func (r *userRepository) FindById(user_id int) (*record.UserRecord, error) {
-	res, err := r.db.GetUserByID(r.ctx, int32(user_id))
+func (r *userRepository) FindById(ctx context.Context, user_id int) (*record.UserRecord, error) {
+	res, err := r.db.GetUserByID(ctx, int32(user_id))
 
 	if err != nil {
 		fmt.Printf("Error fetching user: %v\n", err)
@@ -61,7 +59,7 @@ func (r *userRepository) FindById(user_id int) (*record.UserRecord, error) {
 	return r.mapping.ToUserRecord(res), nil
 }
 
-func (r *userRepository) FindByActive(search string, page, pageSize int) ([]*record.UserRecord, int, error) {
+func (r *userRepository) FindByActive(ctx context.Context, search string, page, pageSize int) ([]*record.UserRecord, int, error) {
 	offset := (page - 1) * pageSize
 
 	req := db.GetUsersActiveParams{
@@ -70,7 +68,7 @@ func (r *userRepository) FindByActive(search string, page, pageSize int) ([]*rec
 		Offset:  int32(offset),
 	}
 
-	res, err := r.db.GetUsersActive(r.ctx, req)
+	res, err := r.db.GetUsersActive(ctx, req)
 
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to find users: %w", err)
@@ -86,7 +84,7 @@ func (r *userRepository) FindByActive(search string, page, pageSize int) ([]*rec
 	return r.mapping.ToUsersRecordActivePagination(res), totalCount, nil
 }
 
-func (r *userRepository) FindByTrashed(search string, page, pageSize int) ([]*record.UserRecord, int, error) {
+func (r *userRepository) FindByTrashed(ctx context.Context, search string, page, pageSize int) ([]*record.UserRecord, int, error) {
 	offset := (page - 1) * pageSize
 
 	req := db.GetUserTrashedParams{
@@ -95,7 +93,7 @@ func (r *userRepository) FindByTrashed(search string, page, pageSize int) ([]*re
 		Offset:  int32(offset),
 	}
 
-	res, err := r.db.GetUserTrashed(r.ctx, req)
+	res, err := r.db.GetUserTrashed(ctx, req)
 
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to find users: %w", err)
@@ -111,8 +109,8 @@ func (r *userRepository) FindByTrashed(search string, page, pageSize int) ([]*re
 	return r.mapping.ToUsersRecordTrashedPagination(res), totalCount, nil
 }
 
-func (r *userRepository) FindByEmail(email string) (*record.UserRecord, error) {
-	res, err := r.db.GetUserByEmail(r.ctx, email)
+func (r *userRepository) FindByEmail(ctx context.Context, email string) (*record.UserRecord, error) {
+	res, err := r.db.GetUserByEmail(ctx, email)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to find user by email: %w", err)
@@ -121,7 +119,7 @@ func (r *userRepository) FindByEmail(email string) (*record.UserRecord, error) {
 	return r.mapping.ToUserRecord(res), nil
 }
 
-func (r *userRepository) CreateUser(request *requests.CreateUserRequest) (*record.UserRecord, error) {
+func (r *userRepository) CreateUser(ctx context.Context, request *requests.CreateUserRequest) (*record.UserRecord, error) {
 	req := db.CreateUserParams{
 		Firstname: request.FirstName,
 		Lastname:  request.LastName,
@@ -129,7 +127,7 @@ func (r *userRepository) CreateUser(request *requests.CreateUserRequest) (*recor
 		Password:  request.Password,
 	}
 
-	user, err := r.db.CreateUser(r.ctx, req)
+	user, err := r.db.CreateUser(ctx, req)
 
 	if err != nil {
 		return nil, errors.New("failed create user")
@@ -138,7 +136,7 @@ func (r *userRepository) CreateUser(request *requests.CreateUserRequest) (*recor
 	return r.mapping.ToUserRecord(user), nil
 }
 
-func (r *userRepository) UpdateUser(request *requests.UpdateUserRequest) (*record.UserRecord, error) {
+func (r *userRepository) UpdateUser(ctx context.Context, request *requests.UpdateUserRequest) (*record.UserRecord, error) {
 	req := db.UpdateUserParams{
 		UserID:    int32(request.UserID),
 		Firstname: request.FirstName,
@@ -147,7 +145,7 @@ func (r *userRepository) UpdateUser(request *requests.UpdateUserRequest) (*recor
 		Password:  request.Password,
 	}
 
-	res, err := r.db.UpdateUser(r.ctx, req)
+	res, err := r.db.UpdateUser(ctx, req)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to update user: %w", err)
@@ -156,8 +154,8 @@ func (r *userRepository) UpdateUser(request *requests.UpdateUserRequest) (*recor
 	return r.mapping.ToUserRecord(res), nil
 }
 
-func (r *userRepository) TrashedUser(user_id int) (*record.UserRecord, error) {
-	res, err := r.db.TrashUser(r.ctx, int32(user_id))
+func (r *userRepository) TrashedUser(ctx context.Context, user_id int) (*record.UserRecord, error) {
+	res, err := r.db.TrashUser(ctx, int32(user_id))
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to trash user: %w", err)
@@ -166,8 +164,8 @@ func (r *userRepository) TrashedUser(user_id int) (*record.UserRecord, error) {
 	return r.mapping.ToUserRecord(res), nil
 }
 
-func (r *userRepository) RestoreUser(user_id int) (*record.UserRecord, error) {
-	res, err := r.db.RestoreUser(r.ctx, int32(user_id))
+func (r *userRepository) RestoreUser(ctx context.Context, user_id int) (*record.UserRecord, error) {
+	res, err := r.db.RestoreUser(ctx, int32(user_id))
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to restore topup: %w", err)
@@ -176,8 +174,8 @@ func (r *userRepository) RestoreUser(user_id int) (*record.UserRecord, error) {
 	return r.mapping.ToUserRecord(res), nil
 }
 
-func (r *userRepository) DeleteUserPermanent(user_id int) (bool, error) {
-	err := r.db.DeleteUserPermanently(r.ctx, int32(user_id))
+func (r *userRepository) DeleteUserPermanent(ctx context.Context, user_id int) (bool, error) {
+	err := r.db.DeleteUserPermanently(ctx, int32(user_id))
 
 	if err != nil {
 		return false, fmt.Errorf("failed to delete user: %w", err)
@@ -186,8 +184,8 @@ func (r *userRepository) DeleteUserPermanent(user_id int) (bool, error) {
 	return true, nil
 }
 
-func (r *userRepository) RestoreAllUser() (bool, error) {
-	err := r.db.RestoreAllUsers(r.ctx)
+func (r *userRepository) RestoreAllUser(ctx context.Context) (bool, error) {
+	err := r.db.RestoreAllUsers(ctx)
 
 	if err != nil {
 		return false, fmt.Errorf("failed to restore all users: %w", err)
@@ -195,11 +193,34 @@ func (r *userRepository) RestoreAllUser() (bool, error) {
 	return true, nil
 }
 
-func (r *userRepository) DeleteAllUserPermanent() (bool, error) {
-	err := r.db.DeleteAllPermanentUsers(r.ctx)
+func (r *userRepository) DeleteAllUserPermanent(ctx context.Context) (bool, error) {
+	err := r.db.DeleteAllPermanentUsers(ctx)
 
 	if err != nil {
 		return false, fmt.Errorf("failed to delete all users permanently: %w", err)
 	}
 	return true, nil
 }
+
+func (r *userRepository) UpdatePassword(ctx context.Context, user_id int, password string) (*record.UserRecord, error) {
+	res, err := r.db.UpdateUserPassword(ctx, db.UpdateUserPasswordParams{
+		UserID:   int32(user_id),
+		Password: password,
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to update user password: %w", err)
+	}
+
+	return r.mapping.ToUserRecord(res), nil
+}
+
+func (r *userRepository) VerifyEmail(ctx context.Context, user_id int) (*record.UserRecord, error) {
+	res, err := r.db.VerifyUserEmail(ctx, int32(user_id))
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify user email: %w", err)
+	}
+
+	return r.mapping.ToUserRecord(res), nil
+}
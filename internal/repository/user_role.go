@@ -11,20 +11,18 @@ import (
 
 type userRoleRepository struct {
 	db      *db.Queries
-	ctx     context.Context
 	mapping recordmapper.UserRoleRecordMapping
 }
 
-func NewUserRoleRepository(db *db.Queries, ctx context.Context, mapping recordmapper.UserRoleRecordMapping) *userRoleRepository {
+func NewUserRoleRepository(db *db.Queries, mapping recordmapper.UserRoleRecordMapping) *userRoleRepository {
 	return &userRoleRepository{
 		db:      db,
-		ctx:     ctx,
 		mapping: mapping,
 	}
 }
 
-func (r *userRoleRepository) AssignRoleToUser(req *requests.CreateUserRoleRequest) (*record.UserRoleRecord, error) {
-	res, err := r.db.AssignRoleToUser(r.ctx, db.AssignRoleToUserParams{
+func (r *userRoleRepository) AssignRoleToUser(ctx context.Context, req *requests.CreateUserRoleRequest) (*record.UserRoleRecord, error) {
+	res, err := r.db.AssignRoleToUser(ctx, db.AssignRoleToUserParams{
 		UserID: int32(req.UserId),
 		RoleID: int32(req.RoleId),
 	})
@@ -36,8 +34,8 @@ func (r *userRoleRepository) AssignRoleToUser(req *requests.CreateUserRoleReques
 	return r.mapping.ToUserRoleRecord(res), nil
 }
 
-func (r *userRoleRepository) RemoveRoleFromUser(req *requests.RemoveUserRoleRequest) error {
-	err := r.db.RemoveRoleFromUser(r.ctx, db.RemoveRoleFromUserParams{
+func (r *userRoleRepository) RemoveRoleFromUser(ctx context.Context, req *requests.RemoveUserRoleRequest) error {
+	err := r.db.RemoveRoleFromUser(ctx, db.RemoveRoleFromUserParams{
 		UserID: int32(req.UserId),
 		RoleID: int32(req.RoleId),
 	})
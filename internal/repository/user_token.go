@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"ecommerce/internal/domain/record"
+	"ecommerce/internal/domain/requests"
+	recordmapper "ecommerce/internal/mapper/record"
+	db "ecommerce/pkg/database/schema"
+	"fmt"
+	"time"
+)
+
+type userTokenRepository struct {
+	db      *db.Queries
+	mapping recordmapper.UserTokenRecordMapping
+}
+
+func NewUserTokenRepository(db *db.Queries, mapping recordmapper.UserTokenRecordMapping) *userTokenRepository {
+	return &userTokenRepository{
+		db:      db,
+		mapping: mapping,
+	}
+}
+
+func (r *userTokenRepository) CreateToken(ctx context.Context, request *requests.CreateUserTokenRequest) (*record.UserTokenRecord, error) {
+	res, err := r.db.CreateUserToken(ctx, db.CreateUserTokenParams{
+		UserID:    int32(request.UserID),
+		Purpose:   request.Purpose,
+		Token:     request.Token,
+		ExpiresAt: request.ExpiresAt,
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user token: %w", err)
+	}
+
+	return r.mapping.ToUserTokenRecord(res), nil
+}
+
+// ConsumeToken marks the token used and returns it. sql.ErrNoRows is wrapped
+// when the token is unknown, expired, already used or meant for another
+// purpose.
+func (r *userTokenRepository) ConsumeToken(ctx context.Context, token string, purpose string, now time.Time) (*record.UserTokenRecord, error) {
+	res, err := r.db.ConsumeUserToken(ctx, db.ConsumeUserTokenParams{
+		Now:     now,
+		Token:   token,
+		Purpose: purpose,
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to consume user token: %w", err)
+	}
+
+	return r.mapping.ToUserTokenRecord(res), nil
+}
+
+func (r *userTokenRepository) InvalidateTokens(ctx context.Context, user_id int, purpose string, now time.Time) error {
+	err := r.db.InvalidateUserTokens(ctx, db.InvalidateUserTokensParams{
+		Now:     now,
+		UserID:  int32(user_id),
+		Purpose: purpose,
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to invalidate user tokens: %w", err)
+	}
+
+	return nil
+}
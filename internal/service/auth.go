@@ -1,7 +1,9 @@
 package service
 
 import (
+	"context"
 	"database/sql"
+	"ecommerce/internal/domain/record"
 	"ecommerce/internal/domain/requests"
 	"ecommerce/internal/domain/response"
 	response_service "ecommerce/internal/mapper/response/services"
@@ -9,42 +11,76 @@ import (
 	"ecommerce/pkg/auth"
 	"ecommerce/pkg/hash"
 	"ecommerce/pkg/logger"
+	"ecommerce/pkg/mailer"
 	"errors"
+	"fmt"
+	"net/url"
 	"strconv"
+	"sync"
 	"time"
 
 	"go.uber.org/zap"
 )
 
+const (
+	refreshTokenTTL        = 24 * time.Hour
+	refreshTokenTimeLayout = "2006-01-02 15:04:05"
+)
+
+// customerRole is the role every new account gets. It grants no permission
+// beyond what any signed-in user has.
+const customerRole = "Customer"
+
+// Purposes of the single-use tokens mailed to users, and how long each stays
+// valid.
+const (
+	userTokenEmailVerification = "email_verification"
+	userTokenPasswordReset     = "password_reset"
+
+	emailVerificationTTL = 24 * time.Hour
+	passwordResetTTL     = time.Hour
+)
+
 type authService struct {
-	auth         repository.UserRepository
-	refreshToken repository.RefreshTokenRepository
-	userRole     repository.UserRoleRepository
-	role         repository.RoleRepository
-	hash         hash.HashPassword
-	token        auth.TokenManager
-	logger       logger.LoggerInterface
-	mapping      response_service.UserResponseMapper
+	uow            repository.UnitOfWork
+	auth           repository.UserRepository
+	refreshToken   repository.RefreshTokenRepository
+	userRole       repository.UserRoleRepository
+	role           repository.RoleRepository
+	hash           hash.HashPassword
+	token          auth.TokenManager
+	logger         logger.LoggerInterface
+	mapping        response_service.UserResponseMapper
+	refreshMapping response_service.RefreshTokenResponseMapper
+	revocation     TokenRevocationService
+	userToken      repository.UserTokenRepository
+	mailer         mailer.Mailer
+	appURL         string
+	throttle       LoginThrottleService
+
+	dummyHashOnce sync.Once
+	dummyHash     string
 }
 
-func NewAuthService(auth repository.UserRepository, refreshToken repository.RefreshTokenRepository, role repository.RoleRepository, userRole repository.UserRoleRepository, hash hash.HashPassword, token auth.TokenManager, logger logger.LoggerInterface, mapping response_service.UserResponseMapper) *authService {
-	return &authService{auth: auth, refreshToken: refreshToken, role: role, userRole: userRole, hash: hash, token: token, logger: logger, mapping: mapping}
+func NewAuthService(uow repository.UnitOfWork, auth repository.UserRepository, refreshToken repository.RefreshTokenRepository, role repository.RoleRepository, userRole repository.UserRoleRepository, hash hash.HashPassword, token auth.TokenManager, logger logger.LoggerInterface, mapping response_service.UserResponseMapper, refreshMapping response_service.RefreshTokenResponseMapper, revocation TokenRevocationService, userToken repository.UserTokenRepository, mailer mailer.Mailer, appURL string, throttle LoginThrottleService) *authService {
+	return &authService{uow: uow, auth: auth, refreshToken: refreshToken, role: role, userRole: userRole, hash: hash, token: token, logger: logger, mapping: mapping, refreshMapping: refreshMapping, revocation: revocation, userToken: userToken, mailer: mailer, appURL: appURL, throttle: throttle}
 }
 
-func (s *authService) Register(request *requests.CreateUserRequest) (*response.UserResponse, *response.ErrorResponse) {
+func (s *authService) Register(ctx context.Context, request *requests.CreateUserRequest) (*response.UserResponse, *response.ErrorResponse) {
 	s.logger.Debug("Starting user registration",
 		zap.String("email", request.Email),
 		zap.String("firstname", request.FirstName),
 		zap.String("lastname", request.LastName),
 	)
 
-	_, err := s.auth.FindByEmail(request.Email)
+	_, err := s.auth.FindByEmail(ctx, request.Email)
 
 	if err == nil {
 		s.logger.Error("Email already exists", zap.String("email", request.Email))
 		return nil, &response.ErrorResponse{
 			Status:  "error",
 			Message: "Email already exists",
+			Code:    response.ErrCodeConflict,
 		}
 	}
 
@@ -59,7 +95,7 @@ func (s *authService) Register(request *requests.CreateUserRequest) (*response.U
 	}
 	request.Password = passwordHash
 
-	res, err := s.auth.CreateUser(request)
+	res, err := s.auth.CreateUser(ctx, request)
 	if err != nil {
 		s.logger.Error("Failed to create user", zap.Error(err))
 		return nil, &response.ErrorResponse{
@@ -68,19 +104,19 @@ func (s *authService) Register(request *requests.CreateUserRequest) (*response.U
 		}
 	}
 
-	_, err = s.role.FindByName("User Permission 2")
+	role, err := s.role.FindByName(ctx, customerRole)
 
 	if err != nil {
-		s.logger.Error("Failed to find role", zap.Error(err))
+		s.logger.Error("Failed to find role", zap.String("role", customerRole), zap.Error(err))
 		return nil, &response.ErrorResponse{
 			Status:  "error",
 			Message: "Failed to find role: ",
 		}
 	}
 
-	_, err = s.userRole.AssignRoleToUser(&requests.CreateUserRoleRequest{
+	_, err = s.userRole.AssignRoleToUser(ctx, &requests.CreateUserRoleRequest{
 		UserId: res.ID,
-		RoleId: 1,
+		RoleId: role.ID,
 	})
 
 	if err != nil {
@@ -91,6 +127,12 @@ func (s *authService) Register(request *requests.CreateUserRequest) (*response.U
 		}
 	}
 
+	// The account exists either way; a failed email is sent again on the
+	// next login attempt.
+	if err := s.sendVerificationEmail(ctx, res); err != nil {
+		s.logger.Error("Failed to send verification email", zap.Int("userID", res.ID), zap.Error(err))
+	}
+
 	s.logger.Debug("User registered successfully", zap.String("email", request.Email))
 
 	so := s.mapping.ToUserResponse(res)
@@ -98,32 +140,55 @@ func (s *authService) Register(request *requests.CreateUserRequest) (*response.U
 	return so, nil
 }
 
-func (s *authService) Login(request *requests.AuthRequest) (*response.TokenResponse, *response.ErrorResponse) {
+// Login signs a user in with their email and password. Unknown emails and
+// wrong passwords fail the same way and take as long, so the response does not
+// tell which emails are registered. Repeated failures lock the email and the
+// client's IP address out for a while (see loginThrottleService).
+func (s *authService) Login(ctx context.Context, request *requests.AuthRequest) (*response.TokenResponse, *response.ErrorResponse) {
 	s.logger.Debug("Starting login process",
 		zap.String("email", request.Email),
 	)
 
-	res, err := s.auth.FindByEmail(request.Email)
+	if errResp := s.throttle.Check(ctx, request.Email, request.IPAddress); errResp != nil {
+		return nil, errResp
+	}
+
+	res, err := s.auth.FindByEmail(ctx, request.Email)
 
 	if err != nil {
 		s.logger.Error("Failed to get user", zap.Error(err))
-		return nil, &response.ErrorResponse{
-			Status:  "error",
-			Message: "Failed to get user: " + err.Error(),
-		}
+
+		s.hash.ComparePassword(s.dummyPasswordHash(), request.Password)
+		s.throttle.RecordFailure(ctx, request.Email, request.IPAddress)
+
+		return nil, invalidCredentialsError()
 	}
 
 	err = s.hash.ComparePassword(res.Password, request.Password)
 
 	if err != nil {
 		s.logger.Error("Failed to compare password", zap.Error(err))
+
+		s.throttle.RecordFailure(ctx, request.Email, request.IPAddress)
+
+		return nil, invalidCredentialsError()
+	}
+
+	s.throttle.RecordSuccess(ctx, request.Email)
+
+	if res.EmailVerifiedAt == nil {
+		if err := s.sendVerificationEmail(ctx, res); err != nil {
+			s.logger.Error("Failed to send verification email", zap.Int("userID", res.ID), zap.Error(err))
+		}
+
 		return nil, &response.ErrorResponse{
 			Status:  "error",
-			Message: "Invalid password",
+			Message: "Email address has not been verified, a new verification link has been sent",
+			Code:    response.ErrCodeForbidden,
 		}
 	}
 
-	token, err := s.createAccessToken(res.ID)
+	token, err := s.createAccessToken(ctx, res.ID)
 
 	if err != nil {
 		s.logger.Error("Failed to generate JWT token", zap.Error(err))
@@ -133,7 +198,24 @@ func (s *authService) Login(request *requests.AuthRequest) (*response.TokenRespo
 		}
 	}
 
-	refreshToken, err := s.createRefreshToken(res.ID)
+	familyID, err := auth.NewOpaqueToken(16)
+
+	if err != nil {
+		s.logger.Error("Failed to generate session id", zap.Error(err))
+		return nil, &response.ErrorResponse{
+			Status:  "error",
+			Message: "Failed to generate refresh token: " + err.Error(),
+		}
+	}
+
+	refreshToken, err := s.createRefreshToken(ctx, s.refreshToken, &requests.CreateRefreshToken{
+		UserId:     res.ID,
+		FamilyID:   familyID,
+		Device:     request.Device,
+		UserAgent:  request.UserAgent,
+		IPAddress:  request.IPAddress,
+		SignedInAt: time.Now().Format(refreshTokenTimeLayout),
+	})
 
 	if err != nil {
 		s.logger.Error("Failed to generate refresh token", zap.Error(err))
@@ -148,94 +230,367 @@ func (s *authService) Login(request *requests.AuthRequest) (*response.TokenRespo
 	return &response.TokenResponse{AccessToken: token, RefreshToken: refreshToken}, nil
 }
 
-func (s *authService) RefreshToken(token string) (*response.TokenResponse, *response.ErrorResponse) {
-	s.logger.Debug("Refreshing token",
-		zap.String("token", token),
-	)
+// RefreshToken exchanges a refresh token for a new access/refresh token pair.
+// Every refresh token can be exchanged once; the replacement joins the same
+// family (session). Presenting a token that was already exchanged means it
+// leaked, so the whole family is revoked and the session has to sign in again.
+func (s *authService) RefreshToken(ctx context.Context, request *requests.RefreshTokenRequest) (*response.TokenResponse, *response.ErrorResponse) {
+	s.logger.Debug("Refreshing token")
 
-	userIdStr, err := s.token.ValidateToken(token)
+	current, err := s.refreshToken.FindByToken(ctx, auth.HashOpaqueToken(request.RefreshToken))
 
 	if err != nil {
-		if errors.Is(err, auth.ErrTokenExpired) {
-			if err := s.refreshToken.DeleteRefreshToken(token); err != nil {
-				s.logger.Error("Failed to delete expired refresh token", zap.Error(err))
+		s.logger.Error("Invalid refresh token", zap.Error(err))
+		return nil, &response.ErrorResponse{
+			Status:  "error",
+			Message: "Invalid refresh token",
+			Code:    response.ErrCodeUnauthorized,
+		}
+	}
+
+	if current.RevokedAt != nil {
+		s.logger.Error("Refresh token has been revoked",
+			zap.Int("userID", current.UserID),
+			zap.String("familyID", current.FamilyID),
+		)
+		return nil, &response.ErrorResponse{
+			Status:  "error",
+			Message: "Refresh token has been revoked",
+			Code:    response.ErrCodeUnauthorized,
+		}
+	}
+
+	if current.UsedAt != nil {
+		return nil, s.revokeReusedFamily(ctx, current)
+	}
 
-				return nil, &response.ErrorResponse{
+	expiresAt, err := time.ParseInLocation(refreshTokenTimeLayout, current.ExpiredAt, time.Local)
+
+	if err != nil || time.Now().After(expiresAt) {
+		s.logger.Error("Refresh token has expired", zap.Int("userID", current.UserID))
+		return nil, &response.ErrorResponse{
+			Status:  "error",
+			Message: "Refresh token has expired",
+			Code:    response.ErrCodeUnauthorized,
+		}
+	}
+
+	var (
+		refreshToken string
+		reused       bool
+	)
+
+	errResp := withinTransaction(ctx, s.uow, s.logger, "Failed to update refresh token in storage", func(repos *repository.Repositories) *response.ErrorResponse {
+		if _, err := repos.RefreshToken.MarkUsed(ctx, current.ID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				// A concurrent request exchanged the same token first.
+				reused = true
+				return &response.ErrorResponse{
 					Status:  "error",
-					Message: "Failed to delete expired refresh token",
+					Message: "Refresh token reuse detected",
+					Code:    response.ErrCodeUnauthorized,
 				}
 			}
 
-			s.logger.Error("Refresh token has expired", zap.Error(err))
+			s.logger.Error("Failed to mark refresh token as used", zap.Error(err))
+			return &response.ErrorResponse{
+				Status:  "error",
+				Message: "Failed to update refresh token in storage",
+			}
+		}
 
-			return nil, &response.ErrorResponse{
+		refreshToken, err = s.createRefreshToken(ctx, repos.RefreshToken, &requests.CreateRefreshToken{
+			UserId:     current.UserID,
+			FamilyID:   current.FamilyID,
+			Device:     current.Device,
+			UserAgent:  request.UserAgent,
+			IPAddress:  request.IPAddress,
+			SignedInAt: current.SignedInAt,
+		})
+
+		if err != nil {
+			s.logger.Error("Failed to generate new refresh token", zap.Error(err))
+			return &response.ErrorResponse{
 				Status:  "error",
-				Message: "Refresh token has expired",
+				Message: "Failed to generate new refresh token",
 			}
 		}
-		s.logger.Error("Invalid refresh token", zap.Error(err))
+
+		return nil
+	})
+
+	if reused {
+		return nil, s.revokeReusedFamily(ctx, current)
+	}
+
+	if errResp != nil {
+		return nil, errResp
+	}
+
+	accessToken, err := s.createAccessToken(ctx, current.UserID)
+	if err != nil {
+		s.logger.Error("Failed to generate new access token", zap.Error(err))
+
 		return nil, &response.ErrorResponse{
 			Status:  "error",
-			Message: "Invalid refresh token",
+			Message: "Failed to generate new access token",
 		}
 	}
 
-	userId, err := strconv.Atoi(userIdStr)
+	s.logger.Debug("Refresh token refreshed successfully")
+
+	return &response.TokenResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// Logout ends the session the given refresh token belongs to, and revokes
+// the access token sent along with it. Unknown or already revoked tokens are
+// not an error, so logging out twice is harmless.
+func (s *authService) Logout(ctx context.Context, request *requests.LogoutRequest) (bool, *response.ErrorResponse) {
+	s.logger.Debug("Logging out session")
+
+	current, err := s.refreshToken.FindByToken(ctx, auth.HashOpaqueToken(request.RefreshToken))
 
 	if err != nil {
-		s.logger.Error("Invalid user ID format in token", zap.Error(err))
-		return nil, &response.ErrorResponse{
+		if errors.Is(err, sql.ErrNoRows) {
+			return true, nil
+		}
+
+		s.logger.Error("Failed to find refresh token", zap.Error(err))
+		return false, &response.ErrorResponse{
 			Status:  "error",
-			Message: "Invalid user ID format in token",
+			Message: "Failed to log out",
 		}
 	}
 
-	accessToken, err := s.createAccessToken(userId)
-	if err != nil {
-		s.logger.Error("Failed to generate new access token", zap.Error(err))
+	if err := s.refreshToken.RevokeFamily(ctx, current.FamilyID); err != nil {
+		s.logger.Error("Failed to revoke session", zap.Error(err))
+		return false, &response.ErrorResponse{
+			Status:  "error",
+			Message: "Failed to log out",
+		}
+	}
+
+	if request.AccessToken != "" {
+		claims, err := s.token.ParseToken(request.AccessToken)
 
+		if err == nil && claims.Subject == strconv.Itoa(current.UserID) {
+			if errResp := s.revocation.RevokeToken(ctx, claims, "logout"); errResp != nil {
+				return false, errResp
+			}
+		}
+	}
+
+	s.logger.Debug("Session logged out successfully", zap.Int("userID", current.UserID))
+
+	return true, nil
+}
+
+func (s *authService) LogoutAll(ctx context.Context, userID int) (bool, *response.ErrorResponse) {
+	s.logger.Debug("Logging out all sessions", zap.Int("userID", userID))
+
+	if errResp := s.revocation.RevokeUser(ctx, userID, "logout_all"); errResp != nil {
+		return false, errResp
+	}
+
+	s.logger.Debug("All sessions logged out successfully", zap.Int("userID", userID))
+
+	return true, nil
+}
+
+func (s *authService) FindSessions(ctx context.Context, userID int) ([]*response.SessionResponse, *response.ErrorResponse) {
+	s.logger.Debug("Fetching sessions", zap.Int("userID", userID))
+
+	res, err := s.refreshToken.FindActiveByUserId(ctx, userID)
+
+	if err != nil {
+		s.logger.Error("Failed to find sessions", zap.Int("userID", userID), zap.Error(err))
 		return nil, &response.ErrorResponse{
 			Status:  "error",
-			Message: "Failed to generate new access token",
+			Message: "Failed to find sessions",
+		}
+	}
+
+	return s.refreshMapping.ToSessionResponses(res), nil
+}
+
+func (s *authService) RevokeSession(ctx context.Context, userID int, sessionID string) (bool, *response.ErrorResponse) {
+	s.logger.Debug("Revoking session",
+		zap.Int("userID", userID),
+		zap.String("sessionID", sessionID),
+	)
+
+	if err := s.refreshToken.RevokeUserFamily(ctx, userID, sessionID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, &response.ErrorResponse{
+				Status:  "error",
+				Message: "Session not found",
+				Code:    response.ErrCodeNotFound,
+			}
+		}
+
+		s.logger.Error("Failed to revoke session", zap.Error(err))
+		return false, &response.ErrorResponse{
+			Status:  "error",
+			Message: "Failed to revoke session",
+		}
+	}
+
+	s.logger.Debug("Session revoked successfully", zap.String("sessionID", sessionID))
+
+	return true, nil
+}
+
+// VerifyEmail marks the owner of a verification token as verified. Tokens
+// are single use and expire after emailVerificationTTL.
+func (s *authService) VerifyEmail(ctx context.Context, request *requests.VerifyEmailRequest) (bool, *response.ErrorResponse) {
+	s.logger.Debug("Verifying email")
+
+	var userID int
+
+	errResp := withinTransaction(ctx, s.uow, s.logger, "Failed to verify email", func(repos *repository.Repositories) *response.ErrorResponse {
+		token, errResp := s.consumeUserToken(ctx, repos.UserToken, request.Token, userTokenEmailVerification)
+		if errResp != nil {
+			return errResp
+		}
+
+		userID = token.UserID
+
+		if _, err := repos.User.VerifyEmail(ctx, token.UserID); err != nil {
+			s.logger.Error("Failed to verify email", zap.Int("userID", token.UserID), zap.Error(err))
+			return &response.ErrorResponse{
+				Status:  "error",
+				Message: "Failed to verify email",
+			}
 		}
+
+		return nil
+	})
+
+	if errResp != nil {
+		return false, errResp
 	}
 
-	refreshToken, err := s.createRefreshToken(userId)
+	s.logger.Debug("Email verified successfully", zap.Int("userID", userID))
+
+	return true, nil
+}
+
+// RequestPasswordReset mails a password reset link to the account registered
+// under the given email. The outcome is reported as a success whether or not
+// such an account exists, so the endpoint cannot be used to probe for
+// registered addresses.
+func (s *authService) RequestPasswordReset(ctx context.Context, request *requests.ForgotPasswordRequest) (bool, *response.ErrorResponse) {
+	s.logger.Debug("Requesting password reset", zap.String("email", request.Email))
+
+	user, err := s.auth.FindByEmail(ctx, request.Email)
+
 	if err != nil {
-		s.logger.Error("Failed to generate new refresh token", zap.Error(err))
+		if errors.Is(err, sql.ErrNoRows) {
+			s.logger.Debug("Password reset requested for unknown email", zap.String("email", request.Email))
+			return true, nil
+		}
 
-		return nil, &response.ErrorResponse{
+		s.logger.Error("Failed to find user", zap.Error(err))
+		return false, &response.ErrorResponse{
 			Status:  "error",
-			Message: "Failed to generate new refresh token",
+			Message: "Failed to request password reset",
 		}
 	}
 
-	expiryTime := time.Now().Add(24 * time.Hour)
+	token, err := s.issueUserToken(ctx, user.ID, userTokenPasswordReset, passwordResetTTL)
 
-	updateRequest := &requests.UpdateRefreshToken{
-		UserId:    userId,
-		Token:     refreshToken,
-		ExpiresAt: expiryTime.Format("2006-01-02 15:04:05"),
+	if err != nil {
+		s.logger.Error("Failed to create password reset token", zap.Int("userID", user.ID), zap.Error(err))
+		return false, &response.ErrorResponse{
+			Status:  "error",
+			Message: "Failed to request password reset",
+		}
 	}
 
-	if _, err = s.refreshToken.UpdateRefreshToken(updateRequest); err != nil {
-		s.logger.Error("Failed to update refresh token in storage", zap.Error(err))
+	err = s.mailer.Send(ctx, mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nSomeone asked to reset the password of your account. Use the link below within %s to choose a new one:\n\n%s\n\nIf it was not you, you can ignore this email; your password has not been changed.\n",
+			user.FirstName, passwordResetTTL, s.userTokenURL("/reset-password", token),
+		),
+	})
 
-		return nil, &response.ErrorResponse{
+	if err != nil {
+		// Not reported to the caller, who must not learn whether the
+		// account exists.
+		s.logger.Error("Failed to send password reset email", zap.Int("userID", user.ID), zap.Error(err))
+		return true, nil
+	}
+
+	s.logger.Debug("Password reset email sent", zap.Int("userID", user.ID))
+
+	return true, nil
+}
+
+// ResetPassword sets a new password with a password reset token. Since the
+// link was mailed to the user, the email address counts as verified too.
+// Every existing session and access token of the user is revoked.
+func (s *authService) ResetPassword(ctx context.Context, request *requests.ResetPasswordRequest) (bool, *response.ErrorResponse) {
+	s.logger.Debug("Resetting password")
+
+	passwordHash, err := s.hash.HashPassword(request.Password)
+
+	if err != nil {
+		s.logger.Error("Failed to hash password", zap.Error(err))
+		return false, &response.ErrorResponse{
 			Status:  "error",
-			Message: "Failed to update refresh token in storage",
+			Message: "Failed to hash password",
 		}
 	}
 
-	s.logger.Debug("Refresh token refreshed successfully")
+	var userID int
 
-	return &response.TokenResponse{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-	}, nil
+	errResp := withinTransaction(ctx, s.uow, s.logger, "Failed to reset password", func(repos *repository.Repositories) *response.ErrorResponse {
+		token, errResp := s.consumeUserToken(ctx, repos.UserToken, request.Token, userTokenPasswordReset)
+		if errResp != nil {
+			return errResp
+		}
+
+		userID = token.UserID
+
+		if _, err := repos.User.UpdatePassword(ctx, token.UserID, passwordHash); err != nil {
+			s.logger.Error("Failed to update password", zap.Int("userID", token.UserID), zap.Error(err))
+			return &response.ErrorResponse{
+				Status:  "error",
+				Message: "Failed to reset password",
+			}
+		}
+
+		if _, err := repos.User.VerifyEmail(ctx, token.UserID); err != nil {
+			s.logger.Error("Failed to verify email", zap.Int("userID", token.UserID), zap.Error(err))
+			return &response.ErrorResponse{
+				Status:  "error",
+				Message: "Failed to reset password",
+			}
+		}
+
+		return s.revocation.RevokeUserWithin(ctx, repos, token.UserID, "password_reset")
+	})
+
+	if errResp != nil {
+		return false, errResp
+	}
+
+	s.logger.Debug("Password reset successfully", zap.Int("userID", userID))
+
+	return true, nil
+}
+
+// UnlockLogin lets an admin lift a login lockout before it expires.
+func (s *authService) UnlockLogin(ctx context.Context, actorID int, request *requests.UnlockLoginRequest) (bool, *response.ErrorResponse) {
+	return s.throttle.Unlock(ctx, actorID, request)
 }
 
-func (s *authService) GetMe(token string) (*response.UserResponse, *response.ErrorResponse) {
+func (s *authService) GetMe(ctx context.Context, token string) (*response.UserResponse, *response.ErrorResponse) {
 	s.logger.Debug("Fetching user details",
 		zap.String("token", token),
 	)
@@ -247,6 +602,7 @@ func (s *authService) GetMe(token string) (*response.UserResponse, *response.Err
 		return nil, &response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid access token",
+			Code:    response.ErrCodeUnauthorized,
 		}
 	}
 
@@ -256,16 +612,18 @@ func (s *authService) GetMe(token string) (*response.UserResponse, *response.Err
 		return nil, &response.ErrorResponse{
 			Status:  "error",
 			Message: "Invalid user ID format in token",
+			Code:    response.ErrCodeUnauthorized,
 		}
 	}
 
-	user, err := s.auth.FindById(userId)
+	user, err := s.auth.FindById(ctx, userId)
 
 	if err != nil {
 		s.logger.Error("Failed to find user by ID", zap.Error(err))
 		return nil, &response.ErrorResponse{
 			Status:  "error",
-			Message: "Failed to find user by ID",
+			Message: "User not found",
+			Code:    response.ErrCodeNotFound,
 		}
 	}
 
@@ -278,12 +636,25 @@ func (s *authService) GetMe(token string) (*response.UserResponse, *response.Err
 	return so, nil
 }
 
-func (s *authService) createAccessToken(id int) (string, error) {
+func (s *authService) createAccessToken(ctx context.Context, id int) (string, error) {
 	s.logger.Debug("Creating access token",
 		zap.Int("userID", id),
 	)
 
-	res, err := s.token.GenerateToken(id, "access")
+	roles, err := s.role.FindByUserId(ctx, id)
+	if err != nil {
+		s.logger.Error("Failed to find user roles",
+			zap.Int("userID", id),
+			zap.Error(err))
+		return "", err
+	}
+
+	roleNames := make([]string, 0, len(roles))
+	for _, role := range roles {
+		roleNames = append(roleNames, role.Name)
+	}
+
+	res, err := s.token.GenerateToken(id, roleNames)
 
 	if err != nil {
 		s.logger.Error("Failed to create access token",
@@ -299,28 +670,28 @@ func (s *authService) createAccessToken(id int) (string, error) {
 	return res, nil
 }
 
-func (s *authService) createRefreshToken(id int) (string, error) {
+// createRefreshToken issues a new opaque refresh token for req's session and
+// stores its digest. req.Token and req.ExpiresAt are filled in here.
+func (s *authService) createRefreshToken(ctx context.Context, repo repository.RefreshTokenRepository, req *requests.CreateRefreshToken) (string, error) {
 	s.logger.Debug("Creating refresh token",
-		zap.Int("userID", id),
+		zap.Int("userID", req.UserId),
 	)
 
-	res, err := s.token.GenerateToken(id, "refresh")
+	res, err := auth.NewOpaqueToken(32)
 
 	if err != nil {
 		s.logger.Error("Failed to create refresh token",
-			zap.Int("userID", id),
+			zap.Int("userID", req.UserId),
 			zap.Error(err),
 		)
 
 		return "", err
 	}
 
-	if err := s.refreshToken.DeleteRefreshTokenByUserId(id); err != nil && !errors.Is(err, sql.ErrNoRows) {
-		s.logger.Error("Failed to delete existing refresh token", zap.Error(err))
-		return "", err
-	}
+	req.Token = auth.HashOpaqueToken(res)
+	req.ExpiresAt = time.Now().Add(refreshTokenTTL).Format(refreshTokenTimeLayout)
 
-	_, err = s.refreshToken.CreateRefreshToken(&requests.CreateRefreshToken{Token: res, UserId: id, ExpiresAt: time.Now().Add(24 * time.Hour).Format("2006-01-02 15:04:05")})
+	_, err = repo.CreateRefreshToken(ctx, req)
 	if err != nil {
 		s.logger.Error("Failed to create refresh token", zap.Error(err))
 
@@ -328,8 +699,129 @@ func (s *authService) createRefreshToken(id int) (string, error) {
 	}
 
 	s.logger.Debug("Refresh token created successfully",
-		zap.Int("userID", id),
+		zap.Int("userID", req.UserId),
 	)
 
 	return res, nil
 }
+
+// revokeReusedFamily handles a refresh token that was presented after it had
+// already been exchanged by ending every session token in its family.
+func (s *authService) revokeReusedFamily(ctx context.Context, reused *record.RefreshTokenRecord) *response.ErrorResponse {
+	s.logger.Error("Refresh token reuse detected, revoking session",
+		zap.Int("userID", reused.UserID),
+		zap.String("familyID", reused.FamilyID),
+	)
+
+	if err := s.refreshToken.RevokeFamily(ctx, reused.FamilyID); err != nil {
+		s.logger.Error("Failed to revoke refresh token family", zap.Error(err))
+	}
+
+	return &response.ErrorResponse{
+		Status:  "error",
+		Message: "Refresh token reuse detected",
+		Code:    response.ErrCodeUnauthorized,
+	}
+}
+
+// sendVerificationEmail mails user a fresh email verification link, which
+// replaces any link sent before.
+func (s *authService) sendVerificationEmail(ctx context.Context, user *record.UserRecord) error {
+	token, err := s.issueUserToken(ctx, user.ID, userTokenEmailVerification, emailVerificationTTL)
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(ctx, mailer.Message{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nPlease confirm your email address by opening the link below within %s:\n\n%s\n",
+			user.FirstName, emailVerificationTTL, s.userTokenURL("/verify-email", token),
+		),
+	})
+}
+
+// issueUserToken creates a single-use token for purpose and returns the value
+// to mail to the user. Only its digest is stored, and the user's earlier
+// tokens for the same purpose stop working.
+func (s *authService) issueUserToken(ctx context.Context, userID int, purpose string, ttl time.Duration) (string, error) {
+	token, err := auth.NewOpaqueToken(32)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now().UTC()
+
+	err = s.uow.WithinTransaction(ctx, func(repos *repository.Repositories) error {
+		if err := repos.UserToken.InvalidateTokens(ctx, userID, purpose, now); err != nil {
+			return err
+		}
+
+		_, err := repos.UserToken.CreateToken(ctx, &requests.CreateUserTokenRequest{
+			UserID:    userID,
+			Purpose:   purpose,
+			Token:     auth.HashOpaqueToken(token),
+			ExpiresAt: now.Add(ttl),
+		})
+
+		return err
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// consumeUserToken redeems a token mailed to the user for purpose through
+// repo, which must belong to the caller's transaction.
+func (s *authService) consumeUserToken(ctx context.Context, repo repository.UserTokenRepository, token string, purpose string) (*record.UserTokenRecord, *response.ErrorResponse) {
+	res, err := repo.ConsumeToken(ctx, auth.HashOpaqueToken(token), purpose, time.Now().UTC())
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &response.ErrorResponse{
+				Status:  "error",
+				Message: "Invalid or expired token",
+				Code:    response.ErrCodeValidation,
+			}
+		}
+
+		s.logger.Error("Failed to consume user token", zap.String("purpose", purpose), zap.Error(err))
+		return nil, &response.ErrorResponse{
+			Status:  "error",
+			Message: "Failed to verify token",
+		}
+	}
+
+	return res, nil
+}
+
+func (s *authService) userTokenURL(path string, token string) string {
+	return s.appURL + path + "?token=" + url.QueryEscape(token)
+}
+
+// dummyPasswordHash is compared against when the email is unknown, so the
+// login takes as long as a real password check.
+func (s *authService) dummyPasswordHash() string {
+	s.dummyHashOnce.Do(func() {
+		hash, err := s.hash.HashPassword("not-a-real-password")
+		if err != nil {
+			s.logger.Error("Failed to hash dummy password", zap.Error(err))
+		}
+
+		s.dummyHash = hash
+	})
+
+	return s.dummyHash
+}
+
+func invalidCredentialsError() *response.ErrorResponse {
+	return &response.ErrorResponse{
+		Status:  "error",
+		Message: "Invalid email or password",
+		Code:    response.ErrCodeUnauthorized,
+	}
+}
@@ -0,0 +1,298 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"net/url"
+	"regexp"
+	"testing"
+
+	"ecommerce/internal/domain/requests"
+	"ecommerce/internal/domain/response"
+	"ecommerce/internal/service"
+	"ecommerce/pkg/database/testdb"
+	"ecommerce/pkg/hash"
+	"ecommerce/pkg/mailer"
+)
+
+var mailedTokenPattern = regexp.MustCompile(`\?token=(\S+)`)
+
+// newMailingTestService wires the services with a mailer that keeps the
+// messages it sends.
+func newMailingTestService(t *testing.T, conn *sql.DB) (*service.Service, *mailer.MemoryMailer) {
+	t.Helper()
+
+	mail := mailer.NewMemoryMailer()
+	services := newTestService(t, conn, nil, func(deps *service.Deps) {
+		deps.Mailer = mail
+		deps.AppURL = "https://shop.example.com"
+	})
+
+	return services, mail
+}
+
+// mailedToken returns the token in the link of the last message mailed.
+func mailedToken(t *testing.T, mail *mailer.MemoryMailer) string {
+	t.Helper()
+
+	messages := mail.Messages()
+	if len(messages) == 0 {
+		t.Fatal("no message was mailed")
+	}
+
+	match := mailedTokenPattern.FindStringSubmatch(messages[len(messages)-1].Body)
+	if match == nil {
+		t.Fatalf("no token link in %q", messages[len(messages)-1].Body)
+	}
+
+	token, err := url.QueryUnescape(match[1])
+	if err != nil {
+		t.Fatalf("decode token: %v", err)
+	}
+
+	return token
+}
+
+// seedUserWithPassword inserts an unverified user with password and returns
+// its ID and email.
+func seedUserWithPassword(t *testing.T, conn *sql.DB, password string) (int, string) {
+	t.Helper()
+
+	userID := testdb.SeedUser(t, conn)
+
+	hashed, err := hash.NewHashingPassword().HashPassword(password)
+	if err != nil {
+		t.Fatalf("hash password: %v", err)
+	}
+
+	var email string
+	if err := conn.QueryRow(`UPDATE users SET password = $2 WHERE user_id = $1 RETURNING email`, userID, hashed).Scan(&email); err != nil {
+		t.Fatalf("set password: %v", err)
+	}
+
+	return userID, email
+}
+
+func TestResetPassword(t *testing.T) {
+	conn := testdb.Open(t)
+	services, mail := newMailingTestService(t, conn)
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		// prepare returns the token to reset the password with.
+		prepare  func(t *testing.T, userID int, email string) string
+		wantCode string
+	}{
+		{
+			name: "mailed token",
+			prepare: func(t *testing.T, userID int, email string) string {
+				requestReset(t, services, email)
+				return mailedToken(t, mail)
+			},
+		},
+		{
+			name: "token replaced by a later request",
+			prepare: func(t *testing.T, userID int, email string) string {
+				requestReset(t, services, email)
+				first := mailedToken(t, mail)
+				requestReset(t, services, email)
+				return first
+			},
+			wantCode: response.ErrCodeValidation,
+		},
+		{
+			name: "expired token",
+			prepare: func(t *testing.T, userID int, email string) string {
+				requestReset(t, services, email)
+				if _, err := conn.Exec(`UPDATE user_tokens SET expires_at = NOW() - INTERVAL '1 minute' WHERE user_id = $1`, userID); err != nil {
+					t.Fatalf("expire token: %v", err)
+				}
+				return mailedToken(t, mail)
+			},
+			wantCode: response.ErrCodeValidation,
+		},
+		{
+			name: "email verification token",
+			prepare: func(t *testing.T, userID int, email string) string {
+				login(services, email, "old-password")
+				return mailedToken(t, mail)
+			},
+			wantCode: response.ErrCodeValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mail.Reset()
+			userID, email := seedUserWithPassword(t, conn, "old-password")
+
+			token := tt.prepare(t, userID, email)
+
+			_, errResp := services.Auth.ResetPassword(ctx, &requests.ResetPasswordRequest{
+				Token:           token,
+				Password:        "new-password",
+				ConfirmPassword: "new-password",
+			})
+
+			switch {
+			case tt.wantCode == "" && errResp != nil:
+				t.Fatalf("reset password: %s", errResp.Message)
+			case tt.wantCode != "" && (errResp == nil || errResp.Code != tt.wantCode):
+				t.Fatalf("reset password = %+v, want code %s", errResp, tt.wantCode)
+			}
+
+			var verified bool
+			var revocations int
+			err := conn.QueryRow(
+				`SELECT u.email_verified_at IS NOT NULL,
+					(SELECT COUNT(*) FROM revoked_tokens r WHERE r.user_id = u.user_id AND r.reason = 'password_reset')
+				FROM users u WHERE u.user_id = $1`,
+				userID).Scan(&verified, &revocations)
+			if err != nil {
+				t.Fatalf("read user: %v", err)
+			}
+
+			reset := tt.wantCode == ""
+			if verified != reset || (revocations == 1) != reset {
+				t.Errorf("verified, revocations = %v, %d after reset = %v", verified, revocations, reset)
+			}
+
+			newLogin := login(services, email, "new-password")
+			if (newLogin == nil) != reset {
+				t.Errorf("login with the new password = %+v, reset = %v", newLogin, reset)
+			}
+
+			if !reset {
+				return
+			}
+
+			_, errResp = services.Auth.ResetPassword(ctx, &requests.ResetPasswordRequest{
+				Token:           token,
+				Password:        "other-password",
+				ConfirmPassword: "other-password",
+			})
+			if errResp == nil || errResp.Code != response.ErrCodeValidation {
+				t.Errorf("reusing the token = %+v, want code %s", errResp, response.ErrCodeValidation)
+			}
+		})
+	}
+}
+
+func TestRequestPasswordResetForUnknownEmail(t *testing.T) {
+	conn := testdb.Open(t)
+	services, mail := newMailingTestService(t, conn)
+
+	ok, errResp := services.Auth.RequestPasswordReset(context.Background(), &requests.ForgotPasswordRequest{
+		Email: testdb.Unique("nobody") + "@example.com",
+	})
+	if errResp != nil || !ok {
+		t.Fatalf("request password reset = %v, %+v, want success", ok, errResp)
+	}
+
+	if messages := mail.Messages(); len(messages) != 0 {
+		t.Errorf("mailed %d messages, want none", len(messages))
+	}
+}
+
+func TestVerifyEmail(t *testing.T) {
+	conn := testdb.Open(t)
+	services, mail := newMailingTestService(t, conn)
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		// prepare returns the token to verify the email with.
+		prepare      func(t *testing.T, userID int, email string) string
+		wantCode     string
+		wantVerified bool
+	}{
+		{
+			name: "mailed on login",
+			prepare: func(t *testing.T, userID int, email string) string {
+				if errResp := login(services, email, "password"); errResp == nil || errResp.Code != response.ErrCodeForbidden {
+					t.Fatalf("login before verifying = %+v, want code %s", errResp, response.ErrCodeForbidden)
+				}
+				return mailedToken(t, mail)
+			},
+			wantVerified: true,
+		},
+		{
+			name: "replaced by a later login",
+			prepare: func(t *testing.T, userID int, email string) string {
+				login(services, email, "password")
+				first := mailedToken(t, mail)
+				login(services, email, "password")
+				return first
+			},
+			wantCode: response.ErrCodeValidation,
+		},
+		{
+			name: "password reset token",
+			prepare: func(t *testing.T, userID int, email string) string {
+				requestReset(t, services, email)
+				return mailedToken(t, mail)
+			},
+			wantCode: response.ErrCodeValidation,
+		},
+		{
+			name: "unknown token",
+			prepare: func(t *testing.T, userID int, email string) string {
+				return "not-a-token"
+			},
+			wantCode: response.ErrCodeValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mail.Reset()
+			userID, email := seedUserWithPassword(t, conn, "password")
+
+			token := tt.prepare(t, userID, email)
+
+			_, errResp := services.Auth.VerifyEmail(ctx, &requests.VerifyEmailRequest{Token: token})
+
+			switch {
+			case tt.wantCode == "" && errResp != nil:
+				t.Fatalf("verify email: %s", errResp.Message)
+			case tt.wantCode != "" && (errResp == nil || errResp.Code != tt.wantCode):
+				t.Fatalf("verify email = %+v, want code %s", errResp, tt.wantCode)
+			}
+
+			var verified bool
+			if err := conn.QueryRow(`SELECT email_verified_at IS NOT NULL FROM users WHERE user_id = $1`, userID).Scan(&verified); err != nil {
+				t.Fatalf("read user: %v", err)
+			}
+
+			if verified != tt.wantVerified {
+				t.Errorf("verified = %v, want %v", verified, tt.wantVerified)
+			}
+
+			if !tt.wantVerified {
+				return
+			}
+
+			if errResp := login(services, email, "password"); errResp != nil {
+				t.Errorf("login after verifying: %s", errResp.Message)
+			}
+
+			if _, errResp := services.Auth.VerifyEmail(ctx, &requests.VerifyEmailRequest{Token: token}); errResp == nil || errResp.Code != response.ErrCodeValidation {
+				t.Errorf("reusing the token = %+v, want code %s", errResp, response.ErrCodeValidation)
+			}
+		})
+	}
+}
+
+func requestReset(t *testing.T, services *service.Service, email string) {
+	t.Helper()
+
+	if _, errResp := services.Auth.RequestPasswordReset(context.Background(), &requests.ForgotPasswordRequest{Email: email}); errResp != nil {
+		t.Fatalf("request password reset: %s", errResp.Message)
+	}
+}
+
+func login(services *service.Service, email string, password string) *response.ErrorResponse {
+	_, errResp := services.Auth.Login(context.Background(), &requests.AuthRequest{Email: email, Password: password})
+	return errResp
+}
@@ -1,41 +1,52 @@
 package service
 
 import (
+	"context"
+	"ecommerce/internal/domain/record"
 	"ecommerce/internal/domain/requests"
 	"ecommerce/internal/domain/response"
 	response_service "ecommerce/internal/mapper/response/services"
 	"ecommerce/internal/repository"
 	"ecommerce/pkg/logger"
-	"fmt"
+	"errors"
 
 	"go.uber.org/zap"
 )
 
 type cartService struct {
+	unitOfWork        repository.UnitOfWork
 	productRepository repository.ProductRepository
 	userRepository    repository.UserRepository
 	cartRepository    repository.CartRepository
+	taxRate           int
 	logger            logger.LoggerInterface
 	mapping           response_service.CartResponseMapper
+	orderMapping      response_service.OrderResponseMapper
 }
 
 func NewCartService(
+	unitOfWork repository.UnitOfWork,
 	productRepository repository.ProductRepository,
 	userRepository repository.UserRepository,
 	cartRepository repository.CartRepository,
+	taxRate int,
 	logger logger.LoggerInterface,
 	mapping response_service.CartResponseMapper,
+	orderMapping response_service.OrderResponseMapper,
 ) *cartService {
 	return &cartService{
+		unitOfWork:        unitOfWork,
 		productRepository: productRepository,
 		cartRepository:    cartRepository,
 		userRepository:    userRepository,
+		taxRate:           taxRate,
 		logger:            logger,
 		mapping:           mapping,
+		orderMapping:      orderMapping,
 	}
 }
 
-func (s *cartService) FindAll(user_id int, page int, pageSize int, search string) ([]*response.CartResponse, int, *response.ErrorResponse) {
+func (s *cartService) FindAll(ctx context.Context, user_id int, page int, pageSize int, search string) ([]*response.CartResponse, int, *response.ErrorResponse) {
 	s.logger.Debug("Fetching cart",
 		zap.Int("page", page),
 		zap.Int("pageSize", pageSize),
@@ -49,7 +60,7 @@ func (s *cartService) FindAll(user_id int, page int, pageSize int, search string
 		pageSize = 10
 	}
 
-	cart, totalRecords, err := s.cartRepository.FindCarts(user_id, search, page, pageSize)
+	cart, totalRecords, err := s.cartRepository.FindCarts(ctx, user_id, search, page, pageSize)
 
 	if err != nil {
 		s.logger.Error("Failed to fetch cart",
@@ -74,15 +85,34 @@ func (s *cartService) FindAll(user_id int, page int, pageSize int, search string
 	return cartRes, int(totalRecords), nil
 }
 
-func (s *cartService) CreateCart(req *requests.CreateCartRequest) (*response.CartResponse, error) {
-	product, err := s.productRepository.FindById(req.ProductID)
+func (s *cartService) FindSummary(ctx context.Context, user_id int) (*response.CartSummaryResponse, *response.ErrorResponse) {
+	s.logger.Debug("Fetching cart summary", zap.Int("user_id", user_id))
+
+	summary, err := s.cartRepository.FindSummary(ctx, user_id)
+	if err != nil {
+		s.logger.Error("Failed to fetch cart summary", zap.Error(err), zap.Int("user_id", user_id))
+		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to fetch cart summary"}
+	}
+
+	return s.mapping.ToCartSummaryResponse(summary), nil
+}
+
+// CreateCart adds the product to the user's cart. Adding a product that is
+// already in the cart increases the quantity of the existing row instead of
+// inserting a second one.
+func (s *cartService) CreateCart(ctx context.Context, req *requests.CreateCartRequest) (*response.CartResponse, *response.ErrorResponse) {
+	s.logger.Debug("Adding product to cart", zap.Int("user_id", req.UserID), zap.Int("product_id", req.ProductID))
+
+	product, err := s.productRepository.FindById(ctx, req.ProductID)
 	if err != nil {
-		return nil, fmt.Errorf("product not found: %v", err)
+		s.logger.Error("Product not found", zap.Int("product_id", req.ProductID), zap.Error(err))
+		return nil, &response.ErrorResponse{Status: "error", Message: "Product not found", Code: response.ErrCodeNotFound}
 	}
 
-	_, err = s.userRepository.FindById(req.UserID)
+	_, err = s.userRepository.FindById(ctx, req.UserID)
 	if err != nil {
-		return nil, fmt.Errorf("user not found: %v", err)
+		s.logger.Error("User not found", zap.Int("user_id", req.UserID), zap.Error(err))
+		return nil, &response.ErrorResponse{Status: "error", Message: "User not found", Code: response.ErrCodeNotFound}
 	}
 
 	cartRecord := &requests.CartCreateRecord{
@@ -95,20 +125,149 @@ func (s *cartService) CreateCart(req *requests.CreateCartRequest) (*response.Car
 		Weight:       product.Weight,
 	}
 
-	res, err := s.cartRepository.CreateCart(cartRecord)
+	res, err := s.cartRepository.UpsertCart(ctx, cartRecord)
+	if err != nil {
+		return nil, s.cartStockError(err, "Failed to add product to cart")
+	}
+
+	return s.mapping.ToCartResponse(res), nil
+}
+
+func (s *cartService) UpdateQuantity(ctx context.Context, req *requests.UpdateCartQuantityRequest) (*response.CartResponse, *response.ErrorResponse) {
+	s.logger.Debug("Updating cart quantity", zap.Int("cart_id", req.CartID), zap.Int("quantity", req.Quantity))
+
+	cart, err := s.cartRepository.FindById(ctx, req.CartID)
+	if err != nil || cart.UserID != req.UserID {
+		s.logger.Error("Cart not found", zap.Int("cart_id", req.CartID), zap.Int("user_id", req.UserID), zap.Error(err))
+		return nil, &response.ErrorResponse{Status: "error", Message: "Cart not found", Code: response.ErrCodeNotFound}
+	}
+
+	res, err := s.cartRepository.UpdateQuantity(ctx, req)
+	if err != nil {
+		return nil, s.cartStockError(err, "Failed to update cart quantity")
+	}
+
+	return s.mapping.ToCartResponse(res), nil
+}
+
+func (s *cartService) cartStockError(err error, message string) *response.ErrorResponse {
+	if errors.Is(err, repository.ErrInsufficientStock) {
+		s.logger.Error("Insufficient product stock", zap.Error(err))
+		return &response.ErrorResponse{
+			Status:  "error",
+			Message: "Insufficient stock for product",
+			Code:    response.ErrCodeInsufficientStock,
+		}
+	}
+
+	s.logger.Error(message, zap.Error(err))
+	return &response.ErrorResponse{Status: "error", Message: message}
+}
+
+// Checkout turns the user's cart into one pending order per merchant. When
+// req.MerchantID is set only that merchant's cart rows are checked out; the
+// rest stay in the cart. Prices are taken from the products table, not from
+// the cart rows, so a stale cart never undercharges.
+func (s *cartService) Checkout(ctx context.Context, req *requests.CheckoutCartRequest) ([]*response.OrderResponse, *response.ErrorResponse) {
+	s.logger.Debug("Checking out cart", zap.Int("userID", req.UserID), zap.Int("merchantID", req.MerchantID))
+
+	_, err := s.userRepository.FindById(ctx, req.UserID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create cart: %v", err)
+		s.logger.Error("User not found", zap.Int("userID", req.UserID), zap.Error(err))
+		return nil, &response.ErrorResponse{Status: "error", Message: "User not found", Code: response.ErrCodeNotFound}
 	}
 
-	so := s.mapping.ToCartResponse(res)
+	shipping := requests.CreateShippingAddressRequest{
+		Alamat:         req.ShippingAddress.Alamat,
+		Provinsi:       req.ShippingAddress.Provinsi,
+		Kota:           req.ShippingAddress.Kota,
+		Courier:        req.ShippingAddress.Courier,
+		ShippingMethod: req.ShippingAddress.ShippingMethod,
+		Negara:         req.ShippingAddress.Negara,
+	}
+
+	var orders []*record.OrderRecord
+
+	errResp := withinTransaction(ctx, s.unitOfWork, s.logger, "Failed to checkout cart", func(repos *repository.Repositories) *response.ErrorResponse {
+		carts, err := repos.Cart.FindCartsForCheckout(ctx, req.UserID, req.MerchantID)
+		if err != nil {
+			s.logger.Error("Failed to fetch cart for checkout", zap.Error(err))
+			return &response.ErrorResponse{Status: "error", Message: "Failed to fetch cart"}
+		}
+
+		if len(carts) == 0 {
+			return &response.ErrorResponse{
+				Status:  "error",
+				Message: "Cart is empty",
+				Code:    response.ErrCodeEmptyCart,
+			}
+		}
+
+		var merchantIDs []int
+		itemsByMerchant := make(map[int][]requests.CreateOrderItemRequest)
+		cartIDs := make([]int, 0, len(carts))
+
+		for _, cart := range carts {
+			if _, ok := itemsByMerchant[cart.MerchantID]; !ok {
+				merchantIDs = append(merchantIDs, cart.MerchantID)
+			}
+
+			itemsByMerchant[cart.MerchantID] = append(itemsByMerchant[cart.MerchantID], requests.CreateOrderItemRequest{
+				ProductID: cart.ProductID,
+				Quantity:  cart.Quantity,
+			})
+			cartIDs = append(cartIDs, cart.ID)
+		}
+
+		shippingCosts := splitShippingCost(req.ShippingAddress.ShippingCost, len(merchantIDs))
+
+		for i, merchantID := range merchantIDs {
+			shipping.ShippingCost = shippingCosts[i]
+
+			order, errResp := placeOrder(ctx, repos, s.logger, s.taxRate, merchantID, req.UserID, itemsByMerchant[merchantID], shipping)
+			if errResp != nil {
+				return errResp
+			}
+
+			orders = append(orders, order)
+		}
+
+		if _, err := repos.Cart.DeleteAllPermanently(ctx, &requests.DeleteCartRequest{CartIds: cartIDs, UserID: req.UserID}); err != nil {
+			s.logger.Error("Failed to clear checked out cart", zap.Error(err))
+			return &response.ErrorResponse{Status: "error", Message: "Failed to clear cart"}
+		}
+
+		return nil
+	})
+
+	if errResp != nil {
+		return nil, errResp
+	}
+
+	s.logger.Debug("Successfully checked out cart", zap.Int("userID", req.UserID), zap.Int("orders", len(orders)))
+
+	return s.orderMapping.ToOrdersResponse(orders), nil
+}
+
+// splitShippingCost shares the shipping cost of a checkout across its orders
+// so they add up to it, the first orders taking any remainder.
+func splitShippingCost(cost int64, orders int) []int64 {
+	costs := make([]int64, orders)
+
+	for i := range costs {
+		costs[i] = cost / int64(orders)
+		if int64(i) < cost%int64(orders) {
+			costs[i]++
+		}
+	}
 
-	return so, nil
+	return costs
 }
 
-func (s *cartService) DeletePermanent(cart_id int) (bool, *response.ErrorResponse) {
-	s.logger.Debug("Permanently deleting cart", zap.Int("cart_id", cart_id))
+func (s *cartService) DeletePermanent(ctx context.Context, user_id int, cart_id int) (bool, *response.ErrorResponse) {
+	s.logger.Debug("Permanently deleting cart", zap.Int("cart_id", cart_id), zap.Int("user_id", user_id))
 
-	success, err := s.cartRepository.DeletePermanent(cart_id)
+	success, err := s.cartRepository.DeletePermanent(ctx, user_id, cart_id)
 	if err != nil {
 		s.logger.Error("Failed to permanently delete cart", zap.Error(err))
 		return false, &response.ErrorResponse{Status: "error", Message: "Failed to permanently delete cart"}
@@ -117,10 +276,10 @@ func (s *cartService) DeletePermanent(cart_id int) (bool, *response.ErrorRespons
 	return success, nil
 }
 
-func (s *cartService) DeleteAllPermanently(req *requests.DeleteCartRequest) (bool, *response.ErrorResponse) {
+func (s *cartService) DeleteAllPermanently(ctx context.Context, req *requests.DeleteCartRequest) (bool, *response.ErrorResponse) {
 	s.logger.Debug("Permanently deleting cart all", zap.Any("cart_id", req.CartIds))
 
-	success, err := s.cartRepository.DeleteAllPermanently(req)
+	success, err := s.cartRepository.DeleteAllPermanently(ctx, req)
 	if err != nil {
 		s.logger.Error("Failed to permanently delete cart all", zap.Error(err))
 		return false, &response.ErrorResponse{Status: "error", Message: "Failed to permanently delete cart all"}
@@ -0,0 +1,130 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"ecommerce/internal/domain/requests"
+	"ecommerce/internal/domain/response"
+	"ecommerce/pkg/database/testdb"
+)
+
+func TestCheckoutClearsCart(t *testing.T) {
+	conn := testdb.Open(t)
+	services := newTestService(t, conn, nil)
+	ctx := context.Background()
+
+	_, merchantID := testdb.SeedMerchant(t, conn)
+	productID := testdb.SeedProduct(t, conn, merchantID, 1000, 5)
+	buyerID := testdb.SeedUser(t, conn)
+
+	if _, errResp := services.Cart.CreateCart(ctx, &requests.CreateCartRequest{
+		UserID:    buyerID,
+		ProductID: productID,
+		Quantity:  2,
+	}); errResp != nil {
+		t.Fatalf("add to cart: %s", errResp.Message)
+	}
+
+	checkout := &requests.CheckoutCartRequest{
+		UserID: buyerID,
+		ShippingAddress: requests.CheckoutShippingAddressRequest{
+			Alamat:         "Jl. Test No. 1",
+			Provinsi:       "Jawa Barat",
+			Kota:           "Bandung",
+			Courier:        "JNE",
+			ShippingMethod: "REG",
+			ShippingCost:   500,
+			Negara:         "Indonesia",
+		},
+	}
+
+	orders, errResp := services.Cart.Checkout(ctx, checkout)
+	if errResp != nil {
+		t.Fatalf("checkout: %s", errResp.Message)
+	}
+
+	if len(orders) != 1 {
+		t.Fatalf("checkout placed %d orders, want 1", len(orders))
+	}
+
+	var remaining int
+	if err := conn.QueryRow(`SELECT COUNT(*) FROM carts WHERE user_id = $1`, buyerID).Scan(&remaining); err != nil {
+		t.Fatalf("count carts: %v", err)
+	}
+
+	if remaining != 0 {
+		t.Errorf("cart has %d rows after checkout, want 0", remaining)
+	}
+
+	if stock := testdb.ProductStock(t, conn, productID); stock != 3 {
+		t.Errorf("count_in_stock = %d, want 3", stock)
+	}
+
+	if _, errResp := services.Cart.Checkout(ctx, checkout); errResp == nil || errResp.Code != response.ErrCodeEmptyCart {
+		t.Errorf("second checkout = %+v, want %s", errResp, response.ErrCodeEmptyCart)
+	}
+}
+
+func TestCheckoutSplitsShippingAcrossMerchants(t *testing.T) {
+	conn := testdb.Open(t)
+	services := newTestService(t, conn, nil)
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		merchants int
+		cost      int64
+		want      []int64
+	}{
+		{name: "one merchant pays it all", merchants: 1, cost: 500, want: []int64{500}},
+		{name: "even split", merchants: 2, cost: 1000, want: []int64{500, 500}},
+		{name: "remainder goes first", merchants: 3, cost: 1001, want: []int64{334, 334, 333}},
+		{name: "free shipping", merchants: 2, want: []int64{0, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buyerID := testdb.SeedUser(t, conn)
+
+			for i := 0; i < tt.merchants; i++ {
+				_, merchantID := testdb.SeedMerchant(t, conn)
+				productID := testdb.SeedProduct(t, conn, merchantID, 1000, 5)
+
+				if _, errResp := services.Cart.CreateCart(ctx, &requests.CreateCartRequest{
+					UserID:    buyerID,
+					ProductID: productID,
+					Quantity:  1,
+				}); errResp != nil {
+					t.Fatalf("add to cart: %s", errResp.Message)
+				}
+			}
+
+			orders, errResp := services.Cart.Checkout(ctx, &requests.CheckoutCartRequest{
+				UserID: buyerID,
+				ShippingAddress: requests.CheckoutShippingAddressRequest{
+					Alamat:         "Jl. Test No. 1",
+					Provinsi:       "Jawa Barat",
+					Kota:           "Bandung",
+					Courier:        "JNE",
+					ShippingMethod: "REG",
+					ShippingCost:   tt.cost,
+					Negara:         "Indonesia",
+				},
+			})
+			if errResp != nil {
+				t.Fatalf("checkout: %s", errResp.Message)
+			}
+
+			if len(orders) != len(tt.want) {
+				t.Fatalf("checkout placed %d orders, want %d", len(orders), len(tt.want))
+			}
+
+			for i, order := range orders {
+				if order.ShippingCost.Amount != tt.want[i] {
+					t.Errorf("order %d shipping_cost = %d, want %d", i, order.ShippingCost.Amount, tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"ecommerce/internal/domain/requests"
 	"ecommerce/internal/domain/response"
 	response_service "ecommerce/internal/mapper/response/services"
@@ -28,7 +29,7 @@ func NewCategoryService(
 	}
 }
 
-func (s *categoryService) FindAll(page int, pageSize int, search string) ([]*response.CategoryResponse, int, *response.ErrorResponse) {
+func (s *categoryService) FindAll(ctx context.Context, page int, pageSize int, search string) ([]*response.CategoryResponse, int, *response.ErrorResponse) {
 	s.logger.Debug("Fetching category",
 		zap.Int("page", page),
 		zap.Int("pageSize", pageSize),
@@ -42,7 +43,7 @@ func (s *categoryService) FindAll(page int, pageSize int, search string) ([]*res
 		pageSize = 10
 	}
 
-	category, totalRecords, err := s.categoryRepository.FindAllCategory(search, page, pageSize)
+	category, totalRecords, err := s.categoryRepository.FindAllCategory(ctx, search, page, pageSize)
 
 	if err != nil {
 		s.logger.Error("Failed to fetch category",
@@ -67,19 +68,19 @@ func (s *categoryService) FindAll(page int, pageSize int, search string) ([]*res
 	return categoriesResponse, int(totalRecords), nil
 }
 
-func (s *categoryService) FindById(category_id int) (*response.CategoryResponse, *response.ErrorResponse) {
+func (s *categoryService) FindById(ctx context.Context, category_id int) (*response.CategoryResponse, *response.ErrorResponse) {
 	s.logger.Debug("Fetching category by ID", zap.Int("category_id", category_id))
 
-	category, err := s.categoryRepository.FindById(category_id)
+	category, err := s.categoryRepository.FindById(ctx, category_id)
 	if err != nil {
 		s.logger.Error("Failed to fetch category", zap.Error(err))
-		return nil, &response.ErrorResponse{Status: "error", Message: "category not found"}
+		return nil, &response.ErrorResponse{Status: "error", Message: "category not found", Code: response.ErrCodeNotFound}
 	}
 
 	return s.mapping.ToCategoryResponse(category), nil
 }
 
-func (s *categoryService) FindByActive(search string, page, pageSize int) ([]*response.CategoryResponseDeleteAt, int, *response.ErrorResponse) {
+func (s *categoryService) FindByActive(ctx context.Context, search string, page, pageSize int) ([]*response.CategoryResponseDeleteAt, int, *response.ErrorResponse) {
 	s.logger.Debug("Fetching categories",
 		zap.Int("page", page),
 		zap.Int("pageSize", pageSize),
@@ -93,7 +94,7 @@ func (s *categoryService) FindByActive(search string, page, pageSize int) ([]*re
 		pageSize = 10
 	}
 
-	cashiers, totalRecords, err := s.categoryRepository.FindByActive(search, page, pageSize)
+	cashiers, totalRecords, err := s.categoryRepository.FindByActive(ctx, search, page, pageSize)
 
 	if err != nil {
 		s.logger.Error("Failed to fetch categories",
@@ -112,7 +113,7 @@ func (s *categoryService) FindByActive(search string, page, pageSize int) ([]*re
 	return s.mapping.ToCategorysResponseDeleteAt(cashiers), totalRecords, nil
 }
 
-func (s *categoryService) FindByTrashed(search string, page, pageSize int) ([]*response.CategoryResponseDeleteAt, int, *response.ErrorResponse) {
+func (s *categoryService) FindByTrashed(ctx context.Context, search string, page, pageSize int) ([]*response.CategoryResponseDeleteAt, int, *response.ErrorResponse) {
 	s.logger.Debug("Fetching categories",
 		zap.Int("page", page),
 		zap.Int("pageSize", pageSize),
@@ -126,7 +127,7 @@ func (s *categoryService) FindByTrashed(search string, page, pageSize int) ([]*r
 		pageSize = 10
 	}
 
-	categories, totalRecords, err := s.categoryRepository.FindByTrashed(search, page, pageSize)
+	categories, totalRecords, err := s.categoryRepository.FindByTrashed(ctx, search, page, pageSize)
 	if err != nil {
 		s.logger.Error("Failed to fetch cashier",
 			zap.Error(err),
@@ -144,10 +145,10 @@ func (s *categoryService) FindByTrashed(search string, page, pageSize int) ([]*r
 	return s.mapping.ToCategorysResponseDeleteAt(categories), totalRecords, nil
 }
 
-func (s *categoryService) CreateCategory(req *requests.CreateCategoryRequest) (*response.CategoryResponse, *response.ErrorResponse) {
+func (s *categoryService) CreateCategory(ctx context.Context, req *requests.CreateCategoryRequest) (*response.CategoryResponse, *response.ErrorResponse) {
 	s.logger.Debug("Creating new cashier")
 
-	cashier, err := s.categoryRepository.CreateCategory(req)
+	cashier, err := s.categoryRepository.CreateCategory(ctx, req)
 	if err != nil {
 		s.logger.Error("Failed to create cashier", zap.Error(err))
 		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to create cashier"}
@@ -156,10 +157,10 @@ func (s *categoryService) CreateCategory(req *requests.CreateCategoryRequest) (*
 	return s.mapping.ToCategoryResponse(cashier), nil
 }
 
-func (s *categoryService) UpdateCategory(req *requests.UpdateCategoryRequest) (*response.CategoryResponse, *response.ErrorResponse) {
+func (s *categoryService) UpdateCategory(ctx context.Context, req *requests.UpdateCategoryRequest) (*response.CategoryResponse, *response.ErrorResponse) {
 	s.logger.Debug("Updating category", zap.Int("category_id", req.CategoryID))
 
-	category, err := s.categoryRepository.UpdateCategory(req)
+	category, err := s.categoryRepository.UpdateCategory(ctx, req)
 	if err != nil {
 		s.logger.Error("Failed to update category", zap.Error(err))
 		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to update category"}
@@ -168,10 +169,10 @@ func (s *categoryService) UpdateCategory(req *requests.UpdateCategoryRequest) (*
 	return s.mapping.ToCategoryResponse(category), nil
 }
 
-func (s *categoryService) TrashedCategory(category_id int) (*response.CategoryResponseDeleteAt, *response.ErrorResponse) {
+func (s *categoryService) TrashedCategory(ctx context.Context, category_id int) (*response.CategoryResponseDeleteAt, *response.ErrorResponse) {
 	s.logger.Debug("Trashing category", zap.Int("category", category_id))
 
-	category, err := s.categoryRepository.TrashedCategory(category_id)
+	category, err := s.categoryRepository.TrashedCategory(ctx, category_id)
 	if err != nil {
 		s.logger.Error("Failed to trash category", zap.Error(err))
 		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to trash category"}
@@ -180,10 +181,10 @@ func (s *categoryService) TrashedCategory(category_id int) (*response.CategoryRe
 	return s.mapping.ToCategoryResponseDeleteAt(category), nil
 }
 
-func (s *categoryService) RestoreCategory(categoryID int) (*response.CategoryResponseDeleteAt, *response.ErrorResponse) {
+func (s *categoryService) RestoreCategory(ctx context.Context, categoryID int) (*response.CategoryResponseDeleteAt, *response.ErrorResponse) {
 	s.logger.Debug("Restoring category", zap.Int("categoryID", categoryID))
 
-	category, err := s.categoryRepository.RestoreCategory(categoryID)
+	category, err := s.categoryRepository.RestoreCategory(ctx, categoryID)
 	if err != nil {
 		s.logger.Error("Failed to restore category", zap.Error(err))
 		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to restore category"}
@@ -192,10 +193,10 @@ func (s *categoryService) RestoreCategory(categoryID int) (*response.CategoryRes
 	return s.mapping.ToCategoryResponseDeleteAt(category), nil
 }
 
-func (s *categoryService) DeleteCategoryPermanent(categoryID int) (bool, *response.ErrorResponse) {
+func (s *categoryService) DeleteCategoryPermanent(ctx context.Context, categoryID int) (bool, *response.ErrorResponse) {
 	s.logger.Debug("Permanently deleting category", zap.Int("categoryID", categoryID))
 
-	success, err := s.categoryRepository.DeleteCategoryPermanently(categoryID)
+	success, err := s.categoryRepository.DeleteCategoryPermanently(ctx, categoryID)
 	if err != nil {
 		s.logger.Error("Failed to permanently delete category", zap.Error(err))
 		return false, &response.ErrorResponse{Status: "error", Message: "Failed to permanently delete category"}
@@ -204,10 +205,10 @@ func (s *categoryService) DeleteCategoryPermanent(categoryID int) (bool, *respon
 	return success, nil
 }
 
-func (s *categoryService) RestoreAllCategories() (bool, *response.ErrorResponse) {
+func (s *categoryService) RestoreAllCategories(ctx context.Context) (bool, *response.ErrorResponse) {
 	s.logger.Debug("Restoring all trashed categories")
 
-	success, err := s.categoryRepository.RestoreAllCategories()
+	success, err := s.categoryRepository.RestoreAllCategories(ctx)
 	if err != nil {
 		s.logger.Error("Failed to restore all categories", zap.Error(err))
 		return false, &response.ErrorResponse{Status: "error", Message: "Failed to restore all categories"}
@@ -216,10 +217,10 @@ func (s *categoryService) RestoreAllCategories() (bool, *response.ErrorResponse)
 	return success, nil
 }
 
-func (s *categoryService) DeleteAllCategoriesPermanent() (bool, *response.ErrorResponse) {
+func (s *categoryService) DeleteAllCategoriesPermanent(ctx context.Context) (bool, *response.ErrorResponse) {
 	s.logger.Debug("Permanently deleting all categories")
 
-	success, err := s.categoryRepository.DeleteAllPermanentCategories()
+	success, err := s.categoryRepository.DeleteAllPermanentCategories(ctx)
 	if err != nil {
 		s.logger.Error("Failed to permanently delete all categories", zap.Error(err))
 		return false, &response.ErrorResponse{Status: "error", Message: "Failed to permanently delete all categories"}
@@ -1,173 +1,226 @@
 package service
 
 import (
+	"context"
 	"ecommerce/internal/domain/requests"
 	"ecommerce/internal/domain/response"
+	"ecommerce/internal/repository"
+	"ecommerce/pkg/auth"
 )
 
 //go:generate mockgen -source=interfaces.go -destination=mocks/mock.go
 type AuthService interface {
-	Register(request *requests.CreateUserRequest) (*response.UserResponse, *response.ErrorResponse)
-	Login(request *requests.AuthRequest) (*response.TokenResponse, *response.ErrorResponse)
-	RefreshToken(token string) (*response.TokenResponse, *response.ErrorResponse)
-	GetMe(token string) (*response.UserResponse, *response.ErrorResponse)
+	Register(ctx context.Context, request *requests.CreateUserRequest) (*response.UserResponse, *response.ErrorResponse)
+	Login(ctx context.Context, request *requests.AuthRequest) (*response.TokenResponse, *response.ErrorResponse)
+	RefreshToken(ctx context.Context, request *requests.RefreshTokenRequest) (*response.TokenResponse, *response.ErrorResponse)
+	Logout(ctx context.Context, request *requests.LogoutRequest) (bool, *response.ErrorResponse)
+	LogoutAll(ctx context.Context, userID int) (bool, *response.ErrorResponse)
+	FindSessions(ctx context.Context, userID int) ([]*response.SessionResponse, *response.ErrorResponse)
+	RevokeSession(ctx context.Context, userID int, sessionID string) (bool, *response.ErrorResponse)
+	VerifyEmail(ctx context.Context, request *requests.VerifyEmailRequest) (bool, *response.ErrorResponse)
+	RequestPasswordReset(ctx context.Context, request *requests.ForgotPasswordRequest) (bool, *response.ErrorResponse)
+	ResetPassword(ctx context.Context, request *requests.ResetPasswordRequest) (bool, *response.ErrorResponse)
+	UnlockLogin(ctx context.Context, actorID int, request *requests.UnlockLoginRequest) (bool, *response.ErrorResponse)
+	GetMe(ctx context.Context, token string) (*response.UserResponse, *response.ErrorResponse)
 }
 
 type RoleService interface {
-	FindAll(page int, pageSize int, search string) ([]*response.RoleResponse, int, *response.ErrorResponse)
-	FindByActiveRole(page int, pageSize int, search string) ([]*response.RoleResponseDeleteAt, int, *response.ErrorResponse)
-	FindByTrashedRole(page int, pageSize int, search string) ([]*response.RoleResponseDeleteAt, int, *response.ErrorResponse)
-	FindById(role_id int) (*response.RoleResponse, *response.ErrorResponse)
-	FindByUserId(id int) ([]*response.RoleResponse, *response.ErrorResponse)
-	CreateRole(request *requests.CreateRoleRequest) (*response.RoleResponse, *response.ErrorResponse)
-	UpdateRole(request *requests.UpdateRoleRequest) (*response.RoleResponse, *response.ErrorResponse)
-	TrashedRole(role_id int) (*response.RoleResponse, *response.ErrorResponse)
-	RestoreRole(role_id int) (*response.RoleResponse, *response.ErrorResponse)
-	DeleteRolePermanent(role_id int) (bool, *response.ErrorResponse)
-
-	RestoreAllRole() (bool, *response.ErrorResponse)
-	DeleteAllRolePermanent() (bool, *response.ErrorResponse)
+	FindAll(ctx context.Context, page int, pageSize int, search string) ([]*response.RoleResponse, int, *response.ErrorResponse)
+	FindByActiveRole(ctx context.Context, page int, pageSize int, search string) ([]*response.RoleResponseDeleteAt, int, *response.ErrorResponse)
+	FindByTrashedRole(ctx context.Context, page int, pageSize int, search string) ([]*response.RoleResponseDeleteAt, int, *response.ErrorResponse)
+	FindById(ctx context.Context, role_id int) (*response.RoleResponse, *response.ErrorResponse)
+	FindByUserId(ctx context.Context, id int) ([]*response.RoleResponse, *response.ErrorResponse)
+	CreateRole(ctx context.Context, request *requests.CreateRoleRequest) (*response.RoleResponse, *response.ErrorResponse)
+	UpdateRole(ctx context.Context, request *requests.UpdateRoleRequest) (*response.RoleResponse, *response.ErrorResponse)
+	TrashedRole(ctx context.Context, role_id int) (*response.RoleResponse, *response.ErrorResponse)
+	RestoreRole(ctx context.Context, role_id int) (*response.RoleResponse, *response.ErrorResponse)
+	DeleteRolePermanent(ctx context.Context, role_id int) (bool, *response.ErrorResponse)
+
+	RestoreAllRole(ctx context.Context) (bool, *response.ErrorResponse)
+	DeleteAllRolePermanent(ctx context.Context) (bool, *response.ErrorResponse)
 }
 
 type UserService interface {
-	FindAll(page int, pageSize int, search string) ([]*response.UserResponse, int, *response.ErrorResponse)
-	FindByID(id int) (*response.UserResponse, *response.ErrorResponse)
-	FindByActive(page int, pageSize int, search string) ([]*response.UserResponseDeleteAt, int, *response.ErrorResponse)
-	FindByTrashed(page int, pageSize int, search string) ([]*response.UserResponseDeleteAt, int, *response.ErrorResponse)
-	CreateUser(request *requests.CreateUserRequest) (*response.UserResponse, *response.ErrorResponse)
-	UpdateUser(request *requests.UpdateUserRequest) (*response.UserResponse, *response.ErrorResponse)
-	TrashedUser(user_id int) (*response.UserResponseDeleteAt, *response.ErrorResponse)
-	RestoreUser(user_id int) (*response.UserResponseDeleteAt, *response.ErrorResponse)
-	DeleteUserPermanent(user_id int) (bool, *response.ErrorResponse)
-
-	RestoreAllUser() (bool, *response.ErrorResponse)
-	DeleteAllUserPermanent() (bool, *response.ErrorResponse)
+	FindAll(ctx context.Context, page int, pageSize int, search string) ([]*response.UserResponse, int, *response.ErrorResponse)
+	FindByID(ctx context.Context, id int) (*response.UserResponse, *response.ErrorResponse)
+	FindByActive(ctx context.Context, page int, pageSize int, search string) ([]*response.UserResponseDeleteAt, int, *response.ErrorResponse)
+	FindByTrashed(ctx context.Context, page int, pageSize int, search string) ([]*response.UserResponseDeleteAt, int, *response.ErrorResponse)
+	CreateUser(ctx context.Context, request *requests.CreateUserRequest) (*response.UserResponse, *response.ErrorResponse)
+	UpdateUser(ctx context.Context, request *requests.UpdateUserRequest) (*response.UserResponse, *response.ErrorResponse)
+	TrashedUser(ctx context.Context, user_id int) (*response.UserResponseDeleteAt, *response.ErrorResponse)
+	RestoreUser(ctx context.Context, user_id int) (*response.UserResponseDeleteAt, *response.ErrorResponse)
+	DeleteUserPermanent(ctx context.Context, user_id int) (bool, *response.ErrorResponse)
+
+	RestoreAllUser(ctx context.Context) (bool, *response.ErrorResponse)
+	DeleteAllUserPermanent(ctx context.Context) (bool, *response.ErrorResponse)
 }
 
 type CategoryService interface {
-	FindAll(page int, pageSize int, search string) ([]*response.CategoryResponse, int, *response.ErrorResponse)
-	FindById(category_id int) (*response.CategoryResponse, *response.ErrorResponse)
-	FindByActive(search string, page, pageSize int) ([]*response.CategoryResponseDeleteAt, int, *response.ErrorResponse)
-	FindByTrashed(search string, page, pageSize int) ([]*response.CategoryResponseDeleteAt, int, *response.ErrorResponse)
-	CreateCategory(req *requests.CreateCategoryRequest) (*response.CategoryResponse, *response.ErrorResponse)
-	UpdateCategory(req *requests.UpdateCategoryRequest) (*response.CategoryResponse, *response.ErrorResponse)
-	TrashedCategory(category_id int) (*response.CategoryResponseDeleteAt, *response.ErrorResponse)
-	RestoreCategory(categoryID int) (*response.CategoryResponseDeleteAt, *response.ErrorResponse)
-	DeleteCategoryPermanent(categoryID int) (bool, *response.ErrorResponse)
-	RestoreAllCategories() (bool, *response.ErrorResponse)
-	DeleteAllCategoriesPermanent() (bool, *response.ErrorResponse)
+	FindAll(ctx context.Context, page int, pageSize int, search string) ([]*response.CategoryResponse, int, *response.ErrorResponse)
+	FindById(ctx context.Context, category_id int) (*response.CategoryResponse, *response.ErrorResponse)
+	FindByActive(ctx context.Context, search string, page, pageSize int) ([]*response.CategoryResponseDeleteAt, int, *response.ErrorResponse)
+	FindByTrashed(ctx context.Context, search string, page, pageSize int) ([]*response.CategoryResponseDeleteAt, int, *response.ErrorResponse)
+	CreateCategory(ctx context.Context, req *requests.CreateCategoryRequest) (*response.CategoryResponse, *response.ErrorResponse)
+	UpdateCategory(ctx context.Context, req *requests.UpdateCategoryRequest) (*response.CategoryResponse, *response.ErrorResponse)
+	TrashedCategory(ctx context.Context, category_id int) (*response.CategoryResponseDeleteAt, *response.ErrorResponse)
+	RestoreCategory(ctx context.Context, categoryID int) (*response.CategoryResponseDeleteAt, *response.ErrorResponse)
+	DeleteCategoryPermanent(ctx context.Context, categoryID int) (bool, *response.ErrorResponse)
+	RestoreAllCategories(ctx context.Context) (bool, *response.ErrorResponse)
+	DeleteAllCategoriesPermanent(ctx context.Context) (bool, *response.ErrorResponse)
 }
 
 type MerchantService interface {
-	FindAll(page, pageSize int, search string) ([]*response.MerchantResponse, int, *response.ErrorResponse)
-	FindByActive(search string, page, pageSize int) ([]*response.MerchantResponseDeleteAt, int, *response.ErrorResponse)
-	FindByTrashed(search string, page, pageSize int) ([]*response.MerchantResponseDeleteAt, int, *response.ErrorResponse)
-	FindById(merchantID int) (*response.MerchantResponse, *response.ErrorResponse)
-	CreateMerchant(req *requests.CreateMerchantRequest) (*response.MerchantResponse, *response.ErrorResponse)
-	UpdateMerchant(req *requests.UpdateMerchantRequest) (*response.MerchantResponse, *response.ErrorResponse)
-	TrashedMerchant(merchantID int) (*response.MerchantResponseDeleteAt, *response.ErrorResponse)
-	RestoreMerchant(merchantID int) (*response.MerchantResponseDeleteAt, *response.ErrorResponse)
-	DeleteMerchantPermanent(merchantID int) (bool, *response.ErrorResponse)
-	RestoreAllMerchant() (bool, *response.ErrorResponse)
-	DeleteAllMerchantPermanent() (bool, *response.ErrorResponse)
+	FindAll(ctx context.Context, page, pageSize int, search string) ([]*response.MerchantResponse, int, *response.ErrorResponse)
+	FindByActive(ctx context.Context, search string, page, pageSize int) ([]*response.MerchantResponseDeleteAt, int, *response.ErrorResponse)
+	FindByTrashed(ctx context.Context, search string, page, pageSize int) ([]*response.MerchantResponseDeleteAt, int, *response.ErrorResponse)
+	FindById(ctx context.Context, merchantID int) (*response.MerchantResponse, *response.ErrorResponse)
+	FindByUser(ctx context.Context, user_id int) ([]*response.MerchantResponse, *response.ErrorResponse)
+	CreateMerchant(ctx context.Context, req *requests.CreateMerchantRequest) (*response.MerchantResponse, *response.ErrorResponse)
+	UpdateMerchant(ctx context.Context, req *requests.UpdateMerchantRequest) (*response.MerchantResponse, *response.ErrorResponse)
+	TrashedMerchant(ctx context.Context, merchantID int) (*response.MerchantResponseDeleteAt, *response.ErrorResponse)
+	RestoreMerchant(ctx context.Context, merchantID int) (*response.MerchantResponseDeleteAt, *response.ErrorResponse)
+	DeleteMerchantPermanent(ctx context.Context, merchantID int) (bool, *response.ErrorResponse)
+	RestoreAllMerchant(ctx context.Context) (bool, *response.ErrorResponse)
+	DeleteAllMerchantPermanent(ctx context.Context) (bool, *response.ErrorResponse)
 }
 
 type OrderItemService interface {
-	FindAllOrderItems(search string, page, pageSize int) ([]*response.OrderItemResponse, int, *response.ErrorResponse)
-	FindByActive(search string, page, pageSize int) ([]*response.OrderItemResponseDeleteAt, int, *response.ErrorResponse)
-	FindByTrashed(search string, page, pageSize int) ([]*response.OrderItemResponseDeleteAt, int, *response.ErrorResponse)
-	FindOrderItemByOrder(orderID int) ([]*response.OrderItemResponse, *response.ErrorResponse)
+	FindAllOrderItems(ctx context.Context, search string, page, pageSize int) ([]*response.OrderItemResponse, int, *response.ErrorResponse)
+	FindByActive(ctx context.Context, search string, page, pageSize int) ([]*response.OrderItemResponseDeleteAt, int, *response.ErrorResponse)
+	FindByTrashed(ctx context.Context, search string, page, pageSize int) ([]*response.OrderItemResponseDeleteAt, int, *response.ErrorResponse)
+	FindOrderItemByOrder(ctx context.Context, orderID int) ([]*response.OrderItemResponse, *response.ErrorResponse)
 }
 
 type OrderService interface {
-	FindAll(page int, pageSize int, search string) ([]*response.OrderResponse, int, *response.ErrorResponse)
-	FindById(order_id int) (*response.OrderResponse, *response.ErrorResponse)
-	FindByActive(page int, pageSize int, search string) ([]*response.OrderResponseDeleteAt, int, *response.ErrorResponse)
-	FindByTrashed(page int, pageSize int, search string) ([]*response.OrderResponseDeleteAt, int, *response.ErrorResponse)
-	CreateOrder(req *requests.CreateOrderRequest) (*response.OrderResponse, *response.ErrorResponse)
-	UpdateOrder(req *requests.UpdateOrderRequest) (*response.OrderResponse, *response.ErrorResponse)
-	TrashedOrder(order_id int) (*response.OrderResponseDeleteAt, *response.ErrorResponse)
-	RestoreOrder(order_id int) (*response.OrderResponseDeleteAt, *response.ErrorResponse)
-	DeleteOrderPermanent(order_id int) (bool, *response.ErrorResponse)
-	RestoreAllOrder() (bool, *response.ErrorResponse)
-	DeleteAllOrderPermanent() (bool, *response.ErrorResponse)
+	FindAll(ctx context.Context, page int, pageSize int, search string) ([]*response.OrderResponse, int, *response.ErrorResponse)
+	FindAllCursor(ctx context.Context, cursor string, pageSize int, search string) ([]*response.OrderResponse, string, *response.ErrorResponse)
+	FindById(ctx context.Context, order_id int) (*response.OrderResponse, *response.ErrorResponse)
+	FindByActive(ctx context.Context, page int, pageSize int, search string) ([]*response.OrderResponseDeleteAt, int, *response.ErrorResponse)
+	FindByTrashed(ctx context.Context, page int, pageSize int, search string) ([]*response.OrderResponseDeleteAt, int, *response.ErrorResponse)
+	CreateOrder(ctx context.Context, req *requests.CreateOrderRequest) (*response.OrderResponse, *response.ErrorResponse)
+	UpdateOrder(ctx context.Context, req *requests.UpdateOrderRequest) (*response.OrderResponse, *response.ErrorResponse)
+	FindStatusHistory(ctx context.Context, order_id int) ([]*response.OrderStatusHistoryResponse, *response.ErrorResponse)
+	CancelOrder(ctx context.Context, req *requests.UpdateOrderStatusRequest) (*response.OrderResponse, *response.ErrorResponse)
+	MarkProcessing(ctx context.Context, req *requests.UpdateOrderStatusRequest) (*response.OrderResponse, *response.ErrorResponse)
+	MarkShipped(ctx context.Context, req *requests.UpdateOrderStatusRequest) (*response.OrderResponse, *response.ErrorResponse)
+	MarkDelivered(ctx context.Context, req *requests.UpdateOrderStatusRequest) (*response.OrderResponse, *response.ErrorResponse)
+	TrashedOrder(ctx context.Context, order_id int) (*response.OrderResponseDeleteAt, *response.ErrorResponse)
+	RestoreOrder(ctx context.Context, order_id int) (*response.OrderResponseDeleteAt, *response.ErrorResponse)
+	DeleteOrderPermanent(ctx context.Context, order_id int) (bool, *response.ErrorResponse)
+	RestoreAllOrder(ctx context.Context) (bool, *response.ErrorResponse)
+	DeleteAllOrderPermanent(ctx context.Context) (bool, *response.ErrorResponse)
 }
 
 type ProductService interface {
-	FindAll(page, pageSize int, search string) ([]*response.ProductResponse, int, *response.ErrorResponse)
-	FindByMerchant(merchant_id int, page, pageSize int, search string) ([]*response.ProductResponse, int, *response.ErrorResponse)
-	FindByCategory(category_name string, page, pageSize int, search string) ([]*response.ProductResponse, int, *response.ErrorResponse)
-	FindById(productID int) (*response.ProductResponse, *response.ErrorResponse)
-	FindByActive(search string, page, pageSize int) ([]*response.ProductResponseDeleteAt, int, *response.ErrorResponse)
-	FindByTrashed(search string, page, pageSize int) ([]*response.ProductResponseDeleteAt, int, *response.ErrorResponse)
-	CreateProduct(req *requests.CreateProductRequest) (*response.ProductResponse, *response.ErrorResponse)
-	UpdateProduct(req *requests.UpdateProductRequest) (*response.ProductResponse, *response.ErrorResponse)
-	TrashProduct(productID int) (*response.ProductResponseDeleteAt, *response.ErrorResponse)
-	RestoreProduct(productID int) (*response.ProductResponseDeleteAt, *response.ErrorResponse)
-	DeleteProductPermanent(productID int) (bool, *response.ErrorResponse)
-	RestoreAllProducts() (bool, *response.ErrorResponse)
-	DeleteAllProductsPermanent() (bool, *response.ErrorResponse)
+	FindAll(ctx context.Context, page, pageSize int, search string, filter *requests.ProductFilter) ([]*response.ProductResponse, int, *response.ErrorResponse)
+	FindAllCursor(ctx context.Context, cursor string, pageSize int, search string) ([]*response.ProductResponse, string, *response.ErrorResponse)
+	FindByMerchant(ctx context.Context, merchant_id int, page, pageSize int, search string, filter *requests.ProductFilter) ([]*response.ProductResponse, int, *response.ErrorResponse)
+	FindByUser(ctx context.Context, user_id int, page, pageSize int, search string) ([]*response.ProductResponse, int, *response.ErrorResponse)
+	FindByCategory(ctx context.Context, category_name string, page, pageSize int, search string, filter *requests.ProductFilter) ([]*response.ProductResponse, int, *response.ErrorResponse)
+	Search(ctx context.Context, request *requests.SearchProductRequest) ([]*response.ProductResponse, *response.ProductFacetsResponse, int, *response.ErrorResponse)
+	FindById(ctx context.Context, productID int) (*response.ProductResponse, *response.ErrorResponse)
+	FindByActive(ctx context.Context, search string, page, pageSize int) ([]*response.ProductResponseDeleteAt, int, *response.ErrorResponse)
+	FindByTrashed(ctx context.Context, search string, page, pageSize int) ([]*response.ProductResponseDeleteAt, int, *response.ErrorResponse)
+	CreateProduct(ctx context.Context, req *requests.CreateProductRequest) (*response.ProductResponse, *response.ErrorResponse)
+	UpdateProduct(ctx context.Context, req *requests.UpdateProductRequest) (*response.ProductResponse, *response.ErrorResponse)
+	TrashProduct(ctx context.Context, productID int) (*response.ProductResponseDeleteAt, *response.ErrorResponse)
+	RestoreProduct(ctx context.Context, productID int) (*response.ProductResponseDeleteAt, *response.ErrorResponse)
+	DeleteProductPermanent(ctx context.Context, productID int) (bool, *response.ErrorResponse)
+	RestoreAllProducts(ctx context.Context) (bool, *response.ErrorResponse)
+	DeleteAllProductsPermanent(ctx context.Context) (bool, *response.ErrorResponse)
 }
 
 type TransactionService interface {
-	FindAllTransactions(search string, page, pageSize int) ([]*response.TransactionResponse, int, *response.ErrorResponse)
-	FindByMerchant(merchant_id int, search string, page, pageSize int) ([]*response.TransactionResponse, int, *response.ErrorResponse)
-	FindByActive(search string, page, pageSize int) ([]*response.TransactionResponseDeleteAt, int, *response.ErrorResponse)
-	FindByTrashed(search string, page, pageSize int) ([]*response.TransactionResponseDeleteAt, int, *response.ErrorResponse)
-	FindById(transactionID int) (*response.TransactionResponse, *response.ErrorResponse)
-	FindByOrderId(orderID int) (*response.TransactionResponse, *response.ErrorResponse)
-	CreateTransaction(req *requests.CreateTransactionRequest) (*response.TransactionResponse, *response.ErrorResponse)
-	UpdateTransaction(req *requests.UpdateTransactionRequest) (*response.TransactionResponse, *response.ErrorResponse)
-	TrashedTransaction(transaction_id int) (*response.TransactionResponseDeleteAt, *response.ErrorResponse)
-	RestoreTransaction(transaction_id int) (*response.TransactionResponseDeleteAt, *response.ErrorResponse)
-	DeleteTransactionPermanently(transactionID int) (bool, *response.ErrorResponse)
-	RestoreAllTransactions() (bool, *response.ErrorResponse)
-	DeleteAllTransactionPermanent() (bool, *response.ErrorResponse)
+	FindAllTransactions(ctx context.Context, search string, page, pageSize int) ([]*response.TransactionResponse, int, *response.ErrorResponse)
+	FindAllTransactionsCursor(ctx context.Context, cursor string, pageSize int, search string) ([]*response.TransactionResponse, string, *response.ErrorResponse)
+	FindByMerchant(ctx context.Context, merchant_id int, search string, page, pageSize int) ([]*response.TransactionResponse, int, *response.ErrorResponse)
+	FindByActive(ctx context.Context, search string, page, pageSize int) ([]*response.TransactionResponseDeleteAt, int, *response.ErrorResponse)
+	FindByTrashed(ctx context.Context, search string, page, pageSize int) ([]*response.TransactionResponseDeleteAt, int, *response.ErrorResponse)
+	FindById(ctx context.Context, transactionID int) (*response.TransactionResponse, *response.ErrorResponse)
+	FindByOrderId(ctx context.Context, orderID int) (*response.TransactionResponse, *response.ErrorResponse)
+	CreateTransaction(ctx context.Context, req *requests.CreateTransactionRequest) (*response.TransactionResponse, *response.ErrorResponse)
+	UpdateTransaction(ctx context.Context, req *requests.UpdateTransactionRequest) (*response.TransactionResponse, *response.ErrorResponse)
+	HandlePaymentEvent(ctx context.Context, req *requests.PaymentEventRequest) (*response.TransactionResponse, bool, *response.ErrorResponse)
+	RefundTransaction(ctx context.Context, req *requests.RefundTransactionRequest) (*response.TransactionResponse, *response.ErrorResponse)
+	TrashedTransaction(ctx context.Context, transaction_id int) (*response.TransactionResponseDeleteAt, *response.ErrorResponse)
+	RestoreTransaction(ctx context.Context, transaction_id int) (*response.TransactionResponseDeleteAt, *response.ErrorResponse)
+	DeleteTransactionPermanently(ctx context.Context, transactionID int) (bool, *response.ErrorResponse)
+	RestoreAllTransactions(ctx context.Context) (bool, *response.ErrorResponse)
+	DeleteAllTransactionPermanent(ctx context.Context) (bool, *response.ErrorResponse)
 }
 
 type CartService interface {
-	FindAll(user_id int, page int, pageSize int, search string) ([]*response.CartResponse, int, *response.ErrorResponse)
-	CreateCart(req *requests.CreateCartRequest) (*response.CartResponse, error)
-	DeletePermanent(cart_id int) (bool, *response.ErrorResponse)
-	DeleteAllPermanently(req *requests.DeleteCartRequest) (bool, *response.ErrorResponse)
+	FindAll(ctx context.Context, user_id int, page int, pageSize int, search string) ([]*response.CartResponse, int, *response.ErrorResponse)
+	FindSummary(ctx context.Context, user_id int) (*response.CartSummaryResponse, *response.ErrorResponse)
+	CreateCart(ctx context.Context, req *requests.CreateCartRequest) (*response.CartResponse, *response.ErrorResponse)
+	UpdateQuantity(ctx context.Context, req *requests.UpdateCartQuantityRequest) (*response.CartResponse, *response.ErrorResponse)
+	DeletePermanent(ctx context.Context, user_id int, cart_id int) (bool, *response.ErrorResponse)
+	DeleteAllPermanently(ctx context.Context, req *requests.DeleteCartRequest) (bool, *response.ErrorResponse)
+	Checkout(ctx context.Context, req *requests.CheckoutCartRequest) ([]*response.OrderResponse, *response.ErrorResponse)
 }
 
 type ReviewService interface {
-	FindAllReviews(search string, page, pageSize int) ([]*response.ReviewResponse, int, *response.ErrorResponse)
-	FindByActive(search string, page, pageSize int) ([]*response.ReviewResponseDeleteAt, int, *response.ErrorResponse)
-	FindByTrashed(search string, page, pageSize int) ([]*response.ReviewResponseDeleteAt, int, *response.ErrorResponse)
-	FindByProduct(product_id int, search string, page, pageSize int) ([]*response.ReviewResponse, int, *response.ErrorResponse)
-	CreateReview(req *requests.CreateReviewRequest) (*response.ReviewResponse, *response.ErrorResponse)
-	UpdateReview(req *requests.UpdateReviewRequest) (*response.ReviewResponse, *response.ErrorResponse)
-	TrashedReview(reviewID int) (*response.ReviewResponseDeleteAt, *response.ErrorResponse)
-	RestoreReview(reviewID int) (*response.ReviewResponseDeleteAt, *response.ErrorResponse)
-	DeleteReviewPermanent(reviewID int) (bool, *response.ErrorResponse)
-	RestoreAllReviews() (bool, *response.ErrorResponse)
-	DeleteAllReviewsPermanent() (bool, *response.ErrorResponse)
+	FindAllReviews(ctx context.Context, search string, page, pageSize int) ([]*response.ReviewResponse, int, *response.ErrorResponse)
+	FindAllReviewsCursor(ctx context.Context, cursor string, pageSize int, search string) ([]*response.ReviewResponse, string, *response.ErrorResponse)
+	FindByActive(ctx context.Context, search string, page, pageSize int) ([]*response.ReviewResponseDeleteAt, int, *response.ErrorResponse)
+	FindByTrashed(ctx context.Context, search string, page, pageSize int) ([]*response.ReviewResponseDeleteAt, int, *response.ErrorResponse)
+	FindByProduct(ctx context.Context, product_id int, search string, page, pageSize int) ([]*response.ReviewResponse, int, *response.ErrorResponse)
+	CreateReview(ctx context.Context, req *requests.CreateReviewRequest) (*response.ReviewResponse, *response.ErrorResponse)
+	UpdateReview(ctx context.Context, req *requests.UpdateReviewRequest) (*response.ReviewResponse, *response.ErrorResponse)
+	TrashedReview(ctx context.Context, reviewID int) (*response.ReviewResponseDeleteAt, *response.ErrorResponse)
+	RestoreReview(ctx context.Context, reviewID int) (*response.ReviewResponseDeleteAt, *response.ErrorResponse)
+	DeleteReviewPermanent(ctx context.Context, reviewID int) (bool, *response.ErrorResponse)
+	RestoreAllReviews(ctx context.Context) (bool, *response.ErrorResponse)
+	DeleteAllReviewsPermanent(ctx context.Context) (bool, *response.ErrorResponse)
 }
 
 type ShippingAddressService interface {
-	FindAll(page int, pageSize int, search string) ([]*response.ShippingAddressResponse, int, *response.ErrorResponse)
-	FindById(shipping_id int) (*response.ShippingAddressResponse, *response.ErrorResponse)
-	FindByOrder(order_id int) (*response.ShippingAddressResponse, *response.ErrorResponse)
-	FindByActive(search string, page, pageSize int) ([]*response.ShippingAddressResponseDeleteAt, int, *response.ErrorResponse)
-	FindByTrashed(search string, page, pageSize int) ([]*response.ShippingAddressResponseDeleteAt, int, *response.ErrorResponse)
-	TrashShippingAddress(shipping_id int) (*response.ShippingAddressResponseDeleteAt, *response.ErrorResponse)
-	RestoreShippingAddress(shipping_id int) (*response.ShippingAddressResponseDeleteAt, *response.ErrorResponse)
-	DeleteShippingAddressPermanently(categoryID int) (bool, *response.ErrorResponse)
-	RestoreAllShippingAddress() (bool, *response.ErrorResponse)
-	DeleteAllPermanentShippingAddress() (bool, *response.ErrorResponse)
+	FindAll(ctx context.Context, page int, pageSize int, search string) ([]*response.ShippingAddressResponse, int, *response.ErrorResponse)
+	FindById(ctx context.Context, shipping_id int) (*response.ShippingAddressResponse, *response.ErrorResponse)
+	FindByOrder(ctx context.Context, order_id int) (*response.ShippingAddressResponse, *response.ErrorResponse)
+	FindByActive(ctx context.Context, search string, page, pageSize int) ([]*response.ShippingAddressResponseDeleteAt, int, *response.ErrorResponse)
+	FindByTrashed(ctx context.Context, search string, page, pageSize int) ([]*response.ShippingAddressResponseDeleteAt, int, *response.ErrorResponse)
+	TrashShippingAddress(ctx context.Context, shipping_id int) (*response.ShippingAddressResponseDeleteAt, *response.ErrorResponse)
+	RestoreShippingAddress(ctx context.Context, shipping_id int) (*response.ShippingAddressResponseDeleteAt, *response.ErrorResponse)
+	DeleteShippingAddressPermanently(ctx context.Context, categoryID int) (bool, *response.ErrorResponse)
+	RestoreAllShippingAddress(ctx context.Context) (bool, *response.ErrorResponse)
+	DeleteAllPermanentShippingAddress(ctx context.Context) (bool, *response.ErrorResponse)
 }
 
 type SliderService interface {
-	FindAll(page int, pageSize int, search string) ([]*response.SliderResponse, int, *response.ErrorResponse)
-	FindByActive(search string, page, pageSize int) ([]*response.SliderResponseDeleteAt, int, *response.ErrorResponse)
-	FindByTrashed(search string, page, pageSize int) ([]*response.SliderResponseDeleteAt, int, *response.ErrorResponse)
-	CreateSlider(req *requests.CreateSliderRequest) (*response.SliderResponse, *response.ErrorResponse)
-	UpdateSlider(req *requests.UpdateSliderRequest) (*response.SliderResponse, *response.ErrorResponse)
-	TrashedSlider(slider_id int) (*response.SliderResponseDeleteAt, *response.ErrorResponse)
-	RestoreSlider(sliderID int) (*response.SliderResponseDeleteAt, *response.ErrorResponse)
-	DeleteSliderPermanent(sliderID int) (bool, *response.ErrorResponse)
-	RestoreAllSliders() (bool, *response.ErrorResponse)
-	DeleteAllSlidersPermanent() (bool, *response.ErrorResponse)
+	FindAll(ctx context.Context, page int, pageSize int, search string) ([]*response.SliderResponse, int, *response.ErrorResponse)
+	FindByActive(ctx context.Context, search string, page, pageSize int) ([]*response.SliderResponseDeleteAt, int, *response.ErrorResponse)
+	FindByTrashed(ctx context.Context, search string, page, pageSize int) ([]*response.SliderResponseDeleteAt, int, *response.ErrorResponse)
+	CreateSlider(ctx context.Context, req *requests.CreateSliderRequest) (*response.SliderResponse, *response.ErrorResponse)
+	UpdateSlider(ctx context.Context, req *requests.UpdateSliderRequest) (*response.SliderResponse, *response.ErrorResponse)
+	TrashedSlider(ctx context.Context, slider_id int) (*response.SliderResponseDeleteAt, *response.ErrorResponse)
+	RestoreSlider(ctx context.Context, sliderID int) (*response.SliderResponseDeleteAt, *response.ErrorResponse)
+	DeleteSliderPermanent(ctx context.Context, sliderID int) (bool, *response.ErrorResponse)
+	RestoreAllSliders(ctx context.Context) (bool, *response.ErrorResponse)
+	DeleteAllSlidersPermanent(ctx context.Context) (bool, *response.ErrorResponse)
+}
+
+type OwnershipService interface {
+	AuthorizeMerchant(ctx context.Context, user_id int, merchant_id int) *response.ErrorResponse
+	AuthorizeProduct(ctx context.Context, user_id int, product_id int) *response.ErrorResponse
+	AuthorizeOrder(ctx context.Context, user_id int, order_id int) *response.ErrorResponse
+	AuthorizeTransaction(ctx context.Context, user_id int, transaction_id int) *response.ErrorResponse
+	AuthorizeOrderAccess(ctx context.Context, user_id int, order_id int) *response.ErrorResponse
+	AuthorizeTransactionAccess(ctx context.Context, user_id int, transaction_id int) *response.ErrorResponse
+	AuthorizeShippingAccess(ctx context.Context, user_id int, shipping_id int) *response.ErrorResponse
+	AuthorizeReview(ctx context.Context, user_id int, review_id int) *response.ErrorResponse
+}
+
+type TokenRevocationService interface {
+	IsRevoked(ctx context.Context, claims *auth.Claims) bool
+	RevokeToken(ctx context.Context, claims *auth.Claims, reason string) *response.ErrorResponse
+	RevokeUser(ctx context.Context, user_id int, reason string) *response.ErrorResponse
+	RevokeUserWithin(ctx context.Context, repos *repository.Repositories, user_id int, reason string) *response.ErrorResponse
+}
+
+type LoginThrottleService interface {
+	Check(ctx context.Context, email string, ip string) *response.ErrorResponse
+	RecordFailure(ctx context.Context, email string, ip string)
+	RecordSuccess(ctx context.Context, email string)
+	Unlock(ctx context.Context, actorID int, request *requests.UnlockLoginRequest) (bool, *response.ErrorResponse)
 }
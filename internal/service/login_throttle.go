@@ -0,0 +1,253 @@
+package service
+
+import (
+	"context"
+	"ecommerce/internal/domain/requests"
+	"ecommerce/internal/domain/response"
+	"ecommerce/internal/repository"
+	"ecommerce/pkg/logger"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Failed logins are counted separately per account and per IP address.
+const (
+	loginScopeAccount = "account"
+	loginScopeIP      = "ip"
+)
+
+// Events written to the login audit log.
+const (
+	loginEventLocked   = "login_locked"
+	loginEventUnlocked = "login_unlocked"
+)
+
+// loginAttemptLimits is how many failed logins in a row lock an account or IP
+// address. IP addresses get more room since users behind NAT share them.
+var loginAttemptLimits = map[string]int{
+	loginScopeAccount: 5,
+	loginScopeIP:      20,
+}
+
+const (
+	// loginLockoutBase is how long the first lockout lasts. Each failure
+	// after the lock expires doubles it, up to loginLockoutMax.
+	loginLockoutBase = time.Minute
+	loginLockoutMax  = time.Hour
+	// loginFailureWindow is how long failed logins are remembered after the
+	// last one.
+	loginFailureWindow = 24 * time.Hour
+)
+
+// loginThrottleService locks accounts and IP addresses out of logging in
+// after too many failed attempts. Accounts are keyed by the email that was
+// tried, whether or not it is registered, so a lockout says nothing about
+// which emails exist.
+type loginThrottleService struct {
+	uow      repository.UnitOfWork
+	throttle repository.LoginThrottleRepository
+	logger   logger.LoggerInterface
+}
+
+func NewLoginThrottleService(uow repository.UnitOfWork, throttle repository.LoginThrottleRepository, logger logger.LoggerInterface) *loginThrottleService {
+	return &loginThrottleService{
+		uow:      uow,
+		throttle: throttle,
+		logger:   logger,
+	}
+}
+
+// Check rejects a login while the account or the IP address is locked. The
+// login is let through when the locks cannot be read, so an outage of the
+// table does not lock everyone out.
+func (s *loginThrottleService) Check(ctx context.Context, email string, ip string) *response.ErrorResponse {
+	locks, err := s.throttle.FindLocked(ctx, loginAccountKey(email), ip, time.Now().UTC())
+
+	if err != nil {
+		s.logger.Error("Failed to check login locks", zap.Error(err))
+		return nil
+	}
+
+	if len(locks) > 0 {
+		s.logger.Debug("Login rejected while locked",
+			zap.String("email", email),
+			zap.String("ip", ip),
+		)
+		return &response.ErrorResponse{
+			Status:  "error",
+			Message: "Too many failed login attempts, please try again later",
+			Code:    response.ErrCodeTooManyAttempts,
+		}
+	}
+
+	return nil
+}
+
+// RecordFailure counts a failed login against the account and the IP address,
+// locking whichever went over its limit.
+func (s *loginThrottleService) RecordFailure(ctx context.Context, email string, ip string) {
+	now := time.Now().UTC()
+
+	s.recordFailure(ctx, loginScopeAccount, loginAccountKey(email), ip, now)
+
+	if ip != "" {
+		s.recordFailure(ctx, loginScopeIP, ip, ip, now)
+	}
+}
+
+// RecordSuccess forgets the failed logins of the account. The IP address
+// keeps its count, otherwise an attacker could clear it with an account of
+// their own.
+func (s *loginThrottleService) RecordSuccess(ctx context.Context, email string) {
+	if _, err := s.throttle.Reset(ctx, loginScopeAccount, loginAccountKey(email)); err != nil {
+		s.logger.Error("Failed to reset failed logins", zap.String("email", email), zap.Error(err))
+	}
+}
+
+// Unlock lifts the lockout of an account, an IP address or both on behalf of
+// the admin actorID, and clears their failed login counts.
+func (s *loginThrottleService) Unlock(ctx context.Context, actorID int, request *requests.UnlockLoginRequest) (bool, *response.ErrorResponse) {
+	s.logger.Debug("Unlocking login",
+		zap.Int("actorID", actorID),
+		zap.String("email", request.Email),
+		zap.String("ip", request.IPAddress),
+	)
+
+	subjects := map[string]string{}
+
+	if request.Email != "" {
+		subjects[loginScopeAccount] = loginAccountKey(request.Email)
+	}
+
+	if request.IPAddress != "" {
+		subjects[loginScopeIP] = request.IPAddress
+	}
+
+	var unlocked bool
+
+	errResp := withinTransaction(ctx, s.uow, s.logger, "Failed to unlock login", func(repos *repository.Repositories) *response.ErrorResponse {
+		now := time.Now().UTC()
+
+		for scope, subject := range subjects {
+			found, err := repos.LoginThrottle.Reset(ctx, scope, subject)
+
+			if err != nil {
+				s.logger.Error("Failed to reset failed logins", zap.String("scope", scope), zap.Error(err))
+				return &response.ErrorResponse{
+					Status:  "error",
+					Message: "Failed to unlock login",
+				}
+			}
+
+			if !found {
+				continue
+			}
+
+			unlocked = true
+
+			err = repos.LoginAudit.CreateAuditLog(ctx, &requests.CreateLoginAuditLogRequest{
+				Event:     loginEventUnlocked,
+				Scope:     scope,
+				Subject:   subject,
+				ActorID:   actorID,
+				CreatedAt: now,
+			})
+
+			if err != nil {
+				s.logger.Error("Failed to write login audit log", zap.Error(err))
+				return &response.ErrorResponse{
+					Status:  "error",
+					Message: "Failed to unlock login",
+				}
+			}
+		}
+
+		return nil
+	})
+
+	if errResp != nil {
+		return false, errResp
+	}
+
+	if !unlocked {
+		return false, &response.ErrorResponse{
+			Status:  "error",
+			Message: "No failed logins recorded for the given email or IP address",
+			Code:    response.ErrCodeNotFound,
+		}
+	}
+
+	s.logger.Debug("Login unlocked successfully", zap.Int("actorID", actorID))
+
+	return true, nil
+}
+
+func (s *loginThrottleService) recordFailure(ctx context.Context, scope string, subject string, ip string, now time.Time) {
+	err := s.uow.WithinTransaction(ctx, func(repos *repository.Repositories) error {
+		throttle, err := repos.LoginThrottle.RecordFailure(ctx, &requests.RecordLoginFailureRequest{
+			Scope:       scope,
+			Subject:     subject,
+			Now:         now,
+			ResetBefore: now.Add(-loginFailureWindow),
+		})
+
+		if err != nil {
+			return err
+		}
+
+		lockout := loginLockout(scope, throttle.FailedAttempts)
+		if lockout == 0 {
+			return nil
+		}
+
+		lockedUntil := now.Add(lockout)
+
+		if err := repos.LoginThrottle.Lock(ctx, throttle.ID, lockedUntil); err != nil {
+			return err
+		}
+
+		s.logger.Error("Login locked after repeated failures",
+			zap.String("scope", scope),
+			zap.String("subject", subject),
+			zap.Int("failedAttempts", throttle.FailedAttempts),
+			zap.Duration("lockout", lockout),
+		)
+
+		return repos.LoginAudit.CreateAuditLog(ctx, &requests.CreateLoginAuditLogRequest{
+			Event:          loginEventLocked,
+			Scope:          scope,
+			Subject:        subject,
+			FailedAttempts: throttle.FailedAttempts,
+			LockedUntil:    &lockedUntil,
+			IPAddress:      ip,
+			CreatedAt:      now,
+		})
+	})
+
+	if err != nil {
+		s.logger.Error("Failed to record failed login", zap.String("scope", scope), zap.Error(err))
+	}
+}
+
+// loginLockout returns how long failedAttempts in a row lock scope out: not
+// at all below the limit, then loginLockoutBase doubled for every failure
+// past it.
+func loginLockout(scope string, failedAttempts int) time.Duration {
+	limit := loginAttemptLimits[scope]
+	if failedAttempts < limit {
+		return 0
+	}
+
+	lockout := loginLockoutBase
+	for i := limit; i < failedAttempts && lockout < loginLockoutMax; i++ {
+		lockout *= 2
+	}
+
+	return min(lockout, loginLockoutMax)
+}
+
+func loginAccountKey(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
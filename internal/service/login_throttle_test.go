@@ -0,0 +1,123 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	"ecommerce/internal/domain/requests"
+	"ecommerce/internal/domain/response"
+	"ecommerce/pkg/database/testdb"
+)
+
+// throttleSubject returns a unique email or IP address whose failed logins
+// and audit log rows are deleted when the test ends.
+func throttleSubject(t *testing.T, conn *sql.DB, prefix string) string {
+	t.Helper()
+
+	subject := testdb.Unique(prefix)
+	if prefix == "user" {
+		subject += "@example.com"
+	}
+
+	t.Cleanup(func() {
+		for _, statement := range []string{
+			`DELETE FROM login_audit_logs WHERE subject = $1`,
+			`DELETE FROM login_throttles WHERE subject = $1`,
+		} {
+			if _, err := conn.Exec(statement, strings.ToLower(subject)); err != nil {
+				t.Logf("cleanup %q: %v", statement, err)
+			}
+		}
+	})
+
+	return subject
+}
+
+func TestLoginThrottle(t *testing.T) {
+	conn := testdb.Open(t)
+	services := newTestService(t, conn, nil)
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		// failures are failed logins of one account, from one IP address
+		// unless spreadAccounts tries a new account for every failure.
+		failures       int
+		spreadAccounts bool
+		// retryEmail logs in again with the email in another case.
+		retryEmail  func(email string) string
+		retryNewIP  bool
+		unlock      bool
+		wantLocked  bool
+		wantAudited int
+	}{
+		{name: "below the account limit", failures: 4},
+		{name: "account locked at the limit", failures: 5, wantLocked: true, wantAudited: 1},
+		{name: "account lock applies from any IP", failures: 5, retryNewIP: true, wantLocked: true, wantAudited: 1},
+		{name: "email is matched case insensitively", failures: 5, retryEmail: strings.ToUpper, wantLocked: true, wantAudited: 1},
+		{name: "IP locked across accounts", failures: 20, spreadAccounts: true, wantLocked: true, wantAudited: 1},
+		{name: "unlock lifts the account lock", failures: 5, unlock: true, wantAudited: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adminID := testdb.SeedUser(t, conn)
+			email := throttleSubject(t, conn, "user")
+			ip := throttleSubject(t, conn, "ip")
+
+			for i := 0; i < tt.failures; i++ {
+				tried := email
+				if tt.spreadAccounts {
+					tried = throttleSubject(t, conn, "user")
+				}
+
+				_, errResp := services.Auth.Login(ctx, &requests.AuthRequest{Email: tried, Password: "wrong-password", IPAddress: ip})
+				if errResp == nil || errResp.Code == response.ErrCodeTooManyAttempts {
+					t.Fatalf("failed login %d = %+v, want invalid credentials", i+1, errResp)
+				}
+			}
+
+			if tt.unlock {
+				if _, errResp := services.Auth.UnlockLogin(ctx, adminID, &requests.UnlockLoginRequest{Email: email}); errResp != nil {
+					t.Fatalf("unlock login: %s", errResp.Message)
+				}
+			}
+
+			retry := &requests.AuthRequest{Email: email, Password: "wrong-password", IPAddress: ip}
+			if tt.spreadAccounts {
+				retry.Email = throttleSubject(t, conn, "user")
+			}
+			if tt.retryEmail != nil {
+				retry.Email = tt.retryEmail(email)
+			}
+			if tt.retryNewIP {
+				retry.IPAddress = throttleSubject(t, conn, "ip")
+			}
+
+			_, errResp := services.Auth.Login(ctx, retry)
+			if errResp == nil {
+				t.Fatal("login with a wrong password succeeded")
+			}
+
+			if locked := errResp.Code == response.ErrCodeTooManyAttempts; locked != tt.wantLocked {
+				t.Errorf("locked = %v, want %v (%s)", locked, tt.wantLocked, errResp.Message)
+			}
+
+			subject := strings.ToLower(email)
+			if tt.spreadAccounts {
+				subject = ip
+			}
+
+			var audited int
+			if err := conn.QueryRow(`SELECT COUNT(*) FROM login_audit_logs WHERE subject = $1`, subject).Scan(&audited); err != nil {
+				t.Fatalf("read audit log: %v", err)
+			}
+
+			if audited != tt.wantAudited {
+				t.Errorf("audit log rows = %d, want %d", audited, tt.wantAudited)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"ecommerce/internal/domain/requests"
 	"ecommerce/internal/domain/response"
 	response_service "ecommerce/internal/mapper/response/services"
@@ -28,7 +29,7 @@ func NewMerchantService(
 	}
 }
 
-func (s *merchantService) FindAll(page, pageSize int, search string) ([]*response.MerchantResponse, int, *response.ErrorResponse) {
+func (s *merchantService) FindAll(ctx context.Context, page, pageSize int, search string) ([]*response.MerchantResponse, int, *response.ErrorResponse) {
 	s.logger.Debug("Fetching all merchants", zap.Int("page", page), zap.Int("pageSize", pageSize), zap.String("search", search))
 
 	if page <= 0 {
@@ -38,7 +39,7 @@ func (s *merchantService) FindAll(page, pageSize int, search string) ([]*respons
 		pageSize = 10
 	}
 
-	merchants, totalRecords, err := s.merchantRepository.FindAllMerchants(search, page, pageSize)
+	merchants, totalRecords, err := s.merchantRepository.FindAllMerchants(ctx, search, page, pageSize)
 	if err != nil {
 		s.logger.Error("Failed to fetch merchants", zap.Error(err))
 		return nil, 0, &response.ErrorResponse{Status: "error", Message: "Failed to fetch merchants"}
@@ -47,7 +48,7 @@ func (s *merchantService) FindAll(page, pageSize int, search string) ([]*respons
 	return s.mapping.ToMerchantsResponse(merchants), totalRecords, nil
 }
 
-func (s *merchantService) FindByActive(search string, page, pageSize int) ([]*response.MerchantResponseDeleteAt, int, *response.ErrorResponse) {
+func (s *merchantService) FindByActive(ctx context.Context, search string, page, pageSize int) ([]*response.MerchantResponseDeleteAt, int, *response.ErrorResponse) {
 	s.logger.Debug("Fetching active merchants", zap.String("search", search), zap.Int("page", page), zap.Int("pageSize", pageSize))
 
 	if page <= 0 {
@@ -57,7 +58,7 @@ func (s *merchantService) FindByActive(search string, page, pageSize int) ([]*re
 		pageSize = 10
 	}
 
-	merchants, totalRecords, err := s.merchantRepository.FindByActive(search, page, pageSize)
+	merchants, totalRecords, err := s.merchantRepository.FindByActive(ctx, search, page, pageSize)
 	if err != nil {
 		s.logger.Error("Failed to fetch active merchants", zap.Error(err))
 		return nil, 0, &response.ErrorResponse{Status: "error", Message: "Failed to fetch active merchants"}
@@ -66,7 +67,7 @@ func (s *merchantService) FindByActive(search string, page, pageSize int) ([]*re
 	return s.mapping.ToMerchantsResponseDeleteAt(merchants), totalRecords, nil
 }
 
-func (s *merchantService) FindByTrashed(search string, page, pageSize int) ([]*response.MerchantResponseDeleteAt, int, *response.ErrorResponse) {
+func (s *merchantService) FindByTrashed(ctx context.Context, search string, page, pageSize int) ([]*response.MerchantResponseDeleteAt, int, *response.ErrorResponse) {
 	s.logger.Debug("Fetching trashed merchants", zap.String("search", search), zap.Int("page", page), zap.Int("pageSize", pageSize))
 
 	if page <= 0 {
@@ -76,7 +77,7 @@ func (s *merchantService) FindByTrashed(search string, page, pageSize int) ([]*r
 		pageSize = 10
 	}
 
-	merchants, totalRecords, err := s.merchantRepository.FindByTrashed(search, page, pageSize)
+	merchants, totalRecords, err := s.merchantRepository.FindByTrashed(ctx, search, page, pageSize)
 	if err != nil {
 		s.logger.Error("Failed to fetch trashed merchants", zap.Error(err))
 		return nil, 0, &response.ErrorResponse{Status: "error", Message: "Failed to fetch trashed merchants"}
@@ -85,22 +86,34 @@ func (s *merchantService) FindByTrashed(search string, page, pageSize int) ([]*r
 	return s.mapping.ToMerchantsResponseDeleteAt(merchants), totalRecords, nil
 }
 
-func (s *merchantService) FindById(merchantID int) (*response.MerchantResponse, *response.ErrorResponse) {
+func (s *merchantService) FindById(ctx context.Context, merchantID int) (*response.MerchantResponse, *response.ErrorResponse) {
 	s.logger.Debug("Fetching merchant by ID", zap.Int("merchantID", merchantID))
 
-	merchant, err := s.merchantRepository.FindById(merchantID)
+	merchant, err := s.merchantRepository.FindById(ctx, merchantID)
 	if err != nil {
 		s.logger.Error("Merchant not found", zap.Error(err))
-		return nil, &response.ErrorResponse{Status: "error", Message: "Merchant not found"}
+		return nil, &response.ErrorResponse{Status: "error", Message: "Merchant not found", Code: response.ErrCodeNotFound}
 	}
 
 	return s.mapping.ToMerchantResponse(merchant), nil
 }
 
-func (s *merchantService) CreateMerchant(req *requests.CreateMerchantRequest) (*response.MerchantResponse, *response.ErrorResponse) {
+func (s *merchantService) FindByUser(ctx context.Context, user_id int) ([]*response.MerchantResponse, *response.ErrorResponse) {
+	s.logger.Debug("Fetching merchants by user", zap.Int("userID", user_id))
+
+	merchants, err := s.merchantRepository.FindByUser(ctx, user_id)
+	if err != nil {
+		s.logger.Error("Failed to fetch merchants by user", zap.Int("userID", user_id), zap.Error(err))
+		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to fetch merchants"}
+	}
+
+	return s.mapping.ToMerchantsResponse(merchants), nil
+}
+
+func (s *merchantService) CreateMerchant(ctx context.Context, req *requests.CreateMerchantRequest) (*response.MerchantResponse, *response.ErrorResponse) {
 	s.logger.Debug("Creating new merchant")
 
-	merchant, err := s.merchantRepository.CreateMerchant(req)
+	merchant, err := s.merchantRepository.CreateMerchant(ctx, req)
 	if err != nil {
 		s.logger.Error("Failed to create merchant", zap.Error(err))
 		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to create merchant"}
@@ -109,10 +122,10 @@ func (s *merchantService) CreateMerchant(req *requests.CreateMerchantRequest) (*
 	return s.mapping.ToMerchantResponse(merchant), nil
 }
 
-func (s *merchantService) UpdateMerchant(req *requests.UpdateMerchantRequest) (*response.MerchantResponse, *response.ErrorResponse) {
+func (s *merchantService) UpdateMerchant(ctx context.Context, req *requests.UpdateMerchantRequest) (*response.MerchantResponse, *response.ErrorResponse) {
 	s.logger.Debug("Updating merchant", zap.Int("merchantID", req.MerchantID))
 
-	merchant, err := s.merchantRepository.UpdateMerchant(req)
+	merchant, err := s.merchantRepository.UpdateMerchant(ctx, req)
 	if err != nil {
 		s.logger.Error("Failed to update merchant", zap.Error(err))
 		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to update merchant"}
@@ -121,10 +134,10 @@ func (s *merchantService) UpdateMerchant(req *requests.UpdateMerchantRequest) (*
 	return s.mapping.ToMerchantResponse(merchant), nil
 }
 
-func (s *merchantService) TrashedMerchant(merchantID int) (*response.MerchantResponseDeleteAt, *response.ErrorResponse) {
+func (s *merchantService) TrashedMerchant(ctx context.Context, merchantID int) (*response.MerchantResponseDeleteAt, *response.ErrorResponse) {
 	s.logger.Debug("Trashing merchant", zap.Int("merchantID", merchantID))
 
-	merchant, err := s.merchantRepository.TrashedMerchant(merchantID)
+	merchant, err := s.merchantRepository.TrashedMerchant(ctx, merchantID)
 	if err != nil {
 		s.logger.Error("Failed to trash merchant", zap.Error(err))
 		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to trash merchant"}
@@ -133,10 +146,10 @@ func (s *merchantService) TrashedMerchant(merchantID int) (*response.MerchantRes
 	return s.mapping.ToMerchantResponseDeleteAt(merchant), nil
 }
 
-func (s *merchantService) RestoreMerchant(merchantID int) (*response.MerchantResponseDeleteAt, *response.ErrorResponse) {
+func (s *merchantService) RestoreMerchant(ctx context.Context, merchantID int) (*response.MerchantResponseDeleteAt, *response.ErrorResponse) {
 	s.logger.Debug("Restoring merchant", zap.Int("merchantID", merchantID))
 
-	merchant, err := s.merchantRepository.RestoreMerchant(merchantID)
+	merchant, err := s.merchantRepository.RestoreMerchant(ctx, merchantID)
 	if err != nil {
 		s.logger.Error("Failed to restore merchant", zap.Error(err))
 		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to restore merchant"}
@@ -145,10 +158,10 @@ func (s *merchantService) RestoreMerchant(merchantID int) (*response.MerchantRes
 	return s.mapping.ToMerchantResponseDeleteAt(merchant), nil
 }
 
-func (s *merchantService) DeleteMerchantPermanent(merchantID int) (bool, *response.ErrorResponse) {
+func (s *merchantService) DeleteMerchantPermanent(ctx context.Context, merchantID int) (bool, *response.ErrorResponse) {
 	s.logger.Debug("Deleting merchant permanently", zap.Int("merchantID", merchantID))
 
-	success, err := s.merchantRepository.DeleteMerchantPermanent(merchantID)
+	success, err := s.merchantRepository.DeleteMerchantPermanent(ctx, merchantID)
 	if err != nil {
 		s.logger.Error("Failed to delete merchant permanently", zap.Error(err))
 		return false, &response.ErrorResponse{Status: "error", Message: "Failed to delete merchant permanently"}
@@ -157,10 +170,10 @@ func (s *merchantService) DeleteMerchantPermanent(merchantID int) (bool, *respon
 	return success, nil
 }
 
-func (s *merchantService) RestoreAllMerchant() (bool, *response.ErrorResponse) {
+func (s *merchantService) RestoreAllMerchant(ctx context.Context) (bool, *response.ErrorResponse) {
 	s.logger.Debug("Restoring all trashed merchants")
 
-	success, err := s.merchantRepository.RestoreAllMerchant()
+	success, err := s.merchantRepository.RestoreAllMerchant(ctx)
 	if err != nil {
 		s.logger.Error("Failed to restore all merchants", zap.Error(err))
 		return false, &response.ErrorResponse{Status: "error", Message: "Failed to restore all merchants"}
@@ -169,10 +182,10 @@ func (s *merchantService) RestoreAllMerchant() (bool, *response.ErrorResponse) {
 	return success, nil
 }
 
-func (s *merchantService) DeleteAllMerchantPermanent() (bool, *response.ErrorResponse) {
+func (s *merchantService) DeleteAllMerchantPermanent(ctx context.Context) (bool, *response.ErrorResponse) {
 	s.logger.Debug("Permanently deleting all merchants")
 
-	success, err := s.merchantRepository.DeleteAllMerchantPermanent()
+	success, err := s.merchantRepository.DeleteAllMerchantPermanent(ctx)
 	if err != nil {
 		s.logger.Error("Failed to permanently delete all merchants", zap.Error(err))
 		return false, &response.ErrorResponse{Status: "error", Message: "Failed to permanently delete all merchants"}
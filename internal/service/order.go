@@ -1,49 +1,59 @@
 package service
 
 import (
+	"context"
+	"ecommerce/internal/domain/record"
 	"ecommerce/internal/domain/requests"
 	"ecommerce/internal/domain/response"
 	response_service "ecommerce/internal/mapper/response/services"
 	"ecommerce/internal/repository"
 	"ecommerce/pkg/logger"
+	"ecommerce/pkg/pagination"
+	"errors"
 
 	"go.uber.org/zap"
 )
 
 type orderServiceMapper struct {
+	unitOfWork          repository.UnitOfWork
 	orderRepository     repository.OrderRepository
 	orderItemRepository repository.OrderItemRepository
 	productRepository   repository.ProductRepository
 	userRepository      repository.UserRepository
 	merchantRepository  repository.MerchantRepository
 	shippingRepository  repository.ShippingAddressRepository
+	taxRate             int
 	logger              logger.LoggerInterface
 	mapping             response_service.OrderResponseMapper
 }
 
 func NewOrderServiceMapper(
+	unitOfWork repository.UnitOfWork,
 	orderRepository repository.OrderRepository,
 	orderItemRepository repository.OrderItemRepository,
 	userRepository repository.UserRepository,
 	merchantRepository repository.MerchantRepository,
 	productRepository repository.ProductRepository,
 	shippingRepository repository.ShippingAddressRepository,
+	taxRate int,
 	logger logger.LoggerInterface,
 	mapping response_service.OrderResponseMapper,
 ) *orderServiceMapper {
 	return &orderServiceMapper{
+		unitOfWork:          unitOfWork,
 		orderRepository:     orderRepository,
 		orderItemRepository: orderItemRepository,
 		productRepository:   productRepository,
 		userRepository:      userRepository,
 		merchantRepository:  merchantRepository,
 		shippingRepository:  shippingRepository,
+		taxRate:             taxRate,
 		logger:              logger,
 		mapping:             mapping,
 	}
 }
 
-func (s *orderServiceMapper) FindAll(page int, pageSize int, search string) ([]*response.OrderResponse, int, *response.ErrorResponse) {
+func (s *orderServiceMapper) FindAll(ctx context.Context, page int, pageSize int, search string) ([]*response.OrderResponse, int, *response.ErrorResponse) {
 	s.logger.Debug("Fetching orders",
 		zap.Int("page", page),
 		zap.Int("pageSize", pageSize),
@@ -57,7 +67,7 @@ func (s *orderServiceMapper) FindAll(page int, pageSize int, search string) ([]*
 		pageSize = 10
 	}
 
-	orders, totalRecords, err := s.orderRepository.FindAllOrders(search, page, pageSize)
+	orders, totalRecords, err := s.orderRepository.FindAllOrders(ctx, search, page, pageSize)
 
 	if err != nil {
 		s.logger.Error("Failed to fetch user",
@@ -82,19 +92,57 @@ func (s *orderServiceMapper) FindAll(page int, pageSize int, search string) ([]*
 	return orderResponse, int(totalRecords), nil
 }
 
-func (s *orderServiceMapper) FindById(order_id int) (*response.OrderResponse, *response.ErrorResponse) {
+func (s *orderServiceMapper) FindAllCursor(ctx context.Context, cursor string, pageSize int, search string) ([]*response.OrderResponse, string, *response.ErrorResponse) {
+	s.logger.Debug("Fetching orders by cursor",
+		zap.String("cursor", cursor),
+		zap.Int("pageSize", pageSize),
+		zap.String("search", search))
+
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+
+	after, err := pagination.DecodeCursor(cursor)
+	if err != nil {
+		s.logger.Debug("Invalid cursor", zap.String("cursor", cursor))
+		return nil, "", &response.ErrorResponse{Status: "error", Message: "Invalid cursor", Code: response.ErrCodeValidation}
+	}
+
+	orders, next, err := s.orderRepository.FindAllOrdersCursor(ctx, search, after, pageSize)
+	if err != nil {
+		s.logger.Error("Failed to fetch orders",
+			zap.Error(err),
+			zap.String("cursor", cursor),
+			zap.Int("pageSize", pageSize),
+			zap.String("search", search))
+		return nil, "", &response.ErrorResponse{Status: "error", Message: "Failed to fetch orders"}
+	}
+
+	var nextCursor string
+	if next != nil {
+		nextCursor = next.Encode()
+	}
+
+	s.logger.Debug("Successfully fetched orders by cursor",
+		zap.Int("count", len(orders)),
+		zap.Bool("hasMore", nextCursor != ""))
+
+	return s.mapping.ToOrdersResponse(orders), nextCursor, nil
+}
+
+func (s *orderServiceMapper) FindById(ctx context.Context, order_id int) (*response.OrderResponse, *response.ErrorResponse) {
 	s.logger.Debug("Fetching order by ID", zap.Int("order_id", order_id))
 
-	order, err := s.orderRepository.FindById(order_id)
+	order, err := s.orderRepository.FindById(ctx, order_id)
 	if err != nil {
 		s.logger.Error("Failed to fetch cashier", zap.Error(err))
-		return nil, &response.ErrorResponse{Status: "error", Message: "Cashier not found"}
+		return nil, &response.ErrorResponse{Status: "error", Message: "Cashier not found", Code: response.ErrCodeNotFound}
 	}
 
 	return s.mapping.ToOrderResponse(order), nil
 }
 
-func (s *orderServiceMapper) FindByActive(page int, pageSize int, search string) ([]*response.OrderResponseDeleteAt, int, *response.ErrorResponse) {
+func (s *orderServiceMapper) FindByActive(ctx context.Context, page int, pageSize int, search string) ([]*response.OrderResponseDeleteAt, int, *response.ErrorResponse) {
 	s.logger.Debug("Fetching orders",
 		zap.Int("page", page),
 		zap.Int("pageSize", pageSize),
@@ -108,7 +156,7 @@ func (s *orderServiceMapper) FindByActive(page int, pageSize int, search string)
 		pageSize = 10
 	}
 
-	orders, totalRecords, err := s.orderRepository.FindByActive(search, page, pageSize)
+	orders, totalRecords, err := s.orderRepository.FindByActive(ctx, search, page, pageSize)
 
 	if err != nil {
 		s.logger.Error("Failed to fetch order",
@@ -133,7 +181,7 @@ func (s *orderServiceMapper) FindByActive(page int, pageSize int, search string)
 	return orderResponse, int(totalRecords), nil
 }
 
-func (s *orderServiceMapper) FindByTrashed(page int, pageSize int, search string) ([]*response.OrderResponseDeleteAt, int, *response.ErrorResponse) {
+func (s *orderServiceMapper) FindByTrashed(ctx context.Context, page int, pageSize int, search string) ([]*response.OrderResponseDeleteAt, int, *response.ErrorResponse) {
 	s.logger.Debug("Fetching orders",
 		zap.Int("page", page),
 		zap.Int("pageSize", pageSize),
@@ -147,7 +195,7 @@ func (s *orderServiceMapper) FindByTrashed(page int, pageSize int, search string
 		pageSize = 10
 	}
 
-	orders, totalRecords, err := s.orderRepository.FindByTrashed(search, page, pageSize)
+	orders, totalRecords, err := s.orderRepository.FindByTrashed(ctx, search, page, pageSize)
 
 	if err != nil {
 		s.logger.Error("Failed to fetch order",
@@ -172,314 +220,566 @@ func (s *orderServiceMapper) FindByTrashed(page int, pageSize int, search string
 	return orderResponse, int(totalRecords), nil
 }
 
-func (s *orderServiceMapper) CreateOrder(req *requests.CreateOrderRequest) (*response.OrderResponse, *response.ErrorResponse) {
+func (s *orderServiceMapper) CreateOrder(ctx context.Context, req *requests.CreateOrderRequest) (*response.OrderResponse, *response.ErrorResponse) {
 	s.logger.Debug("Creating new order with items", zap.Int("merchantID", req.MerchantID), zap.Int("userID", req.UserID))
 
-	_, err := s.merchantRepository.FindById(req.MerchantID)
+	_, err := s.merchantRepository.FindById(ctx, req.MerchantID)
 	if err != nil {
 		s.logger.Error("Merchant not found", zap.Int("merchantID", req.MerchantID), zap.Error(err))
-		return nil, &response.ErrorResponse{Status: "error", Message: "Merchant not found"}
+		return nil, &response.ErrorResponse{Status: "error", Message: "Merchant not found", Code: response.ErrCodeNotFound}
 	}
 
-	_, err = s.userRepository.FindById(req.UserID)
+	_, err = s.userRepository.FindById(ctx, req.UserID)
 	if err != nil {
 		s.logger.Error("User not found", zap.Int("userID", req.UserID), zap.Error(err))
-		return nil, &response.ErrorResponse{Status: "error", Message: "User not found"}
+		return nil, &response.ErrorResponse{Status: "error", Message: "User not found", Code: response.ErrCodeNotFound}
 	}
 
-	order, err := s.orderRepository.CreateOrder(&requests.CreateOrderRecordRequest{
-		MerchantID: req.MerchantID,
-		UserID:     req.UserID,
-		TotalPrice: 0,
+	var order *record.OrderRecord
+
+	errResp := withinTransaction(ctx, s.unitOfWork, s.logger, "Failed to create order", func(repos *repository.Repositories) *response.ErrorResponse {
+		var errResp *response.ErrorResponse
+
+		order, errResp = placeOrder(ctx, repos, s.logger, s.taxRate, req.MerchantID, req.UserID, req.Items, req.ShippingAddress)
+
+		return errResp
 	})
+
+	if errResp != nil {
+		return nil, errResp
+	}
+
+	return s.mapping.ToOrderResponse(order), nil
+}
+
+func (s *orderServiceMapper) UpdateOrder(ctx context.Context, req *requests.UpdateOrderRequest) (*response.OrderResponse, *response.ErrorResponse) {
+	s.logger.Debug("Updating order with items", zap.Int("orderID", req.OrderID))
+
+	_, err := s.userRepository.FindById(ctx, req.UserID)
 	if err != nil {
-		s.logger.Error("Failed to create order", zap.Error(err))
-		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to create order"}
+		s.logger.Error("User not found", zap.Int("userID", req.UserID), zap.Error(err))
+		return nil, &response.ErrorResponse{Status: "error", Message: "User not found", Code: response.ErrCodeNotFound}
 	}
 
-	for _, item := range req.Items {
-		product, err := s.productRepository.FindById(item.ProductID)
+	var order *record.OrderRecord
+
+	errResp := withinTransaction(ctx, s.unitOfWork, s.logger, "Failed to update order", func(repos *repository.Repositories) *response.ErrorResponse {
+		existingOrder, err := repos.Order.FindByIdForUpdate(ctx, req.OrderID)
 		if err != nil {
-			s.logger.Error("Product not found", zap.Int("productID", item.ProductID), zap.Error(err))
-			return nil, &response.ErrorResponse{Status: "error", Message: "Product not found"}
+			s.logger.Error("Order not found", zap.Int("orderID", req.OrderID), zap.Error(err))
+			return &response.ErrorResponse{Status: "error", Message: "Order not found", Code: response.ErrCodeNotFound}
 		}
 
-		if product.CountInStock < 1 {
-			s.logger.Error("Product out of stock", zap.Int("productID", item.ProductID))
-			return nil, &response.ErrorResponse{Status: "error", Message: "Product out of stock"}
+		if existingOrder.Status != record.OrderStatusPending {
+			s.logger.Error("Order is no longer editable", zap.Int("orderID", req.OrderID), zap.String("status", existingOrder.Status))
+			return &response.ErrorResponse{
+				Status:  "error",
+				Message: "Only pending orders can be updated",
+				Code:    response.ErrCodeInvalidStatusTransition,
+			}
 		}
 
-		_, err = s.orderItemRepository.CreateOrderItem(&requests.CreateOrderItemRecordRequest{
-			OrderID:   order.ID,
-			ProductID: item.ProductID,
-			Quantity:  item.Quantity,
-			Price:     product.Price,
-		})
+		orderItems, err := repos.OrderItem.FindOrderItemByOrder(ctx, req.OrderID)
 		if err != nil {
-			s.logger.Error("Failed to create order item", zap.Error(err))
-			return nil, &response.ErrorResponse{Status: "error", Message: "Failed to create order item"}
+			s.logger.Error("Failed to fetch order items", zap.Int("orderID", req.OrderID), zap.Error(err))
+			return &response.ErrorResponse{Status: "error", Message: "Failed to fetch order items"}
+		}
+
+		existingItems := make(map[int]*record.OrderItemRecord, len(orderItems))
+		for _, orderItem := range orderItems {
+			existingItems[orderItem.ID] = orderItem
 		}
 
-		product.CountInStock -= item.Quantity
-		_, err = s.productRepository.UpdateProductCountStock(product.ID, product.CountInStock)
+		for _, item := range req.Items {
+			product, err := repos.Product.FindById(ctx, item.ProductID)
+			if err != nil {
+				s.logger.Error("Product not found", zap.Int("productID", item.ProductID), zap.Error(err))
+				return &response.ErrorResponse{Status: "error", Message: "Product not found", Code: response.ErrCodeNotFound}
+			}
+
+			if item.OrderItemID > 0 {
+				existing, ok := existingItems[item.OrderItemID]
+				if !ok {
+					s.logger.Error("Order item not found", zap.Int("orderID", req.OrderID), zap.Int("orderItemID", item.OrderItemID))
+					return &response.ErrorResponse{Status: "error", Message: "Order item not found", Code: response.ErrCodeNotFound}
+				}
+
+				if errResp := adjustReservedStock(ctx, repos, s.logger, existing, item); errResp != nil {
+					return errResp
+				}
+
+				_, err := repos.OrderItem.UpdateOrderItem(ctx, &requests.UpdateOrderItemRecordRequest{
+					OrderItemID: item.OrderItemID,
+					OrderID:     req.OrderID,
+					ProductID:   item.ProductID,
+					Quantity:    item.Quantity,
+					Price:       product.Price,
+				})
+				if err != nil {
+					s.logger.Error("Failed to update order item", zap.Error(err))
+					return &response.ErrorResponse{Status: "error", Message: "Failed to update order item"}
+				}
+			} else {
+				if _, errResp := reserveStock(ctx, repos, s.logger, item.ProductID, item.Quantity); errResp != nil {
+					return errResp
+				}
+
+				_, err := repos.OrderItem.CreateOrderItem(ctx, &requests.CreateOrderItemRecordRequest{
+					OrderID:   req.OrderID,
+					ProductID: item.ProductID,
+					Quantity:  item.Quantity,
+					Price:     product.Price,
+				})
+				if err != nil {
+					s.logger.Error("Failed to create order item", zap.Error(err))
+					return &response.ErrorResponse{Status: "error", Message: "Failed to create order item"}
+				}
+			}
+		}
+
+		_, err = repos.Shipping.UpdateShippingAddress(ctx, &requests.UpdateShippingAddressRequest{
+			ShippingID:     req.ShippingAddress.ShippingID,
+			OrderID:        req.OrderID,
+			Alamat:         req.ShippingAddress.Alamat,
+			Provinsi:       req.ShippingAddress.Provinsi,
+			Kota:           req.ShippingAddress.Kota,
+			Courier:        req.ShippingAddress.Courier,
+			ShippingMethod: req.ShippingAddress.ShippingMethod,
+			ShippingCost:   req.ShippingAddress.ShippingCost,
+			Negara:         req.ShippingAddress.Negara,
+		})
 		if err != nil {
-			s.logger.Error("Failed to update product stock", zap.Error(err))
-			return nil, &response.ErrorResponse{Status: "error", Message: "Failed to update product stock"}
+			s.logger.Error("Failed to update shipping address", zap.Error(err))
+			return &response.ErrorResponse{Status: "error", Message: "Failed to update shipping address"}
 		}
-	}
 
-	_, err = s.shippingRepository.CreateShippingAddress(&requests.CreateShippingAddressRequest{
-		OrderID:        order.ID,
-		Alamat:         req.ShippingAddress.Alamat,
-		Provinsi:       req.ShippingAddress.Provinsi,
-		Kota:           req.ShippingAddress.Kota,
-		Courier:        req.ShippingAddress.Courier,
-		ShippingMethod: req.ShippingAddress.ShippingMethod,
-		ShippingCost:   req.ShippingAddress.ShippingCost,
-		Negara:         req.ShippingAddress.Negara,
-	})
-	if err != nil {
-		s.logger.Error("Failed to create shipping address", zap.Error(err))
-		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to create shipping address"}
-	}
+		if _, err := repos.Order.UpdateOrder(ctx, &requests.UpdateOrderRecordRequest{
+			OrderID:        req.OrderID,
+			UserID:         req.UserID,
+			DiscountAmount: req.DiscountAmount,
+		}); err != nil {
+			s.logger.Error("Failed to update order", zap.Error(err))
+			return &response.ErrorResponse{Status: "error", Message: "Failed to update order"}
+		}
 
-	totalPrice, err := s.orderItemRepository.CalculateTotalPrice(order.ID)
-	if err != nil {
-		s.logger.Error("Failed to calculate total price", zap.Error(err))
-		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to calculate total price"}
-	}
+		order, err = repos.Order.RecalculateTotal(ctx, req.OrderID, s.taxRate)
+		if err != nil {
+			s.logger.Error("Failed to update order total price", zap.Error(err))
+			return &response.ErrorResponse{Status: "error", Message: "Failed to update order total price"}
+		}
 
-	_, err = s.orderRepository.UpdateOrder(&requests.UpdateOrderRecordRequest{
-		OrderID:    order.ID,
-		UserID:     req.UserID,
-		TotalPrice: int(*totalPrice),
+		return nil
 	})
-	if err != nil {
-		s.logger.Error("Failed to update order total price", zap.Error(err))
-		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to update order total price"}
+
+	if errResp != nil {
+		return nil, errResp
 	}
 
 	return s.mapping.ToOrderResponse(order), nil
 }
 
-func (s *orderServiceMapper) UpdateOrder(req *requests.UpdateOrderRequest) (*response.OrderResponse, *response.ErrorResponse) {
-	s.logger.Debug("Updating order with items", zap.Int("orderID", req.OrderID))
+func (s *orderServiceMapper) FindStatusHistory(ctx context.Context, order_id int) ([]*response.OrderStatusHistoryResponse, *response.ErrorResponse) {
+	s.logger.Debug("Fetching order status history", zap.Int("order_id", order_id))
 
-	existingOrder, err := s.orderRepository.FindById(req.OrderID)
-	if err != nil {
-		s.logger.Error("Order not found", zap.Int("orderID", req.OrderID), zap.Error(err))
-		return nil, &response.ErrorResponse{Status: "error", Message: "Order not found"}
+	if _, err := s.orderRepository.FindById(ctx, order_id); err != nil {
+		s.logger.Error("Order not found", zap.Int("order_id", order_id), zap.Error(err))
+		return nil, &response.ErrorResponse{Status: "error", Message: "Order not found", Code: response.ErrCodeNotFound}
 	}
 
-	_, err = s.userRepository.FindById(req.UserID)
+	histories, err := s.orderRepository.FindStatusHistory(ctx, order_id)
 	if err != nil {
-		s.logger.Error("User not found", zap.Int("userID", req.UserID), zap.Error(err))
-		return nil, &response.ErrorResponse{Status: "error", Message: "User not found"}
+		s.logger.Error("Failed to fetch order status history", zap.Int("order_id", order_id), zap.Error(err))
+		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to fetch order status history"}
 	}
 
-	for _, item := range req.Items {
-		product, err := s.productRepository.FindById(item.ProductID)
-		if err != nil {
-			s.logger.Error("Product not found", zap.Int("productID", item.ProductID), zap.Error(err))
-			return nil, &response.ErrorResponse{Status: "error", Message: "Product not found"}
+	return s.mapping.ToOrderStatusHistoriesResponse(histories), nil
+}
+
+func (s *orderServiceMapper) CancelOrder(ctx context.Context, req *requests.UpdateOrderStatusRequest) (*response.OrderResponse, *response.ErrorResponse) {
+	s.logger.Debug("Cancelling order", zap.Int("order_id", req.OrderID))
+
+	var order *record.OrderRecord
+
+	errResp := withinTransaction(ctx, s.unitOfWork, s.logger, "Failed to cancel order", func(repos *repository.Repositories) *response.ErrorResponse {
+		var errResp *response.ErrorResponse
+
+		order, errResp = changeOrderStatus(ctx, repos, s.logger, req.OrderID, record.OrderStatusCancelled, req.Note)
+		if errResp != nil {
+			return errResp
 		}
 
-		if item.OrderItemID > 0 {
-			_, err := s.orderItemRepository.UpdateOrderItem(&requests.UpdateOrderItemRecordRequest{
-				OrderItemID: item.OrderItemID,
-				ProductID:   item.ProductID,
-				Quantity:    item.Quantity,
-				Price:       product.Price,
-			})
-			if err != nil {
-				s.logger.Error("Failed to update order item", zap.Error(err))
-				return nil, &response.ErrorResponse{Status: "error", Message: "Failed to update order item"}
-			}
-		} else {
-			_, err := s.orderItemRepository.CreateOrderItem(&requests.CreateOrderItemRecordRequest{
-				OrderID:   req.OrderID,
-				ProductID: item.ProductID,
-				Quantity:  item.Quantity,
-				Price:     product.Price,
-			})
-			if err != nil {
-				s.logger.Error("Failed to create order item", zap.Error(err))
-				return nil, &response.ErrorResponse{Status: "error", Message: "Failed to create order item"}
-			}
+		orderItems, err := repos.OrderItem.FindOrderItemByOrder(ctx, req.OrderID)
+		if err != nil {
+			s.logger.Error("Failed to fetch order items for cancelling", zap.Error(err))
+			return &response.ErrorResponse{Status: "error", Message: "Failed to fetch order items"}
+		}
 
-			product.CountInStock -= item.Quantity
-			_, err = s.productRepository.UpdateProductCountStock(product.ID, product.CountInStock)
-			if err != nil {
-				s.logger.Error("Failed to update product stock", zap.Error(err))
-				return nil, &response.ErrorResponse{Status: "error", Message: "Failed to update product stock"}
+		for _, item := range orderItems {
+			if _, err := repos.Product.ReleaseProductStock(ctx, item.ProductID, item.Quantity); err != nil {
+				s.logger.Error("Failed to release product stock", zap.Int("productID", item.ProductID), zap.Error(err))
+				return &response.ErrorResponse{Status: "error", Message: "Failed to release product stock"}
 			}
 		}
-	}
 
-	_, err = s.shippingRepository.UpdateShippingAddress(&requests.UpdateShippingAddressRequest{
-		ShippingID:     req.ShippingAddress.ShippingID,
-		OrderID:        req.OrderID,
-		Alamat:         req.ShippingAddress.Alamat,
-		Provinsi:       req.ShippingAddress.Provinsi,
-		Kota:           req.ShippingAddress.Kota,
-		Courier:        req.ShippingAddress.Courier,
-		ShippingMethod: req.ShippingAddress.ShippingMethod,
-		ShippingCost:   req.ShippingAddress.ShippingCost,
-		Negara:         req.ShippingAddress.Negara,
+		return nil
 	})
-	if err != nil {
-		s.logger.Error("Failed to update shipping address", zap.Error(err))
-		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to update shipping address"}
-	}
 
-	totalPrice, err := s.orderItemRepository.CalculateTotalPrice(req.OrderID)
-	if err != nil {
-		s.logger.Error("Failed to calculate total price", zap.Error(err))
-		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to calculate total price"}
+	if errResp != nil {
+		return nil, errResp
 	}
 
-	_, err = s.orderRepository.UpdateOrder(&requests.UpdateOrderRecordRequest{
-		OrderID:    req.OrderID,
-		UserID:     req.UserID,
-		TotalPrice: int(*totalPrice),
+	return s.mapping.ToOrderResponse(order), nil
+}
+
+func (s *orderServiceMapper) MarkProcessing(ctx context.Context, req *requests.UpdateOrderStatusRequest) (*response.OrderResponse, *response.ErrorResponse) {
+	s.logger.Debug("Marking order as processing", zap.Int("order_id", req.OrderID))
+
+	return s.updateStatus(ctx, req, record.OrderStatusProcessing)
+}
+
+func (s *orderServiceMapper) MarkShipped(ctx context.Context, req *requests.UpdateOrderStatusRequest) (*response.OrderResponse, *response.ErrorResponse) {
+	s.logger.Debug("Marking order as shipped", zap.Int("order_id", req.OrderID))
+
+	return s.updateStatus(ctx, req, record.OrderStatusShipped)
+}
+
+func (s *orderServiceMapper) MarkDelivered(ctx context.Context, req *requests.UpdateOrderStatusRequest) (*response.OrderResponse, *response.ErrorResponse) {
+	s.logger.Debug("Marking order as delivered", zap.Int("order_id", req.OrderID))
+
+	return s.updateStatus(ctx, req, record.OrderStatusDelivered)
+}
+
+func (s *orderServiceMapper) updateStatus(ctx context.Context, req *requests.UpdateOrderStatusRequest, status string) (*response.OrderResponse, *response.ErrorResponse) {
+	var order *record.OrderRecord
+
+	errResp := withinTransaction(ctx, s.unitOfWork, s.logger, "Failed to update order status", func(repos *repository.Repositories) *response.ErrorResponse {
+		var errResp *response.ErrorResponse
+
+		order, errResp = changeOrderStatus(ctx, repos, s.logger, req.OrderID, status, req.Note)
+
+		return errResp
 	})
-	if err != nil {
-		s.logger.Error("Failed to update order total price", zap.Error(err))
-		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to update order total price"}
+
+	if errResp != nil {
+		return nil, errResp
 	}
 
-	return s.mapping.ToOrderResponse(existingOrder), nil
+	return s.mapping.ToOrderResponse(order), nil
 }
 
-func (s *orderServiceMapper) TrashedOrder(order_id int) (*response.OrderResponseDeleteAt, *response.ErrorResponse) {
+func (s *orderServiceMapper) TrashedOrder(ctx context.Context, order_id int) (*response.OrderResponseDeleteAt, *response.ErrorResponse) {
 	s.logger.Debug("Trashing order and related order items", zap.Int("order_id", order_id))
 
-	orderItems, err := s.orderItemRepository.FindOrderItemByOrder(order_id)
+	var order *record.OrderRecord
 
-	if err != nil {
-		s.logger.Error("Failed to fetch order items for trashing", zap.Error(err))
+	errResp := withinTransaction(ctx, s.unitOfWork, s.logger, "Failed to trash order", func(repos *repository.Repositories) *response.ErrorResponse {
+		orderItems, err := repos.OrderItem.FindOrderItemByOrder(ctx, order_id)
 
-		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to fetch order items"}
-	}
+		if err != nil {
+			s.logger.Error("Failed to fetch order items for trashing", zap.Error(err))
 
-	for _, item := range orderItems {
-		_, err := s.orderItemRepository.TrashedOrderItem(item.ID)
+			return &response.ErrorResponse{Status: "error", Message: "Failed to fetch order items"}
+		}
 
-		if err != nil {
-			s.logger.Error("Failed to trash order item", zap.Int("order_item_id", item.ID), zap.Error(err))
+		for _, item := range orderItems {
+			_, err := repos.OrderItem.TrashedOrderItem(ctx, item.ID)
 
-			return nil, &response.ErrorResponse{Status: "error", Message: "Failed to trash order item"}
+			if err != nil {
+				s.logger.Error("Failed to trash order item", zap.Int("order_item_id", item.ID), zap.Error(err))
+
+				return &response.ErrorResponse{Status: "error", Message: "Failed to trash order item"}
+			}
 		}
-	}
 
-	order, err := s.orderRepository.TrashedOrder(order_id)
+		order, err = repos.Order.TrashedOrder(ctx, order_id)
 
-	if err != nil {
-		s.logger.Error("Failed to trash order", zap.Error(err))
+		if err != nil {
+			s.logger.Error("Failed to trash order", zap.Error(err))
+
+			return &response.ErrorResponse{Status: "error", Message: "Failed to trash order"}
+		}
+
+		return nil
+	})
 
-		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to trash order"}
+	if errResp != nil {
+		return nil, errResp
 	}
 
 	return s.mapping.ToOrderResponseDeleteAt(order), nil
 }
 
-func (s *orderServiceMapper) RestoreOrder(order_id int) (*response.OrderResponseDeleteAt, *response.ErrorResponse) {
+func (s *orderServiceMapper) RestoreOrder(ctx context.Context, order_id int) (*response.OrderResponseDeleteAt, *response.ErrorResponse) {
 	s.logger.Debug("Restoring order and related order items", zap.Int("order_id", order_id))
 
-	orderItems, err := s.orderItemRepository.FindOrderItemByOrder(order_id)
+	var order *record.OrderRecord
 
-	if err != nil {
-		s.logger.Error("Failed to fetch order items for restoring", zap.Error(err))
+	errResp := withinTransaction(ctx, s.unitOfWork, s.logger, "Failed to restore order", func(repos *repository.Repositories) *response.ErrorResponse {
+		orderItems, err := repos.OrderItem.FindOrderItemByOrder(ctx, order_id)
 
-		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to fetch order items"}
-	}
+		if err != nil {
+			s.logger.Error("Failed to fetch order items for restoring", zap.Error(err))
 
-	for _, item := range orderItems {
-		_, err := s.orderItemRepository.RestoreOrderItem(item.ID)
+			return &response.ErrorResponse{Status: "error", Message: "Failed to fetch order items"}
+		}
 
-		if err != nil {
-			s.logger.Error("Failed to restore order item", zap.Int("order_item_id", item.ID), zap.Error(err))
+		for _, item := range orderItems {
+			_, err := repos.OrderItem.RestoreOrderItem(ctx, item.ID)
+
+			if err != nil {
+				s.logger.Error("Failed to restore order item", zap.Int("order_item_id", item.ID), zap.Error(err))
 
-			return nil, &response.ErrorResponse{Status: "error", Message: "Failed to restore order item"}
+				return &response.ErrorResponse{Status: "error", Message: "Failed to restore order item"}
+			}
 		}
-	}
 
-	order, err := s.orderRepository.RestoreOrder(order_id)
+		order, err = repos.Order.RestoreOrder(ctx, order_id)
 
-	if err != nil {
-		s.logger.Error("Failed to restore order", zap.Error(err))
-		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to restore order"}
+		if err != nil {
+			s.logger.Error("Failed to restore order", zap.Error(err))
+			return &response.ErrorResponse{Status: "error", Message: "Failed to restore order"}
+		}
+
+		return nil
+	})
+
+	if errResp != nil {
+		return nil, errResp
 	}
 
 	return s.mapping.ToOrderResponseDeleteAt(order), nil
 }
 
-func (s *orderServiceMapper) DeleteOrderPermanent(order_id int) (bool, *response.ErrorResponse) {
+func (s *orderServiceMapper) DeleteOrderPermanent(ctx context.Context, order_id int) (bool, *response.ErrorResponse) {
 	s.logger.Debug("Permanently deleting order and related order items", zap.Int("order_id", order_id))
 
-	orderItems, err := s.orderItemRepository.FindOrderItemByOrder(order_id)
+	var success bool
 
-	if err != nil {
-		s.logger.Error("Failed to fetch order items for permanent deletion", zap.Error(err))
-		return false, &response.ErrorResponse{Status: "error", Message: "Failed to fetch order items"}
-	}
+	errResp := withinTransaction(ctx, s.unitOfWork, s.logger, "Failed to permanently delete order", func(repos *repository.Repositories) *response.ErrorResponse {
+		orderItems, err := repos.OrderItem.FindOrderItemByOrder(ctx, order_id)
 
-	for _, item := range orderItems {
-		_, err := s.orderItemRepository.
-			DeleteOrderItemPermanent(item.ID)
+		if err != nil {
+			s.logger.Error("Failed to fetch order items for permanent deletion", zap.Error(err))
+			return &response.ErrorResponse{Status: "error", Message: "Failed to fetch order items"}
+		}
+
+		for _, item := range orderItems {
+			_, err := repos.OrderItem.DeleteOrderItemPermanent(ctx, item.ID)
+
+			if err != nil {
+				s.logger.Error("Failed to permanently delete order item", zap.Int("order_item_id", item.ID), zap.Error(err))
+				return &response.ErrorResponse{Status: "error", Message: "Failed to permanently delete order item"}
+			}
+		}
+
+		success, err = repos.Order.DeleteOrderPermanent(ctx, order_id)
 
 		if err != nil {
-			s.logger.Error("Failed to permanently delete order item", zap.Int("order_item_id", item.ID), zap.Error(err))
-			return false, &response.ErrorResponse{Status: "error", Message: "Failed to permanently delete order item"}
+			s.logger.Error("Failed to permanently delete order", zap.Error(err))
+			return &response.ErrorResponse{Status: "error", Message: "Failed to permanently delete order"}
 		}
-	}
 
-	success, err := s.orderRepository.DeleteOrderPermanent(order_id)
+		return nil
+	})
 
-	if err != nil {
-		s.logger.Error("Failed to permanently delete order", zap.Error(err))
-		return false, &response.ErrorResponse{Status: "error", Message: "Failed to permanently delete order"}
+	if errResp != nil {
+		return false, errResp
 	}
 
 	return success, nil
 }
 
-func (s *orderServiceMapper) RestoreAllOrder() (bool, *response.ErrorResponse) {
+func (s *orderServiceMapper) RestoreAllOrder(ctx context.Context) (bool, *response.ErrorResponse) {
 	s.logger.Debug("Restoring all trashed orders and related order items")
 
-	successItems, err := s.orderItemRepository.RestoreAllOrderItem()
+	var success bool
 
-	if err != nil || !successItems {
-		s.logger.Error("Failed to restore all order items", zap.Error(err))
-		return false, &response.ErrorResponse{Status: "error", Message: "Failed to restore all order items"}
-	}
+	errResp := withinTransaction(ctx, s.unitOfWork, s.logger, "Failed to restore all orders", func(repos *repository.Repositories) *response.ErrorResponse {
+		successItems, err := repos.OrderItem.RestoreAllOrderItem(ctx)
+
+		if err != nil || !successItems {
+			s.logger.Error("Failed to restore all order items", zap.Error(err))
+			return &response.ErrorResponse{Status: "error", Message: "Failed to restore all order items"}
+		}
 
-	success, err := s.orderRepository.RestoreAllOrder()
+		success, err = repos.Order.RestoreAllOrder(ctx)
 
-	if err != nil || !success {
-		s.logger.Error("Failed to restore all orders", zap.Error(err))
-		return false, &response.ErrorResponse{Status: "error", Message: "Failed to restore all orders"}
+		if err != nil || !success {
+			s.logger.Error("Failed to restore all orders", zap.Error(err))
+			return &response.ErrorResponse{Status: "error", Message: "Failed to restore all orders"}
+		}
+
+		return nil
+	})
+
+	if errResp != nil {
+		return false, errResp
 	}
 
 	return success, nil
 }
 
-func (s *orderServiceMapper) DeleteAllOrderPermanent() (bool, *response.ErrorResponse) {
+func (s *orderServiceMapper) DeleteAllOrderPermanent(ctx context.Context) (bool, *response.ErrorResponse) {
 	s.logger.Debug("Permanently deleting all orders and related order items")
 
-	successItems, err := s.orderItemRepository.DeleteAllOrderPermanent()
+	var success bool
 
-	if err != nil || !successItems {
-		s.logger.Error("Failed to permanently delete all order items", zap.Error(err))
-		return false, &response.ErrorResponse{Status: "error", Message: "Failed to permanently delete all order items"}
-	}
+	errResp := withinTransaction(ctx, s.unitOfWork, s.logger, "Failed to permanently delete all orders", func(repos *repository.Repositories) *response.ErrorResponse {
+		successItems, err := repos.OrderItem.DeleteAllOrderPermanent(ctx)
+
+		if err != nil || !successItems {
+			s.logger.Error("Failed to permanently delete all order items", zap.Error(err))
+			return &response.ErrorResponse{Status: "error", Message: "Failed to permanently delete all order items"}
+		}
 
-	success, err := s.orderRepository.DeleteAllOrderPermanent()
+		success, err = repos.Order.DeleteAllOrderPermanent(ctx)
 
-	if err != nil || !success {
-		s.logger.Error("Failed to permanently delete all orders", zap.Error(err))
-		return false, &response.ErrorResponse{Status: "error", Message: "Failed to permanently delete all orders"}
+		if err != nil || !success {
+			s.logger.Error("Failed to permanently delete all orders", zap.Error(err))
+			return &response.ErrorResponse{Status: "error", Message: "Failed to permanently delete all orders"}
+		}
+
+		return nil
+	})
+
+	if errResp != nil {
+		return false, errResp
 	}
 
 	return success, nil
 }
+
+// placeOrder creates a pending order for one merchant, reserving stock and
+// pricing each item from the current product price, with tax at tax_rate
+// basis points of the items. It must be called inside a unit of work so a
+// failed item rolls the whole order back.
+func placeOrder(
+	ctx context.Context,
+	repos *repository.Repositories,
+	logger logger.LoggerInterface,
+	tax_rate int,
+	merchant_id int,
+	user_id int,
+	items []requests.CreateOrderItemRequest,
+	shipping requests.CreateShippingAddressRequest,
+) (*record.OrderRecord, *response.ErrorResponse) {
+	created, err := repos.Order.CreateOrder(ctx, &requests.CreateOrderRecordRequest{
+		MerchantID: merchant_id,
+		UserID:     user_id,
+		TotalPrice: 0,
+	})
+	if err != nil {
+		logger.Error("Failed to create order", zap.Error(err))
+		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to create order"}
+	}
+
+	_, err = repos.Order.CreateOrderStatusHistory(ctx, &requests.CreateOrderStatusHistoryRecordRequest{
+		OrderID:  created.ID,
+		ToStatus: record.OrderStatusPending,
+	})
+	if err != nil {
+		logger.Error("Failed to record order status history", zap.Error(err))
+		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to record order status history"}
+	}
+
+	for _, item := range items {
+		product, errResp := reserveStock(ctx, repos, logger, item.ProductID, item.Quantity)
+		if errResp != nil {
+			return nil, errResp
+		}
+
+		_, err = repos.OrderItem.CreateOrderItem(ctx, &requests.CreateOrderItemRecordRequest{
+			OrderID:   created.ID,
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+			Price:     product.Price,
+		})
+		if err != nil {
+			logger.Error("Failed to create order item", zap.Error(err))
+			return nil, &response.ErrorResponse{Status: "error", Message: "Failed to create order item"}
+		}
+	}
+
+	_, err = repos.Shipping.CreateShippingAddress(ctx, &requests.CreateShippingAddressRequest{
+		OrderID:        created.ID,
+		Alamat:         shipping.Alamat,
+		Provinsi:       shipping.Provinsi,
+		Kota:           shipping.Kota,
+		Courier:        shipping.Courier,
+		ShippingMethod: shipping.ShippingMethod,
+		ShippingCost:   shipping.ShippingCost,
+		Negara:         shipping.Negara,
+	})
+	if err != nil {
+		logger.Error("Failed to create shipping address", zap.Error(err))
+		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to create shipping address"}
+	}
+
+	order, err := repos.Order.RecalculateTotal(ctx, created.ID, tax_rate)
+	if err != nil {
+		logger.Error("Failed to update order total price", zap.Error(err))
+		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to update order total price"}
+	}
+
+	return order, nil
+}
+
+// adjustReservedStock reserves or releases the difference when an existing
+// order item's quantity changes, so the stock taken for the order keeps
+// matching its items.
+func adjustReservedStock(ctx context.Context, repos *repository.Repositories, logger logger.LoggerInterface, existing *record.OrderItemRecord, item requests.UpdateOrderItemRequest) *response.ErrorResponse {
+	if item.ProductID != existing.ProductID {
+		logger.Error("Order item product cannot change", zap.Int("orderItemID", existing.ID), zap.Int("productID", item.ProductID))
+		return &response.ErrorResponse{Status: "error", Message: "Order item product cannot be changed", Code: response.ErrCodeValidation}
+	}
+
+	if item.Quantity < 1 {
+		logger.Error("Invalid order item quantity", zap.Int("orderItemID", existing.ID), zap.Int("quantity", item.Quantity))
+		return &response.ErrorResponse{Status: "error", Message: "Invalid order item quantity", Code: response.ErrCodeValidation}
+	}
+
+	switch diff := item.Quantity - existing.Quantity; {
+	case diff > 0:
+		if _, errResp := reserveStock(ctx, repos, logger, existing.ProductID, diff); errResp != nil {
+			return errResp
+		}
+	case diff < 0:
+		if _, err := repos.Product.ReleaseProductStock(ctx, existing.ProductID, -diff); err != nil {
+			logger.Error("Failed to release product stock", zap.Int("productID", existing.ProductID), zap.Error(err))
+			return &response.ErrorResponse{Status: "error", Message: "Failed to update product stock"}
+		}
+	}
+
+	return nil
+}
+
+// reserveStock atomically takes quantity units of the product out of stock,
+// failing instead of overselling when a concurrent order got there first.
+func reserveStock(ctx context.Context, repos *repository.Repositories, logger logger.LoggerInterface, product_id int, quantity int) (*record.ProductRecord, *response.ErrorResponse) {
+	if quantity < 1 {
+		logger.Error("Invalid order item quantity", zap.Int("productID", product_id), zap.Int("quantity", quantity))
+		return nil, &response.ErrorResponse{Status: "error", Message: "Invalid order item quantity", Code: response.ErrCodeValidation}
+	}
+
+	if _, err := repos.Product.FindById(ctx, product_id); err != nil {
+		logger.Error("Product not found", zap.Int("productID", product_id), zap.Error(err))
+		return nil, &response.ErrorResponse{Status: "error", Message: "Product not found", Code: response.ErrCodeNotFound}
+	}
+
+	product, err := repos.Product.ReserveProductStock(ctx, product_id, quantity)
+	if err != nil {
+		if errors.Is(err, repository.ErrInsufficientStock) {
+			logger.Error("Insufficient product stock", zap.Int("productID", product_id), zap.Int("quantity", quantity))
+			return nil, &response.ErrorResponse{
+				Status:  "error",
+				Message: "Insufficient stock for product",
+				Code:    response.ErrCodeInsufficientStock,
+			}
+		}
+
+		logger.Error("Failed to update product stock", zap.Error(err))
+		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to update product stock"}
+	}
+
+	return product, nil
+}
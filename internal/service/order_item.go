@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"ecommerce/internal/domain/response"
 	response_service "ecommerce/internal/mapper/response/services"
 	"ecommerce/internal/repository"
@@ -27,10 +28,10 @@ func NewOrderItemService(
 	}
 }
 
-func (s *orderItemService) FindAllOrderItems(search string, page, pageSize int) ([]*response.OrderItemResponse, int, *response.ErrorResponse) {
+func (s *orderItemService) FindAllOrderItems(ctx context.Context, search string, page, pageSize int) ([]*response.OrderItemResponse, int, *response.ErrorResponse) {
 	s.logger.Debug("Fetching all order items", zap.String("search", search), zap.Int("page", page), zap.Int("pageSize", pageSize))
 
-	orderItems, total, err := s.orderItemRepository.FindAllOrderItems(search, page, pageSize)
+	orderItems, total, err := s.orderItemRepository.FindAllOrderItems(ctx, search, page, pageSize)
 	if err != nil {
 		s.logger.Error("Failed to fetch order items", zap.Error(err))
 		return nil, 0, &response.ErrorResponse{Status: "error", Message: "Failed to fetch order items"}
@@ -39,10 +40,10 @@ func (s *orderItemService) FindAllOrderItems(search string, page, pageSize int)
 	return s.mapping.ToOrderItemsResponse(orderItems), total, nil
 }
 
-func (s *orderItemService) FindByActive(search string, page, pageSize int) ([]*response.OrderItemResponseDeleteAt, int, *response.ErrorResponse) {
+func (s *orderItemService) FindByActive(ctx context.Context, search string, page, pageSize int) ([]*response.OrderItemResponseDeleteAt, int, *response.ErrorResponse) {
 	s.logger.Debug("Fetching active order items", zap.String("search", search), zap.Int("page", page), zap.Int("pageSize", pageSize))
 
-	orderItems, total, err := s.orderItemRepository.FindByActive(search, page, pageSize)
+	orderItems, total, err := s.orderItemRepository.FindByActive(ctx, search, page, pageSize)
 	if err != nil {
 		s.logger.Error("Failed to fetch active order items", zap.Error(err))
 		return nil, 0, &response.ErrorResponse{Status: "error", Message: "Failed to fetch active order items"}
@@ -51,10 +52,10 @@ func (s *orderItemService) FindByActive(search string, page, pageSize int) ([]*r
 	return s.mapping.ToOrderItemsResponseDeleteAt(orderItems), total, nil
 }
 
-func (s *orderItemService) FindByTrashed(search string, page, pageSize int) ([]*response.OrderItemResponseDeleteAt, int, *response.ErrorResponse) {
+func (s *orderItemService) FindByTrashed(ctx context.Context, search string, page, pageSize int) ([]*response.OrderItemResponseDeleteAt, int, *response.ErrorResponse) {
 	s.logger.Debug("Fetching trashed order items", zap.String("search", search), zap.Int("page", page), zap.Int("pageSize", pageSize))
 
-	orderItems, total, err := s.orderItemRepository.FindByTrashed(search, page, pageSize)
+	orderItems, total, err := s.orderItemRepository.FindByTrashed(ctx, search, page, pageSize)
 	if err != nil {
 		s.logger.Error("Failed to fetch trashed order items", zap.Error(err))
 		return nil, 0, &response.ErrorResponse{Status: "error", Message: "Failed to fetch trashed order items"}
@@ -63,10 +64,10 @@ func (s *orderItemService) FindByTrashed(search string, page, pageSize int) ([]*
 	return s.mapping.ToOrderItemsResponseDeleteAt(orderItems), total, nil
 }
 
-func (s *orderItemService) FindOrderItemByOrder(orderID int) ([]*response.OrderItemResponse, *response.ErrorResponse) {
+func (s *orderItemService) FindOrderItemByOrder(ctx context.Context, orderID int) ([]*response.OrderItemResponse, *response.ErrorResponse) {
 	s.logger.Debug("Fetching order items by order", zap.Int("order_id", orderID))
 
-	orderItems, err := s.orderItemRepository.FindOrderItemByOrder(orderID)
+	orderItems, err := s.orderItemRepository.FindOrderItemByOrder(ctx, orderID)
 	if err != nil {
 		s.logger.Error("Failed to fetch order items by order", zap.Error(err))
 		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to fetch order items by order"}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"ecommerce/internal/domain/record"
+	"ecommerce/internal/domain/requests"
+	"ecommerce/internal/domain/response"
+	"ecommerce/internal/repository"
+	"ecommerce/pkg/logger"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// orderStatusTransitions lists, for every order status, the statuses it may
+// move to next. Cancelled and refunded orders are final.
+var orderStatusTransitions = map[string][]string{
+	record.OrderStatusPending:    {record.OrderStatusPaid, record.OrderStatusCancelled},
+	record.OrderStatusPaid:       {record.OrderStatusProcessing, record.OrderStatusCancelled, record.OrderStatusRefunded},
+	record.OrderStatusProcessing: {record.OrderStatusShipped, record.OrderStatusCancelled, record.OrderStatusRefunded},
+	record.OrderStatusShipped:    {record.OrderStatusDelivered},
+	record.OrderStatusDelivered:  {record.OrderStatusRefunded},
+	record.OrderStatusCancelled:  {},
+	record.OrderStatusRefunded:   {},
+}
+
+func canTransitionOrder(from string, to string) bool {
+	for _, next := range orderStatusTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// changeOrderStatus moves an order to status and records the change in its
+// status history. It is meant to run inside a unit of work so the status and
+// its history entry are written together.
+func changeOrderStatus(
+	ctx context.Context,
+	repos *repository.Repositories,
+	logger logger.LoggerInterface,
+	order_id int,
+	status string,
+	note string,
+) (*record.OrderRecord, *response.ErrorResponse) {
+	order, err := repos.Order.FindById(ctx, order_id)
+	if err != nil {
+		logger.Error("Order not found", zap.Int("orderID", order_id), zap.Error(err))
+		return nil, &response.ErrorResponse{Status: "error", Message: "Order not found", Code: response.ErrCodeNotFound}
+	}
+
+	if !canTransitionOrder(order.Status, status) {
+		logger.Error("Invalid order status transition",
+			zap.Int("orderID", order_id),
+			zap.String("from", order.Status),
+			zap.String("to", status))
+
+		return nil, &response.ErrorResponse{
+			Status:  "error",
+			Message: fmt.Sprintf("Order cannot move from %s to %s", order.Status, status),
+			Code:    response.ErrCodeInvalidStatusTransition,
+		}
+	}
+
+	updated, err := repos.Order.UpdateOrderStatus(ctx, order_id, order.Status, status)
+	if err != nil {
+		if errors.Is(err, repository.ErrOrderStatusConflict) {
+			logger.Error("Order status changed concurrently", zap.Int("orderID", order_id), zap.Error(err))
+			return nil, &response.ErrorResponse{
+				Status:  "error",
+				Message: "Order status was changed by another request",
+				Code:    response.ErrCodeInvalidStatusTransition,
+			}
+		}
+
+		logger.Error("Failed to update order status", zap.Int("orderID", order_id), zap.Error(err))
+		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to update order status"}
+	}
+
+	_, err = repos.Order.CreateOrderStatusHistory(ctx, &requests.CreateOrderStatusHistoryRecordRequest{
+		OrderID:    order_id,
+		FromStatus: order.Status,
+		ToStatus:   status,
+		Note:       note,
+	})
+	if err != nil {
+		logger.Error("Failed to record order status history", zap.Int("orderID", order_id), zap.Error(err))
+		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to record order status history"}
+	}
+
+	return updated, nil
+}
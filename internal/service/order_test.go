@@ -0,0 +1,266 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"ecommerce/internal/domain/requests"
+	"ecommerce/internal/domain/response"
+	"ecommerce/internal/service"
+	"ecommerce/pkg/database/testdb"
+)
+
+type testOrder struct {
+	orderID    int
+	itemID     int
+	shippingID int
+}
+
+// placeTestOrder orders quantity units of productID for buyerID.
+func placeTestOrder(t *testing.T, conn *sql.DB, services *service.Service, buyerID, merchantID, productID, quantity int) testOrder {
+	t.Helper()
+
+	order, errResp := services.Order.CreateOrder(context.Background(), &requests.CreateOrderRequest{
+		MerchantID: merchantID,
+		UserID:     buyerID,
+		Items:      []requests.CreateOrderItemRequest{{ProductID: productID, Quantity: quantity}},
+		ShippingAddress: requests.CreateShippingAddressRequest{
+			Alamat:         "Jl. Test No. 1",
+			Provinsi:       "Jawa Barat",
+			Kota:           "Bandung",
+			Courier:        "JNE",
+			ShippingMethod: "REG",
+			ShippingCost:   500,
+			Negara:         "Indonesia",
+		},
+	})
+	if errResp != nil {
+		t.Fatalf("create order: %s", errResp.Message)
+	}
+
+	placed := testOrder{orderID: order.ID}
+
+	err := conn.QueryRow(`SELECT order_item_id FROM order_items WHERE order_id = $1`, order.ID).Scan(&placed.itemID)
+	if err != nil {
+		t.Fatalf("read order item: %v", err)
+	}
+
+	err = conn.QueryRow(`SELECT shipping_address_id FROM shipping_addresses WHERE order_id = $1`, order.ID).Scan(&placed.shippingID)
+	if err != nil {
+		t.Fatalf("read shipping address: %v", err)
+	}
+
+	return placed
+}
+
+func TestUpdateOrderAdjustsReservedStock(t *testing.T) {
+	conn := testdb.Open(t)
+	services := newTestService(t, conn, nil)
+
+	tests := []struct {
+		name      string
+		quantity  int
+		otherItem bool
+		wantCode  string
+		wantStock int
+	}{
+		{name: "increase reserves the difference", quantity: 5, wantStock: 5},
+		{name: "decrease releases the difference", quantity: 1, wantStock: 9},
+		{name: "unchanged keeps the stock", quantity: 2, wantStock: 8},
+		{name: "increase beyond stock", quantity: 1000, wantCode: response.ErrCodeInsufficientStock, wantStock: 8},
+		{name: "item of another order", quantity: 1, otherItem: true, wantCode: response.ErrCodeNotFound, wantStock: 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, merchantID := testdb.SeedMerchant(t, conn)
+			productID := testdb.SeedProduct(t, conn, merchantID, 1000, 10)
+			buyerID := testdb.SeedUser(t, conn)
+
+			order := placeTestOrder(t, conn, services, buyerID, merchantID, productID, 2)
+
+			itemID := order.itemID
+			if tt.otherItem {
+				otherProductID := testdb.SeedProduct(t, conn, merchantID, 1000, 10)
+				itemID = placeTestOrder(t, conn, services, buyerID, merchantID, otherProductID, 1).itemID
+			}
+
+			_, errResp := services.Order.UpdateOrder(context.Background(), &requests.UpdateOrderRequest{
+				OrderID: order.orderID,
+				UserID:  buyerID,
+				Items: []requests.UpdateOrderItemRequest{
+					{OrderItemID: itemID, ProductID: productID, Quantity: tt.quantity},
+				},
+				ShippingAddress: requests.UpdateShippingAddressRequest{
+					ShippingID:     order.shippingID,
+					OrderID:        order.orderID,
+					Alamat:         "Jl. Test No. 2",
+					Provinsi:       "Jawa Barat",
+					Kota:           "Bandung",
+					Courier:        "JNE",
+					ShippingMethod: "REG",
+					ShippingCost:   500,
+					Negara:         "Indonesia",
+				},
+			})
+
+			switch {
+			case tt.wantCode == "" && errResp != nil:
+				t.Fatalf("update order: %s", errResp.Message)
+			case tt.wantCode != "" && (errResp == nil || errResp.Code != tt.wantCode):
+				t.Fatalf("update order = %+v, want code %s", errResp, tt.wantCode)
+			}
+
+			if stock := testdb.ProductStock(t, conn, productID); stock != tt.wantStock {
+				t.Errorf("count_in_stock = %d, want %d", stock, tt.wantStock)
+			}
+		})
+	}
+}
+
+func TestOrderTotalIncludesTaxAndDiscount(t *testing.T) {
+	conn := testdb.Open(t)
+	services := newTestService(t, conn, nil, func(deps *service.Deps) {
+		deps.TaxRate = 1100
+	})
+
+	tests := []struct {
+		name         string
+		discount     int64
+		wantTax      int64
+		wantDiscount int64
+		wantTotal    int64
+	}{
+		{name: "no discount", wantTax: 220, wantTotal: 2720},
+		{name: "discount", discount: 300, wantTax: 220, wantDiscount: 300, wantTotal: 2420},
+		{name: "discount above the total", discount: 5000, wantTax: 220, wantDiscount: 5000, wantTotal: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, merchantID := testdb.SeedMerchant(t, conn)
+			productID := testdb.SeedProduct(t, conn, merchantID, 1000, 10)
+			buyerID := testdb.SeedUser(t, conn)
+
+			placed := placeTestOrder(t, conn, services, buyerID, merchantID, productID, 2)
+
+			order, errResp := services.Order.UpdateOrder(context.Background(), &requests.UpdateOrderRequest{
+				OrderID:        placed.orderID,
+				UserID:         buyerID,
+				DiscountAmount: tt.discount,
+				Items: []requests.UpdateOrderItemRequest{
+					{OrderItemID: placed.itemID, ProductID: productID, Quantity: 2},
+				},
+				ShippingAddress: requests.UpdateShippingAddressRequest{
+					ShippingID:     placed.shippingID,
+					OrderID:        placed.orderID,
+					Alamat:         "Jl. Test No. 1",
+					Provinsi:       "Jawa Barat",
+					Kota:           "Bandung",
+					Courier:        "JNE",
+					ShippingMethod: "REG",
+					ShippingCost:   500,
+					Negara:         "Indonesia",
+				},
+			})
+			if errResp != nil {
+				t.Fatalf("update order: %s", errResp.Message)
+			}
+
+			if order.Subtotal.Amount != 2000 || order.ShippingCost.Amount != 500 {
+				t.Errorf("subtotal, shipping = %d, %d, want 2000, 500", order.Subtotal.Amount, order.ShippingCost.Amount)
+			}
+
+			if order.TaxAmount.Amount != tt.wantTax {
+				t.Errorf("tax_amount = %d, want %d", order.TaxAmount.Amount, tt.wantTax)
+			}
+
+			if order.DiscountAmount.Amount != tt.wantDiscount {
+				t.Errorf("discount_amount = %d, want %d", order.DiscountAmount.Amount, tt.wantDiscount)
+			}
+
+			if order.TotalPrice.Amount != tt.wantTotal {
+				t.Errorf("total_price = %d, want %d", order.TotalPrice.Amount, tt.wantTotal)
+			}
+		})
+	}
+}
+
+func TestOrderStatusTransitions(t *testing.T) {
+	conn := testdb.Open(t)
+	services := newTestService(t, conn, nil)
+
+	type action func(context.Context, *requests.UpdateOrderStatusRequest) (*response.OrderResponse, *response.ErrorResponse)
+
+	tests := []struct {
+		name      string
+		from      string
+		action    action
+		want      string
+		wantCode  string
+		wantStock int
+	}{
+		{name: "pending to cancelled", from: "pending", action: services.Order.CancelOrder, want: "cancelled", wantStock: 10},
+		{name: "paid to processing", from: "paid", action: services.Order.MarkProcessing, want: "processing", wantStock: 8},
+		{name: "processing to shipped", from: "processing", action: services.Order.MarkShipped, want: "shipped", wantStock: 8},
+		{name: "shipped to delivered", from: "shipped", action: services.Order.MarkDelivered, want: "delivered", wantStock: 8},
+		{name: "processing to cancelled", from: "processing", action: services.Order.CancelOrder, want: "cancelled", wantStock: 10},
+		{name: "pending cannot ship", from: "pending", action: services.Order.MarkShipped, want: "pending", wantCode: response.ErrCodeInvalidStatusTransition, wantStock: 8},
+		{name: "shipped cannot be cancelled", from: "shipped", action: services.Order.CancelOrder, want: "shipped", wantCode: response.ErrCodeInvalidStatusTransition, wantStock: 8},
+		{name: "cancelled is final", from: "cancelled", action: services.Order.MarkProcessing, want: "cancelled", wantCode: response.ErrCodeInvalidStatusTransition, wantStock: 8},
+		{name: "refunded is final", from: "refunded", action: services.Order.CancelOrder, want: "refunded", wantCode: response.ErrCodeInvalidStatusTransition, wantStock: 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, merchantID := testdb.SeedMerchant(t, conn)
+			productID := testdb.SeedProduct(t, conn, merchantID, 1000, 10)
+			buyerID := testdb.SeedUser(t, conn)
+
+			orderID := placeTestOrder(t, conn, services, buyerID, merchantID, productID, 2).orderID
+
+			if _, err := conn.Exec(`UPDATE orders SET status = $2 WHERE order_id = $1`, orderID, tt.from); err != nil {
+				t.Fatalf("set order status: %v", err)
+			}
+
+			_, errResp := tt.action(context.Background(), &requests.UpdateOrderStatusRequest{OrderID: orderID, Note: tt.name})
+
+			switch {
+			case tt.wantCode == "" && errResp != nil:
+				t.Fatalf("change status: %s", errResp.Message)
+			case tt.wantCode != "" && (errResp == nil || errResp.Code != tt.wantCode):
+				t.Fatalf("change status = %+v, want code %s", errResp, tt.wantCode)
+			}
+
+			var status string
+			if err := conn.QueryRow(`SELECT status FROM orders WHERE order_id = $1`, orderID).Scan(&status); err != nil {
+				t.Fatalf("read order status: %v", err)
+			}
+
+			if status != tt.want {
+				t.Errorf("status = %s, want %s", status, tt.want)
+			}
+
+			var histories int
+			err := conn.QueryRow(`SELECT COUNT(*) FROM order_status_histories WHERE order_id = $1 AND from_status = $2 AND to_status = $3`,
+				orderID, tt.from, tt.want).Scan(&histories)
+			if err != nil {
+				t.Fatalf("read status history: %v", err)
+			}
+
+			wantHistories := 0
+			if tt.wantCode == "" {
+				wantHistories = 1
+			}
+
+			if histories != wantHistories {
+				t.Errorf("status history rows = %d, want %d", histories, wantHistories)
+			}
+
+			if stock := testdb.ProductStock(t, conn, productID); stock != tt.wantStock {
+				t.Errorf("count_in_stock = %d, want %d", stock, tt.wantStock)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"ecommerce/internal/domain/response"
+	"ecommerce/internal/repository"
+	"ecommerce/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// ownershipService decides whether a user may write to merchant-scoped data.
+// A resource belongs to the user whose ID is stored in merchants.user_id of
+// the merchant the resource is attached to. Reviews belong to their author.
+type ownershipService struct {
+	merchantRepository    repository.MerchantRepository
+	productRepository     repository.ProductRepository
+	orderRepository       repository.OrderRepository
+	transactionRepository repository.TransactionRepository
+	shippingRepository    repository.ShippingAddressRepository
+	reviewRepository      repository.ReviewRepository
+	logger                logger.LoggerInterface
+}
+
+func NewOwnershipService(
+	merchantRepository repository.MerchantRepository,
+	productRepository repository.ProductRepository,
+	orderRepository repository.OrderRepository,
+	transactionRepository repository.TransactionRepository,
+	shippingRepository repository.ShippingAddressRepository,
+	reviewRepository repository.ReviewRepository,
+	logger logger.LoggerInterface,
+) *ownershipService {
+	return &ownershipService{
+		merchantRepository:    merchantRepository,
+		productRepository:     productRepository,
+		orderRepository:       orderRepository,
+		transactionRepository: transactionRepository,
+		shippingRepository:    shippingRepository,
+		reviewRepository:      reviewRepository,
+		logger:                logger,
+	}
+}
+
+func (s *ownershipService) AuthorizeMerchant(ctx context.Context, user_id int, merchant_id int) *response.ErrorResponse {
+	merchant, err := s.merchantRepository.FindById(ctx, merchant_id)
+	if err != nil {
+		s.logger.Error("Merchant not found", zap.Int("merchant_id", merchant_id), zap.Error(err))
+		return &response.ErrorResponse{
+			Status:  "error",
+			Message: "Merchant not found",
+			Code:    response.ErrCodeNotFound,
+		}
+	}
+
+	if merchant.UserID != user_id {
+		s.logger.Debug("Merchant is owned by another user", zap.Int("merchant_id", merchant_id), zap.Int("user_id", user_id))
+		return &response.ErrorResponse{
+			Status:  "error",
+			Message: "You do not own this merchant",
+			Code:    response.ErrCodeForbidden,
+		}
+	}
+
+	return nil
+}
+
+func (s *ownershipService) AuthorizeProduct(ctx context.Context, user_id int, product_id int) *response.ErrorResponse {
+	product, err := s.productRepository.FindById(ctx, product_id)
+	if err != nil {
+		s.logger.Error("Product not found", zap.Int("product_id", product_id), zap.Error(err))
+		return &response.ErrorResponse{
+			Status:  "error",
+			Message: "Product not found",
+			Code:    response.ErrCodeNotFound,
+		}
+	}
+
+	return s.AuthorizeMerchant(ctx, user_id, product.MerchantID)
+}
+
+func (s *ownershipService) AuthorizeOrder(ctx context.Context, user_id int, order_id int) *response.ErrorResponse {
+	order, err := s.orderRepository.FindById(ctx, order_id)
+	if err != nil {
+		s.logger.Error("Order not found", zap.Int("order_id", order_id), zap.Error(err))
+		return &response.ErrorResponse{
+			Status:  "error",
+			Message: "Order not found",
+			Code:    response.ErrCodeNotFound,
+		}
+	}
+
+	return s.AuthorizeMerchant(ctx, user_id, order.MerchantID)
+}
+
+func (s *ownershipService) AuthorizeTransaction(ctx context.Context, user_id int, transaction_id int) *response.ErrorResponse {
+	transaction, err := s.transactionRepository.FindById(ctx, transaction_id)
+	if err != nil {
+		s.logger.Error("Transaction not found", zap.Int("transaction_id", transaction_id), zap.Error(err))
+		return &response.ErrorResponse{
+			Status:  "error",
+			Message: "Transaction not found",
+			Code:    response.ErrCodeNotFound,
+		}
+	}
+
+	return s.AuthorizeMerchant(ctx, user_id, transaction.MerchantID)
+}
+
+// AuthorizeOrderAccess lets the buyer of an order read it as well as the
+// owner of the merchant it was placed with.
+func (s *ownershipService) AuthorizeOrderAccess(ctx context.Context, user_id int, order_id int) *response.ErrorResponse {
+	order, err := s.orderRepository.FindById(ctx, order_id)
+	if err != nil {
+		s.logger.Error("Order not found", zap.Int("order_id", order_id), zap.Error(err))
+		return &response.ErrorResponse{
+			Status:  "error",
+			Message: "Order not found",
+			Code:    response.ErrCodeNotFound,
+		}
+	}
+
+	if order.UserID == user_id {
+		return nil
+	}
+
+	return s.AuthorizeMerchant(ctx, user_id, order.MerchantID)
+}
+
+// AuthorizeTransactionAccess applies AuthorizeOrderAccess to the order the
+// transaction pays for.
+func (s *ownershipService) AuthorizeTransactionAccess(ctx context.Context, user_id int, transaction_id int) *response.ErrorResponse {
+	transaction, err := s.transactionRepository.FindById(ctx, transaction_id)
+	if err != nil {
+		s.logger.Error("Transaction not found", zap.Int("transaction_id", transaction_id), zap.Error(err))
+		return &response.ErrorResponse{
+			Status:  "error",
+			Message: "Transaction not found",
+			Code:    response.ErrCodeNotFound,
+		}
+	}
+
+	return s.AuthorizeOrderAccess(ctx, user_id, transaction.OrderID)
+}
+
+// AuthorizeShippingAccess applies AuthorizeOrderAccess to the order the
+// shipping address belongs to.
+func (s *ownershipService) AuthorizeShippingAccess(ctx context.Context, user_id int, shipping_id int) *response.ErrorResponse {
+	shipping, err := s.shippingRepository.FindById(ctx, shipping_id)
+	if err != nil {
+		s.logger.Error("Shipping address not found", zap.Int("shipping_id", shipping_id), zap.Error(err))
+		return &response.ErrorResponse{
+			Status:  "error",
+			Message: "Shipping address not found",
+			Code:    response.ErrCodeNotFound,
+		}
+	}
+
+	return s.AuthorizeOrderAccess(ctx, user_id, shipping.OrderID)
+}
+
+// AuthorizeReview lets only the author of a review change it.
+func (s *ownershipService) AuthorizeReview(ctx context.Context, user_id int, review_id int) *response.ErrorResponse {
+	review, err := s.reviewRepository.FindById(ctx, review_id)
+	if err != nil {
+		s.logger.Error("Review not found", zap.Int("review_id", review_id), zap.Error(err))
+		return &response.ErrorResponse{
+			Status:  "error",
+			Message: "Review not found",
+			Code:    response.ErrCodeNotFound,
+		}
+	}
+
+	if review.UserID != user_id {
+		s.logger.Debug("Review is written by another user", zap.Int("review_id", review_id), zap.Int("user_id", user_id))
+		return &response.ErrorResponse{
+			Status:  "error",
+			Message: "You did not write this review",
+			Code:    response.ErrCodeForbidden,
+		}
+	}
+
+	return nil
+}
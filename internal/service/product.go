@@ -1,15 +1,22 @@
 package service
 
 import (
+	"context"
 	"ecommerce/internal/domain/requests"
 	"ecommerce/internal/domain/response"
 	response_service "ecommerce/internal/mapper/response/services"
 	"ecommerce/internal/repository"
 	"ecommerce/pkg/logger"
+	"ecommerce/pkg/pagination"
+	"strings"
 
 	"go.uber.org/zap"
 )
 
+// productPriceBounds delimits the price buckets reported by Search, in the
+// smallest currency unit.
+var productPriceBounds = []int64{100000, 500000, 1000000, 5000000}
+
 type productService struct {
 	categoryRepository repository.CategoryRepository
 	merchantRepository repository.MerchantRepository
@@ -34,7 +41,7 @@ func NewProductService(
 	}
 }
 
-func (s *productService) FindAll(page, pageSize int, search string) ([]*response.ProductResponse, int, *response.ErrorResponse) {
+func (s *productService) FindAll(ctx context.Context, page, pageSize int, search string, filter *requests.ProductFilter) ([]*response.ProductResponse, int, *response.ErrorResponse) {
 	s.logger.Debug("Fetching products",
 		zap.Int("page", page),
 		zap.Int("pageSize", pageSize),
@@ -48,7 +55,12 @@ func (s *productService) FindAll(page, pageSize int, search string) ([]*response
 		pageSize = 10
 	}
 
-	products, totalRecords, err := s.productRepository.FindAllProducts(search, page, pageSize)
+	filter, errResp := s.normalizeFilter(filter)
+	if errResp != nil {
+		return nil, 0, errResp
+	}
+
+	products, totalRecords, err := s.productRepository.FindAllProducts(ctx, search, filter, page, pageSize)
 	if err != nil {
 		s.logger.Error("Failed to fetch products",
 			zap.Error(err),
@@ -66,7 +78,45 @@ func (s *productService) FindAll(page, pageSize int, search string) ([]*response
 	return s.mapping.ToProductsResponse(products), totalRecords, nil
 }
 
-func (s *productService) FindByMerchant(merchant_id int, page, pageSize int, search string) ([]*response.ProductResponse, int, *response.ErrorResponse) {
+func (s *productService) FindAllCursor(ctx context.Context, cursor string, pageSize int, search string) ([]*response.ProductResponse, string, *response.ErrorResponse) {
+	s.logger.Debug("Fetching products by cursor",
+		zap.String("cursor", cursor),
+		zap.Int("pageSize", pageSize),
+		zap.String("search", search))
+
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+
+	after, err := pagination.DecodeCursor(cursor)
+	if err != nil {
+		s.logger.Debug("Invalid cursor", zap.String("cursor", cursor))
+		return nil, "", &response.ErrorResponse{Status: "error", Message: "Invalid cursor", Code: response.ErrCodeValidation}
+	}
+
+	products, next, err := s.productRepository.FindAllProductsCursor(ctx, search, after, pageSize)
+	if err != nil {
+		s.logger.Error("Failed to fetch products",
+			zap.Error(err),
+			zap.String("cursor", cursor),
+			zap.Int("pageSize", pageSize),
+			zap.String("search", search))
+		return nil, "", &response.ErrorResponse{Status: "error", Message: "Failed to fetch products"}
+	}
+
+	var nextCursor string
+	if next != nil {
+		nextCursor = next.Encode()
+	}
+
+	s.logger.Debug("Successfully fetched products by cursor",
+		zap.Int("count", len(products)),
+		zap.Bool("hasMore", nextCursor != ""))
+
+	return s.mapping.ToProductsResponse(products), nextCursor, nil
+}
+
+func (s *productService) FindByMerchant(ctx context.Context, merchant_id int, page, pageSize int, search string, filter *requests.ProductFilter) ([]*response.ProductResponse, int, *response.ErrorResponse) {
 	s.logger.Debug("Fetching products",
 		zap.Int("page", page),
 		zap.Int("pageSize", pageSize),
@@ -80,7 +130,45 @@ func (s *productService) FindByMerchant(merchant_id int, page, pageSize int, sea
 		pageSize = 10
 	}
 
-	products, totalRecords, err := s.productRepository.FindByMerchant(merchant_id, search, page, pageSize)
+	filter, errResp := s.normalizeFilter(filter)
+	if errResp != nil {
+		return nil, 0, errResp
+	}
+
+	products, totalRecords, err := s.productRepository.FindByMerchant(ctx, merchant_id, search, filter, page, pageSize)
+	if err != nil {
+		s.logger.Error("Failed to fetch products",
+			zap.Error(err),
+			zap.Int("page", page),
+			zap.Int("pageSize", pageSize),
+			zap.String("search", search))
+		return nil, 0, &response.ErrorResponse{Status: "error", Message: "Failed to fetch products"}
+	}
+
+	s.logger.Debug("Successfully fetched products",
+		zap.Int("totalRecords", totalRecords),
+		zap.Int("page", page),
+		zap.Int("pageSize", pageSize))
+
+	return s.mapping.ToProductsResponse(products), totalRecords, nil
+}
+
+func (s *productService) FindByUser(ctx context.Context, user_id int, page, pageSize int, search string) ([]*response.ProductResponse, int, *response.ErrorResponse) {
+	s.logger.Debug("Fetching products by user",
+		zap.Int("userID", user_id),
+		zap.Int("page", page),
+		zap.Int("pageSize", pageSize),
+		zap.String("search", search))
+
+	if page <= 0 {
+		page = 1
+	}
+
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+
+	products, totalRecords, err := s.productRepository.FindByUser(ctx, user_id, search, page, pageSize)
 	if err != nil {
 		s.logger.Error("Failed to fetch products",
 			zap.Error(err),
@@ -98,7 +186,7 @@ func (s *productService) FindByMerchant(merchant_id int, page, pageSize int, sea
 	return s.mapping.ToProductsResponse(products), totalRecords, nil
 }
 
-func (s *productService) FindByCategory(category_name string, page, pageSize int, search string) ([]*response.ProductResponse, int, *response.ErrorResponse) {
+func (s *productService) FindByCategory(ctx context.Context, category_name string, page, pageSize int, search string, filter *requests.ProductFilter) ([]*response.ProductResponse, int, *response.ErrorResponse) {
 	s.logger.Debug("Fetching products",
 		zap.Int("page", page),
 		zap.Int("pageSize", pageSize),
@@ -112,7 +200,12 @@ func (s *productService) FindByCategory(category_name string, page, pageSize int
 		pageSize = 10
 	}
 
-	products, totalRecords, err := s.productRepository.FindByCategory(category_name, search, page, pageSize)
+	filter, errResp := s.normalizeFilter(filter)
+	if errResp != nil {
+		return nil, 0, errResp
+	}
+
+	products, totalRecords, err := s.productRepository.FindByCategory(ctx, category_name, search, filter, page, pageSize)
 	if err != nil {
 		s.logger.Error("Failed to fetch products",
 			zap.Error(err),
@@ -130,19 +223,66 @@ func (s *productService) FindByCategory(category_name string, page, pageSize int
 	return s.mapping.ToProductsResponse(products), totalRecords, nil
 }
 
-func (s *productService) FindById(productID int) (*response.ProductResponse, *response.ErrorResponse) {
+func (s *productService) Search(ctx context.Context, request *requests.SearchProductRequest) ([]*response.ProductResponse, *response.ProductFacetsResponse, int, *response.ErrorResponse) {
+	s.logger.Debug("Searching products",
+		zap.String("query", request.Query),
+		zap.Int("page", request.Page),
+		zap.Int("pageSize", request.PageSize))
+
+	request.Query = strings.TrimSpace(request.Query)
+	if request.Query == "" {
+		return nil, nil, 0, &response.ErrorResponse{Status: "error", Message: "Search query is required", Code: response.ErrCodeValidation}
+	}
+
+	if request.MaxPrice > 0 && request.MaxPrice < request.MinPrice {
+		return nil, nil, 0, &response.ErrorResponse{Status: "error", Message: "Maximum price must not be lower than minimum price", Code: response.ErrCodeValidation}
+	}
+
+	if request.Page <= 0 {
+		request.Page = 1
+	}
+
+	if request.PageSize <= 0 {
+		request.PageSize = 10
+	}
+
+	products, totalRecords, err := s.productRepository.SearchProducts(ctx, request)
+	if err != nil {
+		s.logger.Error("Failed to search products",
+			zap.Error(err),
+			zap.String("query", request.Query))
+		return nil, nil, 0, &response.ErrorResponse{Status: "error", Message: "Failed to search products"}
+	}
+
+	facets, err := s.productRepository.SearchProductFacets(ctx, request, productPriceBounds)
+	if err != nil {
+		s.logger.Error("Failed to count product facets",
+			zap.Error(err),
+			zap.String("query", request.Query))
+		return nil, nil, 0, &response.ErrorResponse{Status: "error", Message: "Failed to search products"}
+	}
+
+	s.logger.Debug("Successfully searched products",
+		zap.Int("totalRecords", totalRecords),
+		zap.Int("page", request.Page),
+		zap.Int("pageSize", request.PageSize))
+
+	return s.mapping.ToProductsResponse(products), s.mapping.ToProductFacetsResponse(facets, productPriceBounds), totalRecords, nil
+}
+
+func (s *productService) FindById(ctx context.Context, productID int) (*response.ProductResponse, *response.ErrorResponse) {
 	s.logger.Debug("Fetching product by ID", zap.Int("productID", productID))
 
-	product, err := s.productRepository.FindById(productID)
+	product, err := s.productRepository.FindById(ctx, productID)
 	if err != nil {
 		s.logger.Error("Failed to fetch product", zap.Error(err))
-		return nil, &response.ErrorResponse{Status: "error", Message: "Product not found"}
+		return nil, &response.ErrorResponse{Status: "error", Message: "Product not found", Code: response.ErrCodeNotFound}
 	}
 
 	return s.mapping.ToProductResponse(product), nil
 }
 
-func (s *productService) FindByActive(search string, page, pageSize int) ([]*response.ProductResponseDeleteAt, int, *response.ErrorResponse) {
+func (s *productService) FindByActive(ctx context.Context, search string, page, pageSize int) ([]*response.ProductResponseDeleteAt, int, *response.ErrorResponse) {
 	s.logger.Debug("Fetching products",
 		zap.Int("page", page),
 		zap.Int("pageSize", pageSize),
@@ -156,7 +296,7 @@ func (s *productService) FindByActive(search string, page, pageSize int) ([]*res
 		pageSize = 10
 	}
 
-	products, totalRecords, err := s.productRepository.FindByActive(search, page, pageSize)
+	products, totalRecords, err := s.productRepository.FindByActive(ctx, search, page, pageSize)
 
 	if err != nil {
 		s.logger.Error("Failed to fetch products",
@@ -175,7 +315,7 @@ func (s *productService) FindByActive(search string, page, pageSize int) ([]*res
 	return s.mapping.ToProductsResponseDeleteAt(products), totalRecords, nil
 }
 
-func (s *productService) FindByTrashed(search string, page, pageSize int) ([]*response.ProductResponseDeleteAt, int, *response.ErrorResponse) {
+func (s *productService) FindByTrashed(ctx context.Context, search string, page, pageSize int) ([]*response.ProductResponseDeleteAt, int, *response.ErrorResponse) {
 	s.logger.Debug("Fetching products",
 		zap.Int("page", page),
 		zap.Int("pageSize", pageSize),
@@ -189,7 +329,7 @@ func (s *productService) FindByTrashed(search string, page, pageSize int) ([]*re
 		pageSize = 10
 	}
 
-	products, totalRecords, err := s.productRepository.FindByTrashed(search, page, pageSize)
+	products, totalRecords, err := s.productRepository.FindByTrashed(ctx, search, page, pageSize)
 	if err != nil {
 		s.logger.Error("Failed to fetch products",
 			zap.Error(err),
@@ -207,22 +347,22 @@ func (s *productService) FindByTrashed(search string, page, pageSize int) ([]*re
 	return s.mapping.ToProductsResponseDeleteAt(products), totalRecords, nil
 }
 
-func (s *productService) CreateProduct(req *requests.CreateProductRequest) (*response.ProductResponse, *response.ErrorResponse) {
+func (s *productService) CreateProduct(ctx context.Context, req *requests.CreateProductRequest) (*response.ProductResponse, *response.ErrorResponse) {
 	s.logger.Debug("Creating new product")
 
-	_, err := s.categoryRepository.FindById(req.CategoryID)
+	_, err := s.categoryRepository.FindById(ctx, req.CategoryID)
 	if err != nil {
 		s.logger.Error("Category not found", zap.Int("categoryID", req.CategoryID), zap.Error(err))
-		return nil, &response.ErrorResponse{Status: "error", Message: "Category not found"}
+		return nil, &response.ErrorResponse{Status: "error", Message: "Category not found", Code: response.ErrCodeNotFound}
 	}
 
-	_, err = s.merchantRepository.FindById(req.MerchantID)
+	_, err = s.merchantRepository.FindById(ctx, req.MerchantID)
 	if err != nil {
 		s.logger.Error("Merchant not found", zap.Int("merchantID", req.MerchantID), zap.Error(err))
-		return nil, &response.ErrorResponse{Status: "error", Message: "Merchant not found"}
+		return nil, &response.ErrorResponse{Status: "error", Message: "Merchant not found", Code: response.ErrCodeNotFound}
 	}
 
-	product, err := s.productRepository.CreateProduct(req)
+	product, err := s.productRepository.CreateProduct(ctx, req)
 	if err != nil {
 		s.logger.Error("Failed to create product", zap.Error(err))
 		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to create product"}
@@ -232,22 +372,22 @@ func (s *productService) CreateProduct(req *requests.CreateProductRequest) (*res
 	return s.mapping.ToProductResponse(product), nil
 }
 
-func (s *productService) UpdateProduct(req *requests.UpdateProductRequest) (*response.ProductResponse, *response.ErrorResponse) {
+func (s *productService) UpdateProduct(ctx context.Context, req *requests.UpdateProductRequest) (*response.ProductResponse, *response.ErrorResponse) {
 	s.logger.Debug("Updating product", zap.Int("productID", req.ProductID))
 
-	_, err := s.categoryRepository.FindById(req.CategoryID)
+	_, err := s.categoryRepository.FindById(ctx, req.CategoryID)
 	if err != nil {
 		s.logger.Error("Category not found", zap.Int("categoryID", req.CategoryID), zap.Error(err))
-		return nil, &response.ErrorResponse{Status: "error", Message: "Category not found"}
+		return nil, &response.ErrorResponse{Status: "error", Message: "Category not found", Code: response.ErrCodeNotFound}
 	}
 
-	_, err = s.merchantRepository.FindById(req.MerchantID)
+	_, err = s.merchantRepository.FindById(ctx, req.MerchantID)
 	if err != nil {
 		s.logger.Error("Merchant not found", zap.Int("merchantID", req.MerchantID), zap.Error(err))
-		return nil, &response.ErrorResponse{Status: "error", Message: "Merchant not found"}
+		return nil, &response.ErrorResponse{Status: "error", Message: "Merchant not found", Code: response.ErrCodeNotFound}
 	}
 
-	product, err := s.productRepository.UpdateProduct(req)
+	product, err := s.productRepository.UpdateProduct(ctx, req)
 	if err != nil {
 		s.logger.Error("Failed to update product", zap.Error(err))
 		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to update product"}
@@ -257,10 +397,10 @@ func (s *productService) UpdateProduct(req *requests.UpdateProductRequest) (*res
 	return s.mapping.ToProductResponse(product), nil
 }
 
-func (s *productService) TrashProduct(productID int) (*response.ProductResponseDeleteAt, *response.ErrorResponse) {
+func (s *productService) TrashProduct(ctx context.Context, productID int) (*response.ProductResponseDeleteAt, *response.ErrorResponse) {
 	s.logger.Debug("Trashing product", zap.Int("productID", productID))
 
-	product, err := s.productRepository.TrashedProduct(productID)
+	product, err := s.productRepository.TrashedProduct(ctx, productID)
 	if err != nil {
 		s.logger.Error("Failed to trash product", zap.Error(err))
 		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to trash product"}
@@ -269,10 +409,10 @@ func (s *productService) TrashProduct(productID int) (*response.ProductResponseD
 	return s.mapping.ToProductResponseDeleteAt(product), nil
 }
 
-func (s *productService) RestoreProduct(productID int) (*response.ProductResponseDeleteAt, *response.ErrorResponse) {
+func (s *productService) RestoreProduct(ctx context.Context, productID int) (*response.ProductResponseDeleteAt, *response.ErrorResponse) {
 	s.logger.Debug("Restoring product", zap.Int("productID", productID))
 
-	product, err := s.productRepository.RestoreProduct(productID)
+	product, err := s.productRepository.RestoreProduct(ctx, productID)
 	if err != nil {
 		s.logger.Error("Failed to restore product", zap.Error(err))
 		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to restore product"}
@@ -281,10 +421,10 @@ func (s *productService) RestoreProduct(productID int) (*response.ProductRespons
 	return s.mapping.ToProductResponseDeleteAt(product), nil
 }
 
-func (s *productService) DeleteProductPermanent(productID int) (bool, *response.ErrorResponse) {
+func (s *productService) DeleteProductPermanent(ctx context.Context, productID int) (bool, *response.ErrorResponse) {
 	s.logger.Debug("Permanently deleting product", zap.Int("productID", productID))
 
-	success, err := s.productRepository.DeleteProductPermanent(productID)
+	success, err := s.productRepository.DeleteProductPermanent(ctx, productID)
 	if err != nil {
 		s.logger.Error("Failed to permanently delete product", zap.Error(err))
 		return false, &response.ErrorResponse{Status: "error", Message: "Failed to permanently delete product"}
@@ -293,10 +433,10 @@ func (s *productService) DeleteProductPermanent(productID int) (bool, *response.
 	return success, nil
 }
 
-func (s *productService) RestoreAllProducts() (bool, *response.ErrorResponse) {
+func (s *productService) RestoreAllProducts(ctx context.Context) (bool, *response.ErrorResponse) {
 	s.logger.Debug("Restoring all trashed products")
 
-	success, err := s.productRepository.RestoreAllProducts()
+	success, err := s.productRepository.RestoreAllProducts(ctx)
 	if err != nil {
 		s.logger.Error("Failed to restore all products", zap.Error(err))
 		return false, &response.ErrorResponse{Status: "error", Message: "Failed to restore all products"}
@@ -305,10 +445,10 @@ func (s *productService) RestoreAllProducts() (bool, *response.ErrorResponse) {
 	return success, nil
 }
 
-func (s *productService) DeleteAllProductsPermanent() (bool, *response.ErrorResponse) {
+func (s *productService) DeleteAllProductsPermanent(ctx context.Context) (bool, *response.ErrorResponse) {
 	s.logger.Debug("Permanently deleting all products")
 
-	success, err := s.productRepository.DeleteAllProductPermanent()
+	success, err := s.productRepository.DeleteAllProductPermanent(ctx)
 	if err != nil {
 		s.logger.Error("Failed to permanently delete all products", zap.Error(err))
 		return false, &response.ErrorResponse{Status: "error", Message: "Failed to permanently delete all products"}
@@ -316,3 +456,20 @@ func (s *productService) DeleteAllProductsPermanent() (bool, *response.ErrorResp
 
 	return success, nil
 }
+
+// normalizeFilter defaults a missing listing filter and rejects one whose
+// bounds or sort order are invalid.
+func (s *productService) normalizeFilter(filter *requests.ProductFilter) (*requests.ProductFilter, *response.ErrorResponse) {
+	if filter == nil {
+		return &requests.ProductFilter{}, nil
+	}
+
+	filter.Brand = strings.TrimSpace(filter.Brand)
+
+	if err := filter.Validate(); err != nil {
+		s.logger.Debug("Invalid product filter", zap.Error(err))
+		return nil, &response.ErrorResponse{Status: "error", Message: "Invalid product filter", Code: response.ErrCodeValidation}
+	}
+
+	return filter, nil
+}
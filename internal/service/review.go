@@ -1,11 +1,13 @@
 package service
 
 import (
+	"context"
 	"ecommerce/internal/domain/requests"
 	"ecommerce/internal/domain/response"
 	response_service "ecommerce/internal/mapper/response/services"
 	"ecommerce/internal/repository"
 	"ecommerce/pkg/logger"
+	"ecommerce/pkg/pagination"
 
 	"go.uber.org/zap"
 )
@@ -30,7 +32,7 @@ func NewReviewService(
 	}
 }
 
-func (s *reviewService) FindAllReviews(search string, page, pageSize int) ([]*response.ReviewResponse, int, *response.ErrorResponse) {
+func (s *reviewService) FindAllReviews(ctx context.Context, search string, page, pageSize int) ([]*response.ReviewResponse, int, *response.ErrorResponse) {
 	s.logger.Debug("Fetching Reviews",
 		zap.Int("page", page),
 		zap.Int("pageSize", pageSize),
@@ -44,7 +46,7 @@ func (s *reviewService) FindAllReviews(search string, page, pageSize int) ([]*re
 		pageSize = 10
 	}
 
-	Reviews, totalRecords, err := s.reviewRepository.FindAllReview(search, page, pageSize)
+	Reviews, totalRecords, err := s.reviewRepository.FindAllReview(ctx, search, page, pageSize)
 	if err != nil {
 		s.logger.Error("Failed to fetch Reviews",
 			zap.Error(err),
@@ -62,7 +64,45 @@ func (s *reviewService) FindAllReviews(search string, page, pageSize int) ([]*re
 	return s.mapping.ToReviewsResponse(Reviews), totalRecords, nil
 }
 
-func (s *reviewService) FindByActive(search string, page, pageSize int) ([]*response.ReviewResponseDeleteAt, int, *response.ErrorResponse) {
+func (s *reviewService) FindAllReviewsCursor(ctx context.Context, cursor string, pageSize int, search string) ([]*response.ReviewResponse, string, *response.ErrorResponse) {
+	s.logger.Debug("Fetching reviews by cursor",
+		zap.String("cursor", cursor),
+		zap.Int("pageSize", pageSize),
+		zap.String("search", search))
+
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+
+	after, err := pagination.DecodeCursor(cursor)
+	if err != nil {
+		s.logger.Debug("Invalid cursor", zap.String("cursor", cursor))
+		return nil, "", &response.ErrorResponse{Status: "error", Message: "Invalid cursor", Code: response.ErrCodeValidation}
+	}
+
+	reviews, next, err := s.reviewRepository.FindAllReviewsCursor(ctx, search, after, pageSize)
+	if err != nil {
+		s.logger.Error("Failed to fetch reviews",
+			zap.Error(err),
+			zap.String("cursor", cursor),
+			zap.Int("pageSize", pageSize),
+			zap.String("search", search))
+		return nil, "", &response.ErrorResponse{Status: "error", Message: "Failed to fetch reviews"}
+	}
+
+	var nextCursor string
+	if next != nil {
+		nextCursor = next.Encode()
+	}
+
+	s.logger.Debug("Successfully fetched reviews by cursor",
+		zap.Int("count", len(reviews)),
+		zap.Bool("hasMore", nextCursor != ""))
+
+	return s.mapping.ToReviewsResponse(reviews), nextCursor, nil
+}
+
+func (s *reviewService) FindByActive(ctx context.Context, search string, page, pageSize int) ([]*response.ReviewResponseDeleteAt, int, *response.ErrorResponse) {
 	s.logger.Debug("Fetching Reviews",
 		zap.Int("page", page),
 		zap.Int("pageSize", pageSize),
@@ -76,7 +116,7 @@ func (s *reviewService) FindByActive(search string, page, pageSize int) ([]*resp
 		pageSize = 10
 	}
 
-	Reviews, totalRecords, err := s.reviewRepository.FindByActive(search, page, pageSize)
+	Reviews, totalRecords, err := s.reviewRepository.FindByActive(ctx, search, page, pageSize)
 	if err != nil {
 		s.logger.Error("Failed to fetch Reviews",
 			zap.Error(err),
@@ -94,7 +134,7 @@ func (s *reviewService) FindByActive(search string, page, pageSize int) ([]*resp
 	return s.mapping.ToReviewsResponseDeleteAt(Reviews), totalRecords, nil
 }
 
-func (s *reviewService) FindByTrashed(search string, page, pageSize int) ([]*response.ReviewResponseDeleteAt, int, *response.ErrorResponse) {
+func (s *reviewService) FindByTrashed(ctx context.Context, search string, page, pageSize int) ([]*response.ReviewResponseDeleteAt, int, *response.ErrorResponse) {
 	s.logger.Debug("Fetching Reviews",
 		zap.Int("page", page),
 		zap.Int("pageSize", pageSize),
@@ -108,7 +148,7 @@ func (s *reviewService) FindByTrashed(search string, page, pageSize int) ([]*res
 		pageSize = 10
 	}
 
-	Reviews, totalRecords, err := s.reviewRepository.FindByTrashed(search, page, pageSize)
+	Reviews, totalRecords, err := s.reviewRepository.FindByTrashed(ctx, search, page, pageSize)
 
 	if err != nil {
 		s.logger.Error("Failed to fetch Reviews",
@@ -127,7 +167,7 @@ func (s *reviewService) FindByTrashed(search string, page, pageSize int) ([]*res
 	return s.mapping.ToReviewsResponseDeleteAt(Reviews), totalRecords, nil
 }
 
-func (s *reviewService) FindByProduct(product_id int, search string, page, pageSize int) ([]*response.ReviewResponse, int, *response.ErrorResponse) {
+func (s *reviewService) FindByProduct(ctx context.Context, product_id int, search string, page, pageSize int) ([]*response.ReviewResponse, int, *response.ErrorResponse) {
 	s.logger.Debug("Fetching Reviews",
 		zap.Int("page", page),
 		zap.Int("pageSize", pageSize),
@@ -141,7 +181,7 @@ func (s *reviewService) FindByProduct(product_id int, search string, page, pageS
 		pageSize = 10
 	}
 
-	reviews, totalRecords, err := s.reviewRepository.FindByProduct(product_id, search, page, pageSize)
+	reviews, totalRecords, err := s.reviewRepository.FindByProduct(ctx, product_id, search, page, pageSize)
 
 	if err != nil {
 		s.logger.Error("Failed to fetch Reviews",
@@ -160,24 +200,24 @@ func (s *reviewService) FindByProduct(product_id int, search string, page, pageS
 	return s.mapping.ToReviewsResponse(reviews), totalRecords, nil
 }
 
-func (s *reviewService) CreateReview(req *requests.CreateReviewRequest) (*response.ReviewResponse, *response.ErrorResponse) {
+func (s *reviewService) CreateReview(ctx context.Context, req *requests.CreateReviewRequest) (*response.ReviewResponse, *response.ErrorResponse) {
 	s.logger.Debug("Creating new cashier")
 
-	_, err := s.userRepository.FindById(req.UserID)
+	_, err := s.userRepository.FindById(ctx, req.UserID)
 
 	if err != nil {
 		s.logger.Error("Failed to find user", zap.Error(err))
-		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to find user"}
+		return nil, &response.ErrorResponse{Status: "error", Message: "User not found", Code: response.ErrCodeNotFound}
 	}
 
-	_, err = s.productRepository.FindById(req.ProductID)
+	_, err = s.productRepository.FindById(ctx, req.ProductID)
 
 	if err != nil {
 		s.logger.Error("Failed to find product", zap.Error(err))
-		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to find product"}
+		return nil, &response.ErrorResponse{Status: "error", Message: "Product not found", Code: response.ErrCodeNotFound}
 	}
 
-	review, err := s.reviewRepository.CreateReview(req)
+	review, err := s.reviewRepository.CreateReview(ctx, req)
 	if err != nil {
 		s.logger.Error("Failed to create review", zap.Error(err))
 		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to create review"}
@@ -186,17 +226,17 @@ func (s *reviewService) CreateReview(req *requests.CreateReviewRequest) (*respon
 	return s.mapping.ToReviewResponse(review), nil
 }
 
-func (s *reviewService) UpdateReview(req *requests.UpdateReviewRequest) (*response.ReviewResponse, *response.ErrorResponse) {
+func (s *reviewService) UpdateReview(ctx context.Context, req *requests.UpdateReviewRequest) (*response.ReviewResponse, *response.ErrorResponse) {
 	s.logger.Debug("Updating review", zap.Int("review_id", req.ReviewID))
 
-	_, err := s.reviewRepository.FindById(req.ReviewID)
+	_, err := s.reviewRepository.FindById(ctx, req.ReviewID)
 
 	if err != nil {
 		s.logger.Error("Failed to update review", zap.Error(err))
 		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to update review"}
 	}
 
-	review, err := s.reviewRepository.UpdateReview(req)
+	review, err := s.reviewRepository.UpdateReview(ctx, req)
 	if err != nil {
 		s.logger.Error("Failed to update review", zap.Error(err))
 		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to update review"}
@@ -205,10 +245,10 @@ func (s *reviewService) UpdateReview(req *requests.UpdateReviewRequest) (*respon
 	return s.mapping.ToReviewResponse(review), nil
 }
 
-func (s *reviewService) TrashedReview(reviewID int) (*response.ReviewResponseDeleteAt, *response.ErrorResponse) {
+func (s *reviewService) TrashedReview(ctx context.Context, reviewID int) (*response.ReviewResponseDeleteAt, *response.ErrorResponse) {
 	s.logger.Debug("Trashing review", zap.Int("reviewID", reviewID))
 
-	review, err := s.reviewRepository.TrashReview(reviewID)
+	review, err := s.reviewRepository.TrashReview(ctx, reviewID)
 	if err != nil {
 		s.logger.Error("Failed to trash review", zap.Error(err))
 		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to trash review"}
@@ -217,10 +257,10 @@ func (s *reviewService) TrashedReview(reviewID int) (*response.ReviewResponseDel
 	return s.mapping.ToReviewResponseDeleteAt(review), nil
 }
 
-func (s *reviewService) RestoreReview(reviewID int) (*response.ReviewResponseDeleteAt, *response.ErrorResponse) {
+func (s *reviewService) RestoreReview(ctx context.Context, reviewID int) (*response.ReviewResponseDeleteAt, *response.ErrorResponse) {
 	s.logger.Debug("Restoring review", zap.Int("reviewID", reviewID))
 
-	review, err := s.reviewRepository.RestoreReview(reviewID)
+	review, err := s.reviewRepository.RestoreReview(ctx, reviewID)
 	if err != nil {
 		s.logger.Error("Failed to restore review", zap.Error(err))
 		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to restore review"}
@@ -229,10 +269,10 @@ func (s *reviewService) RestoreReview(reviewID int) (*response.ReviewResponseDel
 	return s.mapping.ToReviewResponseDeleteAt(review), nil
 }
 
-func (s *reviewService) DeleteReviewPermanent(reviewID int) (bool, *response.ErrorResponse) {
+func (s *reviewService) DeleteReviewPermanent(ctx context.Context, reviewID int) (bool, *response.ErrorResponse) {
 	s.logger.Debug("Permanently deleting review", zap.Int("reviewID", reviewID))
 
-	success, err := s.reviewRepository.DeleteReviewPermanently(reviewID)
+	success, err := s.reviewRepository.DeleteReviewPermanently(ctx, reviewID)
 	if err != nil {
 		s.logger.Error("Failed to permanently delete review", zap.Error(err))
 		return false, &response.ErrorResponse{Status: "error", Message: "Failed to permanently delete review"}
@@ -241,10 +281,10 @@ func (s *reviewService) DeleteReviewPermanent(reviewID int) (bool, *response.Err
 	return success, nil
 }
 
-func (s *reviewService) RestoreAllReviews() (bool, *response.ErrorResponse) {
+func (s *reviewService) RestoreAllReviews(ctx context.Context) (bool, *response.ErrorResponse) {
 	s.logger.Debug("Restoring all trashed reviews")
 
-	success, err := s.reviewRepository.RestoreAllReview()
+	success, err := s.reviewRepository.RestoreAllReview(ctx)
 	if err != nil {
 		s.logger.Error("Failed to restore all reviews", zap.Error(err))
 		return false, &response.ErrorResponse{Status: "error", Message: "Failed to restore all reviews"}
@@ -253,10 +293,10 @@ func (s *reviewService) RestoreAllReviews() (bool, *response.ErrorResponse) {
 	return success, nil
 }
 
-func (s *reviewService) DeleteAllReviewsPermanent() (bool, *response.ErrorResponse) {
+func (s *reviewService) DeleteAllReviewsPermanent(ctx context.Context) (bool, *response.ErrorResponse) {
 	s.logger.Debug("Permanently deleting all reviews")
 
-	success, err := s.reviewRepository.DeleteAllPermanentReview()
+	success, err := s.reviewRepository.DeleteAllPermanentReview(ctx)
 	if err != nil {
 		s.logger.Error("Failed to permanently delete all reviews", zap.Error(err))
 		return false, &response.ErrorResponse{Status: "error", Message: "Failed to permanently delete all reviews"}
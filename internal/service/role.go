@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"ecommerce/internal/domain/requests"
 	"ecommerce/internal/domain/response"
 	response_service "ecommerce/internal/mapper/response/services"
@@ -24,7 +25,7 @@ func NewRoleService(roleRepository repository.RoleRepository, logger logger.Logg
 	}
 }
 
-func (s *roleService) FindAll(page int, pageSize int, search string) ([]*response.RoleResponse, int, *response.ErrorResponse) {
+func (s *roleService) FindAll(ctx context.Context, page int, pageSize int, search string) ([]*response.RoleResponse, int, *response.ErrorResponse) {
 	s.logger.Debug("Fetching role",
 		zap.Int("page", page),
 		zap.Int("pageSize", pageSize),
@@ -37,7 +38,7 @@ func (s *roleService) FindAll(page int, pageSize int, search string) ([]*respons
 		pageSize = 10
 	}
 
-	res, totalRecords, err := s.roleRepository.FindAllRoles(page, pageSize, search)
+	res, totalRecords, err := s.roleRepository.FindAllRoles(ctx, page, pageSize, search)
 	if err != nil {
 		s.logger.Error("Failed to fetch role",
 			zap.Error(err),
@@ -61,10 +62,10 @@ func (s *roleService) FindAll(page int, pageSize int, search string) ([]*respons
 	return so, totalRecords, nil
 }
 
-func (s *roleService) FindById(id int) (*response.RoleResponse, *response.ErrorResponse) {
+func (s *roleService) FindById(ctx context.Context, id int) (*response.RoleResponse, *response.ErrorResponse) {
 	s.logger.Debug("Fetching role by ID", zap.Int("id", id))
 
-	res, err := s.roleRepository.FindById(id)
+	res, err := s.roleRepository.FindById(ctx, id)
 
 	if err != nil {
 		s.logger.Error("Failed to fetch role record by ID", zap.Error(err))
@@ -82,10 +83,10 @@ func (s *roleService) FindById(id int) (*response.RoleResponse, *response.ErrorR
 	return so, nil
 }
 
-func (s *roleService) FindByUserId(id int) ([]*response.RoleResponse, *response.ErrorResponse) {
+func (s *roleService) FindByUserId(ctx context.Context, id int) ([]*response.RoleResponse, *response.ErrorResponse) {
 	s.logger.Debug("Fetching role by user ID", zap.Int("id", id))
 
-	res, err := s.roleRepository.FindByUserId(id)
+	res, err := s.roleRepository.FindByUserId(ctx, id)
 
 	if err != nil {
 		s.logger.Error("Failed to fetch role record by ID", zap.Error(err))
@@ -103,7 +104,7 @@ func (s *roleService) FindByUserId(id int) ([]*response.RoleResponse, *response.
 	return so, nil
 }
 
-func (s *roleService) FindByActiveRole(page int, pageSize int, search string) ([]*response.RoleResponseDeleteAt, int, *response.ErrorResponse) {
+func (s *roleService) FindByActiveRole(ctx context.Context, page int, pageSize int, search string) ([]*response.RoleResponseDeleteAt, int, *response.ErrorResponse) {
 	s.logger.Debug("Fetching active role",
 		zap.Int("page", page),
 		zap.Int("pageSize", pageSize),
@@ -116,7 +117,7 @@ func (s *roleService) FindByActiveRole(page int, pageSize int, search string) ([
 		pageSize = 10
 	}
 
-	res, totalRecords, err := s.roleRepository.FindByActiveRole(page, pageSize, search)
+	res, totalRecords, err := s.roleRepository.FindByActiveRole(ctx, page, pageSize, search)
 	if err != nil {
 		s.logger.Error("Failed to fetch active role",
 			zap.Error(err),
@@ -140,7 +141,7 @@ func (s *roleService) FindByActiveRole(page int, pageSize int, search string) ([
 	return so, totalRecords, nil
 }
 
-func (s *roleService) FindByTrashedRole(page int, pageSize int, search string) ([]*response.RoleResponseDeleteAt, int, *response.ErrorResponse) {
+func (s *roleService) FindByTrashedRole(ctx context.Context, page int, pageSize int, search string) ([]*response.RoleResponseDeleteAt, int, *response.ErrorResponse) {
 	s.logger.Debug("Fetching trashed role",
 		zap.Int("page", page),
 		zap.Int("pageSize", pageSize),
@@ -153,7 +154,7 @@ func (s *roleService) FindByTrashedRole(page int, pageSize int, search string) (
 		pageSize = 10
 	}
 
-	res, totalRecords, err := s.roleRepository.FindByTrashedRole(page, pageSize, search)
+	res, totalRecords, err := s.roleRepository.FindByTrashedRole(ctx, page, pageSize, search)
 
 	if err != nil {
 		s.logger.Error("Failed to fetch trashed role",
@@ -178,12 +179,12 @@ func (s *roleService) FindByTrashedRole(page int, pageSize int, search string) (
 	return so, totalRecords, nil
 }
 
-func (s *roleService) CreateRole(request *requests.CreateRoleRequest) (*response.RoleResponse, *response.ErrorResponse) {
+func (s *roleService) CreateRole(ctx context.Context, request *requests.CreateRoleRequest) (*response.RoleResponse, *response.ErrorResponse) {
 	s.logger.Debug("Starting CreateRole process",
 		zap.String("roleName", request.Name),
 	)
 
-	res, err := s.roleRepository.CreateRole(request)
+	res, err := s.roleRepository.CreateRole(ctx, request)
 
 	if err != nil {
 		s.logger.Error("Failed to create role",
@@ -207,13 +208,13 @@ func (s *roleService) CreateRole(request *requests.CreateRoleRequest) (*response
 	return so, nil
 }
 
-func (s *roleService) UpdateRole(request *requests.UpdateRoleRequest) (*response.RoleResponse, *response.ErrorResponse) {
+func (s *roleService) UpdateRole(ctx context.Context, request *requests.UpdateRoleRequest) (*response.RoleResponse, *response.ErrorResponse) {
 	s.logger.Debug("Starting UpdateRole process",
 		zap.Int("roleID", request.ID),
 		zap.String("newRoleName", request.Name),
 	)
 
-	res, err := s.roleRepository.UpdateRole(request)
+	res, err := s.roleRepository.UpdateRole(ctx, request)
 
 	if err != nil {
 		s.logger.Error("Failed to update role",
@@ -237,12 +238,12 @@ func (s *roleService) UpdateRole(request *requests.UpdateRoleRequest) (*response
 	return so, nil
 }
 
-func (s *roleService) TrashedRole(id int) (*response.RoleResponse, *response.ErrorResponse) {
+func (s *roleService) TrashedRole(ctx context.Context, id int) (*response.RoleResponse, *response.ErrorResponse) {
 	s.logger.Debug("Starting TrashedRole process",
 		zap.Int("roleID", id),
 	)
 
-	res, err := s.roleRepository.TrashedRole(id)
+	res, err := s.roleRepository.TrashedRole(ctx, id)
 
 	if err != nil {
 		s.logger.Error("Failed to move role to trash",
@@ -264,12 +265,12 @@ func (s *roleService) TrashedRole(id int) (*response.RoleResponse, *response.Err
 	return so, nil
 }
 
-func (s *roleService) RestoreRole(id int) (*response.RoleResponse, *response.ErrorResponse) {
+func (s *roleService) RestoreRole(ctx context.Context, id int) (*response.RoleResponse, *response.ErrorResponse) {
 	s.logger.Debug("Starting RestoreRole process",
 		zap.Int("roleID", id),
 	)
 
-	res, err := s.roleRepository.RestoreRole(id)
+	res, err := s.roleRepository.RestoreRole(ctx, id)
 
 	if err != nil {
 		s.logger.Error("Failed to restore role", zap.Error(err))
@@ -289,12 +290,12 @@ func (s *roleService) RestoreRole(id int) (*response.RoleResponse, *response.Err
 	return so, nil
 }
 
-func (s *roleService) DeleteRolePermanent(id int) (bool, *response.ErrorResponse) {
+func (s *roleService) DeleteRolePermanent(ctx context.Context, id int) (bool, *response.ErrorResponse) {
 	s.logger.Debug("Starting DeleteRolePermanent process",
 		zap.Int("roleID", id),
 	)
 
-	_, err := s.roleRepository.DeleteRolePermanent(id)
+	_, err := s.roleRepository.DeleteRolePermanent(ctx, id)
 
 	if err != nil {
 		s.logger.Error("Failed to delete role permanently",
@@ -315,10 +316,10 @@ func (s *roleService) DeleteRolePermanent(id int) (bool, *response.ErrorResponse
 	return true, nil
 }
 
-func (s *roleService) RestoreAllRole() (bool, *response.ErrorResponse) {
+func (s *roleService) RestoreAllRole(ctx context.Context) (bool, *response.ErrorResponse) {
 	s.logger.Debug("Restoring all roles")
 
-	_, err := s.roleRepository.RestoreAllRole()
+	_, err := s.roleRepository.RestoreAllRole(ctx)
 
 	if err != nil {
 		s.logger.Error("Failed to restore all roles", zap.Error(err))
@@ -332,10 +333,10 @@ func (s *roleService) RestoreAllRole() (bool, *response.ErrorResponse) {
 	return true, nil
 }
 
-func (s *roleService) DeleteAllRolePermanent() (bool, *response.ErrorResponse) {
+func (s *roleService) DeleteAllRolePermanent(ctx context.Context) (bool, *response.ErrorResponse) {
 	s.logger.Debug("Permanently deleting all roles")
 
-	_, err := s.roleRepository.DeleteAllRolePermanent()
+	_, err := s.roleRepository.DeleteAllRolePermanent(ctx)
 
 	if err != nil {
 		s.logger.Error("Failed to permanently delete all roles", zap.Error(err))
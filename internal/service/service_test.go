@@ -0,0 +1,59 @@
+package service_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	recordmapper "ecommerce/internal/mapper/record"
+	response_service "ecommerce/internal/mapper/response/services"
+	"ecommerce/internal/repository"
+	"ecommerce/internal/service"
+	"ecommerce/pkg/auth"
+	db "ecommerce/pkg/database/schema"
+	"ecommerce/pkg/hash"
+	"ecommerce/pkg/logger"
+	"ecommerce/pkg/payment"
+
+	"go.uber.org/zap"
+)
+
+// newTestService wires every service over conn the way the gRPC server does,
+// with the fake payment provider. options adjust the dependencies before the
+// services are built.
+func newTestService(t *testing.T, conn *sql.DB, provider payment.PaymentProvider, options ...func(*service.Deps)) *service.Service {
+	t.Helper()
+
+	tokenManager, err := auth.NewManager(auth.Config{
+		SecretKey: "test-secret",
+		Issuer:    "ecommerce",
+		Audience:  "ecommerce-api",
+	})
+	if err != nil {
+		t.Fatalf("create token manager: %v", err)
+	}
+
+	if provider == nil {
+		provider = payment.NewFakeProvider()
+	}
+
+	deps := service.Deps{
+		Repositories: repository.NewRepositories(repository.Deps{
+			DB:           db.New(conn),
+			Conn:         conn,
+			MapperRecord: recordmapper.NewRecordMapper(),
+		}),
+		Hash:               hash.NewHashingPassword(),
+		Token:              tokenManager,
+		Logger:             &logger.Logger{Log: zap.NewNop()},
+		Payment:            provider,
+		Mapper:             *response_service.NewResponseServiceMapper(),
+		RevocationCacheTTL: time.Nanosecond,
+	}
+
+	for _, option := range options {
+		option(&deps)
+	}
+
+	return service.NewService(deps)
+}
@@ -6,6 +6,9 @@ import (
 	"ecommerce/pkg/auth"
 	"ecommerce/pkg/hash"
 	"ecommerce/pkg/logger"
+	"ecommerce/pkg/mailer"
+	"ecommerce/pkg/payment"
+	"time"
 )
 
 type Service struct {
@@ -22,6 +25,8 @@ type Service struct {
 	Cart        CartService
 	Shipping    ShippingAddressService
 	Slider      SliderService
+	Ownership   OwnershipService
+	Revocation  TokenRevocationService
 }
 
 type Deps struct {
@@ -29,22 +34,36 @@ type Deps struct {
 	Token        auth.TokenManager
 	Hash         hash.HashPassword
 	Logger       logger.LoggerInterface
+	Payment      payment.PaymentProvider
 	Mapper       response_service.ResponseServiceMapper
+	// RevocationCacheTTL is how long revoked tokens are cached before they
+	// are reloaded from the database.
+	RevocationCacheTTL time.Duration
+	Mailer             mailer.Mailer
+	// AppURL is the base URL of the frontend that verification and password
+	// reset links point to.
+	AppURL string
+	// TaxRate is the tax charged on the items of an order, in basis points.
+	TaxRate int
 }
 
 func NewService(deps Deps) *Service {
+	revocation := NewTokenRevocationService(deps.Repositories.RevokedToken, deps.Repositories.RefreshToken, deps.Logger, deps.RevocationCacheTTL)
+
 	return &Service{
-		Auth:        NewAuthService(deps.Repositories.User, deps.Repositories.RefreshToken, deps.Repositories.Role, deps.Repositories.UserRole, deps.Hash, deps.Token, deps.Logger, deps.Mapper.UserResponseMapper),
-		User:        NewUserService(deps.Repositories.User, deps.Logger, deps.Mapper.UserResponseMapper, deps.Hash),
+		Auth:        NewAuthService(deps.Repositories.UnitOfWork, deps.Repositories.User, deps.Repositories.RefreshToken, deps.Repositories.Role, deps.Repositories.UserRole, deps.Hash, deps.Token, deps.Logger, deps.Mapper.UserResponseMapper, deps.Mapper.RefreshTokenResponseMapper, revocation, deps.Repositories.UserToken, deps.Mailer, deps.AppURL, NewLoginThrottleService(deps.Repositories.UnitOfWork, deps.Repositories.LoginThrottle, deps.Logger)),
+		User:        NewUserService(deps.Repositories.User, deps.Logger, deps.Mapper.UserResponseMapper, deps.Hash, revocation),
 		Category:    NewCategoryService(deps.Repositories.Category, deps.Logger, deps.Mapper.CategoryResponseMapper),
 		Merchant:    NewMerchantService(deps.Repositories.Merchant, deps.Logger, deps.Mapper.MerchantResponseMapper),
 		OrderItem:   NewOrderItemService(deps.Repositories.OrderItem, deps.Logger, deps.Mapper.OrderItemResponseMapper),
-		Order:       NewOrderServiceMapper(deps.Repositories.Order, deps.Repositories.OrderItem, deps.Repositories.User, deps.Repositories.Merchant, deps.Repositories.Product, deps.Repositories.Shipping, deps.Logger, deps.Mapper.OrderResponseMapper),
+		Order:       NewOrderServiceMapper(deps.Repositories.UnitOfWork, deps.Repositories.Order, deps.Repositories.OrderItem, deps.Repositories.User, deps.Repositories.Merchant, deps.Repositories.Product, deps.Repositories.Shipping, deps.TaxRate, deps.Logger, deps.Mapper.OrderResponseMapper),
 		Product:     NewProductService(deps.Repositories.Category, deps.Repositories.Merchant, deps.Repositories.Product, deps.Logger, deps.Mapper.ProductResponseMapper),
-		Transaction: NewTransactionService(deps.Repositories.Merchant, deps.Repositories.Transaction, deps.Repositories.Order, deps.Repositories.OrderItem, deps.Logger, deps.Mapper.TransactionResponseMapper),
-		Cart:        NewCartService(deps.Repositories.Product, deps.Repositories.User, deps.Repositories.Cart, deps.Logger, deps.Mapper.CartResponseMapper),
+		Transaction: NewTransactionService(deps.Repositories.UnitOfWork, deps.Repositories.Merchant, deps.Repositories.Transaction, deps.Repositories.Order, deps.Repositories.OrderItem, deps.Payment, deps.Logger, deps.Mapper.TransactionResponseMapper),
+		Cart:        NewCartService(deps.Repositories.UnitOfWork, deps.Repositories.Product, deps.Repositories.User, deps.Repositories.Cart, deps.TaxRate, deps.Logger, deps.Mapper.CartResponseMapper, deps.Mapper.OrderResponseMapper),
 		Shipping:    NewShippingAddressService(deps.Repositories.Shipping, deps.Logger, deps.Mapper.ShippingAddressResponseMapper),
 		Slider:      NewSliderService(deps.Repositories.Slider, deps.Logger, deps.Mapper.SliderResponseMapper),
+		Ownership:   NewOwnershipService(deps.Repositories.Merchant, deps.Repositories.Product, deps.Repositories.Order, deps.Repositories.Transaction, deps.Repositories.Shipping, deps.Repositories.Review, deps.Logger),
 		Review:      NewReviewService(deps.Repositories.Review, deps.Repositories.Product, deps.Repositories.User),
+		Revocation:  revocation,
 	}
 }
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"ecommerce/internal/domain/response"
 	response_service "ecommerce/internal/mapper/response/services"
 	"ecommerce/internal/repository"
@@ -27,7 +28,7 @@ func NewShippingAddressService(
 	}
 }
 
-func (s *shippingAddressService) FindAll(page int, pageSize int, search string) ([]*response.ShippingAddressResponse, int, *response.ErrorResponse) {
+func (s *shippingAddressService) FindAll(ctx context.Context, page int, pageSize int, search string) ([]*response.ShippingAddressResponse, int, *response.ErrorResponse) {
 	s.logger.Debug("Fetching category",
 		zap.Int("page", page),
 		zap.Int("pageSize", pageSize),
@@ -41,7 +42,7 @@ func (s *shippingAddressService) FindAll(page int, pageSize int, search string)
 		pageSize = 10
 	}
 
-	shipping, totalRecords, err := s.shippingRepository.FindAllShippingAddress(search, page, pageSize)
+	shipping, totalRecords, err := s.shippingRepository.FindAllShippingAddress(ctx, search, page, pageSize)
 
 	if err != nil {
 		s.logger.Error("Failed to fetch category",
@@ -66,31 +67,31 @@ func (s *shippingAddressService) FindAll(page int, pageSize int, search string)
 	return shippingRes, int(totalRecords), nil
 }
 
-func (s *shippingAddressService) FindById(shipping_id int) (*response.ShippingAddressResponse, *response.ErrorResponse) {
+func (s *shippingAddressService) FindById(ctx context.Context, shipping_id int) (*response.ShippingAddressResponse, *response.ErrorResponse) {
 	s.logger.Debug("Fetching shipping address by ID", zap.Int("shipping_id", shipping_id))
 
-	shipping, err := s.shippingRepository.FindById(shipping_id)
+	shipping, err := s.shippingRepository.FindById(ctx, shipping_id)
 	if err != nil {
 		s.logger.Error("Failed to fetch shipping address", zap.Error(err))
-		return nil, &response.ErrorResponse{Status: "error", Message: "shipping address not found"}
+		return nil, &response.ErrorResponse{Status: "error", Message: "shipping address not found", Code: response.ErrCodeNotFound}
 	}
 
 	return s.mapping.ToShippingAddressResponse(shipping), nil
 }
 
-func (s *shippingAddressService) FindByOrder(order_id int) (*response.ShippingAddressResponse, *response.ErrorResponse) {
+func (s *shippingAddressService) FindByOrder(ctx context.Context, order_id int) (*response.ShippingAddressResponse, *response.ErrorResponse) {
 	s.logger.Debug("Fetching shipping address by order id", zap.Int("shipping_id", order_id))
 
-	shipping, err := s.shippingRepository.FindByOrder(order_id)
+	shipping, err := s.shippingRepository.FindByOrder(ctx, order_id)
 	if err != nil {
 		s.logger.Error("Failed to fetch shipping address", zap.Error(err))
-		return nil, &response.ErrorResponse{Status: "error", Message: "shipping address not found"}
+		return nil, &response.ErrorResponse{Status: "error", Message: "shipping address not found", Code: response.ErrCodeNotFound}
 	}
 
 	return s.mapping.ToShippingAddressResponse(shipping), nil
 }
 
-func (s *shippingAddressService) FindByActive(search string, page, pageSize int) ([]*response.ShippingAddressResponseDeleteAt, int, *response.ErrorResponse) {
+func (s *shippingAddressService) FindByActive(ctx context.Context, search string, page, pageSize int) ([]*response.ShippingAddressResponseDeleteAt, int, *response.ErrorResponse) {
 	s.logger.Debug("Fetching categories",
 		zap.Int("page", page),
 		zap.Int("pageSize", pageSize),
@@ -104,7 +105,7 @@ func (s *shippingAddressService) FindByActive(search string, page, pageSize int)
 		pageSize = 10
 	}
 
-	cashiers, totalRecords, err := s.shippingRepository.FindByActive(search, page, pageSize)
+	cashiers, totalRecords, err := s.shippingRepository.FindByActive(ctx, search, page, pageSize)
 
 	if err != nil {
 		s.logger.Error("Failed to fetch shipping address",
@@ -123,7 +124,7 @@ func (s *shippingAddressService) FindByActive(search string, page, pageSize int)
 	return s.mapping.ToShippingAddressesResponseDeleteAt(cashiers), totalRecords, nil
 }
 
-func (s *shippingAddressService) FindByTrashed(search string, page, pageSize int) ([]*response.ShippingAddressResponseDeleteAt, int, *response.ErrorResponse) {
+func (s *shippingAddressService) FindByTrashed(ctx context.Context, search string, page, pageSize int) ([]*response.ShippingAddressResponseDeleteAt, int, *response.ErrorResponse) {
 	s.logger.Debug("Fetching shipping address",
 		zap.Int("page", page),
 		zap.Int("pageSize", pageSize),
@@ -137,7 +138,7 @@ func (s *shippingAddressService) FindByTrashed(search string, page, pageSize int
 		pageSize = 10
 	}
 
-	shipping, totalRecords, err := s.shippingRepository.FindByTrashed(search, page, pageSize)
+	shipping, totalRecords, err := s.shippingRepository.FindByTrashed(ctx, search, page, pageSize)
 	if err != nil {
 		s.logger.Error("Failed to fetch shipping address",
 			zap.Error(err),
@@ -155,10 +156,10 @@ func (s *shippingAddressService) FindByTrashed(search string, page, pageSize int
 	return s.mapping.ToShippingAddressesResponseDeleteAt(shipping), totalRecords, nil
 }
 
-func (s *shippingAddressService) TrashShippingAddress(shipping_id int) (*response.ShippingAddressResponseDeleteAt, *response.ErrorResponse) {
+func (s *shippingAddressService) TrashShippingAddress(ctx context.Context, shipping_id int) (*response.ShippingAddressResponseDeleteAt, *response.ErrorResponse) {
 	s.logger.Debug("Trashing shipping address", zap.Int("category", shipping_id))
 
-	category, err := s.shippingRepository.TrashShippingAddress(shipping_id)
+	category, err := s.shippingRepository.TrashShippingAddress(ctx, shipping_id)
 	if err != nil {
 		s.logger.Error("Failed to trash shipping address", zap.Error(err))
 		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to trash shipping address"}
@@ -167,10 +168,10 @@ func (s *shippingAddressService) TrashShippingAddress(shipping_id int) (*respons
 	return s.mapping.ToShippingAddressResponseDeleteAt(category), nil
 }
 
-func (s *shippingAddressService) RestoreShippingAddress(shipping_id int) (*response.ShippingAddressResponseDeleteAt, *response.ErrorResponse) {
+func (s *shippingAddressService) RestoreShippingAddress(ctx context.Context, shipping_id int) (*response.ShippingAddressResponseDeleteAt, *response.ErrorResponse) {
 	s.logger.Debug("Restoring shipping address", zap.Int("shipping_id", shipping_id))
 
-	shipping, err := s.shippingRepository.RestoreShippingAddress(shipping_id)
+	shipping, err := s.shippingRepository.RestoreShippingAddress(ctx, shipping_id)
 	if err != nil {
 		s.logger.Error("Failed to restore category", zap.Error(err))
 		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to restore category"}
@@ -179,10 +180,10 @@ func (s *shippingAddressService) RestoreShippingAddress(shipping_id int) (*respo
 	return s.mapping.ToShippingAddressResponseDeleteAt(shipping), nil
 }
 
-func (s *shippingAddressService) DeleteShippingAddressPermanently(categoryID int) (bool, *response.ErrorResponse) {
+func (s *shippingAddressService) DeleteShippingAddressPermanently(ctx context.Context, categoryID int) (bool, *response.ErrorResponse) {
 	s.logger.Debug("Permanently deleting category", zap.Int("categoryID", categoryID))
 
-	success, err := s.shippingRepository.DeleteShippingAddressPermanently(categoryID)
+	success, err := s.shippingRepository.DeleteShippingAddressPermanently(ctx, categoryID)
 	if err != nil {
 		s.logger.Error("Failed to permanently delete category", zap.Error(err))
 		return false, &response.ErrorResponse{Status: "error", Message: "Failed to permanently delete category"}
@@ -191,10 +192,10 @@ func (s *shippingAddressService) DeleteShippingAddressPermanently(categoryID int
 	return success, nil
 }
 
-func (s *shippingAddressService) RestoreAllShippingAddress() (bool, *response.ErrorResponse) {
+func (s *shippingAddressService) RestoreAllShippingAddress(ctx context.Context) (bool, *response.ErrorResponse) {
 	s.logger.Debug("Restoring all trashed shipping address")
 
-	success, err := s.shippingRepository.RestoreAllShippingAddress()
+	success, err := s.shippingRepository.RestoreAllShippingAddress(ctx)
 	if err != nil {
 		s.logger.Error("Failed to restore all shipping address", zap.Error(err))
 		return false, &response.ErrorResponse{Status: "error", Message: "Failed to restore all shipping address"}
@@ -203,10 +204,10 @@ func (s *shippingAddressService) RestoreAllShippingAddress() (bool, *response.Er
 	return success, nil
 }
 
-func (s *shippingAddressService) DeleteAllPermanentShippingAddress() (bool, *response.ErrorResponse) {
+func (s *shippingAddressService) DeleteAllPermanentShippingAddress(ctx context.Context) (bool, *response.ErrorResponse) {
 	s.logger.Debug("Permanently deleting all shipping address")
 
-	success, err := s.shippingRepository.DeleteAllPermanentShippingAddress()
+	success, err := s.shippingRepository.DeleteAllPermanentShippingAddress(ctx)
 	if err != nil {
 		s.logger.Error("Failed to permanently delete all shipping address", zap.Error(err))
 		return false, &response.ErrorResponse{Status: "error", Message: "Failed to permanently delete all shipping address"}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"ecommerce/internal/domain/requests"
 	"ecommerce/internal/domain/response"
 	response_service "ecommerce/internal/mapper/response/services"
@@ -28,7 +29,7 @@ func NewSliderService(
 	}
 }
 
-func (s *sliderService) FindAll(page int, pageSize int, search string) ([]*response.SliderResponse, int, *response.ErrorResponse) {
+func (s *sliderService) FindAll(ctx context.Context, page int, pageSize int, search string) ([]*response.SliderResponse, int, *response.ErrorResponse) {
 	s.logger.Debug("Fetching sliders",
 		zap.Int("page", page),
 		zap.Int("pageSize", pageSize),
@@ -42,7 +43,7 @@ func (s *sliderService) FindAll(page int, pageSize int, search string) ([]*respo
 		pageSize = 10
 	}
 
-	sliders, totalRecords, err := s.sliderRepository.FindAllSlider(search, page, pageSize)
+	sliders, totalRecords, err := s.sliderRepository.FindAllSlider(ctx, search, page, pageSize)
 
 	if err != nil {
 		s.logger.Error("Failed to fetch sliders",
@@ -67,7 +68,7 @@ func (s *sliderService) FindAll(page int, pageSize int, search string) ([]*respo
 	return slidersResponse, int(totalRecords), nil
 }
 
-func (s *sliderService) FindByActive(search string, page, pageSize int) ([]*response.SliderResponseDeleteAt, int, *response.ErrorResponse) {
+func (s *sliderService) FindByActive(ctx context.Context, search string, page, pageSize int) ([]*response.SliderResponseDeleteAt, int, *response.ErrorResponse) {
 	s.logger.Debug("Fetching sliders",
 		zap.Int("page", page),
 		zap.Int("pageSize", pageSize),
@@ -81,7 +82,7 @@ func (s *sliderService) FindByActive(search string, page, pageSize int) ([]*resp
 		pageSize = 10
 	}
 
-	sliders, totalRecords, err := s.sliderRepository.FindByActive(search, page, pageSize)
+	sliders, totalRecords, err := s.sliderRepository.FindByActive(ctx, search, page, pageSize)
 
 	if err != nil {
 		s.logger.Error("Failed to fetch sliders",
@@ -100,7 +101,7 @@ func (s *sliderService) FindByActive(search string, page, pageSize int) ([]*resp
 	return s.mapping.ToSlidersResponseDeleteAt(sliders), totalRecords, nil
 }
 
-func (s *sliderService) FindByTrashed(search string, page, pageSize int) ([]*response.SliderResponseDeleteAt, int, *response.ErrorResponse) {
+func (s *sliderService) FindByTrashed(ctx context.Context, search string, page, pageSize int) ([]*response.SliderResponseDeleteAt, int, *response.ErrorResponse) {
 	s.logger.Debug("Fetching sliders",
 		zap.Int("page", page),
 		zap.Int("pageSize", pageSize),
@@ -114,7 +115,7 @@ func (s *sliderService) FindByTrashed(search string, page, pageSize int) ([]*res
 		pageSize = 10
 	}
 
-	sliders, totalRecords, err := s.sliderRepository.FindByTrashed(search, page, pageSize)
+	sliders, totalRecords, err := s.sliderRepository.FindByTrashed(ctx, search, page, pageSize)
 	if err != nil {
 		s.logger.Error("Failed to fetch sliders",
 			zap.Error(err),
@@ -132,10 +133,10 @@ func (s *sliderService) FindByTrashed(search string, page, pageSize int) ([]*res
 	return s.mapping.ToSlidersResponseDeleteAt(sliders), totalRecords, nil
 }
 
-func (s *sliderService) CreateSlider(req *requests.CreateSliderRequest) (*response.SliderResponse, *response.ErrorResponse) {
+func (s *sliderService) CreateSlider(ctx context.Context, req *requests.CreateSliderRequest) (*response.SliderResponse, *response.ErrorResponse) {
 	s.logger.Debug("Creating new slider")
 
-	slider, err := s.sliderRepository.CreateSlider(req)
+	slider, err := s.sliderRepository.CreateSlider(ctx, req)
 	if err != nil {
 		s.logger.Error("Failed to create slider", zap.Error(err))
 		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to create slider"}
@@ -144,10 +145,10 @@ func (s *sliderService) CreateSlider(req *requests.CreateSliderRequest) (*respon
 	return s.mapping.ToSliderResponse(slider), nil
 }
 
-func (s *sliderService) UpdateSlider(req *requests.UpdateSliderRequest) (*response.SliderResponse, *response.ErrorResponse) {
+func (s *sliderService) UpdateSlider(ctx context.Context, req *requests.UpdateSliderRequest) (*response.SliderResponse, *response.ErrorResponse) {
 	s.logger.Debug("Updating slider", zap.Int("slider_id", req.ID))
 
-	slider, err := s.sliderRepository.UpdateSlider(req)
+	slider, err := s.sliderRepository.UpdateSlider(ctx, req)
 	if err != nil {
 		s.logger.Error("Failed to update slider", zap.Error(err))
 		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to update slider"}
@@ -156,10 +157,10 @@ func (s *sliderService) UpdateSlider(req *requests.UpdateSliderRequest) (*respon
 	return s.mapping.ToSliderResponse(slider), nil
 }
 
-func (s *sliderService) TrashedSlider(slider_id int) (*response.SliderResponseDeleteAt, *response.ErrorResponse) {
+func (s *sliderService) TrashedSlider(ctx context.Context, slider_id int) (*response.SliderResponseDeleteAt, *response.ErrorResponse) {
 	s.logger.Debug("Trashing slider", zap.Int("slider", slider_id))
 
-	slider, err := s.sliderRepository.TrashSlider(slider_id)
+	slider, err := s.sliderRepository.TrashSlider(ctx, slider_id)
 	if err != nil {
 		s.logger.Error("Failed to trash slider", zap.Error(err))
 		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to trash slider"}
@@ -168,10 +169,10 @@ func (s *sliderService) TrashedSlider(slider_id int) (*response.SliderResponseDe
 	return s.mapping.ToSliderResponseDeleteAt(slider), nil
 }
 
-func (s *sliderService) RestoreSlider(sliderID int) (*response.SliderResponseDeleteAt, *response.ErrorResponse) {
+func (s *sliderService) RestoreSlider(ctx context.Context, sliderID int) (*response.SliderResponseDeleteAt, *response.ErrorResponse) {
 	s.logger.Debug("Restoring slider", zap.Int("sliderID", sliderID))
 
-	slider, err := s.sliderRepository.RestoreSlider(sliderID)
+	slider, err := s.sliderRepository.RestoreSlider(ctx, sliderID)
 	if err != nil {
 		s.logger.Error("Failed to restore slider", zap.Error(err))
 		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to restore slider"}
@@ -180,10 +181,10 @@ func (s *sliderService) RestoreSlider(sliderID int) (*response.SliderResponseDel
 	return s.mapping.ToSliderResponseDeleteAt(slider), nil
 }
 
-func (s *sliderService) DeleteSliderPermanent(sliderID int) (bool, *response.ErrorResponse) {
+func (s *sliderService) DeleteSliderPermanent(ctx context.Context, sliderID int) (bool, *response.ErrorResponse) {
 	s.logger.Debug("Permanently deleting slider", zap.Int("sliderID", sliderID))
 
-	success, err := s.sliderRepository.DeleteSliderPermanently(sliderID)
+	success, err := s.sliderRepository.DeleteSliderPermanently(ctx, sliderID)
 	if err != nil {
 		s.logger.Error("Failed to permanently delete slider", zap.Error(err))
 		return false, &response.ErrorResponse{Status: "error", Message: "Failed to permanently delete slider"}
@@ -192,10 +193,10 @@ func (s *sliderService) DeleteSliderPermanent(sliderID int) (bool, *response.Err
 	return success, nil
 }
 
-func (s *sliderService) RestoreAllSliders() (bool, *response.ErrorResponse) {
+func (s *sliderService) RestoreAllSliders(ctx context.Context) (bool, *response.ErrorResponse) {
 	s.logger.Debug("Restoring all trashed sliders")
 
-	success, err := s.sliderRepository.RestoreAllSlider()
+	success, err := s.sliderRepository.RestoreAllSlider(ctx)
 	if err != nil {
 		s.logger.Error("Failed to restore all sliders", zap.Error(err))
 		return false, &response.ErrorResponse{Status: "error", Message: "Failed to restore all sliders"}
@@ -204,10 +205,10 @@ func (s *sliderService) RestoreAllSliders() (bool, *response.ErrorResponse) {
 	return success, nil
 }
 
-func (s *sliderService) DeleteAllSlidersPermanent() (bool, *response.ErrorResponse) {
+func (s *sliderService) DeleteAllSlidersPermanent(ctx context.Context) (bool, *response.ErrorResponse) {
 	s.logger.Debug("Permanently deleting all sliders")
 
-	success, err := s.sliderRepository.DeleteAllPermanentSlider()
+	success, err := s.sliderRepository.DeleteAllPermanentSlider(ctx)
 	if err != nil {
 		s.logger.Error("Failed to permanently delete all sliders", zap.Error(err))
 		return false, &response.ErrorResponse{Status: "error", Message: "Failed to permanently delete all sliders"}
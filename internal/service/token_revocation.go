@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"ecommerce/internal/domain/requests"
+	"ecommerce/internal/domain/response"
+	"ecommerce/internal/repository"
+	"ecommerce/pkg/auth"
+	"ecommerce/pkg/logger"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const revokedTokenTimeLayout = "2006-01-02 15:04:05.000"
+
+// tokenRevocationService keeps the revoked access tokens in memory so every
+// request can be checked without a database round trip. The cache is reloaded
+// from Postgres once it is older than ttl, which bounds how long a revocation
+// made by another process takes to be enforced here.
+type tokenRevocationService struct {
+	revokedToken repository.RevokedTokenRepository
+	refreshToken repository.RefreshTokenRepository
+	logger       logger.LoggerInterface
+	ttl          time.Duration
+
+	mu       sync.RWMutex
+	tokens   map[string]struct{}
+	users    map[int]time.Time
+	loadedAt time.Time
+}
+
+func NewTokenRevocationService(revokedToken repository.RevokedTokenRepository, refreshToken repository.RefreshTokenRepository, logger logger.LoggerInterface, ttl time.Duration) *tokenRevocationService {
+	return &tokenRevocationService{
+		revokedToken: revokedToken,
+		refreshToken: refreshToken,
+		logger:       logger,
+		ttl:          ttl,
+		tokens:       map[string]struct{}{},
+		users:        map[int]time.Time{},
+	}
+}
+
+// IsRevoked reports whether claims belong to a revoked access token. When the
+// cache cannot be reloaded the last loaded revocations keep being enforced.
+func (s *tokenRevocationService) IsRevoked(ctx context.Context, claims *auth.Claims) bool {
+	s.reloadIfStale(ctx)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.tokens[claims.ID]; ok && claims.ID != "" {
+		return true
+	}
+
+	userID, err := strconv.Atoi(claims.Subject)
+	if err != nil {
+		return false
+	}
+
+	revokedAt, ok := s.users[userID]
+	if !ok {
+		return false
+	}
+
+	// Tokens issued before iat was added cannot be told apart, so they are
+	// treated as issued before the revocation.
+	if claims.IssuedAt == nil {
+		return true
+	}
+
+	// iat only has second precision, so a token issued in the same second
+	// as the revocation may predate it and is rejected too. Signing in
+	// again a second later issues a token that is accepted.
+	return !claims.IssuedAt.Time.After(revokedAt.Truncate(time.Second))
+}
+
+func (s *tokenRevocationService) RevokeToken(ctx context.Context, claims *auth.Claims, reason string) *response.ErrorResponse {
+	if claims.ID == "" || claims.ExpiresAt == nil {
+		return nil
+	}
+
+	userID, _ := strconv.Atoi(claims.Subject)
+
+	err := s.revokedToken.RevokeAccessToken(ctx, &requests.RevokeAccessTokenRequest{
+		TokenID:   claims.ID,
+		UserID:    userID,
+		Reason:    reason,
+		RevokedAt: time.Now().UTC(),
+		ExpiresAt: claims.ExpiresAt.Time.UTC(),
+	})
+
+	if err != nil {
+		s.logger.Error("Failed to revoke access token", zap.Error(err))
+		return &response.ErrorResponse{
+			Status:  "error",
+			Message: "Failed to revoke access token",
+		}
+	}
+
+	s.mu.Lock()
+	s.tokens[claims.ID] = struct{}{}
+	s.mu.Unlock()
+
+	s.logger.Debug("Access token revoked",
+		zap.Int("userID", userID),
+		zap.String("reason", reason),
+	)
+
+	return nil
+}
+
+// RevokeUser signs the user out everywhere: every access token issued so far
+// is revoked and every session is ended, so no new ones can be refreshed.
+func (s *tokenRevocationService) RevokeUser(ctx context.Context, user_id int, reason string) *response.ErrorResponse {
+	return s.revokeUser(ctx, s.revokedToken, s.refreshToken, user_id, reason)
+}
+
+// RevokeUserWithin is RevokeUser writing through the repositories of a unit
+// of work, so the revocation commits or rolls back with the caller's changes.
+// The revocation is cached straight away; if the unit of work rolls back it
+// is dropped again on the next reload.
+func (s *tokenRevocationService) RevokeUserWithin(ctx context.Context, repos *repository.Repositories, user_id int, reason string) *response.ErrorResponse {
+	return s.revokeUser(ctx, repos.RevokedToken, repos.RefreshToken, user_id, reason)
+}
+
+func (s *tokenRevocationService) revokeUser(ctx context.Context, revokedToken repository.RevokedTokenRepository, refreshToken repository.RefreshTokenRepository, user_id int, reason string) *response.ErrorResponse {
+	now := time.Now().UTC()
+
+	err := revokedToken.RevokeUserAccessTokens(ctx, &requests.RevokeUserAccessTokensRequest{
+		UserID:    user_id,
+		Reason:    reason,
+		RevokedAt: now,
+		ExpiresAt: now.Add(auth.AccessTokenTTL),
+	})
+
+	if err != nil {
+		s.logger.Error("Failed to revoke user access tokens", zap.Int("userID", user_id), zap.Error(err))
+		return &response.ErrorResponse{
+			Status:  "error",
+			Message: "Failed to revoke user tokens",
+		}
+	}
+
+	s.mu.Lock()
+	s.users[user_id] = now
+	s.mu.Unlock()
+
+	if err := refreshToken.RevokeByUserId(ctx, user_id); err != nil {
+		s.logger.Error("Failed to revoke user sessions", zap.Int("userID", user_id), zap.Error(err))
+		return &response.ErrorResponse{
+			Status:  "error",
+			Message: "Failed to revoke user tokens",
+		}
+	}
+
+	s.logger.Debug("User tokens revoked",
+		zap.Int("userID", user_id),
+		zap.String("reason", reason),
+	)
+
+	return nil
+}
+
+func (s *tokenRevocationService) reloadIfStale(ctx context.Context) {
+	s.mu.RLock()
+	fresh := time.Since(s.loadedAt) < s.ttl
+	s.mu.RUnlock()
+
+	if fresh {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if time.Since(s.loadedAt) < s.ttl {
+		return
+	}
+
+	now := time.Now().UTC()
+
+	res, err := s.revokedToken.FindActive(ctx, now)
+	if err != nil {
+		// Keep the previous revocations and retry after another ttl rather
+		// than hitting the database on every request while it is down.
+		s.logger.Error("Failed to reload revoked tokens", zap.Error(err))
+		s.loadedAt = time.Now()
+		return
+	}
+
+	tokens := make(map[string]struct{}, len(res))
+	users := make(map[int]time.Time)
+
+	for _, revoked := range res {
+		if revoked.TokenID != "" {
+			tokens[revoked.TokenID] = struct{}{}
+			continue
+		}
+
+		revokedAt, err := time.Parse(revokedTokenTimeLayout, revoked.RevokedAt)
+		if err != nil {
+			s.logger.Error("Invalid revocation time", zap.Int("revokedTokenID", revoked.ID), zap.Error(err))
+			continue
+		}
+
+		if revokedAt.After(users[revoked.UserID]) {
+			users[revoked.UserID] = revokedAt
+		}
+	}
+
+	s.tokens = tokens
+	s.users = users
+	s.loadedAt = time.Now()
+
+	if err := s.revokedToken.DeleteExpired(ctx, now); err != nil {
+		s.logger.Error("Failed to delete expired revoked tokens", zap.Error(err))
+	}
+}
@@ -0,0 +1,100 @@
+package service_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"ecommerce/pkg/auth"
+	"ecommerce/pkg/database/testdb"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestRevokeUserRejectsTokensIssuedUpToTheRevocation(t *testing.T) {
+	conn := testdb.Open(t)
+	services := newTestService(t, conn, nil)
+	ctx := context.Background()
+
+	userID := testdb.SeedUser(t, conn)
+
+	if errResp := services.Revocation.RevokeUser(ctx, userID, "logout_all"); errResp != nil {
+		t.Fatalf("revoke user: %s", errResp.Message)
+	}
+
+	var revokedAt time.Time
+	if err := conn.QueryRow(`SELECT revoked_at FROM revoked_tokens WHERE user_id = $1 AND jti IS NULL`, userID).Scan(&revokedAt); err != nil {
+		t.Fatalf("read revocation: %v", err)
+	}
+
+	second := revokedAt.Truncate(time.Second)
+
+	tests := []struct {
+		name     string
+		issuedAt *time.Time
+		want     bool
+	}{
+		{name: "issued before", issuedAt: timePtr(second.Add(-time.Second)), want: true},
+		{name: "issued in the same second", issuedAt: timePtr(second), want: true},
+		{name: "issued the next second", issuedAt: timePtr(second.Add(time.Second)), want: false},
+		{name: "no issued at", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := &auth.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: strconv.Itoa(userID)}}
+			if tt.issuedAt != nil {
+				claims.IssuedAt = jwt.NewNumericDate(*tt.issuedAt)
+			}
+
+			if got := services.Revocation.IsRevoked(ctx, claims); got != tt.want {
+				t.Errorf("IsRevoked = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRevokeTokenRejectsOnlyThatToken(t *testing.T) {
+	conn := testdb.Open(t)
+	services := newTestService(t, conn, nil)
+	ctx := context.Background()
+
+	userID := testdb.SeedUser(t, conn)
+	issuedAt := time.Now().Add(-time.Minute)
+
+	claimsFor := func(id string) *auth.Claims {
+		return &auth.Claims{RegisteredClaims: jwt.RegisteredClaims{
+			ID:        id,
+			Subject:   strconv.Itoa(userID),
+			IssuedAt:  jwt.NewNumericDate(issuedAt),
+			ExpiresAt: jwt.NewNumericDate(issuedAt.Add(auth.AccessTokenTTL)),
+		}}
+	}
+
+	revoked := claimsFor(testdb.Unique("jti"))
+	if errResp := services.Revocation.RevokeToken(ctx, revoked, "logout"); errResp != nil {
+		t.Fatalf("revoke token: %s", errResp.Message)
+	}
+
+	tests := []struct {
+		name   string
+		claims *auth.Claims
+		want   bool
+	}{
+		{name: "revoked token", claims: revoked, want: true},
+		{name: "other token of the user", claims: claimsFor(testdb.Unique("jti")), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := services.Revocation.IsRevoked(ctx, tt.claims); got != tt.want {
+				t.Errorf("IsRevoked = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
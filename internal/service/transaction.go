@@ -1,43 +1,80 @@
 package service
 
 import (
+	"context"
+	"database/sql"
+	"ecommerce/internal/domain/money"
+	"ecommerce/internal/domain/record"
 	"ecommerce/internal/domain/requests"
 	"ecommerce/internal/domain/response"
 	response_service "ecommerce/internal/mapper/response/services"
 	"ecommerce/internal/repository"
 	"ecommerce/pkg/logger"
+	"ecommerce/pkg/pagination"
+	"ecommerce/pkg/payment"
+	"errors"
+	"fmt"
 
 	"go.uber.org/zap"
 )
 
+// paymentEventStatuses maps the webhook events that settle a payment to the
+// transaction payment status they lead to.
+var paymentEventStatuses = map[string]string{
+	payment.EventChargeSucceeded: record.PaymentStatusPaid,
+	payment.EventChargeFailed:    record.PaymentStatusFailed,
+	payment.EventChargeRefunded:  record.PaymentStatusRefunded,
+}
+
+// paymentStatusTransitions lists, for every payment status, the statuses a
+// provider event may move it to. Out-of-order events are ignored.
+var paymentStatusTransitions = map[string][]string{
+	record.PaymentStatusPending:  {record.PaymentStatusPaid, record.PaymentStatusFailed},
+	record.PaymentStatusFailed:   {record.PaymentStatusPaid},
+	record.PaymentStatusPaid:     {record.PaymentStatusRefunded},
+	record.PaymentStatusRefunded: {},
+}
+
+// paymentOrderStatuses is the order status that follows a payment status.
+var paymentOrderStatuses = map[string]string{
+	record.PaymentStatusPaid:     record.OrderStatusPaid,
+	record.PaymentStatusRefunded: record.OrderStatusRefunded,
+}
+
 type transactionService struct {
+	uow                   repository.UnitOfWork
 	merchantRepository    repository.MerchantRepository
 	transactionRepository repository.TransactionRepository
 	orderRepository       repository.OrderRepository
 	orderItemRepository   repository.OrderItemRepository
+	paymentProvider       payment.PaymentProvider
 	logger                logger.LoggerInterface
 	mapping               response_service.TransactionResponseMapper
 }
 
 func NewTransactionService(
+	uow repository.UnitOfWork,
 	merchantRepository repository.MerchantRepository,
 	transactionRepository repository.TransactionRepository,
 	orderRepository repository.OrderRepository,
 	orderItemRepository repository.OrderItemRepository,
+	paymentProvider payment.PaymentProvider,
 	logger logger.LoggerInterface,
 	mapping response_service.TransactionResponseMapper,
 ) *transactionService {
 	return &transactionService{
+		uow:                   uow,
 		merchantRepository:    merchantRepository,
 		transactionRepository: transactionRepository,
 		orderRepository:       orderRepository,
 		orderItemRepository:   orderItemRepository,
+		paymentProvider:       paymentProvider,
 		mapping:               mapping,
 		logger:                logger,
 	}
 }
 
-func (s *transactionService) FindAllTransactions(search string, page, pageSize int) ([]*response.TransactionResponse, int, *response.ErrorResponse) {
+func (s *transactionService) FindAllTransactions(ctx context.Context, search string, page, pageSize int) ([]*response.TransactionResponse, int, *response.ErrorResponse) {
 	s.logger.Debug("Fetching transactions",
 		zap.Int("page", page),
 		zap.Int("pageSize", pageSize),
@@ -51,7 +88,7 @@ func (s *transactionService) FindAllTransactions(search string, page, pageSize i
 		pageSize = 10
 	}
 
-	transactions, totalRecords, err := s.transactionRepository.FindAllTransactions(search, page, pageSize)
+	transactions, totalRecords, err := s.transactionRepository.FindAllTransactions(ctx, search, page, pageSize)
 	if err != nil {
 		s.logger.Error("Failed to fetch transactions",
 			zap.Error(err),
@@ -69,7 +106,45 @@ func (s *transactionService) FindAllTransactions(search string, page, pageSize i
 	return s.mapping.ToTransactionsResponse(transactions), totalRecords, nil
 }
 
-func (s *transactionService) FindByMerchant(merchant_id int, search string, page, pageSize int) ([]*response.TransactionResponse, int, *response.ErrorResponse) {
+func (s *transactionService) FindAllTransactionsCursor(ctx context.Context, cursor string, pageSize int, search string) ([]*response.TransactionResponse, string, *response.ErrorResponse) {
+	s.logger.Debug("Fetching transactions by cursor",
+		zap.String("cursor", cursor),
+		zap.Int("pageSize", pageSize),
+		zap.String("search", search))
+
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+
+	after, err := pagination.DecodeCursor(cursor)
+	if err != nil {
+		s.logger.Debug("Invalid cursor", zap.String("cursor", cursor))
+		return nil, "", &response.ErrorResponse{Status: "error", Message: "Invalid cursor", Code: response.ErrCodeValidation}
+	}
+
+	transactions, next, err := s.transactionRepository.FindAllTransactionsCursor(ctx, search, after, pageSize)
+	if err != nil {
+		s.logger.Error("Failed to fetch transactions",
+			zap.Error(err),
+			zap.String("cursor", cursor),
+			zap.Int("pageSize", pageSize),
+			zap.String("search", search))
+		return nil, "", &response.ErrorResponse{Status: "error", Message: "Failed to fetch transactions"}
+	}
+
+	var nextCursor string
+	if next != nil {
+		nextCursor = next.Encode()
+	}
+
+	s.logger.Debug("Successfully fetched transactions by cursor",
+		zap.Int("count", len(transactions)),
+		zap.Bool("hasMore", nextCursor != ""))
+
+	return s.mapping.ToTransactionsResponse(transactions), nextCursor, nil
+}
+
+func (s *transactionService) FindByMerchant(ctx context.Context, merchant_id int, search string, page, pageSize int) ([]*response.TransactionResponse, int, *response.ErrorResponse) {
 	s.logger.Debug("Fetching transactions",
 		zap.Int("page", page),
 		zap.Int("pageSize", pageSize),
@@ -83,7 +158,7 @@ func (s *transactionService) FindByMerchant(merchant_id int, search string, page
 		pageSize = 10
 	}
 
-	transactions, totalRecords, err := s.transactionRepository.FindByMerchant(merchant_id, search, page, pageSize)
+	transactions, totalRecords, err := s.transactionRepository.FindByMerchant(ctx, merchant_id, search, page, pageSize)
 	if err != nil {
 		s.logger.Error("Failed to fetch transactions",
 			zap.Error(err),
@@ -101,7 +176,7 @@ func (s *transactionService) FindByMerchant(merchant_id int, search string, page
 	return s.mapping.ToTransactionsResponse(transactions), totalRecords, nil
 }
 
-func (s *transactionService) FindByActive(search string, page, pageSize int) ([]*response.TransactionResponseDeleteAt, int, *response.ErrorResponse) {
+func (s *transactionService) FindByActive(ctx context.Context, search string, page, pageSize int) ([]*response.TransactionResponseDeleteAt, int, *response.ErrorResponse) {
 	s.logger.Debug("Fetching transactions",
 		zap.Int("page", page),
 		zap.Int("pageSize", pageSize),
@@ -115,7 +190,7 @@ func (s *transactionService) FindByActive(search string, page, pageSize int) ([]
 		pageSize = 10
 	}
 
-	transactions, totalRecords, err := s.transactionRepository.FindByActive(search, page, pageSize)
+	transactions, totalRecords, err := s.transactionRepository.FindByActive(ctx, search, page, pageSize)
 	if err != nil {
 		s.logger.Error("Failed to fetch transactions",
 			zap.Error(err),
@@ -133,7 +208,7 @@ func (s *transactionService) FindByActive(search string, page, pageSize int) ([]
 	return s.mapping.ToTransactionsResponseDeleteAt(transactions), totalRecords, nil
 }
 
-func (s *transactionService) FindByTrashed(search string, page, pageSize int) ([]*response.TransactionResponseDeleteAt, int, *response.ErrorResponse) {
+func (s *transactionService) FindByTrashed(ctx context.Context, search string, page, pageSize int) ([]*response.TransactionResponseDeleteAt, int, *response.ErrorResponse) {
 	s.logger.Debug("Fetching transactions",
 		zap.Int("page", page),
 		zap.Int("pageSize", pageSize),
@@ -147,7 +222,7 @@ func (s *transactionService) FindByTrashed(search string, page, pageSize int) ([
 		pageSize = 10
 	}
 
-	transactions, totalRecords, err := s.transactionRepository.FindByTrashed(search, page, pageSize)
+	transactions, totalRecords, err := s.transactionRepository.FindByTrashed(ctx, search, page, pageSize)
 
 	if err != nil {
 		s.logger.Error("Failed to fetch transactions",
@@ -166,148 +241,855 @@ func (s *transactionService) FindByTrashed(search string, page, pageSize int) ([
 	return s.mapping.ToTransactionsResponseDeleteAt(transactions), totalRecords, nil
 }
 
-func (s *transactionService) FindById(transactionID int) (*response.TransactionResponse, *response.ErrorResponse) {
+func (s *transactionService) FindById(ctx context.Context, transactionID int) (*response.TransactionResponse, *response.ErrorResponse) {
 	s.logger.Debug("Fetching transaction by ID", zap.Int("transactionID", transactionID))
 
-	transaction, err := s.transactionRepository.FindById(transactionID)
+	transaction, err := s.transactionRepository.FindById(ctx, transactionID)
 	if err != nil {
 		s.logger.Error("Failed to fetch transaction", zap.Error(err))
-		return nil, &response.ErrorResponse{Status: "error", Message: "Transaction not found"}
+		return nil, &response.ErrorResponse{Status: "error", Message: "Transaction not found", Code: response.ErrCodeNotFound}
 	}
 
 	return s.mapping.ToTransactionResponse(transaction), nil
 }
 
-func (s *transactionService) FindByOrderId(orderID int) (*response.TransactionResponse, *response.ErrorResponse) {
+func (s *transactionService) FindByOrderId(ctx context.Context, orderID int) (*response.TransactionResponse, *response.ErrorResponse) {
 	s.logger.Debug("Fetching transaction by Order ID", zap.Int("orderID", orderID))
 
-	transaction, err := s.transactionRepository.FindByOrderId(orderID)
+	transaction, err := s.transactionRepository.FindByOrderId(ctx, orderID)
 	if err != nil {
 		s.logger.Error("Failed to fetch transaction by order ID", zap.Error(err))
-		return nil, &response.ErrorResponse{Status: "error", Message: "Transaction not found"}
+		return nil, &response.ErrorResponse{Status: "error", Message: "Transaction not found", Code: response.ErrCodeNotFound}
 	}
 
 	return s.mapping.ToTransactionResponse(transaction), nil
 }
 
-func (s *transactionService) CreateTransaction(req *requests.CreateTransactionRequest) (*response.TransactionResponse, *response.ErrorResponse) {
+func (s *transactionService) CreateTransaction(ctx context.Context, req *requests.CreateTransactionRequest) (*response.TransactionResponse, *response.ErrorResponse) {
 	s.logger.Debug("Creating new transaction", zap.Int("orderID", req.OrderID))
 
-	_, err := s.merchantRepository.FindById(req.MerchantID)
+	_, err := s.merchantRepository.FindById(ctx, req.MerchantID)
 
 	if err != nil {
 		s.logger.Error("Merchant not found", zap.Int("merchantId", req.MerchantID), zap.Error(err))
-		return nil, &response.ErrorResponse{Status: "error", Message: "Merchant not found"}
+		return nil, &response.ErrorResponse{Status: "error", Message: "Merchant not found", Code: response.ErrCodeNotFound}
+	}
+
+	var (
+		order       *record.OrderRecord
+		transaction *record.TransactionRecord
+	)
+
+	errResp := withinTransaction(ctx, s.uow, s.logger, "Failed to create transaction", func(repos *repository.Repositories) *response.ErrorResponse {
+		found, err := repos.Order.FindByIdForUpdate(ctx, req.OrderID)
+		if err != nil {
+			s.logger.Error("Order not found", zap.Int("orderID", req.OrderID), zap.Error(err))
+			return &response.ErrorResponse{Status: "error", Message: "Order not found", Code: response.ErrCodeNotFound}
+		}
+		order = found
+
+		if order.MerchantID != req.MerchantID {
+			return &response.ErrorResponse{Status: "error", Message: "Order does not belong to this merchant", Code: response.ErrCodeValidation}
+		}
+
+		if order.Status != record.OrderStatusPending {
+			return &response.ErrorResponse{
+				Status:  "error",
+				Message: fmt.Sprintf("Order with status %s cannot be paid", order.Status),
+				Code:    response.ErrCodeInvalidStatusTransition,
+			}
+		}
+
+		if order.TotalPrice <= 0 {
+			s.logger.Error("Order has nothing to pay", zap.Int("orderID", order.ID), zap.Int64("totalPrice", order.TotalPrice))
+			return &response.ErrorResponse{Status: "error", Message: "Order has nothing to pay", Code: response.ErrCodeValidation}
+		}
+
+		if req.Amount < order.TotalPrice {
+			s.logger.Error("Insufficient payment amount", zap.Int64("amount", req.Amount), zap.Int64("amountDue", order.TotalPrice))
+			return &response.ErrorResponse{Status: "error", Message: "Insufficient payment amount", Code: response.ErrCodeValidation}
+		}
+
+		live, err := repos.Transaction.HasLiveTransaction(ctx, order.ID)
+		if err != nil {
+			s.logger.Error("Failed to check order transactions", zap.Int("orderID", order.ID), zap.Error(err))
+			return &response.ErrorResponse{Status: "error", Message: "Failed to create transaction"}
+		}
+
+		if live {
+			return &response.ErrorResponse{Status: "error", Message: "Order already has a pending or paid transaction", Code: response.ErrCodeConflict}
+		}
+
+		req.AmountDue = order.TotalPrice
+		req.ChangeAmount = req.Amount - order.TotalPrice
+		req.PaymentStatus = record.PaymentStatusPending
+
+		created, err := repos.Transaction.CreateTransaction(ctx, req)
+		if err != nil {
+			s.logger.Error("Failed to create transaction", zap.Error(err))
+			return &response.ErrorResponse{Status: "error", Message: "Failed to create transaction"}
+		}
+		transaction = created
+
+		return nil
+	})
+
+	if errResp != nil {
+		return nil, errResp
+	}
+
+	charge, err := s.charge(ctx, transaction.ID, money.New(transaction.AmountDue, order.Currency), req.PaymentMethod)
+
+	update := &requests.UpdateTransactionPaymentRequest{
+		TransactionID:   transaction.ID,
+		PaymentStatus:   record.PaymentStatusFailed,
+		PaymentProvider: s.paymentProvider.Name(),
+	}
+
+	if charge != nil {
+		update.ProviderChargeID = charge.ID
+		update.PaymentStatus = paymentStatusFromCharge(charge)
+	}
+
+	errResp = withinTransaction(ctx, s.uow, s.logger, "Failed to record payment outcome", func(repos *repository.Repositories) *response.ErrorResponse {
+		updated, err := repos.Transaction.UpdateTransactionPayment(ctx, update)
+		if err != nil {
+			s.logger.Error("Failed to record payment outcome",
+				zap.Error(err),
+				zap.Int("transactionID", update.TransactionID),
+				zap.String("chargeID", update.ProviderChargeID),
+				zap.String("paymentStatus", update.PaymentStatus))
+			return &response.ErrorResponse{Status: "error", Message: "Failed to record payment outcome"}
+		}
+		transaction = updated
+
+		if updated.PaymentStatus != record.PaymentStatusPaid {
+			return nil
+		}
+
+		current, err := repos.Order.FindById(ctx, order.ID)
+		if err != nil {
+			s.logger.Error("Order not found", zap.Int("orderID", order.ID), zap.Error(err))
+			return &response.ErrorResponse{Status: "error", Message: "Order not found", Code: response.ErrCodeNotFound}
+		}
+
+		if !canTransitionOrder(current.Status, record.OrderStatusPaid) {
+			s.logger.Debug("Order status left unchanged by payment",
+				zap.Int("orderID", current.ID),
+				zap.String("orderStatus", current.Status))
+			return nil
+		}
+
+		_, errResp := changeOrderStatus(ctx, repos, s.logger, order.ID, record.OrderStatusPaid, "Payment "+update.ProviderChargeID)
+		return errResp
+	})
+
+	if errResp != nil {
+		return nil, errResp
 	}
 
-	_, err = s.orderRepository.FindById(req.OrderID)
 	if err != nil {
-		s.logger.Error("Order not found", zap.Int("orderID", req.OrderID), zap.Error(err))
-		return nil, &response.ErrorResponse{Status: "error", Message: "Order not found"}
+		s.logger.Error("Payment provider error",
+			zap.Error(err),
+			zap.Int("transactionID", transaction.ID),
+			zap.String("provider", s.paymentProvider.Name()))
+		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to process payment"}
 	}
 
-	orderItems, err := s.orderItemRepository.FindOrderItemByOrder(req.OrderID)
+	s.logger.Debug("Payment processed",
+		zap.Int("transactionID", transaction.ID),
+		zap.String("chargeID", charge.ID),
+		zap.String("paymentStatus", transaction.PaymentStatus))
+
+	return s.mapping.ToTransactionResponse(transaction), nil
+}
+
+// charge asks the payment provider to collect amount for a transaction,
+// capturing the charge when the provider only authorized it.
+func (s *transactionService) charge(ctx context.Context, transactionID int, amount money.Money, paymentMethod string) (*payment.Charge, error) {
+	charge, err := s.paymentProvider.CreateCharge(ctx, payment.ChargeRequest{
+		Reference:     fmt.Sprintf("transaction-%d", transactionID),
+		Amount:        amount.Amount,
+		Currency:      amount.Currency,
+		PaymentMethod: paymentMethod,
+	})
 	if err != nil {
-		s.logger.Error("Failed to fetch order items", zap.Int("orderID", req.OrderID), zap.Error(err))
-		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to fetch order items"}
+		return nil, err
 	}
 
-	var totalAmount int
-	for _, item := range orderItems {
-		totalAmount += item.Price * item.Quantity
+	if charge.Status == payment.ChargeStatusAuthorized {
+		captured, err := s.paymentProvider.Capture(ctx, charge.ID)
+		if err != nil {
+			return charge, err
+		}
+		charge = captured
+	}
+
+	return charge, nil
+}
+
+// paymentStatusFromCharge maps a provider charge status to the transaction
+// payment status. Charges still awaiting an outcome stay pending.
+func paymentStatusFromCharge(charge *payment.Charge) string {
+	switch charge.Status {
+	case payment.ChargeStatusSucceeded:
+		return record.PaymentStatusPaid
+	case payment.ChargeStatusFailed:
+		return record.PaymentStatusFailed
+	default:
+		return record.PaymentStatusPending
 	}
+}
+
+func (s *transactionService) HandlePaymentEvent(ctx context.Context, req *requests.PaymentEventRequest) (*response.TransactionResponse, bool, *response.ErrorResponse) {
+	event, err := s.paymentProvider.VerifyWebhook(req.Payload, req.Signature)
+	if err != nil {
+		s.logger.Error("Rejected payment event", zap.Error(err))
+
+		if errors.Is(err, payment.ErrInvalidSignature) {
+			return nil, false, &response.ErrorResponse{Status: "error", Message: "Invalid webhook signature", Code: response.ErrCodeUnauthorized}
+		}
 
-	if req.PaymentStatus != "pending" && req.PaymentStatus != "paid" && req.PaymentStatus != "failed" {
-		s.logger.Error("Invalid payment status", zap.String("paymentStatus", req.PaymentStatus))
-		return nil, &response.ErrorResponse{Status: "error", Message: "Invalid payment status"}
+		return nil, false, &response.ErrorResponse{Status: "error", Message: "Invalid payment event", Code: response.ErrCodeValidation}
 	}
 
-	if req.PaymentStatus == "paid" {
-		if req.Amount < totalAmount {
-			s.logger.Error("Insufficient payment amount", zap.Int("amount", req.Amount), zap.Int("totalAmount", totalAmount))
-			return nil, &response.ErrorResponse{Status: "error", Message: "Insufficient payment amount"}
+	s.logger.Debug("Handling payment event",
+		zap.String("eventID", event.ID),
+		zap.String("type", event.Type),
+		zap.String("chargeID", event.ChargeID))
+
+	provider := s.paymentProvider.Name()
+
+	var (
+		transaction *record.TransactionRecord
+		duplicate   bool
+	)
+
+	errResp := withinTransaction(ctx, s.uow, s.logger, "Failed to process payment event", func(repos *repository.Repositories) *response.ErrorResponse {
+		found, err := repos.Transaction.FindByProviderChargeID(ctx, provider, event.ChargeID)
+		if err != nil {
+			s.logger.Error("Transaction not found for charge", zap.String("chargeID", event.ChargeID), zap.Error(err))
+			return &response.ErrorResponse{Status: "error", Message: "Transaction not found", Code: response.ErrCodeNotFound}
+		}
+		transaction = found
+
+		_, err = repos.Transaction.CreatePaymentEvent(ctx, &requests.CreatePaymentEventRequest{
+			Provider:      provider,
+			EventID:       event.ID,
+			EventType:     event.Type,
+			TransactionID: found.ID,
+			Payload:       req.Payload,
+		})
+		if err != nil {
+			if errors.Is(err, repository.ErrPaymentEventExists) {
+				duplicate = true
+				return nil
+			}
+
+			s.logger.Error("Failed to record payment event", zap.String("eventID", event.ID), zap.Error(err))
+			return &response.ErrorResponse{Status: "error", Message: "Failed to record payment event"}
+		}
+
+		status, ok := paymentEventStatuses[event.Type]
+		if !ok || !canTransitionPayment(found.PaymentStatus, status) {
+			s.logger.Debug("Payment event does not change the transaction",
+				zap.String("eventID", event.ID),
+				zap.String("type", event.Type),
+				zap.String("paymentStatus", found.PaymentStatus))
+			return nil
+		}
+
+		if status == record.PaymentStatusRefunded {
+			locked, err := repos.Transaction.FindByIdForUpdate(ctx, found.ID)
+			if err != nil {
+				s.logger.Error("Transaction not found", zap.Int("transactionID", found.ID), zap.Error(err))
+				return &response.ErrorResponse{Status: "error", Message: "Transaction not found", Code: response.ErrCodeNotFound}
+			}
+			transaction = locked
+
+			if !canTransitionPayment(locked.PaymentStatus, status) {
+				return nil
+			}
+
+			updated, errResp := s.refundByProvider(ctx, repos, locked)
+			if updated != nil {
+				transaction = updated
+			}
+
+			return errResp
+		}
+
+		updated, err := repos.Transaction.UpdateTransactionPayment(ctx, &requests.UpdateTransactionPaymentRequest{
+			TransactionID:    found.ID,
+			PaymentStatus:    status,
+			PaymentProvider:  found.PaymentProvider,
+			ProviderChargeID: found.ProviderChargeID,
+		})
+		if err != nil {
+			s.logger.Error("Failed to update transaction payment", zap.Int("transactionID", found.ID), zap.Error(err))
+			return &response.ErrorResponse{Status: "error", Message: "Failed to update transaction payment"}
+		}
+		transaction = updated
+
+		orderStatus, ok := paymentOrderStatuses[status]
+		if !ok {
+			return nil
 		}
-		req.ChangeAmount = req.Amount - totalAmount
-	} else if req.PaymentStatus == "failed" {
-		if req.Amount >= totalAmount {
-			s.logger.Error("Invalid amount for failed payment", zap.Int("amount", req.Amount), zap.Int("totalAmount", totalAmount))
-			return nil, &response.ErrorResponse{Status: "error", Message: "Invalid amount for failed payment"}
+
+		order, err := repos.Order.FindById(ctx, found.OrderID)
+		if err != nil {
+			s.logger.Error("Order not found", zap.Int("orderID", found.OrderID), zap.Error(err))
+			return &response.ErrorResponse{Status: "error", Message: "Order not found", Code: response.ErrCodeNotFound}
 		}
+
+		if !canTransitionOrder(order.Status, orderStatus) {
+			s.logger.Debug("Order status left unchanged by payment event",
+				zap.Int("orderID", order.ID),
+				zap.String("orderStatus", order.Status),
+				zap.String("paymentStatus", status))
+			return nil
+		}
+
+		_, errResp := changeOrderStatus(ctx, repos, s.logger, order.ID, orderStatus, "Payment event "+event.Type)
+		return errResp
+	})
+
+	if errResp != nil {
+		return nil, false, errResp
 	}
 
-	transaction, err := s.transactionRepository.CreateTransaction(req)
-	if err != nil {
-		s.logger.Error("Failed to create transaction", zap.Error(err))
-		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to create transaction"}
+	s.logger.Debug("Handled payment event",
+		zap.String("eventID", event.ID),
+		zap.Bool("duplicate", duplicate),
+		zap.Int("transactionID", transaction.ID),
+		zap.String("paymentStatus", transaction.PaymentStatus))
+
+	return s.mapping.ToTransactionResponse(transaction), duplicate, nil
+}
+
+// RefundTransaction refunds a paid transaction in three steps so the payment
+// provider is never called while database locks are held: the refund is
+// recorded as pending, the provider is asked to refund it under an
+// idempotency key derived from the refund, and the outcome is then applied.
+// A refund whose outcome the provider left unknown stays pending and is
+// retried under the same key by the next refund of the transaction, before
+// any new refund is taken; a charge.refunded event settles it as well.
+func (s *transactionService) RefundTransaction(ctx context.Context, req *requests.RefundTransactionRequest) (*response.TransactionResponse, *response.ErrorResponse) {
+	s.logger.Debug("Refunding transaction",
+		zap.Int("transactionID", req.TransactionID),
+		zap.Int("items", len(req.Items)))
+
+	var (
+		transaction *record.TransactionRecord
+		refund      *record.RefundRecord
+		lines       []refundLine
+	)
+
+	errResp := withinTransaction(ctx, s.uow, s.logger, "Failed to refund transaction", func(repos *repository.Repositories) *response.ErrorResponse {
+		found, err := repos.Transaction.FindByIdForUpdate(ctx, req.TransactionID)
+		if err != nil {
+			s.logger.Error("Transaction not found", zap.Int("transactionID", req.TransactionID), zap.Error(err))
+			return &response.ErrorResponse{Status: "error", Message: "Transaction not found", Code: response.ErrCodeNotFound}
+		}
+		transaction = found
+
+		if found.PaymentStatus != record.PaymentStatusPaid {
+			return &response.ErrorResponse{
+				Status:  "error",
+				Message: fmt.Sprintf("Transaction with payment status %s cannot be refunded", found.PaymentStatus),
+				Code:    response.ErrCodeInvalidStatusTransition,
+			}
+		}
+
+		pending, err := repos.Transaction.FindPendingRefunds(ctx, found.ID)
+		if err != nil {
+			s.logger.Error("Failed to fetch pending refunds", zap.Int("transactionID", found.ID), zap.Error(err))
+			return &response.ErrorResponse{Status: "error", Message: "Failed to fetch pending refunds"}
+		}
+
+		if len(pending) > 0 {
+			refund = pending[0]
+
+			s.logger.Debug("Retrying pending refund",
+				zap.Int("transactionID", found.ID),
+				zap.Int("refundID", refund.ID))
+
+			var errResp *response.ErrorResponse
+			lines, errResp = s.pendingRefundLines(ctx, repos, found, refund.ID)
+			return errResp
+		}
+
+		orderItems, err := repos.OrderItem.FindOrderItemByOrder(ctx, found.OrderID)
+		if err != nil {
+			s.logger.Error("Failed to fetch order items", zap.Int("orderID", found.OrderID), zap.Error(err))
+			return &response.ErrorResponse{Status: "error", Message: "Failed to fetch order items"}
+		}
+
+		refunded, err := repos.Transaction.FindRefundedQuantities(ctx, found.ID)
+		if err != nil {
+			s.logger.Error("Failed to fetch refunded quantities", zap.Int("transactionID", found.ID), zap.Error(err))
+			return &response.ErrorResponse{Status: "error", Message: "Failed to fetch refunded quantities"}
+		}
+
+		var errResp *response.ErrorResponse
+		lines, errResp = refundLines(orderItems, refunded, req.Items)
+		if errResp != nil {
+			return errResp
+		}
+
+		var amount int64
+		for _, line := range lines {
+			amount += line.amount
+			refunded[line.item.ID] += line.quantity
+		}
+
+		// The last refund returns whatever is left of the amount due, so
+		// shipping goes back with the final item.
+		remaining := found.AmountDue - found.RefundedAmount
+		if amount > remaining || fullyRefunded(orderItems, refunded) {
+			amount = remaining
+		}
+
+		if amount <= 0 {
+			return &response.ErrorResponse{Status: "error", Message: "Nothing left to refund", Code: response.ErrCodeValidation}
+		}
+
+		created, err := repos.Transaction.CreateRefund(ctx, &requests.CreateRefundRequest{
+			TransactionID: found.ID,
+			Amount:        amount,
+			Reason:        req.Reason,
+			Status:        payment.RefundStatusPending,
+		})
+		if err != nil {
+			s.logger.Error("Failed to create refund", zap.Int("transactionID", found.ID), zap.Error(err))
+			return &response.ErrorResponse{Status: "error", Message: "Failed to create refund"}
+		}
+		refund = created
+
+		// Pending refund items already count against the quantities left to
+		// refund, so a concurrent refund cannot claim them twice.
+		for _, line := range lines {
+			_, err := repos.Transaction.CreateRefundItem(ctx, &requests.CreateRefundItemRequest{
+				RefundID:    created.ID,
+				OrderItemID: line.item.ID,
+				Quantity:    line.quantity,
+				Amount:      line.amount,
+			})
+			if err != nil {
+				s.logger.Error("Failed to create refund item", zap.Int("orderItemID", line.item.ID), zap.Error(err))
+				return &response.ErrorResponse{Status: "error", Message: "Failed to create refund"}
+			}
+		}
+
+		return nil
+	})
+
+	if errResp != nil {
+		return nil, errResp
+	}
+
+	outcome := &requests.UpdateRefundStatusRequest{
+		RefundID: refund.ID,
+		Status:   payment.RefundStatusSucceeded,
+	}
+
+	if transaction.ProviderChargeID != "" {
+		providerRefund, err := s.paymentProvider.Refund(ctx, payment.RefundRequest{
+			ChargeID:       transaction.ProviderChargeID,
+			Amount:         refund.Amount,
+			IdempotencyKey: fmt.Sprintf("refund-%d", refund.ID),
+		})
+		if err != nil {
+			s.logger.Error("Payment provider refund failed",
+				zap.Int("transactionID", transaction.ID),
+				zap.Int("refundID", refund.ID),
+				zap.String("chargeID", transaction.ProviderChargeID),
+				zap.Error(err))
+
+			if !isRefundRejected(err) {
+				// The provider may still have refunded the charge, so the
+				// refund stays pending until it is retried under the same
+				// idempotency key.
+				return nil, &response.ErrorResponse{Status: "error", Message: "Refund is pending with the payment provider, retry to settle it"}
+			}
+
+			outcome.Status = payment.RefundStatusFailed
+		} else {
+			outcome.ProviderRefundID = providerRefund.ID
+			outcome.Status = providerRefund.Status
+		}
+	}
+
+	errResp = withinTransaction(ctx, s.uow, s.logger, "Failed to record refund outcome", func(repos *repository.Repositories) *response.ErrorResponse {
+		found, err := repos.Transaction.FindByIdForUpdate(ctx, transaction.ID)
+		if err != nil {
+			s.logger.Error("Transaction not found", zap.Int("transactionID", transaction.ID), zap.Error(err))
+			return &response.ErrorResponse{Status: "error", Message: "Transaction not found", Code: response.ErrCodeNotFound}
+		}
+		transaction = found
+
+		updated, errResp := s.settleRefund(ctx, repos, found, outcome, lines)
+		if updated != nil {
+			transaction = updated
+		}
+
+		return errResp
+	})
+
+	if errResp != nil {
+		return nil, errResp
 	}
 
+	if outcome.Status == payment.RefundStatusFailed {
+		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to refund payment"}
+	}
+
+	s.logger.Debug("Refunded transaction",
+		zap.Int("transactionID", transaction.ID),
+		zap.Int("refundID", refund.ID),
+		zap.String("refundStatus", outcome.Status),
+		zap.Int64("refundedAmount", transaction.RefundedAmount),
+		zap.String("paymentStatus", transaction.PaymentStatus))
+
 	return s.mapping.ToTransactionResponse(transaction), nil
 }
 
-func (s *transactionService) UpdateTransaction(req *requests.UpdateTransactionRequest) (*response.TransactionResponse, *response.ErrorResponse) {
-	s.logger.Debug("Updating transaction", zap.Int("transactionID", req.TransactionID))
+// settleRefund records the payment provider outcome of a pending refund of a
+// transaction the caller has locked. A succeeded refund restocks its lines and
+// counts towards the refunded amount; once the whole amount due is back with
+// the customer the transaction and its order are marked refunded. A refund
+// that is no longer pending was settled already and is left as it is.
+func (s *transactionService) settleRefund(ctx context.Context, repos *repository.Repositories, transaction *record.TransactionRecord, outcome *requests.UpdateRefundStatusRequest, lines []refundLine) (*record.TransactionRecord, *response.ErrorResponse) {
+	if _, err := repos.Transaction.UpdateRefundStatus(ctx, outcome); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.logger.Debug("Refund already settled", zap.Int("refundID", outcome.RefundID))
+			return transaction, nil
+		}
+
+		s.logger.Error("Failed to record refund outcome",
+			zap.Int("refundID", outcome.RefundID),
+			zap.String("status", outcome.Status),
+			zap.Error(err))
+		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to record refund outcome"}
+	}
 
-	_, err := s.transactionRepository.FindById(req.TransactionID)
+	if outcome.Status != payment.RefundStatusSucceeded {
+		return transaction, nil
+	}
+
+	for _, line := range lines {
+		if _, err := repos.Product.ReleaseProductStock(ctx, line.item.ProductID, line.quantity); err != nil {
+			s.logger.Error("Failed to restock product", zap.Int("productID", line.item.ProductID), zap.Error(err))
+			return nil, &response.ErrorResponse{Status: "error", Message: "Failed to restock refunded items"}
+		}
+	}
+
+	updated, err := repos.Transaction.UpdateRefundedAmount(ctx, transaction.ID)
 	if err != nil {
-		s.logger.Error("Transaction not found", zap.Int("transactionID", req.TransactionID), zap.Error(err))
-		return nil, &response.ErrorResponse{Status: "error", Message: "Transaction not found"}
+		s.logger.Error("Failed to update refunded amount", zap.Int("transactionID", transaction.ID), zap.Error(err))
+		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to update refunded amount"}
 	}
 
-	_, err = s.merchantRepository.FindById(req.MerchantID)
+	if updated.RefundedAmount < updated.AmountDue {
+		return updated, nil
+	}
 
+	updated, err = repos.Transaction.UpdateTransactionPayment(ctx, &requests.UpdateTransactionPaymentRequest{
+		TransactionID:    transaction.ID,
+		PaymentStatus:    record.PaymentStatusRefunded,
+		PaymentProvider:  transaction.PaymentProvider,
+		ProviderChargeID: transaction.ProviderChargeID,
+	})
 	if err != nil {
-		s.logger.Error("Merchant not found", zap.Int("merchantId", req.MerchantID), zap.Error(err))
-		return nil, &response.ErrorResponse{Status: "error", Message: "Merchant not found"}
+		s.logger.Error("Failed to update transaction payment", zap.Int("transactionID", transaction.ID), zap.Error(err))
+		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to update transaction payment"}
 	}
 
-	_, err = s.orderRepository.FindById(req.OrderID)
+	order, err := repos.Order.FindById(ctx, transaction.OrderID)
 	if err != nil {
-		s.logger.Error("Order not found", zap.Int("orderID", req.OrderID), zap.Error(err))
-		return nil, &response.ErrorResponse{Status: "error", Message: "Order not found"}
+		s.logger.Error("Order not found", zap.Int("orderID", transaction.OrderID), zap.Error(err))
+		return nil, &response.ErrorResponse{Status: "error", Message: "Order not found", Code: response.ErrCodeNotFound}
 	}
 
-	orderItems, err := s.orderItemRepository.FindOrderItemByOrder(req.OrderID)
+	if !canTransitionOrder(order.Status, record.OrderStatusRefunded) {
+		s.logger.Debug("Order status left unchanged by refund",
+			zap.Int("orderID", order.ID),
+			zap.String("orderStatus", order.Status))
+		return updated, nil
+	}
+
+	_, errResp := changeOrderStatus(ctx, repos, s.logger, order.ID, record.OrderStatusRefunded, "Transaction fully refunded")
+	return updated, errResp
+}
+
+// pendingRefundLines rebuilds the lines of a refund recorded earlier from its
+// refund items.
+func (s *transactionService) pendingRefundLines(ctx context.Context, repos *repository.Repositories, transaction *record.TransactionRecord, refund_id int) ([]refundLine, *response.ErrorResponse) {
+	orderItems, err := repos.OrderItem.FindOrderItemByOrder(ctx, transaction.OrderID)
 	if err != nil {
-		s.logger.Error("Failed to fetch order items", zap.Int("orderID", req.OrderID), zap.Error(err))
+		s.logger.Error("Failed to fetch order items", zap.Int("orderID", transaction.OrderID), zap.Error(err))
 		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to fetch order items"}
 	}
 
-	var totalAmount int
+	items, err := repos.Transaction.FindRefundItems(ctx, refund_id)
+	if err != nil {
+		s.logger.Error("Failed to fetch refund items", zap.Int("refundID", refund_id), zap.Error(err))
+		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to fetch refund items"}
+	}
+
+	byID := make(map[int]*record.OrderItemRecord, len(orderItems))
 	for _, item := range orderItems {
-		totalAmount += item.Price * item.Quantity
+		byID[item.ID] = item
+	}
+
+	lines := make([]refundLine, 0, len(items))
+	for _, item := range items {
+		orderItem, ok := byID[item.OrderItemID]
+		if !ok {
+			s.logger.Error("Refund item without order item", zap.Int("refundID", refund_id), zap.Int("orderItemID", item.OrderItemID))
+			return nil, &response.ErrorResponse{Status: "error", Message: "Failed to fetch refund items"}
+		}
+
+		lines = append(lines, refundLine{item: orderItem, quantity: item.Quantity, amount: item.Amount})
 	}
 
-	if req.PaymentStatus != "pending" && req.PaymentStatus != "paid" && req.PaymentStatus != "failed" {
-		s.logger.Error("Invalid payment status", zap.String("paymentStatus", req.PaymentStatus))
-		return nil, &response.ErrorResponse{Status: "error", Message: "Invalid payment status"}
+	return lines, nil
+}
+
+// refundByProvider books a charge the payment provider reports as fully
+// refunded, whether or not the refund was asked for here. Refunds still
+// pending are settled as succeeded and whatever is left is recorded as one
+// last refund, so every refunded item is restocked once.
+func (s *transactionService) refundByProvider(ctx context.Context, repos *repository.Repositories, transaction *record.TransactionRecord) (*record.TransactionRecord, *response.ErrorResponse) {
+	pending, err := repos.Transaction.FindPendingRefunds(ctx, transaction.ID)
+	if err != nil {
+		s.logger.Error("Failed to fetch pending refunds", zap.Int("transactionID", transaction.ID), zap.Error(err))
+		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to fetch pending refunds"}
 	}
 
-	if req.PaymentStatus == "paid" {
-		if req.Amount < totalAmount {
-			s.logger.Error("Insufficient payment amount", zap.Int("amount", req.Amount), zap.Int("totalAmount", totalAmount))
-			return nil, &response.ErrorResponse{Status: "error", Message: "Insufficient payment amount"}
+	for _, refund := range pending {
+		lines, errResp := s.pendingRefundLines(ctx, repos, transaction, refund.ID)
+		if errResp != nil {
+			return nil, errResp
 		}
-		req.ChangeAmount = req.Amount - totalAmount
-	} else if req.PaymentStatus == "failed" {
-		if req.Amount >= totalAmount {
-			s.logger.Error("Invalid amount for failed payment", zap.Int("amount", req.Amount), zap.Int("totalAmount", totalAmount))
-			return nil, &response.ErrorResponse{Status: "error", Message: "Invalid amount for failed payment"}
+
+		transaction, errResp = s.settleRefund(ctx, repos, transaction, &requests.UpdateRefundStatusRequest{
+			RefundID: refund.ID,
+			Status:   payment.RefundStatusSucceeded,
+		}, lines)
+		if errResp != nil {
+			return nil, errResp
 		}
 	}
 
-	transaction, err := s.transactionRepository.UpdateTransaction(req)
+	remaining := transaction.AmountDue - transaction.RefundedAmount
+	if remaining <= 0 {
+		return transaction, nil
+	}
+
+	orderItems, err := repos.OrderItem.FindOrderItemByOrder(ctx, transaction.OrderID)
+	if err != nil {
+		s.logger.Error("Failed to fetch order items", zap.Int("orderID", transaction.OrderID), zap.Error(err))
+		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to fetch order items"}
+	}
+
+	refunded, err := repos.Transaction.FindRefundedQuantities(ctx, transaction.ID)
+	if err != nil {
+		s.logger.Error("Failed to fetch refunded quantities", zap.Int("transactionID", transaction.ID), zap.Error(err))
+		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to fetch refunded quantities"}
+	}
+
+	// Only shipping may be left to refund, in which case there are no lines.
+	lines, _ := refundLines(orderItems, refunded, nil)
+
+	refund, err := repos.Transaction.CreateRefund(ctx, &requests.CreateRefundRequest{
+		TransactionID: transaction.ID,
+		Amount:        remaining,
+		Reason:        "Refunded by the payment provider",
+		Status:        payment.RefundStatusPending,
+	})
 	if err != nil {
-		s.logger.Error("Failed to update transaction", zap.Error(err))
-		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to update transaction"}
+		s.logger.Error("Failed to create refund", zap.Int("transactionID", transaction.ID), zap.Error(err))
+		return nil, &response.ErrorResponse{Status: "error", Message: "Failed to create refund"}
+	}
+
+	for _, line := range lines {
+		_, err := repos.Transaction.CreateRefundItem(ctx, &requests.CreateRefundItemRequest{
+			RefundID:    refund.ID,
+			OrderItemID: line.item.ID,
+			Quantity:    line.quantity,
+			Amount:      line.amount,
+		})
+		if err != nil {
+			s.logger.Error("Failed to create refund item", zap.Int("orderItemID", line.item.ID), zap.Error(err))
+			return nil, &response.ErrorResponse{Status: "error", Message: "Failed to create refund"}
+		}
+	}
+
+	return s.settleRefund(ctx, repos, transaction, &requests.UpdateRefundStatusRequest{
+		RefundID: refund.ID,
+		Status:   payment.RefundStatusSucceeded,
+	}, lines)
+}
+
+// isRefundRejected reports whether the payment provider refused a refund
+// outright, as opposed to failing in a way that leaves its outcome unknown.
+func isRefundRejected(err error) bool {
+	return errors.Is(err, payment.ErrChargeNotFound) ||
+		errors.Is(err, payment.ErrChargeNotRefundable) ||
+		errors.Is(err, payment.ErrRefundExceedsCharge) ||
+		errors.Is(err, payment.ErrInvalidAmount)
+}
+
+// fullyRefunded reports whether every unit of every order item has been
+// refunded.
+func fullyRefunded(orderItems []*record.OrderItemRecord, refunded map[int]int) bool {
+	for _, item := range orderItems {
+		if refunded[item.ID] < item.Quantity {
+			return false
+		}
+	}
+
+	return true
+}
+
+// refundLine is the quantity of one order item being refunded.
+type refundLine struct {
+	item     *record.OrderItemRecord
+	quantity int
+	amount   int64
+}
+
+// refundLines resolves the requested refund items against the order items
+// and what has been refunded already. No requested items means refunding
+// every remaining unit.
+func refundLines(orderItems []*record.OrderItemRecord, refunded map[int]int, items []requests.RefundItemRequest) ([]refundLine, *response.ErrorResponse) {
+	byID := make(map[int]*record.OrderItemRecord, len(orderItems))
+	for _, item := range orderItems {
+		byID[item.ID] = item
+	}
+
+	var lines []refundLine
+
+	if len(items) == 0 {
+		for _, item := range orderItems {
+			if remaining := item.Quantity - refunded[item.ID]; remaining > 0 {
+				lines = append(lines, refundLine{item: item, quantity: remaining, amount: item.Price * int64(remaining)})
+			}
+		}
+	}
+
+	requested := make(map[int]int, len(items))
+	for _, req := range items {
+		item, ok := byID[req.OrderItemID]
+		if !ok {
+			return nil, &response.ErrorResponse{
+				Status:  "error",
+				Message: fmt.Sprintf("Order item %d does not belong to this transaction", req.OrderItemID),
+				Code:    response.ErrCodeValidation,
+			}
+		}
+
+		requested[item.ID] += req.Quantity
+		if requested[item.ID] > item.Quantity-refunded[item.ID] {
+			return nil, &response.ErrorResponse{
+				Status:  "error",
+				Message: fmt.Sprintf("Refund quantity for order item %d exceeds the quantity left to refund", item.ID),
+				Code:    response.ErrCodeValidation,
+			}
+		}
+
+		lines = append(lines, refundLine{item: item, quantity: req.Quantity, amount: item.Price * int64(req.Quantity)})
+	}
+
+	if len(lines) == 0 {
+		return nil, &response.ErrorResponse{Status: "error", Message: "Nothing left to refund", Code: response.ErrCodeValidation}
+	}
+
+	return lines, nil
+}
+
+func canTransitionPayment(from string, to string) bool {
+	for _, next := range paymentStatusTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// UpdateTransaction amends the payment details of a transaction that is still
+// pending. The payment status itself only moves on payment provider outcomes.
+func (s *transactionService) UpdateTransaction(ctx context.Context, req *requests.UpdateTransactionRequest) (*response.TransactionResponse, *response.ErrorResponse) {
+	s.logger.Debug("Updating transaction", zap.Int("transactionID", req.TransactionID))
+
+	var transaction *record.TransactionRecord
+
+	errResp := withinTransaction(ctx, s.uow, s.logger, "Failed to update transaction", func(repos *repository.Repositories) *response.ErrorResponse {
+		found, err := repos.Transaction.FindByIdForUpdate(ctx, req.TransactionID)
+		if err != nil {
+			s.logger.Error("Transaction not found", zap.Int("transactionID", req.TransactionID), zap.Error(err))
+			return &response.ErrorResponse{Status: "error", Message: "Transaction not found", Code: response.ErrCodeNotFound}
+		}
+
+		if found.PaymentStatus != record.PaymentStatusPending {
+			return &response.ErrorResponse{
+				Status:  "error",
+				Message: fmt.Sprintf("Transaction with payment status %s cannot be updated", found.PaymentStatus),
+				Code:    response.ErrCodeInvalidStatusTransition,
+			}
+		}
+
+		if req.OrderID != found.OrderID || req.MerchantID != found.MerchantID {
+			return &response.ErrorResponse{Status: "error", Message: "Transaction order and merchant cannot be changed", Code: response.ErrCodeValidation}
+		}
+
+		order, err := repos.Order.FindByIdForUpdate(ctx, found.OrderID)
+		if err != nil {
+			s.logger.Error("Order not found", zap.Int("orderID", found.OrderID), zap.Error(err))
+			return &response.ErrorResponse{Status: "error", Message: "Order not found", Code: response.ErrCodeNotFound}
+		}
+
+		if order.MerchantID != found.MerchantID {
+			return &response.ErrorResponse{Status: "error", Message: "Order does not belong to this merchant", Code: response.ErrCodeValidation}
+		}
+
+		if order.Status != record.OrderStatusPending {
+			return &response.ErrorResponse{
+				Status:  "error",
+				Message: fmt.Sprintf("Order with status %s cannot be paid", order.Status),
+				Code:    response.ErrCodeInvalidStatusTransition,
+			}
+		}
+
+		if req.Amount < order.TotalPrice {
+			s.logger.Error("Insufficient payment amount", zap.Int64("amount", req.Amount), zap.Int64("amountDue", order.TotalPrice))
+			return &response.ErrorResponse{Status: "error", Message: "Insufficient payment amount", Code: response.ErrCodeValidation}
+		}
+		req.AmountDue = order.TotalPrice
+		req.ChangeAmount = req.Amount - order.TotalPrice
+
+		updated, err := repos.Transaction.UpdateTransaction(ctx, req)
+		if err != nil {
+			s.logger.Error("Failed to update transaction", zap.Error(err))
+			return &response.ErrorResponse{Status: "error", Message: "Failed to update transaction"}
+		}
+		transaction = updated
+
+		return nil
+	})
+	if errResp != nil {
+		return nil, errResp
 	}
 
 	return s.mapping.ToTransactionResponse(transaction), nil
 }
 
-func (s *transactionService) TrashedTransaction(transaction_id int) (*response.TransactionResponseDeleteAt, *response.ErrorResponse) {
+func (s *transactionService) TrashedTransaction(ctx context.Context, transaction_id int) (*response.TransactionResponseDeleteAt, *response.ErrorResponse) {
 	s.logger.Debug("Trashing transaction", zap.Int("transaction_id", transaction_id))
 
-	res, err := s.transactionRepository.TrashTransaction(transaction_id)
+	res, err := s.transactionRepository.TrashTransaction(ctx, transaction_id)
 
 	if err != nil {
 		s.logger.Error("Failed to trash transaction", zap.Error(err), zap.Int("transaction_id", transaction_id))
@@ -324,10 +1106,10 @@ func (s *transactionService) TrashedTransaction(transaction_id int) (*response.T
 	return so, nil
 }
 
-func (s *transactionService) RestoreTransaction(transaction_id int) (*response.TransactionResponseDeleteAt, *response.ErrorResponse) {
+func (s *transactionService) RestoreTransaction(ctx context.Context, transaction_id int) (*response.TransactionResponseDeleteAt, *response.ErrorResponse) {
 	s.logger.Debug("Restoring transaction", zap.Int("transaction_id", transaction_id))
 
-	res, err := s.transactionRepository.RestoreTransaction(transaction_id)
+	res, err := s.transactionRepository.RestoreTransaction(ctx, transaction_id)
 
 	if err != nil {
 		s.logger.Error("Failed to restore transaction", zap.Error(err), zap.Int("transaction_id", transaction_id))
@@ -345,10 +1127,10 @@ func (s *transactionService) RestoreTransaction(transaction_id int) (*response.T
 	return so, nil
 }
 
-func (s *transactionService) DeleteTransactionPermanently(transactionID int) (bool, *response.ErrorResponse) {
+func (s *transactionService) DeleteTransactionPermanently(ctx context.Context, transactionID int) (bool, *response.ErrorResponse) {
 	s.logger.Debug("Permanently deleting transaction", zap.Int("transactionID", transactionID))
 
-	success, err := s.transactionRepository.DeleteTransactionPermanently(transactionID)
+	success, err := s.transactionRepository.DeleteTransactionPermanently(ctx, transactionID)
 	if err != nil {
 		s.logger.Error("Failed to permanently delete transaction", zap.Error(err))
 		return false, &response.ErrorResponse{Status: "error", Message: "Failed to permanently delete transaction"}
@@ -357,10 +1139,10 @@ func (s *transactionService) DeleteTransactionPermanently(transactionID int) (bo
 	return success, nil
 }
 
-func (s *transactionService) RestoreAllTransactions() (bool, *response.ErrorResponse) {
+func (s *transactionService) RestoreAllTransactions(ctx context.Context) (bool, *response.ErrorResponse) {
 	s.logger.Debug("Restoring all trashed transactions")
 
-	success, err := s.transactionRepository.RestoreAllTransactions()
+	success, err := s.transactionRepository.RestoreAllTransactions(ctx)
 	if err != nil {
 		s.logger.Error("Failed to restore all transactions", zap.Error(err))
 		return false, &response.ErrorResponse{Status: "error", Message: "Failed to restore all transactions"}
@@ -369,10 +1151,10 @@ func (s *transactionService) RestoreAllTransactions() (bool, *response.ErrorResp
 	return success, nil
 }
 
-func (s *transactionService) DeleteAllTransactionPermanent() (bool, *response.ErrorResponse) {
+func (s *transactionService) DeleteAllTransactionPermanent(ctx context.Context) (bool, *response.ErrorResponse) {
 	s.logger.Debug("Permanently deleting all transactions")
 
-	success, err := s.transactionRepository.DeleteAllTransactionPermanent()
+	success, err := s.transactionRepository.DeleteAllTransactionPermanent(ctx)
 	if err != nil {
 		s.logger.Error("Failed to permanently delete all transactions", zap.Error(err))
 		return false, &response.ErrorResponse{Status: "error", Message: "Failed to permanently delete all transactions"}
@@ -0,0 +1,438 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"ecommerce/internal/domain/requests"
+	"ecommerce/internal/domain/response"
+	"ecommerce/internal/service"
+	"ecommerce/pkg/database/testdb"
+	"ecommerce/pkg/payment"
+)
+
+// payTestOrder pays the whole order through the payment provider.
+func payTestOrder(t *testing.T, conn *sql.DB, services *service.Service, orderID, merchantID int) *response.TransactionResponse {
+	t.Helper()
+
+	var total int64
+	if err := conn.QueryRow(`SELECT total_price FROM orders WHERE order_id = $1`, orderID).Scan(&total); err != nil {
+		t.Fatalf("read order total: %v", err)
+	}
+
+	transaction, errResp := services.Transaction.CreateTransaction(context.Background(), &requests.CreateTransactionRequest{
+		OrderID:       orderID,
+		MerchantID:    merchantID,
+		PaymentMethod: "card",
+		Amount:        total,
+	})
+	if errResp != nil {
+		t.Fatalf("create transaction: %s", errResp.Message)
+	}
+
+	return transaction
+}
+
+// signedPaymentEvent builds a webhook request for an event of the given type
+// on chargeID, signed with secret.
+func signedPaymentEvent(t *testing.T, secret []byte, eventType, chargeID string) *requests.PaymentEventRequest {
+	t.Helper()
+
+	payload, err := json.Marshal(payment.Event{ID: testdb.Unique("evt"), Type: eventType, ChargeID: chargeID})
+	if err != nil {
+		t.Fatalf("encode event: %v", err)
+	}
+
+	return &requests.PaymentEventRequest{Payload: payload, Signature: payment.Sign(secret, payload)}
+}
+
+// lostRefundProvider drops the response of the first failures refunds the
+// fake provider applies, as a timeout would, leaving their outcome unknown.
+type lostRefundProvider struct {
+	*payment.FakeProvider
+	failures int
+}
+
+func (p *lostRefundProvider) Refund(ctx context.Context, req payment.RefundRequest) (*payment.Refund, error) {
+	refund, err := p.FakeProvider.Refund(ctx, req)
+	if err == nil && p.failures > 0 {
+		p.failures--
+		return nil, errors.New("payment provider timed out")
+	}
+
+	return refund, err
+}
+
+// insertPendingTransaction records a pending payment of the whole order,
+// as CreateTransaction does before the provider answers.
+func insertPendingTransaction(t *testing.T, conn *sql.DB, orderID, merchantID int) int {
+	t.Helper()
+
+	var transactionID int
+
+	err := conn.QueryRow(
+		`INSERT INTO transactions (order_id, merchant_id, payment_method, amount, amount_due, change_amount, payment_status)
+		SELECT order_id, merchant_id, 'card', total_price, total_price, 0, 'pending' FROM orders WHERE order_id = $1 AND merchant_id = $2
+		RETURNING transaction_id`,
+		orderID, merchantID).Scan(&transactionID)
+	if err != nil {
+		t.Fatalf("insert transaction: %v", err)
+	}
+
+	return transactionID
+}
+
+func TestUpdateTransactionKeepsOrderAndMerchant(t *testing.T) {
+	conn := testdb.Open(t)
+	services := newTestService(t, conn, nil)
+
+	tests := []struct {
+		name          string
+		otherOrder    bool
+		otherMerchant bool
+		orderStatus   string
+		wantCode      string
+	}{
+		{name: "same order and merchant"},
+		{name: "another order", otherOrder: true, wantCode: response.ErrCodeValidation},
+		{name: "another merchant", otherMerchant: true, wantCode: response.ErrCodeValidation},
+		{name: "order no longer pending", orderStatus: "cancelled", wantCode: response.ErrCodeInvalidStatusTransition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, merchantID := testdb.SeedMerchant(t, conn)
+			productID := testdb.SeedProduct(t, conn, merchantID, 1000, 10)
+			buyerID := testdb.SeedUser(t, conn)
+
+			orderID := placeTestOrder(t, conn, services, buyerID, merchantID, productID, 1).orderID
+			transactionID := insertPendingTransaction(t, conn, orderID, merchantID)
+
+			req := &requests.UpdateTransactionRequest{
+				TransactionID: transactionID,
+				OrderID:       orderID,
+				MerchantID:    merchantID,
+				PaymentMethod: "card",
+				Amount:        5000,
+			}
+
+			if tt.otherOrder {
+				req.OrderID = placeTestOrder(t, conn, services, buyerID, merchantID, productID, 1).orderID
+			}
+
+			if tt.otherMerchant {
+				_, otherMerchantID := testdb.SeedMerchant(t, conn)
+				otherProductID := testdb.SeedProduct(t, conn, otherMerchantID, 1000, 10)
+				req.MerchantID = otherMerchantID
+				req.OrderID = placeTestOrder(t, conn, services, buyerID, otherMerchantID, otherProductID, 1).orderID
+			}
+
+			if tt.orderStatus != "" {
+				if _, err := conn.Exec(`UPDATE orders SET status = $2 WHERE order_id = $1`, orderID, tt.orderStatus); err != nil {
+					t.Fatalf("set order status: %v", err)
+				}
+			}
+
+			_, errResp := services.Transaction.UpdateTransaction(context.Background(), req)
+
+			switch {
+			case tt.wantCode == "" && errResp != nil:
+				t.Fatalf("update transaction: %s", errResp.Message)
+			case tt.wantCode != "" && (errResp == nil || errResp.Code != tt.wantCode):
+				t.Fatalf("update transaction = %+v, want code %s", errResp, tt.wantCode)
+			}
+
+			var storedOrderID, storedMerchantID int
+			var amount int64
+
+			err := conn.QueryRow(`SELECT order_id, merchant_id, amount FROM transactions WHERE transaction_id = $1`, transactionID).
+				Scan(&storedOrderID, &storedMerchantID, &amount)
+			if err != nil {
+				t.Fatalf("read transaction: %v", err)
+			}
+
+			if storedOrderID != orderID || storedMerchantID != merchantID {
+				t.Errorf("transaction pays order %d of merchant %d, want order %d of merchant %d", storedOrderID, storedMerchantID, orderID, merchantID)
+			}
+
+			if tt.wantCode == "" && amount != req.Amount {
+				t.Errorf("amount = %d, want %d", amount, req.Amount)
+			}
+		})
+	}
+}
+
+func TestRefundedPaymentEventBooksRefunds(t *testing.T) {
+	conn := testdb.Open(t)
+	secret := []byte("webhook-secret")
+	provider := payment.NewFakeProvider()
+	provider.WebhookSecret = secret
+	services := newTestService(t, conn, provider)
+
+	tests := []struct {
+		name           string
+		partialRefund  bool
+		pendingRefund  bool
+		wantRefundRows int
+	}{
+		{name: "refunded at the provider only", wantRefundRows: 1},
+		{name: "after a partial refund", partialRefund: true, wantRefundRows: 2},
+		{name: "settles a pending refund", pendingRefund: true, wantRefundRows: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, merchantID := testdb.SeedMerchant(t, conn)
+			productID := testdb.SeedProduct(t, conn, merchantID, 1000, 10)
+			buyerID := testdb.SeedUser(t, conn)
+
+			placed := placeTestOrder(t, conn, services, buyerID, merchantID, productID, 2)
+			transaction := payTestOrder(t, conn, services, placed.orderID, merchantID)
+
+			var chargeID string
+			if err := conn.QueryRow(`SELECT provider_charge_id FROM transactions WHERE transaction_id = $1`, transaction.ID).Scan(&chargeID); err != nil {
+				t.Fatalf("read charge: %v", err)
+			}
+
+			if tt.partialRefund {
+				_, errResp := services.Transaction.RefundTransaction(context.Background(), &requests.RefundTransactionRequest{
+					TransactionID: transaction.ID,
+					Items:         []requests.RefundItemRequest{{OrderItemID: placed.itemID, Quantity: 1}},
+				})
+				if errResp != nil {
+					t.Fatalf("refund transaction: %s", errResp.Message)
+				}
+			}
+
+			if tt.pendingRefund {
+				var refundID int
+				err := conn.QueryRow(`INSERT INTO refunds (transaction_id, amount, status) VALUES ($1, 1000, 'pending') RETURNING refund_id`, transaction.ID).Scan(&refundID)
+				if err != nil {
+					t.Fatalf("insert refund: %v", err)
+				}
+
+				if _, err := conn.Exec(`INSERT INTO refund_items (refund_id, order_item_id, quantity, amount) VALUES ($1, $2, 1, 1000)`, refundID, placed.itemID); err != nil {
+					t.Fatalf("insert refund item: %v", err)
+				}
+			}
+
+			event := signedPaymentEvent(t, secret, payment.EventChargeRefunded, chargeID)
+			if _, _, errResp := services.Transaction.HandlePaymentEvent(context.Background(), event); errResp != nil {
+				t.Fatalf("handle payment event: %s", errResp.Message)
+			}
+
+			if stock := testdb.ProductStock(t, conn, productID); stock != 10 {
+				t.Errorf("count_in_stock = %d, want 10", stock)
+			}
+
+			var paymentStatus, orderStatus string
+			var amountDue, refundedAmount int64
+
+			err := conn.QueryRow(
+				`SELECT t.payment_status, t.amount_due, t.refunded_amount, o.status
+				FROM transactions t JOIN orders o ON o.order_id = t.order_id WHERE t.transaction_id = $1`,
+				transaction.ID).Scan(&paymentStatus, &amountDue, &refundedAmount, &orderStatus)
+			if err != nil {
+				t.Fatalf("read transaction: %v", err)
+			}
+
+			if paymentStatus != "refunded" || orderStatus != "refunded" {
+				t.Errorf("payment, order status = %s, %s, want refunded, refunded", paymentStatus, orderStatus)
+			}
+
+			if refundedAmount != amountDue {
+				t.Errorf("refunded_amount = %d, want %d", refundedAmount, amountDue)
+			}
+
+			var refunds, pending int
+			err = conn.QueryRow(`SELECT COUNT(*), COUNT(*) FILTER (WHERE status = 'pending') FROM refunds WHERE transaction_id = $1`, transaction.ID).
+				Scan(&refunds, &pending)
+			if err != nil {
+				t.Fatalf("read refunds: %v", err)
+			}
+
+			if refunds != tt.wantRefundRows || pending != 0 {
+				t.Errorf("refunds, pending = %d, %d, want %d, 0", refunds, pending, tt.wantRefundRows)
+			}
+		})
+	}
+}
+
+func TestRefundTransactionRetriesPendingRefund(t *testing.T) {
+	conn := testdb.Open(t)
+	provider := &lostRefundProvider{FakeProvider: payment.NewFakeProvider()}
+	services := newTestService(t, conn, provider)
+
+	tests := []struct {
+		name       string
+		retryItems bool
+	}{
+		{name: "same refund retried", retryItems: true},
+		{name: "full refund asked while one is pending"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, merchantID := testdb.SeedMerchant(t, conn)
+			productID := testdb.SeedProduct(t, conn, merchantID, 1000, 10)
+			buyerID := testdb.SeedUser(t, conn)
+
+			placed := placeTestOrder(t, conn, services, buyerID, merchantID, productID, 2)
+			transaction := payTestOrder(t, conn, services, placed.orderID, merchantID)
+
+			items := []requests.RefundItemRequest{{OrderItemID: placed.itemID, Quantity: 1}}
+			provider.failures = 1
+
+			_, errResp := services.Transaction.RefundTransaction(context.Background(), &requests.RefundTransactionRequest{
+				TransactionID: transaction.ID,
+				Items:         items,
+			})
+			if errResp == nil {
+				t.Fatal("refund with a lost provider response succeeded")
+			}
+
+			if stock := testdb.ProductStock(t, conn, productID); stock != 8 {
+				t.Errorf("count_in_stock while pending = %d, want 8", stock)
+			}
+
+			retry := &requests.RefundTransactionRequest{TransactionID: transaction.ID}
+			if tt.retryItems {
+				retry.Items = items
+			}
+
+			refunded, errResp := services.Transaction.RefundTransaction(context.Background(), retry)
+			if errResp != nil {
+				t.Fatalf("retry refund: %s", errResp.Message)
+			}
+
+			if refunded.RefundedAmount.Amount != 1000 {
+				t.Errorf("refunded_amount = %d, want 1000", refunded.RefundedAmount.Amount)
+			}
+
+			if stock := testdb.ProductStock(t, conn, productID); stock != 9 {
+				t.Errorf("count_in_stock = %d, want 9", stock)
+			}
+
+			var refunds, succeeded int
+			err := conn.QueryRow(`SELECT COUNT(*), COUNT(*) FILTER (WHERE status = 'succeeded') FROM refunds WHERE transaction_id = $1`, transaction.ID).
+				Scan(&refunds, &succeeded)
+			if err != nil {
+				t.Fatalf("read refunds: %v", err)
+			}
+
+			if refunds != 1 || succeeded != 1 {
+				t.Errorf("refunds, succeeded = %d, %d, want 1, 1", refunds, succeeded)
+			}
+
+			var chargeID string
+			if err := conn.QueryRow(`SELECT provider_charge_id FROM transactions WHERE transaction_id = $1`, transaction.ID).Scan(&chargeID); err != nil {
+				t.Fatalf("read charge: %v", err)
+			}
+
+			charge, err := provider.GetCharge(context.Background(), chargeID)
+			if err != nil {
+				t.Fatalf("get charge: %v", err)
+			}
+
+			if charge.AmountRefunded != 1000 {
+				t.Errorf("provider refunded %d, want 1000", charge.AmountRefunded)
+			}
+		})
+	}
+}
+
+func TestRefundTransaction(t *testing.T) {
+	conn := testdb.Open(t)
+	services := newTestService(t, conn, nil)
+
+	tests := []struct {
+		name              string
+		quantity          int
+		otherItem         bool
+		unpaid            bool
+		unknownCharge     bool
+		wantCode          string
+		wantError         bool
+		wantRefunded      int64
+		wantPaymentStatus string
+		wantOrderStatus   string
+		wantStock         int
+	}{
+		{name: "partial refund", quantity: 1, wantRefunded: 1000, wantPaymentStatus: "paid", wantOrderStatus: "paid", wantStock: 9},
+		{name: "full refund returns shipping", wantRefunded: 2500, wantPaymentStatus: "refunded", wantOrderStatus: "refunded", wantStock: 10},
+		{name: "more than ordered", quantity: 3, wantCode: response.ErrCodeValidation, wantPaymentStatus: "paid", wantOrderStatus: "paid", wantStock: 8},
+		{name: "item of another order", quantity: 1, otherItem: true, wantCode: response.ErrCodeValidation, wantPaymentStatus: "paid", wantOrderStatus: "paid", wantStock: 7},
+		{name: "transaction not paid", unpaid: true, wantCode: response.ErrCodeInvalidStatusTransition, wantPaymentStatus: "pending", wantOrderStatus: "pending", wantStock: 8},
+		{name: "rejected by the provider", unknownCharge: true, wantError: true, wantPaymentStatus: "paid", wantOrderStatus: "paid", wantStock: 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, merchantID := testdb.SeedMerchant(t, conn)
+			productID := testdb.SeedProduct(t, conn, merchantID, 1000, 10)
+			buyerID := testdb.SeedUser(t, conn)
+
+			placed := placeTestOrder(t, conn, services, buyerID, merchantID, productID, 2)
+
+			var transactionID int
+			if tt.unpaid {
+				transactionID = insertPendingTransaction(t, conn, placed.orderID, merchantID)
+			} else {
+				transactionID = payTestOrder(t, conn, services, placed.orderID, merchantID).ID
+			}
+
+			if tt.unknownCharge {
+				if _, err := conn.Exec(`UPDATE transactions SET provider_charge_id = 'ch_unknown' WHERE transaction_id = $1`, transactionID); err != nil {
+					t.Fatalf("set charge: %v", err)
+				}
+			}
+
+			req := &requests.RefundTransactionRequest{TransactionID: transactionID, Reason: tt.name}
+			if tt.quantity > 0 {
+				itemID := placed.itemID
+				if tt.otherItem {
+					itemID = placeTestOrder(t, conn, services, buyerID, merchantID, productID, 1).itemID
+				}
+
+				req.Items = []requests.RefundItemRequest{{OrderItemID: itemID, Quantity: tt.quantity}}
+			}
+
+			_, errResp := services.Transaction.RefundTransaction(context.Background(), req)
+
+			switch {
+			case tt.wantCode == "" && !tt.wantError && errResp != nil:
+				t.Fatalf("refund transaction: %s", errResp.Message)
+			case tt.wantCode != "" && (errResp == nil || errResp.Code != tt.wantCode):
+				t.Fatalf("refund transaction = %+v, want code %s", errResp, tt.wantCode)
+			case tt.wantError && errResp == nil:
+				t.Fatal("refund transaction succeeded, want an error")
+			}
+
+			var paymentStatus, orderStatus string
+			var refunded int64
+
+			err := conn.QueryRow(
+				`SELECT t.payment_status, t.refunded_amount, o.status
+				FROM transactions t JOIN orders o ON o.order_id = t.order_id WHERE t.transaction_id = $1`,
+				transactionID).Scan(&paymentStatus, &refunded, &orderStatus)
+			if err != nil {
+				t.Fatalf("read transaction: %v", err)
+			}
+
+			if refunded != tt.wantRefunded {
+				t.Errorf("refunded_amount = %d, want %d", refunded, tt.wantRefunded)
+			}
+
+			if paymentStatus != tt.wantPaymentStatus || orderStatus != tt.wantOrderStatus {
+				t.Errorf("payment, order status = %s, %s, want %s, %s", paymentStatus, orderStatus, tt.wantPaymentStatus, tt.wantOrderStatus)
+			}
+
+			if stock := testdb.ProductStock(t, conn, productID); stock != tt.wantStock {
+				t.Errorf("count_in_stock = %d, want %d", stock, tt.wantStock)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"ecommerce/internal/domain/response"
+	"ecommerce/internal/repository"
+	"ecommerce/pkg/logger"
+	"errors"
+
+	"go.uber.org/zap"
+)
+
+// withinTransaction runs fn inside uow and rolls back whenever fn reports an
+// error response. Failures of the transaction itself (begin/commit) are logged
+// and surfaced as a generic error response carrying fallbackMessage.
+func withinTransaction(
+	ctx context.Context,
+	uow repository.UnitOfWork,
+	logger logger.LoggerInterface,
+	fallbackMessage string,
+	fn func(repos *repository.Repositories) *response.ErrorResponse,
+) *response.ErrorResponse {
+	var errResp *response.ErrorResponse
+
+	err := uow.WithinTransaction(ctx, func(repos *repository.Repositories) error {
+		errResp = fn(repos)
+		if errResp != nil {
+			return errors.New(errResp.Message)
+		}
+
+		return nil
+	})
+
+	if errResp != nil {
+		return errResp
+	}
+
+	if err != nil {
+		logger.Error("Transaction failed", zap.Error(err))
+		return &response.ErrorResponse{Status: "error", Message: fallbackMessage}
+	}
+
+	return nil
+}
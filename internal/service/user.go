@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"database/sql"
 	"ecommerce/internal/domain/requests"
 	"ecommerce/internal/domain/response"
@@ -18,6 +19,7 @@ type userService struct {
 	logger         logger.LoggerInterface
 	mapping        response_service.UserResponseMapper
 	hashing        hash.HashPassword
+	revocation     TokenRevocationService
 }
 
 func NewUserService(
@@ -25,16 +27,18 @@ func NewUserService(
 	logger logger.LoggerInterface,
 	mapper response_service.UserResponseMapper,
 	hashing hash.HashPassword,
+	revocation TokenRevocationService,
 ) *userService {
 	return &userService{
 		userRepository: userRepository,
 		logger:         logger,
 		mapping:        mapper,
 		hashing:        hashing,
+		revocation:     revocation,
 	}
 }
 
-func (s *userService) FindAll(page int, pageSize int, search string) ([]*response.UserResponse, int, *response.ErrorResponse) {
+func (s *userService) FindAll(ctx context.Context, page int, pageSize int, search string) ([]*response.UserResponse, int, *response.ErrorResponse) {
 	s.logger.Debug("Fetching users",
 		zap.Int("page", page),
 		zap.Int("pageSize", pageSize),
@@ -48,7 +52,7 @@ func (s *userService) FindAll(page int, pageSize int, search string) ([]*respons
 		pageSize = 10
 	}
 
-	users, totalRecords, err := s.userRepository.FindAllUsers(search, page, pageSize)
+	users, totalRecords, err := s.userRepository.FindAllUsers(ctx, search, page, pageSize)
 
 	if err != nil {
 		s.logger.Error("Failed to fetch user",
@@ -73,16 +77,17 @@ func (s *userService) FindAll(page int, pageSize int, search string) ([]*respons
 	return userResponses, int(totalRecords), nil
 }
 
-func (s *userService) FindByID(id int) (*response.UserResponse, *response.ErrorResponse) {
+func (s *userService) FindByID(ctx context.Context, id int) (*response.UserResponse, *response.ErrorResponse) {
 	s.logger.Debug("Fetching user by id", zap.Int("user_id", id))
 
-	user, err := s.userRepository.FindById(id)
+	user, err := s.userRepository.FindById(ctx, id)
 
 	if err != nil {
 		s.logger.Error("failed to find user by ID", zap.Error(err))
 		return nil, &response.ErrorResponse{
 			Status:  "error",
 			Message: "User not found",
+			Code:    response.ErrCodeNotFound,
 		}
 	}
 
@@ -93,7 +98,7 @@ func (s *userService) FindByID(id int) (*response.UserResponse, *response.ErrorR
 	return so, nil
 }
 
-func (s *userService) FindByActive(page int, pageSize int, search string) ([]*response.UserResponseDeleteAt, int, *response.ErrorResponse) {
+func (s *userService) FindByActive(ctx context.Context, page int, pageSize int, search string) ([]*response.UserResponseDeleteAt, int, *response.ErrorResponse) {
 	s.logger.Debug("Fetching active user",
 		zap.Int("page", page),
 		zap.Int("pageSize", pageSize),
@@ -107,7 +112,7 @@ func (s *userService) FindByActive(page int, pageSize int, search string) ([]*re
 		pageSize = 10
 	}
 
-	users, totalRecords, err := s.userRepository.FindByActive(search, page, pageSize)
+	users, totalRecords, err := s.userRepository.FindByActive(ctx, search, page, pageSize)
 
 	if err != nil {
 		s.logger.Error("Failed to fetch active user",
@@ -132,7 +137,7 @@ func (s *userService) FindByActive(page int, pageSize int, search string) ([]*re
 	return so, totalRecords, nil
 }
 
-func (s *userService) FindByTrashed(page int, pageSize int, search string) ([]*response.UserResponseDeleteAt, int, *response.ErrorResponse) {
+func (s *userService) FindByTrashed(ctx context.Context, page int, pageSize int, search string) ([]*response.UserResponseDeleteAt, int, *response.ErrorResponse) {
 	s.logger.Debug("Fetching trashed user",
 		zap.Int("page", page),
 		zap.Int("pageSize", pageSize),
@@ -146,7 +151,7 @@ func (s *userService) FindByTrashed(page int, pageSize int, search string) ([]*r
 		pageSize = 10
 	}
 
-	users, totalRecords, err := s.userRepository.FindByTrashed(search, page, pageSize)
+	users, totalRecords, err := s.userRepository.FindByTrashed(ctx, search, page, pageSize)
 
 	if err != nil {
 		s.logger.Error("Failed to find trashed users", zap.Error(err))
@@ -167,10 +172,10 @@ func (s *userService) FindByTrashed(page int, pageSize int, search string) ([]*r
 	return so, totalRecords, nil
 }
 
-func (s *userService) CreateUser(request *requests.CreateUserRequest) (*response.UserResponse, *response.ErrorResponse) {
+func (s *userService) CreateUser(ctx context.Context, request *requests.CreateUserRequest) (*response.UserResponse, *response.ErrorResponse) {
 	s.logger.Debug("Creating new user", zap.String("email", request.Email), zap.Any("request", request))
 
-	existingUser, err := s.userRepository.FindByEmail(request.Email)
+	existingUser, err := s.userRepository.FindByEmail(ctx, request.Email)
 	if err != nil {
 
 		if errors.Is(err, sql.ErrNoRows) {
@@ -187,6 +192,7 @@ func (s *userService) CreateUser(request *requests.CreateUserRequest) (*response
 		return nil, &response.ErrorResponse{
 			Status:  "error",
 			Message: "Email is already in use",
+			Code:    response.ErrCodeConflict,
 		}
 	}
 
@@ -202,7 +208,7 @@ func (s *userService) CreateUser(request *requests.CreateUserRequest) (*response
 
 	request.Password = hash
 
-	res, err := s.userRepository.CreateUser(request)
+	res, err := s.userRepository.CreateUser(ctx, request)
 	if err != nil {
 		s.logger.Error("Failed to create user", zap.Error(err))
 		return nil, &response.ErrorResponse{
@@ -219,26 +225,28 @@ func (s *userService) CreateUser(request *requests.CreateUserRequest) (*response
 	return so, nil
 }
 
-func (s *userService) UpdateUser(request *requests.UpdateUserRequest) (*response.UserResponse, *response.ErrorResponse) {
+func (s *userService) UpdateUser(ctx context.Context, request *requests.UpdateUserRequest) (*response.UserResponse, *response.ErrorResponse) {
 	s.logger.Debug("Updating user", zap.Int("user_id", request.UserID), zap.Any("request", request))
 
-	existingUser, err := s.userRepository.FindById(request.UserID)
+	existingUser, err := s.userRepository.FindById(ctx, request.UserID)
 
 	if err != nil {
 		s.logger.Error("Failed to find user by ID", zap.Error(err))
 		return nil, &response.ErrorResponse{
 			Status:  "error",
 			Message: "User not found",
+			Code:    response.ErrCodeNotFound,
 		}
 	}
 
 	if request.Email != "" && request.Email != existingUser.Email {
-		duplicateUser, _ := s.userRepository.FindByEmail(request.Email)
+		duplicateUser, _ := s.userRepository.FindByEmail(ctx, request.Email)
 
 		if duplicateUser != nil {
 			return nil, &response.ErrorResponse{
 				Status:  "error",
 				Message: "Email is already in use",
+				Code:    response.ErrCodeConflict,
 			}
 		}
 
@@ -257,7 +265,7 @@ func (s *userService) UpdateUser(request *requests.UpdateUserRequest) (*response
 		existingUser.Password = hash
 	}
 
-	res, err := s.userRepository.UpdateUser(request)
+	res, err := s.userRepository.UpdateUser(ctx, request)
 	if err != nil {
 		s.logger.Error("Failed to update user", zap.Error(err))
 		return nil, &response.ErrorResponse{
@@ -266,6 +274,12 @@ func (s *userService) UpdateUser(request *requests.UpdateUserRequest) (*response
 		}
 	}
 
+	if request.Password != "" {
+		if errResp := s.revocation.RevokeUser(ctx, request.UserID, "password_changed"); errResp != nil {
+			return nil, errResp
+		}
+	}
+
 	so := s.mapping.ToUserResponse(res)
 
 	s.logger.Debug("Successfully updated user", zap.Int("user_id", so.ID))
@@ -273,10 +287,10 @@ func (s *userService) UpdateUser(request *requests.UpdateUserRequest) (*response
 	return so, nil
 }
 
-func (s *userService) TrashedUser(user_id int) (*response.UserResponseDeleteAt, *response.ErrorResponse) {
+func (s *userService) TrashedUser(ctx context.Context, user_id int) (*response.UserResponseDeleteAt, *response.ErrorResponse) {
 	s.logger.Debug("Trashing user", zap.Int("user_id", user_id))
 
-	res, err := s.userRepository.TrashedUser(user_id)
+	res, err := s.userRepository.TrashedUser(ctx, user_id)
 
 	if err != nil {
 		s.logger.Error("Failed to trash user", zap.Error(err), zap.Int("user_id", user_id))
@@ -286,6 +300,10 @@ func (s *userService) TrashedUser(user_id int) (*response.UserResponseDeleteAt,
 		}
 	}
 
+	if errResp := s.revocation.RevokeUser(ctx, user_id, "user_trashed"); errResp != nil {
+		return nil, errResp
+	}
+
 	so := s.mapping.ToUserResponseDeleteAt(res)
 
 	s.logger.Debug("Successfully trashed user", zap.Int("user_id", user_id))
@@ -293,10 +311,10 @@ func (s *userService) TrashedUser(user_id int) (*response.UserResponseDeleteAt,
 	return so, nil
 }
 
-func (s *userService) RestoreUser(user_id int) (*response.UserResponseDeleteAt, *response.ErrorResponse) {
+func (s *userService) RestoreUser(ctx context.Context, user_id int) (*response.UserResponseDeleteAt, *response.ErrorResponse) {
 	s.logger.Debug("Restoring user", zap.Int("user_id", user_id))
 
-	res, err := s.userRepository.RestoreUser(user_id)
+	res, err := s.userRepository.RestoreUser(ctx, user_id)
 
 	if err != nil {
 		s.logger.Error("Failed to restore user", zap.Error(err), zap.Int("user_id", user_id))
@@ -314,10 +332,10 @@ func (s *userService) RestoreUser(user_id int) (*response.UserResponseDeleteAt,
 	return so, nil
 }
 
-func (s *userService) DeleteUserPermanent(user_id int) (bool, *response.ErrorResponse) {
+func (s *userService) DeleteUserPermanent(ctx context.Context, user_id int) (bool, *response.ErrorResponse) {
 	s.logger.Debug("Deleting user permanently", zap.Int("user_id", user_id))
 
-	_, err := s.userRepository.DeleteUserPermanent(user_id)
+	_, err := s.userRepository.DeleteUserPermanent(ctx, user_id)
 
 	if err != nil {
 		s.logger.Error("Failed to delete user permanently", zap.Error(err), zap.Int("user_id", user_id))
@@ -328,15 +346,19 @@ func (s *userService) DeleteUserPermanent(user_id int) (bool, *response.ErrorRes
 		}
 	}
 
+	if errResp := s.revocation.RevokeUser(ctx, user_id, "user_deleted"); errResp != nil {
+		return false, errResp
+	}
+
 	s.logger.Debug("Successfully deleted user permanently", zap.Int("user_id", user_id))
 
 	return true, nil
 }
 
-func (s *userService) RestoreAllUser() (bool, *response.ErrorResponse) {
+func (s *userService) RestoreAllUser(ctx context.Context) (bool, *response.ErrorResponse) {
 	s.logger.Debug("Restoring all users")
 
-	_, err := s.userRepository.RestoreAllUser()
+	_, err := s.userRepository.RestoreAllUser(ctx)
 
 	if err != nil {
 		s.logger.Error("Failed to restore all users", zap.Error(err))
@@ -351,10 +373,10 @@ func (s *userService) RestoreAllUser() (bool, *response.ErrorResponse) {
 	return true, nil
 }
 
-func (s *userService) DeleteAllUserPermanent() (bool, *response.ErrorResponse) {
+func (s *userService) DeleteAllUserPermanent(ctx context.Context) (bool, *response.ErrorResponse) {
 	s.logger.Debug("Permanently deleting all users")
 
-	_, err := s.userRepository.DeleteAllUserPermanent()
+	_, err := s.userRepository.DeleteAllUserPermanent(ctx)
 
 	if err != nil {
 		s.logger.Error("Failed to permanently delete all users", zap.Error(err))
@@ -0,0 +1,32 @@
+package auth
+
+import "context"
+
+type contextKey string
+
+const (
+	userIDContextKey contextKey = "userID"
+	rolesContextKey  contextKey = "roles"
+)
+
+// WithUserID returns a copy of ctx carrying the authenticated user's ID.
+func WithUserID(ctx context.Context, userID int) context.Context {
+	return context.WithValue(ctx, userIDContextKey, userID)
+}
+
+// UserIDFromContext returns the authenticated user's ID stored by WithUserID.
+func UserIDFromContext(ctx context.Context) (int, bool) {
+	userID, ok := ctx.Value(userIDContextKey).(int)
+	return userID, ok
+}
+
+// WithRoles returns a copy of ctx carrying the authenticated user's role names.
+func WithRoles(ctx context.Context, roles []string) context.Context {
+	return context.WithValue(ctx, rolesContextKey, roles)
+}
+
+// RolesFromContext returns the role names stored by WithRoles.
+func RolesFromContext(ctx context.Context) []string {
+	roles, _ := ctx.Value(rolesContextKey).([]string)
+	return roles
+}
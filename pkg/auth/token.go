@@ -11,57 +11,231 @@ import (
 
 var ErrTokenExpired = errors.New("token expired")
 
+// ErrCannotSign is returned by GenerateToken on a Manager built by
+// NewVerifier.
+var ErrCannotSign = errors.New("token manager can only verify tokens")
+
+// AccessTokenTTL is how long a token issued by Manager stays valid.
+const AccessTokenTTL = 12 * time.Hour
+
 //go:generate mockgen -source=token.go -destination=mocks/token.go
 type TokenManager interface {
-	GenerateToken(userId int, audience string) (string, error)
+	GenerateToken(userId int, roles []string) (string, error)
 	ValidateToken(tokenString string) (string, error)
+	ParseToken(tokenString string) (*Claims, error)
+	JWKS() JWKS
+}
+
+// Claims are the JWT claims issued by Manager. Roles is only set on access
+// tokens and holds the names of the roles assigned to the user at login.
+type Claims struct {
+	Roles []string `json:"roles,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// Config selects how a Manager signs tokens.
+//
+// With KeysDir set, tokens are signed with the asymmetric key ActiveKeyID
+// from that directory (see LoadKeys) and every other key in it is accepted
+// for verification. To rotate, add the new key, point ActiveKeyID at it and
+// keep the old file, or just its public key, for AccessTokenTTL before
+// removing it.
+//
+// Without KeysDir, tokens are signed with HS256 and SecretKey, which every
+// verifier then has to share.
+type Config struct {
+	SecretKey   string
+	KeysDir     string
+	ActiveKeyID string
+	Issuer      string
+	Audience    string
 }
 
 type Manager struct {
-	secretKey []byte
+	keys     map[string]*Key
+	active   *Key
+	methods  []string
+	issuer   string
+	audience string
+}
+
+func NewManager(cfg Config) (*Manager, error) {
+	if cfg.Issuer == "" || cfg.Audience == "" {
+		return nil, errors.New("issuer and audience are required")
+	}
+
+	m := &Manager{issuer: cfg.Issuer, audience: cfg.Audience}
+
+	if cfg.KeysDir == "" {
+		if cfg.SecretKey == "" {
+			return nil, errors.New("empty secret key")
+		}
+
+		secret := []byte(cfg.SecretKey)
+		m.active = &Key{Method: jwt.SigningMethodHS256, signKey: secret, verifyKey: secret}
+		m.keys = map[string]*Key{}
+		m.methods = []string{jwt.SigningMethodHS256.Alg()}
+
+		return m, nil
+	}
+
+	keys, err := LoadKeys(cfg.KeysDir)
+	if err != nil {
+		return nil, err
+	}
+
+	active, ok := keys[cfg.ActiveKeyID]
+	if !ok {
+		return nil, fmt.Errorf("active key %q not found in %s", cfg.ActiveKeyID, cfg.KeysDir)
+	}
+
+	if !active.CanSign() {
+		return nil, fmt.Errorf("active key %q has no private key", cfg.ActiveKeyID)
+	}
+
+	m.keys = keys
+	m.active = active
+	m.methods = keyMethods(keys)
+
+	return m, nil
+}
+
+// NewVerifier builds a Manager that only verifies tokens, for processes such
+// as the REST gateway that never issue them. With KeysDir set the directory
+// only needs the public keys, and the private half of any key found there is
+// dropped. Without KeysDir the HS256 SecretKey is still required, since it is
+// also what tokens are verified with.
+func NewVerifier(cfg Config) (*Manager, error) {
+	if cfg.KeysDir == "" {
+		m, err := NewManager(cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		m.active = &Key{Method: m.active.Method, verifyKey: m.active.verifyKey}
+
+		return m, nil
+	}
+
+	if cfg.Issuer == "" || cfg.Audience == "" {
+		return nil, errors.New("issuer and audience are required")
+	}
+
+	keys, err := LoadKeys(cfg.KeysDir)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no keys found in %s", cfg.KeysDir)
+	}
+
+	m := &Manager{issuer: cfg.Issuer, audience: cfg.Audience, keys: make(map[string]*Key, len(keys))}
+	for kid, key := range keys {
+		m.keys[kid] = &Key{ID: key.ID, Method: key.Method, verifyKey: key.verifyKey}
+	}
+	m.methods = keyMethods(m.keys)
+
+	return m, nil
 }
 
-func NewManager(secretKey string) (*Manager, error) {
-	if secretKey == "" {
-		return nil, errors.New("empty secret key")
+// keyMethods lists the signing algorithms of keys, each once.
+func keyMethods(keys map[string]*Key) []string {
+	var methods []string
+
+	seen := map[string]bool{}
+	for _, key := range keys {
+		if alg := key.Method.Alg(); !seen[alg] {
+			seen[alg] = true
+			methods = append(methods, alg)
+		}
 	}
-	return &Manager{secretKey: []byte(secretKey)}, nil
+
+	return methods
 }
 
-func (m *Manager) GenerateToken(userId int, audience string) (string, error) {
+func (m *Manager) GenerateToken(userId int, roles []string) (string, error) {
+	if m.active == nil || !m.active.CanSign() {
+		return "", ErrCannotSign
+	}
+
 	nowTime := time.Now()
-	expireTime := nowTime.Add(12 * time.Hour)
+	expireTime := nowTime.Add(AccessTokenTTL)
+
+	jti, err := NewOpaqueToken(16)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate token id: %w", err)
+	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
-		ExpiresAt: jwt.NewNumericDate(expireTime),
-		Subject:   strconv.Itoa(userId),
-		Audience:  []string{audience},
+	token := jwt.NewWithClaims(m.active.Method, Claims{
+		Roles: roles,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
+			Issuer:    m.issuer,
+			IssuedAt:  jwt.NewNumericDate(nowTime),
+			ExpiresAt: jwt.NewNumericDate(expireTime),
+			Subject:   strconv.Itoa(userId),
+			Audience:  []string{m.audience},
+		},
 	})
 
-	return token.SignedString([]byte(m.secretKey))
+	if m.active.ID != "" {
+		token.Header["kid"] = m.active.ID
+	}
+
+	return token.SignedString(m.active.signKey)
 }
 
 func (m *Manager) ValidateToken(accessToken string) (string, error) {
-	token, err := jwt.Parse(accessToken, func(token *jwt.Token) (i interface{}, err error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
+	claims, err := m.ParseToken(accessToken)
+	if err != nil {
+		return "", err
+	}
 
-		return []byte(m.secretKey), nil
-	})
+	return claims.Subject, nil
+}
+
+// ParseToken verifies the signature, expiry, issuer and audience of
+// accessToken and returns its claims.
+func (m *Manager) ParseToken(accessToken string) (*Claims, error) {
+	token, err := jwt.ParseWithClaims(accessToken, &Claims{}, m.keyFunc,
+		jwt.WithValidMethods(m.methods),
+		jwt.WithIssuer(m.issuer),
+		jwt.WithAudience(m.audience),
+		jwt.WithExpirationRequired(),
+	)
 
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
-			return "", ErrTokenExpired
+			return nil, ErrTokenExpired
 		}
-		return "", fmt.Errorf("failed to parse token: %w", err)
+		return nil, fmt.Errorf("failed to parse token: %w", err)
 	}
 
-	claims, ok := token.Claims.(jwt.MapClaims)
+	claims, ok := token.Claims.(*Claims)
 	if !ok {
-		return "", fmt.Errorf("error get user claims from token")
+		return nil, fmt.Errorf("error get user claims from token")
+	}
+
+	return claims, nil
+}
+
+// keyFunc picks the verification key named by the token's kid. Tokens without
+// a kid are only accepted when verifying with the HS256 secret.
+func (m *Manager) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	key := m.active
+	if kid != "" || key == nil || key.ID != "" {
+		var ok bool
+		if key, ok = m.keys[kid]; !ok {
+			return nil, fmt.Errorf("unknown signing key %q", kid)
+		}
 	}
 
-	return claims["sub"].(string), nil
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
 
+	return key.verifyKey, nil
 }
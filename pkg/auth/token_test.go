@@ -0,0 +1,274 @@
+package auth_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"ecommerce/pkg/auth"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	testIssuer   = "ecommerce"
+	testAudience = "ecommerce-api"
+)
+
+// testKey is the PEM encoding of both halves of a key pair.
+type testKey struct {
+	private []byte
+	public  []byte
+}
+
+func newEd25519Key(t *testing.T) testKey {
+	t.Helper()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generate ed25519 key: %v", err)
+	}
+
+	return encodeKey(t, priv, pub)
+}
+
+func newRSAKey(t *testing.T, bits int) testKey {
+	t.Helper()
+
+	priv, err := rsa.GenerateKey(rand.Reader, bits)
+	if err != nil {
+		t.Fatalf("generate rsa key: %v", err)
+	}
+
+	return encodeKey(t, priv, &priv.PublicKey)
+}
+
+func encodeKey(t *testing.T, priv interface{}, pub interface{}) testKey {
+	t.Helper()
+
+	privDER, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatalf("encode private key: %v", err)
+	}
+
+	pubDER, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatalf("encode public key: %v", err)
+	}
+
+	return testKey{
+		private: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER}),
+		public:  pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}),
+	}
+}
+
+// keysDir writes files, keyed by kid, to a new key directory.
+func keysDir(t *testing.T, files map[string][]byte) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for kid, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, kid+".pem"), data, 0o600); err != nil {
+			t.Fatalf("write key %s: %v", kid, err)
+		}
+	}
+
+	return dir
+}
+
+func newManager(t *testing.T, files map[string][]byte, activeKeyID string) *auth.Manager {
+	t.Helper()
+
+	manager, err := auth.NewManager(auth.Config{
+		KeysDir:     keysDir(t, files),
+		ActiveKeyID: activeKeyID,
+		Issuer:      testIssuer,
+		Audience:    testAudience,
+	})
+	if err != nil {
+		t.Fatalf("create manager: %v", err)
+	}
+
+	return manager
+}
+
+func issue(t *testing.T, manager *auth.Manager) string {
+	t.Helper()
+
+	token, err := manager.GenerateToken(1, []string{"Customer"})
+	if err != nil {
+		t.Fatalf("generate token: %v", err)
+	}
+
+	return token
+}
+
+func TestKeyRotation(t *testing.T) {
+	oldKey := newEd25519Key(t)
+	newKey := newRSAKey(t, 2048)
+
+	oldToken := issue(t, newManager(t, map[string][]byte{"2025-01": oldKey.private}, "2025-01"))
+	newToken := issue(t, newManager(t, map[string][]byte{"2025-01": oldKey.private, "2025-02": newKey.private}, "2025-02"))
+
+	// An HS256 token keyed with the published RSA key must not pass as
+	// signed by that key.
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, auth.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "1",
+			Issuer:    testIssuer,
+			Audience:  []string{testAudience},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(auth.AccessTokenTTL)),
+		},
+	})
+	forged.Header["kid"] = "2025-02"
+
+	forgedToken, err := forged.SignedString(newKey.public)
+	if err != nil {
+		t.Fatalf("sign forged token: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		files       map[string][]byte
+		activeKeyID string
+		token       string
+		wantErr     bool
+	}{
+		{name: "old key kept after rotation", files: map[string][]byte{"2025-01": oldKey.private, "2025-02": newKey.private}, activeKeyID: "2025-02", token: oldToken},
+		{name: "old key kept as public key only", files: map[string][]byte{"2025-01": oldKey.public, "2025-02": newKey.private}, activeKeyID: "2025-02", token: oldToken},
+		{name: "old key removed", files: map[string][]byte{"2025-02": newKey.private}, activeKeyID: "2025-02", token: oldToken, wantErr: true},
+		{name: "verifier with public keys", files: map[string][]byte{"2025-01": oldKey.public, "2025-02": newKey.public}, token: newToken},
+		{name: "verifier without the signing key", files: map[string][]byte{"2025-01": oldKey.public}, token: newToken, wantErr: true},
+		{name: "HS256 signed with a public key", files: map[string][]byte{"2025-02": newKey.public}, token: forgedToken, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var manager *auth.Manager
+
+			if tt.activeKeyID != "" {
+				manager = newManager(t, tt.files, tt.activeKeyID)
+			} else {
+				verifier, err := auth.NewVerifier(auth.Config{KeysDir: keysDir(t, tt.files), Issuer: testIssuer, Audience: testAudience})
+				if err != nil {
+					t.Fatalf("create verifier: %v", err)
+				}
+				manager = verifier
+			}
+
+			claims, err := manager.ParseToken(tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseToken error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err == nil && claims.Subject != "1" {
+				t.Errorf("subject = %q, want %q", claims.Subject, "1")
+			}
+		})
+	}
+}
+
+func TestNewManagerKeyChecks(t *testing.T) {
+	key := newEd25519Key(t)
+	weakKey := newRSAKey(t, 1024)
+
+	tests := []struct {
+		name        string
+		files       map[string][]byte
+		activeKeyID string
+	}{
+		{name: "active key missing", files: map[string][]byte{"2025-01": key.private}, activeKeyID: "2025-02"},
+		{name: "active key has no private key", files: map[string][]byte{"2025-01": key.public}, activeKeyID: "2025-01"},
+		{name: "RSA key too small", files: map[string][]byte{"2025-01": weakKey.private}, activeKeyID: "2025-01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := auth.NewManager(auth.Config{
+				KeysDir:     keysDir(t, tt.files),
+				ActiveKeyID: tt.activeKeyID,
+				Issuer:      testIssuer,
+				Audience:    testAudience,
+			})
+			if err == nil {
+				t.Error("NewManager succeeded, want an error")
+			}
+		})
+	}
+}
+
+func TestVerifierCannotSign(t *testing.T) {
+	key := newEd25519Key(t)
+
+	verifier, err := auth.NewVerifier(auth.Config{
+		KeysDir:  keysDir(t, map[string][]byte{"2025-01": key.private}),
+		Issuer:   testIssuer,
+		Audience: testAudience,
+	})
+	if err != nil {
+		t.Fatalf("create verifier: %v", err)
+	}
+
+	if _, err := verifier.GenerateToken(1, nil); !errors.Is(err, auth.ErrCannotSign) {
+		t.Errorf("GenerateToken error = %v, want %v", err, auth.ErrCannotSign)
+	}
+}
+
+func TestJWKS(t *testing.T) {
+	edKey := newEd25519Key(t)
+	rsaKey := newRSAKey(t, 2048)
+
+	hmac, err := auth.NewManager(auth.Config{SecretKey: "test-secret", Issuer: testIssuer, Audience: testAudience})
+	if err != nil {
+		t.Fatalf("create manager: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		manager *auth.Manager
+		want    []auth.JWK
+	}{
+		{name: "HS256 secret is not published", manager: hmac},
+		{
+			name:    "current and retired keys",
+			manager: newManager(t, map[string][]byte{"2025-01": edKey.public, "2025-02": rsaKey.private}, "2025-02"),
+			want: []auth.JWK{
+				{Kty: "OKP", Use: "sig", Alg: "EdDSA", Kid: "2025-01", Crv: "Ed25519"},
+				{Kty: "RSA", Use: "sig", Alg: "RS256", Kid: "2025-02", E: "AQAB"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys := tt.manager.JWKS().Keys
+
+			if len(keys) != len(tt.want) {
+				t.Fatalf("JWKS has %d keys, want %d", len(keys), len(tt.want))
+			}
+
+			for i, want := range tt.want {
+				got := keys[i]
+
+				if got.Kty != want.Kty || got.Use != want.Use || got.Alg != want.Alg || got.Kid != want.Kid || got.Crv != want.Crv {
+					t.Errorf("key %d = %+v, want %+v", i, got, want)
+				}
+
+				if want.E != "" && got.E != want.E {
+					t.Errorf("key %d exponent = %q, want %q", i, got.E, want.E)
+				}
+
+				if got.N == "" && got.X == "" {
+					t.Errorf("key %d has no public key material", i)
+				}
+			}
+		})
+	}
+}
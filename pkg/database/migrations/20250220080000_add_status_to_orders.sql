@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "orders"
+    ADD COLUMN "status" VARCHAR(20) NOT NULL DEFAULT 'pending'
+    CHECK ("status" IN ('pending', 'paid', 'processing', 'shipped', 'delivered', 'cancelled', 'refunded'));
+
+CREATE INDEX idx_orders_status ON orders(status);
+
+CREATE TABLE "order_status_histories" (
+    "order_status_history_id" SERIAL PRIMARY KEY,
+    "order_id" INT NOT NULL REFERENCES "orders" ("order_id") ON DELETE CASCADE,
+    "from_status" VARCHAR(20),
+    "to_status" VARCHAR(20) NOT NULL,
+    "note" TEXT,
+    "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_order_status_histories_order_id ON order_status_histories(order_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_order_status_histories_order_id;
+
+DROP TABLE IF EXISTS "order_status_histories";
+
+DROP INDEX IF EXISTS idx_orders_status;
+
+ALTER TABLE "orders" DROP COLUMN IF EXISTS "status";
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+UPDATE "carts" c
+SET "quantity" = merged.total_quantity
+FROM (
+    SELECT MIN("cart_id") AS cart_id, SUM("quantity") AS total_quantity
+    FROM "carts"
+    GROUP BY "user_id", "product_id"
+    HAVING COUNT(*) > 1
+) merged
+WHERE c."cart_id" = merged.cart_id;
+
+DELETE FROM "carts" c
+USING "carts" d
+WHERE c."user_id" = d."user_id"
+AND c."product_id" = d."product_id"
+AND c."cart_id" > d."cart_id";
+
+CREATE UNIQUE INDEX idx_carts_user_id_product_id ON carts(user_id, product_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_carts_user_id_product_id;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE INDEX idx_products_keyset ON products(created_at DESC, product_id DESC) WHERE deleted_at IS NULL;
+CREATE INDEX idx_orders_keyset ON orders(created_at DESC, order_id DESC) WHERE deleted_at IS NULL;
+CREATE INDEX idx_transactions_keyset ON transactions(created_at DESC, transaction_id DESC) WHERE deleted_at IS NULL;
+CREATE INDEX idx_reviews_keyset ON reviews(created_at DESC, review_id DESC) WHERE deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_reviews_keyset;
+DROP INDEX IF EXISTS idx_transactions_keyset;
+DROP INDEX IF EXISTS idx_orders_keyset;
+DROP INDEX IF EXISTS idx_products_keyset;
+-- +goose StatementEnd
@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE "products" ADD COLUMN "search_vector" TSVECTOR;
+
+CREATE OR REPLACE FUNCTION products_search_vector_update() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.search_vector :=
+        setweight(to_tsvector('english', COALESCE(NEW.name, '')), 'A') ||
+        setweight(to_tsvector('english', COALESCE(NEW.brand, '')), 'B') ||
+        setweight(to_tsvector('english', COALESCE(NEW.description, '')), 'C') ||
+        setweight(to_tsvector('english', COALESCE(REPLACE(NEW.slug_product, '-', ' '), '')), 'D');
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_products_search_vector
+BEFORE INSERT OR UPDATE OF name, brand, description, slug_product ON products
+FOR EACH ROW EXECUTE FUNCTION products_search_vector_update();
+
+UPDATE "products" SET "name" = "name";
+
+CREATE INDEX idx_products_search_vector ON products USING GIN (search_vector);
+
+CREATE INDEX idx_products_name_trgm ON products USING GIN (name gin_trgm_ops);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_products_name_trgm;
+DROP INDEX IF EXISTS idx_products_search_vector;
+DROP TRIGGER IF EXISTS trg_products_search_vector ON products;
+DROP FUNCTION IF EXISTS products_search_vector_update();
+ALTER TABLE "products" DROP COLUMN IF EXISTS "search_vector";
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "transactions"
+    ADD COLUMN "payment_provider" VARCHAR(50),
+    ADD COLUMN "provider_charge_id" VARCHAR(255);
+
+ALTER TABLE "transactions" ALTER COLUMN "payment_status" SET DEFAULT 'pending';
+
+CREATE UNIQUE INDEX idx_transactions_provider_charge_id ON transactions(payment_provider, provider_charge_id)
+WHERE provider_charge_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_transactions_provider_charge_id;
+
+ALTER TABLE "transactions" ALTER COLUMN "payment_status" SET DEFAULT 'completed';
+
+ALTER TABLE "transactions"
+    DROP COLUMN IF EXISTS "provider_charge_id",
+    DROP COLUMN IF EXISTS "payment_provider";
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE "payment_events" (
+    "payment_event_id" SERIAL PRIMARY KEY,
+    "provider" VARCHAR(50) NOT NULL,
+    "event_id" VARCHAR(255) NOT NULL,
+    "event_type" VARCHAR(100) NOT NULL,
+    "transaction_id" INT REFERENCES "transactions" ("transaction_id") ON DELETE SET NULL,
+    "payload" JSONB NOT NULL,
+    "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE ("provider", "event_id")
+);
+
+CREATE INDEX idx_payment_events_transaction_id ON payment_events(transaction_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_payment_events_transaction_id;
+
+DROP TABLE IF EXISTS "payment_events";
+-- +goose StatementEnd
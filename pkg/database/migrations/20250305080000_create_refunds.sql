@@ -0,0 +1,41 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "transactions" ADD COLUMN "refunded_amount" INT NOT NULL DEFAULT 0;
+
+CREATE TABLE "refunds" (
+    "refund_id" SERIAL PRIMARY KEY,
+    "transaction_id" INT NOT NULL REFERENCES "transactions" ("transaction_id") ON DELETE CASCADE,
+    "amount" INT NOT NULL CHECK ("amount" > 0),
+    "reason" TEXT,
+    "provider_refund_id" VARCHAR(255),
+    "status" VARCHAR(20) NOT NULL DEFAULT 'succeeded',
+    "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_refunds_transaction_id ON refunds(transaction_id);
+
+CREATE TABLE "refund_items" (
+    "refund_item_id" SERIAL PRIMARY KEY,
+    "refund_id" INT NOT NULL REFERENCES "refunds" ("refund_id") ON DELETE CASCADE,
+    "order_item_id" INT NOT NULL REFERENCES "order_items" ("order_item_id"),
+    "quantity" INT NOT NULL CHECK ("quantity" > 0),
+    "amount" INT NOT NULL CHECK ("amount" >= 0)
+);
+
+CREATE INDEX idx_refund_items_refund_id ON refund_items(refund_id);
+CREATE INDEX idx_refund_items_order_item_id ON refund_items(order_item_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_refund_items_order_item_id;
+DROP INDEX IF EXISTS idx_refund_items_refund_id;
+
+DROP TABLE IF EXISTS "refund_items";
+
+DROP INDEX IF EXISTS idx_refunds_transaction_id;
+
+DROP TABLE IF EXISTS "refunds";
+
+ALTER TABLE "transactions" DROP COLUMN IF EXISTS "refunded_amount";
+-- +goose StatementEnd
@@ -0,0 +1,56 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "orders"
+    ADD COLUMN "subtotal" INT NOT NULL DEFAULT 0,
+    ADD COLUMN "shipping_cost" INT NOT NULL DEFAULT 0,
+    ADD COLUMN "tax_amount" INT NOT NULL DEFAULT 0 CHECK ("tax_amount" >= 0),
+    ADD COLUMN "discount_amount" INT NOT NULL DEFAULT 0 CHECK ("discount_amount" >= 0);
+
+UPDATE orders o
+SET subtotal = totals.subtotal,
+    shipping_cost = totals.shipping_cost,
+    total_price = GREATEST(totals.subtotal + totals.shipping_cost, 0)
+FROM (
+    SELECT
+        ord.order_id,
+        COALESCE((
+            SELECT SUM(oi.quantity * oi.price)
+            FROM order_items oi
+            WHERE oi.order_id = ord.order_id AND oi.deleted_at IS NULL
+        ), 0)::INT AS subtotal,
+        COALESCE((
+            SELECT ROUND(sa.shipping_cost)
+            FROM shipping_addresses sa
+            WHERE sa.order_id = ord.order_id AND sa.deleted_at IS NULL
+            ORDER BY sa.shipping_address_id DESC
+            LIMIT 1
+        ), 0)::INT AS shipping_cost
+    FROM orders ord
+) totals
+WHERE o.order_id = totals.order_id;
+
+ALTER TABLE "transactions"
+    ADD COLUMN "amount_due" INT NOT NULL DEFAULT 0 CHECK ("amount_due" >= 0),
+    ADD COLUMN "change_amount" INT NOT NULL DEFAULT 0 CHECK ("change_amount" >= 0);
+
+UPDATE transactions SET amount_due = amount;
+
+ALTER TABLE "shipping_addresses"
+    ADD CONSTRAINT "shipping_addresses_shipping_cost_check" CHECK ("shipping_cost" >= 0) NOT VALID;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE "shipping_addresses"
+    DROP CONSTRAINT IF EXISTS "shipping_addresses_shipping_cost_check";
+
+ALTER TABLE "transactions"
+    DROP COLUMN IF EXISTS "change_amount",
+    DROP COLUMN IF EXISTS "amount_due";
+
+ALTER TABLE "orders"
+    DROP COLUMN IF EXISTS "discount_amount",
+    DROP COLUMN IF EXISTS "tax_amount",
+    DROP COLUMN IF EXISTS "shipping_cost",
+    DROP COLUMN IF EXISTS "subtotal";
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE "idempotency_keys" (
+    "idempotency_key_id" SERIAL PRIMARY KEY,
+    "user_id" INT NOT NULL REFERENCES "users" ("user_id") ON DELETE CASCADE,
+    "method" VARCHAR(255) NOT NULL,
+    "idempotency_key" VARCHAR(255) NOT NULL,
+    "request_hash" VARCHAR(64) NOT NULL,
+    "response" BYTEA,
+    "failed" BOOLEAN NOT NULL DEFAULT FALSE,
+    "locked_at" TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "completed_at" TIMESTAMP DEFAULT NULL,
+    "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE ("user_id", "method", "idempotency_key")
+);
+
+CREATE INDEX idx_idempotency_keys_created_at ON idempotency_keys(created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_idempotency_keys_created_at;
+
+DROP TABLE IF EXISTS "idempotency_keys";
+-- +goose StatementEnd
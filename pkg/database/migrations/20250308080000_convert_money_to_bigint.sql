@@ -0,0 +1,69 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "products" ALTER COLUMN "price" TYPE BIGINT;
+
+ALTER TABLE "carts" ALTER COLUMN "price" TYPE BIGINT;
+
+ALTER TABLE "order_items" ALTER COLUMN "price" TYPE BIGINT;
+
+ALTER TABLE "orders"
+    ALTER COLUMN "total_price" TYPE BIGINT,
+    ALTER COLUMN "subtotal" TYPE BIGINT,
+    ALTER COLUMN "shipping_cost" TYPE BIGINT,
+    ALTER COLUMN "tax_amount" TYPE BIGINT,
+    ALTER COLUMN "discount_amount" TYPE BIGINT;
+
+ALTER TABLE "transactions"
+    ALTER COLUMN "amount" TYPE BIGINT,
+    ALTER COLUMN "amount_due" TYPE BIGINT,
+    ALTER COLUMN "change_amount" TYPE BIGINT,
+    ALTER COLUMN "refunded_amount" TYPE BIGINT;
+
+ALTER TABLE "refunds" ALTER COLUMN "amount" TYPE BIGINT;
+
+ALTER TABLE "refund_items" ALTER COLUMN "amount" TYPE BIGINT;
+
+ALTER TABLE "shipping_addresses"
+    ALTER COLUMN "shipping_cost" TYPE BIGINT USING ROUND("shipping_cost")::BIGINT;
+
+ALTER TABLE "merchants" ADD COLUMN "currency" CHAR(3) NOT NULL DEFAULT 'IDR';
+
+ALTER TABLE "orders" ADD COLUMN "currency" CHAR(3) NOT NULL DEFAULT 'IDR';
+
+UPDATE orders o
+SET currency = m.currency
+FROM merchants m
+WHERE m.merchant_id = o.merchant_id;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE "orders" DROP COLUMN IF EXISTS "currency";
+
+ALTER TABLE "merchants" DROP COLUMN IF EXISTS "currency";
+
+ALTER TABLE "shipping_addresses" ALTER COLUMN "shipping_cost" TYPE DECIMAL(10,2);
+
+ALTER TABLE "refund_items" ALTER COLUMN "amount" TYPE INT;
+
+ALTER TABLE "refunds" ALTER COLUMN "amount" TYPE INT;
+
+ALTER TABLE "transactions"
+    ALTER COLUMN "amount" TYPE INT,
+    ALTER COLUMN "amount_due" TYPE INT,
+    ALTER COLUMN "change_amount" TYPE INT,
+    ALTER COLUMN "refunded_amount" TYPE INT;
+
+ALTER TABLE "orders"
+    ALTER COLUMN "total_price" TYPE INT,
+    ALTER COLUMN "subtotal" TYPE INT,
+    ALTER COLUMN "shipping_cost" TYPE INT,
+    ALTER COLUMN "tax_amount" TYPE INT,
+    ALTER COLUMN "discount_amount" TYPE INT;
+
+ALTER TABLE "order_items" ALTER COLUMN "price" TYPE INT;
+
+ALTER TABLE "carts" ALTER COLUMN "price" TYPE INT;
+
+ALTER TABLE "products" ALTER COLUMN "price" TYPE INT;
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Refresh tokens are stored hashed from now on, so the plaintext tokens
+-- issued so far can no longer be looked up and their users sign in again.
+DELETE FROM refresh_tokens;
+
+ALTER TABLE refresh_tokens
+    ADD COLUMN family_id VARCHAR(64) NOT NULL,
+    ADD COLUMN device VARCHAR(255),
+    ADD COLUMN user_agent TEXT,
+    ADD COLUMN ip_address VARCHAR(45),
+    ADD COLUMN signed_in_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+    ADD COLUMN used_at TIMESTAMP DEFAULT NULL,
+    ADD COLUMN revoked_at TIMESTAMP DEFAULT NULL;
+
+CREATE INDEX idx_refresh_tokens_family_id ON refresh_tokens (family_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_refresh_tokens_family_id;
+
+ALTER TABLE refresh_tokens
+    DROP COLUMN IF EXISTS revoked_at,
+    DROP COLUMN IF EXISTS used_at,
+    DROP COLUMN IF EXISTS signed_in_at,
+    DROP COLUMN IF EXISTS ip_address,
+    DROP COLUMN IF EXISTS user_agent,
+    DROP COLUMN IF EXISTS device,
+    DROP COLUMN IF EXISTS family_id;
+-- +goose StatementEnd
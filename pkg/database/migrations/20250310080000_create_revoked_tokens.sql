@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE "revoked_tokens" (
+    "revoked_token_id" SERIAL PRIMARY KEY,
+    "jti" VARCHAR(64) UNIQUE,
+    "user_id" INT,
+    "reason" VARCHAR(50) NOT NULL,
+    "revoked_at" TIMESTAMP NOT NULL,
+    "expires_at" TIMESTAMP NOT NULL,
+    CHECK ("jti" IS NOT NULL OR "user_id" IS NOT NULL)
+);
+
+CREATE INDEX idx_revoked_tokens_expires_at ON revoked_tokens(expires_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_revoked_tokens_expires_at;
+
+DROP TABLE IF EXISTS "revoked_tokens";
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "users" ADD COLUMN "email_verified_at" TIMESTAMP DEFAULT NULL;
+
+-- Accounts created before verification existed keep working.
+UPDATE "users" SET "email_verified_at" = "created_at";
+
+CREATE TABLE "user_tokens" (
+    "user_token_id" SERIAL PRIMARY KEY,
+    "user_id" INT NOT NULL REFERENCES "users" ("user_id") ON DELETE CASCADE,
+    "purpose" VARCHAR(30) NOT NULL,
+    "token" VARCHAR(64) UNIQUE NOT NULL,
+    "expires_at" TIMESTAMP NOT NULL,
+    "used_at" TIMESTAMP DEFAULT NULL,
+    "created_at" TIMESTAMP NOT NULL DEFAULT current_timestamp
+);
+
+CREATE INDEX idx_user_tokens_user_id_purpose ON user_tokens(user_id, purpose);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_user_tokens_user_id_purpose;
+
+DROP TABLE IF EXISTS "user_tokens";
+
+ALTER TABLE "users" DROP COLUMN IF EXISTS "email_verified_at";
+-- +goose StatementEnd
@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE "login_throttles" (
+    "login_throttle_id" SERIAL PRIMARY KEY,
+    "scope" VARCHAR(10) NOT NULL,
+    "subject" VARCHAR(255) NOT NULL,
+    "failed_attempts" INT NOT NULL DEFAULT 0,
+    "last_failed_at" TIMESTAMP NOT NULL,
+    "locked_until" TIMESTAMP DEFAULT NULL,
+    UNIQUE ("scope", "subject")
+);
+
+CREATE TABLE "login_audit_logs" (
+    "login_audit_log_id" SERIAL PRIMARY KEY,
+    "event" VARCHAR(30) NOT NULL,
+    "scope" VARCHAR(10) NOT NULL,
+    "subject" VARCHAR(255) NOT NULL,
+    "failed_attempts" INT NOT NULL DEFAULT 0,
+    "locked_until" TIMESTAMP DEFAULT NULL,
+    "ip_address" VARCHAR(64) DEFAULT NULL,
+    "actor_id" INT DEFAULT NULL,
+    "created_at" TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_login_audit_logs_scope_subject ON login_audit_logs(scope, subject);
+
+CREATE INDEX idx_login_audit_logs_created_at ON login_audit_logs(created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_login_audit_logs_created_at;
+
+DROP INDEX IF EXISTS idx_login_audit_logs_scope_subject;
+
+DROP TABLE IF EXISTS "login_audit_logs";
+
+DROP TABLE IF EXISTS "login_throttles";
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+INSERT INTO "roles" ("role_name")
+VALUES ('Customer')
+ON CONFLICT ("role_name") DO NOTHING;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM "user_roles"
+WHERE "role_id" IN (SELECT "role_id" FROM "roles" WHERE "role_name" = 'Customer');
+
+DELETE FROM "roles" WHERE "role_name" = 'Customer';
+-- +goose StatementEnd
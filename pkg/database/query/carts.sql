@@ -10,15 +10,78 @@ ORDER BY created_at DESC
 LIMIT $3 OFFSET $4;
 
 
--- name: CreateCart :one
+-- name: GetCart :one
+SELECT *
+FROM carts
+WHERE cart_id = $1
+AND deleted_at IS NULL;
+
+
+-- Adds the product to the user's cart, merging into an existing row for the
+-- same product. No row is returned when the merged quantity would exceed the
+-- product's stock.
+-- name: UpsertCart :one
 INSERT INTO "carts" ("user_id", "product_id", "name", "price", "image", "quantity", "weight")
-VALUES ($1, $2, $3, $4, $5, $6, $7)
+SELECT
+    sqlc.arg(user_id)::INT,
+    sqlc.arg(product_id)::INT,
+    sqlc.arg(name)::VARCHAR,
+    sqlc.arg(price)::BIGINT,
+    sqlc.arg(image)::VARCHAR,
+    sqlc.arg(quantity)::INT,
+    sqlc.arg(weight)::INT
+WHERE sqlc.arg(quantity)::INT <= (SELECT count_in_stock FROM products WHERE product_id = sqlc.arg(product_id)::INT)
+ON CONFLICT ("user_id", "product_id") DO UPDATE
+SET "quantity" = carts.quantity + EXCLUDED.quantity,
+    "name" = EXCLUDED.name,
+    "price" = EXCLUDED.price,
+    "image" = EXCLUDED.image,
+    "weight" = EXCLUDED.weight,
+    "updated_at" = CURRENT_TIMESTAMP
+WHERE carts.quantity + EXCLUDED.quantity <= (SELECT count_in_stock FROM products WHERE product_id = EXCLUDED.product_id)
 RETURNING *;
 
 
+-- name: UpdateCartQuantity :one
+UPDATE "carts"
+SET "quantity" = $2,
+    "updated_at" = CURRENT_TIMESTAMP
+WHERE "cart_id" = $1
+AND "user_id" = $3
+AND "deleted_at" IS NULL
+AND $2 <= (SELECT count_in_stock FROM products WHERE product_id = carts.product_id)
+RETURNING *;
+
+
+-- name: GetCartSummary :one
+SELECT
+    COALESCE(SUM(quantity), 0)::INT AS item_count,
+    COALESCE(SUM(weight * quantity), 0)::INT AS total_weight,
+    COALESCE(SUM(price * quantity), 0)::BIGINT AS subtotal
+FROM carts
+WHERE deleted_at IS NULL
+AND user_id = $1;
+
+
 -- name: DeleteCart :exec
-DELETE FROM "carts" WHERE "cart_id" = $1;
+DELETE FROM "carts" WHERE "cart_id" = $1 AND "user_id" = $2;
 
 
 -- name: DeleteAllCart :exec
-DELETE FROM "carts" WHERE "cart_id" = ANY($1::int[]);
+DELETE FROM "carts" WHERE "cart_id" = ANY(sqlc.arg(cart_ids)::int[]) AND "user_id" = sqlc.arg(user_id);
+
+
+-- name: GetCartsForCheckout :many
+SELECT
+    c.cart_id,
+    c.user_id,
+    c.product_id,
+    c.quantity,
+    p.merchant_id
+FROM carts c
+JOIN products p ON p.product_id = c.product_id
+WHERE c.deleted_at IS NULL
+AND c.user_id = $1
+AND ($2::INT = 0 OR p.merchant_id = $2)
+ORDER BY p.merchant_id, c.cart_id
+FOR UPDATE OF c;
@@ -0,0 +1,47 @@
+-- Claim a key for a request, taking over keys whose holder stalled or that expired
+-- name: ClaimIdempotencyKey :one
+INSERT INTO idempotency_keys (user_id, method, idempotency_key, request_hash, locked_at, created_at)
+VALUES (sqlc.arg(user_id), sqlc.arg(method), sqlc.arg(idempotency_key), sqlc.arg(request_hash), sqlc.arg(locked_at)::TIMESTAMP, sqlc.arg(locked_at)::TIMESTAMP)
+ON CONFLICT (user_id, method, idempotency_key) DO UPDATE
+SET request_hash = EXCLUDED.request_hash,
+    response = NULL,
+    failed = FALSE,
+    completed_at = NULL,
+    locked_at = EXCLUDED.locked_at,
+    created_at = EXCLUDED.created_at
+WHERE (idempotency_keys.completed_at IS NULL AND idempotency_keys.locked_at < sqlc.arg(stale_before)::TIMESTAMP)
+   OR idempotency_keys.created_at < sqlc.arg(expired_before)::TIMESTAMP
+RETURNING *;
+
+
+-- name: GetIdempotencyKey :one
+SELECT *
+FROM idempotency_keys
+WHERE user_id = $1
+  AND method = $2
+  AND idempotency_key = $3;
+
+
+-- Store the response of a completed request so retries can replay it
+-- name: CompleteIdempotencyKey :exec
+UPDATE idempotency_keys
+SET response = $2,
+    completed_at = CURRENT_TIMESTAMP
+WHERE idempotency_key_id = $1;
+
+
+-- Store the error a request failed with after it may have had side effects, so
+-- retries get the same error instead of running again
+-- name: FailIdempotencyKey :exec
+UPDATE idempotency_keys
+SET response = $2,
+    failed = TRUE,
+    completed_at = CURRENT_TIMESTAMP
+WHERE idempotency_key_id = $1;
+
+
+-- Drop the claim of a request that failed so it can be retried
+-- name: ReleaseIdempotencyKey :exec
+DELETE FROM idempotency_keys
+WHERE idempotency_key_id = $1
+  AND completed_at IS NULL;
@@ -0,0 +1,3 @@
+-- name: CreateLoginAuditLog :exec
+INSERT INTO login_audit_logs (event, scope, subject, failed_attempts, locked_until, ip_address, actor_id, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
@@ -0,0 +1,31 @@
+-- name: FindLockedLoginThrottles :many
+SELECT *
+FROM login_throttles
+WHERE ((scope = 'account' AND subject = sqlc.arg(account))
+    OR (scope = 'ip' AND subject = sqlc.arg(ip_address)))
+  AND locked_until > sqlc.arg(now)::timestamp;
+
+
+-- Count a failed login, starting over when the last failure is older than reset_before
+-- name: RecordLoginFailure :one
+INSERT INTO login_throttles (scope, subject, failed_attempts, last_failed_at)
+VALUES (sqlc.arg(scope), sqlc.arg(subject), 1, sqlc.arg(now)::timestamp)
+ON CONFLICT (scope, subject) DO UPDATE
+SET failed_attempts = CASE
+        WHEN login_throttles.last_failed_at < sqlc.arg(reset_before)::timestamp THEN 1
+        ELSE login_throttles.failed_attempts + 1
+    END,
+    last_failed_at = EXCLUDED.last_failed_at
+RETURNING *;
+
+
+-- name: LockLoginThrottle :exec
+UPDATE login_throttles
+SET locked_until = $2
+WHERE login_throttle_id = $1;
+
+
+-- Forget the failed logins of an account or IP address, lifting any lock
+-- name: ResetLoginThrottle :execrows
+DELETE FROM login_throttles
+WHERE scope = $1 AND subject = $2;
@@ -34,8 +34,8 @@ LIMIT $2 OFFSET $3;
 
 -- Create Merchant
 -- name: CreateMerchant :one
-INSERT INTO merchants (user_id, name, description, address, contact_email, contact_phone, status)
-VALUES ($1, $2, $3, $4, $5, $6, $7)
+INSERT INTO merchants (user_id, name, description, address, contact_email, contact_phone, status, currency)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 RETURNING *;
 
 -- name: GetMerchantByID :one
@@ -44,6 +44,14 @@ FROM merchants
 WHERE merchant_id = $1
   AND deleted_at IS NULL;
 
+-- Get Merchants owned by a user
+-- name: GetMerchantsByUserID :many
+SELECT *
+FROM merchants
+WHERE user_id = $1
+AND deleted_at IS NULL
+ORDER BY created_at DESC;
+
 -- Update Merchant
 -- name: UpdateMerchant :one
 UPDATE merchants
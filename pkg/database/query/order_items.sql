@@ -36,12 +36,6 @@ LIMIT $2 OFFSET $3;
 
 
 
--- name: CalculateTotalPrice :one
-SELECT COALESCE(SUM(quantity * price), 0)::int AS total_price
-FROM order_items
-WHERE order_id = $1 AND deleted_at IS NULL;
-
-
 
 -- name: CreateOrderItem :one
 INSERT INTO order_items (order_id, product_id, quantity, price)
@@ -55,12 +49,14 @@ FROM order_items
 WHERE order_id = $1
   AND deleted_at IS NULL;
 
+-- Only touches the item when it belongs to the given order
 -- name: UpdateOrderItem :one
 UPDATE order_items
 SET quantity = $2,
     price = $3,
     updated_at = CURRENT_TIMESTAMP
 WHERE order_item_id = $1
+  AND order_id = $4
   AND deleted_at IS NULL
   RETURNING *;
 
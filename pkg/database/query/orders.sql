@@ -9,6 +9,20 @@ ORDER BY created_at DESC
 LIMIT $2 OFFSET $3;
 
 
+-- Get Orders with Keyset Pagination
+-- name: GetOrdersCursor :many
+SELECT *
+FROM orders
+WHERE deleted_at IS NULL
+AND (sqlc.arg(search)::TEXT IS NULL
+       OR order_id::TEXT ILIKE '%' || sqlc.arg(search)::TEXT || '%'
+       OR total_price::TEXT ILIKE '%' || sqlc.arg(search)::TEXT || '%')
+AND (sqlc.narg(cursor_created_at)::TIMESTAMP IS NULL
+       OR (created_at, order_id) < (sqlc.narg(cursor_created_at)::TIMESTAMP, sqlc.narg(cursor_id)::INT))
+ORDER BY created_at DESC, order_id DESC
+LIMIT sqlc.arg(page_limit);
+
+
 -- name: GetOrdersActive :many
 SELECT
     *,
@@ -45,9 +59,10 @@ LIMIT $2 OFFSET $3;
 
 
 
+-- Orders are priced in the currency of the merchant they are placed with
 -- name: CreateOrder :one
-INSERT INTO orders (merchant_id, user_id, total_price)
-VALUES ($1, $2, $3)
+INSERT INTO orders (merchant_id, user_id, total_price, currency)
+VALUES ($1, $2, $3, (SELECT currency FROM merchants WHERE merchant_id = $1))
 RETURNING *;
 
 
@@ -57,17 +72,84 @@ FROM orders
 WHERE order_id = $1
 AND deleted_at IS NULL;
 
+-- Lock an order while a payment is being started for it
+-- name: GetOrderByIDForUpdate :one
+SELECT *
+FROM orders
+WHERE order_id = $1
+AND deleted_at IS NULL
+FOR UPDATE;
+
 
 -- name: UpdateOrder :one
 UPDATE orders
-SET total_price = $2,
-    user_id = $3,
+SET user_id = $2,
+    discount_amount = $3,
     updated_at = CURRENT_TIMESTAMP
 WHERE order_id = $1
   AND deleted_at IS NULL
   RETURNING *;  
 
 
+-- Recompute the payable amount of an order: its items plus shipping cost plus
+-- tax at tax_rate basis points of the items, less the order's discount
+-- name: RecalculateOrderTotal :one
+UPDATE orders o
+SET subtotal = totals.subtotal,
+    shipping_cost = totals.shipping_cost,
+    tax_amount = totals.tax_amount,
+    total_price = GREATEST(totals.subtotal + totals.shipping_cost + totals.tax_amount - o.discount_amount, 0),
+    updated_at = CURRENT_TIMESTAMP
+FROM (
+    SELECT
+        amounts.subtotal,
+        amounts.shipping_cost,
+        ROUND(amounts.subtotal * sqlc.arg(tax_rate)::BIGINT / 10000.0)::BIGINT AS tax_amount
+    FROM (
+        SELECT
+            COALESCE((
+                SELECT SUM(oi.quantity * oi.price)
+                FROM order_items oi
+                WHERE oi.order_id = sqlc.arg(order_id) AND oi.deleted_at IS NULL
+            ), 0)::BIGINT AS subtotal,
+            COALESCE((
+                SELECT sa.shipping_cost
+                FROM shipping_addresses sa
+                WHERE sa.order_id = sqlc.arg(order_id) AND sa.deleted_at IS NULL
+                ORDER BY sa.shipping_address_id DESC
+                LIMIT 1
+            ), 0)::BIGINT AS shipping_cost
+    ) amounts
+) totals
+WHERE o.order_id = sqlc.arg(order_id)
+  AND o.deleted_at IS NULL
+RETURNING o.*;
+
+
+-- Moves an order to a new status only if it is still in the expected one
+-- name: UpdateOrderStatus :one
+UPDATE orders
+SET status = sqlc.arg(status),
+    updated_at = CURRENT_TIMESTAMP
+WHERE order_id = sqlc.arg(order_id)
+  AND status = sqlc.arg(current_status)
+  AND deleted_at IS NULL
+RETURNING *;
+
+
+-- name: CreateOrderStatusHistory :one
+INSERT INTO order_status_histories (order_id, from_status, to_status, note)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+
+-- name: GetOrderStatusHistories :many
+SELECT *
+FROM order_status_histories
+WHERE order_id = $1
+ORDER BY created_at ASC, order_status_history_id ASC;
+
+
 -- name: TrashOrder :one
 UPDATE orders
 SET deleted_at = CURRENT_TIMESTAMP
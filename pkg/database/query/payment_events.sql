@@ -0,0 +1,6 @@
+-- Record a webhook event, skipping redeliveries of an event already stored
+-- name: CreatePaymentEvent :one
+INSERT INTO payment_events (provider, event_id, event_type, transaction_id, payload)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (provider, event_id) DO NOTHING
+RETURNING *;
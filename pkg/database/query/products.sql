@@ -1,16 +1,50 @@
 -- name: GetProducts :many
 SELECT
-    *,
+    p.*,
     COUNT(*) OVER() AS total_count
+FROM products p
+WHERE p.deleted_at IS NULL
+  AND (sqlc.arg(search)::TEXT IS NULL
+         OR p.name ILIKE '%' || sqlc.arg(search)::TEXT || '%'
+         OR p.description ILIKE '%' || sqlc.arg(search)::TEXT || '%'
+         OR p.brand ILIKE '%' || sqlc.arg(search)::TEXT || '%'
+         OR p.slug_product ILIKE '%' || sqlc.arg(search)::TEXT || '%')
+  AND (sqlc.narg(min_price)::BIGINT IS NULL OR p.price >= sqlc.narg(min_price)::BIGINT)
+  AND (sqlc.narg(max_price)::BIGINT IS NULL OR p.price <= sqlc.narg(max_price)::BIGINT)
+  AND (sqlc.narg(min_rating)::FLOAT IS NULL OR p.rating >= sqlc.narg(min_rating)::FLOAT)
+  AND (sqlc.narg(brand)::TEXT IS NULL OR LOWER(p.brand) = LOWER(sqlc.narg(brand)::TEXT))
+  AND (NOT sqlc.arg(in_stock)::BOOLEAN OR p.count_in_stock > 0)
+ORDER BY
+    CASE WHEN sqlc.arg(sort)::TEXT = 'price_asc' THEN p.price END ASC,
+    CASE WHEN sqlc.arg(sort)::TEXT = 'price_desc' THEN p.price END DESC,
+    CASE WHEN sqlc.arg(sort)::TEXT = 'rating' THEN p.rating END DESC NULLS LAST,
+    CASE WHEN sqlc.arg(sort)::TEXT = 'best_selling' THEN (
+        SELECT COALESCE(SUM(oi.quantity), 0)
+        FROM order_items oi
+        JOIN orders o ON o.order_id = oi.order_id
+        WHERE oi.product_id = p.product_id
+          AND oi.deleted_at IS NULL
+          AND o.status IN ('paid', 'processing', 'shipped', 'delivered')
+    ) END DESC,
+    p.created_at DESC,
+    p.product_id DESC
+LIMIT sqlc.arg(page_limit) OFFSET sqlc.arg(page_offset);
+
+
+-- Get Products with Keyset Pagination
+-- name: GetProductsCursor :many
+SELECT *
 FROM products
 WHERE deleted_at IS NULL
-AND ($1::TEXT IS NULL 
-       OR p.name ILIKE '%' || $1 || '%'
-       OR p.description ILIKE '%' || $1 || '%'
-       OR p.brand ILIKE '%' || $1 || '%'
-       OR p.slug_product ILIKE '%' || $1 || '%')
-ORDER BY created_at DESC
-LIMIT $2 OFFSET $3;
+AND (sqlc.arg(search)::TEXT IS NULL
+       OR name ILIKE '%' || sqlc.arg(search)::TEXT || '%'
+       OR description ILIKE '%' || sqlc.arg(search)::TEXT || '%'
+       OR brand ILIKE '%' || sqlc.arg(search)::TEXT || '%'
+       OR slug_product ILIKE '%' || sqlc.arg(search)::TEXT || '%')
+AND (sqlc.narg(cursor_created_at)::TIMESTAMP IS NULL
+       OR (created_at, product_id) < (sqlc.narg(cursor_created_at)::TIMESTAMP, sqlc.narg(cursor_id)::INT))
+ORDER BY created_at DESC, product_id DESC
+LIMIT sqlc.arg(page_limit);
 
 
 -- Get Active Products with Pagination and Total Count
@@ -47,18 +81,54 @@ LIMIT $2 OFFSET $3;
 
 -- name: GetProductsByMerchant :many
 SELECT
-    *,
+    p.*,
     COUNT(*) OVER() AS total_count
-FROM products
-WHERE merchant_id = $1
-AND deleted_at IS NULL
-AND ($2::TEXT IS NULL 
+FROM products p
+WHERE p.merchant_id = sqlc.arg(merchant_id)
+  AND p.deleted_at IS NULL
+  AND (sqlc.arg(search)::TEXT IS NULL
+         OR p.name ILIKE '%' || sqlc.arg(search)::TEXT || '%'
+         OR p.description ILIKE '%' || sqlc.arg(search)::TEXT || '%'
+         OR p.brand ILIKE '%' || sqlc.arg(search)::TEXT || '%'
+         OR p.slug_product ILIKE '%' || sqlc.arg(search)::TEXT || '%')
+  AND (sqlc.narg(min_price)::BIGINT IS NULL OR p.price >= sqlc.narg(min_price)::BIGINT)
+  AND (sqlc.narg(max_price)::BIGINT IS NULL OR p.price <= sqlc.narg(max_price)::BIGINT)
+  AND (sqlc.narg(min_rating)::FLOAT IS NULL OR p.rating >= sqlc.narg(min_rating)::FLOAT)
+  AND (sqlc.narg(brand)::TEXT IS NULL OR LOWER(p.brand) = LOWER(sqlc.narg(brand)::TEXT))
+  AND (NOT sqlc.arg(in_stock)::BOOLEAN OR p.count_in_stock > 0)
+ORDER BY
+    CASE WHEN sqlc.arg(sort)::TEXT = 'price_asc' THEN p.price END ASC,
+    CASE WHEN sqlc.arg(sort)::TEXT = 'price_desc' THEN p.price END DESC,
+    CASE WHEN sqlc.arg(sort)::TEXT = 'rating' THEN p.rating END DESC NULLS LAST,
+    CASE WHEN sqlc.arg(sort)::TEXT = 'best_selling' THEN (
+        SELECT COALESCE(SUM(oi.quantity), 0)
+        FROM order_items oi
+        JOIN orders o ON o.order_id = oi.order_id
+        WHERE oi.product_id = p.product_id
+          AND oi.deleted_at IS NULL
+          AND o.status IN ('paid', 'processing', 'shipped', 'delivered')
+    ) END DESC,
+    p.created_at DESC,
+    p.product_id DESC
+LIMIT sqlc.arg(page_limit) OFFSET sqlc.arg(page_offset);
+
+
+-- Get Products of every merchant owned by a user
+-- name: GetProductsByUserID :many
+SELECT
+    p.*,
+    COUNT(*) OVER() AS total_count
+FROM products p
+JOIN merchants m ON m.merchant_id = p.merchant_id
+WHERE m.user_id = $1
+AND m.deleted_at IS NULL
+AND p.deleted_at IS NULL
+AND ($2::TEXT IS NULL
        OR p.name ILIKE '%' || $2 || '%'
        OR p.description ILIKE '%' || $2 || '%'
        OR p.brand ILIKE '%' || $2 || '%'
-       OR p.slug_product ILIKE '%' || $2 || '%'
-       OR p.barcode ILIKE '%' || $2 || '%')
-ORDER BY created_at DESC
+       OR p.slug_product ILIKE '%' || $2 || '%')
+ORDER BY p.created_at DESC
 LIMIT $3 OFFSET $4;
 
 
@@ -70,16 +140,91 @@ SELECT
 FROM products p
 JOIN categories c ON p.category_id = c.category_id
 WHERE c.deleted_at IS NULL
-  AND c.name = $1 
-  AND ($2::TEXT IS NULL 
-       OR p.name ILIKE '%' || $2 || '%'
-       OR p.description ILIKE '%' || $2 || '%'
-       OR p.brand ILIKE '%' || $2 || '%'
-       OR p.slug_product ILIKE '%' || $2 || '%')
-ORDER BY p.created_at DESC
-LIMIT $3 OFFSET $4;
+  AND c.name = sqlc.arg(category_name)::TEXT
+  AND p.deleted_at IS NULL
+  AND (sqlc.arg(search)::TEXT IS NULL
+         OR p.name ILIKE '%' || sqlc.arg(search)::TEXT || '%'
+         OR p.description ILIKE '%' || sqlc.arg(search)::TEXT || '%'
+         OR p.brand ILIKE '%' || sqlc.arg(search)::TEXT || '%'
+         OR p.slug_product ILIKE '%' || sqlc.arg(search)::TEXT || '%')
+  AND (sqlc.narg(min_price)::BIGINT IS NULL OR p.price >= sqlc.narg(min_price)::BIGINT)
+  AND (sqlc.narg(max_price)::BIGINT IS NULL OR p.price <= sqlc.narg(max_price)::BIGINT)
+  AND (sqlc.narg(min_rating)::FLOAT IS NULL OR p.rating >= sqlc.narg(min_rating)::FLOAT)
+  AND (sqlc.narg(brand)::TEXT IS NULL OR LOWER(p.brand) = LOWER(sqlc.narg(brand)::TEXT))
+  AND (NOT sqlc.arg(in_stock)::BOOLEAN OR p.count_in_stock > 0)
+ORDER BY
+    CASE WHEN sqlc.arg(sort)::TEXT = 'price_asc' THEN p.price END ASC,
+    CASE WHEN sqlc.arg(sort)::TEXT = 'price_desc' THEN p.price END DESC,
+    CASE WHEN sqlc.arg(sort)::TEXT = 'rating' THEN p.rating END DESC NULLS LAST,
+    CASE WHEN sqlc.arg(sort)::TEXT = 'best_selling' THEN (
+        SELECT COALESCE(SUM(oi.quantity), 0)
+        FROM order_items oi
+        JOIN orders o ON o.order_id = oi.order_id
+        WHERE oi.product_id = p.product_id
+          AND oi.deleted_at IS NULL
+          AND o.status IN ('paid', 'processing', 'shipped', 'delivered')
+    ) END DESC,
+    p.created_at DESC,
+    p.product_id DESC
+LIMIT sqlc.arg(page_limit) OFFSET sqlc.arg(page_offset);
+
+
+
+
+-- Search Products ranked by full-text relevance with a trigram fallback on the name
+-- name: SearchProducts :many
+SELECT
+    p.*,
+    COUNT(*) OVER() AS total_count
+FROM products p
+WHERE p.deleted_at IS NULL
+  AND (p.search_vector @@ websearch_to_tsquery('english', sqlc.arg(query)::TEXT)
+       OR p.name % sqlc.arg(query)::TEXT)
+  AND (sqlc.narg(category_id)::INT IS NULL OR p.category_id = sqlc.narg(category_id)::INT)
+  AND (sqlc.narg(merchant_id)::INT IS NULL OR p.merchant_id = sqlc.narg(merchant_id)::INT)
+  AND (sqlc.narg(brand)::TEXT IS NULL OR p.brand = sqlc.narg(brand)::TEXT)
+  AND (sqlc.narg(min_price)::BIGINT IS NULL OR p.price >= sqlc.narg(min_price)::BIGINT)
+  AND (sqlc.narg(max_price)::BIGINT IS NULL OR p.price <= sqlc.narg(max_price)::BIGINT)
+ORDER BY
+    ts_rank(p.search_vector, websearch_to_tsquery('english', sqlc.arg(query)::TEXT)) DESC,
+    similarity(p.name, sqlc.arg(query)::TEXT) DESC,
+    p.product_id DESC
+LIMIT sqlc.arg(page_limit) OFFSET sqlc.arg(page_offset);
 
 
+-- Count the Products matched by a search per category, brand, merchant and price bucket
+-- name: SearchProductFacets :many
+WITH matched AS (
+    SELECT p.category_id, p.merchant_id, p.brand, p.price
+    FROM products p
+    WHERE p.deleted_at IS NULL
+      AND (p.search_vector @@ websearch_to_tsquery('english', sqlc.arg(query)::TEXT)
+           OR p.name % sqlc.arg(query)::TEXT)
+      AND (sqlc.narg(category_id)::INT IS NULL OR p.category_id = sqlc.narg(category_id)::INT)
+      AND (sqlc.narg(merchant_id)::INT IS NULL OR p.merchant_id = sqlc.narg(merchant_id)::INT)
+      AND (sqlc.narg(brand)::TEXT IS NULL OR p.brand = sqlc.narg(brand)::TEXT)
+      AND (sqlc.narg(min_price)::BIGINT IS NULL OR p.price >= sqlc.narg(min_price)::BIGINT)
+      AND (sqlc.narg(max_price)::BIGINT IS NULL OR p.price <= sqlc.narg(max_price)::BIGINT)
+)
+SELECT 'category'::TEXT AS facet, c.category_id::TEXT AS value, c.name::TEXT AS label, COUNT(*) AS count
+FROM matched m
+JOIN categories c ON c.category_id = m.category_id
+GROUP BY c.category_id, c.name
+UNION ALL
+SELECT 'brand'::TEXT, m.brand::TEXT, m.brand::TEXT, COUNT(*)
+FROM matched m
+WHERE m.brand IS NOT NULL
+GROUP BY m.brand
+UNION ALL
+SELECT 'merchant'::TEXT, mc.merchant_id::TEXT, mc.name::TEXT, COUNT(*)
+FROM matched m
+JOIN merchants mc ON mc.merchant_id = m.merchant_id
+GROUP BY mc.merchant_id, mc.name
+UNION ALL
+SELECT 'price'::TEXT, width_bucket(m.price, sqlc.arg(price_bounds)::BIGINT[])::TEXT, ''::TEXT, COUNT(*)
+FROM matched m
+GROUP BY 2
+ORDER BY facet, count DESC, value;
 
 
 -- name: CreateProduct :one
@@ -119,6 +264,25 @@ WHERE product_id = $1
     AND deleted_at IS NULL
 RETURNING *;
 
+
+-- Atomically take $2 units out of stock; returns no row when not enough is left
+-- name: ReserveProductStock :one
+UPDATE products
+SET count_in_stock = count_in_stock - $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE product_id = $1
+    AND deleted_at IS NULL
+    AND count_in_stock >= $2
+RETURNING *;
+
+-- Puts $2 units back into stock, e.g. when an order is cancelled
+-- name: ReleaseProductStock :one
+UPDATE products
+SET count_in_stock = count_in_stock + $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE product_id = $1
+RETURNING *;
+
 -- Trash Product
 -- name: TrashProduct :one
 UPDATE products
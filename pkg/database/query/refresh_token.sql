@@ -1,15 +1,16 @@
 -- name: CreateRefreshToken :one
-INSERT INTO refresh_tokens (user_id, token, expiration, created_at, updated_at)
-VALUES ($1, $2, $3, current_timestamp, current_timestamp)
-RETURNING refresh_token_id, user_id, token, expiration, created_at, updated_at, deleted_at;
+INSERT INTO refresh_tokens (user_id, token, family_id, device, user_agent, ip_address, signed_in_at, expiration, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, current_timestamp, current_timestamp)
+RETURNING refresh_token_id, user_id, token, expiration, created_at, updated_at, deleted_at, family_id, device, user_agent, ip_address, signed_in_at, used_at, revoked_at;
 
 -- name: FindRefreshTokenByToken :one
-SELECT refresh_token_id, user_id, token, expiration, created_at, updated_at, deleted_at
+SELECT refresh_token_id, user_id, token, expiration, created_at, updated_at, deleted_at, family_id, device, user_agent, ip_address, signed_in_at, used_at, revoked_at
 FROM refresh_tokens
 WHERE token = $1 AND deleted_at IS NULL;
 
 
--- name: FindRefreshTokenByUserId :one
+-- The current token of every session a user is still signed in with
+-- name: FindActiveRefreshTokensByUserId :many
 SELECT
     refresh_token_id,
     user_id,
@@ -17,27 +18,45 @@ SELECT
     expiration,
     created_at,
     updated_at,
-    deleted_at
+    deleted_at,
+    family_id,
+    device,
+    user_agent,
+    ip_address,
+    signed_in_at,
+    used_at,
+    revoked_at
 FROM
     refresh_tokens
 WHERE
-    user_id = $1 AND deleted_at IS NULL
+    user_id = $1
+    AND used_at IS NULL
+    AND revoked_at IS NULL
+    AND expiration > current_timestamp
+    AND deleted_at IS NULL
 ORDER BY
-    created_at DESC
-LIMIT 1;
+    created_at DESC;
 
 
-
--- name: UpdateRefreshTokenByUserId :exec
+-- Marks a token as exchanged, only if it has not been used or revoked yet
+-- name: MarkRefreshTokenUsed :one
 UPDATE refresh_tokens
-SET token = $2, expiration = $3, updated_at = current_timestamp
-WHERE user_id = $1 AND deleted_at IS NULL;
+SET used_at = current_timestamp, updated_at = current_timestamp
+WHERE refresh_token_id = $1 AND used_at IS NULL AND revoked_at IS NULL
+RETURNING refresh_token_id, user_id, token, expiration, created_at, updated_at, deleted_at, family_id, device, user_agent, ip_address, signed_in_at, used_at, revoked_at;
 
 
--- name: DeleteRefreshToken :exec
-DELETE FROM refresh_tokens
-WHERE token = $1;
+-- name: RevokeRefreshTokenFamily :exec
+UPDATE refresh_tokens
+SET revoked_at = current_timestamp, updated_at = current_timestamp
+WHERE family_id = $1 AND revoked_at IS NULL;
 
--- name: DeleteRefreshTokenByUserId :exec
-DELETE FROM refresh_tokens
-WHERE user_id = $1;
+-- name: RevokeRefreshTokenFamilyByUserId :execrows
+UPDATE refresh_tokens
+SET revoked_at = current_timestamp, updated_at = current_timestamp
+WHERE user_id = $1 AND family_id = $2 AND revoked_at IS NULL;
+
+-- name: RevokeRefreshTokensByUserId :exec
+UPDATE refresh_tokens
+SET revoked_at = current_timestamp, updated_at = current_timestamp
+WHERE user_id = $1 AND revoked_at IS NULL;
@@ -0,0 +1,49 @@
+-- name: CreateRefund :one
+INSERT INTO refunds (transaction_id, amount, reason, provider_refund_id, status)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+
+-- name: CreateRefundItem :one
+INSERT INTO refund_items (refund_id, order_item_id, quantity, amount)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+
+-- Quantity of every order item already refunded on a transaction, counting
+-- refunds still pending with the payment provider
+-- name: GetRefundedQuantitiesByTransaction :many
+SELECT
+    ri.order_item_id,
+    SUM(ri.quantity)::INT AS refunded_quantity
+FROM refund_items ri
+JOIN refunds r ON r.refund_id = ri.refund_id
+WHERE r.transaction_id = $1
+  AND r.status <> 'failed'
+GROUP BY ri.order_item_id;
+
+
+-- Record the payment provider outcome of a pending refund
+-- name: UpdateRefundStatus :one
+UPDATE refunds
+SET status = sqlc.arg(status),
+    provider_refund_id = sqlc.narg(provider_refund_id)
+WHERE refund_id = sqlc.arg(refund_id)
+  AND status = 'pending'
+RETURNING *;
+
+
+-- Refunds of a transaction still waiting on the payment provider outcome
+-- name: GetPendingRefundsByTransaction :many
+SELECT *
+FROM refunds
+WHERE transaction_id = $1
+  AND status = 'pending'
+ORDER BY refund_id;
+
+
+-- name: GetRefundItemsByRefund :many
+SELECT *
+FROM refund_items
+WHERE refund_id = $1
+ORDER BY refund_item_id;
@@ -9,6 +9,20 @@ ORDER BY created_at DESC
 LIMIT $2 OFFSET $3;
 
 
+-- Get Reviews with Keyset Pagination
+-- name: GetReviewsCursor :many
+SELECT *
+FROM reviews
+WHERE deleted_at IS NULL
+AND (sqlc.arg(search)::TEXT IS NULL
+       OR review_id::TEXT ILIKE '%' || sqlc.arg(search)::TEXT || '%'
+       OR name ILIKE '%' || sqlc.arg(search)::TEXT || '%')
+AND (sqlc.narg(cursor_created_at)::TIMESTAMP IS NULL
+       OR (created_at, review_id) < (sqlc.narg(cursor_created_at)::TIMESTAMP, sqlc.narg(cursor_id)::INT))
+ORDER BY created_at DESC, review_id DESC
+LIMIT sqlc.arg(page_limit);
+
+
 -- name: GetReviewsActive :many
 SELECT
     *,
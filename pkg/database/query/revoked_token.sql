@@ -0,0 +1,23 @@
+-- Revoke a single access token by its jti
+-- name: RevokeAccessToken :exec
+INSERT INTO revoked_tokens (jti, user_id, reason, revoked_at, expires_at)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (jti) DO NOTHING;
+
+
+-- Revoke every access token of a user issued before revoked_at
+-- name: RevokeUserAccessTokens :exec
+INSERT INTO revoked_tokens (user_id, reason, revoked_at, expires_at)
+VALUES ($1, $2, $3, $4);
+
+
+-- name: FindActiveRevokedTokens :many
+SELECT *
+FROM revoked_tokens
+WHERE expires_at > $1;
+
+
+-- Drop revocations no token they cover can outlive
+-- name: DeleteExpiredRevokedTokens :exec
+DELETE FROM revoked_tokens
+WHERE expires_at <= $1;
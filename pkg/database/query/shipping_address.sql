@@ -52,6 +52,7 @@ RETURNING *;
 
 
 
+-- Only touches the address when it belongs to the given order
 -- name: UpdateShippingAddress :one
 UPDATE shipping_addresses
 SET 
@@ -64,6 +65,7 @@ SET
     shipping_cost = $8,
     updated_at = CURRENT_TIMESTAMP
 WHERE shipping_address_id = $1
+AND order_id = $9
 AND deleted_at IS NULL
 RETURNING *;
 
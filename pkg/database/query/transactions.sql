@@ -9,6 +9,20 @@ ORDER BY created_at DESC
 LIMIT $2 OFFSET $3;
 
 
+-- Get Transactions with Keyset Pagination
+-- name: GetTransactionsCursor :many
+SELECT *
+FROM transactions
+WHERE deleted_at IS NULL
+AND (sqlc.arg(search)::TEXT IS NULL
+       OR payment_method ILIKE '%' || sqlc.arg(search)::TEXT || '%'
+       OR payment_status ILIKE '%' || sqlc.arg(search)::TEXT || '%')
+AND (sqlc.narg(cursor_created_at)::TIMESTAMP IS NULL
+       OR (created_at, transaction_id) < (sqlc.narg(cursor_created_at)::TIMESTAMP, sqlc.narg(cursor_id)::INT))
+ORDER BY created_at DESC, transaction_id DESC
+LIMIT sqlc.arg(page_limit);
+
+
 -- Get Active Transactions with Pagination and Total Count
 -- name: GetTransactionsActive :many
 SELECT
@@ -47,8 +61,8 @@ LIMIT $3 OFFSET $4;
 
 -- name: CreateTransaction :one
 INSERT INTO transactions (
-    order_id, merchant_id, payment_method, amount, payment_status
-) VALUES ($1, $2, $3, $4, $5)
+    order_id, merchant_id, payment_method, amount, amount_due, change_amount, payment_status
+) VALUES ($1, $2, $3, $4, $5, $6, $7)
 RETURNING *;
 
 
@@ -58,6 +72,34 @@ FROM transactions
 WHERE order_id = $1
   AND deleted_at IS NULL;
 
+-- Whether an order already has a transaction that is awaiting or has
+-- collected payment
+-- name: HasLiveTransactionByOrderID :one
+SELECT EXISTS (
+    SELECT 1
+    FROM transactions
+    WHERE order_id = $1
+      AND payment_status IN ('pending', 'paid')
+      AND deleted_at IS NULL
+);
+
+-- Lock the transaction a provider charge belongs to
+-- name: GetTransactionByProviderChargeID :one
+SELECT *
+FROM transactions
+WHERE payment_provider = $1
+  AND provider_charge_id = $2
+  AND deleted_at IS NULL
+FOR UPDATE;
+
+-- Lock a transaction while it is being refunded
+-- name: GetTransactionByIDForUpdate :one
+SELECT *
+FROM transactions
+WHERE transaction_id = $1
+  AND deleted_at IS NULL
+FOR UPDATE;
+
 -- name: GetTransactionByID :one
 SELECT *
 FROM transactions
@@ -65,13 +107,42 @@ WHERE transaction_id = $1
   AND deleted_at IS NULL;
 
 
+-- Amend the payment of a transaction whose outcome is still pending; the order
+-- and merchant it pays are fixed when it is created
 -- name: UpdateTransaction :one
 UPDATE transactions
-SET merchant_id = $2,
-    payment_method = $3,
-    amount = $4,
-    payment_status = $5,
-    order_id = $6,
+SET payment_method = $2,
+    amount = $3,
+    amount_due = $4,
+    change_amount = $5,
+    updated_at = CURRENT_TIMESTAMP
+WHERE transaction_id = $1
+  AND payment_status = 'pending'
+  AND deleted_at IS NULL
+RETURNING *;
+
+
+-- Record the payment provider outcome of a transaction
+-- name: UpdateTransactionPayment :one
+UPDATE transactions
+SET payment_status = sqlc.arg(payment_status),
+    payment_provider = sqlc.narg(payment_provider),
+    provider_charge_id = sqlc.narg(provider_charge_id),
+    updated_at = CURRENT_TIMESTAMP
+WHERE transaction_id = sqlc.arg(transaction_id)
+  AND deleted_at IS NULL
+RETURNING *;
+
+
+-- Recompute the refunded total of a transaction from its succeeded refunds
+-- name: UpdateTransactionRefundedAmount :one
+UPDATE transactions
+SET refunded_amount = (
+        SELECT COALESCE(SUM(r.amount), 0)
+        FROM refunds r
+        WHERE r.transaction_id = transactions.transaction_id
+          AND r.status = 'succeeded'
+    ),
     updated_at = CURRENT_TIMESTAMP
 WHERE transaction_id = $1
   AND deleted_at IS NULL
@@ -0,0 +1,24 @@
+-- Consume a single-use token, only while it is unused and unexpired
+-- name: ConsumeUserToken :one
+UPDATE user_tokens
+SET used_at = sqlc.arg(now)::timestamp
+WHERE token = sqlc.arg(token)
+  AND purpose = sqlc.arg(purpose)
+  AND used_at IS NULL
+  AND expires_at > sqlc.arg(now)::timestamp
+RETURNING *;
+
+
+-- name: CreateUserToken :one
+INSERT INTO user_tokens (user_id, purpose, token, expires_at)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+
+-- Invalidate the outstanding tokens of a user for one purpose
+-- name: InvalidateUserTokens :exec
+UPDATE user_tokens
+SET used_at = sqlc.arg(now)::timestamp
+WHERE user_id = sqlc.arg(user_id)
+  AND purpose = sqlc.arg(purpose)
+  AND used_at IS NULL;
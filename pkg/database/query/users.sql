@@ -125,3 +125,27 @@ WHERE
 DELETE FROM users
 WHERE
     deleted_at IS NOT NULL;
+
+
+-- Update User Password
+-- name: UpdateUserPassword :one
+UPDATE users
+SET
+    password = $2,
+    updated_at = current_timestamp
+WHERE
+    user_id = $1
+    AND deleted_at IS NULL
+    RETURNING *;
+
+
+-- Mark User Email as Verified
+-- name: VerifyUserEmail :one
+UPDATE users
+SET
+    email_verified_at = COALESCE(email_verified_at, current_timestamp),
+    updated_at = current_timestamp
+WHERE
+    user_id = $1
+    AND deleted_at IS NULL
+    RETURNING *;
@@ -12,32 +12,43 @@ import (
 	"github.com/lib/pq"
 )
 
-const createCart = `-- name: CreateCart :one
-INSERT INTO "carts" ("user_id", "product_id", "name", "price", "image", "quantity", "weight")
-VALUES ($1, $2, $3, $4, $5, $6, $7)
-RETURNING cart_id, user_id, product_id, name, price, image, quantity, weight, created_at, updated_at, deleted_at
+const deleteAllCart = `-- name: DeleteAllCart :exec
+DELETE FROM "carts" WHERE "cart_id" = ANY($1::int[]) AND "user_id" = $2
 `
 
-type CreateCartParams struct {
-	UserID    int32  `json:"user_id"`
-	ProductID int32  `json:"product_id"`
-	Name      string `json:"name"`
-	Price     int32  `json:"price"`
-	Image     string `json:"image"`
-	Quantity  int32  `json:"quantity"`
-	Weight    int32  `json:"weight"`
+type DeleteAllCartParams struct {
+	CartIds []int32 `json:"cart_ids"`
+	UserID  int32   `json:"user_id"`
 }
 
-func (q *Queries) CreateCart(ctx context.Context, arg CreateCartParams) (*Cart, error) {
-	row := q.db.QueryRowContext(ctx, createCart,
-		arg.UserID,
-		arg.ProductID,
-		arg.Name,
-		arg.Price,
-		arg.Image,
-		arg.Quantity,
-		arg.Weight,
-	)
+func (q *Queries) DeleteAllCart(ctx context.Context, arg DeleteAllCartParams) error {
+	_, err := q.db.ExecContext(ctx, deleteAllCart, pq.Array(arg.CartIds), arg.UserID)
+	return err
+}
+
+const deleteCart = `-- name: DeleteCart :exec
+DELETE FROM "carts" WHERE "cart_id" = $1 AND "user_id" = $2
+`
+
+type DeleteCartParams struct {
+	CartID int32 `json:"cart_id"`
+	UserID int32 `json:"user_id"`
+}
+
+func (q *Queries) DeleteCart(ctx context.Context, arg DeleteCartParams) error {
+	_, err := q.db.ExecContext(ctx, deleteCart, arg.CartID, arg.UserID)
+	return err
+}
+
+const getCart = `-- name: GetCart :one
+SELECT cart_id, user_id, product_id, name, price, image, quantity, weight, created_at, updated_at, deleted_at
+FROM carts
+WHERE cart_id = $1
+AND deleted_at IS NULL
+`
+
+func (q *Queries) GetCart(ctx context.Context, cartID int32) (*Cart, error) {
+	row := q.db.QueryRowContext(ctx, getCart, cartID)
 	var i Cart
 	err := row.Scan(
 		&i.CartID,
@@ -55,22 +66,27 @@ func (q *Queries) CreateCart(ctx context.Context, arg CreateCartParams) (*Cart,
 	return &i, err
 }
 
-const deleteAllCart = `-- name: DeleteAllCart :exec
-DELETE FROM "carts" WHERE "cart_id" = ANY($1::int[])
+const getCartSummary = `-- name: GetCartSummary :one
+SELECT
+    COALESCE(SUM(quantity), 0)::INT AS item_count,
+    COALESCE(SUM(weight * quantity), 0)::INT AS total_weight,
+    COALESCE(SUM(price * quantity), 0)::BIGINT AS subtotal
+FROM carts
+WHERE deleted_at IS NULL
+AND user_id = $1
 `
 
-func (q *Queries) DeleteAllCart(ctx context.Context, dollar_1 []int32) error {
-	_, err := q.db.ExecContext(ctx, deleteAllCart, pq.Array(dollar_1))
-	return err
+type GetCartSummaryRow struct {
+	ItemCount   int32 `json:"item_count"`
+	TotalWeight int32 `json:"total_weight"`
+	Subtotal    int64 `json:"subtotal"`
 }
 
-const deleteCart = `-- name: DeleteCart :exec
-DELETE FROM "carts" WHERE "cart_id" = $1
-`
-
-func (q *Queries) DeleteCart(ctx context.Context, cartID int32) error {
-	_, err := q.db.ExecContext(ctx, deleteCart, cartID)
-	return err
+func (q *Queries) GetCartSummary(ctx context.Context, userID int32) (*GetCartSummaryRow, error) {
+	row := q.db.QueryRowContext(ctx, getCartSummary, userID)
+	var i GetCartSummaryRow
+	err := row.Scan(&i.ItemCount, &i.TotalWeight, &i.Subtotal)
+	return &i, err
 }
 
 const getCarts = `-- name: GetCarts :many
@@ -97,7 +113,7 @@ type GetCartsRow struct {
 	UserID     int32        `json:"user_id"`
 	ProductID  int32        `json:"product_id"`
 	Name       string       `json:"name"`
-	Price      int32        `json:"price"`
+	Price      int64        `json:"price"`
 	Image      string       `json:"image"`
 	Quantity   int32        `json:"quantity"`
 	Weight     int32        `json:"weight"`
@@ -147,3 +163,159 @@ func (q *Queries) GetCarts(ctx context.Context, arg GetCartsParams) ([]*GetCarts
 	}
 	return items, nil
 }
+
+const getCartsForCheckout = `-- name: GetCartsForCheckout :many
+SELECT
+    c.cart_id,
+    c.user_id,
+    c.product_id,
+    c.quantity,
+    p.merchant_id
+FROM carts c
+JOIN products p ON p.product_id = c.product_id
+WHERE c.deleted_at IS NULL
+AND c.user_id = $1
+AND ($2::INT = 0 OR p.merchant_id = $2)
+ORDER BY p.merchant_id, c.cart_id
+FOR UPDATE OF c
+`
+
+type GetCartsForCheckoutParams struct {
+	UserID  int32 `json:"user_id"`
+	Column2 int32 `json:"column_2"`
+}
+
+type GetCartsForCheckoutRow struct {
+	CartID     int32 `json:"cart_id"`
+	UserID     int32 `json:"user_id"`
+	ProductID  int32 `json:"product_id"`
+	Quantity   int32 `json:"quantity"`
+	MerchantID int32 `json:"merchant_id"`
+}
+
+func (q *Queries) GetCartsForCheckout(ctx context.Context, arg GetCartsForCheckoutParams) ([]*GetCartsForCheckoutRow, error) {
+	rows, err := q.db.QueryContext(ctx, getCartsForCheckout, arg.UserID, arg.Column2)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*GetCartsForCheckoutRow
+	for rows.Next() {
+		var i GetCartsForCheckoutRow
+		if err := rows.Scan(
+			&i.CartID,
+			&i.UserID,
+			&i.ProductID,
+			&i.Quantity,
+			&i.MerchantID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateCartQuantity = `-- name: UpdateCartQuantity :one
+UPDATE "carts"
+SET "quantity" = $2,
+    "updated_at" = CURRENT_TIMESTAMP
+WHERE "cart_id" = $1
+AND "user_id" = $3
+AND "deleted_at" IS NULL
+AND $2 <= (SELECT count_in_stock FROM products WHERE product_id = carts.product_id)
+RETURNING cart_id, user_id, product_id, name, price, image, quantity, weight, created_at, updated_at, deleted_at
+`
+
+type UpdateCartQuantityParams struct {
+	CartID   int32 `json:"cart_id"`
+	Quantity int32 `json:"quantity"`
+	UserID   int32 `json:"user_id"`
+}
+
+func (q *Queries) UpdateCartQuantity(ctx context.Context, arg UpdateCartQuantityParams) (*Cart, error) {
+	row := q.db.QueryRowContext(ctx, updateCartQuantity, arg.CartID, arg.Quantity, arg.UserID)
+	var i Cart
+	err := row.Scan(
+		&i.CartID,
+		&i.UserID,
+		&i.ProductID,
+		&i.Name,
+		&i.Price,
+		&i.Image,
+		&i.Quantity,
+		&i.Weight,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return &i, err
+}
+
+const upsertCart = `-- name: UpsertCart :one
+INSERT INTO "carts" ("user_id", "product_id", "name", "price", "image", "quantity", "weight")
+SELECT
+    $1::INT,
+    $2::INT,
+    $3::VARCHAR,
+    $4::BIGINT,
+    $5::VARCHAR,
+    $6::INT,
+    $7::INT
+WHERE $6::INT <= (SELECT count_in_stock FROM products WHERE product_id = $2::INT)
+ON CONFLICT ("user_id", "product_id") DO UPDATE
+SET "quantity" = carts.quantity + EXCLUDED.quantity,
+    "name" = EXCLUDED.name,
+    "price" = EXCLUDED.price,
+    "image" = EXCLUDED.image,
+    "weight" = EXCLUDED.weight,
+    "updated_at" = CURRENT_TIMESTAMP
+WHERE carts.quantity + EXCLUDED.quantity <= (SELECT count_in_stock FROM products WHERE product_id = EXCLUDED.product_id)
+RETURNING cart_id, user_id, product_id, name, price, image, quantity, weight, created_at, updated_at, deleted_at
+`
+
+type UpsertCartParams struct {
+	UserID    int32  `json:"user_id"`
+	ProductID int32  `json:"product_id"`
+	Name      string `json:"name"`
+	Price     int64  `json:"price"`
+	Image     string `json:"image"`
+	Quantity  int32  `json:"quantity"`
+	Weight    int32  `json:"weight"`
+}
+
+// Adds the product to the user's cart, merging into an existing row for the
+// same product. No row is returned when the merged quantity would exceed the
+// product's stock.
+func (q *Queries) UpsertCart(ctx context.Context, arg UpsertCartParams) (*Cart, error) {
+	row := q.db.QueryRowContext(ctx, upsertCart,
+		arg.UserID,
+		arg.ProductID,
+		arg.Name,
+		arg.Price,
+		arg.Image,
+		arg.Quantity,
+		arg.Weight,
+	)
+	var i Cart
+	err := row.Scan(
+		&i.CartID,
+		&i.UserID,
+		&i.ProductID,
+		&i.Name,
+		&i.Price,
+		&i.Image,
+		&i.Quantity,
+		&i.Weight,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return &i, err
+}
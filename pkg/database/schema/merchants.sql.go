@@ -11,9 +11,9 @@ import (
 )
 
 const createMerchant = `-- name: CreateMerchant :one
-INSERT INTO merchants (user_id, name, description, address, contact_email, contact_phone, status)
-VALUES ($1, $2, $3, $4, $5, $6, $7)
-RETURNING merchant_id, user_id, name, description, address, contact_email, contact_phone, status, created_at, updated_at, deleted_at
+INSERT INTO merchants (user_id, name, description, address, contact_email, contact_phone, status, currency)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING merchant_id, user_id, name, description, address, contact_email, contact_phone, status, created_at, updated_at, deleted_at, currency
 `
 
 type CreateMerchantParams struct {
@@ -24,6 +24,7 @@ type CreateMerchantParams struct {
 	ContactEmail sql.NullString `json:"contact_email"`
 	ContactPhone sql.NullString `json:"contact_phone"`
 	Status       string         `json:"status"`
+	Currency     string         `json:"currency"`
 }
 
 // Create Merchant
@@ -36,6 +37,7 @@ func (q *Queries) CreateMerchant(ctx context.Context, arg CreateMerchantParams)
 		arg.ContactEmail,
 		arg.ContactPhone,
 		arg.Status,
+		arg.Currency,
 	)
 	var i Merchant
 	err := row.Scan(
@@ -50,6 +52,7 @@ func (q *Queries) CreateMerchant(ctx context.Context, arg CreateMerchantParams)
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Currency,
 	)
 	return &i, err
 }
@@ -77,7 +80,7 @@ func (q *Queries) DeleteMerchantPermanently(ctx context.Context, merchantID int3
 }
 
 const getMerchantByID = `-- name: GetMerchantByID :one
-SELECT merchant_id, user_id, name, description, address, contact_email, contact_phone, status, created_at, updated_at, deleted_at
+SELECT merchant_id, user_id, name, description, address, contact_email, contact_phone, status, created_at, updated_at, deleted_at, currency
 FROM merchants
 WHERE merchant_id = $1
   AND deleted_at IS NULL
@@ -98,13 +101,14 @@ func (q *Queries) GetMerchantByID(ctx context.Context, merchantID int32) (*Merch
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Currency,
 	)
 	return &i, err
 }
 
 const getMerchants = `-- name: GetMerchants :many
 SELECT
-    merchant_id, user_id, name, description, address, contact_email, contact_phone, status, created_at, updated_at, deleted_at,
+    merchant_id, user_id, name, description, address, contact_email, contact_phone, status, created_at, updated_at, deleted_at, currency,
     COUNT(*) OVER() AS total_count
 FROM merchants
 WHERE deleted_at IS NULL
@@ -131,6 +135,7 @@ type GetMerchantsRow struct {
 	CreatedAt    sql.NullTime   `json:"created_at"`
 	UpdatedAt    sql.NullTime   `json:"updated_at"`
 	DeletedAt    sql.NullTime   `json:"deleted_at"`
+	Currency     string         `json:"currency"`
 	TotalCount   int64          `json:"total_count"`
 }
 
@@ -155,6 +160,7 @@ func (q *Queries) GetMerchants(ctx context.Context, arg GetMerchantsParams) ([]*
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.Currency,
 			&i.TotalCount,
 		); err != nil {
 			return nil, err
@@ -172,7 +178,7 @@ func (q *Queries) GetMerchants(ctx context.Context, arg GetMerchantsParams) ([]*
 
 const getMerchantsActive = `-- name: GetMerchantsActive :many
 SELECT
-    merchant_id, user_id, name, description, address, contact_email, contact_phone, status, created_at, updated_at, deleted_at,
+    merchant_id, user_id, name, description, address, contact_email, contact_phone, status, created_at, updated_at, deleted_at, currency,
     COUNT(*) OVER() AS total_count
 FROM merchants
 WHERE deleted_at IS NULL
@@ -199,6 +205,7 @@ type GetMerchantsActiveRow struct {
 	CreatedAt    sql.NullTime   `json:"created_at"`
 	UpdatedAt    sql.NullTime   `json:"updated_at"`
 	DeletedAt    sql.NullTime   `json:"deleted_at"`
+	Currency     string         `json:"currency"`
 	TotalCount   int64          `json:"total_count"`
 }
 
@@ -224,6 +231,7 @@ func (q *Queries) GetMerchantsActive(ctx context.Context, arg GetMerchantsActive
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.Currency,
 			&i.TotalCount,
 		); err != nil {
 			return nil, err
@@ -239,9 +247,54 @@ func (q *Queries) GetMerchantsActive(ctx context.Context, arg GetMerchantsActive
 	return items, nil
 }
 
+const getMerchantsByUserID = `-- name: GetMerchantsByUserID :many
+SELECT merchant_id, user_id, name, description, address, contact_email, contact_phone, status, created_at, updated_at, deleted_at, currency
+FROM merchants
+WHERE user_id = $1
+AND deleted_at IS NULL
+ORDER BY created_at DESC
+`
+
+// Get Merchants owned by a user
+func (q *Queries) GetMerchantsByUserID(ctx context.Context, userID int32) ([]*Merchant, error) {
+	rows, err := q.db.QueryContext(ctx, getMerchantsByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*Merchant
+	for rows.Next() {
+		var i Merchant
+		if err := rows.Scan(
+			&i.MerchantID,
+			&i.UserID,
+			&i.Name,
+			&i.Description,
+			&i.Address,
+			&i.ContactEmail,
+			&i.ContactPhone,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.Currency,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getMerchantsTrashed = `-- name: GetMerchantsTrashed :many
 SELECT
-    merchant_id, user_id, name, description, address, contact_email, contact_phone, status, created_at, updated_at, deleted_at,
+    merchant_id, user_id, name, description, address, contact_email, contact_phone, status, created_at, updated_at, deleted_at, currency,
     COUNT(*) OVER() AS total_count
 FROM merchants
 WHERE deleted_at IS NOT NULL
@@ -268,6 +321,7 @@ type GetMerchantsTrashedRow struct {
 	CreatedAt    sql.NullTime   `json:"created_at"`
 	UpdatedAt    sql.NullTime   `json:"updated_at"`
 	DeletedAt    sql.NullTime   `json:"deleted_at"`
+	Currency     string         `json:"currency"`
 	TotalCount   int64          `json:"total_count"`
 }
 
@@ -293,6 +347,7 @@ func (q *Queries) GetMerchantsTrashed(ctx context.Context, arg GetMerchantsTrash
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.Currency,
 			&i.TotalCount,
 		); err != nil {
 			return nil, err
@@ -329,7 +384,7 @@ SET
 WHERE
     merchant_id = $1
     AND deleted_at IS NOT NULL
-  RETURNING merchant_id, user_id, name, description, address, contact_email, contact_phone, status, created_at, updated_at, deleted_at
+  RETURNING merchant_id, user_id, name, description, address, contact_email, contact_phone, status, created_at, updated_at, deleted_at, currency
 `
 
 // Restore Trashed Merchant
@@ -348,6 +403,7 @@ func (q *Queries) RestoreMerchant(ctx context.Context, merchantID int32) (*Merch
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Currency,
 	)
 	return &i, err
 }
@@ -359,7 +415,7 @@ SET
 WHERE
     merchant_id = $1
     AND deleted_at IS NULL
-    RETURNING merchant_id, user_id, name, description, address, contact_email, contact_phone, status, created_at, updated_at, deleted_at
+    RETURNING merchant_id, user_id, name, description, address, contact_email, contact_phone, status, created_at, updated_at, deleted_at, currency
 `
 
 // Trash Merchant
@@ -378,6 +434,7 @@ func (q *Queries) TrashMerchant(ctx context.Context, merchantID int32) (*Merchan
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Currency,
 	)
 	return &i, err
 }
@@ -393,7 +450,7 @@ SET name = $2,
     updated_at = CURRENT_TIMESTAMP
 WHERE merchant_id = $1
   AND deleted_at IS NULL
-  RETURNING merchant_id, user_id, name, description, address, contact_email, contact_phone, status, created_at, updated_at, deleted_at
+  RETURNING merchant_id, user_id, name, description, address, contact_email, contact_phone, status, created_at, updated_at, deleted_at, currency
 `
 
 type UpdateMerchantParams struct {
@@ -430,6 +487,7 @@ func (q *Queries) UpdateMerchant(ctx context.Context, arg UpdateMerchantParams)
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Currency,
 	)
 	return &i, err
 }
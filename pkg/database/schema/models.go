@@ -6,6 +6,7 @@ package db
 
 import (
 	"database/sql"
+	"encoding/json"
 	"time"
 )
 
@@ -14,7 +15,7 @@ type Cart struct {
 	UserID    int32        `json:"user_id"`
 	ProductID int32        `json:"product_id"`
 	Name      string       `json:"name"`
-	Price     int32        `json:"price"`
+	Price     int64        `json:"price"`
 	Image     string       `json:"image"`
 	Quantity  int32        `json:"quantity"`
 	Weight    int32        `json:"weight"`
@@ -34,6 +35,40 @@ type Category struct {
 	DeletedAt     sql.NullTime   `json:"deleted_at"`
 }
 
+type IdempotencyKey struct {
+	IdempotencyKeyID int32        `json:"idempotency_key_id"`
+	UserID           int32        `json:"user_id"`
+	Method           string       `json:"method"`
+	IdempotencyKey   string       `json:"idempotency_key"`
+	RequestHash      string       `json:"request_hash"`
+	Response         []byte       `json:"response"`
+	Failed           bool         `json:"failed"`
+	LockedAt         time.Time    `json:"locked_at"`
+	CompletedAt      sql.NullTime `json:"completed_at"`
+	CreatedAt        sql.NullTime `json:"created_at"`
+}
+
+type LoginAuditLog struct {
+	LoginAuditLogID int32          `json:"login_audit_log_id"`
+	Event           string         `json:"event"`
+	Scope           string         `json:"scope"`
+	Subject         string         `json:"subject"`
+	FailedAttempts  int32          `json:"failed_attempts"`
+	LockedUntil     sql.NullTime   `json:"locked_until"`
+	IpAddress       sql.NullString `json:"ip_address"`
+	ActorID         sql.NullInt32  `json:"actor_id"`
+	CreatedAt       time.Time      `json:"created_at"`
+}
+
+type LoginThrottle struct {
+	LoginThrottleID int32        `json:"login_throttle_id"`
+	Scope           string       `json:"scope"`
+	Subject         string       `json:"subject"`
+	FailedAttempts  int32        `json:"failed_attempts"`
+	LastFailedAt    time.Time    `json:"last_failed_at"`
+	LockedUntil     sql.NullTime `json:"locked_until"`
+}
+
 type Merchant struct {
 	MerchantID   int32          `json:"merchant_id"`
 	UserID       int32          `json:"user_id"`
@@ -46,16 +81,23 @@ type Merchant struct {
 	CreatedAt    sql.NullTime   `json:"created_at"`
 	UpdatedAt    sql.NullTime   `json:"updated_at"`
 	DeletedAt    sql.NullTime   `json:"deleted_at"`
+	Currency     string         `json:"currency"`
 }
 
 type Order struct {
-	OrderID    int32        `json:"order_id"`
-	UserID     int32        `json:"user_id"`
-	MerchantID int32        `json:"merchant_id"`
-	TotalPrice int32        `json:"total_price"`
-	CreatedAt  sql.NullTime `json:"created_at"`
-	UpdatedAt  sql.NullTime `json:"updated_at"`
-	DeletedAt  sql.NullTime `json:"deleted_at"`
+	OrderID        int32        `json:"order_id"`
+	UserID         int32        `json:"user_id"`
+	MerchantID     int32        `json:"merchant_id"`
+	TotalPrice     int64        `json:"total_price"`
+	CreatedAt      sql.NullTime `json:"created_at"`
+	UpdatedAt      sql.NullTime `json:"updated_at"`
+	DeletedAt      sql.NullTime `json:"deleted_at"`
+	Status         string       `json:"status"`
+	Subtotal       int64        `json:"subtotal"`
+	ShippingCost   int64        `json:"shipping_cost"`
+	TaxAmount      int64        `json:"tax_amount"`
+	DiscountAmount int64        `json:"discount_amount"`
+	Currency       string       `json:"currency"`
 }
 
 type OrderItem struct {
@@ -64,19 +106,38 @@ type OrderItem struct {
 	ProductID   int32        `json:"product_id"`
 	Name        string       `json:"name"`
 	Quantity    int32        `json:"quantity"`
-	Price       int32        `json:"price"`
+	Price       int64        `json:"price"`
 	CreatedAt   sql.NullTime `json:"created_at"`
 	UpdatedAt   sql.NullTime `json:"updated_at"`
 	DeletedAt   sql.NullTime `json:"deleted_at"`
 }
 
+type OrderStatusHistory struct {
+	OrderStatusHistoryID int32          `json:"order_status_history_id"`
+	OrderID              int32          `json:"order_id"`
+	FromStatus           sql.NullString `json:"from_status"`
+	ToStatus             string         `json:"to_status"`
+	Note                 sql.NullString `json:"note"`
+	CreatedAt            sql.NullTime   `json:"created_at"`
+}
+
+type PaymentEvent struct {
+	PaymentEventID int32           `json:"payment_event_id"`
+	Provider       string          `json:"provider"`
+	EventID        string          `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	TransactionID  sql.NullInt32   `json:"transaction_id"`
+	Payload        json.RawMessage `json:"payload"`
+	CreatedAt      sql.NullTime    `json:"created_at"`
+}
+
 type Product struct {
 	ProductID    int32           `json:"product_id"`
 	MerchantID   int32           `json:"merchant_id"`
 	CategoryID   int32           `json:"category_id"`
 	Name         string          `json:"name"`
 	Description  sql.NullString  `json:"description"`
-	Price        int32           `json:"price"`
+	Price        int64           `json:"price"`
 	CountInStock int32           `json:"count_in_stock"`
 	Brand        sql.NullString  `json:"brand"`
 	Weight       sql.NullInt32   `json:"weight"`
@@ -86,16 +147,42 @@ type Product struct {
 	CreatedAt    sql.NullTime    `json:"created_at"`
 	UpdatedAt    sql.NullTime    `json:"updated_at"`
 	DeletedAt    sql.NullTime    `json:"deleted_at"`
+	SearchVector interface{}     `json:"search_vector"`
 }
 
 type RefreshToken struct {
-	RefreshTokenID int32        `json:"refresh_token_id"`
-	UserID         int32        `json:"user_id"`
-	Token          string       `json:"token"`
-	Expiration     time.Time    `json:"expiration"`
-	CreatedAt      sql.NullTime `json:"created_at"`
-	UpdatedAt      sql.NullTime `json:"updated_at"`
-	DeletedAt      sql.NullTime `json:"deleted_at"`
+	RefreshTokenID int32          `json:"refresh_token_id"`
+	UserID         int32          `json:"user_id"`
+	Token          string         `json:"token"`
+	Expiration     time.Time      `json:"expiration"`
+	CreatedAt      sql.NullTime   `json:"created_at"`
+	UpdatedAt      sql.NullTime   `json:"updated_at"`
+	DeletedAt      sql.NullTime   `json:"deleted_at"`
+	FamilyID       string         `json:"family_id"`
+	Device         sql.NullString `json:"device"`
+	UserAgent      sql.NullString `json:"user_agent"`
+	IpAddress      sql.NullString `json:"ip_address"`
+	SignedInAt     time.Time      `json:"signed_in_at"`
+	UsedAt         sql.NullTime   `json:"used_at"`
+	RevokedAt      sql.NullTime   `json:"revoked_at"`
+}
+
+type Refund struct {
+	RefundID         int32          `json:"refund_id"`
+	TransactionID    int32          `json:"transaction_id"`
+	Amount           int64          `json:"amount"`
+	Reason           sql.NullString `json:"reason"`
+	ProviderRefundID sql.NullString `json:"provider_refund_id"`
+	Status           string         `json:"status"`
+	CreatedAt        sql.NullTime   `json:"created_at"`
+}
+
+type RefundItem struct {
+	RefundItemID int32 `json:"refund_item_id"`
+	RefundID     int32 `json:"refund_id"`
+	OrderItemID  int32 `json:"order_item_id"`
+	Quantity     int32 `json:"quantity"`
+	Amount       int64 `json:"amount"`
 }
 
 type Review struct {
@@ -110,6 +197,15 @@ type Review struct {
 	DeletedAt sql.NullTime `json:"deleted_at"`
 }
 
+type RevokedToken struct {
+	RevokedTokenID int32          `json:"revoked_token_id"`
+	Jti            sql.NullString `json:"jti"`
+	UserID         sql.NullInt32  `json:"user_id"`
+	Reason         string         `json:"reason"`
+	RevokedAt      time.Time      `json:"revoked_at"`
+	ExpiresAt      time.Time      `json:"expires_at"`
+}
+
 type Role struct {
 	RoleID    int32        `json:"role_id"`
 	RoleName  string       `json:"role_name"`
@@ -127,7 +223,7 @@ type ShippingAddress struct {
 	Kota              string       `json:"kota"`
 	Courier           string       `json:"courier"`
 	ShippingMethod    string       `json:"shipping_method"`
-	ShippingCost      float64      `json:"shipping_cost"`
+	ShippingCost      int64        `json:"shipping_cost"`
 	CreatedAt         sql.NullTime `json:"created_at"`
 	UpdatedAt         sql.NullTime `json:"updated_at"`
 	DeletedAt         sql.NullTime `json:"deleted_at"`
@@ -143,26 +239,32 @@ type Slider struct {
 }
 
 type Transaction struct {
-	TransactionID int32        `json:"transaction_id"`
-	OrderID       int32        `json:"order_id"`
-	MerchantID    int32        `json:"merchant_id"`
-	PaymentMethod string       `json:"payment_method"`
-	Amount        int32        `json:"amount"`
-	PaymentStatus string       `json:"payment_status"`
-	CreatedAt     sql.NullTime `json:"created_at"`
-	UpdatedAt     sql.NullTime `json:"updated_at"`
-	DeletedAt     sql.NullTime `json:"deleted_at"`
+	TransactionID    int32          `json:"transaction_id"`
+	OrderID          int32          `json:"order_id"`
+	MerchantID       int32          `json:"merchant_id"`
+	PaymentMethod    string         `json:"payment_method"`
+	Amount           int64          `json:"amount"`
+	PaymentStatus    string         `json:"payment_status"`
+	CreatedAt        sql.NullTime   `json:"created_at"`
+	UpdatedAt        sql.NullTime   `json:"updated_at"`
+	DeletedAt        sql.NullTime   `json:"deleted_at"`
+	PaymentProvider  sql.NullString `json:"payment_provider"`
+	ProviderChargeID sql.NullString `json:"provider_charge_id"`
+	RefundedAmount   int64          `json:"refunded_amount"`
+	AmountDue        int64          `json:"amount_due"`
+	ChangeAmount     int64          `json:"change_amount"`
 }
 
 type User struct {
-	UserID    int32        `json:"user_id"`
-	Firstname string       `json:"firstname"`
-	Lastname  string       `json:"lastname"`
-	Email     string       `json:"email"`
-	Password  string       `json:"password"`
-	CreatedAt sql.NullTime `json:"created_at"`
-	UpdatedAt sql.NullTime `json:"updated_at"`
-	DeletedAt sql.NullTime `json:"deleted_at"`
+	UserID          int32        `json:"user_id"`
+	Firstname       string       `json:"firstname"`
+	Lastname        string       `json:"lastname"`
+	Email           string       `json:"email"`
+	Password        string       `json:"password"`
+	CreatedAt       sql.NullTime `json:"created_at"`
+	UpdatedAt       sql.NullTime `json:"updated_at"`
+	DeletedAt       sql.NullTime `json:"deleted_at"`
+	EmailVerifiedAt sql.NullTime `json:"email_verified_at"`
 }
 
 type UserRole struct {
@@ -173,3 +275,13 @@ type UserRole struct {
 	UpdatedAt  sql.NullTime `json:"updated_at"`
 	DeletedAt  sql.NullTime `json:"deleted_at"`
 }
+
+type UserToken struct {
+	UserTokenID int32        `json:"user_token_id"`
+	UserID      int32        `json:"user_id"`
+	Purpose     string       `json:"purpose"`
+	Token       string       `json:"token"`
+	ExpiresAt   time.Time    `json:"expires_at"`
+	UsedAt      sql.NullTime `json:"used_at"`
+	CreatedAt   time.Time    `json:"created_at"`
+}
@@ -10,19 +10,6 @@ import (
 	"database/sql"
 )
 
-const calculateTotalPrice = `-- name: CalculateTotalPrice :one
-SELECT COALESCE(SUM(quantity * price), 0)::int AS total_price
-FROM order_items
-WHERE order_id = $1 AND deleted_at IS NULL
-`
-
-func (q *Queries) CalculateTotalPrice(ctx context.Context, orderID int32) (int32, error) {
-	row := q.db.QueryRowContext(ctx, calculateTotalPrice, orderID)
-	var total_price int32
-	err := row.Scan(&total_price)
-	return total_price, err
-}
-
 const createOrderItem = `-- name: CreateOrderItem :one
 INSERT INTO order_items (order_id, product_id, quantity, price)
 VALUES ($1, $2, $3, $4)
@@ -33,7 +20,7 @@ type CreateOrderItemParams struct {
 	OrderID   int32 `json:"order_id"`
 	ProductID int32 `json:"product_id"`
 	Quantity  int32 `json:"quantity"`
-	Price     int32 `json:"price"`
+	Price     int64 `json:"price"`
 }
 
 func (q *Queries) CreateOrderItem(ctx context.Context, arg CreateOrderItemParams) (*OrderItem, error) {
@@ -103,7 +90,7 @@ type GetOrderItemsRow struct {
 	ProductID   int32        `json:"product_id"`
 	Name        string       `json:"name"`
 	Quantity    int32        `json:"quantity"`
-	Price       int32        `json:"price"`
+	Price       int64        `json:"price"`
 	CreatedAt   sql.NullTime `json:"created_at"`
 	UpdatedAt   sql.NullTime `json:"updated_at"`
 	DeletedAt   sql.NullTime `json:"deleted_at"`
@@ -168,7 +155,7 @@ type GetOrderItemsActiveRow struct {
 	ProductID   int32        `json:"product_id"`
 	Name        string       `json:"name"`
 	Quantity    int32        `json:"quantity"`
-	Price       int32        `json:"price"`
+	Price       int64        `json:"price"`
 	CreatedAt   sql.NullTime `json:"created_at"`
 	UpdatedAt   sql.NullTime `json:"updated_at"`
 	DeletedAt   sql.NullTime `json:"deleted_at"`
@@ -273,7 +260,7 @@ type GetOrderItemsTrashedRow struct {
 	ProductID   int32        `json:"product_id"`
 	Name        string       `json:"name"`
 	Quantity    int32        `json:"quantity"`
-	Price       int32        `json:"price"`
+	Price       int64        `json:"price"`
 	CreatedAt   sql.NullTime `json:"created_at"`
 	UpdatedAt   sql.NullTime `json:"updated_at"`
 	DeletedAt   sql.NullTime `json:"deleted_at"`
@@ -391,6 +378,7 @@ SET quantity = $2,
     price = $3,
     updated_at = CURRENT_TIMESTAMP
 WHERE order_item_id = $1
+  AND order_id = $4
   AND deleted_at IS NULL
   RETURNING order_item_id, order_id, product_id, name, quantity, price, created_at, updated_at, deleted_at
 `
@@ -398,11 +386,18 @@ WHERE order_item_id = $1
 type UpdateOrderItemParams struct {
 	OrderItemID int32 `json:"order_item_id"`
 	Quantity    int32 `json:"quantity"`
-	Price       int32 `json:"price"`
+	Price       int64 `json:"price"`
+	OrderID     int32 `json:"order_id"`
 }
 
+// Only touches the item when it belongs to the given order
 func (q *Queries) UpdateOrderItem(ctx context.Context, arg UpdateOrderItemParams) (*OrderItem, error) {
-	row := q.db.QueryRowContext(ctx, updateOrderItem, arg.OrderItemID, arg.Quantity, arg.Price)
+	row := q.db.QueryRowContext(ctx, updateOrderItem,
+		arg.OrderItemID,
+		arg.Quantity,
+		arg.Price,
+		arg.OrderID,
+	)
 	var i OrderItem
 	err := row.Scan(
 		&i.OrderItemID,
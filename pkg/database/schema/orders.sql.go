@@ -11,17 +11,18 @@ import (
 )
 
 const createOrder = `-- name: CreateOrder :one
-INSERT INTO orders (merchant_id, user_id, total_price)
-VALUES ($1, $2, $3)
-RETURNING order_id, user_id, merchant_id, total_price, created_at, updated_at, deleted_at
+INSERT INTO orders (merchant_id, user_id, total_price, currency)
+VALUES ($1, $2, $3, (SELECT currency FROM merchants WHERE merchant_id = $1))
+RETURNING order_id, user_id, merchant_id, total_price, created_at, updated_at, deleted_at, status, subtotal, shipping_cost, tax_amount, discount_amount, currency
 `
 
 type CreateOrderParams struct {
 	MerchantID int32 `json:"merchant_id"`
 	UserID     int32 `json:"user_id"`
-	TotalPrice int32 `json:"total_price"`
+	TotalPrice int64 `json:"total_price"`
 }
 
+// Orders are priced in the currency of the merchant they are placed with
 func (q *Queries) CreateOrder(ctx context.Context, arg CreateOrderParams) (*Order, error) {
 	row := q.db.QueryRowContext(ctx, createOrder, arg.MerchantID, arg.UserID, arg.TotalPrice)
 	var i Order
@@ -33,6 +34,44 @@ func (q *Queries) CreateOrder(ctx context.Context, arg CreateOrderParams) (*Orde
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Status,
+		&i.Subtotal,
+		&i.ShippingCost,
+		&i.TaxAmount,
+		&i.DiscountAmount,
+		&i.Currency,
+	)
+	return &i, err
+}
+
+const createOrderStatusHistory = `-- name: CreateOrderStatusHistory :one
+INSERT INTO order_status_histories (order_id, from_status, to_status, note)
+VALUES ($1, $2, $3, $4)
+RETURNING order_status_history_id, order_id, from_status, to_status, note, created_at
+`
+
+type CreateOrderStatusHistoryParams struct {
+	OrderID    int32          `json:"order_id"`
+	FromStatus sql.NullString `json:"from_status"`
+	ToStatus   string         `json:"to_status"`
+	Note       sql.NullString `json:"note"`
+}
+
+func (q *Queries) CreateOrderStatusHistory(ctx context.Context, arg CreateOrderStatusHistoryParams) (*OrderStatusHistory, error) {
+	row := q.db.QueryRowContext(ctx, createOrderStatusHistory,
+		arg.OrderID,
+		arg.FromStatus,
+		arg.ToStatus,
+		arg.Note,
+	)
+	var i OrderStatusHistory
+	err := row.Scan(
+		&i.OrderStatusHistoryID,
+		&i.OrderID,
+		&i.FromStatus,
+		&i.ToStatus,
+		&i.Note,
+		&i.CreatedAt,
 	)
 	return &i, err
 }
@@ -57,7 +96,7 @@ func (q *Queries) DeleteOrderPermanently(ctx context.Context, orderID int32) err
 }
 
 const getOrderByID = `-- name: GetOrderByID :one
-SELECT order_id, user_id, merchant_id, total_price, created_at, updated_at, deleted_at
+SELECT order_id, user_id, merchant_id, total_price, created_at, updated_at, deleted_at, status, subtotal, shipping_cost, tax_amount, discount_amount, currency
 FROM orders
 WHERE order_id = $1
 AND deleted_at IS NULL
@@ -74,13 +113,86 @@ func (q *Queries) GetOrderByID(ctx context.Context, orderID int32) (*Order, erro
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Status,
+		&i.Subtotal,
+		&i.ShippingCost,
+		&i.TaxAmount,
+		&i.DiscountAmount,
+		&i.Currency,
+	)
+	return &i, err
+}
+
+const getOrderByIDForUpdate = `-- name: GetOrderByIDForUpdate :one
+SELECT order_id, user_id, merchant_id, total_price, created_at, updated_at, deleted_at, status, subtotal, shipping_cost, tax_amount, discount_amount, currency
+FROM orders
+WHERE order_id = $1
+AND deleted_at IS NULL
+FOR UPDATE
+`
+
+// Lock an order while a payment is being started for it
+func (q *Queries) GetOrderByIDForUpdate(ctx context.Context, orderID int32) (*Order, error) {
+	row := q.db.QueryRowContext(ctx, getOrderByIDForUpdate, orderID)
+	var i Order
+	err := row.Scan(
+		&i.OrderID,
+		&i.UserID,
+		&i.MerchantID,
+		&i.TotalPrice,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Status,
+		&i.Subtotal,
+		&i.ShippingCost,
+		&i.TaxAmount,
+		&i.DiscountAmount,
+		&i.Currency,
 	)
 	return &i, err
 }
 
+const getOrderStatusHistories = `-- name: GetOrderStatusHistories :many
+SELECT order_status_history_id, order_id, from_status, to_status, note, created_at
+FROM order_status_histories
+WHERE order_id = $1
+ORDER BY created_at ASC, order_status_history_id ASC
+`
+
+func (q *Queries) GetOrderStatusHistories(ctx context.Context, orderID int32) ([]*OrderStatusHistory, error) {
+	rows, err := q.db.QueryContext(ctx, getOrderStatusHistories, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*OrderStatusHistory
+	for rows.Next() {
+		var i OrderStatusHistory
+		if err := rows.Scan(
+			&i.OrderStatusHistoryID,
+			&i.OrderID,
+			&i.FromStatus,
+			&i.ToStatus,
+			&i.Note,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getOrders = `-- name: GetOrders :many
 SELECT
-    order_id, user_id, merchant_id, total_price, created_at, updated_at, deleted_at,
+    order_id, user_id, merchant_id, total_price, created_at, updated_at, deleted_at, status, subtotal, shipping_cost, tax_amount, discount_amount, currency,
     COUNT(*) OVER() AS total_count
 FROM orders
 WHERE deleted_at IS NULL
@@ -96,14 +208,20 @@ type GetOrdersParams struct {
 }
 
 type GetOrdersRow struct {
-	OrderID    int32        `json:"order_id"`
-	UserID     int32        `json:"user_id"`
-	MerchantID int32        `json:"merchant_id"`
-	TotalPrice int32        `json:"total_price"`
-	CreatedAt  sql.NullTime `json:"created_at"`
-	UpdatedAt  sql.NullTime `json:"updated_at"`
-	DeletedAt  sql.NullTime `json:"deleted_at"`
-	TotalCount int64        `json:"total_count"`
+	OrderID        int32        `json:"order_id"`
+	UserID         int32        `json:"user_id"`
+	MerchantID     int32        `json:"merchant_id"`
+	TotalPrice     int64        `json:"total_price"`
+	CreatedAt      sql.NullTime `json:"created_at"`
+	UpdatedAt      sql.NullTime `json:"updated_at"`
+	DeletedAt      sql.NullTime `json:"deleted_at"`
+	Status         string       `json:"status"`
+	Subtotal       int64        `json:"subtotal"`
+	ShippingCost   int64        `json:"shipping_cost"`
+	TaxAmount      int64        `json:"tax_amount"`
+	DiscountAmount int64        `json:"discount_amount"`
+	Currency       string       `json:"currency"`
+	TotalCount     int64        `json:"total_count"`
 }
 
 func (q *Queries) GetOrders(ctx context.Context, arg GetOrdersParams) ([]*GetOrdersRow, error) {
@@ -123,6 +241,12 @@ func (q *Queries) GetOrders(ctx context.Context, arg GetOrdersParams) ([]*GetOrd
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.Status,
+			&i.Subtotal,
+			&i.ShippingCost,
+			&i.TaxAmount,
+			&i.DiscountAmount,
+			&i.Currency,
 			&i.TotalCount,
 		); err != nil {
 			return nil, err
@@ -140,7 +264,7 @@ func (q *Queries) GetOrders(ctx context.Context, arg GetOrdersParams) ([]*GetOrd
 
 const getOrdersActive = `-- name: GetOrdersActive :many
 SELECT
-    order_id, user_id, merchant_id, total_price, created_at, updated_at, deleted_at,
+    order_id, user_id, merchant_id, total_price, created_at, updated_at, deleted_at, status, subtotal, shipping_cost, tax_amount, discount_amount, currency,
     COUNT(*) OVER() AS total_count
 FROM orders
 WHERE deleted_at IS NULL
@@ -156,14 +280,20 @@ type GetOrdersActiveParams struct {
 }
 
 type GetOrdersActiveRow struct {
-	OrderID    int32        `json:"order_id"`
-	UserID     int32        `json:"user_id"`
-	MerchantID int32        `json:"merchant_id"`
-	TotalPrice int32        `json:"total_price"`
-	CreatedAt  sql.NullTime `json:"created_at"`
-	UpdatedAt  sql.NullTime `json:"updated_at"`
-	DeletedAt  sql.NullTime `json:"deleted_at"`
-	TotalCount int64        `json:"total_count"`
+	OrderID        int32        `json:"order_id"`
+	UserID         int32        `json:"user_id"`
+	MerchantID     int32        `json:"merchant_id"`
+	TotalPrice     int64        `json:"total_price"`
+	CreatedAt      sql.NullTime `json:"created_at"`
+	UpdatedAt      sql.NullTime `json:"updated_at"`
+	DeletedAt      sql.NullTime `json:"deleted_at"`
+	Status         string       `json:"status"`
+	Subtotal       int64        `json:"subtotal"`
+	ShippingCost   int64        `json:"shipping_cost"`
+	TaxAmount      int64        `json:"tax_amount"`
+	DiscountAmount int64        `json:"discount_amount"`
+	Currency       string       `json:"currency"`
+	TotalCount     int64        `json:"total_count"`
 }
 
 func (q *Queries) GetOrdersActive(ctx context.Context, arg GetOrdersActiveParams) ([]*GetOrdersActiveRow, error) {
@@ -183,6 +313,12 @@ func (q *Queries) GetOrdersActive(ctx context.Context, arg GetOrdersActiveParams
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.Status,
+			&i.Subtotal,
+			&i.ShippingCost,
+			&i.TaxAmount,
+			&i.DiscountAmount,
+			&i.Currency,
 			&i.TotalCount,
 		); err != nil {
 			return nil, err
@@ -200,7 +336,7 @@ func (q *Queries) GetOrdersActive(ctx context.Context, arg GetOrdersActiveParams
 
 const getOrdersByMerchant = `-- name: GetOrdersByMerchant :many
 SELECT
-    order_id, user_id, merchant_id, total_price, created_at, updated_at, deleted_at,
+    order_id, user_id, merchant_id, total_price, created_at, updated_at, deleted_at, status, subtotal, shipping_cost, tax_amount, discount_amount, currency,
     COUNT(*) OVER() AS total_count
 FROM orders
 WHERE 
@@ -219,14 +355,20 @@ type GetOrdersByMerchantParams struct {
 }
 
 type GetOrdersByMerchantRow struct {
-	OrderID    int32        `json:"order_id"`
-	UserID     int32        `json:"user_id"`
-	MerchantID int32        `json:"merchant_id"`
-	TotalPrice int32        `json:"total_price"`
-	CreatedAt  sql.NullTime `json:"created_at"`
-	UpdatedAt  sql.NullTime `json:"updated_at"`
-	DeletedAt  sql.NullTime `json:"deleted_at"`
-	TotalCount int64        `json:"total_count"`
+	OrderID        int32        `json:"order_id"`
+	UserID         int32        `json:"user_id"`
+	MerchantID     int32        `json:"merchant_id"`
+	TotalPrice     int64        `json:"total_price"`
+	CreatedAt      sql.NullTime `json:"created_at"`
+	UpdatedAt      sql.NullTime `json:"updated_at"`
+	DeletedAt      sql.NullTime `json:"deleted_at"`
+	Status         string       `json:"status"`
+	Subtotal       int64        `json:"subtotal"`
+	ShippingCost   int64        `json:"shipping_cost"`
+	TaxAmount      int64        `json:"tax_amount"`
+	DiscountAmount int64        `json:"discount_amount"`
+	Currency       string       `json:"currency"`
+	TotalCount     int64        `json:"total_count"`
 }
 
 func (q *Queries) GetOrdersByMerchant(ctx context.Context, arg GetOrdersByMerchantParams) ([]*GetOrdersByMerchantRow, error) {
@@ -251,6 +393,12 @@ func (q *Queries) GetOrdersByMerchant(ctx context.Context, arg GetOrdersByMercha
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.Status,
+			&i.Subtotal,
+			&i.ShippingCost,
+			&i.TaxAmount,
+			&i.DiscountAmount,
+			&i.Currency,
 			&i.TotalCount,
 		); err != nil {
 			return nil, err
@@ -266,9 +414,72 @@ func (q *Queries) GetOrdersByMerchant(ctx context.Context, arg GetOrdersByMercha
 	return items, nil
 }
 
+const getOrdersCursor = `-- name: GetOrdersCursor :many
+SELECT order_id, user_id, merchant_id, total_price, created_at, updated_at, deleted_at, status, subtotal, shipping_cost, tax_amount, discount_amount, currency
+FROM orders
+WHERE deleted_at IS NULL
+AND ($1::TEXT IS NULL
+       OR order_id::TEXT ILIKE '%' || $1::TEXT || '%'
+       OR total_price::TEXT ILIKE '%' || $1::TEXT || '%')
+AND ($2::TIMESTAMP IS NULL
+       OR (created_at, order_id) < ($2::TIMESTAMP, $3::INT))
+ORDER BY created_at DESC, order_id DESC
+LIMIT $4
+`
+
+type GetOrdersCursorParams struct {
+	Search          string        `json:"search"`
+	CursorCreatedAt sql.NullTime  `json:"cursor_created_at"`
+	CursorID        sql.NullInt32 `json:"cursor_id"`
+	PageLimit       int32         `json:"page_limit"`
+}
+
+// Get Orders with Keyset Pagination
+func (q *Queries) GetOrdersCursor(ctx context.Context, arg GetOrdersCursorParams) ([]*Order, error) {
+	rows, err := q.db.QueryContext(ctx, getOrdersCursor,
+		arg.Search,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*Order
+	for rows.Next() {
+		var i Order
+		if err := rows.Scan(
+			&i.OrderID,
+			&i.UserID,
+			&i.MerchantID,
+			&i.TotalPrice,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.Status,
+			&i.Subtotal,
+			&i.ShippingCost,
+			&i.TaxAmount,
+			&i.DiscountAmount,
+			&i.Currency,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getOrdersTrashed = `-- name: GetOrdersTrashed :many
 SELECT
-    order_id, user_id, merchant_id, total_price, created_at, updated_at, deleted_at,
+    order_id, user_id, merchant_id, total_price, created_at, updated_at, deleted_at, status, subtotal, shipping_cost, tax_amount, discount_amount, currency,
     COUNT(*) OVER() AS total_count
 FROM orders
 WHERE deleted_at IS NOT NULL
@@ -284,14 +495,20 @@ type GetOrdersTrashedParams struct {
 }
 
 type GetOrdersTrashedRow struct {
-	OrderID    int32        `json:"order_id"`
-	UserID     int32        `json:"user_id"`
-	MerchantID int32        `json:"merchant_id"`
-	TotalPrice int32        `json:"total_price"`
-	CreatedAt  sql.NullTime `json:"created_at"`
-	UpdatedAt  sql.NullTime `json:"updated_at"`
-	DeletedAt  sql.NullTime `json:"deleted_at"`
-	TotalCount int64        `json:"total_count"`
+	OrderID        int32        `json:"order_id"`
+	UserID         int32        `json:"user_id"`
+	MerchantID     int32        `json:"merchant_id"`
+	TotalPrice     int64        `json:"total_price"`
+	CreatedAt      sql.NullTime `json:"created_at"`
+	UpdatedAt      sql.NullTime `json:"updated_at"`
+	DeletedAt      sql.NullTime `json:"deleted_at"`
+	Status         string       `json:"status"`
+	Subtotal       int64        `json:"subtotal"`
+	ShippingCost   int64        `json:"shipping_cost"`
+	TaxAmount      int64        `json:"tax_amount"`
+	DiscountAmount int64        `json:"discount_amount"`
+	Currency       string       `json:"currency"`
+	TotalCount     int64        `json:"total_count"`
 }
 
 func (q *Queries) GetOrdersTrashed(ctx context.Context, arg GetOrdersTrashedParams) ([]*GetOrdersTrashedRow, error) {
@@ -311,6 +528,12 @@ func (q *Queries) GetOrdersTrashed(ctx context.Context, arg GetOrdersTrashedPara
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.Status,
+			&i.Subtotal,
+			&i.ShippingCost,
+			&i.TaxAmount,
+			&i.DiscountAmount,
+			&i.Currency,
 			&i.TotalCount,
 		); err != nil {
 			return nil, err
@@ -326,6 +549,67 @@ func (q *Queries) GetOrdersTrashed(ctx context.Context, arg GetOrdersTrashedPara
 	return items, nil
 }
 
+const recalculateOrderTotal = `-- name: RecalculateOrderTotal :one
+UPDATE orders o
+SET subtotal = totals.subtotal,
+    shipping_cost = totals.shipping_cost,
+    tax_amount = totals.tax_amount,
+    total_price = GREATEST(totals.subtotal + totals.shipping_cost + totals.tax_amount - o.discount_amount, 0),
+    updated_at = CURRENT_TIMESTAMP
+FROM (
+    SELECT
+        amounts.subtotal,
+        amounts.shipping_cost,
+        ROUND(amounts.subtotal * $1::BIGINT / 10000.0)::BIGINT AS tax_amount
+    FROM (
+        SELECT
+            COALESCE((
+                SELECT SUM(oi.quantity * oi.price)
+                FROM order_items oi
+                WHERE oi.order_id = $2 AND oi.deleted_at IS NULL
+            ), 0)::BIGINT AS subtotal,
+            COALESCE((
+                SELECT sa.shipping_cost
+                FROM shipping_addresses sa
+                WHERE sa.order_id = $2 AND sa.deleted_at IS NULL
+                ORDER BY sa.shipping_address_id DESC
+                LIMIT 1
+            ), 0)::BIGINT AS shipping_cost
+    ) amounts
+) totals
+WHERE o.order_id = $2
+  AND o.deleted_at IS NULL
+RETURNING o.order_id, o.user_id, o.merchant_id, o.total_price, o.created_at, o.updated_at, o.deleted_at, o.status, o.subtotal, o.shipping_cost, o.tax_amount, o.discount_amount, o.currency
+`
+
+type RecalculateOrderTotalParams struct {
+	TaxRate int64 `json:"tax_rate"`
+	OrderID int32 `json:"order_id"`
+}
+
+// Recompute the payable amount of an order: its items plus shipping cost plus
+// tax at tax_rate basis points of the items, less the order's discount
+func (q *Queries) RecalculateOrderTotal(ctx context.Context, arg RecalculateOrderTotalParams) (*Order, error) {
+	row := q.db.QueryRowContext(ctx, recalculateOrderTotal, arg.TaxRate, arg.OrderID)
+	var i Order
+	err := row.Scan(
+		&i.OrderID,
+		&i.UserID,
+		&i.MerchantID,
+		&i.TotalPrice,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Status,
+		&i.Subtotal,
+		&i.ShippingCost,
+		&i.TaxAmount,
+		&i.DiscountAmount,
+		&i.Currency,
+	)
+	return &i, err
+}
+
 const restoreAllOrders = `-- name: RestoreAllOrders :exec
 UPDATE orders
 SET deleted_at = NULL
@@ -342,7 +626,7 @@ UPDATE orders
 SET deleted_at = NULL
 WHERE order_id = $1
 AND deleted_at IS NOT NULL
-RETURNING order_id, user_id, merchant_id, total_price, created_at, updated_at, deleted_at
+RETURNING order_id, user_id, merchant_id, total_price, created_at, updated_at, deleted_at, status, subtotal, shipping_cost, tax_amount, discount_amount, currency
 `
 
 func (q *Queries) RestoreOrder(ctx context.Context, orderID int32) (*Order, error) {
@@ -356,6 +640,12 @@ func (q *Queries) RestoreOrder(ctx context.Context, orderID int32) (*Order, erro
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Status,
+		&i.Subtotal,
+		&i.ShippingCost,
+		&i.TaxAmount,
+		&i.DiscountAmount,
+		&i.Currency,
 	)
 	return &i, err
 }
@@ -365,7 +655,7 @@ UPDATE orders
 SET deleted_at = CURRENT_TIMESTAMP
 WHERE order_id = $1
 AND deleted_at IS NULL
-RETURNING order_id, user_id, merchant_id, total_price, created_at, updated_at, deleted_at
+RETURNING order_id, user_id, merchant_id, total_price, created_at, updated_at, deleted_at, status, subtotal, shipping_cost, tax_amount, discount_amount, currency
 `
 
 func (q *Queries) TrashOrder(ctx context.Context, orderID int32) (*Order, error) {
@@ -379,28 +669,72 @@ func (q *Queries) TrashOrder(ctx context.Context, orderID int32) (*Order, error)
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Status,
+		&i.Subtotal,
+		&i.ShippingCost,
+		&i.TaxAmount,
+		&i.DiscountAmount,
+		&i.Currency,
 	)
 	return &i, err
 }
 
 const updateOrder = `-- name: UpdateOrder :one
 UPDATE orders
-SET total_price = $2,
-    user_id = $3,
+SET user_id = $2,
+    discount_amount = $3,
     updated_at = CURRENT_TIMESTAMP
 WHERE order_id = $1
   AND deleted_at IS NULL
-  RETURNING order_id, user_id, merchant_id, total_price, created_at, updated_at, deleted_at
+  RETURNING order_id, user_id, merchant_id, total_price, created_at, updated_at, deleted_at, status, subtotal, shipping_cost, tax_amount, discount_amount, currency
 `
 
 type UpdateOrderParams struct {
-	OrderID    int32 `json:"order_id"`
-	TotalPrice int32 `json:"total_price"`
-	UserID     int32 `json:"user_id"`
+	OrderID        int32 `json:"order_id"`
+	UserID         int32 `json:"user_id"`
+	DiscountAmount int64 `json:"discount_amount"`
 }
 
 func (q *Queries) UpdateOrder(ctx context.Context, arg UpdateOrderParams) (*Order, error) {
-	row := q.db.QueryRowContext(ctx, updateOrder, arg.OrderID, arg.TotalPrice, arg.UserID)
+	row := q.db.QueryRowContext(ctx, updateOrder, arg.OrderID, arg.UserID, arg.DiscountAmount)
+	var i Order
+	err := row.Scan(
+		&i.OrderID,
+		&i.UserID,
+		&i.MerchantID,
+		&i.TotalPrice,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Status,
+		&i.Subtotal,
+		&i.ShippingCost,
+		&i.TaxAmount,
+		&i.DiscountAmount,
+		&i.Currency,
+	)
+	return &i, err
+}
+
+const updateOrderStatus = `-- name: UpdateOrderStatus :one
+UPDATE orders
+SET status = $1,
+    updated_at = CURRENT_TIMESTAMP
+WHERE order_id = $2
+  AND status = $3
+  AND deleted_at IS NULL
+RETURNING order_id, user_id, merchant_id, total_price, created_at, updated_at, deleted_at, status, subtotal, shipping_cost, tax_amount, discount_amount, currency
+`
+
+type UpdateOrderStatusParams struct {
+	Status        string `json:"status"`
+	OrderID       int32  `json:"order_id"`
+	CurrentStatus string `json:"current_status"`
+}
+
+// Moves an order to a new status only if it is still in the expected one
+func (q *Queries) UpdateOrderStatus(ctx context.Context, arg UpdateOrderStatusParams) (*Order, error) {
+	row := q.db.QueryRowContext(ctx, updateOrderStatus, arg.Status, arg.OrderID, arg.CurrentStatus)
 	var i Order
 	err := row.Scan(
 		&i.OrderID,
@@ -410,6 +744,12 @@ func (q *Queries) UpdateOrder(ctx context.Context, arg UpdateOrderParams) (*Orde
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Status,
+		&i.Subtotal,
+		&i.ShippingCost,
+		&i.TaxAmount,
+		&i.DiscountAmount,
+		&i.Currency,
 	)
 	return &i, err
 }
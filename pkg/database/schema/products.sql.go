@@ -8,12 +8,14 @@ package db
 import (
 	"context"
 	"database/sql"
+
+	"github.com/lib/pq"
 )
 
 const createProduct = `-- name: CreateProduct :one
 INSERT INTO products (merchant_id, category_id, name, description, price, count_in_stock, brand, weight, rating, slug_product, image_product)
 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
-RETURNING product_id, merchant_id, category_id, name, description, price, count_in_stock, brand, weight, rating, slug_product, image_product, created_at, updated_at, deleted_at
+RETURNING product_id, merchant_id, category_id, name, description, price, count_in_stock, brand, weight, rating, slug_product, image_product, created_at, updated_at, deleted_at, search_vector
 `
 
 type CreateProductParams struct {
@@ -21,7 +23,7 @@ type CreateProductParams struct {
 	CategoryID   int32           `json:"category_id"`
 	Name         string          `json:"name"`
 	Description  sql.NullString  `json:"description"`
-	Price        int32           `json:"price"`
+	Price        int64           `json:"price"`
 	CountInStock int32           `json:"count_in_stock"`
 	Brand        sql.NullString  `json:"brand"`
 	Weight       sql.NullInt32   `json:"weight"`
@@ -61,6 +63,7 @@ func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (*
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.SearchVector,
 	)
 	return &i, err
 }
@@ -88,7 +91,7 @@ func (q *Queries) DeleteProductPermanently(ctx context.Context, productID int32)
 }
 
 const getProductByID = `-- name: GetProductByID :one
-SELECT product_id, merchant_id, category_id, name, description, price, count_in_stock, brand, weight, rating, slug_product, image_product, created_at, updated_at, deleted_at
+SELECT product_id, merchant_id, category_id, name, description, price, count_in_stock, brand, weight, rating, slug_product, image_product, created_at, updated_at, deleted_at, search_vector
 FROM products
 WHERE product_id = $1
   AND deleted_at IS NULL
@@ -113,29 +116,54 @@ func (q *Queries) GetProductByID(ctx context.Context, productID int32) (*Product
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.SearchVector,
 	)
 	return &i, err
 }
 
 const getProducts = `-- name: GetProducts :many
 SELECT
-    product_id, merchant_id, category_id, name, description, price, count_in_stock, brand, weight, rating, slug_product, image_product, created_at, updated_at, deleted_at,
+    p.product_id, p.merchant_id, p.category_id, p.name, p.description, p.price, p.count_in_stock, p.brand, p.weight, p.rating, p.slug_product, p.image_product, p.created_at, p.updated_at, p.deleted_at, p.search_vector,
     COUNT(*) OVER() AS total_count
-FROM products
-WHERE deleted_at IS NULL
-AND ($1::TEXT IS NULL 
-       OR p.name ILIKE '%' || $1 || '%'
-       OR p.description ILIKE '%' || $1 || '%'
-       OR p.brand ILIKE '%' || $1 || '%'
-       OR p.slug_product ILIKE '%' || $1 || '%')
-ORDER BY created_at DESC
-LIMIT $2 OFFSET $3
+FROM products p
+WHERE p.deleted_at IS NULL
+  AND ($1::TEXT IS NULL
+         OR p.name ILIKE '%' || $1::TEXT || '%'
+         OR p.description ILIKE '%' || $1::TEXT || '%'
+         OR p.brand ILIKE '%' || $1::TEXT || '%'
+         OR p.slug_product ILIKE '%' || $1::TEXT || '%')
+  AND ($2::BIGINT IS NULL OR p.price >= $2::BIGINT)
+  AND ($3::BIGINT IS NULL OR p.price <= $3::BIGINT)
+  AND ($4::FLOAT IS NULL OR p.rating >= $4::FLOAT)
+  AND ($5::TEXT IS NULL OR LOWER(p.brand) = LOWER($5::TEXT))
+  AND (NOT $6::BOOLEAN OR p.count_in_stock > 0)
+ORDER BY
+    CASE WHEN $7::TEXT = 'price_asc' THEN p.price END ASC,
+    CASE WHEN $7::TEXT = 'price_desc' THEN p.price END DESC,
+    CASE WHEN $7::TEXT = 'rating' THEN p.rating END DESC NULLS LAST,
+    CASE WHEN $7::TEXT = 'best_selling' THEN (
+        SELECT COALESCE(SUM(oi.quantity), 0)
+        FROM order_items oi
+        JOIN orders o ON o.order_id = oi.order_id
+        WHERE oi.product_id = p.product_id
+          AND oi.deleted_at IS NULL
+          AND o.status IN ('paid', 'processing', 'shipped', 'delivered')
+    ) END DESC,
+    p.created_at DESC,
+    p.product_id DESC
+LIMIT $8 OFFSET $9
 `
 
 type GetProductsParams struct {
-	Column1 string `json:"column_1"`
-	Limit   int32  `json:"limit"`
-	Offset  int32  `json:"offset"`
+	Search     string          `json:"search"`
+	MinPrice   sql.NullInt64   `json:"min_price"`
+	MaxPrice   sql.NullInt64   `json:"max_price"`
+	MinRating  sql.NullFloat64 `json:"min_rating"`
+	Brand      sql.NullString  `json:"brand"`
+	InStock    bool            `json:"in_stock"`
+	Sort       string          `json:"sort"`
+	PageLimit  int32           `json:"page_limit"`
+	PageOffset int32           `json:"page_offset"`
 }
 
 type GetProductsRow struct {
@@ -144,7 +172,7 @@ type GetProductsRow struct {
 	CategoryID   int32           `json:"category_id"`
 	Name         string          `json:"name"`
 	Description  sql.NullString  `json:"description"`
-	Price        int32           `json:"price"`
+	Price        int64           `json:"price"`
 	CountInStock int32           `json:"count_in_stock"`
 	Brand        sql.NullString  `json:"brand"`
 	Weight       sql.NullInt32   `json:"weight"`
@@ -154,11 +182,22 @@ type GetProductsRow struct {
 	CreatedAt    sql.NullTime    `json:"created_at"`
 	UpdatedAt    sql.NullTime    `json:"updated_at"`
 	DeletedAt    sql.NullTime    `json:"deleted_at"`
+	SearchVector interface{}     `json:"search_vector"`
 	TotalCount   int64           `json:"total_count"`
 }
 
 func (q *Queries) GetProducts(ctx context.Context, arg GetProductsParams) ([]*GetProductsRow, error) {
-	rows, err := q.db.QueryContext(ctx, getProducts, arg.Column1, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, getProducts,
+		arg.Search,
+		arg.MinPrice,
+		arg.MaxPrice,
+		arg.MinRating,
+		arg.Brand,
+		arg.InStock,
+		arg.Sort,
+		arg.PageLimit,
+		arg.PageOffset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -182,6 +221,7 @@ func (q *Queries) GetProducts(ctx context.Context, arg GetProductsParams) ([]*Ge
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.SearchVector,
 			&i.TotalCount,
 		); err != nil {
 			return nil, err
@@ -199,7 +239,7 @@ func (q *Queries) GetProducts(ctx context.Context, arg GetProductsParams) ([]*Ge
 
 const getProductsActive = `-- name: GetProductsActive :many
 SELECT
-    product_id, merchant_id, category_id, name, description, price, count_in_stock, brand, weight, rating, slug_product, image_product, created_at, updated_at, deleted_at,
+    product_id, merchant_id, category_id, name, description, price, count_in_stock, brand, weight, rating, slug_product, image_product, created_at, updated_at, deleted_at, search_vector,
     COUNT(*) OVER() AS total_count
 FROM products
 WHERE deleted_at IS NULL
@@ -224,7 +264,7 @@ type GetProductsActiveRow struct {
 	CategoryID   int32           `json:"category_id"`
 	Name         string          `json:"name"`
 	Description  sql.NullString  `json:"description"`
-	Price        int32           `json:"price"`
+	Price        int64           `json:"price"`
 	CountInStock int32           `json:"count_in_stock"`
 	Brand        sql.NullString  `json:"brand"`
 	Weight       sql.NullInt32   `json:"weight"`
@@ -234,6 +274,7 @@ type GetProductsActiveRow struct {
 	CreatedAt    sql.NullTime    `json:"created_at"`
 	UpdatedAt    sql.NullTime    `json:"updated_at"`
 	DeletedAt    sql.NullTime    `json:"deleted_at"`
+	SearchVector interface{}     `json:"search_vector"`
 	TotalCount   int64           `json:"total_count"`
 }
 
@@ -263,6 +304,7 @@ func (q *Queries) GetProductsActive(ctx context.Context, arg GetProductsActivePa
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.SearchVector,
 			&i.TotalCount,
 		); err != nil {
 			return nil, err
@@ -280,26 +322,51 @@ func (q *Queries) GetProductsActive(ctx context.Context, arg GetProductsActivePa
 
 const getProductsByCategoryName = `-- name: GetProductsByCategoryName :many
 SELECT
-    p.product_id, p.merchant_id, p.category_id, p.name, p.description, p.price, p.count_in_stock, p.brand, p.weight, p.rating, p.slug_product, p.image_product, p.created_at, p.updated_at, p.deleted_at,
+    p.product_id, p.merchant_id, p.category_id, p.name, p.description, p.price, p.count_in_stock, p.brand, p.weight, p.rating, p.slug_product, p.image_product, p.created_at, p.updated_at, p.deleted_at, p.search_vector,
     COUNT(*) OVER() AS total_count
 FROM products p
 JOIN categories c ON p.category_id = c.category_id
 WHERE c.deleted_at IS NULL
-  AND c.name = $1 
-  AND ($2::TEXT IS NULL 
-       OR p.name ILIKE '%' || $2 || '%'
-       OR p.description ILIKE '%' || $2 || '%'
-       OR p.brand ILIKE '%' || $2 || '%'
-       OR p.slug_product ILIKE '%' || $2 || '%')
-ORDER BY p.created_at DESC
-LIMIT $3 OFFSET $4
+  AND c.name = $1::TEXT
+  AND p.deleted_at IS NULL
+  AND ($2::TEXT IS NULL
+         OR p.name ILIKE '%' || $2::TEXT || '%'
+         OR p.description ILIKE '%' || $2::TEXT || '%'
+         OR p.brand ILIKE '%' || $2::TEXT || '%'
+         OR p.slug_product ILIKE '%' || $2::TEXT || '%')
+  AND ($3::BIGINT IS NULL OR p.price >= $3::BIGINT)
+  AND ($4::BIGINT IS NULL OR p.price <= $4::BIGINT)
+  AND ($5::FLOAT IS NULL OR p.rating >= $5::FLOAT)
+  AND ($6::TEXT IS NULL OR LOWER(p.brand) = LOWER($6::TEXT))
+  AND (NOT $7::BOOLEAN OR p.count_in_stock > 0)
+ORDER BY
+    CASE WHEN $8::TEXT = 'price_asc' THEN p.price END ASC,
+    CASE WHEN $8::TEXT = 'price_desc' THEN p.price END DESC,
+    CASE WHEN $8::TEXT = 'rating' THEN p.rating END DESC NULLS LAST,
+    CASE WHEN $8::TEXT = 'best_selling' THEN (
+        SELECT COALESCE(SUM(oi.quantity), 0)
+        FROM order_items oi
+        JOIN orders o ON o.order_id = oi.order_id
+        WHERE oi.product_id = p.product_id
+          AND oi.deleted_at IS NULL
+          AND o.status IN ('paid', 'processing', 'shipped', 'delivered')
+    ) END DESC,
+    p.created_at DESC,
+    p.product_id DESC
+LIMIT $9 OFFSET $10
 `
 
 type GetProductsByCategoryNameParams struct {
-	Name    string `json:"name"`
-	Column2 string `json:"column_2"`
-	Limit   int32  `json:"limit"`
-	Offset  int32  `json:"offset"`
+	CategoryName string          `json:"category_name"`
+	Search       string          `json:"search"`
+	MinPrice     sql.NullInt64   `json:"min_price"`
+	MaxPrice     sql.NullInt64   `json:"max_price"`
+	MinRating    sql.NullFloat64 `json:"min_rating"`
+	Brand        sql.NullString  `json:"brand"`
+	InStock      bool            `json:"in_stock"`
+	Sort         string          `json:"sort"`
+	PageLimit    int32           `json:"page_limit"`
+	PageOffset   int32           `json:"page_offset"`
 }
 
 type GetProductsByCategoryNameRow struct {
@@ -308,7 +375,7 @@ type GetProductsByCategoryNameRow struct {
 	CategoryID   int32           `json:"category_id"`
 	Name         string          `json:"name"`
 	Description  sql.NullString  `json:"description"`
-	Price        int32           `json:"price"`
+	Price        int64           `json:"price"`
 	CountInStock int32           `json:"count_in_stock"`
 	Brand        sql.NullString  `json:"brand"`
 	Weight       sql.NullInt32   `json:"weight"`
@@ -318,16 +385,23 @@ type GetProductsByCategoryNameRow struct {
 	CreatedAt    sql.NullTime    `json:"created_at"`
 	UpdatedAt    sql.NullTime    `json:"updated_at"`
 	DeletedAt    sql.NullTime    `json:"deleted_at"`
+	SearchVector interface{}     `json:"search_vector"`
 	TotalCount   int64           `json:"total_count"`
 }
 
 // Get Products by Category Name with Filters
 func (q *Queries) GetProductsByCategoryName(ctx context.Context, arg GetProductsByCategoryNameParams) ([]*GetProductsByCategoryNameRow, error) {
 	rows, err := q.db.QueryContext(ctx, getProductsByCategoryName,
-		arg.Name,
-		arg.Column2,
-		arg.Limit,
-		arg.Offset,
+		arg.CategoryName,
+		arg.Search,
+		arg.MinPrice,
+		arg.MaxPrice,
+		arg.MinRating,
+		arg.Brand,
+		arg.InStock,
+		arg.Sort,
+		arg.PageLimit,
+		arg.PageOffset,
 	)
 	if err != nil {
 		return nil, err
@@ -352,6 +426,7 @@ func (q *Queries) GetProductsByCategoryName(ctx context.Context, arg GetProducts
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.SearchVector,
 			&i.TotalCount,
 		); err != nil {
 			return nil, err
@@ -369,26 +444,49 @@ func (q *Queries) GetProductsByCategoryName(ctx context.Context, arg GetProducts
 
 const getProductsByMerchant = `-- name: GetProductsByMerchant :many
 SELECT
-    product_id, merchant_id, category_id, name, description, price, count_in_stock, brand, weight, rating, slug_product, image_product, created_at, updated_at, deleted_at,
+    p.product_id, p.merchant_id, p.category_id, p.name, p.description, p.price, p.count_in_stock, p.brand, p.weight, p.rating, p.slug_product, p.image_product, p.created_at, p.updated_at, p.deleted_at, p.search_vector,
     COUNT(*) OVER() AS total_count
-FROM products
-WHERE merchant_id = $1
-AND deleted_at IS NULL
-AND ($2::TEXT IS NULL 
-       OR p.name ILIKE '%' || $2 || '%'
-       OR p.description ILIKE '%' || $2 || '%'
-       OR p.brand ILIKE '%' || $2 || '%'
-       OR p.slug_product ILIKE '%' || $2 || '%'
-       OR p.barcode ILIKE '%' || $2 || '%')
-ORDER BY created_at DESC
-LIMIT $3 OFFSET $4
+FROM products p
+WHERE p.merchant_id = $1
+  AND p.deleted_at IS NULL
+  AND ($2::TEXT IS NULL
+         OR p.name ILIKE '%' || $2::TEXT || '%'
+         OR p.description ILIKE '%' || $2::TEXT || '%'
+         OR p.brand ILIKE '%' || $2::TEXT || '%'
+         OR p.slug_product ILIKE '%' || $2::TEXT || '%')
+  AND ($3::BIGINT IS NULL OR p.price >= $3::BIGINT)
+  AND ($4::BIGINT IS NULL OR p.price <= $4::BIGINT)
+  AND ($5::FLOAT IS NULL OR p.rating >= $5::FLOAT)
+  AND ($6::TEXT IS NULL OR LOWER(p.brand) = LOWER($6::TEXT))
+  AND (NOT $7::BOOLEAN OR p.count_in_stock > 0)
+ORDER BY
+    CASE WHEN $8::TEXT = 'price_asc' THEN p.price END ASC,
+    CASE WHEN $8::TEXT = 'price_desc' THEN p.price END DESC,
+    CASE WHEN $8::TEXT = 'rating' THEN p.rating END DESC NULLS LAST,
+    CASE WHEN $8::TEXT = 'best_selling' THEN (
+        SELECT COALESCE(SUM(oi.quantity), 0)
+        FROM order_items oi
+        JOIN orders o ON o.order_id = oi.order_id
+        WHERE oi.product_id = p.product_id
+          AND oi.deleted_at IS NULL
+          AND o.status IN ('paid', 'processing', 'shipped', 'delivered')
+    ) END DESC,
+    p.created_at DESC,
+    p.product_id DESC
+LIMIT $9 OFFSET $10
 `
 
 type GetProductsByMerchantParams struct {
-	MerchantID int32  `json:"merchant_id"`
-	Column2    string `json:"column_2"`
-	Limit      int32  `json:"limit"`
-	Offset     int32  `json:"offset"`
+	MerchantID int32           `json:"merchant_id"`
+	Search     string          `json:"search"`
+	MinPrice   sql.NullInt64   `json:"min_price"`
+	MaxPrice   sql.NullInt64   `json:"max_price"`
+	MinRating  sql.NullFloat64 `json:"min_rating"`
+	Brand      sql.NullString  `json:"brand"`
+	InStock    bool            `json:"in_stock"`
+	Sort       string          `json:"sort"`
+	PageLimit  int32           `json:"page_limit"`
+	PageOffset int32           `json:"page_offset"`
 }
 
 type GetProductsByMerchantRow struct {
@@ -397,7 +495,7 @@ type GetProductsByMerchantRow struct {
 	CategoryID   int32           `json:"category_id"`
 	Name         string          `json:"name"`
 	Description  sql.NullString  `json:"description"`
-	Price        int32           `json:"price"`
+	Price        int64           `json:"price"`
 	CountInStock int32           `json:"count_in_stock"`
 	Brand        sql.NullString  `json:"brand"`
 	Weight       sql.NullInt32   `json:"weight"`
@@ -407,12 +505,111 @@ type GetProductsByMerchantRow struct {
 	CreatedAt    sql.NullTime    `json:"created_at"`
 	UpdatedAt    sql.NullTime    `json:"updated_at"`
 	DeletedAt    sql.NullTime    `json:"deleted_at"`
+	SearchVector interface{}     `json:"search_vector"`
 	TotalCount   int64           `json:"total_count"`
 }
 
 func (q *Queries) GetProductsByMerchant(ctx context.Context, arg GetProductsByMerchantParams) ([]*GetProductsByMerchantRow, error) {
 	rows, err := q.db.QueryContext(ctx, getProductsByMerchant,
 		arg.MerchantID,
+		arg.Search,
+		arg.MinPrice,
+		arg.MaxPrice,
+		arg.MinRating,
+		arg.Brand,
+		arg.InStock,
+		arg.Sort,
+		arg.PageLimit,
+		arg.PageOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*GetProductsByMerchantRow
+	for rows.Next() {
+		var i GetProductsByMerchantRow
+		if err := rows.Scan(
+			&i.ProductID,
+			&i.MerchantID,
+			&i.CategoryID,
+			&i.Name,
+			&i.Description,
+			&i.Price,
+			&i.CountInStock,
+			&i.Brand,
+			&i.Weight,
+			&i.Rating,
+			&i.SlugProduct,
+			&i.ImageProduct,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.SearchVector,
+			&i.TotalCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getProductsByUserID = `-- name: GetProductsByUserID :many
+SELECT
+    p.product_id, p.merchant_id, p.category_id, p.name, p.description, p.price, p.count_in_stock, p.brand, p.weight, p.rating, p.slug_product, p.image_product, p.created_at, p.updated_at, p.deleted_at, p.search_vector,
+    COUNT(*) OVER() AS total_count
+FROM products p
+JOIN merchants m ON m.merchant_id = p.merchant_id
+WHERE m.user_id = $1
+AND m.deleted_at IS NULL
+AND p.deleted_at IS NULL
+AND ($2::TEXT IS NULL
+       OR p.name ILIKE '%' || $2 || '%'
+       OR p.description ILIKE '%' || $2 || '%'
+       OR p.brand ILIKE '%' || $2 || '%'
+       OR p.slug_product ILIKE '%' || $2 || '%')
+ORDER BY p.created_at DESC
+LIMIT $3 OFFSET $4
+`
+
+type GetProductsByUserIDParams struct {
+	UserID  int32  `json:"user_id"`
+	Column2 string `json:"column_2"`
+	Limit   int32  `json:"limit"`
+	Offset  int32  `json:"offset"`
+}
+
+type GetProductsByUserIDRow struct {
+	ProductID    int32           `json:"product_id"`
+	MerchantID   int32           `json:"merchant_id"`
+	CategoryID   int32           `json:"category_id"`
+	Name         string          `json:"name"`
+	Description  sql.NullString  `json:"description"`
+	Price        int64           `json:"price"`
+	CountInStock int32           `json:"count_in_stock"`
+	Brand        sql.NullString  `json:"brand"`
+	Weight       sql.NullInt32   `json:"weight"`
+	Rating       sql.NullFloat64 `json:"rating"`
+	SlugProduct  sql.NullString  `json:"slug_product"`
+	ImageProduct sql.NullString  `json:"image_product"`
+	CreatedAt    sql.NullTime    `json:"created_at"`
+	UpdatedAt    sql.NullTime    `json:"updated_at"`
+	DeletedAt    sql.NullTime    `json:"deleted_at"`
+	SearchVector interface{}     `json:"search_vector"`
+	TotalCount   int64           `json:"total_count"`
+}
+
+// Get Products of every merchant owned by a user
+func (q *Queries) GetProductsByUserID(ctx context.Context, arg GetProductsByUserIDParams) ([]*GetProductsByUserIDRow, error) {
+	rows, err := q.db.QueryContext(ctx, getProductsByUserID,
+		arg.UserID,
 		arg.Column2,
 		arg.Limit,
 		arg.Offset,
@@ -421,9 +618,9 @@ func (q *Queries) GetProductsByMerchant(ctx context.Context, arg GetProductsByMe
 		return nil, err
 	}
 	defer rows.Close()
-	var items []*GetProductsByMerchantRow
+	var items []*GetProductsByUserIDRow
 	for rows.Next() {
-		var i GetProductsByMerchantRow
+		var i GetProductsByUserIDRow
 		if err := rows.Scan(
 			&i.ProductID,
 			&i.MerchantID,
@@ -440,6 +637,7 @@ func (q *Queries) GetProductsByMerchant(ctx context.Context, arg GetProductsByMe
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.SearchVector,
 			&i.TotalCount,
 		); err != nil {
 			return nil, err
@@ -455,9 +653,77 @@ func (q *Queries) GetProductsByMerchant(ctx context.Context, arg GetProductsByMe
 	return items, nil
 }
 
+const getProductsCursor = `-- name: GetProductsCursor :many
+SELECT product_id, merchant_id, category_id, name, description, price, count_in_stock, brand, weight, rating, slug_product, image_product, created_at, updated_at, deleted_at, search_vector
+FROM products
+WHERE deleted_at IS NULL
+AND ($1::TEXT IS NULL
+       OR name ILIKE '%' || $1::TEXT || '%'
+       OR description ILIKE '%' || $1::TEXT || '%'
+       OR brand ILIKE '%' || $1::TEXT || '%'
+       OR slug_product ILIKE '%' || $1::TEXT || '%')
+AND ($2::TIMESTAMP IS NULL
+       OR (created_at, product_id) < ($2::TIMESTAMP, $3::INT))
+ORDER BY created_at DESC, product_id DESC
+LIMIT $4
+`
+
+type GetProductsCursorParams struct {
+	Search          string        `json:"search"`
+	CursorCreatedAt sql.NullTime  `json:"cursor_created_at"`
+	CursorID        sql.NullInt32 `json:"cursor_id"`
+	PageLimit       int32         `json:"page_limit"`
+}
+
+// Get Products with Keyset Pagination
+func (q *Queries) GetProductsCursor(ctx context.Context, arg GetProductsCursorParams) ([]*Product, error) {
+	rows, err := q.db.QueryContext(ctx, getProductsCursor,
+		arg.Search,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*Product
+	for rows.Next() {
+		var i Product
+		if err := rows.Scan(
+			&i.ProductID,
+			&i.MerchantID,
+			&i.CategoryID,
+			&i.Name,
+			&i.Description,
+			&i.Price,
+			&i.CountInStock,
+			&i.Brand,
+			&i.Weight,
+			&i.Rating,
+			&i.SlugProduct,
+			&i.ImageProduct,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.SearchVector,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getProductsTrashed = `-- name: GetProductsTrashed :many
 SELECT
-    product_id, merchant_id, category_id, name, description, price, count_in_stock, brand, weight, rating, slug_product, image_product, created_at, updated_at, deleted_at,
+    product_id, merchant_id, category_id, name, description, price, count_in_stock, brand, weight, rating, slug_product, image_product, created_at, updated_at, deleted_at, search_vector,
     COUNT(*) OVER() AS total_count
 FROM products
 WHERE deleted_at IS NOT NULL
@@ -482,7 +748,7 @@ type GetProductsTrashedRow struct {
 	CategoryID   int32           `json:"category_id"`
 	Name         string          `json:"name"`
 	Description  sql.NullString  `json:"description"`
-	Price        int32           `json:"price"`
+	Price        int64           `json:"price"`
 	CountInStock int32           `json:"count_in_stock"`
 	Brand        sql.NullString  `json:"brand"`
 	Weight       sql.NullInt32   `json:"weight"`
@@ -492,6 +758,7 @@ type GetProductsTrashedRow struct {
 	CreatedAt    sql.NullTime    `json:"created_at"`
 	UpdatedAt    sql.NullTime    `json:"updated_at"`
 	DeletedAt    sql.NullTime    `json:"deleted_at"`
+	SearchVector interface{}     `json:"search_vector"`
 	TotalCount   int64           `json:"total_count"`
 }
 
@@ -521,6 +788,7 @@ func (q *Queries) GetProductsTrashed(ctx context.Context, arg GetProductsTrashed
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.SearchVector,
 			&i.TotalCount,
 		); err != nil {
 			return nil, err
@@ -536,6 +804,84 @@ func (q *Queries) GetProductsTrashed(ctx context.Context, arg GetProductsTrashed
 	return items, nil
 }
 
+const releaseProductStock = `-- name: ReleaseProductStock :one
+UPDATE products
+SET count_in_stock = count_in_stock + $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE product_id = $1
+RETURNING product_id, merchant_id, category_id, name, description, price, count_in_stock, brand, weight, rating, slug_product, image_product, created_at, updated_at, deleted_at, search_vector
+`
+
+type ReleaseProductStockParams struct {
+	ProductID    int32 `json:"product_id"`
+	CountInStock int32 `json:"count_in_stock"`
+}
+
+// Puts $2 units back into stock, e.g. when an order is cancelled
+func (q *Queries) ReleaseProductStock(ctx context.Context, arg ReleaseProductStockParams) (*Product, error) {
+	row := q.db.QueryRowContext(ctx, releaseProductStock, arg.ProductID, arg.CountInStock)
+	var i Product
+	err := row.Scan(
+		&i.ProductID,
+		&i.MerchantID,
+		&i.CategoryID,
+		&i.Name,
+		&i.Description,
+		&i.Price,
+		&i.CountInStock,
+		&i.Brand,
+		&i.Weight,
+		&i.Rating,
+		&i.SlugProduct,
+		&i.ImageProduct,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.SearchVector,
+	)
+	return &i, err
+}
+
+const reserveProductStock = `-- name: ReserveProductStock :one
+UPDATE products
+SET count_in_stock = count_in_stock - $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE product_id = $1
+    AND deleted_at IS NULL
+    AND count_in_stock >= $2
+RETURNING product_id, merchant_id, category_id, name, description, price, count_in_stock, brand, weight, rating, slug_product, image_product, created_at, updated_at, deleted_at, search_vector
+`
+
+type ReserveProductStockParams struct {
+	ProductID    int32 `json:"product_id"`
+	CountInStock int32 `json:"count_in_stock"`
+}
+
+// Atomically take $2 units out of stock; returns no row when not enough is left
+func (q *Queries) ReserveProductStock(ctx context.Context, arg ReserveProductStockParams) (*Product, error) {
+	row := q.db.QueryRowContext(ctx, reserveProductStock, arg.ProductID, arg.CountInStock)
+	var i Product
+	err := row.Scan(
+		&i.ProductID,
+		&i.MerchantID,
+		&i.CategoryID,
+		&i.Name,
+		&i.Description,
+		&i.Price,
+		&i.CountInStock,
+		&i.Brand,
+		&i.Weight,
+		&i.Rating,
+		&i.SlugProduct,
+		&i.ImageProduct,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.SearchVector,
+	)
+	return &i, err
+}
+
 const restoreAllProducts = `-- name: RestoreAllProducts :exec
 UPDATE products
 SET
@@ -557,7 +903,7 @@ SET
 WHERE
     product_id = $1
     AND deleted_at IS NOT NULL
-  RETURNING product_id, merchant_id, category_id, name, description, price, count_in_stock, brand, weight, rating, slug_product, image_product, created_at, updated_at, deleted_at
+  RETURNING product_id, merchant_id, category_id, name, description, price, count_in_stock, brand, weight, rating, slug_product, image_product, created_at, updated_at, deleted_at, search_vector
 `
 
 // Restore Trashed Product
@@ -580,10 +926,201 @@ func (q *Queries) RestoreProduct(ctx context.Context, productID int32) (*Product
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.SearchVector,
 	)
 	return &i, err
 }
 
+const searchProductFacets = `-- name: SearchProductFacets :many
+WITH matched AS (
+    SELECT p.category_id, p.merchant_id, p.brand, p.price
+    FROM products p
+    WHERE p.deleted_at IS NULL
+      AND (p.search_vector @@ websearch_to_tsquery('english', $1::TEXT)
+           OR p.name % $1::TEXT)
+      AND ($2::INT IS NULL OR p.category_id = $2::INT)
+      AND ($3::INT IS NULL OR p.merchant_id = $3::INT)
+      AND ($4::TEXT IS NULL OR p.brand = $4::TEXT)
+      AND ($5::BIGINT IS NULL OR p.price >= $5::BIGINT)
+      AND ($6::BIGINT IS NULL OR p.price <= $6::BIGINT)
+)
+SELECT 'category'::TEXT AS facet, c.category_id::TEXT AS value, c.name::TEXT AS label, COUNT(*) AS count
+FROM matched m
+JOIN categories c ON c.category_id = m.category_id
+GROUP BY c.category_id, c.name
+UNION ALL
+SELECT 'brand'::TEXT, m.brand::TEXT, m.brand::TEXT, COUNT(*)
+FROM matched m
+WHERE m.brand IS NOT NULL
+GROUP BY m.brand
+UNION ALL
+SELECT 'merchant'::TEXT, mc.merchant_id::TEXT, mc.name::TEXT, COUNT(*)
+FROM matched m
+JOIN merchants mc ON mc.merchant_id = m.merchant_id
+GROUP BY mc.merchant_id, mc.name
+UNION ALL
+SELECT 'price'::TEXT, width_bucket(m.price, $7::BIGINT[])::TEXT, ''::TEXT, COUNT(*)
+FROM matched m
+GROUP BY 2
+ORDER BY facet, count DESC, value
+`
+
+type SearchProductFacetsParams struct {
+	Query       string         `json:"query"`
+	CategoryID  sql.NullInt32  `json:"category_id"`
+	MerchantID  sql.NullInt32  `json:"merchant_id"`
+	Brand       sql.NullString `json:"brand"`
+	MinPrice    sql.NullInt64  `json:"min_price"`
+	MaxPrice    sql.NullInt64  `json:"max_price"`
+	PriceBounds []int64        `json:"price_bounds"`
+}
+
+type SearchProductFacetsRow struct {
+	Facet string `json:"facet"`
+	Value string `json:"value"`
+	Label string `json:"label"`
+	Count int64  `json:"count"`
+}
+
+// Count the Products matched by a search per category, brand, merchant and price bucket
+func (q *Queries) SearchProductFacets(ctx context.Context, arg SearchProductFacetsParams) ([]*SearchProductFacetsRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchProductFacets,
+		arg.Query,
+		arg.CategoryID,
+		arg.MerchantID,
+		arg.Brand,
+		arg.MinPrice,
+		arg.MaxPrice,
+		pq.Array(arg.PriceBounds),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*SearchProductFacetsRow
+	for rows.Next() {
+		var i SearchProductFacetsRow
+		if err := rows.Scan(
+			&i.Facet,
+			&i.Value,
+			&i.Label,
+			&i.Count,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchProducts = `-- name: SearchProducts :many
+SELECT
+    p.product_id, p.merchant_id, p.category_id, p.name, p.description, p.price, p.count_in_stock, p.brand, p.weight, p.rating, p.slug_product, p.image_product, p.created_at, p.updated_at, p.deleted_at, p.search_vector,
+    COUNT(*) OVER() AS total_count
+FROM products p
+WHERE p.deleted_at IS NULL
+  AND (p.search_vector @@ websearch_to_tsquery('english', $1::TEXT)
+       OR p.name % $1::TEXT)
+  AND ($2::INT IS NULL OR p.category_id = $2::INT)
+  AND ($3::INT IS NULL OR p.merchant_id = $3::INT)
+  AND ($4::TEXT IS NULL OR p.brand = $4::TEXT)
+  AND ($5::BIGINT IS NULL OR p.price >= $5::BIGINT)
+  AND ($6::BIGINT IS NULL OR p.price <= $6::BIGINT)
+ORDER BY
+    ts_rank(p.search_vector, websearch_to_tsquery('english', $1::TEXT)) DESC,
+    similarity(p.name, $1::TEXT) DESC,
+    p.product_id DESC
+LIMIT $7 OFFSET $8
+`
+
+type SearchProductsParams struct {
+	Query      string         `json:"query"`
+	CategoryID sql.NullInt32  `json:"category_id"`
+	MerchantID sql.NullInt32  `json:"merchant_id"`
+	Brand      sql.NullString `json:"brand"`
+	MinPrice   sql.NullInt64  `json:"min_price"`
+	MaxPrice   sql.NullInt64  `json:"max_price"`
+	PageLimit  int32          `json:"page_limit"`
+	PageOffset int32          `json:"page_offset"`
+}
+
+type SearchProductsRow struct {
+	ProductID    int32           `json:"product_id"`
+	MerchantID   int32           `json:"merchant_id"`
+	CategoryID   int32           `json:"category_id"`
+	Name         string          `json:"name"`
+	Description  sql.NullString  `json:"description"`
+	Price        int64           `json:"price"`
+	CountInStock int32           `json:"count_in_stock"`
+	Brand        sql.NullString  `json:"brand"`
+	Weight       sql.NullInt32   `json:"weight"`
+	Rating       sql.NullFloat64 `json:"rating"`
+	SlugProduct  sql.NullString  `json:"slug_product"`
+	ImageProduct sql.NullString  `json:"image_product"`
+	CreatedAt    sql.NullTime    `json:"created_at"`
+	UpdatedAt    sql.NullTime    `json:"updated_at"`
+	DeletedAt    sql.NullTime    `json:"deleted_at"`
+	SearchVector interface{}     `json:"search_vector"`
+	TotalCount   int64           `json:"total_count"`
+}
+
+// Search Products ranked by full-text relevance with a trigram fallback on the name
+func (q *Queries) SearchProducts(ctx context.Context, arg SearchProductsParams) ([]*SearchProductsRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchProducts,
+		arg.Query,
+		arg.CategoryID,
+		arg.MerchantID,
+		arg.Brand,
+		arg.MinPrice,
+		arg.MaxPrice,
+		arg.PageLimit,
+		arg.PageOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*SearchProductsRow
+	for rows.Next() {
+		var i SearchProductsRow
+		if err := rows.Scan(
+			&i.ProductID,
+			&i.MerchantID,
+			&i.CategoryID,
+			&i.Name,
+			&i.Description,
+			&i.Price,
+			&i.CountInStock,
+			&i.Brand,
+			&i.Weight,
+			&i.Rating,
+			&i.SlugProduct,
+			&i.ImageProduct,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.SearchVector,
+			&i.TotalCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const trashProduct = `-- name: TrashProduct :one
 UPDATE products
 SET
@@ -591,7 +1128,7 @@ SET
 WHERE
     product_id = $1
     AND deleted_at IS NULL
-    RETURNING product_id, merchant_id, category_id, name, description, price, count_in_stock, brand, weight, rating, slug_product, image_product, created_at, updated_at, deleted_at
+    RETURNING product_id, merchant_id, category_id, name, description, price, count_in_stock, brand, weight, rating, slug_product, image_product, created_at, updated_at, deleted_at, search_vector
 `
 
 // Trash Product
@@ -614,6 +1151,7 @@ func (q *Queries) TrashProduct(ctx context.Context, productID int32) (*Product,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.SearchVector,
 	)
 	return &i, err
 }
@@ -633,7 +1171,7 @@ SET category_id = $2,
     updated_at = CURRENT_TIMESTAMP
 WHERE product_id = $1
   AND deleted_at IS NULL
-  RETURNING product_id, merchant_id, category_id, name, description, price, count_in_stock, brand, weight, rating, slug_product, image_product, created_at, updated_at, deleted_at
+  RETURNING product_id, merchant_id, category_id, name, description, price, count_in_stock, brand, weight, rating, slug_product, image_product, created_at, updated_at, deleted_at, search_vector
 `
 
 type UpdateProductParams struct {
@@ -641,7 +1179,7 @@ type UpdateProductParams struct {
 	CategoryID   int32           `json:"category_id"`
 	Name         string          `json:"name"`
 	Description  sql.NullString  `json:"description"`
-	Price        int32           `json:"price"`
+	Price        int64           `json:"price"`
 	CountInStock int32           `json:"count_in_stock"`
 	Brand        sql.NullString  `json:"brand"`
 	Weight       sql.NullInt32   `json:"weight"`
@@ -681,6 +1219,7 @@ func (q *Queries) UpdateProduct(ctx context.Context, arg UpdateProductParams) (*
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.SearchVector,
 	)
 	return &i, err
 }
@@ -690,7 +1229,7 @@ UPDATE products
 SET count_in_stock = $2
 WHERE product_id = $1
     AND deleted_at IS NULL
-RETURNING product_id, merchant_id, category_id, name, description, price, count_in_stock, brand, weight, rating, slug_product, image_product, created_at, updated_at, deleted_at
+RETURNING product_id, merchant_id, category_id, name, description, price, count_in_stock, brand, weight, rating, slug_product, image_product, created_at, updated_at, deleted_at, search_vector
 `
 
 type UpdateProductCountStockParams struct {
@@ -717,6 +1256,7 @@ func (q *Queries) UpdateProductCountStock(ctx context.Context, arg UpdateProduct
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.SearchVector,
 	)
 	return &i, err
 }
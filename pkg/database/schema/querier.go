@@ -6,19 +6,31 @@ package db
 
 import (
 	"context"
+	"time"
 )
 
 type Querier interface {
 	AssignRoleToUser(ctx context.Context, arg AssignRoleToUserParams) (*UserRole, error)
-	CalculateTotalPrice(ctx context.Context, orderID int32) (int32, error)
-	CreateCart(ctx context.Context, arg CreateCartParams) (*Cart, error)
+	// Claim a key for a request, taking over keys whose holder stalled or that expired
+	ClaimIdempotencyKey(ctx context.Context, arg ClaimIdempotencyKeyParams) (*IdempotencyKey, error)
+	// Store the response of a completed request so retries can replay it
+	CompleteIdempotencyKey(ctx context.Context, arg CompleteIdempotencyKeyParams) error
+	// Consume a single-use token, only while it is unused and unexpired
+	ConsumeUserToken(ctx context.Context, arg ConsumeUserTokenParams) (*UserToken, error)
 	CreateCategory(ctx context.Context, arg CreateCategoryParams) (*Category, error)
+	CreateLoginAuditLog(ctx context.Context, arg CreateLoginAuditLogParams) error
 	// Create Merchant
 	CreateMerchant(ctx context.Context, arg CreateMerchantParams) (*Merchant, error)
+	// Orders are priced in the currency of the merchant they are placed with
 	CreateOrder(ctx context.Context, arg CreateOrderParams) (*Order, error)
 	CreateOrderItem(ctx context.Context, arg CreateOrderItemParams) (*OrderItem, error)
+	CreateOrderStatusHistory(ctx context.Context, arg CreateOrderStatusHistoryParams) (*OrderStatusHistory, error)
+	// Record a webhook event, skipping redeliveries of an event already stored
+	CreatePaymentEvent(ctx context.Context, arg CreatePaymentEventParams) (*PaymentEvent, error)
 	CreateProduct(ctx context.Context, arg CreateProductParams) (*Product, error)
 	CreateRefreshToken(ctx context.Context, arg CreateRefreshTokenParams) (*RefreshToken, error)
+	CreateRefund(ctx context.Context, arg CreateRefundParams) (*Refund, error)
+	CreateRefundItem(ctx context.Context, arg CreateRefundItemParams) (*RefundItem, error)
 	CreateReview(ctx context.Context, arg CreateReviewParams) (*Review, error)
 	CreateRole(ctx context.Context, roleName string) (*Role, error)
 	CreateShippingAddress(ctx context.Context, arg CreateShippingAddressParams) (*ShippingAddress, error)
@@ -26,7 +38,8 @@ type Querier interface {
 	CreateTransaction(ctx context.Context, arg CreateTransactionParams) (*Transaction, error)
 	// Create User
 	CreateUser(ctx context.Context, arg CreateUserParams) (*User, error)
-	DeleteAllCart(ctx context.Context, dollar_1 []int32) error
+	CreateUserToken(ctx context.Context, arg CreateUserTokenParams) (*UserToken, error)
+	DeleteAllCart(ctx context.Context, arg DeleteAllCartParams) error
 	// Delete All Trashed Category Permanently
 	DeleteAllPermanentCategories(ctx context.Context) error
 	// Delete All Trashed Merchant Permanently
@@ -45,9 +58,11 @@ type Querier interface {
 	DeleteAllPermanentTransactions(ctx context.Context) error
 	// Delete All Trashed Users Permanently
 	DeleteAllPermanentUsers(ctx context.Context) error
-	DeleteCart(ctx context.Context, cartID int32) error
+	DeleteCart(ctx context.Context, arg DeleteCartParams) error
 	// Delete Category Permanently
 	DeleteCategoryPermanently(ctx context.Context, categoryID int32) error
+	// Drop revocations no token they cover can outlive
+	DeleteExpiredRevokedTokens(ctx context.Context, expiresAt time.Time) error
 	// Delete Merchant Permanently
 	DeleteMerchantPermanently(ctx context.Context, merchantID int32) error
 	// Delete Order Item Permanently
@@ -56,8 +71,6 @@ type Querier interface {
 	DeletePermanentRole(ctx context.Context, roleID int32) error
 	// Delete Product Permanently
 	DeleteProductPermanently(ctx context.Context, productID int32) error
-	DeleteRefreshToken(ctx context.Context, token string) error
-	DeleteRefreshTokenByUserId(ctx context.Context, userID int32) error
 	DeleteReviewPermanently(ctx context.Context, reviewID int32) error
 	DeleteShippingAddressPermanently(ctx context.Context, shippingAddressID int32) error
 	DeleteSliderPermanently(ctx context.Context, sliderID int32) error
@@ -65,11 +78,20 @@ type Querier interface {
 	DeleteTransactionPermanently(ctx context.Context, transactionID int32) error
 	// Delete User Permanently
 	DeleteUserPermanently(ctx context.Context, userID int32) error
+	// Store the error a request failed with after it may have had side effects, so
+	// retries get the same error instead of running again
+	FailIdempotencyKey(ctx context.Context, arg FailIdempotencyKeyParams) error
+	// The current token of every session a user is still signed in with
+	FindActiveRefreshTokensByUserId(ctx context.Context, userID int32) ([]*RefreshToken, error)
+	FindActiveRevokedTokens(ctx context.Context, expiresAt time.Time) ([]*RevokedToken, error)
+	FindLockedLoginThrottles(ctx context.Context, arg FindLockedLoginThrottlesParams) ([]*LoginThrottle, error)
 	FindRefreshTokenByToken(ctx context.Context, token string) (*RefreshToken, error)
-	FindRefreshTokenByUserId(ctx context.Context, userID int32) (*RefreshToken, error)
 	// Get All Active Roles
 	GetActiveRoles(ctx context.Context, arg GetActiveRolesParams) ([]*GetActiveRolesRow, error)
+	GetCart(ctx context.Context, cartID int32) (*Cart, error)
+	GetCartSummary(ctx context.Context, userID int32) (*GetCartSummaryRow, error)
 	GetCarts(ctx context.Context, arg GetCartsParams) ([]*GetCartsRow, error)
+	GetCartsForCheckout(ctx context.Context, arg GetCartsForCheckoutParams) ([]*GetCartsForCheckoutRow, error)
 	// Get Categories with Pagination and Total Count
 	GetCategories(ctx context.Context, arg GetCategoriesParams) ([]*GetCategoriesRow, error)
 	// Get Active Categories with Pagination and Total Count
@@ -77,13 +99,18 @@ type Querier interface {
 	// Get Trashed Categories with Pagination and Total Count
 	GetCategoriesTrashed(ctx context.Context, arg GetCategoriesTrashedParams) ([]*GetCategoriesTrashedRow, error)
 	GetCategoryByID(ctx context.Context, categoryID int32) (*Category, error)
+	GetIdempotencyKey(ctx context.Context, arg GetIdempotencyKeyParams) (*IdempotencyKey, error)
 	GetMerchantByID(ctx context.Context, merchantID int32) (*Merchant, error)
 	GetMerchants(ctx context.Context, arg GetMerchantsParams) ([]*GetMerchantsRow, error)
 	// Get Active Merchants with Pagination and Total Count
 	GetMerchantsActive(ctx context.Context, arg GetMerchantsActiveParams) ([]*GetMerchantsActiveRow, error)
+	// Get Merchants owned by a user
+	GetMerchantsByUserID(ctx context.Context, userID int32) ([]*Merchant, error)
 	// Get Trashed Merchants with Pagination and Total Count
 	GetMerchantsTrashed(ctx context.Context, arg GetMerchantsTrashedParams) ([]*GetMerchantsTrashedRow, error)
 	GetOrderByID(ctx context.Context, orderID int32) (*Order, error)
+	// Lock an order while a payment is being started for it
+	GetOrderByIDForUpdate(ctx context.Context, orderID int32) (*Order, error)
 	// Get Order Items  with Pagination and Total Count
 	GetOrderItems(ctx context.Context, arg GetOrderItemsParams) ([]*GetOrderItemsRow, error)
 	// Get Active Order Item with Pagination and Total Count
@@ -91,10 +118,15 @@ type Querier interface {
 	GetOrderItemsByOrder(ctx context.Context, orderID int32) ([]*OrderItem, error)
 	// Get Trashed Orders Items with Pagination and Total Count
 	GetOrderItemsTrashed(ctx context.Context, arg GetOrderItemsTrashedParams) ([]*GetOrderItemsTrashedRow, error)
+	GetOrderStatusHistories(ctx context.Context, orderID int32) ([]*OrderStatusHistory, error)
 	GetOrders(ctx context.Context, arg GetOrdersParams) ([]*GetOrdersRow, error)
 	GetOrdersActive(ctx context.Context, arg GetOrdersActiveParams) ([]*GetOrdersActiveRow, error)
 	GetOrdersByMerchant(ctx context.Context, arg GetOrdersByMerchantParams) ([]*GetOrdersByMerchantRow, error)
+	// Get Orders with Keyset Pagination
+	GetOrdersCursor(ctx context.Context, arg GetOrdersCursorParams) ([]*Order, error)
 	GetOrdersTrashed(ctx context.Context, arg GetOrdersTrashedParams) ([]*GetOrdersTrashedRow, error)
+	// Refunds of a transaction still waiting on the payment provider outcome
+	GetPendingRefundsByTransaction(ctx context.Context, transactionID int32) ([]*Refund, error)
 	GetProductByID(ctx context.Context, productID int32) (*Product, error)
 	GetProducts(ctx context.Context, arg GetProductsParams) ([]*GetProductsRow, error)
 	// Get Active Products with Pagination and Total Count
@@ -102,12 +134,22 @@ type Querier interface {
 	// Get Products by Category Name with Filters
 	GetProductsByCategoryName(ctx context.Context, arg GetProductsByCategoryNameParams) ([]*GetProductsByCategoryNameRow, error)
 	GetProductsByMerchant(ctx context.Context, arg GetProductsByMerchantParams) ([]*GetProductsByMerchantRow, error)
+	// Get Products of every merchant owned by a user
+	GetProductsByUserID(ctx context.Context, arg GetProductsByUserIDParams) ([]*GetProductsByUserIDRow, error)
+	// Get Products with Keyset Pagination
+	GetProductsCursor(ctx context.Context, arg GetProductsCursorParams) ([]*Product, error)
 	// Get Trashed Products with Pagination and Total Count
 	GetProductsTrashed(ctx context.Context, arg GetProductsTrashedParams) ([]*GetProductsTrashedRow, error)
+	GetRefundItemsByRefund(ctx context.Context, refundID int32) ([]*RefundItem, error)
+	// Quantity of every order item already refunded on a transaction, counting
+	// refunds still pending with the payment provider
+	GetRefundedQuantitiesByTransaction(ctx context.Context, transactionID int32) ([]*GetRefundedQuantitiesByTransactionRow, error)
 	GetReviewByID(ctx context.Context, reviewID int32) (*Review, error)
 	GetReviews(ctx context.Context, arg GetReviewsParams) ([]*GetReviewsRow, error)
 	GetReviewsActive(ctx context.Context, arg GetReviewsActiveParams) ([]*GetReviewsActiveRow, error)
 	GetReviewsByProductID(ctx context.Context, arg GetReviewsByProductIDParams) ([]*GetReviewsByProductIDRow, error)
+	// Get Reviews with Keyset Pagination
+	GetReviewsCursor(ctx context.Context, arg GetReviewsCursorParams) ([]*Review, error)
 	GetReviewsTrashed(ctx context.Context, arg GetReviewsTrashedParams) ([]*GetReviewsTrashedRow, error)
 	GetRole(ctx context.Context, roleID int32) (*Role, error)
 	GetRoleByName(ctx context.Context, roleName string) (*Role, error)
@@ -122,11 +164,17 @@ type Querier interface {
 	GetSlidersActive(ctx context.Context, arg GetSlidersActiveParams) ([]*GetSlidersActiveRow, error)
 	GetSlidersTrashed(ctx context.Context, arg GetSlidersTrashedParams) ([]*GetSlidersTrashedRow, error)
 	GetTransactionByID(ctx context.Context, transactionID int32) (*Transaction, error)
+	// Lock a transaction while it is being refunded
+	GetTransactionByIDForUpdate(ctx context.Context, transactionID int32) (*Transaction, error)
 	GetTransactionByMerchant(ctx context.Context, arg GetTransactionByMerchantParams) ([]*GetTransactionByMerchantRow, error)
 	GetTransactionByOrderID(ctx context.Context, orderID int32) (*Transaction, error)
+	// Lock the transaction a provider charge belongs to
+	GetTransactionByProviderChargeID(ctx context.Context, arg GetTransactionByProviderChargeIDParams) (*Transaction, error)
 	GetTransactions(ctx context.Context, arg GetTransactionsParams) ([]*GetTransactionsRow, error)
 	// Get Active Transactions with Pagination and Total Count
 	GetTransactionsActive(ctx context.Context, arg GetTransactionsActiveParams) ([]*GetTransactionsActiveRow, error)
+	// Get Transactions with Keyset Pagination
+	GetTransactionsCursor(ctx context.Context, arg GetTransactionsCursorParams) ([]*Transaction, error)
 	// Get Trashed Transactions with Pagination and Total Count
 	GetTransactionsTrashed(ctx context.Context, arg GetTransactionsTrashedParams) ([]*GetTransactionsTrashedRow, error)
 	// Get All Trashed Roles
@@ -143,7 +191,28 @@ type Querier interface {
 	GetUsers(ctx context.Context, arg GetUsersParams) ([]*GetUsersRow, error)
 	// Get Active Users with Pagination and Total Count
 	GetUsersActive(ctx context.Context, arg GetUsersActiveParams) ([]*GetUsersActiveRow, error)
+	// Whether an order already has a transaction that is awaiting or has
+	// collected payment
+	HasLiveTransactionByOrderID(ctx context.Context, orderID int32) (bool, error)
+	// Invalidate the outstanding tokens of a user for one purpose
+	InvalidateUserTokens(ctx context.Context, arg InvalidateUserTokensParams) error
+	LockLoginThrottle(ctx context.Context, arg LockLoginThrottleParams) error
+	// Marks a token as exchanged, only if it has not been used or revoked yet
+	MarkRefreshTokenUsed(ctx context.Context, refreshTokenID int32) (*RefreshToken, error)
+	// Recompute the payable amount of an order: its items plus shipping cost plus
+	// tax at tax_rate basis points of the items, less the order's discount
+	RecalculateOrderTotal(ctx context.Context, arg RecalculateOrderTotalParams) (*Order, error)
+	// Count a failed login, starting over when the last failure is older than reset_before
+	RecordLoginFailure(ctx context.Context, arg RecordLoginFailureParams) (*LoginThrottle, error)
+	// Drop the claim of a request that failed so it can be retried
+	ReleaseIdempotencyKey(ctx context.Context, idempotencyKeyID int32) error
+	// Puts $2 units back into stock, e.g. when an order is cancelled
+	ReleaseProductStock(ctx context.Context, arg ReleaseProductStockParams) (*Product, error)
 	RemoveRoleFromUser(ctx context.Context, arg RemoveRoleFromUserParams) error
+	// Atomically take $2 units out of stock; returns no row when not enough is left
+	ReserveProductStock(ctx context.Context, arg ReserveProductStockParams) (*Product, error)
+	// Forget the failed logins of an account or IP address, lifting any lock
+	ResetLoginThrottle(ctx context.Context, arg ResetLoginThrottleParams) (int64, error)
 	// Restore All Trashed Category
 	RestoreAllCategories(ctx context.Context) error
 	// Restore All Trashed Merchant
@@ -180,6 +249,17 @@ type Querier interface {
 	// Restore Trashed User
 	RestoreUser(ctx context.Context, userID int32) (*User, error)
 	RestoreUserRole(ctx context.Context, userRoleID int32) error
+	// Revoke a single access token by its jti
+	RevokeAccessToken(ctx context.Context, arg RevokeAccessTokenParams) error
+	RevokeRefreshTokenFamily(ctx context.Context, familyID string) error
+	RevokeRefreshTokenFamilyByUserId(ctx context.Context, arg RevokeRefreshTokenFamilyByUserIdParams) (int64, error)
+	RevokeRefreshTokensByUserId(ctx context.Context, userID int32) error
+	// Revoke every access token of a user issued before revoked_at
+	RevokeUserAccessTokens(ctx context.Context, arg RevokeUserAccessTokensParams) error
+	// Count the Products matched by a search per category, brand, merchant and price bucket
+	SearchProductFacets(ctx context.Context, arg SearchProductFacetsParams) ([]*SearchProductFacetsRow, error)
+	// Search Products ranked by full-text relevance with a trigram fallback on the name
+	SearchProducts(ctx context.Context, arg SearchProductsParams) ([]*SearchProductsRow, error)
 	// Trash Category
 	TrashCategory(ctx context.Context, categoryID int32) (*Category, error)
 	// Trash Merchant
@@ -198,21 +278,41 @@ type Querier interface {
 	// Trash User
 	TrashUser(ctx context.Context, userID int32) (*User, error)
 	TrashUserRole(ctx context.Context, userRoleID int32) error
+	UpdateCartQuantity(ctx context.Context, arg UpdateCartQuantityParams) (*Cart, error)
 	UpdateCategory(ctx context.Context, arg UpdateCategoryParams) (*Category, error)
 	// Update Merchant
 	UpdateMerchant(ctx context.Context, arg UpdateMerchantParams) (*Merchant, error)
 	UpdateOrder(ctx context.Context, arg UpdateOrderParams) (*Order, error)
+	// Only touches the item when it belongs to the given order
 	UpdateOrderItem(ctx context.Context, arg UpdateOrderItemParams) (*OrderItem, error)
+	// Moves an order to a new status only if it is still in the expected one
+	UpdateOrderStatus(ctx context.Context, arg UpdateOrderStatusParams) (*Order, error)
 	UpdateProduct(ctx context.Context, arg UpdateProductParams) (*Product, error)
 	UpdateProductCountStock(ctx context.Context, arg UpdateProductCountStockParams) (*Product, error)
-	UpdateRefreshTokenByUserId(ctx context.Context, arg UpdateRefreshTokenByUserIdParams) error
+	// Record the payment provider outcome of a pending refund
+	UpdateRefundStatus(ctx context.Context, arg UpdateRefundStatusParams) (*Refund, error)
 	UpdateReview(ctx context.Context, arg UpdateReviewParams) (*Review, error)
 	UpdateRole(ctx context.Context, arg UpdateRoleParams) (*Role, error)
+	// Only touches the address when it belongs to the given order
 	UpdateShippingAddress(ctx context.Context, arg UpdateShippingAddressParams) (*ShippingAddress, error)
 	UpdateSlider(ctx context.Context, arg UpdateSliderParams) (*Slider, error)
+	// Amend the payment of a transaction whose outcome is still pending; the order
+	// and merchant it pays are fixed when it is created
 	UpdateTransaction(ctx context.Context, arg UpdateTransactionParams) (*Transaction, error)
+	// Record the payment provider outcome of a transaction
+	UpdateTransactionPayment(ctx context.Context, arg UpdateTransactionPaymentParams) (*Transaction, error)
+	// Recompute the refunded total of a transaction from its succeeded refunds
+	UpdateTransactionRefundedAmount(ctx context.Context, transactionID int32) (*Transaction, error)
 	// Update User
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (*User, error)
+	// Update User Password
+	UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) (*User, error)
+	// Adds the product to the user's cart, merging into an existing row for the
+	// same product. No row is returned when the merged quantity would exceed the
+	// product's stock.
+	UpsertCart(ctx context.Context, arg UpsertCartParams) (*Cart, error)
+	// Mark User Email as Verified
+	VerifyUserEmail(ctx context.Context, userID int32) (*User, error)
 }
 
 var _ Querier = (*Queries)(nil)
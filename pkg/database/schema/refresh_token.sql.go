@@ -7,23 +7,38 @@ package db
 
 import (
 	"context"
+	"database/sql"
 	"time"
 )
 
 const createRefreshToken = `-- name: CreateRefreshToken :one
-INSERT INTO refresh_tokens (user_id, token, expiration, created_at, updated_at)
-VALUES ($1, $2, $3, current_timestamp, current_timestamp)
-RETURNING refresh_token_id, user_id, token, expiration, created_at, updated_at, deleted_at
+INSERT INTO refresh_tokens (user_id, token, family_id, device, user_agent, ip_address, signed_in_at, expiration, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, current_timestamp, current_timestamp)
+RETURNING refresh_token_id, user_id, token, expiration, created_at, updated_at, deleted_at, family_id, device, user_agent, ip_address, signed_in_at, used_at, revoked_at
 `
 
 type CreateRefreshTokenParams struct {
-	UserID     int32     `json:"user_id"`
-	Token      string    `json:"token"`
-	Expiration time.Time `json:"expiration"`
+	UserID     int32          `json:"user_id"`
+	Token      string         `json:"token"`
+	FamilyID   string         `json:"family_id"`
+	Device     sql.NullString `json:"device"`
+	UserAgent  sql.NullString `json:"user_agent"`
+	IpAddress  sql.NullString `json:"ip_address"`
+	SignedInAt time.Time      `json:"signed_in_at"`
+	Expiration time.Time      `json:"expiration"`
 }
 
 func (q *Queries) CreateRefreshToken(ctx context.Context, arg CreateRefreshTokenParams) (*RefreshToken, error) {
-	row := q.db.QueryRowContext(ctx, createRefreshToken, arg.UserID, arg.Token, arg.Expiration)
+	row := q.db.QueryRowContext(ctx, createRefreshToken,
+		arg.UserID,
+		arg.Token,
+		arg.FamilyID,
+		arg.Device,
+		arg.UserAgent,
+		arg.IpAddress,
+		arg.SignedInAt,
+		arg.Expiration,
+	)
 	var i RefreshToken
 	err := row.Scan(
 		&i.RefreshTokenID,
@@ -33,32 +48,86 @@ func (q *Queries) CreateRefreshToken(ctx context.Context, arg CreateRefreshToken
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.FamilyID,
+		&i.Device,
+		&i.UserAgent,
+		&i.IpAddress,
+		&i.SignedInAt,
+		&i.UsedAt,
+		&i.RevokedAt,
 	)
 	return &i, err
 }
 
-const deleteRefreshToken = `-- name: DeleteRefreshToken :exec
-DELETE FROM refresh_tokens
-WHERE token = $1
-`
-
-func (q *Queries) DeleteRefreshToken(ctx context.Context, token string) error {
-	_, err := q.db.ExecContext(ctx, deleteRefreshToken, token)
-	return err
-}
-
-const deleteRefreshTokenByUserId = `-- name: DeleteRefreshTokenByUserId :exec
-DELETE FROM refresh_tokens
-WHERE user_id = $1
+const findActiveRefreshTokensByUserId = `-- name: FindActiveRefreshTokensByUserId :many
+SELECT
+    refresh_token_id,
+    user_id,
+    token,
+    expiration,
+    created_at,
+    updated_at,
+    deleted_at,
+    family_id,
+    device,
+    user_agent,
+    ip_address,
+    signed_in_at,
+    used_at,
+    revoked_at
+FROM
+    refresh_tokens
+WHERE
+    user_id = $1
+    AND used_at IS NULL
+    AND revoked_at IS NULL
+    AND expiration > current_timestamp
+    AND deleted_at IS NULL
+ORDER BY
+    created_at DESC
 `
 
-func (q *Queries) DeleteRefreshTokenByUserId(ctx context.Context, userID int32) error {
-	_, err := q.db.ExecContext(ctx, deleteRefreshTokenByUserId, userID)
-	return err
+// The current token of every session a user is still signed in with
+func (q *Queries) FindActiveRefreshTokensByUserId(ctx context.Context, userID int32) ([]*RefreshToken, error) {
+	rows, err := q.db.QueryContext(ctx, findActiveRefreshTokensByUserId, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*RefreshToken
+	for rows.Next() {
+		var i RefreshToken
+		if err := rows.Scan(
+			&i.RefreshTokenID,
+			&i.UserID,
+			&i.Token,
+			&i.Expiration,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.FamilyID,
+			&i.Device,
+			&i.UserAgent,
+			&i.IpAddress,
+			&i.SignedInAt,
+			&i.UsedAt,
+			&i.RevokedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
 const findRefreshTokenByToken = `-- name: FindRefreshTokenByToken :one
-SELECT refresh_token_id, user_id, token, expiration, created_at, updated_at, deleted_at
+SELECT refresh_token_id, user_id, token, expiration, created_at, updated_at, deleted_at, family_id, device, user_agent, ip_address, signed_in_at, used_at, revoked_at
 FROM refresh_tokens
 WHERE token = $1 AND deleted_at IS NULL
 `
@@ -74,30 +143,27 @@ func (q *Queries) FindRefreshTokenByToken(ctx context.Context, token string) (*R
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.FamilyID,
+		&i.Device,
+		&i.UserAgent,
+		&i.IpAddress,
+		&i.SignedInAt,
+		&i.UsedAt,
+		&i.RevokedAt,
 	)
 	return &i, err
 }
 
-const findRefreshTokenByUserId = `-- name: FindRefreshTokenByUserId :one
-SELECT
-    refresh_token_id,
-    user_id,
-    token,
-    expiration,
-    created_at,
-    updated_at,
-    deleted_at
-FROM
-    refresh_tokens
-WHERE
-    user_id = $1 AND deleted_at IS NULL
-ORDER BY
-    created_at DESC
-LIMIT 1
+const markRefreshTokenUsed = `-- name: MarkRefreshTokenUsed :one
+UPDATE refresh_tokens
+SET used_at = current_timestamp, updated_at = current_timestamp
+WHERE refresh_token_id = $1 AND used_at IS NULL AND revoked_at IS NULL
+RETURNING refresh_token_id, user_id, token, expiration, created_at, updated_at, deleted_at, family_id, device, user_agent, ip_address, signed_in_at, used_at, revoked_at
 `
 
-func (q *Queries) FindRefreshTokenByUserId(ctx context.Context, userID int32) (*RefreshToken, error) {
-	row := q.db.QueryRowContext(ctx, findRefreshTokenByUserId, userID)
+// Marks a token as exchanged, only if it has not been used or revoked yet
+func (q *Queries) MarkRefreshTokenUsed(ctx context.Context, refreshTokenID int32) (*RefreshToken, error) {
+	row := q.db.QueryRowContext(ctx, markRefreshTokenUsed, refreshTokenID)
 	var i RefreshToken
 	err := row.Scan(
 		&i.RefreshTokenID,
@@ -107,23 +173,54 @@ func (q *Queries) FindRefreshTokenByUserId(ctx context.Context, userID int32) (*
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.FamilyID,
+		&i.Device,
+		&i.UserAgent,
+		&i.IpAddress,
+		&i.SignedInAt,
+		&i.UsedAt,
+		&i.RevokedAt,
 	)
 	return &i, err
 }
 
-const updateRefreshTokenByUserId = `-- name: UpdateRefreshTokenByUserId :exec
+const revokeRefreshTokenFamily = `-- name: RevokeRefreshTokenFamily :exec
 UPDATE refresh_tokens
-SET token = $2, expiration = $3, updated_at = current_timestamp
-WHERE user_id = $1 AND deleted_at IS NULL
+SET revoked_at = current_timestamp, updated_at = current_timestamp
+WHERE family_id = $1 AND revoked_at IS NULL
 `
 
-type UpdateRefreshTokenByUserIdParams struct {
-	UserID     int32     `json:"user_id"`
-	Token      string    `json:"token"`
-	Expiration time.Time `json:"expiration"`
+func (q *Queries) RevokeRefreshTokenFamily(ctx context.Context, familyID string) error {
+	_, err := q.db.ExecContext(ctx, revokeRefreshTokenFamily, familyID)
+	return err
 }
 
-func (q *Queries) UpdateRefreshTokenByUserId(ctx context.Context, arg UpdateRefreshTokenByUserIdParams) error {
-	_, err := q.db.ExecContext(ctx, updateRefreshTokenByUserId, arg.UserID, arg.Token, arg.Expiration)
+const revokeRefreshTokenFamilyByUserId = `-- name: RevokeRefreshTokenFamilyByUserId :execrows
+UPDATE refresh_tokens
+SET revoked_at = current_timestamp, updated_at = current_timestamp
+WHERE user_id = $1 AND family_id = $2 AND revoked_at IS NULL
+`
+
+type RevokeRefreshTokenFamilyByUserIdParams struct {
+	UserID   int32  `json:"user_id"`
+	FamilyID string `json:"family_id"`
+}
+
+func (q *Queries) RevokeRefreshTokenFamilyByUserId(ctx context.Context, arg RevokeRefreshTokenFamilyByUserIdParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, revokeRefreshTokenFamilyByUserId, arg.UserID, arg.FamilyID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const revokeRefreshTokensByUserId = `-- name: RevokeRefreshTokensByUserId :exec
+UPDATE refresh_tokens
+SET revoked_at = current_timestamp, updated_at = current_timestamp
+WHERE user_id = $1 AND revoked_at IS NULL
+`
+
+func (q *Queries) RevokeRefreshTokensByUserId(ctx context.Context, userID int32) error {
+	_, err := q.db.ExecContext(ctx, revokeRefreshTokensByUserId, userID)
 	return err
 }
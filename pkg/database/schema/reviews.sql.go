@@ -290,6 +290,65 @@ func (q *Queries) GetReviewsByProductID(ctx context.Context, arg GetReviewsByPro
 	return items, nil
 }
 
+const getReviewsCursor = `-- name: GetReviewsCursor :many
+SELECT review_id, user_id, product_id, name, comment, rating, created_at, updated_at, deleted_at
+FROM reviews
+WHERE deleted_at IS NULL
+AND ($1::TEXT IS NULL
+       OR review_id::TEXT ILIKE '%' || $1::TEXT || '%'
+       OR name ILIKE '%' || $1::TEXT || '%')
+AND ($2::TIMESTAMP IS NULL
+       OR (created_at, review_id) < ($2::TIMESTAMP, $3::INT))
+ORDER BY created_at DESC, review_id DESC
+LIMIT $4
+`
+
+type GetReviewsCursorParams struct {
+	Search          string        `json:"search"`
+	CursorCreatedAt sql.NullTime  `json:"cursor_created_at"`
+	CursorID        sql.NullInt32 `json:"cursor_id"`
+	PageLimit       int32         `json:"page_limit"`
+}
+
+// Get Reviews with Keyset Pagination
+func (q *Queries) GetReviewsCursor(ctx context.Context, arg GetReviewsCursorParams) ([]*Review, error) {
+	rows, err := q.db.QueryContext(ctx, getReviewsCursor,
+		arg.Search,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*Review
+	for rows.Next() {
+		var i Review
+		if err := rows.Scan(
+			&i.ReviewID,
+			&i.UserID,
+			&i.ProductID,
+			&i.Name,
+			&i.Comment,
+			&i.Rating,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getReviewsTrashed = `-- name: GetReviewsTrashed :many
 SELECT
     review_id, user_id, product_id, name, comment, rating, created_at, updated_at, deleted_at,
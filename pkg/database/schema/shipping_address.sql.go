@@ -18,14 +18,14 @@ RETURNING shipping_address_id, order_id, alamat, provinsi, negara, kota, courier
 `
 
 type CreateShippingAddressParams struct {
-	OrderID        int32   `json:"order_id"`
-	Alamat         string  `json:"alamat"`
-	Provinsi       string  `json:"provinsi"`
-	Negara         string  `json:"negara"`
-	Kota           string  `json:"kota"`
-	Courier        string  `json:"courier"`
-	ShippingMethod string  `json:"shipping_method"`
-	ShippingCost   float64 `json:"shipping_cost"`
+	OrderID        int32  `json:"order_id"`
+	Alamat         string `json:"alamat"`
+	Provinsi       string `json:"provinsi"`
+	Negara         string `json:"negara"`
+	Kota           string `json:"kota"`
+	Courier        string `json:"courier"`
+	ShippingMethod string `json:"shipping_method"`
+	ShippingCost   int64  `json:"shipping_cost"`
 }
 
 func (q *Queries) CreateShippingAddress(ctx context.Context, arg CreateShippingAddressParams) (*ShippingAddress, error) {
@@ -102,7 +102,7 @@ type GetShippingAddressRow struct {
 	Kota              string       `json:"kota"`
 	Courier           string       `json:"courier"`
 	ShippingMethod    string       `json:"shipping_method"`
-	ShippingCost      float64      `json:"shipping_cost"`
+	ShippingCost      int64        `json:"shipping_cost"`
 	CreatedAt         sql.NullTime `json:"created_at"`
 	UpdatedAt         sql.NullTime `json:"updated_at"`
 	DeletedAt         sql.NullTime `json:"deleted_at"`
@@ -172,7 +172,7 @@ type GetShippingAddressActiveRow struct {
 	Kota              string       `json:"kota"`
 	Courier           string       `json:"courier"`
 	ShippingMethod    string       `json:"shipping_method"`
-	ShippingCost      float64      `json:"shipping_cost"`
+	ShippingCost      int64        `json:"shipping_cost"`
 	CreatedAt         sql.NullTime `json:"created_at"`
 	UpdatedAt         sql.NullTime `json:"updated_at"`
 	DeletedAt         sql.NullTime `json:"deleted_at"`
@@ -269,7 +269,7 @@ type GetShippingAddressTrashedRow struct {
 	Kota              string       `json:"kota"`
 	Courier           string       `json:"courier"`
 	ShippingMethod    string       `json:"shipping_method"`
-	ShippingCost      float64      `json:"shipping_cost"`
+	ShippingCost      int64        `json:"shipping_cost"`
 	CreatedAt         sql.NullTime `json:"created_at"`
 	UpdatedAt         sql.NullTime `json:"updated_at"`
 	DeletedAt         sql.NullTime `json:"deleted_at"`
@@ -419,21 +419,24 @@ SET
     shipping_cost = $8,
     updated_at = CURRENT_TIMESTAMP
 WHERE shipping_address_id = $1
+AND order_id = $9
 AND deleted_at IS NULL
 RETURNING shipping_address_id, order_id, alamat, provinsi, negara, kota, courier, shipping_method, shipping_cost, created_at, updated_at, deleted_at
 `
 
 type UpdateShippingAddressParams struct {
-	ShippingAddressID int32   `json:"shipping_address_id"`
-	Alamat            string  `json:"alamat"`
-	Provinsi          string  `json:"provinsi"`
-	Negara            string  `json:"negara"`
-	Kota              string  `json:"kota"`
-	Courier           string  `json:"courier"`
-	ShippingMethod    string  `json:"shipping_method"`
-	ShippingCost      float64 `json:"shipping_cost"`
+	ShippingAddressID int32  `json:"shipping_address_id"`
+	Alamat            string `json:"alamat"`
+	Provinsi          string `json:"provinsi"`
+	Negara            string `json:"negara"`
+	Kota              string `json:"kota"`
+	Courier           string `json:"courier"`
+	ShippingMethod    string `json:"shipping_method"`
+	ShippingCost      int64  `json:"shipping_cost"`
+	OrderID           int32  `json:"order_id"`
 }
 
+// Only touches the address when it belongs to the given order
 func (q *Queries) UpdateShippingAddress(ctx context.Context, arg UpdateShippingAddressParams) (*ShippingAddress, error) {
 	row := q.db.QueryRowContext(ctx, updateShippingAddress,
 		arg.ShippingAddressID,
@@ -444,6 +447,7 @@ func (q *Queries) UpdateShippingAddress(ctx context.Context, arg UpdateShippingA
 		arg.Courier,
 		arg.ShippingMethod,
 		arg.ShippingCost,
+		arg.OrderID,
 	)
 	var i ShippingAddress
 	err := row.Scan(
@@ -12,16 +12,18 @@ import (
 
 const createTransaction = `-- name: CreateTransaction :one
 INSERT INTO transactions (
-    order_id, merchant_id, payment_method, amount, payment_status
-) VALUES ($1, $2, $3, $4, $5)
-RETURNING transaction_id, order_id, merchant_id, payment_method, amount, payment_status, created_at, updated_at, deleted_at
+    order_id, merchant_id, payment_method, amount, amount_due, change_amount, payment_status
+) VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING transaction_id, order_id, merchant_id, payment_method, amount, payment_status, created_at, updated_at, deleted_at, payment_provider, provider_charge_id, refunded_amount, amount_due, change_amount
 `
 
 type CreateTransactionParams struct {
 	OrderID       int32  `json:"order_id"`
 	MerchantID    int32  `json:"merchant_id"`
 	PaymentMethod string `json:"payment_method"`
-	Amount        int32  `json:"amount"`
+	Amount        int64  `json:"amount"`
+	AmountDue     int64  `json:"amount_due"`
+	ChangeAmount  int64  `json:"change_amount"`
 	PaymentStatus string `json:"payment_status"`
 }
 
@@ -31,6 +33,8 @@ func (q *Queries) CreateTransaction(ctx context.Context, arg CreateTransactionPa
 		arg.MerchantID,
 		arg.PaymentMethod,
 		arg.Amount,
+		arg.AmountDue,
+		arg.ChangeAmount,
 		arg.PaymentStatus,
 	)
 	var i Transaction
@@ -44,6 +48,11 @@ func (q *Queries) CreateTransaction(ctx context.Context, arg CreateTransactionPa
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.PaymentProvider,
+		&i.ProviderChargeID,
+		&i.RefundedAmount,
+		&i.AmountDue,
+		&i.ChangeAmount,
 	)
 	return &i, err
 }
@@ -71,7 +80,7 @@ func (q *Queries) DeleteTransactionPermanently(ctx context.Context, transactionI
 }
 
 const getTransactionByID = `-- name: GetTransactionByID :one
-SELECT transaction_id, order_id, merchant_id, payment_method, amount, payment_status, created_at, updated_at, deleted_at
+SELECT transaction_id, order_id, merchant_id, payment_method, amount, payment_status, created_at, updated_at, deleted_at, payment_provider, provider_charge_id, refunded_amount, amount_due, change_amount
 FROM transactions
 WHERE transaction_id = $1
   AND deleted_at IS NULL
@@ -90,13 +99,49 @@ func (q *Queries) GetTransactionByID(ctx context.Context, transactionID int32) (
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.PaymentProvider,
+		&i.ProviderChargeID,
+		&i.RefundedAmount,
+		&i.AmountDue,
+		&i.ChangeAmount,
+	)
+	return &i, err
+}
+
+const getTransactionByIDForUpdate = `-- name: GetTransactionByIDForUpdate :one
+SELECT transaction_id, order_id, merchant_id, payment_method, amount, payment_status, created_at, updated_at, deleted_at, payment_provider, provider_charge_id, refunded_amount, amount_due, change_amount
+FROM transactions
+WHERE transaction_id = $1
+  AND deleted_at IS NULL
+FOR UPDATE
+`
+
+// Lock a transaction while it is being refunded
+func (q *Queries) GetTransactionByIDForUpdate(ctx context.Context, transactionID int32) (*Transaction, error) {
+	row := q.db.QueryRowContext(ctx, getTransactionByIDForUpdate, transactionID)
+	var i Transaction
+	err := row.Scan(
+		&i.TransactionID,
+		&i.OrderID,
+		&i.MerchantID,
+		&i.PaymentMethod,
+		&i.Amount,
+		&i.PaymentStatus,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.PaymentProvider,
+		&i.ProviderChargeID,
+		&i.RefundedAmount,
+		&i.AmountDue,
+		&i.ChangeAmount,
 	)
 	return &i, err
 }
 
 const getTransactionByMerchant = `-- name: GetTransactionByMerchant :many
 SELECT
-    transaction_id, order_id, merchant_id, payment_method, amount, payment_status, created_at, updated_at, deleted_at,
+    transaction_id, order_id, merchant_id, payment_method, amount, payment_status, created_at, updated_at, deleted_at, payment_provider, provider_charge_id, refunded_amount, amount_due, change_amount,
     COUNT(*) OVER() AS total_count
 FROM transactions
 WHERE deleted_at IS NULL
@@ -114,16 +159,21 @@ type GetTransactionByMerchantParams struct {
 }
 
 type GetTransactionByMerchantRow struct {
-	TransactionID int32        `json:"transaction_id"`
-	OrderID       int32        `json:"order_id"`
-	MerchantID    int32        `json:"merchant_id"`
-	PaymentMethod string       `json:"payment_method"`
-	Amount        int32        `json:"amount"`
-	PaymentStatus string       `json:"payment_status"`
-	CreatedAt     sql.NullTime `json:"created_at"`
-	UpdatedAt     sql.NullTime `json:"updated_at"`
-	DeletedAt     sql.NullTime `json:"deleted_at"`
-	TotalCount    int64        `json:"total_count"`
+	TransactionID    int32          `json:"transaction_id"`
+	OrderID          int32          `json:"order_id"`
+	MerchantID       int32          `json:"merchant_id"`
+	PaymentMethod    string         `json:"payment_method"`
+	Amount           int64          `json:"amount"`
+	PaymentStatus    string         `json:"payment_status"`
+	CreatedAt        sql.NullTime   `json:"created_at"`
+	UpdatedAt        sql.NullTime   `json:"updated_at"`
+	DeletedAt        sql.NullTime   `json:"deleted_at"`
+	PaymentProvider  sql.NullString `json:"payment_provider"`
+	ProviderChargeID sql.NullString `json:"provider_charge_id"`
+	RefundedAmount   int64          `json:"refunded_amount"`
+	AmountDue        int64          `json:"amount_due"`
+	ChangeAmount     int64          `json:"change_amount"`
+	TotalCount       int64          `json:"total_count"`
 }
 
 func (q *Queries) GetTransactionByMerchant(ctx context.Context, arg GetTransactionByMerchantParams) ([]*GetTransactionByMerchantRow, error) {
@@ -150,6 +200,11 @@ func (q *Queries) GetTransactionByMerchant(ctx context.Context, arg GetTransacti
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.PaymentProvider,
+			&i.ProviderChargeID,
+			&i.RefundedAmount,
+			&i.AmountDue,
+			&i.ChangeAmount,
 			&i.TotalCount,
 		); err != nil {
 			return nil, err
@@ -166,7 +221,7 @@ func (q *Queries) GetTransactionByMerchant(ctx context.Context, arg GetTransacti
 }
 
 const getTransactionByOrderID = `-- name: GetTransactionByOrderID :one
-SELECT transaction_id, order_id, merchant_id, payment_method, amount, payment_status, created_at, updated_at, deleted_at
+SELECT transaction_id, order_id, merchant_id, payment_method, amount, payment_status, created_at, updated_at, deleted_at, payment_provider, provider_charge_id, refunded_amount, amount_due, change_amount
 FROM transactions
 WHERE order_id = $1
   AND deleted_at IS NULL
@@ -185,13 +240,55 @@ func (q *Queries) GetTransactionByOrderID(ctx context.Context, orderID int32) (*
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.PaymentProvider,
+		&i.ProviderChargeID,
+		&i.RefundedAmount,
+		&i.AmountDue,
+		&i.ChangeAmount,
+	)
+	return &i, err
+}
+
+const getTransactionByProviderChargeID = `-- name: GetTransactionByProviderChargeID :one
+SELECT transaction_id, order_id, merchant_id, payment_method, amount, payment_status, created_at, updated_at, deleted_at, payment_provider, provider_charge_id, refunded_amount, amount_due, change_amount
+FROM transactions
+WHERE payment_provider = $1
+  AND provider_charge_id = $2
+  AND deleted_at IS NULL
+FOR UPDATE
+`
+
+type GetTransactionByProviderChargeIDParams struct {
+	PaymentProvider  sql.NullString `json:"payment_provider"`
+	ProviderChargeID sql.NullString `json:"provider_charge_id"`
+}
+
+// Lock the transaction a provider charge belongs to
+func (q *Queries) GetTransactionByProviderChargeID(ctx context.Context, arg GetTransactionByProviderChargeIDParams) (*Transaction, error) {
+	row := q.db.QueryRowContext(ctx, getTransactionByProviderChargeID, arg.PaymentProvider, arg.ProviderChargeID)
+	var i Transaction
+	err := row.Scan(
+		&i.TransactionID,
+		&i.OrderID,
+		&i.MerchantID,
+		&i.PaymentMethod,
+		&i.Amount,
+		&i.PaymentStatus,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.PaymentProvider,
+		&i.ProviderChargeID,
+		&i.RefundedAmount,
+		&i.AmountDue,
+		&i.ChangeAmount,
 	)
 	return &i, err
 }
 
 const getTransactions = `-- name: GetTransactions :many
 SELECT
-    transaction_id, order_id, merchant_id, payment_method, amount, payment_status, created_at, updated_at, deleted_at,
+    transaction_id, order_id, merchant_id, payment_method, amount, payment_status, created_at, updated_at, deleted_at, payment_provider, provider_charge_id, refunded_amount, amount_due, change_amount,
     COUNT(*) OVER() AS total_count
 FROM transactions
 WHERE deleted_at IS NULL
@@ -207,16 +304,21 @@ type GetTransactionsParams struct {
 }
 
 type GetTransactionsRow struct {
-	TransactionID int32        `json:"transaction_id"`
-	OrderID       int32        `json:"order_id"`
-	MerchantID    int32        `json:"merchant_id"`
-	PaymentMethod string       `json:"payment_method"`
-	Amount        int32        `json:"amount"`
-	PaymentStatus string       `json:"payment_status"`
-	CreatedAt     sql.NullTime `json:"created_at"`
-	UpdatedAt     sql.NullTime `json:"updated_at"`
-	DeletedAt     sql.NullTime `json:"deleted_at"`
-	TotalCount    int64        `json:"total_count"`
+	TransactionID    int32          `json:"transaction_id"`
+	OrderID          int32          `json:"order_id"`
+	MerchantID       int32          `json:"merchant_id"`
+	PaymentMethod    string         `json:"payment_method"`
+	Amount           int64          `json:"amount"`
+	PaymentStatus    string         `json:"payment_status"`
+	CreatedAt        sql.NullTime   `json:"created_at"`
+	UpdatedAt        sql.NullTime   `json:"updated_at"`
+	DeletedAt        sql.NullTime   `json:"deleted_at"`
+	PaymentProvider  sql.NullString `json:"payment_provider"`
+	ProviderChargeID sql.NullString `json:"provider_charge_id"`
+	RefundedAmount   int64          `json:"refunded_amount"`
+	AmountDue        int64          `json:"amount_due"`
+	ChangeAmount     int64          `json:"change_amount"`
+	TotalCount       int64          `json:"total_count"`
 }
 
 func (q *Queries) GetTransactions(ctx context.Context, arg GetTransactionsParams) ([]*GetTransactionsRow, error) {
@@ -238,6 +340,11 @@ func (q *Queries) GetTransactions(ctx context.Context, arg GetTransactionsParams
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.PaymentProvider,
+			&i.ProviderChargeID,
+			&i.RefundedAmount,
+			&i.AmountDue,
+			&i.ChangeAmount,
 			&i.TotalCount,
 		); err != nil {
 			return nil, err
@@ -255,7 +362,7 @@ func (q *Queries) GetTransactions(ctx context.Context, arg GetTransactionsParams
 
 const getTransactionsActive = `-- name: GetTransactionsActive :many
 SELECT
-    transaction_id, order_id, merchant_id, payment_method, amount, payment_status, created_at, updated_at, deleted_at,
+    transaction_id, order_id, merchant_id, payment_method, amount, payment_status, created_at, updated_at, deleted_at, payment_provider, provider_charge_id, refunded_amount, amount_due, change_amount,
     COUNT(*) OVER() AS total_count
 FROM transactions
 WHERE deleted_at IS NULL
@@ -271,16 +378,21 @@ type GetTransactionsActiveParams struct {
 }
 
 type GetTransactionsActiveRow struct {
-	TransactionID int32        `json:"transaction_id"`
-	OrderID       int32        `json:"order_id"`
-	MerchantID    int32        `json:"merchant_id"`
-	PaymentMethod string       `json:"payment_method"`
-	Amount        int32        `json:"amount"`
-	PaymentStatus string       `json:"payment_status"`
-	CreatedAt     sql.NullTime `json:"created_at"`
-	UpdatedAt     sql.NullTime `json:"updated_at"`
-	DeletedAt     sql.NullTime `json:"deleted_at"`
-	TotalCount    int64        `json:"total_count"`
+	TransactionID    int32          `json:"transaction_id"`
+	OrderID          int32          `json:"order_id"`
+	MerchantID       int32          `json:"merchant_id"`
+	PaymentMethod    string         `json:"payment_method"`
+	Amount           int64          `json:"amount"`
+	PaymentStatus    string         `json:"payment_status"`
+	CreatedAt        sql.NullTime   `json:"created_at"`
+	UpdatedAt        sql.NullTime   `json:"updated_at"`
+	DeletedAt        sql.NullTime   `json:"deleted_at"`
+	PaymentProvider  sql.NullString `json:"payment_provider"`
+	ProviderChargeID sql.NullString `json:"provider_charge_id"`
+	RefundedAmount   int64          `json:"refunded_amount"`
+	AmountDue        int64          `json:"amount_due"`
+	ChangeAmount     int64          `json:"change_amount"`
+	TotalCount       int64          `json:"total_count"`
 }
 
 // Get Active Transactions with Pagination and Total Count
@@ -303,6 +415,11 @@ func (q *Queries) GetTransactionsActive(ctx context.Context, arg GetTransactions
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.PaymentProvider,
+			&i.ProviderChargeID,
+			&i.RefundedAmount,
+			&i.AmountDue,
+			&i.ChangeAmount,
 			&i.TotalCount,
 		); err != nil {
 			return nil, err
@@ -318,9 +435,73 @@ func (q *Queries) GetTransactionsActive(ctx context.Context, arg GetTransactions
 	return items, nil
 }
 
+const getTransactionsCursor = `-- name: GetTransactionsCursor :many
+SELECT transaction_id, order_id, merchant_id, payment_method, amount, payment_status, created_at, updated_at, deleted_at, payment_provider, provider_charge_id, refunded_amount, amount_due, change_amount
+FROM transactions
+WHERE deleted_at IS NULL
+AND ($1::TEXT IS NULL
+       OR payment_method ILIKE '%' || $1::TEXT || '%'
+       OR payment_status ILIKE '%' || $1::TEXT || '%')
+AND ($2::TIMESTAMP IS NULL
+       OR (created_at, transaction_id) < ($2::TIMESTAMP, $3::INT))
+ORDER BY created_at DESC, transaction_id DESC
+LIMIT $4
+`
+
+type GetTransactionsCursorParams struct {
+	Search          string        `json:"search"`
+	CursorCreatedAt sql.NullTime  `json:"cursor_created_at"`
+	CursorID        sql.NullInt32 `json:"cursor_id"`
+	PageLimit       int32         `json:"page_limit"`
+}
+
+// Get Transactions with Keyset Pagination
+func (q *Queries) GetTransactionsCursor(ctx context.Context, arg GetTransactionsCursorParams) ([]*Transaction, error) {
+	rows, err := q.db.QueryContext(ctx, getTransactionsCursor,
+		arg.Search,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*Transaction
+	for rows.Next() {
+		var i Transaction
+		if err := rows.Scan(
+			&i.TransactionID,
+			&i.OrderID,
+			&i.MerchantID,
+			&i.PaymentMethod,
+			&i.Amount,
+			&i.PaymentStatus,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.PaymentProvider,
+			&i.ProviderChargeID,
+			&i.RefundedAmount,
+			&i.AmountDue,
+			&i.ChangeAmount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getTransactionsTrashed = `-- name: GetTransactionsTrashed :many
 SELECT
-    transaction_id, order_id, merchant_id, payment_method, amount, payment_status, created_at, updated_at, deleted_at,
+    transaction_id, order_id, merchant_id, payment_method, amount, payment_status, created_at, updated_at, deleted_at, payment_provider, provider_charge_id, refunded_amount, amount_due, change_amount,
     COUNT(*) OVER() AS total_count
 FROM transactions
 WHERE deleted_at IS NOT NULL
@@ -336,16 +517,21 @@ type GetTransactionsTrashedParams struct {
 }
 
 type GetTransactionsTrashedRow struct {
-	TransactionID int32        `json:"transaction_id"`
-	OrderID       int32        `json:"order_id"`
-	MerchantID    int32        `json:"merchant_id"`
-	PaymentMethod string       `json:"payment_method"`
-	Amount        int32        `json:"amount"`
-	PaymentStatus string       `json:"payment_status"`
-	CreatedAt     sql.NullTime `json:"created_at"`
-	UpdatedAt     sql.NullTime `json:"updated_at"`
-	DeletedAt     sql.NullTime `json:"deleted_at"`
-	TotalCount    int64        `json:"total_count"`
+	TransactionID    int32          `json:"transaction_id"`
+	OrderID          int32          `json:"order_id"`
+	MerchantID       int32          `json:"merchant_id"`
+	PaymentMethod    string         `json:"payment_method"`
+	Amount           int64          `json:"amount"`
+	PaymentStatus    string         `json:"payment_status"`
+	CreatedAt        sql.NullTime   `json:"created_at"`
+	UpdatedAt        sql.NullTime   `json:"updated_at"`
+	DeletedAt        sql.NullTime   `json:"deleted_at"`
+	PaymentProvider  sql.NullString `json:"payment_provider"`
+	ProviderChargeID sql.NullString `json:"provider_charge_id"`
+	RefundedAmount   int64          `json:"refunded_amount"`
+	AmountDue        int64          `json:"amount_due"`
+	ChangeAmount     int64          `json:"change_amount"`
+	TotalCount       int64          `json:"total_count"`
 }
 
 // Get Trashed Transactions with Pagination and Total Count
@@ -368,6 +554,11 @@ func (q *Queries) GetTransactionsTrashed(ctx context.Context, arg GetTransaction
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.PaymentProvider,
+			&i.ProviderChargeID,
+			&i.RefundedAmount,
+			&i.AmountDue,
+			&i.ChangeAmount,
 			&i.TotalCount,
 		); err != nil {
 			return nil, err
@@ -383,6 +574,25 @@ func (q *Queries) GetTransactionsTrashed(ctx context.Context, arg GetTransaction
 	return items, nil
 }
 
+const hasLiveTransactionByOrderID = `-- name: HasLiveTransactionByOrderID :one
+SELECT EXISTS (
+    SELECT 1
+    FROM transactions
+    WHERE order_id = $1
+      AND payment_status IN ('pending', 'paid')
+      AND deleted_at IS NULL
+)
+`
+
+// Whether an order already has a transaction that is awaiting or has
+// collected payment
+func (q *Queries) HasLiveTransactionByOrderID(ctx context.Context, orderID int32) (bool, error) {
+	row := q.db.QueryRowContext(ctx, hasLiveTransactionByOrderID, orderID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
 const restoreAllTransactions = `-- name: RestoreAllTransactions :exec
 UPDATE transactions
 SET
@@ -404,7 +614,7 @@ SET
 WHERE
     transaction_id = $1
     AND deleted_at IS NOT NULL
-  RETURNING transaction_id, order_id, merchant_id, payment_method, amount, payment_status, created_at, updated_at, deleted_at
+  RETURNING transaction_id, order_id, merchant_id, payment_method, amount, payment_status, created_at, updated_at, deleted_at, payment_provider, provider_charge_id, refunded_amount, amount_due, change_amount
 `
 
 // Restore Trashed Transaction
@@ -421,6 +631,11 @@ func (q *Queries) RestoreTransaction(ctx context.Context, transactionID int32) (
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.PaymentProvider,
+		&i.ProviderChargeID,
+		&i.RefundedAmount,
+		&i.AmountDue,
+		&i.ChangeAmount,
 	)
 	return &i, err
 }
@@ -432,7 +647,7 @@ SET
 WHERE
     transaction_id = $1
     AND deleted_at IS NULL
-    RETURNING transaction_id, order_id, merchant_id, payment_method, amount, payment_status, created_at, updated_at, deleted_at
+    RETURNING transaction_id, order_id, merchant_id, payment_method, amount, payment_status, created_at, updated_at, deleted_at, payment_provider, provider_charge_id, refunded_amount, amount_due, change_amount
 `
 
 // Trash Transaction
@@ -449,40 +664,91 @@ func (q *Queries) TrashTransaction(ctx context.Context, transactionID int32) (*T
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.PaymentProvider,
+		&i.ProviderChargeID,
+		&i.RefundedAmount,
+		&i.AmountDue,
+		&i.ChangeAmount,
 	)
 	return &i, err
 }
 
 const updateTransaction = `-- name: UpdateTransaction :one
 UPDATE transactions
-SET merchant_id = $2,
-    payment_method = $3,
-    amount = $4,
-    payment_status = $5,
-    order_id = $6,
+SET payment_method = $2,
+    amount = $3,
+    amount_due = $4,
+    change_amount = $5,
     updated_at = CURRENT_TIMESTAMP
 WHERE transaction_id = $1
+  AND payment_status = 'pending'
   AND deleted_at IS NULL
-RETURNING transaction_id, order_id, merchant_id, payment_method, amount, payment_status, created_at, updated_at, deleted_at
+RETURNING transaction_id, order_id, merchant_id, payment_method, amount, payment_status, created_at, updated_at, deleted_at, payment_provider, provider_charge_id, refunded_amount, amount_due, change_amount
 `
 
 type UpdateTransactionParams struct {
 	TransactionID int32  `json:"transaction_id"`
-	MerchantID    int32  `json:"merchant_id"`
 	PaymentMethod string `json:"payment_method"`
-	Amount        int32  `json:"amount"`
-	PaymentStatus string `json:"payment_status"`
-	OrderID       int32  `json:"order_id"`
+	Amount        int64  `json:"amount"`
+	AmountDue     int64  `json:"amount_due"`
+	ChangeAmount  int64  `json:"change_amount"`
 }
 
+// Amend the payment of a transaction whose outcome is still pending; the order
+// and merchant it pays are fixed when it is created
 func (q *Queries) UpdateTransaction(ctx context.Context, arg UpdateTransactionParams) (*Transaction, error) {
 	row := q.db.QueryRowContext(ctx, updateTransaction,
 		arg.TransactionID,
-		arg.MerchantID,
 		arg.PaymentMethod,
 		arg.Amount,
+		arg.AmountDue,
+		arg.ChangeAmount,
+	)
+	var i Transaction
+	err := row.Scan(
+		&i.TransactionID,
+		&i.OrderID,
+		&i.MerchantID,
+		&i.PaymentMethod,
+		&i.Amount,
+		&i.PaymentStatus,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.PaymentProvider,
+		&i.ProviderChargeID,
+		&i.RefundedAmount,
+		&i.AmountDue,
+		&i.ChangeAmount,
+	)
+	return &i, err
+}
+
+const updateTransactionPayment = `-- name: UpdateTransactionPayment :one
+UPDATE transactions
+SET payment_status = $1,
+    payment_provider = $2,
+    provider_charge_id = $3,
+    updated_at = CURRENT_TIMESTAMP
+WHERE transaction_id = $4
+  AND deleted_at IS NULL
+RETURNING transaction_id, order_id, merchant_id, payment_method, amount, payment_status, created_at, updated_at, deleted_at, payment_provider, provider_charge_id, refunded_amount, amount_due, change_amount
+`
+
+type UpdateTransactionPaymentParams struct {
+	PaymentStatus    string         `json:"payment_status"`
+	PaymentProvider  sql.NullString `json:"payment_provider"`
+	ProviderChargeID sql.NullString `json:"provider_charge_id"`
+	TransactionID    int32          `json:"transaction_id"`
+}
+
+// Record the payment provider outcome of a transaction
+func (q *Queries) UpdateTransactionPayment(ctx context.Context, arg UpdateTransactionPaymentParams) (*Transaction, error) {
+	row := q.db.QueryRowContext(ctx, updateTransactionPayment,
 		arg.PaymentStatus,
-		arg.OrderID,
+		arg.PaymentProvider,
+		arg.ProviderChargeID,
+		arg.TransactionID,
 	)
 	var i Transaction
 	err := row.Scan(
@@ -495,6 +761,48 @@ func (q *Queries) UpdateTransaction(ctx context.Context, arg UpdateTransactionPa
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.PaymentProvider,
+		&i.ProviderChargeID,
+		&i.RefundedAmount,
+		&i.AmountDue,
+		&i.ChangeAmount,
+	)
+	return &i, err
+}
+
+const updateTransactionRefundedAmount = `-- name: UpdateTransactionRefundedAmount :one
+UPDATE transactions
+SET refunded_amount = (
+        SELECT COALESCE(SUM(r.amount), 0)
+        FROM refunds r
+        WHERE r.transaction_id = transactions.transaction_id
+          AND r.status = 'succeeded'
+    ),
+    updated_at = CURRENT_TIMESTAMP
+WHERE transaction_id = $1
+  AND deleted_at IS NULL
+RETURNING transaction_id, order_id, merchant_id, payment_method, amount, payment_status, created_at, updated_at, deleted_at, payment_provider, provider_charge_id, refunded_amount, amount_due, change_amount
+`
+
+// Recompute the refunded total of a transaction from its succeeded refunds
+func (q *Queries) UpdateTransactionRefundedAmount(ctx context.Context, transactionID int32) (*Transaction, error) {
+	row := q.db.QueryRowContext(ctx, updateTransactionRefundedAmount, transactionID)
+	var i Transaction
+	err := row.Scan(
+		&i.TransactionID,
+		&i.OrderID,
+		&i.MerchantID,
+		&i.PaymentMethod,
+		&i.Amount,
+		&i.PaymentStatus,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.PaymentProvider,
+		&i.ProviderChargeID,
+		&i.RefundedAmount,
+		&i.AmountDue,
+		&i.ChangeAmount,
 	)
 	return &i, err
 }
@@ -27,7 +27,7 @@ VALUES (
         $4,
         current_timestamp,
         current_timestamp
-    ) RETURNING user_id, firstname, lastname, email, password, created_at, updated_at, deleted_at
+    ) RETURNING user_id, firstname, lastname, email, password, created_at, updated_at, deleted_at, email_verified_at
 `
 
 type CreateUserParams struct {
@@ -55,6 +55,7 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (*User,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.EmailVerifiedAt,
 	)
 	return &i, err
 }
@@ -82,7 +83,7 @@ func (q *Queries) DeleteUserPermanently(ctx context.Context, userID int32) error
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT user_id, firstname, lastname, email, password, created_at, updated_at, deleted_at FROM users WHERE email = $1 AND deleted_at IS NULL
+SELECT user_id, firstname, lastname, email, password, created_at, updated_at, deleted_at, email_verified_at FROM users WHERE email = $1 AND deleted_at IS NULL
 `
 
 // Get User by Email
@@ -98,12 +99,13 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (*User, erro
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.EmailVerifiedAt,
 	)
 	return &i, err
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT user_id, firstname, lastname, email, password, created_at, updated_at, deleted_at FROM users WHERE user_id = $1 AND deleted_at IS NULL
+SELECT user_id, firstname, lastname, email, password, created_at, updated_at, deleted_at, email_verified_at FROM users WHERE user_id = $1 AND deleted_at IS NULL
 `
 
 // Get User by ID
@@ -119,13 +121,14 @@ func (q *Queries) GetUserByID(ctx context.Context, userID int32) (*User, error)
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.EmailVerifiedAt,
 	)
 	return &i, err
 }
 
 const getUserTrashed = `-- name: GetUserTrashed :many
 SELECT
-    user_id, firstname, lastname, email, password, created_at, updated_at, deleted_at,
+    user_id, firstname, lastname, email, password, created_at, updated_at, deleted_at, email_verified_at,
     COUNT(*) OVER() AS total_count
 FROM users
 WHERE deleted_at IS NOT NULL
@@ -141,15 +144,16 @@ type GetUserTrashedParams struct {
 }
 
 type GetUserTrashedRow struct {
-	UserID     int32        `json:"user_id"`
-	Firstname  string       `json:"firstname"`
-	Lastname   string       `json:"lastname"`
-	Email      string       `json:"email"`
-	Password   string       `json:"password"`
-	CreatedAt  sql.NullTime `json:"created_at"`
-	UpdatedAt  sql.NullTime `json:"updated_at"`
-	DeletedAt  sql.NullTime `json:"deleted_at"`
-	TotalCount int64        `json:"total_count"`
+	UserID          int32        `json:"user_id"`
+	Firstname       string       `json:"firstname"`
+	Lastname        string       `json:"lastname"`
+	Email           string       `json:"email"`
+	Password        string       `json:"password"`
+	CreatedAt       sql.NullTime `json:"created_at"`
+	UpdatedAt       sql.NullTime `json:"updated_at"`
+	DeletedAt       sql.NullTime `json:"deleted_at"`
+	EmailVerifiedAt sql.NullTime `json:"email_verified_at"`
+	TotalCount      int64        `json:"total_count"`
 }
 
 // Get Trashed Users with Pagination and Total Count
@@ -171,6 +175,7 @@ func (q *Queries) GetUserTrashed(ctx context.Context, arg GetUserTrashedParams)
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.EmailVerifiedAt,
 			&i.TotalCount,
 		); err != nil {
 			return nil, err
@@ -188,7 +193,7 @@ func (q *Queries) GetUserTrashed(ctx context.Context, arg GetUserTrashedParams)
 
 const getUsers = `-- name: GetUsers :many
 SELECT
-    user_id, firstname, lastname, email, password, created_at, updated_at, deleted_at,
+    user_id, firstname, lastname, email, password, created_at, updated_at, deleted_at, email_verified_at,
     COUNT(*) OVER() AS total_count
 FROM users
 WHERE deleted_at IS NULL
@@ -204,15 +209,16 @@ type GetUsersParams struct {
 }
 
 type GetUsersRow struct {
-	UserID     int32        `json:"user_id"`
-	Firstname  string       `json:"firstname"`
-	Lastname   string       `json:"lastname"`
-	Email      string       `json:"email"`
-	Password   string       `json:"password"`
-	CreatedAt  sql.NullTime `json:"created_at"`
-	UpdatedAt  sql.NullTime `json:"updated_at"`
-	DeletedAt  sql.NullTime `json:"deleted_at"`
-	TotalCount int64        `json:"total_count"`
+	UserID          int32        `json:"user_id"`
+	Firstname       string       `json:"firstname"`
+	Lastname        string       `json:"lastname"`
+	Email           string       `json:"email"`
+	Password        string       `json:"password"`
+	CreatedAt       sql.NullTime `json:"created_at"`
+	UpdatedAt       sql.NullTime `json:"updated_at"`
+	DeletedAt       sql.NullTime `json:"deleted_at"`
+	EmailVerifiedAt sql.NullTime `json:"email_verified_at"`
+	TotalCount      int64        `json:"total_count"`
 }
 
 // Get Users with Pagination and Total Count
@@ -234,6 +240,7 @@ func (q *Queries) GetUsers(ctx context.Context, arg GetUsersParams) ([]*GetUsers
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.EmailVerifiedAt,
 			&i.TotalCount,
 		); err != nil {
 			return nil, err
@@ -251,7 +258,7 @@ func (q *Queries) GetUsers(ctx context.Context, arg GetUsersParams) ([]*GetUsers
 
 const getUsersActive = `-- name: GetUsersActive :many
 SELECT
-    user_id, firstname, lastname, email, password, created_at, updated_at, deleted_at,
+    user_id, firstname, lastname, email, password, created_at, updated_at, deleted_at, email_verified_at,
     COUNT(*) OVER() AS total_count
 FROM users
 WHERE deleted_at IS NULL
@@ -267,15 +274,16 @@ type GetUsersActiveParams struct {
 }
 
 type GetUsersActiveRow struct {
-	UserID     int32        `json:"user_id"`
-	Firstname  string       `json:"firstname"`
-	Lastname   string       `json:"lastname"`
-	Email      string       `json:"email"`
-	Password   string       `json:"password"`
-	CreatedAt  sql.NullTime `json:"created_at"`
-	UpdatedAt  sql.NullTime `json:"updated_at"`
-	DeletedAt  sql.NullTime `json:"deleted_at"`
-	TotalCount int64        `json:"total_count"`
+	UserID          int32        `json:"user_id"`
+	Firstname       string       `json:"firstname"`
+	Lastname        string       `json:"lastname"`
+	Email           string       `json:"email"`
+	Password        string       `json:"password"`
+	CreatedAt       sql.NullTime `json:"created_at"`
+	UpdatedAt       sql.NullTime `json:"updated_at"`
+	DeletedAt       sql.NullTime `json:"deleted_at"`
+	EmailVerifiedAt sql.NullTime `json:"email_verified_at"`
+	TotalCount      int64        `json:"total_count"`
 }
 
 // Get Active Users with Pagination and Total Count
@@ -297,6 +305,7 @@ func (q *Queries) GetUsersActive(ctx context.Context, arg GetUsersActiveParams)
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.EmailVerifiedAt,
 			&i.TotalCount,
 		); err != nil {
 			return nil, err
@@ -333,7 +342,7 @@ SET
 WHERE
     user_id = $1
     AND deleted_at IS NOT NULL
-    RETURNING user_id, firstname, lastname, email, password, created_at, updated_at, deleted_at
+    RETURNING user_id, firstname, lastname, email, password, created_at, updated_at, deleted_at, email_verified_at
 `
 
 // Restore Trashed User
@@ -349,6 +358,7 @@ func (q *Queries) RestoreUser(ctx context.Context, userID int32) (*User, error)
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.EmailVerifiedAt,
 	)
 	return &i, err
 }
@@ -360,7 +370,7 @@ SET
 WHERE
     user_id = $1
     AND deleted_at IS NULL
-    RETURNING user_id, firstname, lastname, email, password, created_at, updated_at, deleted_at
+    RETURNING user_id, firstname, lastname, email, password, created_at, updated_at, deleted_at, email_verified_at
 `
 
 // Trash User
@@ -376,6 +386,7 @@ func (q *Queries) TrashUser(ctx context.Context, userID int32) (*User, error) {
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.EmailVerifiedAt,
 	)
 	return &i, err
 }
@@ -391,7 +402,7 @@ SET
 WHERE
     user_id = $1
     AND deleted_at IS NULL
-    RETURNING user_id, firstname, lastname, email, password, created_at, updated_at, deleted_at
+    RETURNING user_id, firstname, lastname, email, password, created_at, updated_at, deleted_at, email_verified_at
 `
 
 type UpdateUserParams struct {
@@ -421,6 +432,70 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (*User,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.EmailVerifiedAt,
+	)
+	return &i, err
+}
+
+const updateUserPassword = `-- name: UpdateUserPassword :one
+UPDATE users
+SET
+    password = $2,
+    updated_at = current_timestamp
+WHERE
+    user_id = $1
+    AND deleted_at IS NULL
+    RETURNING user_id, firstname, lastname, email, password, created_at, updated_at, deleted_at, email_verified_at
+`
+
+type UpdateUserPasswordParams struct {
+	UserID   int32  `json:"user_id"`
+	Password string `json:"password"`
+}
+
+// Update User Password
+func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) (*User, error) {
+	row := q.db.QueryRowContext(ctx, updateUserPassword, arg.UserID, arg.Password)
+	var i User
+	err := row.Scan(
+		&i.UserID,
+		&i.Firstname,
+		&i.Lastname,
+		&i.Email,
+		&i.Password,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.EmailVerifiedAt,
+	)
+	return &i, err
+}
+
+const verifyUserEmail = `-- name: VerifyUserEmail :one
+UPDATE users
+SET
+    email_verified_at = COALESCE(email_verified_at, current_timestamp),
+    updated_at = current_timestamp
+WHERE
+    user_id = $1
+    AND deleted_at IS NULL
+    RETURNING user_id, firstname, lastname, email, password, created_at, updated_at, deleted_at, email_verified_at
+`
+
+// Mark User Email as Verified
+func (q *Queries) VerifyUserEmail(ctx context.Context, userID int32) (*User, error) {
+	row := q.db.QueryRowContext(ctx, verifyUserEmail, userID)
+	var i User
+	err := row.Scan(
+		&i.UserID,
+		&i.Firstname,
+		&i.Lastname,
+		&i.Email,
+		&i.Password,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.EmailVerifiedAt,
 	)
 	return &i, err
 }
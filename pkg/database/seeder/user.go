@@ -51,6 +51,11 @@ func (r *userSeeder) Seed() error {
 			return fmt.Errorf("failed to seed user %d: %w", i, err)
 		}
 
+		if _, err := r.db.VerifyUserEmail(r.ctx, createdUser.UserID); err != nil {
+			r.logger.Error("failed to verify user email", zap.Int("user", i), zap.Error(err))
+			return fmt.Errorf("failed to verify email of user %d: %w", i, err)
+		}
+
 		if i > 5 {
 			_, err = r.db.TrashUser(r.ctx, createdUser.UserID)
 			if err != nil {
@@ -0,0 +1,182 @@
+// Package testdb gives tests a migrated Postgres database and a few rows to
+// work with. Tests using it are skipped unless TEST_DATABASE_URL is set.
+package testdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/pressly/goose/v3"
+)
+
+var seq atomic.Int64
+
+// Open connects to the database named by TEST_DATABASE_URL and migrates it to
+// the latest schema.
+func Open(t testing.TB) *sql.DB {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	conn, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	if err := goose.SetDialect("postgres"); err != nil {
+		t.Fatalf("set migration dialect: %v", err)
+	}
+
+	goose.SetLogger(goose.NopLogger())
+
+	if err := goose.Up(conn, migrationsDir()); err != nil {
+		t.Fatalf("migrate database: %v", err)
+	}
+
+	return conn
+}
+
+// migrationsDir resolves pkg/database/migrations from this file, so tests in
+// any package find it.
+func migrationsDir() string {
+	_, file, _, _ := runtime.Caller(0)
+
+	return filepath.Join(filepath.Dir(file), "..", "migrations")
+}
+
+// Unique returns a value no other call in this or an earlier test run
+// returns, for columns with unique constraints.
+func Unique(prefix string) string {
+	return fmt.Sprintf("%s-%d-%d", prefix, time.Now().UnixNano(), seq.Add(1))
+}
+
+// SeedUser inserts a user and returns its ID. The user is deleted when the
+// test ends, along with the orders, carts, tokens and other rows the test
+// created for it.
+func SeedUser(t testing.TB, conn *sql.DB) int {
+	t.Helper()
+
+	var userID int
+
+	err := conn.QueryRowContext(context.Background(),
+		`INSERT INTO users (firstname, lastname, email, password) VALUES ('Test', 'User', $1, 'x') RETURNING user_id`,
+		Unique("user")+"@example.com").Scan(&userID)
+	if err != nil {
+		t.Fatalf("insert user: %v", err)
+	}
+
+	cleanup(t, conn, `DELETE FROM users WHERE user_id = $1`, userID)
+	cleanupUserData(t, conn, userID)
+
+	return userID
+}
+
+// SeedMerchant inserts a merchant owned by a new user and returns the IDs of
+// both.
+func SeedMerchant(t testing.TB, conn *sql.DB) (userID int, merchantID int) {
+	t.Helper()
+
+	userID = SeedUser(t, conn)
+
+	err := conn.QueryRowContext(context.Background(),
+		`INSERT INTO merchants (user_id, name) VALUES ($1, 'Test Merchant') RETURNING merchant_id`,
+		userID).Scan(&merchantID)
+	if err != nil {
+		t.Fatalf("insert merchant: %v", err)
+	}
+
+	cleanup(t, conn, `DELETE FROM merchants WHERE merchant_id = $1`, merchantID)
+
+	return userID, merchantID
+}
+
+// SeedProduct inserts a product of merchantID in a new category with the
+// given price and stock and returns its ID.
+func SeedProduct(t testing.TB, conn *sql.DB, merchantID int, price int64, stock int) int {
+	t.Helper()
+
+	ctx := context.Background()
+	slug := Unique("product")
+
+	var categoryID, productID int
+
+	err := conn.QueryRowContext(ctx,
+		`INSERT INTO categories (name, slug_category) VALUES ('Test Category', $1) RETURNING category_id`,
+		slug).Scan(&categoryID)
+	if err != nil {
+		t.Fatalf("insert category: %v", err)
+	}
+
+	cleanup(t, conn, `DELETE FROM categories WHERE category_id = $1`, categoryID)
+
+	err = conn.QueryRowContext(ctx,
+		`INSERT INTO products (merchant_id, category_id, name, price, count_in_stock, weight, slug_product, image_product)
+		VALUES ($1, $2, 'Test Product', $3, $4, 100, $5, 'test.png') RETURNING product_id`,
+		merchantID, categoryID, price, stock, slug).Scan(&productID)
+	if err != nil {
+		t.Fatalf("insert product: %v", err)
+	}
+
+	cleanup(t, conn, `DELETE FROM products WHERE product_id = $1`, productID)
+
+	return productID
+}
+
+// ProductStock reads the stock left of a product.
+func ProductStock(t testing.TB, conn *sql.DB, productID int) int {
+	t.Helper()
+
+	var stock int
+	if err := conn.QueryRow(`SELECT count_in_stock FROM products WHERE product_id = $1`, productID).Scan(&stock); err != nil {
+		t.Fatalf("read stock: %v", err)
+	}
+
+	return stock
+}
+
+// cleanupUserData deletes the rows hanging off userID when the test ends,
+// before the user itself.
+func cleanupUserData(t testing.TB, conn *sql.DB, userID int) {
+	statements := []string{
+		`DELETE FROM refund_items WHERE refund_id IN (SELECT r.refund_id FROM refunds r JOIN transactions t ON t.transaction_id = r.transaction_id JOIN orders o ON o.order_id = t.order_id WHERE o.user_id = $1)`,
+		`DELETE FROM refunds WHERE transaction_id IN (SELECT t.transaction_id FROM transactions t JOIN orders o ON o.order_id = t.order_id WHERE o.user_id = $1)`,
+		`DELETE FROM transactions WHERE order_id IN (SELECT order_id FROM orders WHERE user_id = $1)`,
+		`DELETE FROM order_status_histories WHERE order_id IN (SELECT order_id FROM orders WHERE user_id = $1)`,
+		`DELETE FROM shipping_addresses WHERE order_id IN (SELECT order_id FROM orders WHERE user_id = $1)`,
+		`DELETE FROM order_items WHERE order_id IN (SELECT order_id FROM orders WHERE user_id = $1)`,
+		`DELETE FROM orders WHERE user_id = $1`,
+		`DELETE FROM carts WHERE user_id = $1`,
+		`DELETE FROM revoked_tokens WHERE user_id = $1`,
+		`DELETE FROM refresh_tokens WHERE user_id = $1`,
+		`DELETE FROM user_tokens WHERE user_id = $1`,
+		`DELETE FROM user_roles WHERE user_id = $1`,
+		`DELETE FROM idempotency_keys WHERE user_id = $1`,
+	}
+
+	// Registered backwards so they run in the order listed.
+	for i := len(statements) - 1; i >= 0; i-- {
+		cleanup(t, conn, statements[i], userID)
+	}
+}
+
+// cleanup runs statement when the test ends. Cleanups run last registered
+// first, so rows are deleted before the rows they reference.
+func cleanup(t testing.TB, conn *sql.DB, statement string, args ...interface{}) {
+	t.Cleanup(func() {
+		if _, err := conn.ExecContext(context.Background(), statement, args...); err != nil {
+			t.Logf("cleanup %q: %v", statement, err)
+		}
+	})
+}
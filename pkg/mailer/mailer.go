@@ -0,0 +1,93 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidMessage    = errors.New("invalid mail message")
+	ErrUnsupportedDriver = errors.New("unsupported mail driver")
+)
+
+// Mail drivers accepted by NewMailer.
+const (
+	DriverSMTP   = "smtp"
+	DriverFile   = "file"
+	DriverMemory = "memory"
+)
+
+// Message is a plain text email to a single recipient.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer delivers transactional emails such as verification and password
+// reset links.
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+type Config struct {
+	Driver string
+	From   string
+
+	// SMTP driver.
+	Host     string
+	Port     int
+	Username string
+	Password string
+
+	// File driver.
+	Dir string
+}
+
+// NewMailer returns the mailer selected by cfg.Driver. An empty driver
+// selects the file mailer so local setups never send real email.
+func NewMailer(cfg Config) (Mailer, error) {
+	switch cfg.Driver {
+	case DriverSMTP:
+		return NewSMTPMailer(cfg.Host, cfg.Port, cfg.Username, cfg.Password, cfg.From)
+	case "", DriverFile:
+		return NewFileMailer(cfg.Dir, cfg.From)
+	case DriverMemory:
+		return NewMemoryMailer(), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedDriver, cfg.Driver)
+	}
+}
+
+// encode renders msg as an RFC 5322 message sent by from. Header values are
+// rejected when they contain line breaks so they cannot inject headers.
+func (m Message) encode(from string) ([]byte, error) {
+	for _, v := range []string{from, m.To, m.Subject} {
+		if strings.ContainsAny(v, "\r\n") {
+			return nil, fmt.Errorf("%w: header contains a line break", ErrInvalidMessage)
+		}
+	}
+
+	if m.To == "" {
+		return nil, fmt.Errorf("%w: missing recipient", ErrInvalidMessage)
+	}
+
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", m.To)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", m.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	buf.WriteString("\r\n")
+	buf.WriteString(strings.ReplaceAll(strings.ReplaceAll(m.Body, "\r\n", "\n"), "\n", "\r\n"))
+
+	return buf.Bytes(), nil
+}
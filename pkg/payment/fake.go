@@ -0,0 +1,184 @@
+package payment
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+const FakeProviderName = "fake"
+
+// FakeProvider is an in-memory PaymentProvider for local development and
+// tests. Charges succeed immediately unless Decline rejects them or
+// ManualCapture holds them for an explicit Capture.
+type FakeProvider struct {
+	// Decline reports whether a charge should be declined. Nil approves
+	// every charge.
+	Decline func(req ChargeRequest) bool
+	// ManualCapture leaves approved charges authorized until Capture.
+	ManualCapture bool
+	// WebhookSecret signs the webhooks accepted by VerifyWebhook.
+	WebhookSecret []byte
+
+	mu      sync.Mutex
+	seq     int
+	charges map[string]*Charge
+	refunds map[string]*Refund
+	// refundKeys maps idempotency keys to the refund they created.
+	refundKeys map[string]string
+}
+
+func NewFakeProvider() *FakeProvider {
+	return &FakeProvider{
+		charges:    make(map[string]*Charge),
+		refunds:    make(map[string]*Refund),
+		refundKeys: make(map[string]string),
+	}
+}
+
+func (p *FakeProvider) Name() string {
+	return FakeProviderName
+}
+
+func (p *FakeProvider) CreateCharge(ctx context.Context, req ChargeRequest) (*Charge, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if req.Amount <= 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	charge := &Charge{
+		ID:        p.nextID("ch"),
+		Reference: req.Reference,
+		Amount:    req.Amount,
+		Currency:  req.Currency,
+		Status:    ChargeStatusSucceeded,
+	}
+
+	switch {
+	case p.Decline != nil && p.Decline(req):
+		charge.Status = ChargeStatusFailed
+		charge.FailureReason = "card_declined"
+	case p.ManualCapture:
+		charge.Status = ChargeStatusAuthorized
+	}
+
+	p.charges[charge.ID] = charge
+
+	result := *charge
+	return &result, nil
+}
+
+func (p *FakeProvider) Capture(ctx context.Context, chargeID string) (*Charge, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	charge, ok := p.charges[chargeID]
+	if !ok {
+		return nil, ErrChargeNotFound
+	}
+
+	if charge.Status != ChargeStatusAuthorized {
+		return nil, ErrChargeNotCapturable
+	}
+
+	charge.Status = ChargeStatusSucceeded
+
+	result := *charge
+	return &result, nil
+}
+
+func (p *FakeProvider) Refund(ctx context.Context, req RefundRequest) (*Refund, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	chargeID, amount := req.ChargeID, req.Amount
+	if amount < 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if refundID, ok := p.refundKeys[req.IdempotencyKey]; ok && req.IdempotencyKey != "" {
+		result := *p.refunds[refundID]
+		return &result, nil
+	}
+
+	charge, ok := p.charges[chargeID]
+	if !ok {
+		return nil, ErrChargeNotFound
+	}
+
+	if charge.Status != ChargeStatusSucceeded {
+		return nil, ErrChargeNotRefundable
+	}
+
+	remaining := charge.Amount - charge.AmountRefunded
+	if amount == 0 {
+		amount = remaining
+	}
+
+	if amount > remaining {
+		return nil, ErrRefundExceedsCharge
+	}
+
+	charge.AmountRefunded += amount
+	if charge.AmountRefunded == charge.Amount {
+		charge.Status = ChargeStatusRefunded
+	}
+
+	refund := &Refund{
+		ID:       p.nextID("re"),
+		ChargeID: chargeID,
+		Amount:   amount,
+		Status:   RefundStatusSucceeded,
+	}
+	p.refunds[refund.ID] = refund
+	if req.IdempotencyKey != "" {
+		p.refundKeys[req.IdempotencyKey] = refund.ID
+	}
+
+	result := *refund
+	return &result, nil
+}
+
+func (p *FakeProvider) GetCharge(ctx context.Context, chargeID string) (*Charge, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	charge, ok := p.charges[chargeID]
+	if !ok {
+		return nil, ErrChargeNotFound
+	}
+
+	result := *charge
+	return &result, nil
+}
+
+func (p *FakeProvider) VerifyWebhook(payload []byte, signature string) (*Event, error) {
+	if err := VerifySignature(p.WebhookSecret, payload, signature); err != nil {
+		return nil, err
+	}
+
+	return ParseEvent(payload)
+}
+
+func (p *FakeProvider) nextID(prefix string) string {
+	p.seq++
+	return fmt.Sprintf("%s_fake_%d", prefix, p.seq)
+}
@@ -0,0 +1,101 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrChargeNotFound      = errors.New("charge not found")
+	ErrInvalidAmount       = errors.New("invalid amount")
+	ErrChargeNotCapturable = errors.New("charge cannot be captured")
+	ErrChargeNotRefundable = errors.New("charge cannot be refunded")
+	ErrRefundExceedsCharge = errors.New("refund exceeds the captured amount")
+	ErrUnsupportedProvider = errors.New("unsupported payment provider")
+)
+
+// Charge statuses reported by a PaymentProvider.
+const (
+	// ChargeStatusPending means the outcome is not known yet and will be
+	// reported asynchronously by the provider.
+	ChargeStatusPending = "pending"
+	// ChargeStatusAuthorized means the funds are held and must be captured.
+	ChargeStatusAuthorized = "authorized"
+	ChargeStatusSucceeded  = "succeeded"
+	ChargeStatusFailed     = "failed"
+	ChargeStatusRefunded   = "refunded"
+)
+
+// Refund statuses reported by a PaymentProvider.
+const (
+	RefundStatusPending   = "pending"
+	RefundStatusSucceeded = "succeeded"
+	RefundStatusFailed    = "failed"
+)
+
+// ChargeRequest asks a provider to charge an amount in minor units.
+// Reference is our own identifier for the payment and is echoed back by the
+// provider so its notifications can be matched to a transaction.
+type ChargeRequest struct {
+	Reference     string
+	Amount        int64
+	Currency      string
+	PaymentMethod string
+}
+
+type Charge struct {
+	ID             string
+	Reference      string
+	Amount         int64
+	AmountRefunded int64
+	Currency       string
+	Status         string
+	FailureReason  string
+}
+
+// RefundRequest returns Amount of a captured charge. Requests sharing an
+// IdempotencyKey are applied once; repeats return the original refund.
+type RefundRequest struct {
+	ChargeID       string
+	Amount         int64
+	IdempotencyKey string
+}
+
+type Refund struct {
+	ID       string
+	ChargeID string
+	Amount   int64
+	Status   string
+}
+
+// PaymentProvider is a payment gateway that transactions are charged through.
+type PaymentProvider interface {
+	// Name identifies the provider in stored transactions.
+	Name() string
+	CreateCharge(ctx context.Context, req ChargeRequest) (*Charge, error)
+	Capture(ctx context.Context, chargeID string) (*Charge, error)
+	// Refund returns an amount of a captured charge to the customer. A zero
+	// amount refunds whatever has not been refunded yet.
+	Refund(ctx context.Context, req RefundRequest) (*Refund, error)
+	GetCharge(ctx context.Context, chargeID string) (*Charge, error)
+	// VerifyWebhook authenticates a webhook body delivered by the provider
+	// and decodes the event it carries.
+	VerifyWebhook(payload []byte, signature string) (*Event, error)
+}
+
+// NewProvider returns the provider registered under name, verifying its
+// webhooks with webhookSecret. The local fake provider is only returned when
+// it is configured by name.
+func NewProvider(name string, webhookSecret string) (PaymentProvider, error) {
+	switch name {
+	case "":
+		return nil, fmt.Errorf("%w: no provider configured", ErrUnsupportedProvider)
+	case FakeProviderName:
+		provider := NewFakeProvider()
+		provider.WebhookSecret = []byte(webhookSecret)
+		return provider, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedProvider, name)
+	}
+}
@@ -0,0 +1,80 @@
+package payment
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrInvalidEvent     = errors.New("invalid webhook event")
+)
+
+// SignatureHeader carries the hex encoded HMAC-SHA256 of the raw webhook
+// body, optionally prefixed with "sha256=".
+const SignatureHeader = "X-Payment-Signature"
+
+// Webhook event types.
+const (
+	EventChargeSucceeded = "charge.succeeded"
+	EventChargeFailed    = "charge.failed"
+	EventChargeRefunded  = "charge.refunded"
+)
+
+// Event is a payment notification delivered by a provider webhook. ID is
+// unique per provider and is used to drop redelivered events.
+type Event struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	ChargeID  string `json:"charge_id"`
+	Reference string `json:"reference"`
+	Amount    int64  `json:"amount"`
+}
+
+// Sign returns the signature of payload under secret in the format expected
+// by VerifySignature.
+func Sign(secret []byte, payload []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature checks signature against payload in constant time. An
+// empty secret rejects every payload so an unconfigured webhook is closed.
+func VerifySignature(secret []byte, payload []byte, signature string) error {
+	if len(secret) == 0 {
+		return ErrInvalidSignature
+	}
+
+	got, err := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+// ParseEvent decodes a webhook body into an Event.
+func ParseEvent(payload []byte) (*Event, error) {
+	var event Event
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, ErrInvalidEvent
+	}
+
+	if event.ID == "" || event.Type == "" || event.ChargeID == "" {
+		return nil, ErrInvalidEvent
+	}
+
+	return &event, nil
+}